@@ -358,6 +358,10 @@ func (app *Application) startScheduler() error {
 					logger.Info("✅ [Scheduler] SubscriptionService подключен к планировщику")
 				}
 			}
+			if svc := coreLayer.GetMarketDigestService(); svc != nil {
+				deps.MarketDigest = svc
+				logger.Info("✅ [Scheduler] MarketDigestService подключен к планировщику")
+			}
 		}
 	}
 
@@ -0,0 +1,346 @@
+// application/cmd/backtest/main.go
+package main
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/backtest"
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	"crypto-exchange-screener-bot/internal/infrastructure/config"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultDataDir = "data/backtest"
+
+func main() {
+	if len(os.Args) < 2 {
+		printHelp()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "fetch":
+		err = runFetch(os.Args[2:])
+	case "run":
+		err = runBacktest(os.Args[2:])
+	case "optimize":
+		err = runOptimize(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "help", "-h", "--help":
+		printHelp()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "❌ Неизвестная команда: %s\n\n", os.Args[1])
+		printHelp()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runFetch загружает минутные свечи с Bybit в локальное хранилище
+func runFetch(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	var (
+		env      = fs.String("env", "dev", "Окружение (dev/prod) для настроек биржи")
+		cfgPath  = fs.String("config", "", "Путь к .env (переопределяет env)")
+		symbols  = fs.String("symbols", "", "Символы через запятую (по умолчанию — SYMBOL_FILTER из конфигурации)")
+		limit    = fs.Int("limit", 1000, "Сколько последних минутных свечей запросить (до 1000)")
+		dataDir  = fs.String("data", defaultDataDir, "Каталог хранилища свечей")
+		logLevel = fs.String("log-level", "", "Включить логи приложения: debug, info, warn, error")
+	)
+	fs.Parse(args)
+	initLogger(*logLevel)
+
+	configFile := *cfgPath
+	if configFile == "" {
+		configFile = filepath.Join("configs", *env, ".env")
+	}
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("не удалось загрузить конфигурацию: %w", err)
+	}
+
+	list := splitSymbols(*symbols)
+	if len(list) == 0 {
+		list = cfg.GetSymbolList()
+	}
+	if len(list) == 0 {
+		return fmt.Errorf("укажите символы: -symbols BTCUSDT,ETHUSDT")
+	}
+
+	store := backtest.NewKlineStore(*dataDir)
+	results := backtest.Download(bybit.NewBybitClient(cfg), store, list, *limit)
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", r.Symbol, r.Err)
+			continue
+		}
+		fmt.Printf("✅ %s: получено %d, новых %d\n", r.Symbol, r.Loaded, r.Added)
+	}
+	fmt.Printf("📁 Свечи сохранены в %s\n", store.Dir())
+
+	if failed == len(results) {
+		return fmt.Errorf("не удалось загрузить ни один символ")
+	}
+	return nil
+}
+
+// runBacktest прогоняет одну или две конфигурации и выводит отчет
+func runBacktest(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var (
+		cfgPath     = fs.String("config", "", "JSON конфигурация бэктеста (по умолчанию — counter с настройками схемы)")
+		comparePath = fs.String("compare", "", "Вторая JSON конфигурация для сравнения")
+		symbols     = fs.String("symbols", "", "Символы через запятую (по умолчанию — все в хранилище)")
+		from        = fs.String("from", "", "Начало периода: 2006-01-02 или 2006-01-02T15:04")
+		to          = fs.String("to", "", "Конец периода (не включительно)")
+		dataDir     = fs.String("data", defaultDataDir, "Каталог хранилища свечей")
+		format      = fs.String("format", "table", "Формат отчета: table, json, csv")
+		outPath     = fs.String("out", "", "Файл отчета (по умолчанию — stdout)")
+		withSignals = fs.Bool("signals", false, "Добавить в JSON список сигналов с исходами")
+		logLevel    = fs.String("log-level", "", "Включить логи приложения: debug, info, warn, error")
+	)
+	fs.Parse(args)
+	initLogger(*logLevel)
+
+	opts := backtest.Options{Symbols: splitSymbols(*symbols)}
+	var err error
+	if opts.From, err = parseTime(*from); err != nil {
+		return err
+	}
+	if opts.To, err = parseTime(*to); err != nil {
+		return err
+	}
+
+	configs := []backtest.Config{backtest.DefaultConfig()}
+	if *cfgPath != "" {
+		if configs[0], err = backtest.LoadConfig(*cfgPath); err != nil {
+			return err
+		}
+	}
+	if *comparePath != "" {
+		cfg, err := backtest.LoadConfig(*comparePath)
+		if err != nil {
+			return err
+		}
+		if cfg.Name == configs[0].Name {
+			cfg.Name += "_2"
+		}
+		configs = append(configs, cfg)
+	}
+
+	store := backtest.NewKlineStore(*dataDir)
+	reports := make([]*backtest.Report, 0, len(configs))
+	for _, cfg := range configs {
+		started := time.Now()
+		fmt.Fprintf(os.Stderr, "⏳ Прогон %s...\n", cfg.Name)
+		report, err := backtest.Run(store, cfg, opts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		fmt.Fprintf(os.Stderr, "✅ %s: %d сигналов за %v\n", cfg.Name, report.Total.Signals, time.Since(started).Round(time.Millisecond))
+		reports = append(reports, report)
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return fmt.Errorf("ошибка создания файла отчета: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch *format {
+	case "json":
+		return backtest.WriteJSON(out, *withSignals, reports...)
+	case "csv":
+		return backtest.WriteCSV(out, reports...)
+	case "table":
+		return backtest.WriteTable(out, reports...)
+	default:
+		return fmt.Errorf("неизвестный формат %q: table, json, csv", *format)
+	}
+}
+
+// runOptimize перебирает настройки counter и сохраняет рейтинг наборов
+func runOptimize(args []string) error {
+	fs := flag.NewFlagSet("optimize", flag.ExitOnError)
+	var (
+		sweepPath = fs.String("sweep", "configs/backtest/sweep.json", "JSON конфигурация оптимизации")
+		symbols   = fs.String("symbols", "", "Символы через запятую (по умолчанию — все в хранилище)")
+		from      = fs.String("from", "", "Начало периода: 2006-01-02 или 2006-01-02T15:04")
+		to        = fs.String("to", "", "Конец периода (не включительно)")
+		dataDir   = fs.String("data", defaultDataDir, "Каталог хранилища свечей")
+		workers   = fs.Int("workers", 0, "Параллельные прогоны (по умолчанию — число ядер)")
+		top       = fs.Int("top", 20, "Сколько лучших наборов вывести (0 — все)")
+		outPath   = fs.String("out", "", "Файл результатов JSON (по умолчанию — <data>/optimize/<имя>_<время>.json)")
+		envPath   = fs.String("env", "", "Записать .env фрагмент лучшего набора в файл")
+		logLevel  = fs.String("log-level", "", "Включить логи приложения: debug, info, warn, error")
+	)
+	fs.Parse(args)
+	initLogger(*logLevel)
+
+	sweep, err := backtest.LoadSweepConfig(*sweepPath)
+	if err != nil {
+		return err
+	}
+
+	opts := backtest.OptimizeOptions{
+		Options: backtest.Options{Symbols: splitSymbols(*symbols)},
+		Workers: *workers,
+		Progress: func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\r⏳ %s: %d/%d", sweep.Name, done, total)
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		},
+	}
+	if opts.From, err = parseTime(*from); err != nil {
+		return err
+	}
+	if opts.To, err = parseTime(*to); err != nil {
+		return err
+	}
+
+	result, err := backtest.Optimize(backtest.NewKlineStore(*dataDir), sweep, opts)
+	if err != nil {
+		return err
+	}
+
+	resultPath := *outPath
+	if resultPath == "" {
+		resultPath = filepath.Join(*dataDir, "optimize",
+			fmt.Sprintf("%s_%s.json", result.Name, result.Started.Format("20060102-150405")))
+	}
+	if err := backtest.SaveSweepResult(resultPath, result); err != nil {
+		return err
+	}
+
+	if err := backtest.WriteSweepTable(os.Stdout, result, *top); err != nil {
+		return err
+	}
+	fmt.Printf("\n📁 Результаты сохранены в %s\n", resultPath)
+
+	if *envPath != "" {
+		if err := writeEnvFile(*envPath, result, 1); err != nil {
+			return err
+		}
+		fmt.Printf("📝 .env фрагмент лучшего набора: %s\n", *envPath)
+	}
+	return nil
+}
+
+// runExport выводит .env фрагмент набора из сохраненных результатов
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var (
+		resultsPath = fs.String("results", "", "Файл результатов optimize")
+		rank        = fs.Int("rank", 1, "Место набора в рейтинге")
+		outPath     = fs.String("out", "", "Файл .env фрагмента (по умолчанию — stdout)")
+	)
+	fs.Parse(args)
+
+	if *resultsPath == "" {
+		return fmt.Errorf("укажите файл результатов: -results data/backtest/optimize/<файл>.json")
+	}
+	result, err := backtest.LoadSweepResult(*resultsPath)
+	if err != nil {
+		return err
+	}
+	if *outPath == "" {
+		return backtest.WriteEnv(os.Stdout, result, *rank)
+	}
+	return writeEnvFile(*outPath, result, *rank)
+}
+
+func writeEnvFile(path string, result *backtest.SweepResult, rank int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ошибка создания .env фрагмента: %w", err)
+	}
+	defer file.Close()
+	return backtest.WriteEnv(file, result, rank)
+}
+
+// initLogger включает логи приложения; без уровня логи анализаторов не выводятся
+func initLogger(level string) {
+	if level == "" {
+		return
+	}
+	if err := logger.InitGlobal("", level, true); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ Не удалось инициализировать логгер: %v\n", err)
+	}
+}
+
+func splitSymbols(value string) []string {
+	var symbols []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			symbols = append(symbols, s)
+		}
+	}
+	return symbols
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("неверная дата %q, ожидается 2006-01-02 или 2006-01-02T15:04 (UTC)", value)
+}
+
+func printHelp() {
+	fmt.Println("📊 Backtest — прогон анализаторов по историческим свечам")
+	fmt.Println()
+	fmt.Println("Использование: backtest <команда> [опции]")
+	fmt.Println()
+	fmt.Println("Команды:")
+	fmt.Println("  fetch     Загрузить последние минутные свечи с Bybit в локальное хранилище")
+	fmt.Println("            (GetKline отдает до 1000 свечей — запускайте регулярно, история накапливается)")
+	fmt.Println("  run       Прогнать анализаторы по свечам хранилища и вывести отчет")
+	fmt.Println("  optimize  Перебрать настройки counter (grid/random) с проверкой walk-forward")
+	fmt.Println("  export    Вывести .env фрагмент набора из сохраненных результатов optimize")
+	fmt.Println()
+	fmt.Println("Хранилище: <data>/<SYMBOL>_1m.csv с колонками start_ms,open,high,low,close,volume,turnover;")
+	fmt.Println("записанные ранее данные можно положить в том же формате.")
+	fmt.Println()
+	fmt.Println("Конфигурация run (JSON):")
+	fmt.Println(`  {"name": "strict", "analyzers": {"counter": {"custom_settings": {"growth_threshold": 2, "fall_threshold": 2}}},`)
+	fmt.Println(`   "confirmations": 3, "horizon": "1h"}`)
+	fmt.Println()
+	fmt.Println("Конфигурация optimize (JSON, параметры — настройки counter и confirmations):")
+	fmt.Println(`  {"name": "counter_sweep", "base": {"analyzers": {"counter": {}}, "horizon": "1h"},`)
+	fmt.Println(`   "parameters": [{"key": "growth_threshold", "min": 0.5, "max": 3, "step": 0.5},`)
+	fmt.Println(`                  {"key": "confirmations", "values": [1, 2, 3]}],`)
+	fmt.Println(`   "search": "grid", "objective": "expectancy", "splits": 3}`)
+	fmt.Println("  search: grid | random (samples, seed); objective: expectancy | precision (signals_per_day)")
+	fmt.Println()
+	fmt.Println("Примеры:")
+	fmt.Println("  go run application/cmd/backtest/main.go fetch -symbols BTCUSDT,ETHUSDT,SOLUSDT")
+	fmt.Println("  go run application/cmd/backtest/main.go run -config configs/backtest/base.json -compare configs/backtest/strict.json")
+	fmt.Println("  go run application/cmd/backtest/main.go run -config a.json -compare b.json -format csv -out report.csv")
+	fmt.Println("  go run application/cmd/backtest/main.go run -symbols BTCUSDT -from 2026-03-01 -to 2026-03-15 -format json")
+	fmt.Println("  go run application/cmd/backtest/main.go optimize -sweep configs/backtest/sweep.json -env counter.env")
+	fmt.Println("  go run application/cmd/backtest/main.go export -results data/backtest/optimize/<файл>.json -rank 2")
+}
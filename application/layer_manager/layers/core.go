@@ -2,9 +2,17 @@
 package layers
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
 	"crypto-exchange-screener-bot/internal/core/domain/candle"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
 	"crypto-exchange-screener-bot/internal/core/domain/fetchers"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
 	"crypto-exchange-screener-bot/internal/core/domain/payment"
+	"crypto-exchange-screener-bot/internal/core/domain/rules"
 	engine "crypto-exchange-screener-bot/internal/core/domain/signals/engine"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
@@ -13,6 +21,7 @@ import (
 	"crypto-exchange-screener-bot/internal/infrastructure/config"
 	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
 	redis_storage_factory "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/factory"
+	returns_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/returns_storage"
 	sr_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/sr_storage"
 	events "crypto-exchange-screener-bot/internal/infrastructure/transport/event_bus"
 	"crypto-exchange-screener-bot/internal/types"
@@ -22,6 +31,7 @@ import (
 	"time"
 
 	sr_engine "crypto-exchange-screener-bot/internal/core/domain/analysis/sr_engine"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/wall_tracker"
 	bybit_ws "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit/ws"
 )
 
@@ -37,6 +47,15 @@ type CoreLayer struct {
 	candleSystem      *candle.CandleSystem
 	analysisEngine    *engine.AnalysisEngine
 	srZoneEngine      *sr_engine.Engine
+	wallTracker       *wall_tracker.Tracker
+	rulesEngine       *rules.Engine
+	alertMonitor      *alerts.Monitor
+	strengthDigest    *strength.DigestScheduler
+	journalRecorder   *journal.Recorder
+	journalEvaluator  *journal.Evaluator
+	paperMonitor      *paper.Monitor
+	marketDigest      *digest.Service
+	marketRegime      *regime.Classifier
 	srZoneStorage     *sr_storage.SRZoneStorage
 	liqWatcher        *bybit_ws.LiquidationWatcher
 	histLoader        *candle.HistoricalCandleLoader
@@ -207,6 +226,14 @@ func (cl *CoreLayer) Start() error {
 		}
 	}
 
+	// Классификатор режима рынка (нужны свечи); запускается до AnalysisEngine,
+	// чтобы режим попадал в метаданные сигналов и пороги анализаторов
+	if cl.config.Telegram.Enabled && cl.config.MarketRegime.Enabled && cl.candleSystem != nil {
+		if err := cl.startMarketRegime(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить классификатор режима рынка: %v", err)
+		}
+	}
+
 	// НОВОЕ: Запускаем AnalysisEngine если CounterAnalyzer включен в конфигурации
 	if cl.config.Telegram.Enabled && cl.infraLayer != nil {
 		logger.Info("🔧 Проверка условий запуска AnalysisEngine:")
@@ -231,6 +258,48 @@ func (cl *CoreLayer) Start() error {
 		logger.Info("✅ SubscriptionService создан, валидатор запущен")
 	}
 
+	// Движок пользовательских правил (нужны свечи и EventCandleClosed)
+	if cl.config.Telegram.Enabled && cl.config.RulesEngine.Enabled && cl.candleSystem != nil {
+		if err := cl.startRulesEngine(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить RulesEngine: %v (правила пользователей недоступны)", err)
+		}
+	}
+
+	// Монитор ценовых алертов (нужны цены и EventPriceUpdated)
+	if cl.config.Telegram.Enabled && cl.config.PriceAlerts.Enabled && cl.candleSystem != nil {
+		if err := cl.startPriceAlertMonitor(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить PriceAlertMonitor: %v (ценовые алерты недоступны)", err)
+		}
+	}
+
+	// Дайджест ротаций секторов (нужны свечи)
+	if cl.config.Telegram.Enabled && cl.config.Strength.Enabled && cl.candleSystem != nil {
+		if err := cl.startStrengthDigest(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить дайджест секторов: %v", err)
+		}
+	}
+
+	// Журнал сигналов (исходы считаются по свечам)
+	if cl.config.SignalJournal.Enabled && cl.candleSystem != nil {
+		if err := cl.startSignalJournal(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить журнал сигналов: %v (история сигналов не пишется)", err)
+		}
+	}
+
+	// Монитор paper-позиций (нужны цены и EventPriceUpdated)
+	if cl.config.PaperTrading.Enabled && cl.candleSystem != nil {
+		if err := cl.startPaperTradingMonitor(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить PaperTradingMonitor: %v (paper-позиции не закрываются)", err)
+		}
+	}
+
+	// Дайджест рынка (нужны цены; строится и рассылается задачами планировщика)
+	if cl.config.MarketDigest.Enabled && cl.candleSystem != nil {
+		if err := cl.startMarketDigest(); err != nil {
+			logger.Warn("⚠️ Не удалось создать сервис дайджеста рынка: %v (дайджест не строится)", err)
+		}
+	}
+
 	// Фабрика ядра не требует отдельного запуска,
 	// так как сервисы создаются лениво
 
@@ -305,6 +374,21 @@ func (cl *CoreLayer) startAnalysisEngine() error {
 		logger.Info("✅ SRZoneStorage передан в AnalysisEngine Factory")
 	}
 
+	// Окна доходностей для AnomalyAnalyzer; без Redis анализатор просто не создается
+	if returnsStorage, err := cl.createReturnsStorage(); err != nil {
+		logger.Warn("⚠️ ReturnsStorage недоступен, анализатор аномалий отключен: %v", err)
+	} else {
+		engineFactory.SetReturnsStorage(returnsStorage)
+		logger.Info("✅ ReturnsStorage передан в AnalysisEngine Factory")
+	}
+
+	// Режим рынка для метаданных сигналов и порогов анализаторов
+	// (передаем nil-интерфейс, а не типизированный nil)
+	if marketRegime := cl.GetMarketRegime(); marketRegime != nil {
+		engineFactory.SetRegimeSource(marketRegime)
+		logger.Info("✅ Классификатор режима рынка передан в AnalysisEngine Factory")
+	}
+
 	// 7. Создаем движок анализа через фабрику
 	analysisEngine := engineFactory.NewAnalysisEngineFromConfig(
 		priceStorage,
@@ -637,6 +721,26 @@ func (cl *CoreLayer) startBybitPriceFetcher() {
 	}
 }
 
+// createReturnsStorage создает Redis-хранилище окон доходностей
+func (cl *CoreLayer) createReturnsStorage() (*returns_storage.ReturnsStorage, error) {
+	redisServiceComp, exists := cl.infraLayer.GetComponent("RedisService")
+	if !exists {
+		return nil, fmt.Errorf("RedisService не найден")
+	}
+
+	redisServiceInterface, err := cl.getComponentValue(redisServiceComp)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить RedisService: %w", err)
+	}
+
+	redisService, ok := redisServiceInterface.(*redis_service.RedisService)
+	if !ok {
+		return nil, fmt.Errorf("неверный тип RedisService")
+	}
+
+	return returns_storage.NewReturnsStorage(redisService)
+}
+
 // startSRZoneEngine запускает движок зон S/R
 func (cl *CoreLayer) startSRZoneEngine() error {
 	logger.Info("📐 CoreLayer: запуск SRZoneEngine...")
@@ -699,10 +803,15 @@ func (cl *CoreLayer) startSRZoneEngine() error {
 		eventBus,
 	)
 
-	// 7. Запускаем движок
+	// 7. Трекер стен: зоны обогащаются только стабильными стенами
+	if cl.config.WallTracker.Enabled {
+		cl.startWallTracker(eventBus)
+	}
+
+	// 8. Запускаем движок
 	cl.srZoneEngine.Start()
 
-	// 8. Прогреваем зоны при первом поступлении батча цен (one-shot горутина).
+	// 9. Прогреваем зоны при первом поступлении батча цен (one-shot горутина).
 	// Это устраняет "холодный старт": без прогрева зоны появляются только
 	// после первого EventCandleClosed (~60с для 1m), а сигналы идут сразу.
 	go cl.warmupSRZonesOnFirstPriceEvent(eventBus)
@@ -712,6 +821,409 @@ func (cl *CoreLayer) startSRZoneEngine() error {
 	return nil
 }
 
+// startWallTracker запускает трекер стен стакана и подключает его к SRZoneEngine
+func (cl *CoreLayer) startWallTracker(eventBus *events.EventBus) {
+	wtCfg := cl.config.WallTracker
+	cl.wallTracker = wall_tracker.NewTracker(wall_tracker.Config{
+		MaxSymbols:        wtCfg.MaxSymbols,
+		SampleInterval:    time.Duration(wtCfg.SampleIntervalSec) * time.Second,
+		MinStableLifetime: time.Duration(wtCfg.MinStableSec) * time.Second,
+		PullAlerts:        wtCfg.PullAlerts,
+		PullAlertMinUSD:   wtCfg.PullAlertMinUSD,
+		PullApproachPct:   wtCfg.PullApproachPct,
+	},
+		cl.bybitPriceFetcher, // OrderBookFetcher
+		cl.bybitPriceFetcher, // Volume24hProvider
+		eventBus,
+	)
+	cl.wallTracker.Start()
+	cl.srZoneEngine.SetWallSource(cl.wallTracker)
+
+	cl.registerComponent("WallTracker", cl.wallTracker)
+	logger.Info("✅ WallTracker запущен и подключён к SRZoneEngine")
+}
+
+// startRulesEngine запускает проверку пользовательских правил сигналов
+func (cl *CoreLayer) startRulesEngine() error {
+	logger.Info("📐 CoreLayer: запуск RulesEngine...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	// Лимит правил по тарифу; без SubscriptionService правила не ограничиваются
+	var limits subscription.LimitChecker
+	if subSvc, err := cl.GetSubscriptionService(); err == nil {
+		if svc, ok := subSvc.(*subscription.Service); ok && svc != nil {
+			limits = svc
+		}
+	}
+
+	rulesService, err := cl.coreFactory.CreateRulesService(limits)
+	if err != nil {
+		return fmt.Errorf("ошибка создания RulesService: %w", err)
+	}
+
+	sources := rules.Sources{
+		Candles: cl.candleSystem,
+		Prices:  cl.candleSystem.GetPriceStorage(),
+	}
+	if cl.bybitPriceFetcher != nil {
+		sources.Deltas = cl.bybitPriceFetcher
+		sources.Liquidations = cl.bybitPriceFetcher
+	}
+	if cl.srZoneStorage != nil {
+		sources.Zones = cl.srZoneStorage
+	}
+
+	rulesCfg := cl.config.RulesEngine
+	cl.rulesEngine = rules.NewEngine(rules.EngineConfig{
+		RefreshInterval: time.Duration(rulesCfg.RefreshSec) * time.Second,
+		Workers:         rulesCfg.Workers,
+	}, rulesService, sources, eventBus)
+	cl.rulesEngine.Start()
+
+	cl.registerComponent("RulesEngine", cl.rulesEngine)
+	logger.Info("✅ RulesEngine запущен и зарегистрирован")
+	return nil
+}
+
+// startPriceAlertMonitor запускает проверку ценовых алертов пользователей
+func (cl *CoreLayer) startPriceAlertMonitor() error {
+	logger.Info("🔔 CoreLayer: запуск PriceAlertMonitor...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	candleSystem := cl.candleSystem
+	alertService, err := cl.coreFactory.CreatePriceAlertService(nil, func() storage.PriceStorageInterface {
+		return candleSystem.GetPriceStorage()
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка создания PriceAlertService: %w", err)
+	}
+
+	cl.alertMonitor = alerts.NewMonitor(alertService, eventBus)
+	cl.alertMonitor.Start()
+
+	cl.registerComponent("PriceAlertMonitor", cl.alertMonitor)
+	logger.Info("✅ PriceAlertMonitor запущен и зарегистрирован")
+	return nil
+}
+
+// startStrengthDigest запускает рассылку дайджеста ротаций секторов
+func (cl *CoreLayer) startStrengthDigest() error {
+	logger.Info("📊 CoreLayer: запуск дайджеста секторов...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	strengthService, err := cl.coreFactory.CreateStrengthService(strengthConfig(cl.config), cl.strengthSources())
+	if err != nil {
+		return fmt.Errorf("ошибка создания StrengthService: %w", err)
+	}
+
+	cl.strengthDigest = strength.NewDigestScheduler(strengthService, eventBus)
+	cl.strengthDigest.Start()
+
+	cl.registerComponent("StrengthDigest", cl.strengthDigest)
+	logger.Info("✅ Дайджест секторов запущен и зарегистрирован")
+	return nil
+}
+
+// startSignalJournal запускает запись сигналов в журнал и оценку их исходов
+func (cl *CoreLayer) startSignalJournal() error {
+	logger.Info("📓 CoreLayer: запуск журнала сигналов...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	journalService, err := cl.coreFactory.CreateSignalJournalService()
+	if err != nil {
+		return fmt.Errorf("ошибка создания SignalJournalService: %w", err)
+	}
+
+	journalCfg := journal.DefaultConfig()
+	journalCfg.EvalInterval = time.Duration(cl.config.SignalJournal.EvalIntervalSec) * time.Second
+	journalCfg.BatchSize = cl.config.SignalJournal.BatchSize
+	journalCfg.Retention = time.Duration(cl.config.SignalJournal.RetentionDays) * 24 * time.Hour
+
+	cl.journalRecorder = journal.NewRecorder(journalService, eventBus)
+	cl.journalRecorder.Start()
+	cl.journalEvaluator = journal.NewEvaluator(journalService, cl.candleSystem, journalCfg)
+	cl.journalEvaluator.Start()
+
+	cl.registerComponent("SignalJournal", journalService)
+	logger.Info("✅ Журнал сигналов запущен и зарегистрирован")
+	return nil
+}
+
+// startPaperTradingMonitor запускает сопровождение paper-позиций пользователей
+func (cl *CoreLayer) startPaperTradingMonitor() error {
+	logger.Info("📄 CoreLayer: запуск PaperTradingMonitor...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	candleSystem := cl.candleSystem
+	paperService, err := cl.coreFactory.CreatePaperTradingService(
+		func() storage.PriceStorageInterface { return candleSystem.GetPriceStorage() },
+		func() paper.CandleSource { return candleSystem },
+		paperConfig(cl.config),
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания PaperTradingService: %w", err)
+	}
+
+	cl.paperMonitor = paper.NewMonitor(paperService, eventBus)
+	cl.paperMonitor.Start()
+
+	cl.registerComponent("PaperTradingMonitor", cl.paperMonitor)
+	logger.Info("✅ PaperTradingMonitor запущен и зарегистрирован")
+	return nil
+}
+
+// startMarketDigest создает сервис дайджеста рынка. Построение и рассылку
+// запускает планировщик (scheduler.RegisterAll), сервис только регистрируется.
+func (cl *CoreLayer) startMarketDigest() error {
+	logger.Info("📰 CoreLayer: создание сервиса дайджеста рынка...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	service, err := cl.coreFactory.CreateMarketDigestService(cl.digestSources(), eventBus, digestConfig(cl.config))
+	if err != nil {
+		return fmt.Errorf("ошибка создания MarketDigestService: %w", err)
+	}
+
+	cl.mu.Lock()
+	cl.marketDigest = service
+	cl.mu.Unlock()
+
+	cl.registerComponent("MarketDigestService", service)
+	logger.Info("✅ Сервис дайджеста рынка создан и зарегистрирован")
+	return nil
+}
+
+// startMarketRegime запускает классификатор режима рынка
+func (cl *CoreLayer) startMarketRegime() error {
+	logger.Info("🧭 CoreLayer: запуск классификатора режима рынка...")
+
+	eventBusComp, exists := cl.infraLayer.GetComponent("EventBus")
+	if !exists {
+		return fmt.Errorf("EventBus не найден")
+	}
+	eventBusInterface, err := cl.getComponentValue(eventBusComp)
+	if err != nil {
+		return fmt.Errorf("не удалось получить EventBus: %w", err)
+	}
+	eventBus, ok := eventBusInterface.(*events.EventBus)
+	if !ok {
+		return fmt.Errorf("неверный тип EventBus")
+	}
+
+	classifier := regime.NewClassifier(regimeConfig(cl.config), cl.regimeSources(), eventBus)
+	classifier.Start()
+
+	cl.mu.Lock()
+	cl.marketRegime = classifier
+	cl.mu.Unlock()
+
+	cl.registerComponent("MarketRegime", classifier)
+	logger.Info("✅ Классификатор режима рынка запущен и зарегистрирован")
+	return nil
+}
+
+// regimeConfig переводит MARKET_REGIME_* в настройки классификатора
+func regimeConfig(cfg *config.Config) regime.Config {
+	regimeCfg := regime.DefaultConfig()
+	if interval, err := time.ParseDuration(cfg.MarketRegime.Interval); err == nil && interval > 0 {
+		regimeCfg.Interval = interval
+	} else {
+		logger.Warn("⚠️ MARKET_REGIME_INTERVAL=%q: ожидается длительность (5m), используется %v",
+			cfg.MarketRegime.Interval, regimeCfg.Interval)
+	}
+	if benchmarks := regime.ParseSymbols(cfg.MarketRegime.Benchmarks); len(benchmarks) > 0 {
+		regimeCfg.Benchmarks = benchmarks
+	}
+	regimeCfg.TrendChangePct = cfg.MarketRegime.TrendChangePct
+	regimeCfg.BreadthUp = cfg.MarketRegime.BreadthUp
+	regimeCfg.BreadthDown = cfg.MarketRegime.BreadthDown
+	regimeCfg.PanicVolatility = cfg.MarketRegime.PanicVolatility
+	regimeCfg.PanicDeclineShare = cfg.MarketRegime.PanicDeclineShare
+	regimeCfg.MinVolumeUSD = cfg.MarketRegime.MinVolumeUSD
+	regimeCfg.ConfirmRuns = cfg.MarketRegime.ConfirmRuns
+	return regimeCfg
+}
+
+// regimeSources отдает классификатору свечи, цены и VWAP CandleSystem лениво
+func (cl *CoreLayer) regimeSources() regime.Sources {
+	return regime.Sources{
+		Candles: func() regime.CandleSource {
+			if cs := cl.GetCandleSystem(); cs != nil {
+				return cs
+			}
+			return nil
+		},
+		Prices: func() storage.PriceStorageInterface {
+			if cs := cl.GetCandleSystem(); cs != nil {
+				return cs.GetPriceStorage()
+			}
+			return nil
+		},
+		VWAP: func() *vwap.Tracker {
+			if cs := cl.GetCandleSystem(); cs != nil {
+				return cs.VWAP()
+			}
+			return nil
+		},
+	}
+}
+
+// digestConfig переводит MARKET_DIGEST_* в настройки дайджеста
+func digestConfig(cfg *config.Config) digest.Config {
+	digestCfg := digest.DefaultConfig()
+	if at, err := time.Parse("15:04", cfg.MarketDigest.BuildTime); err == nil {
+		digestCfg.BuildHour, digestCfg.BuildMinute = at.Hour(), at.Minute()
+	} else {
+		logger.Warn("⚠️ MARKET_DIGEST_BUILD_TIME=%q: ожидается ЧЧ:ММ, используется %02d:%02d UTC",
+			cfg.MarketDigest.BuildTime, digestCfg.BuildHour, digestCfg.BuildMinute)
+	}
+	if at, err := time.Parse("15:04", cfg.MarketDigest.SendTime); err == nil {
+		digestCfg.DefaultSendMinute = at.Hour()*60 + at.Minute()
+	}
+	if cfg.UserDefaults.Timezone != "" {
+		digestCfg.DefaultTimezone = cfg.UserDefaults.Timezone
+	}
+	if cfg.MarketDigest.TopN > 0 {
+		digestCfg.TopN = cfg.MarketDigest.TopN
+	}
+	digestCfg.MinVolumeUSD = cfg.MarketDigest.MinVolumeUSD
+	if cfg.MarketDigest.Horizon != "" {
+		digestCfg.Horizon = cfg.MarketDigest.Horizon
+	}
+	return digestCfg
+}
+
+// digestSources отдает дайджесту цены и ликвидации лениво
+func (cl *CoreLayer) digestSources() digest.Sources {
+	return digest.Sources{
+		Prices: func() storage.PriceStorageInterface {
+			if cs := cl.GetCandleSystem(); cs != nil {
+				return cs.GetPriceStorage()
+			}
+			return nil
+		},
+		Liquidations: func() digest.LiquidationSource {
+			cl.mu.RLock()
+			defer cl.mu.RUnlock()
+			if cl.liqWatcher != nil {
+				return cl.liqWatcher
+			}
+			return nil
+		},
+	}
+}
+
+// paperConfig переводит PAPER_TRADING_* в настройки paper trading
+func paperConfig(cfg *config.Config) paper.Config {
+	return paper.Config{
+		StartBalance:    cfg.PaperTrading.StartBalance,
+		FeePct:          cfg.PaperTrading.FeePct,
+		MaxOpenLimit:    cfg.PaperTrading.MaxOpenLimit,
+		RefreshInterval: time.Duration(cfg.PaperTrading.RefreshSec) * time.Second,
+	}
+}
+
+// strengthConfig переводит STRENGTH_* в настройки рейтинга
+func strengthConfig(cfg *config.Config) strength.Config {
+	strengthCfg := strength.DefaultConfig()
+	strengthCfg.MaxSymbols = cfg.Strength.MaxSymbols
+	strengthCfg.TopN = cfg.Strength.TopN
+	strengthCfg.DigestPeriod = cfg.Strength.DigestPeriod
+	strengthCfg.DigestInterval = time.Duration(cfg.Strength.DigestIntervalMin) * time.Minute
+	strengthCfg.Sectors = strength.ParseSectors(cfg.Strength.Sectors)
+	return strengthCfg
+}
+
+// strengthSources отдает рейтингу свечи и цены CandleSystem лениво:
+// слой доставки создает сервис раньше, чем запускается CandleSystem
+func (cl *CoreLayer) strengthSources() strength.Sources {
+	return strength.Sources{
+		Candles: func() strength.CandleSource {
+			if cs := cl.GetCandleSystem(); cs != nil {
+				return cs
+			}
+			return nil
+		},
+		Prices: func() storage.PriceStorageInterface {
+			if cs := cl.GetCandleSystem(); cs != nil {
+				return cs.GetPriceStorage()
+			}
+			return nil
+		},
+	}
+}
+
 // warmupSRZonesOnFirstPriceEvent подписывается на EventPriceUpdated,
 // берёт символы из первого батча и запускает Warmup, затем отписывается.
 func (cl *CoreLayer) warmupSRZonesOnFirstPriceEvent(eventBus *events.EventBus) {
@@ -768,6 +1280,44 @@ func (cl *CoreLayer) Stop() error {
 		logger.Info("📐 SRZoneEngine остановлен")
 	}
 
+	// Останавливаем WallTracker если запущен
+	if cl.wallTracker != nil {
+		cl.wallTracker.Stop()
+	}
+
+	// Останавливаем RulesEngine если запущен
+	if cl.rulesEngine != nil {
+		cl.rulesEngine.Stop()
+	}
+
+	// Останавливаем PriceAlertMonitor если запущен
+	if cl.alertMonitor != nil {
+		cl.alertMonitor.Stop()
+	}
+
+	// Останавливаем дайджест секторов если запущен
+	if cl.strengthDigest != nil {
+		cl.strengthDigest.Stop()
+	}
+
+	// Останавливаем журнал сигналов если запущен
+	if cl.journalRecorder != nil {
+		cl.journalRecorder.Stop()
+	}
+	if cl.journalEvaluator != nil {
+		cl.journalEvaluator.Stop()
+	}
+
+	// Останавливаем PaperTradingMonitor если запущен
+	if cl.paperMonitor != nil {
+		cl.paperMonitor.Stop()
+	}
+
+	// Останавливаем классификатор режима рынка если запущен
+	if cl.marketRegime != nil {
+		cl.marketRegime.Stop()
+	}
+
 	// Останавливаем AnalysisEngine если запущен
 	if cl.analysisEngine != nil {
 		// ✅ ИСПРАВЛЕНИЕ: Вызываем Stop() без проверки возвращаемого значения
@@ -826,6 +1376,33 @@ func (cl *CoreLayer) Reset() error {
 	if cl.srZoneStorage != nil {
 		cl.srZoneStorage = nil
 	}
+	if cl.wallTracker != nil {
+		cl.wallTracker = nil
+	}
+	if cl.rulesEngine != nil {
+		cl.rulesEngine = nil
+	}
+	if cl.alertMonitor != nil {
+		cl.alertMonitor = nil
+	}
+	if cl.strengthDigest != nil {
+		cl.strengthDigest = nil
+	}
+	if cl.journalRecorder != nil {
+		cl.journalRecorder = nil
+	}
+	if cl.journalEvaluator != nil {
+		cl.journalEvaluator = nil
+	}
+	if cl.paperMonitor != nil {
+		cl.paperMonitor = nil
+	}
+	if cl.marketDigest != nil {
+		cl.marketDigest = nil
+	}
+	if cl.marketRegime != nil {
+		cl.marketRegime = nil
+	}
 
 	// Сбрасываем AnalysisEngine
 	if cl.analysisEngine != nil {
@@ -887,6 +1464,20 @@ func (cl *CoreLayer) GetCandleSystem() *candle.CandleSystem {
 	return cl.candleSystem
 }
 
+// GetMarketDigestService возвращает сервис дайджеста рынка (nil, если выключен)
+func (cl *CoreLayer) GetMarketDigestService() *digest.Service {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	return cl.marketDigest
+}
+
+// GetMarketRegime возвращает классификатор режима рынка (nil, если выключен)
+func (cl *CoreLayer) GetMarketRegime() *regime.Classifier {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	return cl.marketRegime
+}
+
 // GetAnalysisEngine возвращает AnalysisEngine
 func (cl *CoreLayer) GetAnalysisEngine() *engine.AnalysisEngine {
 	cl.mu.RLock()
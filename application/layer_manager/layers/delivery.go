@@ -4,7 +4,19 @@ package layers
 import (
 	"context"
 	"fmt"
-
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/export"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/internal/core/domain/subscription"
+	analytics_server "crypto-exchange-screener-bot/internal/delivery/analytics"
 	max_package "crypto-exchange-screener-bot/internal/delivery/max"
 	max_bot "crypto-exchange-screener-bot/internal/delivery/max/bot"
 	max_transport "crypto-exchange-screener-bot/internal/delivery/max/transport"
@@ -31,6 +43,7 @@ type DeliveryLayer struct {
 	maxPackage      *max_package.Package
 	maxBot          *max_bot.Bot
 	maxBotCancel    context.CancelFunc
+	analyticsServer *analytics_server.Server
 	initialized     bool
 }
 
@@ -112,13 +125,180 @@ func (dl *DeliveryLayer) Initialize() error {
 		logger.Warn("⚠️ UserService недоступен, WatchlistService не создан")
 	}
 
+	// Сервис ценовых алертов общий для Telegram и MAX; срабатывания
+	// проверяет PriceAlertMonitor в CoreLayer
+	var alertService *alerts.Service
+	if dl.config.PriceAlerts.Enabled {
+		var limits subscription.LimitChecker
+		if subSvc, err := dl.coreLayer.GetSubscriptionService(); err == nil {
+			if svc, ok := subSvc.(*subscription.Service); ok && svc != nil {
+				limits = svc
+			}
+		}
+		coreLayer := dl.coreLayer
+		svc, err := coreFactory.CreatePriceAlertService(limits, func() storage.PriceStorageInterface {
+			if cs := coreLayer.GetCandleSystem(); cs != nil {
+				return cs.GetPriceStorage()
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Warn("⚠️ PriceAlertService не создан: %v (ценовые алерты недоступны)", err)
+		} else {
+			alertService = svc
+			logger.Info("✅ PriceAlertService создан")
+		}
+	}
+
+	// Рейтинг относительной силы для /top в Telegram и MAX; дайджест
+	// ротаций по расписанию рассылает CoreLayer
+	var strengthService *strength.Service
+	if dl.config.Strength.Enabled {
+		svc, err := coreFactory.CreateStrengthService(strengthConfig(dl.config), dl.coreLayer.strengthSources())
+		if err != nil {
+			logger.Warn("⚠️ StrengthService не создан: %v (команда /top недоступна)", err)
+		} else {
+			strengthService = svc
+			logger.Info("✅ StrengthService создан")
+		}
+	}
+
+	// Журнал сигналов: доставки пользователям пишутся для /history в Telegram и MAX
+	var signalJournal *journal.Service
+	if dl.config.SignalJournal.Enabled {
+		svc, err := coreFactory.CreateSignalJournalService()
+		if err != nil {
+			logger.Warn("⚠️ SignalJournalService не создан: %v (история сигналов недоступна)", err)
+		} else {
+			signalJournal = svc
+			signalJournal.SetFeedbackConfig(journal.FeedbackConfig{
+				Enabled:        dl.config.SignalJournal.FeedbackEnabled,
+				Window:         time.Duration(dl.config.SignalJournal.FeedbackWindowDays) * 24 * time.Hour,
+				NoiseMinVotes:  dl.config.SignalJournal.FeedbackNoiseMinVotes,
+				NoiseStep:      dl.config.SignalJournal.FeedbackNoiseStep,
+				NoiseMaxFactor: dl.config.SignalJournal.FeedbackNoiseMaxFactor,
+			})
+			logger.Info("✅ SignalJournalService создан")
+		}
+	}
+
+	// Paper-портфели: доставленные сигналы открывают виртуальные позиции, команда /paper
+	// в Telegram и MAX; закрытие по TP/SL/таймауту — PaperTradingMonitor в CoreLayer
+	var paperService *paper.Service
+	if dl.config.PaperTrading.Enabled {
+		coreLayer := dl.coreLayer
+		svc, err := coreFactory.CreatePaperTradingService(
+			func() storage.PriceStorageInterface {
+				if cs := coreLayer.GetCandleSystem(); cs != nil {
+					return cs.GetPriceStorage()
+				}
+				return nil
+			},
+			func() paper.CandleSource {
+				if cs := coreLayer.GetCandleSystem(); cs != nil {
+					return cs
+				}
+				return nil
+			},
+			paperConfig(dl.config),
+		)
+		if err != nil {
+			logger.Warn("⚠️ PaperTradingService не создан: %v (paper trading недоступен)", err)
+		} else {
+			paperService = svc
+			logger.Info("✅ PaperTradingService создан")
+		}
+	}
+
+	// Выгрузки сигналов, свечей и активности в CSV/JSON Lines для /export в Telegram;
+	// число выгрузок в сутки ограничено фичей тарифа max_exports_per_day
+	var exportService *export.Service
+	if dl.config.Export.Enabled {
+		var limits subscription.LimitChecker
+		if subSvc, err := dl.coreLayer.GetSubscriptionService(); err == nil {
+			if svc, ok := subSvc.(*subscription.Service); ok && svc != nil {
+				limits = svc
+			}
+		}
+		coreLayer := dl.coreLayer
+		svc, err := coreFactory.CreateExportService(
+			func() export.CandleSource {
+				if cs := coreLayer.GetCandleSystem(); cs != nil {
+					return cs
+				}
+				return nil
+			},
+			limits,
+			export.Config{
+				Dir:          dl.config.Export.Dir,
+				MaxRows:      dl.config.Export.MaxRows,
+				MaxDocBytes:  int64(dl.config.Export.MaxDocMB) << 20,
+				MaxRangeDays: dl.config.Export.MaxRangeDays,
+			},
+		)
+		if err != nil {
+			logger.Warn("⚠️ ExportService не создан: %v (выгрузки недоступны)", err)
+		} else {
+			exportService = svc
+			logger.Info("✅ ExportService создан")
+		}
+	}
+
+	// Дайджест рынка: команда /digest в Telegram и MAX (настройки и последний дайджест);
+	// построение и рассылку выполняют задачи планировщика через сервис CoreLayer
+	var digestService *digest.Service
+	if dl.config.MarketDigest.Enabled {
+		svc, err := coreFactory.CreateMarketDigestService(dl.coreLayer.digestSources(), nil, digestConfig(dl.config))
+		if err != nil {
+			logger.Warn("⚠️ MarketDigestService не создан: %v (/digest недоступен)", err)
+		} else {
+			digestService = svc
+			logger.Info("✅ MarketDigestService создан")
+		}
+	}
+
+	// JSON аналитики исходов сигналов для внешних дашбордов
+	if signalJournal != nil && dl.config.SignalJournal.AnalyticsAPIEnabled {
+		if dl.config.SignalJournal.AnalyticsAPISecret == "" {
+			logger.Warn("⚠️ SIGNAL_ANALYTICS_API_SECRET не задан — сервер аналитики не запущен")
+		} else {
+			dl.analyticsServer = analytics_server.NewServer(signalJournal,
+				dl.config.SignalJournal.AnalyticsAPIPort, dl.config.SignalJournal.AnalyticsAPISecret)
+		}
+	}
+
 	// Создаем TelegramDeliveryPackage
 	deps := telegram_package.TelegramDeliveryPackageDependencies{
 		Config:           dl.config,
 		CoreFactory:      coreFactory,
 		Exchange:         "BYBIT",
 		WatchlistService: watchlistService,
+		AlertService:     alertService,
+		StrengthService:  strengthService,
+		SignalJournal:    signalJournal,
+		PaperTrading:     paperService,
+		ExportService:    exportService,
+		MarketDigest:     digestService,
+	}
+	// Движок анализа создается позже слоя доставки, поэтому сведения берутся лениво
+	coreLayer := dl.coreLayer
+	deps.AnalyzerInfos = func() []common.AnalyzerInfo {
+		if analysisEngine := coreLayer.GetAnalysisEngine(); analysisEngine != nil {
+			return analysisEngine.GetAnalyzerInfos()
+		}
+		return nil
+	}
+	// CandleSystem запускается CoreLayer; VWAP берется из нее при каждом запросе /vwap
+	vwapTracker := func() *vwap.Tracker {
+		return coreLayer.GetCandleSystem().VWAP()
+	}
+	deps.VWAPTracker = vwapTracker
+	// Классификатор режима рынка запускается CoreLayer позже слоя доставки
+	var marketRegime func() *regime.Classifier
+	if dl.config.MarketRegime.Enabled {
+		marketRegime = coreLayer.GetMarketRegime
 	}
+	deps.MarketRegime = marketRegime
 	if redisClient != nil && redisClient.IsRunning() {
 		deps.RedisClient = redisClient.GetClient()
 		logger.Info("🔗 DeliveryLayer: Redis клиент передан в TelegramDeliveryPackage")
@@ -219,6 +399,13 @@ func (dl *DeliveryLayer) Initialize() error {
 					NotifyService:       notifySvc.NewServiceWithDependencies(userSvc),
 					SignalService:       signalSvc.NewServiceWithDependencies(userSvc),
 					WatchlistService:    watchlistService,
+					AlertService:        alertService,
+					StrengthService:     strengthService,
+					VWAPTracker:         vwapTracker,
+					SignalJournal:       signalJournal,
+					PaperTrading:        paperService,
+					MarketDigest:        digestService,
+					MarketRegime:        marketRegime,
 					SessionService:      sessionSvc.NewService(userSvc, nil),
 					TBankService:        maxTBankService,
 					SubscriptionService: maxSubSvc,
@@ -263,6 +450,8 @@ func (dl *DeliveryLayer) Initialize() error {
 				}
 
 				// Регистрируем UserController — per-user доставка сигналов в MAX
+				dl.maxPackage.SetSignalJournal(signalJournal)
+				dl.maxPackage.SetPaperTrading(paperService)
 				dl.maxPackage.RegisterUserController(userSvc)
 			} else {
 				logger.Warn("⚠️ MAX: не удалось получить UserService (%v) — interactive-бот не запустится", err)
@@ -349,6 +538,13 @@ func (dl *DeliveryLayer) Start() error {
 		logger.Info("🤖 MAX Bot запущен (polling)")
 	}
 
+	// Запускаем сервер аналитики сигналов
+	if dl.analyticsServer != nil {
+		if err := dl.analyticsServer.Start(); err != nil {
+			logger.Warn("⚠️ Не удалось запустить сервер аналитики: %v", err)
+		}
+	}
+
 	dl.running = true
 	dl.updateState(StateRunning)
 	logger.Info("✅ Слой доставки запущен")
@@ -385,6 +581,13 @@ func (dl *DeliveryLayer) Stop() error {
 		logger.Info("📲 MAX Package остановлен")
 	}
 
+	// Останавливаем сервер аналитики
+	if dl.analyticsServer != nil {
+		if err := dl.analyticsServer.Stop(); err != nil {
+			logger.Warn("⚠️ Ошибка остановки сервера аналитики: %v", err)
+		}
+	}
+
 	dl.running = false
 	dl.updateState(StateStopped)
 	logger.Info("✅ Слой доставки остановлен")
@@ -19,10 +19,19 @@ type SubscriptionValidator interface {
 	RunValidation(ctx context.Context) error
 }
 
+// MarketDigestService — интерфейс построения и рассылки дайджеста рынка.
+// Реализуется *digest.Service.
+type MarketDigestService interface {
+	BuildDigests(ctx context.Context) error
+	DispatchDigests(ctx context.Context) error
+	BuildTime() (hour, minute int)
+}
+
 // Deps зависимости, необходимые задачам
 type Deps struct {
 	DB                  *sqlx.DB
 	SubscriptionService SubscriptionValidator // nil — задача не регистрируется
+	MarketDigest        MarketDigestService   // nil — задачи дайджеста не регистрируются
 }
 
 // RegisterAll регистрирует все задачи приложения в планировщике.
@@ -52,6 +61,26 @@ func RegisterAll(s *Scheduler, deps Deps) {
 		})
 	}
 
+	// ──────────────────────────────────────────────────────────────
+	// Задачи дайджеста рынка
+	// ──────────────────────────────────────────────────────────────
+
+	if deps.MarketDigest != nil {
+		hour, minute := deps.MarketDigest.BuildTime()
+		s.Register(&Job{
+			Name:        "build-market-digest",
+			Description: "Строит суточный дайджест рынка (по понедельникам — и недельный)",
+			Schedule:    DailyAt(hour, minute),
+			Handler:     deps.MarketDigest.BuildDigests,
+		})
+		s.Register(&Job{
+			Name:        "dispatch-market-digest",
+			Description: "Рассылает дайджест подписчикам, у которых наступило время доставки",
+			Schedule:    Every(5 * time.Minute),
+			Handler:     deps.MarketDigest.DispatchDigests,
+		})
+	}
+
 	// ──────────────────────────────────────────────────────────────
 	// Задачи очистки
 	// ──────────────────────────────────────────────────────────────
@@ -0,0 +1,78 @@
+// internal/core/domain/alerts/levels.go
+package alerts
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/alert_index"
+)
+
+// rearmHysteresis — насколько цена должна отойти от уровня, чтобы повторяющийся
+// алерт перевзвёлся (защита от серии уведомлений при колебаниях у уровня)
+const rearmHysteresis = 0.002
+
+// armLevels возвращает уровни индекса для алерта при текущей цене.
+// price <= 0 — цена неизвестна: пересечения взводятся «как есть»,
+// диапазон пропускается до появления цены.
+func armLevels(alert *models.PriceAlert, price float64) []alert_index.Entry {
+	fire := func(side alert_index.Side, level float64) alert_index.Entry {
+		return alert_index.Entry{AlertID: alert.ID, Side: side, Level: level}
+	}
+	rearm := func(side alert_index.Side, level float64) alert_index.Entry {
+		return alert_index.Entry{AlertID: alert.ID, Side: side, Level: level, Rearm: true}
+	}
+
+	switch alert.AlertType {
+	case models.PriceAlertCrossUp:
+		if price <= 0 || price < alert.Price {
+			return []alert_index.Entry{fire(alert_index.Above, alert.Price)}
+		}
+		// Цена уже выше уровня: ждём возврата под уровень
+		return []alert_index.Entry{rearm(alert_index.Below, alert.Price*(1-rearmHysteresis))}
+
+	case models.PriceAlertCrossDown:
+		if price <= 0 || price > alert.Price {
+			return []alert_index.Entry{fire(alert_index.Below, alert.Price)}
+		}
+		return []alert_index.Entry{rearm(alert_index.Above, alert.Price*(1+rearmHysteresis))}
+
+	case models.PriceAlertRange:
+		switch {
+		case price <= 0:
+			return nil
+		case price < alert.Price:
+			return []alert_index.Entry{fire(alert_index.Above, alert.Price)}
+		case price > alert.PriceHigh:
+			return []alert_index.Entry{fire(alert_index.Below, alert.PriceHigh)}
+		}
+		// Цена внутри диапазона: ждём выхода из него в любую сторону
+		return []alert_index.Entry{
+			rearm(alert_index.Above, alert.PriceHigh*(1+rearmHysteresis)),
+			rearm(alert_index.Below, alert.Price*(1-rearmHysteresis)),
+		}
+
+	case models.PriceAlertPercent:
+		ref := alert.ReferencePrice
+		if ref <= 0 {
+			ref = price
+		}
+		if ref <= 0 {
+			return nil
+		}
+		delta := alert.Percent / 100
+		return []alert_index.Entry{
+			fire(alert_index.Above, ref*(1+delta)),
+			fire(alert_index.Below, ref*(1-delta)),
+		}
+	}
+	return nil
+}
+
+// isWaiting — алерт взведён только на перевзвод (условие уже выполнено при создании)
+func isWaiting(entries []alert_index.Entry) bool {
+	for _, e := range entries {
+		if !e.Rearm {
+			return false
+		}
+	}
+	return len(entries) > 0
+}
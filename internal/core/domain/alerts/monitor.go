@@ -0,0 +1,162 @@
+// internal/core/domain/alerts/monitor.go
+package alerts
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/alert_index"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Monitor проверяет ценовые алерты на каждом EventPriceUpdated.
+// Индекс перестраивается из Postgres при получении первых цен после запуска.
+type Monitor struct {
+	service  *Service
+	eventBus types.EventBus
+
+	mu      sync.Mutex
+	rebuilt bool
+}
+
+// NewMonitor создает монитор алертов
+func NewMonitor(service *Service, eventBus types.EventBus) *Monitor {
+	return &Monitor{
+		service:  service,
+		eventBus: eventBus,
+	}
+}
+
+// Start подписывается на обновления цен
+func (m *Monitor) Start() {
+	m.eventBus.Subscribe(types.EventPriceUpdated, m)
+	logger.Info("✅ PriceAlertMonitor запущен")
+}
+
+// Stop отписывается от обновлений цен
+func (m *Monitor) Stop() {
+	m.eventBus.Unsubscribe(types.EventPriceUpdated, m)
+	logger.Info("🛑 PriceAlertMonitor остановлен")
+}
+
+// GetName возвращает имя подписчика
+func (m *Monitor) GetName() string {
+	return "price_alert_monitor"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (m *Monitor) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventPriceUpdated}
+}
+
+// HandleEvent проверяет достигнутые уровни алертов
+func (m *Monitor) HandleEvent(event types.Event) error {
+	prices := make(map[string]float64)
+	switch data := event.Data.(type) {
+	case []storage.PriceData:
+		for _, p := range data {
+			prices[p.Symbol] = p.Price
+		}
+	case storage.PriceData:
+		prices[data.Symbol] = data.Price
+	default:
+		return nil
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+
+	// Обновления цен обрабатываются последовательно: уровни, перевзведённые
+	// на одном обновлении, должны быть видны следующему
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.rebuilt {
+		armed, err := m.service.Rebuild(prices)
+		if err != nil {
+			logger.Warn("⚠️ PriceAlertMonitor: не удалось перестроить индекс: %v", err)
+			return err
+		}
+		m.rebuilt = true
+		logger.Info("🔔 PriceAlertMonitor: индекс перестроен, активных алертов: %d", armed)
+	} else if armed := m.service.ArmPending(prices); armed > 0 {
+		logger.Info("🔔 PriceAlertMonitor: взведено алертов после появления цены: %d", armed)
+	}
+
+	hits, err := m.service.index.Check(prices)
+	if err != nil {
+		return err
+	}
+	for symbol, symbolHits := range hits {
+		for _, hit := range symbolHits {
+			m.process(symbol, hit, prices[symbol])
+		}
+	}
+	return nil
+}
+
+// process обрабатывает достигнутый уровень алерта
+func (m *Monitor) process(symbol string, hit alert_index.Hit, price float64) {
+	// Удаление из индекса — «захват» срабатывания: если уровень уже
+	// обработан другим экземпляром, ZREM ничего не удалит
+	claimed, err := m.service.index.Remove(symbol, hit.AlertID)
+	if err != nil || !claimed {
+		return
+	}
+
+	alert, err := m.service.repo.FindByID(hit.AlertID)
+	if err != nil {
+		logger.Warn("⚠️ PriceAlertMonitor: %v", err)
+		return
+	}
+	if alert == nil || !alert.IsActive {
+		return
+	}
+
+	// Перевзвод или проскок диапазона за одно обновление — только взводим заново
+	if hit.Rearm || (alert.AlertType == models.PriceAlertRange && (price < alert.Price || price > alert.PriceHigh)) {
+		m.arm(alert, price)
+		return
+	}
+
+	now := time.Now()
+	description := Describe(alert)
+
+	reference := 0.0
+	if alert.Recurring && alert.AlertType == models.PriceAlertPercent {
+		reference = price
+		alert.ReferencePrice = price
+	}
+	if err := m.service.repo.MarkTriggered(alert.ID, now, !alert.Recurring, reference); err != nil {
+		logger.Warn("⚠️ PriceAlertMonitor: %v", err)
+	}
+	if alert.Recurring {
+		m.arm(alert, price)
+	}
+
+	_ = m.eventBus.Publish(types.Event{
+		Type:   types.EventPriceAlertTriggered,
+		Source: "price_alert_monitor",
+		Data: types.PriceAlertTriggeredData{
+			AlertID:     alert.ID,
+			UserID:      alert.UserID,
+			Symbol:      alert.Symbol,
+			AlertType:   alert.AlertType,
+			Description: description,
+			Price:       price,
+			Recurring:   alert.Recurring,
+			Timestamp:   now,
+		},
+		Timestamp: now,
+	})
+	logger.Info("🔔 PriceAlertMonitor: алерт #%d (user=%d) сработал: %s %s, цена %s",
+		alert.ID, alert.UserID, alert.Symbol, description, FormatPrice(price))
+}
+
+func (m *Monitor) arm(alert *models.PriceAlert, price float64) {
+	if err := m.service.index.Arm(alert.Symbol, armLevels(alert, price)); err != nil {
+		logger.Warn("⚠️ PriceAlertMonitor: %v", err)
+	}
+}
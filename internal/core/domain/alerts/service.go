@@ -0,0 +1,247 @@
+// internal/core/domain/alerts/service.go
+package alerts
+
+import (
+	"context"
+	"crypto-exchange-screener-bot/internal/core/domain/subscription"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	price_alert_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/price_alert"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/alert_index"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ErrAlertNotFound — алерт не найден или принадлежит другому пользователю
+	ErrAlertNotFound = errors.New("алерт не найден")
+	// ErrAlertLimit — достигнут лимит алертов тарифа
+	ErrAlertLimit = errors.New("достигнут лимит алертов вашего тарифа")
+	// ErrNoPrice — нет текущей цены символа (символ не торгуется или данные ещё не загружены)
+	ErrNoPrice = errors.New("нет текущей цены для символа")
+)
+
+// PriceStorageGetter — ленивое получение хранилища цен (CandleSystem стартует позже)
+type PriceStorageGetter func() storage.PriceStorageInterface
+
+// Service управляет ценовыми алертами пользователей
+type Service struct {
+	repo   price_alert_repo.PriceAlertRepository
+	index  *alert_index.AlertIndex
+	limits subscription.LimitChecker
+	prices PriceStorageGetter
+
+	// pending — алерты, не взведённые при Rebuild из-за отсутствия цены символа;
+	// взводятся ArmPending, когда цена символа появляется
+	pendingMu sync.Mutex
+	pending   map[string][]*models.PriceAlert
+}
+
+// NewService создает сервис алертов.
+// limits может быть nil — тогда количество алертов не ограничивается.
+func NewService(db *sqlx.DB, index *alert_index.AlertIndex, limits subscription.LimitChecker, prices PriceStorageGetter) *Service {
+	return &Service{
+		repo:    price_alert_repo.NewPriceAlertRepository(db),
+		index:   index,
+		limits:  limits,
+		prices:  prices,
+		pending: make(map[string][]*models.PriceAlert),
+	}
+}
+
+// CreateResult — созданный алерт и контекст для ответа пользователю
+type CreateResult struct {
+	Alert        *models.PriceAlert
+	CurrentPrice float64
+	// Waiting — условие уже выполнено при создании: алерт сработает
+	// после того, как цена уйдёт от уровня и вернётся к нему
+	Waiting bool
+}
+
+// CreateAlert сохраняет алерт, если активных алертов меньше лимита тарифа,
+// и добавляет его в индекс. Проверка лимита и вставка выполняются в одной транзакции.
+func (s *Service) CreateAlert(ctx context.Context, userID int, spec Spec) (*CreateResult, error) {
+	price := s.currentPrice(spec.Symbol)
+	if price <= 0 {
+		return nil, fmt.Errorf("%w %s", ErrNoPrice, spec.Symbol)
+	}
+
+	maxActive := -1
+	if s.limits != nil {
+		// При нулевом использовании remaining равен лимиту тарифа
+		var err error
+		_, maxActive, err = s.limits.CheckUserLimit(ctx, userID, subscription.LimitAlerts, 0)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось проверить лимит алертов: %w", err)
+		}
+	}
+
+	alert := &models.PriceAlert{
+		UserID:    userID,
+		Symbol:    spec.Symbol,
+		AlertType: spec.Type,
+		Price:     spec.Price,
+		PriceHigh: spec.PriceHigh,
+		Percent:   spec.Percent,
+		Recurring: spec.Recurring,
+		IsActive:  true,
+	}
+	switch spec.Type {
+	case typeCrossAuto:
+		alert.AlertType = models.PriceAlertCrossUp
+		if price > spec.Price {
+			alert.AlertType = models.PriceAlertCrossDown
+		}
+	case models.PriceAlertPercent:
+		alert.ReferencePrice = price
+	}
+
+	created, err := s.repo.CreateWithinLimit(alert, maxActive)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, fmt.Errorf("%w (%d)", ErrAlertLimit, maxActive)
+	}
+
+	entries := armLevels(alert, price)
+	if err := s.index.Arm(alert.Symbol, entries); err != nil {
+		logger.Warn("⚠️ PriceAlerts: алерт #%d сохранён, но не добавлен в индекс: %v", alert.ID, err)
+	}
+
+	logger.Info("🔔 Алерт #%d создан для user=%d: %s %s", alert.ID, userID, alert.Symbol, Describe(alert))
+	return &CreateResult{Alert: alert, CurrentPrice: price, Waiting: isWaiting(entries)}, nil
+}
+
+// ListAlerts возвращает активные алерты пользователя
+func (s *Service) ListAlerts(userID int) ([]*models.PriceAlert, error) {
+	return s.repo.FindActiveByUser(userID)
+}
+
+// DeleteAlert удаляет алерт пользователя из БД и индекса
+func (s *Service) DeleteAlert(userID, alertID int) error {
+	alert, err := s.repo.FindByID(alertID)
+	if err != nil {
+		return err
+	}
+	if alert == nil || alert.UserID != userID {
+		return ErrAlertNotFound
+	}
+
+	found, err := s.repo.Delete(userID, alertID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrAlertNotFound
+	}
+
+	if _, err := s.index.Remove(alert.Symbol, alertID); err != nil {
+		logger.Warn("⚠️ PriceAlerts: %v", err)
+	}
+	s.dropPending(alert.Symbol, alertID)
+	return nil
+}
+
+// Rebuild перестраивает индекс из активных алертов Postgres.
+// prices — последние цены; для отсутствующих символов используется хранилище цен.
+// Алерты символов без цены откладываются до ArmPending: уровни нельзя выбрать,
+// не зная, с какой стороны от них цена.
+func (s *Service) Rebuild(prices map[string]float64) (int, error) {
+	active, err := s.repo.FindAllActive()
+	if err != nil {
+		return 0, err
+	}
+	if err := s.index.Clear(); err != nil {
+		return 0, err
+	}
+
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	s.pending = make(map[string][]*models.PriceAlert)
+
+	armed := 0
+	for _, alert := range active {
+		price, ok := prices[alert.Symbol]
+		if !ok {
+			price = s.currentPrice(alert.Symbol)
+		}
+		entries := armLevels(alert, price)
+		if price <= 0 || len(entries) == 0 {
+			logger.Debug("⏭️ PriceAlerts: алерт #%d (%s) ждёт появления цены", alert.ID, alert.Symbol)
+			s.pending[alert.Symbol] = append(s.pending[alert.Symbol], alert)
+			continue
+		}
+		if err := s.index.Arm(alert.Symbol, entries); err != nil {
+			return armed, err
+		}
+		armed++
+	}
+	return armed, nil
+}
+
+// ArmPending взводит отложенные при Rebuild алерты символов, для которых
+// в prices появилась цена. Возвращает число взведённых алертов.
+func (s *Service) ArmPending(prices map[string]float64) int {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	if len(s.pending) == 0 {
+		return 0
+	}
+
+	armed := 0
+	for symbol, alerts := range s.pending {
+		price := prices[symbol]
+		if price <= 0 {
+			continue
+		}
+		for _, alert := range alerts {
+			if err := s.index.Arm(symbol, armLevels(alert, price)); err != nil {
+				// Оставляем символ в ожидании до следующего обновления цены
+				logger.Warn("⚠️ PriceAlerts: %v", err)
+				return armed
+			}
+			armed++
+		}
+		delete(s.pending, symbol)
+	}
+	return armed
+}
+
+// dropPending убирает удалённый алерт из ожидающих цены
+func (s *Service) dropPending(symbol string, alertID int) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	alerts := s.pending[symbol]
+	for i, alert := range alerts {
+		if alert.ID == alertID {
+			alerts = append(alerts[:i], alerts[i+1:]...)
+			break
+		}
+	}
+	if len(alerts) == 0 {
+		delete(s.pending, symbol)
+		return
+	}
+	s.pending[symbol] = alerts
+}
+
+func (s *Service) currentPrice(symbol string) float64 {
+	if s.prices == nil {
+		return 0
+	}
+	ps := s.prices()
+	if ps == nil {
+		return 0
+	}
+	price, ok := ps.GetCurrentPrice(symbol)
+	if !ok {
+		return 0
+	}
+	return price
+}
@@ -0,0 +1,184 @@
+// internal/core/domain/alerts/spec.go
+package alerts
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// typeCrossAuto — пересечение уровня; направление выбирается по текущей цене
+	typeCrossAuto = "cross"
+	maxPercent    = 50.0
+)
+
+// recurringWords — слова, делающие алерт повторяющимся
+var recurringWords = map[string]bool{
+	"повтор":    true,
+	"repeat":    true,
+	"recurring": true,
+	"🔁":         true,
+}
+
+// ErrSpecFormat — условие алерта не распознано
+var ErrSpecFormat = errors.New("не удалось разобрать условие алерта")
+
+// Spec — разобранное условие алерта
+type Spec struct {
+	Symbol    string
+	Type      string // models.PriceAlert*, либо пересечение с автоопределением направления
+	Price     float64
+	PriceHigh float64
+	Percent   float64
+	Recurring bool
+}
+
+// ParseSpec разбирает условие вида:
+//
+//	SOLUSDT 180          — пересечение уровня (направление по текущей цене)
+//	SOL >180, SOL <180   — пересечение вверх / вниз
+//	SOL 170-180          — вход цены в диапазон
+//	SOL 5%               — отклонение на 5% от текущей цены
+//
+// Слово «повтор» в конце делает алерт повторяющимся.
+func ParseSpec(text string) (Spec, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return Spec{}, ErrSpecFormat
+	}
+
+	spec := Spec{Symbol: NormalizeSymbol(fields[0])}
+	if spec.Symbol == "" {
+		return Spec{}, ErrSpecFormat
+	}
+
+	var cond strings.Builder
+	for _, f := range fields[1:] {
+		if recurringWords[strings.ToLower(f)] {
+			spec.Recurring = true
+			continue
+		}
+		cond.WriteString(f)
+	}
+	c := strings.ReplaceAll(strings.ToLower(cond.String()), ",", ".")
+	if c == "" {
+		return Spec{}, ErrSpecFormat
+	}
+
+	switch {
+	case strings.HasSuffix(c, "%"):
+		pct, err := parsePositive(strings.TrimLeft(strings.TrimSuffix(c, "%"), "±+"))
+		if err != nil || pct > maxPercent {
+			return Spec{}, fmt.Errorf("процент должен быть от 0 до %.0f", maxPercent)
+		}
+		spec.Type = models.PriceAlertPercent
+		spec.Percent = pct
+
+	case strings.HasPrefix(c, ">") || strings.HasPrefix(c, "<"):
+		spec.Type = models.PriceAlertCrossUp
+		if c[0] == '<' {
+			spec.Type = models.PriceAlertCrossDown
+		}
+		price, err := parsePositive(strings.TrimLeft(c, "<>="))
+		if err != nil {
+			return Spec{}, err
+		}
+		spec.Price = price
+
+	case strings.Contains(c, "-"):
+		parts := strings.SplitN(c, "-", 2)
+		low, err := parsePositive(parts[0])
+		if err != nil {
+			return Spec{}, err
+		}
+		high, err := parsePositive(parts[1])
+		if err != nil {
+			return Spec{}, err
+		}
+		if low > high {
+			low, high = high, low
+		}
+		if low == high {
+			return Spec{}, fmt.Errorf("границы диапазона совпадают")
+		}
+		spec.Type = models.PriceAlertRange
+		spec.Price, spec.PriceHigh = low, high
+
+	default:
+		price, err := parsePositive(c)
+		if err != nil {
+			return Spec{}, err
+		}
+		spec.Type = typeCrossAuto
+		spec.Price = price
+	}
+
+	return spec, nil
+}
+
+// NormalizeSymbol приводит тикер к виду SOLUSDT (SOL, sol/usdt → SOLUSDT)
+func NormalizeSymbol(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.NewReplacer("/", "", "-", "", "_", "").Replace(s)
+	if s == "" {
+		return ""
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	if !strings.HasSuffix(s, "USDT") && !strings.HasSuffix(s, "USDC") {
+		s += "USDT"
+	}
+	return s
+}
+
+// Describe возвращает условие алерта без символа: "пересечение вверх 180"
+func Describe(alert *models.PriceAlert) string {
+	var d string
+	switch alert.AlertType {
+	case models.PriceAlertCrossUp:
+		d = "↗️ пересечение вверх " + FormatPrice(alert.Price)
+	case models.PriceAlertCrossDown:
+		d = "↘️ пересечение вниз " + FormatPrice(alert.Price)
+	case models.PriceAlertRange:
+		d = "↔️ вход в диапазон " + FormatPrice(alert.Price) + " – " + FormatPrice(alert.PriceHigh)
+	case models.PriceAlertPercent:
+		d = fmt.Sprintf("📏 отклонение ±%s%% от %s",
+			strconv.FormatFloat(alert.Percent, 'f', -1, 64), FormatPrice(alert.ReferencePrice))
+	default:
+		d = alert.AlertType
+	}
+	if alert.Recurring {
+		d += " 🔁"
+	}
+	return d
+}
+
+// FormatPrice форматирует цену без лишних нулей: 65000.5, 180.25, 0.00001234
+func FormatPrice(price float64) string {
+	prec := 8
+	switch {
+	case price >= 1000:
+		prec = 2
+	case price >= 1:
+		prec = 4
+	}
+	s := strconv.FormatFloat(price, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+func parsePositive(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("некорректная цена %q", s)
+	}
+	return v, nil
+}
@@ -0,0 +1,78 @@
+// internal/core/domain/analysis/anomaly/distribution.go
+package anomaly
+
+import (
+	"math"
+	"sort"
+)
+
+// madToSigma — MAD нормального распределения, умноженная на это число, равна σ
+const madToSigma = 1.4826
+
+// meanAbsToSigma — то же для среднего абсолютного отклонения (запасной вариант,
+// когда больше половины доходностей одинаковы и MAD = 0)
+const meanAbsToSigma = 1.2533
+
+// Distribution — робастная оценка распределения доходностей: медиана и MAD
+// устойчивы к редким экстремальным свечам, которые мы как раз и ищем
+type Distribution struct {
+	Median  float64 `json:"median"`
+	MAD     float64 `json:"mad"`
+	Scale   float64 `json:"scale"` // оценка σ
+	Samples int     `json:"samples"`
+}
+
+// NewDistribution считает распределение по выборке доходностей
+func NewDistribution(returns []float64) Distribution {
+	if len(returns) == 0 {
+		return Distribution{}
+	}
+
+	median := median(returns)
+	deviations := make([]float64, len(returns))
+	sumAbs := 0.0
+	for i, r := range returns {
+		deviations[i] = math.Abs(r - median)
+		sumAbs += deviations[i]
+	}
+	mad := median0(deviations)
+
+	scale := mad * madToSigma
+	if scale == 0 {
+		scale = sumAbs / float64(len(returns)) * meanAbsToSigma
+	}
+
+	return Distribution{
+		Median:  median,
+		MAD:     mad,
+		Scale:   scale,
+		Samples: len(returns),
+	}
+}
+
+// ZScore возвращает робастный z-score доходности; false — распределение вырождено
+func (d Distribution) ZScore(value float64) (float64, bool) {
+	if d.Samples == 0 || d.Scale <= 0 {
+		return 0, false
+	}
+	return (value - d.Median) / d.Scale, true
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return median0(sorted)
+}
+
+// median0 — медиана; сортирует срез на месте
+func median0(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,105 @@
+// internal/core/domain/analysis/anomaly/sensitivity.go
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Пределы чувствительности пользователя в сигмах (0 — z-score сигналы выключены).
+// MinSensitivitySigma совпадает с min_z анализатора по умолчанию; если анализатор
+// настроен иначе, нижней границей служит его min_z (см. SetAnalyzerMinZ).
+const (
+	MinSensitivitySigma = 2.0
+	MaxSensitivitySigma = 6.0
+)
+
+// SensitivityPresets — варианты выбора в меню чувствительности (см. Presets)
+var SensitivityPresets = []float64{2, 2.5, 3, 4}
+
+// analyzerMinZ — min_z работающего анализатора (биты float64; 0 — не задан)
+var analyzerMinZ atomic.Uint64
+
+// SetAnalyzerMinZ сообщает min_z анализатора: сигналов с |z| ниже него нет,
+// поэтому пользовательский порог ниже не имеет смысла
+func SetAnalyzerMinZ(minZ float64) {
+	if minZ > 0 {
+		analyzerMinZ.Store(math.Float64bits(minZ))
+	}
+}
+
+// SensitivityFloor возвращает минимальную чувствительность пользователя
+func SensitivityFloor() float64 {
+	if bits := analyzerMinZ.Load(); bits != 0 {
+		return math.Min(math.Float64frombits(bits), MaxSensitivitySigma)
+	}
+	return MinSensitivitySigma
+}
+
+// Presets возвращает варианты меню не ниже SensitivityFloor; сама граница
+// добавляется первым вариантом, если ее нет среди SensitivityPresets
+func Presets() []float64 {
+	floor := SensitivityFloor()
+	presets := make([]float64, 0, len(SensitivityPresets)+1)
+	for _, sigma := range SensitivityPresets {
+		if sigma >= floor {
+			presets = append(presets, sigma)
+		}
+	}
+	if len(presets) == 0 || presets[0] > floor {
+		presets = append([]float64{floor}, presets...)
+	}
+	return presets
+}
+
+// levelSigmas — уровни из старого меню ("sensitivity:low")
+var levelSigmas = map[string]float64{
+	"low":    4,
+	"medium": 3,
+	"high":   2,
+}
+
+// ParseSensitivity разбирает чувствительность: число сигм, "off"/"0" или уровень low/medium/high
+func ParseSensitivity(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "off" {
+		return 0, nil
+	}
+	if sigma, ok := levelSigmas[value]; ok {
+		return math.Max(sigma, SensitivityFloor()), nil
+	}
+
+	sigma, err := strconv.ParseFloat(strings.TrimSuffix(value, "σ"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("неверное значение чувствительности %q", value)
+	}
+	return sigma, ValidateSensitivity(sigma)
+}
+
+// ValidateSensitivity проверяет диапазон чувствительности
+func ValidateSensitivity(sigma float64) error {
+	if sigma == 0 {
+		return nil
+	}
+	floor := SensitivityFloor()
+	if sigma < floor || sigma > MaxSensitivitySigma {
+		return fmt.Errorf("чувствительность должна быть от %.1fσ до %.1fσ", floor, MaxSensitivitySigma)
+	}
+	return nil
+}
+
+// DescribeSensitivity возвращает описание чувствительности для меню
+func DescribeSensitivity(sigma float64) string {
+	switch {
+	case sigma <= 0:
+		return "выкл"
+	case sigma >= 4:
+		return fmt.Sprintf("%.1fσ (низкая)", sigma)
+	case sigma >= 3:
+		return fmt.Sprintf("%.1fσ (средняя)", sigma)
+	}
+	return fmt.Sprintf("%.1fσ (высокая)", sigma)
+}
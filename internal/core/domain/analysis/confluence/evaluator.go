@@ -0,0 +1,256 @@
+// internal/core/domain/analysis/confluence/evaluator.go
+package confluence
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/indicators"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	historyLimit   = 80
+	fastEMAPeriod  = 20
+	slowEMAPeriod  = 50
+	rsiPeriod      = 14
+	srNearPct      = 1.0 // зона считается "рядом", если до неё меньше 1%
+	stateCacheTTL  = time.Minute
+	trendFlatRatio = 0.001 // расхождение EMA < 0.1% — флэт
+
+	// Веса компонентов внутри таймфрейма
+	trendWeight = 0.5
+	rsiWeight   = 0.3
+	srWeight    = 0.2
+)
+
+// higherTimeframes — старшие таймфреймы и их веса (чем старше, тем весомее)
+var higherTimeframes = []struct {
+	period string
+	weight float64
+}{
+	{"15m", 1.0},
+	{"1h", 1.5},
+	{"4h", 2.0},
+	{"1d", 2.5},
+}
+
+// CandleHistoryProvider — источник истории свечей (CandleSystem)
+type CandleHistoryProvider interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+// ZoneProvider — источник ближайших зон S/R (SRZoneStorage)
+type ZoneProvider interface {
+	GetNearestZones(symbol, period string, currentPrice float64) (sr_zones.NearestZones, error)
+}
+
+// Evaluator оценивает согласованность сигнала со старшими таймфреймами
+type Evaluator struct {
+	candles CandleHistoryProvider
+	zones   ZoneProvider // опционально
+
+	mu    sync.RWMutex
+	cache map[string]cachedState
+}
+
+type cachedState struct {
+	state     TimeframeState
+	ok        bool
+	expiresAt time.Time
+}
+
+// NewEvaluator создаёт оценщик; zones может быть nil
+func NewEvaluator(candles CandleHistoryProvider, zones ZoneProvider) *Evaluator {
+	return &Evaluator{
+		candles: candles,
+		zones:   zones,
+		cache:   make(map[string]cachedState),
+	}
+}
+
+// Evaluate оценивает сигнал direction ("growth"/"fall") периода signalPeriod по цене price
+func (e *Evaluator) Evaluate(symbol, signalPeriod, direction string, price float64) Result {
+	result := Result{Direction: direction, Score: 50}
+	if e == nil || e.candles == nil {
+		return result
+	}
+
+	signalDuration := periodPkg.PeriodToDuration(signalPeriod)
+	var weighted, totalWeight float64
+
+	for _, tf := range higherTimeframes {
+		if periodPkg.PeriodToDuration(tf.period) <= signalDuration {
+			continue
+		}
+		state, ok := e.timeframeState(symbol, tf.period, price)
+		if !ok {
+			continue
+		}
+
+		score := scoreTimeframe(state, direction)
+		score.Weight = tf.weight
+		result.Timeframes = append(result.Timeframes, score)
+
+		weighted += score.Score * tf.weight
+		totalWeight += tf.weight
+	}
+
+	if totalWeight == 0 {
+		return result
+	}
+
+	result.Available = true
+	result.Score = math.Round((50+50*weighted/totalWeight)*10) / 10
+	return result
+}
+
+// timeframeState рассчитывает (или берёт из кэша) состояние таймфрейма
+func (e *Evaluator) timeframeState(symbol, period string, price float64) (TimeframeState, bool) {
+	key := symbol + ":" + period
+
+	e.mu.RLock()
+	cached, found := e.cache[key]
+	e.mu.RUnlock()
+	if found && time.Now().Before(cached.expiresAt) {
+		return e.withZones(cached.state, symbol, price), cached.ok
+	}
+
+	state, ok := e.calculateState(symbol, period)
+
+	e.mu.Lock()
+	e.cache[key] = cachedState{state: state, ok: ok, expiresAt: time.Now().Add(stateCacheTTL)}
+	e.mu.Unlock()
+
+	return e.withZones(state, symbol, price), ok
+}
+
+// calculateState считает тренд и RSI по закрытым свечам таймфрейма
+func (e *Evaluator) calculateState(symbol, period string) (TimeframeState, bool) {
+	state := TimeframeState{Period: period, Trend: TrendFlat, RSIRegime: RSINeutral, SR: SRNone}
+
+	history, err := e.candles.GetHistory(symbol, period, historyLimit)
+	if err != nil {
+		return state, false
+	}
+
+	closes := make([]float64, 0, len(history))
+	for _, c := range history {
+		if c != nil && c.IsClosedFlag && c.IsRealFlag && c.Close > 0 {
+			closes = append(closes, c.Close)
+		}
+	}
+	if len(closes) <= rsiPeriod {
+		return state, false
+	}
+
+	last := len(closes) - 1
+
+	// Тренд: быстрая EMA относительно медленной (или цены, если истории мало)
+	fast := indicators.EMASeries(closes, fastEMAPeriod)
+	reference := indicators.EMASeries(closes, slowEMAPeriod)[last]
+	if math.IsNaN(reference) {
+		reference = closes[last]
+		fast = indicators.EMASeries(closes, rsiPeriod)
+	}
+	if !math.IsNaN(fast[last]) && reference > 0 {
+		diff := (fast[last] - reference) / reference
+		switch {
+		case diff > trendFlatRatio:
+			state.Trend = TrendUp
+		case diff < -trendFlatRatio:
+			state.Trend = TrendDown
+		}
+	}
+
+	rsi := indicators.RSISeries(closes, rsiPeriod)[last]
+	if !math.IsNaN(rsi) {
+		state.RSI = math.Round(rsi*10) / 10
+		state.RSIRegime = classifyRSI(rsi)
+	}
+
+	return state, true
+}
+
+// withZones дополняет состояние близостью к зонам S/R (зависит от текущей цены)
+func (e *Evaluator) withZones(state TimeframeState, symbol string, price float64) TimeframeState {
+	state.SR = SRNone
+	state.SRDistPct = 0
+	if e.zones == nil || price <= 0 {
+		return state
+	}
+
+	nearest, err := e.zones.GetNearestZones(symbol, state.Period, price)
+	if err != nil {
+		return state
+	}
+
+	supportNear := nearest.Support != nil && nearest.DistToSupportPct <= srNearPct
+	resistNear := nearest.Resistance != nil && nearest.DistToResistPct <= srNearPct
+	switch {
+	case supportNear && (!resistNear || nearest.DistToSupportPct <= nearest.DistToResistPct):
+		state.SR = SRNearSupport
+		state.SRDistPct = nearest.DistToSupportPct
+	case resistNear:
+		state.SR = SRNearResistance
+		state.SRDistPct = nearest.DistToResistPct
+	}
+	return state
+}
+
+// scoreTimeframe оценивает, насколько состояние таймфрейма поддерживает направление сигнала
+func scoreTimeframe(state TimeframeState, direction string) TimeframeScore {
+	sign := 1.0
+	if direction == "fall" {
+		sign = -1.0
+	}
+
+	score := TimeframeScore{TimeframeState: state}
+
+	switch state.Trend {
+	case TrendUp:
+		score.TrendScore = sign
+	case TrendDown:
+		score.TrendScore = -sign
+	}
+
+	switch state.RSIRegime {
+	case RSIBullish:
+		score.RSIScore = sign
+	case RSIBearish:
+		score.RSIScore = -sign
+	case RSIOverbought:
+		// Перекупленность: рост без запаса хода, падение — вероятный откат
+		score.RSIScore = -0.5 * sign
+	case RSIOversold:
+		score.RSIScore = 0.5 * sign
+	}
+
+	// Рост от поддержки и падение от сопротивления — по ходу; упор в зону — против
+	switch state.SR {
+	case SRNearSupport:
+		score.SRScore = sign
+	case SRNearResistance:
+		score.SRScore = -sign
+	}
+
+	score.Score = score.TrendScore*trendWeight + score.RSIScore*rsiWeight + score.SRScore*srWeight
+	return score
+}
+
+func classifyRSI(rsi float64) RSIRegime {
+	switch {
+	case rsi > 70:
+		return RSIOverbought
+	case rsi < 30:
+		return RSIOversold
+	case rsi >= 55:
+		return RSIBullish
+	case rsi <= 45:
+		return RSIBearish
+	default:
+		return RSINeutral
+	}
+}
@@ -0,0 +1,84 @@
+// internal/core/domain/analysis/confluence/types.go
+package confluence
+
+// Trend — направление тренда на таймфрейме
+type Trend string
+
+const (
+	TrendUp   Trend = "up"
+	TrendDown Trend = "down"
+	TrendFlat Trend = "flat"
+)
+
+// RSIRegime — режим RSI на таймфрейме
+type RSIRegime string
+
+const (
+	RSIBullish    RSIRegime = "bullish"    // 55-70: импульс вверх
+	RSIBearish    RSIRegime = "bearish"    // 30-45: импульс вниз
+	RSINeutral    RSIRegime = "neutral"    // 45-55
+	RSIOverbought RSIRegime = "overbought" // > 70
+	RSIOversold   RSIRegime = "oversold"   // < 30
+)
+
+// SRProximity — какая зона S/R рядом с ценой
+type SRProximity string
+
+const (
+	SRNearSupport    SRProximity = "support"
+	SRNearResistance SRProximity = "resistance"
+	SRNone           SRProximity = "none"
+)
+
+// TimeframeState — состояние старшего таймфрейма, не зависящее от направления сигнала
+type TimeframeState struct {
+	Period    string
+	Trend     Trend
+	RSI       float64
+	RSIRegime RSIRegime
+	SR        SRProximity
+	SRDistPct float64 // расстояние до ближайшей зоны, %
+}
+
+// TimeframeScore — вклад одного таймфрейма в итоговую оценку
+type TimeframeScore struct {
+	TimeframeState
+	TrendScore float64 // -1..1
+	RSIScore   float64 // -1..1
+	SRScore    float64 // -1..1
+	Score      float64 // -1..1, взвешенная сумма компонентов
+	Weight     float64 // вес таймфрейма
+}
+
+// Result — итоговая оценка согласованности сигнала со старшими таймфреймами
+type Result struct {
+	Direction  string
+	Score      float64 // 0..100, 50 = нейтрально
+	Available  bool    // false — старших таймфреймов нет или нет данных
+	Timeframes []TimeframeScore
+}
+
+// ToMap сериализует результат для Metadata.Custom и данных события
+func (r Result) ToMap() map[string]interface{} {
+	breakdown := make([]map[string]interface{}, 0, len(r.Timeframes))
+	for _, tf := range r.Timeframes {
+		breakdown = append(breakdown, map[string]interface{}{
+			"period":      tf.Period,
+			"trend":       string(tf.Trend),
+			"rsi":         tf.RSI,
+			"rsi_regime":  string(tf.RSIRegime),
+			"sr":          string(tf.SR),
+			"sr_dist_pct": tf.SRDistPct,
+			"trend_score": tf.TrendScore,
+			"rsi_score":   tf.RSIScore,
+			"sr_score":    tf.SRScore,
+			"score":       tf.Score,
+			"weight":      tf.Weight,
+		})
+	}
+	return map[string]interface{}{
+		"score":      r.Score,
+		"direction":  r.Direction,
+		"timeframes": breakdown,
+	}
+}
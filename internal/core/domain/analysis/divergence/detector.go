@@ -0,0 +1,187 @@
+// internal/core/domain/analysis/divergence/detector.go
+package divergence
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/indicators"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"math"
+	"time"
+)
+
+const (
+	defaultRSIPeriod   = 14
+	defaultMACDFast    = 12
+	defaultMACDSlow    = 26
+	defaultMACDSignal  = 9
+	defaultMinPivotGap = 5  // минимум свечей между pivot-точками
+	defaultMaxPivotGap = 60 // максимум свечей между pivot-точками
+	defaultMaxBarsAgo  = 3  // сколько свечей после подтверждения pivot дивергенция считается свежей
+)
+
+// Config — параметры детектора дивергенций
+type Config struct {
+	PivotLookback int // окно pivot-точек (как в sr_zones)
+	RSIPeriod     int
+	MACDFast      int
+	MACDSlow      int
+	MACDSignal    int
+	MinPivotGap   int
+	MaxPivotGap   int
+	MaxBarsAgo    int
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		PivotLookback: sr_zones.DefaultPivotLookback,
+		RSIPeriod:     defaultRSIPeriod,
+		MACDFast:      defaultMACDFast,
+		MACDSlow:      defaultMACDSlow,
+		MACDSignal:    defaultMACDSignal,
+		MinPivotGap:   defaultMinPivotGap,
+		MaxPivotGap:   defaultMaxPivotGap,
+		MaxBarsAgo:    defaultMaxBarsAgo,
+	}
+}
+
+// Detector ищет дивергенции RSI/MACD по закрытым свечам
+type Detector struct {
+	config Config
+}
+
+// NewDetector создаёт детектор
+func NewDetector(config Config) *Detector {
+	return &Detector{config: config}
+}
+
+// MinCandles — минимальное количество свечей для расчёта обоих индикаторов
+func (d *Detector) MinCandles() int {
+	minCandles := d.config.MACDSlow + d.config.MACDSignal
+	if d.config.RSIPeriod+1 > minCandles {
+		minCandles = d.config.RSIPeriod + 1
+	}
+	return minCandles + d.config.MinPivotGap + 2*d.config.PivotLookback
+}
+
+// Detect ищет дивергенции между двумя последними pivot-точками цены.
+// Свечи должны быть закрытыми и упорядоченными от старых к новым.
+func (d *Detector) Detect(symbol, period string, candles []storage.CandleInterface) []Divergence {
+	if len(candles) < d.MinCandles() {
+		return nil
+	}
+
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.GetClose()
+	}
+
+	series := map[Indicator][]float64{
+		IndicatorRSI:  indicators.RSISeries(closes, d.config.RSIPeriod),
+		IndicatorMACD: indicators.MACDHistogramSeries(closes, d.config.MACDFast, d.config.MACDSlow, d.config.MACDSignal),
+	}
+
+	now := time.Now()
+	var result []Divergence
+
+	lows := sr_zones.PivotLowIndexes(candles, d.config.PivotLookback)
+	if a, b, ok := d.lastPivotPair(lows, len(candles)); ok {
+		for _, indicator := range []Indicator{IndicatorRSI, IndicatorMACD} {
+			kind, found := compareLows(candles[a].GetLow(), candles[b].GetLow(),
+				series[indicator][a], series[indicator][b], indicator)
+			if found {
+				result = append(result, d.build(symbol, period, indicator, kind,
+					candles, a, b, candles[a].GetLow(), candles[b].GetLow(), series[indicator], now))
+			}
+		}
+	}
+
+	highs := sr_zones.PivotHighIndexes(candles, d.config.PivotLookback)
+	if a, b, ok := d.lastPivotPair(highs, len(candles)); ok {
+		for _, indicator := range []Indicator{IndicatorRSI, IndicatorMACD} {
+			kind, found := compareHighs(candles[a].GetHigh(), candles[b].GetHigh(),
+				series[indicator][a], series[indicator][b], indicator)
+			if found {
+				result = append(result, d.build(symbol, period, indicator, kind,
+					candles, a, b, candles[a].GetHigh(), candles[b].GetHigh(), series[indicator], now))
+			}
+		}
+	}
+
+	return result
+}
+
+// lastPivotPair возвращает две последние pivot-точки, если вторая свежая,
+// а расстояние между ними в допустимых пределах.
+func (d *Detector) lastPivotPair(indexes []int, n int) (int, int, bool) {
+	if len(indexes) < 2 {
+		return 0, 0, false
+	}
+	a, b := indexes[len(indexes)-2], indexes[len(indexes)-1]
+
+	// pivot подтверждается через lookback свечей после него
+	barsAgo := n - 1 - b - d.config.PivotLookback
+	if barsAgo > d.config.MaxBarsAgo {
+		return 0, 0, false
+	}
+	gap := b - a
+	if gap < d.config.MinPivotGap || gap > d.config.MaxPivotGap {
+		return 0, 0, false
+	}
+	return a, b, true
+}
+
+// compareLows классифицирует пару минимумов.
+func compareLows(priceA, priceB, indA, indB float64, indicator Indicator) (Kind, bool) {
+	if math.IsNaN(indA) || math.IsNaN(indB) {
+		return "", false
+	}
+	// Для MACD дивергенция на минимумах имеет смысл только ниже нуля
+	if indicator == IndicatorMACD && indA >= 0 {
+		return "", false
+	}
+	switch {
+	case priceB < priceA && indB > indA:
+		return KindRegularBullish, true
+	case priceB > priceA && indB < indA:
+		return KindHiddenBullish, true
+	}
+	return "", false
+}
+
+// compareHighs классифицирует пару максимумов.
+func compareHighs(priceA, priceB, indA, indB float64, indicator Indicator) (Kind, bool) {
+	if math.IsNaN(indA) || math.IsNaN(indB) {
+		return "", false
+	}
+	// Для MACD дивергенция на максимумах имеет смысл только выше нуля
+	if indicator == IndicatorMACD && indA <= 0 {
+		return "", false
+	}
+	switch {
+	case priceB > priceA && indB < indA:
+		return KindRegularBearish, true
+	case priceB < priceA && indB > indA:
+		return KindHiddenBearish, true
+	}
+	return "", false
+}
+
+func (d *Detector) build(symbol, period string, indicator Indicator, kind Kind,
+	candles []storage.CandleInterface, a, b int, priceA, priceB float64,
+	values []float64, now time.Time) Divergence {
+	return Divergence{
+		Symbol:             symbol,
+		Period:             period,
+		Indicator:          indicator,
+		Kind:               kind,
+		FromTime:           candles[a].GetStartTime(),
+		ToTime:             candles[b].GetStartTime(),
+		FromPrice:          priceA,
+		ToPrice:            priceB,
+		FromIndicatorValue: values[a],
+		ToIndicatorValue:   values[b],
+		BarsAgo:            len(candles) - 1 - b,
+		DetectedAt:         now,
+	}
+}
@@ -0,0 +1,71 @@
+// internal/core/domain/analysis/divergence/store.go
+package divergence
+
+import (
+	"sync"
+	"time"
+)
+
+// Store хранит последние найденные дивергенции по symbol+period в памяти.
+// Используется CounterAnalyzer, чтобы прикреплять теги дивергенций к своим сигналам.
+type Store struct {
+	mu      sync.RWMutex
+	entries map[string]storeEntry
+}
+
+type storeEntry struct {
+	divergences []Divergence
+	expiresAt   time.Time
+}
+
+// NewStore создаёт хранилище
+func NewStore() *Store {
+	return &Store{
+		entries: make(map[string]storeEntry),
+	}
+}
+
+// Set заменяет актуальный набор дивергенций для symbol+period.
+// validFor — сколько набор считается актуальным без повторного подтверждения.
+func (s *Store) Set(symbol, period string, divergences []Divergence, validFor time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(divergences) == 0 {
+		delete(s.entries, storeKey(symbol, period))
+		return
+	}
+	s.entries[storeKey(symbol, period)] = storeEntry{
+		divergences: append([]Divergence(nil), divergences...),
+		expiresAt:   time.Now().Add(validFor),
+	}
+}
+
+// Get возвращает актуальные дивергенции для symbol+period
+func (s *Store) Get(symbol, period string) []Divergence {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[storeKey(symbol, period)]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return append([]Divergence(nil), entry.divergences...)
+}
+
+// Tags возвращает структурированные теги актуальных дивергенций
+func (s *Store) Tags(symbol, period string) []string {
+	divergences := s.Get(symbol, period)
+	if len(divergences) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(divergences))
+	for _, d := range divergences {
+		tags = append(tags, d.Tag())
+	}
+	return tags
+}
+
+func storeKey(symbol, period string) string {
+	return symbol + ":" + period
+}
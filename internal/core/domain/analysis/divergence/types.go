@@ -0,0 +1,78 @@
+// internal/core/domain/analysis/divergence/types.go
+package divergence
+
+import (
+	"fmt"
+	"time"
+)
+
+// Indicator — осциллятор, по которому найдена дивергенция
+type Indicator string
+
+const (
+	IndicatorRSI  Indicator = "rsi"
+	IndicatorMACD Indicator = "macd" // гистограмма MACD(12,26,9)
+)
+
+// Kind — тип дивергенции
+type Kind string
+
+const (
+	// KindRegularBullish — цена обновила минимум, осциллятор нет (ослабление падения)
+	KindRegularBullish Kind = "regular_bullish"
+	// KindRegularBearish — цена обновила максимум, осциллятор нет (ослабление роста)
+	KindRegularBearish Kind = "regular_bearish"
+	// KindHiddenBullish — цена сделала более высокий минимум, осциллятор — более низкий (продолжение роста)
+	KindHiddenBullish Kind = "hidden_bullish"
+	// KindHiddenBearish — цена сделала более низкий максимум, осциллятор — более высокий (продолжение падения)
+	KindHiddenBearish Kind = "hidden_bearish"
+)
+
+// IsBullish сообщает, что дивергенция бычья
+func (k Kind) IsBullish() bool {
+	return k == KindRegularBullish || k == KindHiddenBullish
+}
+
+// IsRegular сообщает, что дивергенция классическая (разворотная)
+func (k Kind) IsRegular() bool {
+	return k == KindRegularBullish || k == KindRegularBearish
+}
+
+// Divergence — найденная дивергенция между двумя pivot-точками цены
+type Divergence struct {
+	Symbol    string    `json:"symbol"`
+	Period    string    `json:"period"`
+	Indicator Indicator `json:"indicator"`
+	Kind      Kind      `json:"kind"`
+
+	// Первая (старая) и вторая (свежая) pivot-точки
+	FromTime           time.Time `json:"from_time"`
+	ToTime             time.Time `json:"to_time"`
+	FromPrice          float64   `json:"from_price"`
+	ToPrice            float64   `json:"to_price"`
+	FromIndicatorValue float64   `json:"from_indicator_value"`
+	ToIndicatorValue   float64   `json:"to_indicator_value"`
+
+	// Сколько закрытых свечей прошло с подтверждения второй точки
+	BarsAgo int `json:"bars_ago"`
+
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Tag возвращает структурированный тег вида "divergence:rsi:regular_bullish"
+func (d Divergence) Tag() string {
+	return fmt.Sprintf("divergence:%s:%s", d.Indicator, d.Kind)
+}
+
+// Key уникально идентифицирует дивергенцию (пара pivot-точек + индикатор)
+func (d Divergence) Key() string {
+	return fmt.Sprintf("%s:%s:%s:%s:%d", d.Symbol, d.Period, d.Indicator, d.Kind, d.ToTime.Unix())
+}
+
+// PriceChangePercent — изменение цены между pivot-точками в процентах
+func (d Divergence) PriceChangePercent() float64 {
+	if d.FromPrice == 0 {
+		return 0
+	}
+	return (d.ToPrice - d.FromPrice) / d.FromPrice * 100
+}
@@ -0,0 +1,56 @@
+// internal/core/domain/analysis/episodes/config.go
+package episodes
+
+import "time"
+
+// Config — пороги переходов автомата
+type Config struct {
+	// PumpChangePct — минимальный рост за сигнал, чтобы считать его пампом, %
+	PumpChangePct float64
+	// VolumeSpike — всплеск объёма (к среднему), подтверждающий памп или дамп
+	VolumeSpike float64
+	// AccumulationVolume — всплеск объёма, достаточный для стадии накопления
+	AccumulationVolume float64
+	// DeltaPct — дельта объёма, которая заменяет всплеск объёма как подтверждение, %
+	DeltaPct float64
+	// DumpRetracePct — откат от пика, после которого распределение переходит в дамп, %
+	DumpRetracePct float64
+	// TTL — эпизод закрывается, если по символу не было сигналов дольше TTL
+	TTL time.Duration
+}
+
+// DefaultConfig возвращает пороги по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		PumpChangePct:      3.0,
+		VolumeSpike:        2.0,
+		AccumulationVolume: 1.5,
+		DeltaPct:           15.0,
+		DumpRetracePct:     3.0,
+		TTL:                6 * time.Hour,
+	}
+}
+
+// normalize подставляет значения по умолчанию вместо неположительных
+func (c Config) normalize() Config {
+	def := DefaultConfig()
+	if c.PumpChangePct <= 0 {
+		c.PumpChangePct = def.PumpChangePct
+	}
+	if c.VolumeSpike <= 0 {
+		c.VolumeSpike = def.VolumeSpike
+	}
+	if c.AccumulationVolume <= 0 {
+		c.AccumulationVolume = def.AccumulationVolume
+	}
+	if c.DeltaPct <= 0 {
+		c.DeltaPct = def.DeltaPct
+	}
+	if c.DumpRetracePct <= 0 {
+		c.DumpRetracePct = def.DumpRetracePct
+	}
+	if c.TTL <= 0 {
+		c.TTL = def.TTL
+	}
+	return c
+}
@@ -0,0 +1,207 @@
+// internal/core/domain/analysis/episodes/tracker.go
+package episodes
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Tracker — автомат стадий памп/дамп по каждому символу.
+// Переходы:
+//
+//	(нет) → накопление: рост без импульса, но на объёме, покупках и росте OI
+//	(нет)/накопление → памп: сильный рост, подтверждённый объёмом или дельтой
+//	памп → распределение: падение или рост с продажами (отрицательная дельта)
+//	распределение → памп: новый пик
+//	памп/распределение → дамп: откат от пика ≥ DumpRetracePct, подтверждённый
+//	объёмом, дельтой или снижением OI
+//
+// Падение без открытого эпизода эпизод не создаёт.
+type Tracker struct {
+	config Config
+
+	mu       sync.Mutex
+	episodes map[string]*Episode
+}
+
+// NewTracker создаёт автомат
+func NewTracker(config Config) *Tracker {
+	return &Tracker{
+		config:   config.normalize(),
+		episodes: make(map[string]*Episode),
+	}
+}
+
+// Config возвращает действующие пороги
+func (t *Tracker) Config() Config {
+	return t.config
+}
+
+// Observe применяет сигнал к эпизоду символа. ok=false — сигнал не относится
+// ни к одному эпизоду.
+func (t *Tracker) Observe(obs Observation) (Update, bool) {
+	if obs.Symbol == "" || obs.Price <= 0 {
+		return Update{}, false
+	}
+	if obs.Time.IsZero() {
+		obs.Time = time.Now()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ep := t.episodes[obs.Symbol]
+	if ep != nil && obs.Time.Sub(ep.UpdatedAt) > t.config.TTL {
+		delete(t.episodes, obs.Symbol)
+		ep = nil
+	}
+
+	if ep == nil {
+		stage, ok := t.openingStage(obs)
+		if !ok {
+			return Update{}, false
+		}
+		ep = &Episode{
+			ID:         fmt.Sprintf("%s-%d", obs.Symbol, obs.Time.Unix()),
+			Symbol:     obs.Symbol,
+			Stage:      stage,
+			StartedAt:  obs.Time,
+			StartPrice: startPrice(obs),
+			PeakPrice:  obs.Price,
+			PeakAt:     obs.Time,
+		}
+		t.episodes[obs.Symbol] = ep
+		t.touch(ep, obs)
+		return Update{Episode: *ep, New: true, StageChanged: true}, true
+	}
+
+	prev := ep.Stage
+	if obs.Price > ep.PeakPrice {
+		ep.PeakPrice = obs.Price
+		ep.PeakAt = obs.Time
+	}
+	t.touch(ep, obs)
+
+	next, closed := t.transition(ep, obs)
+	if closed {
+		delete(t.episodes, obs.Symbol)
+		return Update{}, false
+	}
+	ep.Stage = next
+	return Update{Episode: *ep, Previous: prev, StageChanged: next != prev}, true
+}
+
+// Get возвращает открытый эпизод символа
+func (t *Tracker) Get(symbol string) (Episode, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ep, ok := t.episodes[symbol]
+	if !ok || time.Since(ep.UpdatedAt) > t.config.TTL {
+		return Episode{}, false
+	}
+	return *ep, true
+}
+
+// Cleanup удаляет эпизоды без сигналов дольше TTL на момент now.
+// Observe закрывает устаревший эпизод только при новом сигнале символа,
+// поэтому эпизоды замолчавших символов удаляет периодический Cleanup.
+func (t *Tracker) Cleanup(now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	removed := 0
+	for symbol, ep := range t.episodes {
+		if now.Sub(ep.UpdatedAt) > t.config.TTL {
+			delete(t.episodes, symbol)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (t *Tracker) touch(ep *Episode, obs Observation) {
+	ep.LastPrice = obs.Price
+	ep.UpdatedAt = obs.Time
+	ep.Signals++
+}
+
+// openingStage определяет, открывает ли сигнал новый эпизод
+func (t *Tracker) openingStage(obs Observation) (Stage, bool) {
+	if obs.Direction != "growth" {
+		return "", false
+	}
+	if t.isPump(obs) {
+		return StagePump, true
+	}
+	if obs.VolumeRatio >= t.config.AccumulationVolume && obs.DeltaPercent > 0 && obs.OIChange >= 0 {
+		return StageAccumulation, true
+	}
+	return "", false
+}
+
+// transition возвращает следующую стадию; closed=true — эпизод не состоялся
+func (t *Tracker) transition(ep *Episode, obs Observation) (next Stage, closed bool) {
+	growth := obs.Direction == "growth"
+
+	switch ep.Stage {
+	case StageAccumulation:
+		if growth && t.isPump(obs) {
+			return StagePump, false
+		}
+		if !growth {
+			// Падение до импульса — накопление не подтвердилось
+			return "", true
+		}
+		return StageAccumulation, false
+
+	case StagePump:
+		if growth {
+			if obs.DeltaPercent <= -t.config.DeltaPct {
+				return StageDistribution, false
+			}
+			return StagePump, false
+		}
+		if t.isDump(ep, obs) {
+			return StageDump, false
+		}
+		return StageDistribution, false
+
+	case StageDistribution:
+		if growth && ep.PeakAt.Equal(obs.Time) && t.isPump(obs) {
+			return StagePump, false
+		}
+		if !growth && t.isDump(ep, obs) {
+			return StageDump, false
+		}
+		return StageDistribution, false
+
+	case StageDump:
+		// Дамп — конечная стадия до истечения TTL
+		return StageDump, false
+	}
+	return ep.Stage, false
+}
+
+func (t *Tracker) isPump(obs Observation) bool {
+	if obs.ChangePercent < t.config.PumpChangePct {
+		return false
+	}
+	return obs.VolumeRatio >= t.config.VolumeSpike || obs.DeltaPercent >= t.config.DeltaPct
+}
+
+func (t *Tracker) isDump(ep *Episode, obs Observation) bool {
+	if ep.RetracePct() < t.config.DumpRetracePct {
+		return false
+	}
+	return obs.VolumeRatio >= t.config.VolumeSpike ||
+		obs.DeltaPercent <= -t.config.DeltaPct ||
+		obs.OIChange < 0
+}
+
+// startPrice восстанавливает цену начала движения по изменению за период
+func startPrice(obs Observation) float64 {
+	if obs.ChangePercent <= -100 {
+		return obs.Price
+	}
+	return obs.Price / (1 + obs.ChangePercent/100)
+}
@@ -0,0 +1,113 @@
+// internal/core/domain/analysis/episodes/types.go
+package episodes
+
+import "time"
+
+// Stage — стадия эпизода памп/дамп
+type Stage string
+
+const (
+	StageAccumulation Stage = "accumulation" // рост объёма и OI без сильного движения цены
+	StagePump         Stage = "pump"         // импульсный рост на объёме
+	StageDistribution Stage = "distribution" // откат от пика, продажи в силу
+	StageDump         Stage = "dump"         // обвал от пика на объёме
+)
+
+// Label возвращает название стадии для сообщений
+func (s Stage) Label() string {
+	switch s {
+	case StageAccumulation:
+		return "накопление"
+	case StagePump:
+		return "памп"
+	case StageDistribution:
+		return "распределение"
+	case StageDump:
+		return "дамп"
+	}
+	return string(s)
+}
+
+// Emoji возвращает значок стадии
+func (s Stage) Emoji() string {
+	switch s {
+	case StageAccumulation:
+		return "🧲"
+	case StagePump:
+		return "🚀"
+	case StageDistribution:
+		return "⚖️"
+	case StageDump:
+		return "💥"
+	}
+	return "•"
+}
+
+// Observation — один сигнал счётчика, которым питается автомат
+type Observation struct {
+	Symbol        string
+	Direction     string  // "growth" / "fall"
+	ChangePercent float64 // изменение цены за период сигнала, %
+	Price         float64
+	VolumeRatio   float64 // объём последней свечи к среднему (0 — нет данных)
+	DeltaPercent  float64 // дельта объёма, % (знак — сторона агрессора)
+	OIChange      float64 // изменение OI, %
+	Time          time.Time
+}
+
+// Episode — связанная цепочка сигналов одного символа
+type Episode struct {
+	ID        string
+	Symbol    string
+	Stage     Stage
+	StartedAt time.Time
+	UpdatedAt time.Time
+
+	StartPrice float64
+	PeakPrice  float64
+	PeakAt     time.Time
+	LastPrice  float64
+
+	Signals int // сколько сигналов привязано к эпизоду
+}
+
+// GainPct — рост от начала эпизода до пика, %
+func (e Episode) GainPct() float64 {
+	if e.StartPrice <= 0 {
+		return 0
+	}
+	return (e.PeakPrice - e.StartPrice) / e.StartPrice * 100
+}
+
+// RetracePct — откат от пика до последней цены, %
+func (e Episode) RetracePct() float64 {
+	if e.PeakPrice <= 0 || e.LastPrice >= e.PeakPrice {
+		return 0
+	}
+	return (e.PeakPrice - e.LastPrice) / e.PeakPrice * 100
+}
+
+// Update — результат обработки наблюдения
+type Update struct {
+	Episode      Episode
+	Previous     Stage // стадия до наблюдения (пусто для нового эпизода)
+	New          bool  // эпизод открыт этим наблюдением
+	StageChanged bool
+}
+
+// ToMap сериализует обновление для данных события
+func (u Update) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"episode_id":            u.Episode.ID,
+		"episode_stage":         string(u.Episode.Stage),
+		"episode_prev_stage":    string(u.Previous),
+		"episode_new":           u.New,
+		"episode_stage_changed": u.StageChanged,
+		"episode_started_at":    u.Episode.StartedAt,
+		"episode_start_price":   u.Episode.StartPrice,
+		"episode_peak_price":    u.Episode.PeakPrice,
+		"episode_gain_pct":      u.Episode.GainPct(),
+		"episode_retrace_pct":   u.Episode.RetracePct(),
+		"episode_signals":       u.Episode.Signals,
+	}
+}
@@ -0,0 +1,48 @@
+// internal/core/domain/analysis/episodes/volume.go
+package episodes
+
+import (
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+)
+
+const volumeWindow = 20 // свечей для среднего объёма
+
+// CandleProvider — источник свечей (CandleSystem)
+type CandleProvider interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+// VolumeRatio — объём последней закрытой свечи к среднему за volumeWindow предыдущих.
+// 0 — данных недостаточно.
+func VolumeRatio(candles CandleProvider, symbol, period string) float64 {
+	if candles == nil {
+		return 0
+	}
+	history, err := candles.GetHistory(symbol, period, volumeWindow+2)
+	if err != nil {
+		return 0
+	}
+	closed := make([]*storage.Candle, 0, len(history))
+	for _, c := range history {
+		if c != nil && c.IsClosedFlag && c.IsRealFlag && c.Close > 0 {
+			closed = append(closed, c)
+		}
+	}
+	if len(closed) < 6 {
+		return 0
+	}
+
+	prev := closed[:len(closed)-1]
+	if len(prev) > volumeWindow {
+		prev = prev[len(prev)-volumeWindow:]
+	}
+	sum := 0.0
+	for _, c := range prev {
+		sum += c.VolumeUSD
+	}
+	avg := sum / float64(len(prev))
+	if avg <= 0 {
+		return 0
+	}
+	return closed[len(closed)-1].VolumeUSD / avg
+}
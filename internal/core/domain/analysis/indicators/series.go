@@ -0,0 +1,103 @@
+// internal/core/domain/analysis/indicators/series.go
+// Пакет indicators — ряды технических индикаторов по закрытиям свечей.
+package indicators
+
+import "math"
+
+// RSISeries рассчитывает RSI Уайлдера для каждого закрытия.
+// Первые period значений равны NaN — для них недостаточно данных.
+func RSISeries(closes []float64, period int) []float64 {
+	series := nanSeries(len(closes))
+	if period < 1 || len(closes) <= period {
+		return series
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= period; i++ {
+		change := closes[i] - closes[i-1]
+		if change > 0 {
+			avgGain += change
+		} else {
+			avgLoss -= change
+		}
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+	series[period] = rsiValue(avgGain, avgLoss)
+
+	for i := period + 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		gain, loss := 0.0, 0.0
+		if change > 0 {
+			gain = change
+		} else {
+			loss = -change
+		}
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		series[i] = rsiValue(avgGain, avgLoss)
+	}
+	return series
+}
+
+// MACDHistogramSeries рассчитывает гистограмму MACD (MACD - signal) для каждого закрытия.
+// Значения до прогрева обеих EMA и сигнальной линии равны NaN.
+func MACDHistogramSeries(closes []float64, fast, slow, signal int) []float64 {
+	series := nanSeries(len(closes))
+	if fast < 1 || slow <= fast || signal < 1 || len(closes) < slow+signal {
+		return series
+	}
+
+	fastEMA := EMASeries(closes, fast)
+	slowEMA := EMASeries(closes, slow)
+
+	macdLine := make([]float64, 0, len(closes)-slow+1)
+	for i := slow - 1; i < len(closes); i++ {
+		macdLine = append(macdLine, fastEMA[i]-slowEMA[i])
+	}
+
+	signalLine := EMASeries(macdLine, signal)
+	for j := signal - 1; j < len(macdLine); j++ {
+		series[slow-1+j] = macdLine[j] - signalLine[j]
+	}
+	return series
+}
+
+// EMASeries — EMA с затравкой SMA; значения до period-1 равны NaN
+func EMASeries(values []float64, period int) []float64 {
+	series := nanSeries(len(values))
+	if len(values) < period {
+		return series
+	}
+
+	sum := 0.0
+	for i := 0; i < period; i++ {
+		sum += values[i]
+	}
+	series[period-1] = sum / float64(period)
+
+	k := 2.0 / float64(period+1)
+	for i := period; i < len(values); i++ {
+		series[i] = values[i]*k + series[i-1]*(1-k)
+	}
+	return series
+}
+
+func rsiValue(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	rs := avgGain / avgLoss
+	return 100 - 100/(1+rs)
+}
+
+func nanSeries(n int) []float64 {
+	series := make([]float64, n)
+	for i := range series {
+		series[i] = math.NaN()
+	}
+	return series
+}
@@ -0,0 +1,127 @@
+// internal/core/domain/analysis/liquidity/calculator.go
+package liquidity
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	vacuumRangePct = 2.0  // в каких пределах ищем разрывы
+	vacuumGapPct   = 0.3  // разрыв между соседними уровнями от 0.3% — вакуум
+	thinDepthUSD   = 20e3 // глубина ±1% меньше $20K — тонкий стакан
+	thinVolumePct  = 0.05 // или меньше 0.05% суточного объёма
+)
+
+// Calculate считает метрики ликвидности по стакану.
+// volume24hUSD может быть 0 — тогда соотношение глубины к объёму не считается.
+func Calculate(book *sr_zones.OrderBook, volume24hUSD float64) (Snapshot, bool) {
+	if book == nil || len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return Snapshot{}, false
+	}
+
+	bids := sortedLevels(book.Bids, true)
+	asks := sortedLevels(book.Asks, false)
+	bestBid, bestAsk := bids[0].Price, asks[0].Price
+	if bestBid <= 0 || bestAsk <= 0 || bestAsk < bestBid {
+		return Snapshot{}, false
+	}
+
+	mid := (bestBid + bestAsk) / 2
+	snapshot := Snapshot{
+		Symbol:       book.Symbol,
+		Mid:          mid,
+		SpreadPct:    round((bestAsk-bestBid)/mid*100, 4),
+		Volume24hUSD: volume24hUSD,
+		Timestamp:    time.Now(),
+	}
+
+	for _, pct := range BandPcts {
+		bidUSD, bidCovered := depthUSD(bids, mid*(1-pct/100), true)
+		askUSD, askCovered := depthUSD(asks, mid*(1+pct/100), false)
+		band := Band{
+			RangePct: pct,
+			BidUSD:   math.Round(bidUSD),
+			AskUSD:   math.Round(askUSD),
+			Covered:  bidCovered && askCovered,
+		}
+		if total := bidUSD + askUSD; total > 0 && band.Covered {
+			band.Imbalance = round((bidUSD-askUSD)/total, 3)
+		}
+		snapshot.Bands = append(snapshot.Bands, band)
+	}
+
+	// Стакан не доходит до ±1% — глубина неизвестна, тонким его не считаем
+	if band, ok := snapshot.Band(1.0); ok && band.Covered {
+		snapshot.CostUp1Pct = band.AskUSD
+		snapshot.CostDown1Pct = band.BidUSD
+		depth := band.BidUSD + band.AskUSD
+		if volume24hUSD > 0 {
+			snapshot.DepthToVolumePct = round(depth/volume24hUSD*100, 4)
+		}
+		snapshot.ThinBook = depth < thinDepthUSD ||
+			(volume24hUSD > 0 && snapshot.DepthToVolumePct < thinVolumePct)
+	}
+
+	snapshot.VacuumUpPct = round(maxGapPct(asks, mid, mid*(1+vacuumRangePct/100), false), 3)
+	snapshot.VacuumDownPct = round(maxGapPct(bids, mid, mid*(1-vacuumRangePct/100), true), 3)
+
+	return snapshot, true
+}
+
+// sortedLevels сортирует уровни от лучшей цены: bids по убыванию, asks по возрастанию
+func sortedLevels(levels []sr_zones.OrderLevel, desc bool) []sr_zones.OrderLevel {
+	sorted := make([]sr_zones.OrderLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.Price > 0 && l.Size > 0 {
+			sorted = append(sorted, l)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if desc {
+			return sorted[i].Price > sorted[j].Price
+		}
+		return sorted[i].Price < sorted[j].Price
+	})
+	return sorted
+}
+
+// depthUSD суммирует USD-объём уровней до границы limit;
+// covered — в полученном стакане есть уровни на границе или за ней
+func depthUSD(levels []sr_zones.OrderLevel, limit float64, bids bool) (total float64, covered bool) {
+	for _, l := range levels {
+		if (bids && l.Price < limit) || (!bids && l.Price > limit) {
+			return total, true
+		}
+		total += l.Price * l.Size
+		if l.Price == limit {
+			covered = true
+		}
+	}
+	return total, covered
+}
+
+// maxGapPct — наибольший разрыв между соседними уровнями (и от mid до первого уровня)
+// в пределах до границы limit, в процентах от mid
+func maxGapPct(levels []sr_zones.OrderLevel, mid, limit float64, bids bool) float64 {
+	maxGap := 0.0
+	prev := mid
+	for _, l := range levels {
+		if (bids && l.Price < limit) || (!bids && l.Price > limit) {
+			// Разрыв до границы тоже вакуум, если за ней уровней нет в пределах диапазона
+			maxGap = math.Max(maxGap, math.Abs(limit-prev))
+			return maxGap / mid * 100
+		}
+		maxGap = math.Max(maxGap, math.Abs(l.Price-prev))
+		prev = l.Price
+	}
+	// Стакан закончился раньше границы — остаток диапазона не покрыт данными, не считаем
+	return maxGap / mid * 100
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
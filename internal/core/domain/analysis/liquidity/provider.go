@@ -0,0 +1,147 @@
+// internal/core/domain/analysis/liquidity/provider.go
+package liquidity
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// orderBookDepth — максимум Bybit для linear: 200 уровней на мейджорах не доходят до ±1%
+	orderBookDepth = 500
+	// snapshotCacheTTL — после этого возраста снимок обновляется в фоне
+	snapshotCacheTTL = 20 * time.Second
+	// maxSnapshotAge — более старый снимок не используется
+	maxSnapshotAge = 2 * time.Minute
+	refreshWorkers = 4
+)
+
+// ErrEmptyBook — стакан пуст или цены некорректны
+var ErrEmptyBook = errors.New("пустой или некорректный стакан")
+
+// OrderBookFetcher — источник стакана (BybitPriceFetcher)
+type OrderBookFetcher interface {
+	GetOrderBook(symbol string, depth int) (*bybit.OrderBookV5, error)
+}
+
+// Volume24hProvider — источник суточного объёма в USD (BybitPriceFetcher)
+type Volume24hProvider interface {
+	GetVolume24hUSD(symbol string) float64
+}
+
+// Provider хранит снимки ликвидности и обновляет их в фоне: анализатор и
+// CounterAnalyzer читают последний снимок, не дожидаясь запроса стакана
+type Provider struct {
+	fetcher OrderBookFetcher
+	volumes Volume24hProvider // опционально
+
+	mu        sync.RWMutex
+	cache     map[string]Snapshot
+	pending   map[string]bool
+	slots     chan struct{} // ограничивает число одновременных запросов стакана
+	lastPrune time.Time
+}
+
+// NewProvider создаёт провайдер; volumes может быть nil
+func NewProvider(fetcher OrderBookFetcher, volumes Volume24hProvider) *Provider {
+	return &Provider{
+		fetcher:   fetcher,
+		volumes:   volumes,
+		cache:     make(map[string]Snapshot),
+		pending:   make(map[string]bool),
+		slots:     make(chan struct{}, refreshWorkers),
+		lastPrune: time.Now(),
+	}
+}
+
+// Snapshot возвращает последний снимок ликвидности символа не старше maxSnapshotAge.
+// Не блокирует: если снимок устарел или его нет, запрашивает стакан в фоне —
+// свежие данные будут доступны при следующем вызове.
+func (p *Provider) Snapshot(symbol string) (Snapshot, bool) {
+	p.mu.RLock()
+	cached, ok := p.cache[symbol]
+	p.mu.RUnlock()
+
+	age := time.Since(cached.Timestamp)
+	if !ok || age >= snapshotCacheTTL {
+		p.refresh(symbol)
+	}
+	if !ok || age >= maxSnapshotAge {
+		return Snapshot{}, false
+	}
+	return cached, true
+}
+
+// refresh запрашивает стакан в фоне; повторный запрос того же символа
+// не запускается, пока не завершится текущий
+func (p *Provider) refresh(symbol string) {
+	p.mu.Lock()
+	if p.pending[symbol] {
+		p.mu.Unlock()
+		return
+	}
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		// Все запросы заняты — снимок обновится при следующем вызове
+		p.mu.Unlock()
+		return
+	}
+	p.pending[symbol] = true
+	p.mu.Unlock()
+
+	go func() {
+		defer func() {
+			<-p.slots
+			p.mu.Lock()
+			delete(p.pending, symbol)
+			p.mu.Unlock()
+		}()
+
+		if err := p.fetch(symbol); err != nil {
+			logger.Debug("⚠️ [Liquidity] %s: %v", symbol, err)
+		}
+	}()
+}
+
+// fetch получает стакан и сохраняет снимок
+func (p *Provider) fetch(symbol string) error {
+	book, err := p.fetcher.GetOrderBook(symbol, orderBookDepth)
+	if err != nil {
+		return err
+	}
+
+	var volume24h float64
+	if p.volumes != nil {
+		volume24h = p.volumes.GetVolume24hUSD(symbol)
+	}
+
+	snapshot, ok := Calculate(sr_zones.OrderBookFromBybit(book), volume24h)
+	if !ok {
+		return ErrEmptyBook
+	}
+
+	p.mu.Lock()
+	p.cache[symbol] = snapshot
+	p.pruneLocked(snapshot.Timestamp)
+	p.mu.Unlock()
+	return nil
+}
+
+// pruneLocked удаляет снимки символов, которые больше не запрашиваются:
+// снимок старше maxSnapshotAge уже не выдается, а символ мог выпасть из отслеживания
+func (p *Provider) pruneLocked(now time.Time) {
+	if now.Sub(p.lastPrune) < maxSnapshotAge {
+		return
+	}
+	p.lastPrune = now
+	for symbol, cached := range p.cache {
+		if now.Sub(cached.Timestamp) >= maxSnapshotAge {
+			delete(p.cache, symbol)
+		}
+	}
+}
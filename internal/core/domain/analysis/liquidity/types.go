@@ -0,0 +1,92 @@
+// internal/core/domain/analysis/liquidity/types.go
+package liquidity
+
+import "time"
+
+// BandPcts — полосы от mid-цены, в которых считается глубина стакана, %
+var BandPcts = []float64{0.5, 1.0, 2.0}
+
+// Band — глубина стакана в пределах ±RangePct от mid-цены
+type Band struct {
+	RangePct  float64
+	BidUSD    float64
+	AskUSD    float64
+	Imbalance float64 // (bid - ask) / (bid + ask): +1 — только покупатели, -1 — только продавцы
+	// Covered — полученный стакан доходит до границы полосы с обеих сторон.
+	// Иначе BidUSD/AskUSD — лишь нижняя оценка, а дисбаланс не считается.
+	Covered bool
+}
+
+// Snapshot — метрики ликвидности стакана в момент времени
+type Snapshot struct {
+	Symbol    string
+	Mid       float64
+	SpreadPct float64
+	Bands     []Band
+
+	// Сколько USD нужно, чтобы сдвинуть цену на 1% (съесть стакан до ±1%);
+	// 0 — стакан не доходит до ±1%
+	CostUp1Pct   float64
+	CostDown1Pct float64
+
+	Volume24hUSD     float64
+	DepthToVolumePct float64 // глубина ±1% относительно суточного объёма, %
+
+	// Вакуум ликвидности — самый большой разрыв между уровнями в пределах ±2%, %
+	VacuumUpPct   float64
+	VacuumDownPct float64
+
+	ThinBook  bool
+	Timestamp time.Time
+}
+
+// Band возвращает полосу с заданной шириной
+func (s Snapshot) Band(rangePct float64) (Band, bool) {
+	for _, b := range s.Bands {
+		if b.RangePct == rangePct {
+			return b, true
+		}
+	}
+	return Band{}, false
+}
+
+// Warnings возвращает предупреждения о слабой ликвидности
+func (s Snapshot) Warnings() []string {
+	var warnings []string
+	if s.ThinBook {
+		warnings = append(warnings, "thin_book")
+	}
+	if s.VacuumUpPct >= vacuumGapPct {
+		warnings = append(warnings, "vacuum_up")
+	}
+	if s.VacuumDownPct >= vacuumGapPct {
+		warnings = append(warnings, "vacuum_down")
+	}
+	return warnings
+}
+
+// ToMap сериализует снимок для Metadata.Custom и данных события
+func (s Snapshot) ToMap() map[string]interface{} {
+	bands := make([]map[string]interface{}, 0, len(s.Bands))
+	for _, b := range s.Bands {
+		bands = append(bands, map[string]interface{}{
+			"range_pct": b.RangePct,
+			"bid_usd":   b.BidUSD,
+			"ask_usd":   b.AskUSD,
+			"imbalance": b.Imbalance,
+			"covered":   b.Covered,
+		})
+	}
+	return map[string]interface{}{
+		"mid":                 s.Mid,
+		"spread_pct":          s.SpreadPct,
+		"bands":               bands,
+		"cost_up_1pct":        s.CostUp1Pct,
+		"cost_down_1pct":      s.CostDown1Pct,
+		"depth_to_volume_pct": s.DepthToVolumePct,
+		"vacuum_up_pct":       s.VacuumUpPct,
+		"vacuum_down_pct":     s.VacuumDownPct,
+		"thin_book":           s.ThinBook,
+		"warnings":            s.Warnings(),
+	}
+}
@@ -0,0 +1,255 @@
+// internal/core/domain/analysis/market_context/calculator.go
+package market_context
+
+import (
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWindow = 100 // свечей для скользящей беты
+	minSamples    = 30  // меньше совпавших свечей — статистика ненадёжна
+
+	// Движение считается объяснённым рынком, если корреляция не ниже minCorrelation,
+	// ожидаемое движение в ту же сторону, а собственное движение в сторону сигнала
+	// не превышает explainedShare от исходного.
+	minCorrelation = 0.5
+	explainedShare = 0.35
+
+	minStatsTTL = time.Minute
+	maxStatsTTL = 15 * time.Minute
+)
+
+// DefaultBenchmarks — ориентиры рынка по умолчанию
+var DefaultBenchmarks = []string{"BTCUSDT", "ETHUSDT"}
+
+// CandleProvider — источник свечей (CandleSystem)
+type CandleProvider interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+	GetCandle(symbol, period string) (*storage.Candle, error)
+}
+
+// Calculator считает бету и корреляцию символов относительно ориентиров рынка
+type Calculator struct {
+	candles    CandleProvider
+	benchmarks []string
+	window     int
+
+	mu    sync.RWMutex
+	cache map[string]cachedStats
+}
+
+type cachedStats struct {
+	stats     BenchmarkStats
+	ok        bool
+	expiresAt time.Time
+}
+
+// NewCalculator создаёт калькулятор; пустой список ориентиров заменяется DefaultBenchmarks
+func NewCalculator(candles CandleProvider, benchmarks []string, window int) *Calculator {
+	if len(benchmarks) == 0 {
+		benchmarks = DefaultBenchmarks
+	}
+	if window < minSamples {
+		window = defaultWindow
+	}
+	return &Calculator{
+		candles:    candles,
+		benchmarks: benchmarks,
+		window:     window,
+		cache:      make(map[string]cachedStats),
+	}
+}
+
+// Evaluate раскладывает изменение символа на рыночную и собственную часть.
+// candle — свеча сигнала; ориентир берётся за свечу с тем же временем начала.
+func (c *Calculator) Evaluate(symbol, period string, candle *storage.Candle, rawChange float64) (Context, bool) {
+	ctx := Context{Symbol: symbol, Period: period, RawChange: rawChange}
+	if c == nil || c.candles == nil || candle == nil {
+		return ctx, false
+	}
+
+	bestR2 := -1.0
+	for _, benchmark := range c.benchmarks {
+		if benchmark == symbol {
+			continue
+		}
+		stats, ok := c.stats(symbol, benchmark, period)
+		if !ok {
+			continue
+		}
+		change, ok := c.benchmarkChange(benchmark, period, candle.StartTime)
+		if !ok {
+			continue
+		}
+
+		move := BenchmarkMove{
+			BenchmarkStats: stats,
+			Change:         round(change, 3),
+			ExpectedChange: round(stats.Beta*change, 3),
+		}
+		ctx.Benchmarks = append(ctx.Benchmarks, move)
+
+		if r2 := stats.Correlation * stats.Correlation; r2 > bestR2 {
+			bestR2 = r2
+			ctx.Benchmark = benchmark
+			ctx.BenchmarkChange = move.Change
+			ctx.Beta = stats.Beta
+			ctx.Correlation = stats.Correlation
+			ctx.ExpectedChange = move.ExpectedChange
+		}
+	}
+
+	if len(ctx.Benchmarks) == 0 {
+		return ctx, false
+	}
+
+	ctx.IdiosyncraticChange = round(rawChange-ctx.ExpectedChange, 3)
+	ctx.Explained = isExplained(rawChange, ctx.ExpectedChange, ctx.IdiosyncraticChange, ctx.Correlation)
+	return ctx, true
+}
+
+// isExplained — рынок объясняет движение, если ожидаемое движение в ту же сторону,
+// а собственная часть в направлении сигнала мала (или вовсе против него)
+func isExplained(raw, expected, idiosyncratic, correlation float64) bool {
+	if correlation < minCorrelation || raw == 0 || raw*expected <= 0 {
+		return false
+	}
+	sign := 1.0
+	if raw < 0 {
+		sign = -1.0
+	}
+	return idiosyncratic*sign <= explainedShare*math.Abs(raw)
+}
+
+// stats возвращает (или рассчитывает) бету и корреляцию пары символ/ориентир
+func (c *Calculator) stats(symbol, benchmark, period string) (BenchmarkStats, bool) {
+	key := symbol + ":" + benchmark + ":" + period
+
+	c.mu.RLock()
+	cached, found := c.cache[key]
+	c.mu.RUnlock()
+	if found && time.Now().Before(cached.expiresAt) {
+		return cached.stats, cached.ok
+	}
+
+	stats, ok := c.calculateStats(symbol, benchmark, period)
+
+	// Бета меняется медленно — пересчитываем не чаще раза за свечу (в пределах 1-15 минут)
+	ttl := periodPkg.PeriodToDuration(period)
+	if ttl < minStatsTTL {
+		ttl = minStatsTTL
+	} else if ttl > maxStatsTTL {
+		ttl = maxStatsTTL
+	}
+
+	c.mu.Lock()
+	c.cache[key] = cachedStats{stats: stats, ok: ok, expiresAt: time.Now().Add(ttl)}
+	c.mu.Unlock()
+
+	return stats, ok
+}
+
+// calculateStats считает бету и корреляцию по доходностям закрытых свечей, совпавших по времени
+func (c *Calculator) calculateStats(symbol, benchmark, period string) (BenchmarkStats, bool) {
+	stats := BenchmarkStats{Benchmark: benchmark}
+
+	symbolReturns, err := c.closedReturns(symbol, period)
+	if err != nil || len(symbolReturns) < minSamples {
+		return stats, false
+	}
+	benchmarkReturns, err := c.closedReturns(benchmark, period)
+	if err != nil || len(benchmarkReturns) < minSamples {
+		return stats, false
+	}
+
+	var xs, ys []float64
+	for start, y := range symbolReturns {
+		if x, ok := benchmarkReturns[start]; ok {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	if len(xs) < minSamples {
+		return stats, false
+	}
+
+	beta, correlation, ok := regress(xs, ys)
+	if !ok {
+		return stats, false
+	}
+
+	stats.Beta = round(beta, 3)
+	stats.Correlation = round(correlation, 3)
+	stats.Samples = len(xs)
+	return stats, true
+}
+
+// closedReturns возвращает изменение (%) закрытых свечей по времени начала
+func (c *Calculator) closedReturns(symbol, period string) (map[int64]float64, error) {
+	history, err := c.candles.GetHistory(symbol, period, c.window)
+	if err != nil {
+		return nil, err
+	}
+	returns := make(map[int64]float64, len(history))
+	for _, candle := range history {
+		if candle == nil || !candle.IsClosedFlag || !candle.IsRealFlag || candle.Open <= 0 {
+			continue
+		}
+		returns[candle.StartTime.Unix()] = (candle.Close - candle.Open) / candle.Open * 100
+	}
+	return returns, nil
+}
+
+// benchmarkChange возвращает изменение ориентира на свече с заданным временем начала
+func (c *Calculator) benchmarkChange(benchmark, period string, start time.Time) (float64, bool) {
+	// Активная свеча — для сигналов по незакрытым свечам
+	if active, err := c.candles.GetCandle(benchmark, period); err == nil && active != nil &&
+		active.StartTime.Equal(start) && active.Open > 0 {
+		return (active.Close - active.Open) / active.Open * 100, true
+	}
+
+	history, err := c.candles.GetHistory(benchmark, period, 5)
+	if err != nil {
+		return 0, false
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		candle := history[i]
+		if candle != nil && candle.StartTime.Equal(start) && candle.Open > 0 {
+			return (candle.Close - candle.Open) / candle.Open * 100, true
+		}
+	}
+	return 0, false
+}
+
+// regress возвращает бету (наклон y по x) и коэффициент корреляции Пирсона
+func regress(xs, ys []float64) (float64, float64, bool) {
+	n := float64(len(xs))
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, 0, false
+	}
+	return cov / varX, cov / math.Sqrt(varX*varY), true
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
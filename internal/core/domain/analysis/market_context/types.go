@@ -0,0 +1,61 @@
+// internal/core/domain/analysis/market_context/types.go
+package market_context
+
+// BenchmarkStats — скользящие бета и корреляция символа относительно ориентира
+type BenchmarkStats struct {
+	Benchmark   string
+	Beta        float64
+	Correlation float64
+	Samples     int // количество совпавших по времени свечей
+}
+
+// BenchmarkMove — движение ориентира на той же свече и ожидаемое движение символа
+type BenchmarkMove struct {
+	BenchmarkStats
+	Change         float64 // изменение ориентира, %
+	ExpectedChange float64 // Beta * Change, %
+}
+
+// Context — рыночный контекст сигнала
+type Context struct {
+	Symbol    string
+	Period    string
+	RawChange float64 // изменение символа, %
+
+	// Основной ориентир — с наибольшей корреляцией
+	Benchmark           string
+	BenchmarkChange     float64
+	Beta                float64
+	Correlation         float64
+	ExpectedChange      float64 // часть движения, объяснённая рынком
+	IdiosyncraticChange float64 // собственное движение: RawChange - ExpectedChange
+	Explained           bool    // движение полностью объясняется движением ориентира
+
+	Benchmarks []BenchmarkMove
+}
+
+// ToMap сериализует контекст для Metadata.Custom и данных события
+func (c Context) ToMap() map[string]interface{} {
+	benchmarks := make([]map[string]interface{}, 0, len(c.Benchmarks))
+	for _, b := range c.Benchmarks {
+		benchmarks = append(benchmarks, map[string]interface{}{
+			"benchmark":       b.Benchmark,
+			"beta":            b.Beta,
+			"correlation":     b.Correlation,
+			"samples":         b.Samples,
+			"change":          b.Change,
+			"expected_change": b.ExpectedChange,
+		})
+	}
+	return map[string]interface{}{
+		"benchmark":            c.Benchmark,
+		"benchmark_change":     c.BenchmarkChange,
+		"beta":                 c.Beta,
+		"correlation":          c.Correlation,
+		"raw_change":           c.RawChange,
+		"expected_change":      c.ExpectedChange,
+		"idiosyncratic_change": c.IdiosyncraticChange,
+		"explained":            c.Explained,
+		"benchmarks":           benchmarks,
+	}
+}
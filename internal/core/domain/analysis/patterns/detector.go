@@ -0,0 +1,242 @@
+// internal/core/domain/analysis/patterns/detector.go
+package patterns
+
+import (
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"math"
+	"time"
+)
+
+const (
+	defaultDojiBodyRatio   = 0.1 // тело доджи не больше 10% диапазона свечи
+	defaultShadowBodyRatio = 2.0 // тень молота/звезды минимум в 2 раза больше тела
+	defaultTrendLookback   = 5   // свечей для определения предшествующего тренда
+	defaultStarBodyRatio   = 0.3 // тело средней свечи звезды не больше 30% тела первой
+)
+
+// Config — параметры детектора свечных паттернов
+type Config struct {
+	DojiBodyRatio   float64
+	ShadowBodyRatio float64
+	// TrendLookback — разворотные паттерны (молот, поглощение, звезды)
+	// засчитываются только после движения в противоположную сторону
+	TrendLookback int
+	StarBodyRatio float64
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		DojiBodyRatio:   defaultDojiBodyRatio,
+		ShadowBodyRatio: defaultShadowBodyRatio,
+		TrendLookback:   defaultTrendLookback,
+		StarBodyRatio:   defaultStarBodyRatio,
+	}
+}
+
+// Detector распознает свечные паттерны по закрытым свечам
+type Detector struct {
+	config Config
+}
+
+// NewDetector создаёт детектор
+func NewDetector(config Config) *Detector {
+	defaults := DefaultConfig()
+	if config.DojiBodyRatio <= 0 {
+		config.DojiBodyRatio = defaults.DojiBodyRatio
+	}
+	if config.ShadowBodyRatio <= 0 {
+		config.ShadowBodyRatio = defaults.ShadowBodyRatio
+	}
+	if config.TrendLookback <= 0 {
+		config.TrendLookback = defaults.TrendLookback
+	}
+	if config.StarBodyRatio <= 0 {
+		config.StarBodyRatio = defaults.StarBodyRatio
+	}
+	return &Detector{config: config}
+}
+
+// MinCandles — сколько свечей нужно, чтобы проверить все паттерны с учётом тренда
+func (d *Detector) MinCandles() int {
+	return 3 + d.config.TrendLookback
+}
+
+// bar — OHLC одной свечи
+type bar struct {
+	open, high, low, close float64
+	start                  time.Time
+}
+
+func (b bar) body() float64    { return math.Abs(b.close - b.open) }
+func (b bar) span() float64    { return b.high - b.low }
+func (b bar) bodyTop() float64 { return math.Max(b.open, b.close) }
+func (b bar) bodyLow() float64 { return math.Min(b.open, b.close) }
+func (b bar) upper() float64   { return b.high - b.bodyTop() }
+func (b bar) lower() float64   { return b.bodyLow() - b.low }
+func (b bar) mid() float64     { return (b.open + b.close) / 2 }
+func (b bar) bullish() bool    { return b.close > b.open }
+func (b bar) bearish() bool    { return b.close < b.open }
+
+// Detect возвращает паттерны, завершившиеся на последней свече.
+// candles — только закрытые свечи в хронологическом порядке.
+func (d *Detector) Detect(symbol, period string, candles []storage.CandleInterface) []Match {
+	bars := make([]bar, 0, len(candles))
+	for _, c := range candles {
+		if c == nil || c.GetHigh() <= 0 || c.GetHigh() < c.GetLow() {
+			continue
+		}
+		bars = append(bars, bar{
+			open: c.GetOpen(), high: c.GetHigh(), low: c.GetLow(), close: c.GetClose(),
+			start: c.GetStartTime(),
+		})
+	}
+	if len(bars) < 2 {
+		return nil
+	}
+
+	n := len(bars)
+	last, prev := bars[n-1], bars[n-2]
+	var found []Match
+	add := func(name Name, count int) {
+		found = append(found, d.newMatch(symbol, period, name, bars[n-count:]))
+	}
+
+	// Одиночные свечи
+	if last.span() > 0 && last.body() <= d.config.DojiBodyRatio*last.span() {
+		add(Doji, 1)
+	} else if last.body() > 0 {
+		trend := d.trend(bars, n-2)
+		if trend < 0 && last.lower() >= d.config.ShadowBodyRatio*last.body() && last.upper() <= last.body() {
+			add(Hammer, 1)
+		}
+		if trend > 0 && last.upper() >= d.config.ShadowBodyRatio*last.body() && last.lower() <= last.body() {
+			add(ShootingStar, 1)
+		}
+	}
+
+	// Двухсвечные
+	engulfing := false
+	if prev.bearish() && last.bullish() && last.open <= prev.close && last.close >= prev.open &&
+		last.body() > prev.body() && d.trend(bars, n-2) < 0 {
+		add(BullishEngulfing, 2)
+		engulfing = true
+	}
+	if prev.bullish() && last.bearish() && last.open >= prev.close && last.close <= prev.open &&
+		last.body() > prev.body() && d.trend(bars, n-2) > 0 {
+		add(BearishEngulfing, 2)
+		engulfing = true
+	}
+	if last.high < prev.high && last.low > prev.low {
+		add(InsideBar, 2)
+	}
+	if !engulfing && last.high > prev.high && last.low < prev.low {
+		add(OutsideBar, 2)
+	}
+
+	if n < 3 {
+		return found
+	}
+
+	// Трехсвечные
+	first, middle := bars[n-3], bars[n-2]
+	avgBody := d.averageBody(bars, n-3)
+	smallMiddle := middle.body() <= d.config.StarBodyRatio*first.body()
+
+	if first.bearish() && first.body() >= avgBody && smallMiddle &&
+		middle.bodyTop() < first.mid() && last.bullish() && last.close > first.mid() &&
+		d.trend(bars, n-3) < 0 {
+		add(MorningStar, 3)
+	}
+	if first.bullish() && first.body() >= avgBody && smallMiddle &&
+		middle.bodyLow() > first.mid() && last.bearish() && last.close < first.mid() &&
+		d.trend(bars, n-3) > 0 {
+		add(EveningStar, 3)
+	}
+
+	if d.isThreeSoldiers(first, middle, last) {
+		add(ThreeWhiteSoldiers, 3)
+	}
+	if d.isThreeCrows(first, middle, last) {
+		add(ThreeBlackCrows, 3)
+	}
+
+	return found
+}
+
+// isThreeSoldiers — три растущие свечи с крупными телами, каждая открывается внутри тела предыдущей
+func (d *Detector) isThreeSoldiers(a, b, c bar) bool {
+	for _, x := range []bar{a, b, c} {
+		if !x.bullish() || x.body() < 0.5*x.span() {
+			return false
+		}
+	}
+	return b.close > a.close && c.close > b.close &&
+		b.open > a.open && b.open <= a.close &&
+		c.open > b.open && c.open <= b.close
+}
+
+// isThreeCrows — зеркальный вариант трех солдат
+func (d *Detector) isThreeCrows(a, b, c bar) bool {
+	for _, x := range []bar{a, b, c} {
+		if !x.bearish() || x.body() < 0.5*x.span() {
+			return false
+		}
+	}
+	return b.close < a.close && c.close < b.close &&
+		b.open < a.open && b.open >= a.close &&
+		c.open < b.open && c.open >= b.close
+}
+
+// trend возвращает -1/0/+1: направление движения за TrendLookback свечей до end (включительно)
+func (d *Detector) trend(bars []bar, end int) int {
+	start := end - d.config.TrendLookback
+	if start < 0 || end >= len(bars) {
+		return 0
+	}
+	from, to := bars[start].close, bars[end].close
+	switch {
+	case to < from:
+		return -1
+	case to > from:
+		return 1
+	}
+	return 0
+}
+
+// averageBody — средний размер тела за TrendLookback свечей до end (не включая)
+func (d *Detector) averageBody(bars []bar, end int) float64 {
+	start := end - d.config.TrendLookback
+	if start < 0 {
+		start = 0
+	}
+	if end <= start {
+		return 0
+	}
+	sum := 0.0
+	for _, b := range bars[start:end] {
+		sum += b.body()
+	}
+	return sum / float64(end-start)
+}
+
+func (d *Detector) newMatch(symbol, period string, name Name, bars []bar) Match {
+	high, low := bars[0].high, bars[0].low
+	for _, b := range bars[1:] {
+		high = math.Max(high, b.high)
+		low = math.Min(low, b.low)
+	}
+	last := bars[len(bars)-1]
+	return Match{
+		Symbol:     symbol,
+		Period:     period,
+		Name:       name,
+		Bias:       name.Bias(),
+		Candles:    len(bars),
+		CandleTime: last.start,
+		High:       high,
+		Low:        low,
+		Close:      last.close,
+		DetectedAt: time.Now(),
+	}
+}
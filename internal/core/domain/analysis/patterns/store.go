@@ -0,0 +1,84 @@
+// internal/core/domain/analysis/patterns/store.go
+package patterns
+
+import (
+	"sync"
+	"time"
+)
+
+// Store хранит паттерны последней закрытой свечи по symbol+period в памяти.
+// Используется CounterAnalyzer, чтобы добавлять паттерны в метаданные своих сигналов.
+type Store struct {
+	mu      sync.RWMutex
+	entries map[string]storeEntry
+}
+
+type storeEntry struct {
+	matches   []Match
+	expiresAt time.Time
+}
+
+// NewStore создаёт хранилище
+func NewStore() *Store {
+	return &Store{
+		entries: make(map[string]storeEntry),
+	}
+}
+
+// Set заменяет набор паттернов для symbol+period.
+// validFor — сколько набор считается актуальным (обычно до закрытия следующей свечи).
+func (s *Store) Set(symbol, period string, matches []Match, validFor time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(matches) == 0 {
+		delete(s.entries, storeKey(symbol, period))
+		return
+	}
+	s.entries[storeKey(symbol, period)] = storeEntry{
+		matches:   append([]Match(nil), matches...),
+		expiresAt: time.Now().Add(validFor),
+	}
+}
+
+// Get возвращает актуальные паттерны для symbol+period
+func (s *Store) Get(symbol, period string) []Match {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[storeKey(symbol, period)]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return append([]Match(nil), entry.matches...)
+}
+
+// Names возвращает имена актуальных паттернов
+func (s *Store) Names(symbol, period string) []string {
+	matches := s.Get(symbol, period)
+	if len(matches) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, string(m.Name))
+	}
+	return names
+}
+
+// Tags возвращает структурированные теги актуальных паттернов
+func (s *Store) Tags(symbol, period string) []string {
+	matches := s.Get(symbol, period)
+	if len(matches) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(matches))
+	for _, m := range matches {
+		tags = append(tags, m.Tag())
+	}
+	return tags
+}
+
+func storeKey(symbol, period string) string {
+	return symbol + ":" + period
+}
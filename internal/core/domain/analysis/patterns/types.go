@@ -0,0 +1,97 @@
+// internal/core/domain/analysis/patterns/types.go
+package patterns
+
+import (
+	"fmt"
+	"time"
+)
+
+// Name — свечной паттерн
+type Name string
+
+const (
+	BullishEngulfing   Name = "bullish_engulfing"
+	BearishEngulfing   Name = "bearish_engulfing"
+	Hammer             Name = "hammer"
+	ShootingStar       Name = "shooting_star"
+	Doji               Name = "doji"
+	MorningStar        Name = "morning_star"
+	EveningStar        Name = "evening_star"
+	ThreeWhiteSoldiers Name = "three_white_soldiers"
+	ThreeBlackCrows    Name = "three_black_crows"
+	InsideBar          Name = "inside_bar"
+	OutsideBar         Name = "outside_bar"
+)
+
+// Bias — направление, которое подсказывает паттерн
+type Bias string
+
+const (
+	BiasBullish Bias = "bullish"
+	BiasBearish Bias = "bearish"
+	BiasNeutral Bias = "neutral"
+)
+
+// titles — названия паттернов для уведомлений
+var titles = map[Name]string{
+	BullishEngulfing:   "Бычье поглощение",
+	BearishEngulfing:   "Медвежье поглощение",
+	Hammer:             "Молот",
+	ShootingStar:       "Падающая звезда",
+	Doji:               "Доджи",
+	MorningStar:        "Утренняя звезда",
+	EveningStar:        "Вечерняя звезда",
+	ThreeWhiteSoldiers: "Три белых солдата",
+	ThreeBlackCrows:    "Три черных вороны",
+	InsideBar:          "Внутренний бар",
+	OutsideBar:         "Внешний бар",
+}
+
+// Title возвращает название паттерна на русском
+func (n Name) Title() string {
+	if title, ok := titles[n]; ok {
+		return title
+	}
+	return string(n)
+}
+
+// Bias возвращает направление паттерна
+func (n Name) Bias() Bias {
+	switch n {
+	case BullishEngulfing, Hammer, MorningStar, ThreeWhiteSoldiers:
+		return BiasBullish
+	case BearishEngulfing, ShootingStar, EveningStar, ThreeBlackCrows:
+		return BiasBearish
+	}
+	return BiasNeutral
+}
+
+// Match — паттерн, завершившийся на последней закрытой свече
+type Match struct {
+	Symbol string `json:"symbol"`
+	Period string `json:"period"`
+	Name   Name   `json:"name"`
+	Bias   Bias   `json:"bias"`
+
+	// Candles — из скольких свечей состоит паттерн
+	Candles int `json:"candles"`
+	// CandleTime — время начала последней свечи паттерна
+	CandleTime time.Time `json:"candle_time"`
+
+	// Диапазон цен паттерна и закрытие последней свечи
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Tag возвращает структурированный тег вида "pattern:hammer"
+func (m Match) Tag() string {
+	return fmt.Sprintf("pattern:%s", m.Name)
+}
+
+// Key уникально идентифицирует паттерн на конкретной свече
+func (m Match) Key() string {
+	return fmt.Sprintf("%s:%s:%s:%d", m.Symbol, m.Period, m.Name, m.CandleTime.Unix())
+}
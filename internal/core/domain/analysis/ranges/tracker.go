@@ -0,0 +1,227 @@
+// internal/core/domain/analysis/ranges/tracker.go
+package ranges
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxTrackedDays — сколько завершённых дней хранится для скользящих окон
+	maxTrackedDays = 90
+	// DefaultCooldown — повторный алерт по тому же горизонту и границе не раньше
+	DefaultCooldown = 4 * time.Hour
+)
+
+// symbolRange — дневные экстремумы символа
+type symbolRange struct {
+	days    []DailyBar // завершённые дни, от старых к новым
+	today   DailyBar
+	todayHi time.Time // время текущего максимума дня
+	todayLo time.Time // время текущего минимума дня
+
+	allHigh Extreme // экстремумы всей истории без текущего дня
+	allLow  Extreme
+
+	lastAlert map[string]time.Time // horizon:kind → время алерта
+}
+
+// Tracker ведёт скользящие максимумы и минимумы по горизонтам для каждого символа.
+// Окно горизонта N — последние N завершённых дней плюс текущий день.
+type Tracker struct {
+	cooldown    time.Duration
+	minDistance float64 // минимальный выход за экстремум, %
+
+	mu      sync.Mutex
+	symbols map[string]*symbolRange
+}
+
+// NewTracker создаёт трекер диапазонов. Пробой засчитывается, если цена ушла
+// за прежний экстремум не меньше чем на minDistancePct.
+func NewTracker(cooldown time.Duration, minDistancePct float64) *Tracker {
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+	return &Tracker{
+		cooldown:    cooldown,
+		minDistance: minDistancePct,
+		symbols:     make(map[string]*symbolRange),
+	}
+}
+
+// Seeded проверяет, загружена ли история символа
+func (t *Tracker) Seeded(symbol string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.symbols[symbol]
+	return ok
+}
+
+// Seed загружает дневную историю символа (от старых к новым).
+// Свеча текущего дня UTC становится незавершённым днём.
+func (t *Tracker) Seed(symbol string, bars []DailyBar, now time.Time) {
+	today := dayStart(now)
+	sr := &symbolRange{
+		today:     DailyBar{Start: today},
+		lastAlert: make(map[string]time.Time),
+	}
+	for _, bar := range bars {
+		if bar.High <= 0 || bar.Low <= 0 {
+			continue
+		}
+		bar.Start = dayStart(bar.Start)
+		if !bar.Start.Before(today) {
+			sr.today = bar
+			sr.todayHi, sr.todayLo = bar.Start, bar.Start
+			continue
+		}
+		sr.closeDay(bar)
+	}
+
+	t.mu.Lock()
+	t.symbols[symbol] = sr
+	t.mu.Unlock()
+}
+
+// Observe применяет цену и возвращает пробои по всем горизонтам, где цена
+// вышла за прежний экстремум (горизонты с действующим cooldown пропускаются)
+func (t *Tracker) Observe(symbol string, price float64, at time.Time) []Breakout {
+	if price <= 0 {
+		return nil
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sr, ok := t.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	sr.roll(at)
+
+	var result []Breakout
+	for _, h := range Horizons {
+		high, low, ok := sr.extremes(h)
+		if !ok {
+			continue
+		}
+		if price > high.Price*(1+t.minDistance/100) {
+			if b, ok := t.breakout(sr, symbol, h, KindHigh, price, high, at); ok {
+				result = append(result, b)
+			}
+		} else if price < low.Price*(1-t.minDistance/100) {
+			if b, ok := t.breakout(sr, symbol, h, KindLow, price, low, at); ok {
+				result = append(result, b)
+			}
+		}
+	}
+	sr.update(price, at)
+	return result
+}
+
+// Levels возвращает текущие границы горизонта
+func (t *Tracker) Levels(symbol string, h Horizon) (high, low Extreme, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sr, exists := t.symbols[symbol]
+	if !exists {
+		return Extreme{}, Extreme{}, false
+	}
+	return sr.extremes(h)
+}
+
+func (t *Tracker) breakout(sr *symbolRange, symbol string, h Horizon, kind Kind, price float64, prev Extreme, at time.Time) (Breakout, bool) {
+	key := string(h) + ":" + string(kind)
+	if last, ok := sr.lastAlert[key]; ok && at.Sub(last) < t.cooldown {
+		return Breakout{}, false
+	}
+	sr.lastAlert[key] = at
+
+	return Breakout{
+		Symbol:      symbol,
+		Horizon:     h,
+		Kind:        kind,
+		Price:       price,
+		Previous:    prev,
+		DistancePct: (price - prev.Price) / prev.Price * 100,
+		Time:        at,
+	}, true
+}
+
+// roll закрывает текущий день, если наступили новые сутки UTC
+func (sr *symbolRange) roll(at time.Time) {
+	day := dayStart(at)
+	if !day.After(sr.today.Start) {
+		return
+	}
+	if sr.today.High > 0 {
+		sr.closeDay(sr.today)
+	}
+	sr.today = DailyBar{Start: day}
+	sr.todayHi, sr.todayLo = time.Time{}, time.Time{}
+}
+
+// closeDay добавляет завершённый день в окно и в экстремумы всей истории
+func (sr *symbolRange) closeDay(bar DailyBar) {
+	sr.days = append(sr.days, bar)
+	if len(sr.days) > maxTrackedDays {
+		sr.days = sr.days[len(sr.days)-maxTrackedDays:]
+	}
+	if bar.High > sr.allHigh.Price {
+		sr.allHigh = Extreme{Price: bar.High, Time: bar.Start}
+	}
+	if sr.allLow.Price == 0 || bar.Low < sr.allLow.Price {
+		sr.allLow = Extreme{Price: bar.Low, Time: bar.Start}
+	}
+}
+
+// update обновляет экстремумы текущего дня
+func (sr *symbolRange) update(price float64, at time.Time) {
+	if price > sr.today.High {
+		sr.today.High = price
+		sr.todayHi = at
+	}
+	if sr.today.Low == 0 || price < sr.today.Low {
+		sr.today.Low = price
+		sr.todayLo = at
+	}
+}
+
+// extremes — границы горизонта до текущей цены; ok=false, если истории не хватает
+func (sr *symbolRange) extremes(h Horizon) (high, low Extreme, ok bool) {
+	if h == HorizonAll {
+		high, low = sr.allHigh, sr.allLow
+		if high.Price == 0 {
+			return Extreme{}, Extreme{}, false
+		}
+	} else {
+		n := h.Days()
+		if len(sr.days) < n {
+			return Extreme{}, Extreme{}, false
+		}
+		for _, bar := range sr.days[len(sr.days)-n:] {
+			if bar.High > high.Price {
+				high = Extreme{Price: bar.High, Time: bar.Start}
+			}
+			if low.Price == 0 || bar.Low < low.Price {
+				low = Extreme{Price: bar.Low, Time: bar.Start}
+			}
+		}
+	}
+
+	if sr.today.High > high.Price {
+		high = Extreme{Price: sr.today.High, Time: sr.todayHi}
+	}
+	if sr.today.Low > 0 && sr.today.Low < low.Price {
+		low = Extreme{Price: sr.today.Low, Time: sr.todayLo}
+	}
+	return high, low, true
+}
+
+func dayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,127 @@
+// internal/core/domain/analysis/ranges/types.go
+package ranges
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Horizon — окно, в котором отслеживаются максимум и минимум
+type Horizon string
+
+const (
+	Horizon1d  Horizon = "1d"
+	Horizon7d  Horizon = "7d"
+	Horizon30d Horizon = "30d"
+	Horizon90d Horizon = "90d"
+	// HorizonAll — весь диапазон загруженной истории (до 1000 дневных свечей)
+	HorizonAll Horizon = "all"
+)
+
+// Horizons — все горизонты от короткого к длинному
+var Horizons = []Horizon{Horizon1d, Horizon7d, Horizon30d, Horizon90d, HorizonAll}
+
+// Days возвращает длину горизонта в завершённых днях (0 — вся история)
+func (h Horizon) Days() int {
+	switch h {
+	case Horizon1d:
+		return 1
+	case Horizon7d:
+		return 7
+	case Horizon30d:
+		return 30
+	case Horizon90d:
+		return 90
+	}
+	return 0
+}
+
+// Label возвращает название горизонта для сообщений
+func (h Horizon) Label() string {
+	switch h {
+	case Horizon1d:
+		return "1 день"
+	case Horizon7d:
+		return "7 дней"
+	case Horizon30d:
+		return "30 дней"
+	case Horizon90d:
+		return "90 дней"
+	case HorizonAll:
+		return "вся история"
+	}
+	return string(h)
+}
+
+// Rank — порядковый номер горизонта (чем длиннее, тем больше)
+func (h Horizon) Rank() int {
+	for i, known := range Horizons {
+		if known == h {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValidHorizon проверяет, известен ли горизонт
+func IsValidHorizon(h string) bool {
+	return Horizon(h).Rank() >= 0
+}
+
+// ParseHorizons разбирает список горизонтов через запятую; неизвестные отбрасываются
+func ParseHorizons(s string) []Horizon {
+	var result []Horizon
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.ToLower(strings.TrimSpace(part)); IsValidHorizon(part) {
+			result = append(result, Horizon(part))
+		}
+	}
+	return result
+}
+
+// Kind — какая граница диапазона пробита
+type Kind string
+
+const (
+	KindHigh Kind = "high"
+	KindLow  Kind = "low"
+)
+
+// DailyBar — дневная свеча (только экстремумы)
+type DailyBar struct {
+	Start time.Time // начало дня UTC
+	High  float64
+	Low   float64
+}
+
+// Extreme — предыдущий экстремум диапазона
+type Extreme struct {
+	Price float64
+	Time  time.Time // для завершённых дней — начало дня
+}
+
+// Breakout — пробой границы диапазона на одном горизонте
+type Breakout struct {
+	Symbol      string
+	Horizon     Horizon
+	Kind        Kind
+	Price       float64
+	Previous    Extreme
+	DistancePct float64 // насколько цена ушла за предыдущий экстремум, %
+	Time        time.Time
+}
+
+// DaysSincePrevious — сколько дней назад был предыдущий экстремум
+func (b Breakout) DaysSincePrevious() int {
+	if b.Previous.Time.IsZero() {
+		return 0
+	}
+	return int(b.Time.Sub(b.Previous.Time).Hours() / 24)
+}
+
+// String — краткое описание для логов
+func (b Breakout) String() string {
+	return fmt.Sprintf("%s %s %s %.6g (пред. %.6g, %+.2f%%)",
+		b.Symbol, b.Horizon, b.Kind, b.Price, b.Previous.Price, b.DistancePct)
+}
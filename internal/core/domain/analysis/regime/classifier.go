@@ -0,0 +1,210 @@
+// internal/core/domain/analysis/regime/classifier.go
+package regime
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Source — текущий режим рынка для метаданных сигналов и порогов анализаторов
+type Source interface {
+	Current() string
+}
+
+// CandleSource — источник свечей (CandleSystem)
+type CandleSource interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+// Sources — ленивое получение зависимостей: CandleSystem и VWAP появляются после старта
+type Sources struct {
+	Candles func() CandleSource
+	Prices  func() storage.PriceStorageInterface
+	VWAP    func() *vwap.Tracker
+}
+
+// Classifier по расписанию классифицирует рынок по бенчмаркам и ширине рынка
+// и публикует EventMarketRegimeChanged при смене режима
+type Classifier struct {
+	cfg      Config
+	sources  Sources
+	eventBus types.EventBus
+
+	mu        sync.RWMutex
+	current   string
+	metrics   Metrics
+	candidate string
+	runs      int
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewClassifier создает классификатор режима рынка
+func NewClassifier(cfg Config, sources Sources, eventBus types.EventBus) *Classifier {
+	defaults := DefaultConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	if len(cfg.Benchmarks) == 0 {
+		cfg.Benchmarks = defaults.Benchmarks
+	}
+	if cfg.TrendChangePct <= 0 {
+		cfg.TrendChangePct = defaults.TrendChangePct
+	}
+	if cfg.BreadthUp <= 0 {
+		cfg.BreadthUp = defaults.BreadthUp
+	}
+	if cfg.BreadthDown <= 0 {
+		cfg.BreadthDown = defaults.BreadthDown
+	}
+	if cfg.PanicVolatility <= 0 {
+		cfg.PanicVolatility = defaults.PanicVolatility
+	}
+	if cfg.PanicDeclineShare <= 0 {
+		cfg.PanicDeclineShare = defaults.PanicDeclineShare
+	}
+	if cfg.ConfirmRuns <= 0 {
+		cfg.ConfirmRuns = defaults.ConfirmRuns
+	}
+
+	return &Classifier{
+		cfg:      cfg,
+		sources:  sources,
+		eventBus: eventBus,
+		current:  Unknown,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Config возвращает действующие настройки
+func (c *Classifier) Config() Config {
+	return c.cfg
+}
+
+// Current возвращает текущий режим рынка (unknown — еще не определен)
+func (c *Classifier) Current() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+// Snapshot возвращает текущий режим и метрики последнего расчета
+func (c *Classifier) Snapshot() (string, Metrics) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current, c.metrics
+}
+
+// Start запускает периодическую классификацию; первый расчет — сразу
+// (если свечи еще не загружены, режим остается unknown до следующего)
+func (c *Classifier) Start() {
+	c.wg.Add(1)
+	go c.run()
+	logger.Info("✅ [Regime] Классификатор режима рынка запущен (бенчмарки: %v, интервал: %v)",
+		c.cfg.Benchmarks, c.cfg.Interval)
+}
+
+// Stop останавливает классификацию; повторный вызов ничего не делает
+func (c *Classifier) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+		c.wg.Wait()
+	})
+}
+
+func (c *Classifier) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	c.Update()
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.Update()
+		}
+	}
+}
+
+// Update пересчитывает метрики и при подтвержденной смене режима публикует событие
+func (c *Classifier) Update() {
+	metrics, ok := c.measure()
+	if !ok {
+		logger.Debug("⚠️ [Regime] Недостаточно данных для классификации режима")
+		return
+	}
+	next := Classify(metrics, c.cfg)
+
+	c.mu.Lock()
+	c.metrics = metrics
+	previous := c.current
+	changed := c.accept(next)
+	c.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	logger.Info("🧭 [Regime] Режим рынка: %s → %s (бенчмарки %+.2f%%, волатильность x%.2f, выше VWAP %.0f%%, растут %.0f%%)",
+		previous, next, metrics.BenchmarkChange, metrics.VolatilityRatio,
+		metrics.AboveVWAPShare*100, metrics.AdvanceShare*100)
+
+	if c.eventBus == nil {
+		return
+	}
+	if err := c.eventBus.Publish(types.Event{
+		Type:   types.EventMarketRegimeChanged,
+		Source: "market_regime",
+		Data: types.MarketRegimeData{
+			Regime:          next,
+			Previous:        previous,
+			BenchmarkChange: metrics.BenchmarkChange,
+			VolatilityRatio: metrics.VolatilityRatio,
+			AboveVWAPShare:  metrics.AboveVWAPShare,
+			AdvanceShare:    metrics.AdvanceShare,
+			Symbols:         metrics.Symbols,
+			Timestamp:       metrics.MeasuredAt,
+		},
+		Timestamp: time.Now(),
+	}); err != nil {
+		logger.Error("❌ [Regime] Ошибка публикации смены режима: %v", err)
+	}
+}
+
+func (c *Classifier) vwapTracker() *vwap.Tracker {
+	if c.sources.VWAP == nil {
+		return nil
+	}
+	return c.sources.VWAP()
+}
+
+// accept применяет гистерезис: новый режим должен продержаться ConfirmRuns расчетов.
+// Первая классификация и паника применяются сразу. Вызывается под c.mu.
+func (c *Classifier) accept(next string) bool {
+	if next == c.current {
+		c.candidate, c.runs = "", 0
+		return false
+	}
+
+	if next == c.candidate {
+		c.runs++
+	} else {
+		c.candidate, c.runs = next, 1
+	}
+	if c.current != Unknown && next != Panic && c.runs < c.cfg.ConfirmRuns {
+		return false
+	}
+
+	c.current = next
+	c.candidate, c.runs = "", 0
+	return true
+}
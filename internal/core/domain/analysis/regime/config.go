@@ -0,0 +1,158 @@
+// internal/core/domain/analysis/regime/config.go
+package regime
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Режимы рынка
+const (
+	TrendUp   = "trend_up"
+	TrendDown = "trend_down"
+	Range     = "range"
+	Panic     = "panic"
+	Unknown   = "unknown"
+)
+
+// Regimes — режимы в порядке отображения
+var Regimes = []string{TrendUp, TrendDown, Range, Panic}
+
+// Titles — названия режимов для сообщений
+var Titles = map[string]string{
+	TrendUp:   "📈 Восходящий тренд",
+	TrendDown: "📉 Нисходящий тренд",
+	Range:     "↔️ Боковик",
+	Panic:     "🔥 Паника",
+	Unknown:   "❔ Не определен",
+}
+
+// IsValid проверяет, что режим известен
+func IsValid(regime string) bool {
+	for _, r := range Regimes {
+		if r == regime {
+			return true
+		}
+	}
+	return false
+}
+
+// Title возвращает название режима
+func Title(regime string) string {
+	if title, ok := Titles[regime]; ok {
+		return title
+	}
+	return Titles[Unknown]
+}
+
+// Config настройки классификатора режима рынка
+type Config struct {
+	// Interval — как часто пересчитывать режим
+	Interval time.Duration
+	// Benchmarks — символы, по которым определяется тренд и волатильность
+	Benchmarks []string
+	// TrendChangePct — суточное изменение бенчмарков, с которого рынок считается трендовым, %
+	TrendChangePct float64
+	// BreadthUp / BreadthDown — доля символов выше суточного VWAP, подтверждающая тренд
+	BreadthUp   float64
+	BreadthDown float64
+	// PanicVolatility — во сколько раз суточная волатильность бенчмарков выше базовой для паники
+	PanicVolatility float64
+	// PanicDeclineShare — доля падающих за 24ч символов для паники
+	PanicDeclineShare float64
+	// MinVolumeUSD — минимальный суточный оборот символа для расчета ширины рынка
+	MinVolumeUSD float64
+	// ConfirmRuns — сколько расчетов подряд новый режим должен держаться до смены (паника — сразу)
+	ConfirmRuns int
+}
+
+// DefaultConfig возвращает настройки по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Interval:          5 * time.Minute,
+		Benchmarks:        []string{"BTCUSDT", "ETHUSDT"},
+		TrendChangePct:    2,
+		BreadthUp:         0.55,
+		BreadthDown:       0.45,
+		PanicVolatility:   2.5,
+		PanicDeclineShare: 0.75,
+		MinVolumeUSD:      1_000_000,
+		ConfirmRuns:       2,
+	}
+}
+
+// ParseSymbols разбирает список символов "BTCUSDT,ETHUSDT"
+func ParseSymbols(raw string) []string {
+	var symbols []string
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			symbols = append(symbols, s)
+		}
+	}
+	return symbols
+}
+
+// Factors — множители порогов по режимам рынка: "panic=2,range=1.3".
+// Режим без множителя использует исходный порог (1).
+type Factors map[string]float64
+
+// ParseFactors разбирает множители порогов; пустая строка — без множителей
+func ParseFactors(raw string) (Factors, error) {
+	factors := make(Factors)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || !IsValid(name) {
+			return nil, fmt.Errorf("неизвестный режим %q (доступны: %s)", name, strings.Join(Regimes, ", "))
+		}
+		factor, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || factor <= 0 || factor > 10 {
+			return nil, fmt.Errorf("множитель для %s должен быть числом от 0 до 10", name)
+		}
+		factors[name] = factor
+	}
+	return factors, nil
+}
+
+// For возвращает множитель порога для режима (1 — без изменений)
+func (f Factors) For(regime string) float64 {
+	if factor, ok := f[regime]; ok && factor > 0 {
+		return factor
+	}
+	return 1
+}
+
+// String возвращает множители в формате конфигурации
+func (f Factors) String() string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+strconv.FormatFloat(f[k], 'f', -1, 64))
+	}
+	return strings.Join(parts, ",")
+}
+
+// FactorFor разбирает множители и возвращает множитель для режима;
+// некорректная строка не меняет порог
+func FactorFor(raw, regime string) float64 {
+	if raw == "" || regime == "" {
+		return 1
+	}
+	factors, err := ParseFactors(raw)
+	if err != nil {
+		return 1
+	}
+	return factors.For(regime)
+}
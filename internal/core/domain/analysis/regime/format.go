@@ -0,0 +1,48 @@
+// internal/core/domain/analysis/regime/format.go
+package regime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatStatus — текущий режим и метрики последнего расчета (без разметки и «_»:
+// текст отправляется и в Markdown-сообщениях)
+func FormatStatus(current string, m Metrics) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("🧭 Режим рынка: %s\n", Title(current)))
+	if m.MeasuredAt.IsZero() {
+		b.WriteString("Метрики еще не рассчитаны: свечи бенчмарков загружаются")
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("\nБенчмарки за сутки: %+.2f%%\n", m.BenchmarkChange))
+	b.WriteString(fmt.Sprintf("Волатильность к недельной: x%.2f\n", m.VolatilityRatio))
+	if m.Symbols > 0 {
+		b.WriteString(fmt.Sprintf("Выше суточного VWAP: %.0f%%\n", m.AboveVWAPShare*100))
+		b.WriteString(fmt.Sprintf("Растут за 24ч: %.0f%% из %d\n", m.AdvanceShare*100, m.Symbols))
+	}
+	b.WriteString(fmt.Sprintf("\n🕐 %s UTC", m.MeasuredAt.UTC().Format("15:04")))
+	return b.String()
+}
+
+// FormatFactors — множители порогов пользователя построчно ("" — если не заданы)
+func FormatFactors(raw string) string {
+	factors, err := ParseFactors(raw)
+	if err != nil || len(factors) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, r := range Regimes {
+		if factor, ok := factors[r]; ok {
+			b.WriteString(fmt.Sprintf("%s: порог ×%g\n", Title(r), factor))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// NormalizeFactorsArg приводит аргумент команды к формату настройки:
+// "panic=2 range=1.2" → "panic=2,range=1.2"
+func NormalizeFactorsArg(arg string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(arg, ",", " ")), ",")
+}
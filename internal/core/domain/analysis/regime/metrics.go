@@ -0,0 +1,170 @@
+// internal/core/domain/analysis/regime/metrics.go
+package regime
+
+import (
+	"math"
+	"time"
+)
+
+// Свечи бенчмарков: сутки по 1h для изменения и волатильности,
+// неделя — для базовой волатильности
+const (
+	candlePeriod    = "1h"
+	dayCandles      = 24
+	baselineCandles = 168
+)
+
+// Metrics — показатели рынка, по которым определяется режим
+type Metrics struct {
+	BenchmarkChange float64 // средняя доходность бенчмарков за сутки, %
+	VolatilityRatio float64 // средний модуль часовой доходности за сутки к недельному (1 — обычная волатильность)
+	AboveVWAPShare  float64 // доля символов выше суточного VWAP, 0..1
+	AdvanceShare    float64 // доля растущих за 24ч символов, 0..1
+	Symbols         int     // символов в расчете ширины рынка (0 — ширина неизвестна)
+	MeasuredAt      time.Time
+}
+
+// Classify определяет режим по метрикам. Ширина рынка подтверждает тренд;
+// если она неизвестна, режим определяется только по бенчмаркам.
+func Classify(m Metrics, cfg Config) string {
+	hasBreadth := m.Symbols > 0
+
+	if m.VolatilityRatio >= cfg.PanicVolatility && m.BenchmarkChange < 0 &&
+		(!hasBreadth || 1-m.AdvanceShare >= cfg.PanicDeclineShare) {
+		return Panic
+	}
+	if m.BenchmarkChange >= cfg.TrendChangePct && (!hasBreadth || m.AboveVWAPShare >= cfg.BreadthUp) {
+		return TrendUp
+	}
+	if m.BenchmarkChange <= -cfg.TrendChangePct && (!hasBreadth || m.AboveVWAPShare <= cfg.BreadthDown) {
+		return TrendDown
+	}
+	return Range
+}
+
+// measure считает метрики; false — бенчмарки еще без свечей
+func (c *Classifier) measure() (Metrics, bool) {
+	var candles CandleSource
+	if c.sources.Candles != nil {
+		candles = c.sources.Candles()
+	}
+	if candles == nil {
+		return Metrics{}, false
+	}
+
+	var changeSum, ratioSum float64
+	var count int
+	for _, symbol := range c.cfg.Benchmarks {
+		change, ratio, ok := benchmarkMetrics(candles, symbol)
+		if !ok {
+			continue
+		}
+		changeSum += change
+		ratioSum += ratio
+		count++
+	}
+	if count == 0 {
+		return Metrics{}, false
+	}
+
+	m := Metrics{
+		BenchmarkChange: changeSum / float64(count),
+		VolatilityRatio: ratioSum / float64(count),
+		MeasuredAt:      time.Now(),
+	}
+	c.measureBreadth(&m)
+	return m, true
+}
+
+// benchmarkMetrics — изменение символа за сутки (%) и отношение суточной волатильности к недельной
+func benchmarkMetrics(candles CandleSource, symbol string) (change, ratio float64, ok bool) {
+	history, err := candles.GetHistory(symbol, candlePeriod, baselineCandles+1)
+	if err != nil || len(history) < dayCandles+1 {
+		return 0, 0, false
+	}
+
+	returns := make([]float64, 0, len(history)-1)
+	for i := 1; i < len(history); i++ {
+		prev, cur := history[i-1], history[i]
+		if prev == nil || cur == nil || prev.Close <= 0 || cur.Close <= 0 {
+			continue
+		}
+		returns = append(returns, math.Abs(cur.Close-prev.Close)/prev.Close)
+	}
+	if len(returns) < dayCandles {
+		return 0, 0, false
+	}
+
+	first, last := history[len(history)-1-dayCandles], history[len(history)-1]
+	if first == nil || last == nil || first.Close <= 0 || last.Close <= 0 {
+		return 0, 0, false
+	}
+	change = (last.Close - first.Close) / first.Close * 100
+
+	recent, baseline := mean(returns[len(returns)-dayCandles:]), mean(returns)
+	ratio = 1
+	if baseline > 0 {
+		ratio = recent / baseline
+	}
+	return change, ratio, true
+}
+
+// measureBreadth — доля символов выше суточного VWAP и доля растущих за 24ч
+func (c *Classifier) measureBreadth(m *Metrics) {
+	if c.sources.Prices == nil {
+		return
+	}
+	prices := c.sources.Prices()
+	if prices == nil {
+		return
+	}
+	tracker := c.vwapTracker()
+
+	anchor := m.MeasuredAt.Add(-24 * time.Hour)
+	var advancing, aboveVWAP, withVWAP int
+	for symbol, snapshot := range prices.GetAllCurrentPrices() {
+		if snapshot == nil || snapshot.GetPrice() <= 0 || snapshot.GetVolumeUSD() < c.cfg.MinVolumeUSD {
+			continue
+		}
+		m.Symbols++
+		if snapshot.GetChange24h() > 0 {
+			advancing++
+		}
+
+		if tracker == nil {
+			continue
+		}
+		snap, ok := tracker.Anchored(symbol, anchor)
+		if !ok {
+			snap, ok = tracker.Session(symbol)
+		}
+		if !ok {
+			continue
+		}
+		withVWAP++
+		if snapshot.GetPrice() > snap.VWAP {
+			aboveVWAP++
+		}
+	}
+
+	if m.Symbols == 0 {
+		return
+	}
+	m.AdvanceShare = float64(advancing) / float64(m.Symbols)
+	// Без VWAP ширина по VWAP приближается долей растущих
+	m.AboveVWAPShare = m.AdvanceShare
+	if withVWAP > 0 {
+		m.AboveVWAPShare = float64(aboveVWAP) / float64(withVWAP)
+	}
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -29,6 +29,12 @@ type Volume24hProvider interface {
 	GetVolume24hUSD(symbol string) float64
 }
 
+// WallSource — источник стабильных стен с историей (WallTracker).
+// ok=false — истории по символу нет, зоны обогащаются разовым снимком стакана.
+type WallSource interface {
+	StableWalls(symbol string) (walls []sr_zones.WallLevel, ok bool)
+}
+
 // Engine — движок расчёта S/R зон.
 // Подписывается на EventCandleClosed и пересчитывает зоны при каждом закрытии свечи.
 type Engine struct {
@@ -38,6 +44,7 @@ type Engine struct {
 	volume24h      Volume24hProvider
 	eventBus       *event_bus.EventBus
 	calculator     *sr_zones.Calculator
+	walls          WallSource // опционально: только стабильные стены
 
 	// Кэш стакана: symbol → (book, expiry)
 	obCacheMu sync.RWMutex
@@ -87,6 +94,13 @@ func NewEngine(
 	}
 }
 
+// SetWallSource подключает источник стабильных стен.
+// Для отслеживаемых символов зоны обогащаются только стенами,
+// которые стоят достаточно долго и не снимаются до исполнения.
+func (e *Engine) SetWallSource(src WallSource) {
+	e.walls = src
+}
+
 // Start запускает движок — подписывается на EventCandleClosed.
 func (e *Engine) Start() {
 	e.subscriber = event_bus.NewBaseSubscriber(
@@ -175,12 +189,12 @@ func (e *Engine) recalculate(symbol, period string) {
 		return
 	}
 
-	// Получаем стакан (с кэшем)
-	book := e.getOrderBookCached(symbol)
-
-	// Конвертируем bybit.OrderBookV5 → *sr_zones.OrderBook и обогащаем зоны
-	if book != nil {
-		srBook := convertOrderBook(book)
+	// Стены с историей: спуф-стены, снятые до исполнения, не усиливают зоны
+	if stable, ok := e.stableWalls(symbol); ok {
+		zones = sr_zones.EnrichWithWalls(zones, stable)
+	} else if book := e.getOrderBookCached(symbol); book != nil {
+		// Конвертируем bybit.OrderBookV5 → *sr_zones.OrderBook и обогащаем зоны
+		srBook := sr_zones.OrderBookFromBybit(book)
 		vol24h := e.volume24h.GetVolume24hUSD(symbol)
 		zones = sr_zones.EnrichWithOrderBook(zones, srBook, vol24h)
 	}
@@ -193,6 +207,14 @@ func (e *Engine) recalculate(symbol, period string) {
 	logger.Debug("📐 SRZoneEngine: %s/%s → %d зон сохранено", symbol, period, len(zones))
 }
 
+// stableWalls возвращает стабильные стены из WallSource, если он подключён
+func (e *Engine) stableWalls(symbol string) ([]sr_zones.WallLevel, bool) {
+	if e.walls == nil {
+		return nil, false
+	}
+	return e.walls.StableWalls(symbol)
+}
+
 // getOrderBookCached возвращает стакан из кэша или запрашивает у биржи.
 func (e *Engine) getOrderBookCached(symbol string) *bybit.OrderBookV5 {
 	e.obCacheMu.RLock()
@@ -214,15 +236,3 @@ func (e *Engine) getOrderBookCached(symbol string) *bybit.OrderBookV5 {
 
 	return book
 }
-
-// convertOrderBook конвертирует bybit.OrderBookV5 в sr_zones.OrderBook.
-func convertOrderBook(b *bybit.OrderBookV5) *sr_zones.OrderBook {
-	book := &sr_zones.OrderBook{Symbol: b.Symbol}
-	for _, l := range b.Bids {
-		book.Bids = append(book.Bids, sr_zones.OrderLevel{Price: l.Price, Size: l.Size})
-	}
-	for _, l := range b.Asks {
-		book.Asks = append(book.Asks, sr_zones.OrderLevel{Price: l.Price, Size: l.Size})
-	}
-	return book
-}
@@ -111,52 +111,24 @@ type pivotPoint struct {
 
 // findPivotHighs ищет локальные максимумы.
 func (c *Calculator) findPivotHighs(candles []storage.CandleInterface) []pivotPoint {
-	var pivots []pivotPoint
-	n := len(candles)
-	lb := c.lookback
-
-	for i := lb; i < n-lb; i++ {
-		high := candles[i].GetHigh()
-		isPivot := true
-		for j := 1; j <= lb; j++ {
-			if candles[i-j].GetHigh() >= high || candles[i+j].GetHigh() >= high {
-				isPivot = false
-				break
-			}
-		}
-		if isPivot {
-			pivots = append(pivots, pivotPoint{
-				price:     high,
-				volume:    candles[i].GetVolumeUSD(),
-				touchTime: candles[i].GetStartTime(),
-			})
-		}
-	}
-	return pivots
+	return c.toPivotPoints(candles, PivotHighIndexes(candles, c.lookback), storage.CandleInterface.GetHigh)
 }
 
 // findPivotLows ищет локальные минимумы.
 func (c *Calculator) findPivotLows(candles []storage.CandleInterface) []pivotPoint {
-	var pivots []pivotPoint
-	n := len(candles)
-	lb := c.lookback
-
-	for i := lb; i < n-lb; i++ {
-		low := candles[i].GetLow()
-		isPivot := true
-		for j := 1; j <= lb; j++ {
-			if candles[i-j].GetLow() <= low || candles[i+j].GetLow() <= low {
-				isPivot = false
-				break
-			}
-		}
-		if isPivot {
-			pivots = append(pivots, pivotPoint{
-				price:     low,
-				volume:    candles[i].GetVolumeUSD(),
-				touchTime: candles[i].GetStartTime(),
-			})
-		}
+	return c.toPivotPoints(candles, PivotLowIndexes(candles, c.lookback), storage.CandleInterface.GetLow)
+}
+
+// toPivotPoints превращает индексы pivot-свечей в pivot-точки с ценой price(candle).
+func (c *Calculator) toPivotPoints(candles []storage.CandleInterface, indexes []int,
+	price func(storage.CandleInterface) float64) []pivotPoint {
+	pivots := make([]pivotPoint, 0, len(indexes))
+	for _, i := range indexes {
+		pivots = append(pivots, pivotPoint{
+			price:     price(candles[i]),
+			volume:    candles[i].GetVolumeUSD(),
+			touchTime: candles[i].GetStartTime(),
+		})
 	}
 	return pivots
 }
@@ -442,7 +414,14 @@ func computeWallThreshold(bucketMean, bucketStd, volume24hUSD float64) float64 {
 	return math.Max(relative, math.Max(statistical, dynamic))
 }
 
-// EnrichWithOrderBook обогащает зоны данными стакана ордеров.
+// EnrichWithOrderBook обогащает зоны данными стакана ордеров по одному снимку.
+// Используется, когда истории стен по символу нет (символ не отслеживается
+// трекером стен); иначе зоны обогащаются через EnrichWithWalls только устойчивыми стенами.
+func EnrichWithOrderBook(zones []Zone, book *OrderBook, volume24hUSD float64) []Zone {
+	return EnrichWithWalls(zones, DetectWalls(book, volume24hUSD))
+}
+
+// DetectWalls находит стены в снимке стакана.
 //
 // Алгоритм:
 //  1. Агрегируем ордера в ценовые бакеты по 0.1% — учитываем плотность (много
@@ -450,21 +429,38 @@ func computeWallThreshold(bucketMean, bucketStd, volume24hUSD float64) float64 {
 //  2. Единый порог = max(mean×3, mean+2σ, vol24h×0.05%) — бакет стены обязан
 //     быть в 3× выше среднего независимо от σ, статистически выделяться
 //     и быть значимым относительно объёма торгов.
-//  3. Ищем стены в радиусе ±0.5% от центра зоны.
-func EnrichWithOrderBook(zones []Zone, book *OrderBook, volume24hUSD float64) []Zone {
+func DetectWalls(book *OrderBook, volume24hUSD float64) []WallLevel {
 	if book == nil || (len(book.Bids) == 0 && len(book.Asks) == 0) {
-		return zones
+		return nil
 	}
 
-	// Строим бакеты
-	bidBuckets := buildBuckets(book.Bids, bucketWidthPct)
-	askBuckets := buildBuckets(book.Asks, bucketWidthPct)
+	var walls []WallLevel
+	for _, side := range []struct {
+		side   WallSide
+		levels []OrderLevel
+	}{
+		{WallSideBid, book.Bids},
+		{WallSideAsk, book.Asks},
+	} {
+		buckets := buildBuckets(side.levels, bucketWidthPct)
+		mean, std := bucketMeanStd(buckets)
+		threshold := computeWallThreshold(mean, std, volume24hUSD)
+		for _, b := range buckets {
+			if b.volumeUSD >= threshold {
+				walls = append(walls, WallLevel{Side: side.side, Price: b.priceKey, SizeUSD: b.volumeUSD})
+			}
+		}
+	}
+	return walls
+}
 
-	// Единый порог для bids и asks: max(mean×3, mean+2σ, dynFloor)
-	bidMean, bidStd := bucketMeanStd(bidBuckets)
-	askMean, askStd := bucketMeanStd(askBuckets)
-	bidThreshold := computeWallThreshold(bidMean, bidStd, volume24hUSD)
-	askThreshold := computeWallThreshold(askMean, askStd, volume24hUSD)
+// EnrichWithWalls отмечает зоны, рядом с которыми стоят стены.
+// Стены ищутся в радиусе ±0.5% от центра зоны: bid-стены — для поддержек,
+// ask-стены — для сопротивлений.
+func EnrichWithWalls(zones []Zone, walls []WallLevel) []Zone {
+	if len(walls) == 0 {
+		return zones
+	}
 
 	for i := range zones {
 		z := &zones[i]
@@ -473,25 +469,17 @@ func EnrichWithOrderBook(zones []Zone, book *OrderBook, volume24hUSD float64) []
 		searchLow := z.PriceCenter * (1 - wallSearchRadiusPct)
 		searchHigh := z.PriceCenter * (1 + wallSearchRadiusPct)
 
-		var wallUSD float64
-		var threshold float64
-		var buckets []priceBucket
-
+		side := WallSideAsk
 		if z.Type == ZoneTypeSupport {
-			buckets = bidBuckets
-			threshold = bidThreshold
-		} else {
-			buckets = askBuckets
-			threshold = askThreshold
+			side = WallSideBid
 		}
 
-		for _, b := range buckets {
-			if b.priceKey < searchLow || b.priceKey > searchHigh {
+		var wallUSD float64
+		for _, w := range walls {
+			if w.Side != side || w.Price < searchLow || w.Price > searchHigh {
 				continue
 			}
-			if b.volumeUSD >= threshold {
-				wallUSD += b.volumeUSD
-			}
+			wallUSD += w.SizeUSD
 		}
 
 		if wallUSD > 0 {
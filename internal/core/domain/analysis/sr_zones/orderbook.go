@@ -0,0 +1,16 @@
+// internal/core/domain/analysis/sr_zones/orderbook.go
+package sr_zones
+
+import bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+
+// OrderBookFromBybit конвертирует bybit.OrderBookV5 в OrderBook
+func OrderBookFromBybit(b *bybit.OrderBookV5) *OrderBook {
+	book := &OrderBook{Symbol: b.Symbol}
+	for _, l := range b.Bids {
+		book.Bids = append(book.Bids, OrderLevel{Price: l.Price, Size: l.Size})
+	}
+	for _, l := range b.Asks {
+		book.Asks = append(book.Asks, OrderLevel{Price: l.Price, Size: l.Size})
+	}
+	return book
+}
@@ -0,0 +1,49 @@
+// internal/core/domain/analysis/sr_zones/pivots.go
+package sr_zones
+
+import (
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+)
+
+// DefaultPivotLookback — окно pivot-точек по умолчанию (свечей до и после).
+const DefaultPivotLookback = defaultLookback
+
+// PivotHighIndexes возвращает индексы свечей, чей High строго выше
+// lookback соседних свечей слева и справа. Свечи ожидаются от старых к новым.
+func PivotHighIndexes(candles []storage.CandleInterface, lookback int) []int {
+	return pivotIndexes(candles, lookback, func(center, neighbour storage.CandleInterface) bool {
+		return neighbour.GetHigh() >= center.GetHigh()
+	})
+}
+
+// PivotLowIndexes возвращает индексы свечей, чей Low строго ниже
+// lookback соседних свечей слева и справа. Свечи ожидаются от старых к новым.
+func PivotLowIndexes(candles []storage.CandleInterface, lookback int) []int {
+	return pivotIndexes(candles, lookback, func(center, neighbour storage.CandleInterface) bool {
+		return neighbour.GetLow() <= center.GetLow()
+	})
+}
+
+// pivotIndexes — общий проход по свечам; blocks сообщает, что сосед отменяет pivot.
+func pivotIndexes(candles []storage.CandleInterface, lookback int,
+	blocks func(center, neighbour storage.CandleInterface) bool) []int {
+	if lookback < 1 {
+		lookback = defaultLookback
+	}
+
+	var indexes []int
+	n := len(candles)
+	for i := lookback; i < n-lookback; i++ {
+		isPivot := true
+		for j := 1; j <= lookback; j++ {
+			if blocks(candles[i], candles[i-j]) || blocks(candles[i], candles[i+j]) {
+				isPivot = false
+				break
+			}
+		}
+		if isPivot {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
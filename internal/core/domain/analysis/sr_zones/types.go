@@ -49,3 +49,18 @@ type OrderBook struct {
 	Bids   []OrderLevel // покупатели (ниже цены)
 	Asks   []OrderLevel // продавцы (выше цены)
 }
+
+// WallSide — сторона стакана, на которой стоит стена
+type WallSide string
+
+const (
+	WallSideBid WallSide = "bid"
+	WallSideAsk WallSide = "ask"
+)
+
+// WallLevel — крупная стена (бакет стакана выше порога стены)
+type WallLevel struct {
+	Side    WallSide
+	Price   float64
+	SizeUSD float64
+}
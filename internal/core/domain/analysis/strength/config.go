@@ -0,0 +1,66 @@
+// internal/core/domain/analysis/strength/config.go
+package strength
+
+import (
+	"strings"
+	"time"
+)
+
+// Config настройки рейтинга относительной силы
+type Config struct {
+	// MaxSymbols — сколько самых ликвидных символов входит во вселенную рейтинга
+	MaxSymbols int
+	// CacheTTL — сколько переиспользовать посчитанный рейтинг периода
+	CacheTTL time.Duration
+	// SectorsRefresh — как часто перечитывать секторы из Postgres
+	SectorsRefresh time.Duration
+	// DigestPeriod — период рейтинга для дайджеста ротаций
+	DigestPeriod string
+	// DigestInterval — интервал рассылки дайджеста (0 — дайджест выключен)
+	DigestInterval time.Duration
+	// TopN — сколько лидеров и аутсайдеров показывать
+	TopN int
+	// Sectors — секторы из конфигурации; используются, если в Postgres секторов нет
+	Sectors []Sector
+}
+
+// DefaultConfig возвращает настройки по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		MaxSymbols:     150,
+		CacheTTL:       time.Minute,
+		SectorsRefresh: 10 * time.Minute,
+		DigestPeriod:   "4h",
+		DigestInterval: 4 * time.Hour,
+		TopN:           5,
+	}
+}
+
+// ParseSectors разбирает секторы из строки конфигурации:
+// "L1=BTCUSDT,ETHUSDT,SOLUSDT;Memes=DOGEUSDT,PEPEUSDT"
+func ParseSectors(raw string) []Sector {
+	var sectors []Sector
+	for _, group := range strings.Split(raw, ";") {
+		name, list, ok := strings.Cut(group, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+
+		var symbols []string
+		for _, s := range strings.Split(list, ",") {
+			if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+				symbols = append(symbols, s)
+			}
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+		sectors = append(sectors, Sector{
+			Name:    strings.ToLower(name),
+			Title:   name,
+			Symbols: symbols,
+		})
+	}
+	return sectors
+}
@@ -0,0 +1,139 @@
+// internal/core/domain/analysis/strength/digest.go
+package strength
+
+import (
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"sync"
+	"time"
+)
+
+// DigestScheduler по расписанию считает рейтинг секторов, сравнивает его
+// с прошлым дайджестом и публикует EventSectorDigest
+type DigestScheduler struct {
+	service  *Service
+	eventBus types.EventBus
+
+	mu       sync.Mutex
+	previous *Report
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewDigestScheduler создает планировщик дайджеста
+func NewDigestScheduler(service *Service, eventBus types.EventBus) *DigestScheduler {
+	return &DigestScheduler{
+		service:  service,
+		eventBus: eventBus,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start запускает рассылку; первый дайджест — через один интервал,
+// когда свечи уже загружены
+func (d *DigestScheduler) Start() {
+	cfg := d.service.Config()
+	if cfg.DigestInterval <= 0 {
+		logger.Info("ℹ️ [Strength] Дайджест ротаций секторов отключен")
+		return
+	}
+
+	d.wg.Add(1)
+	go d.run(cfg.DigestInterval)
+	logger.Info("✅ [Strength] Дайджест ротаций секторов запущен (период: %s, интервал: %v)",
+		cfg.DigestPeriod, cfg.DigestInterval)
+}
+
+// Stop останавливает рассылку; повторный вызов ничего не делает
+func (d *DigestScheduler) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+		d.wg.Wait()
+	})
+}
+
+func (d *DigestScheduler) run(interval time.Duration) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			d.publish()
+		}
+	}
+}
+
+// publish считает рейтинг и отправляет дайджест
+func (d *DigestScheduler) publish() {
+	cfg := d.service.Config()
+	report, err := d.service.Rank(cfg.DigestPeriod)
+	if err != nil {
+		logger.Warn("⚠️ [Strength] Дайджест не построен: %v", err)
+		return
+	}
+	if len(report.Sectors) == 0 {
+		return
+	}
+
+	d.mu.Lock()
+	previous := d.previous
+	d.previous = report
+	d.mu.Unlock()
+
+	data := BuildDigest(report, previous, cfg.TopN)
+	if err := d.eventBus.Publish(types.Event{
+		Type:      types.EventSectorDigest,
+		Source:    "strength_digest",
+		Data:      data,
+		Timestamp: time.Now(),
+	}); err != nil {
+		logger.Error("❌ [Strength] Ошибка публикации дайджеста: %v", err)
+		return
+	}
+	logger.Info("📊 [Strength] Дайджест секторов опубликован (%d секторов, ротаций: %d)",
+		len(data.Sectors), len(data.Rotations))
+}
+
+// BuildDigest собирает данные дайджеста; previous может быть nil (первый дайджест без ротаций)
+func BuildDigest(report, previous *Report, topN int) types.SectorDigestData {
+	data := types.SectorDigestData{
+		Period:       report.Period,
+		BTCReturn:    report.BTCReturn,
+		MedianReturn: report.MedianReturn,
+		Timestamp:    report.GeneratedAt,
+	}
+
+	for i, s := range report.Sectors {
+		data.Sectors = append(data.Sectors, types.SectorStrength{
+			Title:    s.Title,
+			Return:   s.Return,
+			VsMedian: s.VsMedian,
+			Leader:   s.Leader,
+		})
+		if previous == nil {
+			continue
+		}
+		if from := previous.SectorRank(s.Name); from > 0 && from != i+1 {
+			data.Rotations = append(data.Rotations, types.SectorRotation{
+				Title:    s.Title,
+				FromRank: from,
+				ToRank:   i + 1,
+			})
+		}
+	}
+
+	for _, e := range report.Leaders(topN) {
+		data.Leaders = append(data.Leaders, types.SymbolStrength{Symbol: e.Symbol, Return: e.Return, VsBTC: e.VsBTC})
+	}
+	for _, e := range report.Laggards(topN) {
+		data.Laggards = append(data.Laggards, types.SymbolStrength{Symbol: e.Symbol, Return: e.Return, VsBTC: e.VsBTC})
+	}
+	return data
+}
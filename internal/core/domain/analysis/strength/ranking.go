@@ -0,0 +1,326 @@
+// internal/core/domain/analysis/strength/ranking.go
+package strength
+
+import (
+	symbol_sector_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/symbol_sector"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// benchmarkSymbol — относительно него считается сила "против BTC"
+const benchmarkSymbol = "BTCUSDT"
+
+// Periods — периоды рейтинга в порядке отображения
+var Periods = []string{"1h", "4h", "1d"}
+
+// lookback — какими свечами считается скользящая доходность периода:
+// 1h = 12 свечей по 5m, 4h = 16 по 15m, 1d = 24 по 1h
+var lookback = map[string]struct {
+	candlePeriod string
+	candles      int
+}{
+	"1h": {"5m", 12},
+	"4h": {"15m", 16},
+	"1d": {"1h", 24},
+}
+
+// CandleSource — источник свечей (CandleSystem)
+type CandleSource interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+// Sources — ленивое получение зависимостей: CandleSystem стартует позже сервисов доставки
+type Sources struct {
+	Candles func() CandleSource
+	Prices  func() storage.PriceStorageInterface
+}
+
+// Entry — символ в рейтинге
+type Entry struct {
+	Symbol   string
+	Sector   string  // имя первого сектора символа ("" — вне секторов)
+	Return   float64 // доходность за период, %
+	VsBTC    float64 // доходность минус доходность BTC, п.п.
+	VsMedian float64 // доходность минус медиана вселенной, п.п.
+}
+
+// SectorEntry — сектор в рейтинге
+type SectorEntry struct {
+	Name     string
+	Title    string
+	Return   float64 // медианная доходность символов сектора, %
+	VsMedian float64 // относительно медианы вселенной, п.п.
+	Members  int     // символов сектора с данными
+	Leader   string  // самый сильный символ сектора
+}
+
+// Report — рейтинг относительной силы за период
+type Report struct {
+	Period       string
+	BTCReturn    float64
+	MedianReturn float64
+	Entries      []Entry       // по убыванию доходности
+	Sectors      []SectorEntry // по убыванию доходности
+	GeneratedAt  time.Time
+}
+
+// Leaders возвращает n самых сильных символов
+func (r *Report) Leaders(n int) []Entry {
+	if n > len(r.Entries) {
+		n = len(r.Entries)
+	}
+	return r.Entries[:n]
+}
+
+// Laggards возвращает n самых слабых символов (самый слабый первым)
+func (r *Report) Laggards(n int) []Entry {
+	if n > len(r.Entries) {
+		n = len(r.Entries)
+	}
+	laggards := make([]Entry, 0, n)
+	for i := len(r.Entries) - 1; i >= len(r.Entries)-n; i-- {
+		laggards = append(laggards, r.Entries[i])
+	}
+	return laggards
+}
+
+// SectorRank возвращает место сектора (с 1); 0 — сектора нет в рейтинге
+func (r *Report) SectorRank(name string) int {
+	for i, s := range r.Sectors {
+		if s.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Service считает относительную силу символов против BTC и медианы вселенной
+// и сводит ее по секторам
+type Service struct {
+	cfg     Config
+	sources Sources
+	sectors *sectorCatalog
+
+	mu    sync.Mutex
+	cache map[string]*Report
+}
+
+// NewService создает сервис рейтинга. repo может быть nil — тогда секторы берутся из конфигурации.
+func NewService(cfg Config, repo symbol_sector_repo.SymbolSectorRepository, sources Sources) *Service {
+	defaults := DefaultConfig()
+	if cfg.MaxSymbols <= 0 {
+		cfg.MaxSymbols = defaults.MaxSymbols
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaults.CacheTTL
+	}
+	if cfg.SectorsRefresh <= 0 {
+		cfg.SectorsRefresh = defaults.SectorsRefresh
+	}
+	if cfg.TopN <= 0 {
+		cfg.TopN = defaults.TopN
+	}
+	if _, ok := lookback[cfg.DigestPeriod]; !ok {
+		cfg.DigestPeriod = defaults.DigestPeriod
+	}
+
+	return &Service{
+		cfg:     cfg,
+		sources: sources,
+		sectors: newSectorCatalog(repo, cfg.Sectors, cfg.SectorsRefresh),
+		cache:   make(map[string]*Report),
+	}
+}
+
+// Config возвращает действующие настройки
+func (s *Service) Config() Config {
+	return s.cfg
+}
+
+// Sectors возвращает действующие секторы
+func (s *Service) Sectors() []Sector {
+	return s.sectors.Sectors()
+}
+
+// IsValidPeriod проверяет, что период поддерживается рейтингом
+func IsValidPeriod(period string) bool {
+	_, ok := lookback[period]
+	return ok
+}
+
+// Rank возвращает рейтинг за период; результат кэшируется на CacheTTL
+func (s *Service) Rank(period string) (*Report, error) {
+	if !IsValidPeriod(period) {
+		return nil, fmt.Errorf("неподдерживаемый период %q (доступны: 1h, 4h, 1d)", period)
+	}
+
+	s.mu.Lock()
+	if cached, ok := s.cache[period]; ok && time.Since(cached.GeneratedAt) < s.cfg.CacheTTL {
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	report, err := s.compute(period)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[period] = report
+	s.mu.Unlock()
+	return report, nil
+}
+
+// compute считает доходности вселенной и секторов
+func (s *Service) compute(period string) (*Report, error) {
+	var candles CandleSource
+	if s.sources.Candles != nil {
+		candles = s.sources.Candles()
+	}
+	if candles == nil {
+		return nil, fmt.Errorf("свечные данные ещё не загружены")
+	}
+
+	sectors := s.sectors.Sectors()
+	returns := make(map[string]float64)
+	for _, symbol := range s.universe(sectors) {
+		if r, ok := symbolReturn(candles, symbol, period); ok {
+			returns[symbol] = r
+		}
+	}
+	if len(returns) == 0 {
+		return nil, fmt.Errorf("нет данных для рейтинга за %s", period)
+	}
+
+	sectorOf := make(map[string]string)
+	for _, sector := range sectors {
+		for _, symbol := range sector.Symbols {
+			if _, exists := sectorOf[symbol]; !exists {
+				sectorOf[symbol] = sector.Name
+			}
+		}
+	}
+
+	all := make([]float64, 0, len(returns))
+	for _, r := range returns {
+		all = append(all, r)
+	}
+	median := medianOf(all)
+	btc := returns[benchmarkSymbol]
+
+	report := &Report{
+		Period:       period,
+		BTCReturn:    btc,
+		MedianReturn: median,
+		Entries:      make([]Entry, 0, len(returns)),
+		GeneratedAt:  time.Now(),
+	}
+	for symbol, r := range returns {
+		report.Entries = append(report.Entries, Entry{
+			Symbol:   symbol,
+			Sector:   sectorOf[symbol],
+			Return:   r,
+			VsBTC:    r - btc,
+			VsMedian: r - median,
+		})
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		if report.Entries[i].Return == report.Entries[j].Return {
+			return report.Entries[i].Symbol < report.Entries[j].Symbol
+		}
+		return report.Entries[i].Return > report.Entries[j].Return
+	})
+
+	for _, sector := range sectors {
+		var memberReturns []float64
+		leader, best := "", 0.0
+		for _, symbol := range sector.Symbols {
+			r, ok := returns[symbol]
+			if !ok {
+				continue
+			}
+			memberReturns = append(memberReturns, r)
+			if leader == "" || r > best {
+				leader, best = symbol, r
+			}
+		}
+		if len(memberReturns) == 0 {
+			continue
+		}
+		sectorReturn := medianOf(memberReturns)
+		report.Sectors = append(report.Sectors, SectorEntry{
+			Name:     sector.Name,
+			Title:    sector.Title,
+			Return:   sectorReturn,
+			VsMedian: sectorReturn - median,
+			Members:  len(memberReturns),
+			Leader:   leader,
+		})
+	}
+	sort.SliceStable(report.Sectors, func(i, j int) bool {
+		return report.Sectors[i].Return > report.Sectors[j].Return
+	})
+
+	return report, nil
+}
+
+// universe — самые ликвидные символы плюс все символы секторов и BTC
+func (s *Service) universe(sectors []Sector) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	add := func(symbol string) {
+		if symbol != "" && !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	add(benchmarkSymbol)
+	if s.sources.Prices != nil {
+		if prices := s.sources.Prices(); prices != nil {
+			if top, err := prices.GetTopSymbolsByVolumeUSD(s.cfg.MaxSymbols); err == nil {
+				for _, v := range top {
+					add(v.GetSymbol())
+				}
+			}
+		}
+	}
+	for _, sector := range sectors {
+		for _, symbol := range sector.Symbols {
+			add(symbol)
+		}
+	}
+	return symbols
+}
+
+// symbolReturn — скользящая доходность символа за период, %
+func symbolReturn(candles CandleSource, symbol, period string) (float64, bool) {
+	lb := lookback[period]
+	history, err := candles.GetHistory(symbol, lb.candlePeriod, lb.candles+1)
+	if err != nil || len(history) < lb.candles+1 {
+		return 0, false
+	}
+
+	first, last := history[len(history)-1-lb.candles], history[len(history)-1]
+	if first == nil || last == nil || first.Close <= 0 || last.Close <= 0 {
+		return 0, false
+	}
+	return (last.Close - first.Close) / first.Close * 100, true
+}
+
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,77 @@
+// internal/core/domain/analysis/strength/sectors.go
+package strength
+
+import (
+	symbol_sector_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/symbol_sector"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Sector группа символов (L1, мемы, AI, DeFi)
+type Sector struct {
+	Name    string
+	Title   string
+	Symbols []string
+}
+
+// sectorCatalog отдаёт секторы из Postgres, а если их нет — из конфигурации.
+// Секторы из базы перечитываются не чаще refresh.
+type sectorCatalog struct {
+	repo     symbol_sector_repo.SymbolSectorRepository // может быть nil
+	fallback []Sector
+	refresh  time.Duration
+
+	mu       sync.Mutex
+	cached   []Sector
+	loadedAt time.Time
+}
+
+func newSectorCatalog(repo symbol_sector_repo.SymbolSectorRepository, fallback []Sector, refresh time.Duration) *sectorCatalog {
+	return &sectorCatalog{
+		repo:     repo,
+		fallback: fallback,
+		refresh:  refresh,
+	}
+}
+
+// Sectors возвращает актуальный список секторов
+func (c *sectorCatalog) Sectors() []Sector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.repo == nil {
+		return c.fallback
+	}
+	if c.cached != nil && time.Since(c.loadedAt) < c.refresh {
+		return c.cached
+	}
+
+	rows, err := c.repo.FindActive()
+	if err != nil {
+		logger.Warn("⚠️ [Strength] Не удалось загрузить секторы из Postgres: %v", err)
+		if c.cached != nil {
+			return c.cached
+		}
+		return c.fallback
+	}
+
+	sectors := make([]Sector, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Symbols) == 0 {
+			continue
+		}
+		sectors = append(sectors, Sector{
+			Name:    row.Name,
+			Title:   row.Title,
+			Symbols: append([]string(nil), row.Symbols...),
+		})
+	}
+	if len(sectors) == 0 {
+		sectors = c.fallback
+	}
+
+	c.cached = sectors
+	c.loadedAt = time.Now()
+	return sectors
+}
@@ -0,0 +1,52 @@
+// internal/core/domain/analysis/vwap/anchor.go
+package vwap
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseAnchor разбирает точку привязки VWAP (UTC):
+//
+//	now         — текущий момент
+//	HH:MM       — сегодня; если время еще не наступило — вчера
+//	DD.MM HH:MM — конкретная дата текущего года (или прошлого — для начала января)
+//
+// Привязка старше MaxAnchorAge или в будущем — ошибка.
+func ParseAnchor(text string, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	var anchor time.Time
+	switch fields := strings.Fields(text); {
+	case text == "now" || text == "сейчас":
+		anchor = now
+	case len(fields) == 1:
+		t, err := time.Parse("15:04", fields[0])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("неверное время %q, ожидается ЧЧ:ММ", fields[0])
+		}
+		anchor = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+		if anchor.After(now) {
+			anchor = anchor.AddDate(0, 0, -1)
+		}
+	case len(fields) == 2:
+		t, err := time.Parse("02.01 15:04", fields[0]+" "+fields[1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("неверная дата %q, ожидается ДД.ММ ЧЧ:ММ", text)
+		}
+		anchor = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+		if anchor.After(now) {
+			anchor = anchor.AddDate(-1, 0, 0)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("неверный формат якоря %q", text)
+	}
+
+	anchor = anchor.Truncate(time.Minute)
+	if now.Sub(anchor) > MaxAnchorAge {
+		return time.Time{}, fmt.Errorf("якорь не может быть старше %.0f ч", MaxAnchorAge.Hours())
+	}
+	return anchor, nil
+}
@@ -0,0 +1,153 @@
+// internal/core/domain/analysis/vwap/tracker.go
+package vwap
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// BucketSize — шаг хранения истории для привязанного VWAP (точность точки привязки)
+	BucketSize = 5 * time.Minute
+	// MaxAnchorAge — насколько далеко в прошлое можно привязать VWAP
+	MaxAnchorAge = 72 * time.Hour
+
+	maxBuckets = int(MaxAnchorAge / BucketSize)
+)
+
+// Tracker инкрементально считает VWAP дневной сессии (от 00:00 UTC) и хранит
+// 5-минутные корзины за последние 72 часа для VWAP от произвольной точки привязки.
+//
+// Объём тика — прирост 24-часового оборота (VolumeUSD) между соседними тиками символа:
+// свечи хранят накопленный суточный оборот, а не объём сделок внутри тика.
+// Тики без прироста (первый тик, выпадение старых сделок из окна 24ч) в VWAP не входят.
+type Tracker struct {
+	mu      sync.RWMutex
+	symbols map[string]*symbolState
+}
+
+type symbolState struct {
+	lastTurnover float64
+	lastPrice    float64
+	sessionStart time.Time
+	session      accumulator
+	buckets      []bucket // по возрастанию времени, не более maxBuckets
+	updatedAt    time.Time
+}
+
+type bucket struct {
+	start time.Time
+	acc   accumulator
+}
+
+// NewTracker создаёт трекер VWAP
+func NewTracker() *Tracker {
+	return &Tracker{symbols: make(map[string]*symbolState)}
+}
+
+// SessionStart возвращает начало дневной сессии (00:00 UTC) для момента at
+func SessionStart(at time.Time) time.Time {
+	return at.UTC().Truncate(24 * time.Hour)
+}
+
+// Update учитывает тик: цену и текущий 24-часовой оборот символа в USD
+func (t *Tracker) Update(symbol string, price, turnover24h float64, at time.Time) {
+	if price <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st, ok := t.symbols[symbol]
+	if !ok {
+		st = &symbolState{sessionStart: SessionStart(at)}
+		t.symbols[symbol] = st
+	}
+
+	volume := turnover24h - st.lastTurnover
+	first := st.lastTurnover == 0
+	st.lastTurnover = turnover24h
+	st.lastPrice = price
+	st.updatedAt = at
+
+	if session := SessionStart(at); session.After(st.sessionStart) {
+		st.sessionStart = session
+		st.session = accumulator{}
+	}
+
+	if first || volume <= 0 {
+		return
+	}
+
+	st.session.add(price, volume)
+	st.addToBucket(price, volume, at)
+}
+
+func (st *symbolState) addToBucket(price, volume float64, at time.Time) {
+	start := at.UTC().Truncate(BucketSize)
+	n := len(st.buckets)
+	if n == 0 || st.buckets[n-1].start.Before(start) {
+		st.buckets = append(st.buckets, bucket{start: start})
+		if len(st.buckets) > maxBuckets {
+			st.buckets = st.buckets[len(st.buckets)-maxBuckets:]
+		}
+		n = len(st.buckets)
+	}
+	st.buckets[n-1].acc.add(price, volume)
+}
+
+// Session возвращает VWAP текущей дневной сессии символа
+func (t *Tracker) Session(symbol string) (Snapshot, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	st, ok := t.symbols[symbol]
+	if !ok {
+		return Snapshot{}, false
+	}
+	snap := st.session.snapshot(symbol, st.sessionStart, st.updatedAt)
+	snap.LastPrice = st.lastPrice
+	return snap, snap.IsValid()
+}
+
+// Anchored возвращает VWAP от точки привязки (с точностью до BucketSize).
+// Привязка старше MaxAnchorAge или в будущем не поддерживается.
+func (t *Tracker) Anchored(symbol string, anchor time.Time) (Snapshot, bool) {
+	anchor = anchor.UTC().Truncate(BucketSize)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	st, ok := t.symbols[symbol]
+	if !ok || len(st.buckets) == 0 || anchor.After(st.updatedAt) {
+		return Snapshot{}, false
+	}
+	if st.updatedAt.Sub(anchor) > MaxAnchorAge {
+		return Snapshot{}, false
+	}
+
+	var acc accumulator
+	for i := len(st.buckets) - 1; i >= 0 && !st.buckets[i].start.Before(anchor); i-- {
+		acc.merge(st.buckets[i].acc)
+	}
+	snap := acc.snapshot(symbol, anchor, st.updatedAt)
+	snap.LastPrice = st.lastPrice
+	return snap, snap.IsValid()
+}
+
+// Cleanup удаляет символы без обновлений дольше maxAge
+func (t *Tracker) Cleanup(maxAge time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	removed := 0
+	cutoff := time.Now().Add(-maxAge)
+	for symbol, st := range t.symbols {
+		if st.updatedAt.Before(cutoff) {
+			delete(t.symbols, symbol)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,91 @@
+// internal/core/domain/analysis/vwap/types.go
+package vwap
+
+import (
+	"math"
+	"time"
+)
+
+// Snapshot — VWAP с полосами стандартного отклонения от точки привязки
+type Snapshot struct {
+	Symbol string
+	// Anchor — начало накопления: 00:00 UTC для сессии или точка привязки пользователя
+	Anchor time.Time
+	VWAP   float64
+	// StdDev — стандартное отклонение цены от VWAP, взвешенное по объёму
+	StdDev    float64
+	VolumeUSD float64
+	Ticks     int
+	// LastPrice — цена последнего тика символа
+	LastPrice float64
+	UpdatedAt time.Time
+}
+
+// IsValid проверяет, что VWAP рассчитан
+func (s Snapshot) IsValid() bool {
+	return s.VWAP > 0 && s.VolumeUSD > 0
+}
+
+// Band возвращает верхнюю и нижнюю границы полосы k·σ
+func (s Snapshot) Band(k float64) (upper, lower float64) {
+	return s.VWAP + k*s.StdDev, s.VWAP - k*s.StdDev
+}
+
+// DistancePct возвращает расстояние цены от VWAP в процентах
+func (s Snapshot) DistancePct(price float64) float64 {
+	if s.VWAP <= 0 {
+		return 0
+	}
+	return (price - s.VWAP) / s.VWAP * 100
+}
+
+// Sigmas возвращает отклонение цены от VWAP в σ (0 — если σ не определена)
+func (s Snapshot) Sigmas(price float64) float64 {
+	if s.StdDev <= 0 {
+		return 0
+	}
+	return (price - s.VWAP) / s.StdDev
+}
+
+// accumulator — суммы для инкрементального расчёта VWAP и дисперсии
+type accumulator struct {
+	pv    float64 // Σ price·volume
+	p2v   float64 // Σ price²·volume
+	v     float64 // Σ volume
+	ticks int
+}
+
+func (a *accumulator) add(price, volume float64) {
+	a.pv += price * volume
+	a.p2v += price * price * volume
+	a.v += volume
+	a.ticks++
+}
+
+func (a *accumulator) merge(b accumulator) {
+	a.pv += b.pv
+	a.p2v += b.p2v
+	a.v += b.v
+	a.ticks += b.ticks
+}
+
+// snapshot рассчитывает VWAP и σ: Var = Σp²v/Σv − VWAP²
+func (a accumulator) snapshot(symbol string, anchor, updated time.Time) Snapshot {
+	if a.v <= 0 {
+		return Snapshot{Symbol: symbol, Anchor: anchor, UpdatedAt: updated}
+	}
+	vwap := a.pv / a.v
+	variance := a.p2v/a.v - vwap*vwap
+	if variance < 0 {
+		variance = 0 // погрешность округления на плоском рынке
+	}
+	return Snapshot{
+		Symbol:    symbol,
+		Anchor:    anchor,
+		VWAP:      vwap,
+		StdDev:    math.Sqrt(variance),
+		VolumeUSD: a.v,
+		Ticks:     a.ticks,
+		UpdatedAt: updated,
+	}
+}
@@ -0,0 +1,165 @@
+// internal/core/domain/analysis/wall_tracker/book.go
+package wall_tracker
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	"math"
+	"time"
+)
+
+const (
+	// matchTolerancePct — стены на одной стороне в пределах 0.1% считаются одной стеной
+	matchTolerancePct = 0.001
+	// touchTolerancePct — цена «касается» стены, если лучшая цена в пределах 0.05%
+	touchTolerancePct = 0.0005
+	// residualKeepRatio — если на уровне осталось не меньше половины стены,
+	// она просто опустилась ниже порога, а не снята
+	residualKeepRatio = 0.5
+	// forgetAfter — сколько помним исчезнувшую стену (для учёта мигания)
+	forgetAfter = 10 * time.Minute
+)
+
+// symbolWalls — история стен одного символа
+type symbolWalls struct {
+	walls   []*Wall
+	samples int
+	lastMid float64
+}
+
+// bookTop — лучшие цены стакана
+type bookTop struct {
+	bestBid float64
+	bestAsk float64
+	mid     float64
+}
+
+// topOfBook находит лучшие bid/ask (порядок уровней в стакане не важен)
+func topOfBook(book *sr_zones.OrderBook) (bookTop, bool) {
+	var top bookTop
+	for _, l := range book.Bids {
+		if l.Size > 0 && l.Price > top.bestBid {
+			top.bestBid = l.Price
+		}
+	}
+	for _, l := range book.Asks {
+		if l.Size > 0 && l.Price > 0 && (top.bestAsk == 0 || l.Price < top.bestAsk) {
+			top.bestAsk = l.Price
+		}
+	}
+	if top.bestBid <= 0 || top.bestAsk <= 0 {
+		return top, false
+	}
+	top.mid = (top.bestBid + top.bestAsk) / 2
+	return top, true
+}
+
+// touched — дошла ли цена до стены (или прошла сквозь неё)
+func (t bookTop) touched(side sr_zones.WallSide, price float64) bool {
+	if side == sr_zones.WallSideBid {
+		return t.bestBid <= price*(1+touchTolerancePct)
+	}
+	return t.bestAsk >= price*(1-touchTolerancePct)
+}
+
+// levelUSD — USD-объём на стороне стакана в пределах допуска от цены
+func levelUSD(book *sr_zones.OrderBook, side sr_zones.WallSide, price float64) float64 {
+	levels := book.Asks
+	if side == sr_zones.WallSideBid {
+		levels = book.Bids
+	}
+	total := 0.0
+	for _, l := range levels {
+		if math.Abs(l.Price-price) <= price*matchTolerancePct {
+			total += l.Price * l.Size
+		}
+	}
+	return total
+}
+
+// update применяет новый снимок к истории стен символа.
+// Возвращает стены, снятые без касания ценой (кандидаты на сигнал о снятии).
+func (s *symbolWalls) update(book *sr_zones.OrderBook, detected []sr_zones.WallLevel, top bookTop, now time.Time) []*Wall {
+	matched := make(map[*Wall]bool, len(detected))
+
+	for _, d := range detected {
+		w := s.find(d)
+		if w == nil {
+			w = &Wall{
+				Side:         d.Side,
+				Price:        d.Price,
+				FirstSeen:    now,
+				PresentSince: now,
+				Present:      true,
+				Appearances:  1,
+			}
+			s.walls = append(s.walls, w)
+		} else if !w.Present {
+			// Стена вернулась после исчезновения
+			w.Present = true
+			w.PresentSince = now
+			w.Appearances++
+		} else if d.SizeUSD < w.SizeUSD {
+			s.account(w, w.SizeUSD-d.SizeUSD, top)
+		}
+
+		w.Price = d.Price
+		w.SizeUSD = d.SizeUSD
+		w.PeakUSD = math.Max(w.PeakUSD, d.SizeUSD)
+		w.LastSeen = now
+		matched[w] = true
+	}
+
+	var pulled []*Wall
+	kept := s.walls[:0]
+	for _, w := range s.walls {
+		if w.Present && !matched[w] {
+			residual := levelUSD(book, w.Side, w.Price)
+			if residual >= w.SizeUSD*residualKeepRatio {
+				// Объём на уровне остался — стена лишь опустилась ниже порога
+				w.SizeUSD = residual
+				w.LastSeen = now
+			} else {
+				if s.account(w, w.SizeUSD-residual, top) {
+					pulled = append(pulled, w)
+				}
+				w.Present = false
+			}
+		}
+		if w.Present || now.Sub(w.LastSeen) < forgetAfter {
+			kept = append(kept, w)
+		}
+	}
+	s.walls = kept
+	s.samples++
+
+	return pulled
+}
+
+// account относит ушедший объём к исполнению или снятию; true — снят без касания
+func (s *symbolWalls) account(w *Wall, removed float64, top bookTop) bool {
+	if removed <= 0 {
+		return false
+	}
+	if top.touched(w.Side, w.Price) {
+		w.FilledUSD += removed
+		return false
+	}
+	w.CancelledUSD += removed
+	return true
+}
+
+// find ищет отслеживаемую стену той же стороны рядом с ценой
+func (s *symbolWalls) find(d sr_zones.WallLevel) *Wall {
+	var best *Wall
+	bestDist := math.MaxFloat64
+	for _, w := range s.walls {
+		if w.Side != d.Side {
+			continue
+		}
+		dist := math.Abs(w.Price - d.Price)
+		if dist <= w.Price*matchTolerancePct && dist < bestDist {
+			best, bestDist = w, dist
+		}
+	}
+	return best
+}
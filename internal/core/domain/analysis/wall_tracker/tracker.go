@@ -0,0 +1,357 @@
+// internal/core/domain/analysis/wall_tracker/tracker.go
+package wall_tracker
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	event_bus "crypto-exchange-screener-bot/internal/infrastructure/transport/event_bus"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	orderBookDepth = 200
+	sampleWorkers  = 5
+	// minSamples — сколько снимков нужно, чтобы доверять классификации символа
+	minSamples = 2
+)
+
+// OrderBookFetcher — источник стакана (BybitPriceFetcher)
+type OrderBookFetcher interface {
+	GetOrderBook(symbol string, depth int) (*bybit.OrderBookV5, error)
+}
+
+// Volume24hProvider — источник суточного объёма в USD (BybitPriceFetcher)
+type Volume24hProvider interface {
+	GetVolume24hUSD(symbol string) float64
+}
+
+// Tracker периодически снимает стакан самых ликвидных символов и ведёт историю стен:
+// время жизни, исполнение против снятия, повторные появления.
+// Зоны S/R обогащаются только стабильными стенами (см. StableWalls).
+type Tracker struct {
+	cfg      Config
+	fetcher  OrderBookFetcher
+	volumes  Volume24hProvider
+	eventBus *event_bus.EventBus
+
+	mu      sync.RWMutex
+	symbols map[string]*symbolWalls
+	tracked []string
+
+	subscriber types.EventSubscriber
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+}
+
+// NewTracker создаёт трекер стен
+func NewTracker(cfg Config, fetcher OrderBookFetcher, volumes Volume24hProvider, eventBus *event_bus.EventBus) *Tracker {
+	defaults := DefaultConfig()
+	if cfg.MaxSymbols <= 0 {
+		cfg.MaxSymbols = defaults.MaxSymbols
+	}
+	if cfg.SampleInterval <= 0 {
+		cfg.SampleInterval = defaults.SampleInterval
+	}
+	if cfg.MinStableLifetime <= 0 {
+		cfg.MinStableLifetime = defaults.MinStableLifetime
+	}
+	if cfg.PullApproachPct <= 0 {
+		cfg.PullApproachPct = defaults.PullApproachPct
+	}
+
+	return &Tracker{
+		cfg:      cfg,
+		fetcher:  fetcher,
+		volumes:  volumes,
+		eventBus: eventBus,
+		symbols:  make(map[string]*symbolWalls),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start подписывается на обновления цен (выбор символов) и запускает цикл снимков
+func (t *Tracker) Start() {
+	t.subscriber = event_bus.NewBaseSubscriber(
+		"wall_tracker",
+		[]types.EventType{types.EventPriceUpdated},
+		func(event types.Event) error {
+			if priceList, ok := event.Data.([]storage.PriceData); ok && len(priceList) > 0 {
+				t.updateTracked(priceList)
+			}
+			return nil
+		},
+	)
+	t.eventBus.Subscribe(types.EventPriceUpdated, t.subscriber)
+
+	t.wg.Add(1)
+	go t.run()
+
+	logger.Info("✅ WallTracker запущен (символов: %d, интервал: %v, сигналы о снятии: %v)",
+		t.cfg.MaxSymbols, t.cfg.SampleInterval, t.cfg.PullAlerts)
+}
+
+// Stop останавливает трекер; повторный вызов ничего не делает
+func (t *Tracker) Stop() {
+	t.stopOnce.Do(func() {
+		if t.subscriber != nil {
+			t.eventBus.Unsubscribe(types.EventPriceUpdated, t.subscriber)
+		}
+		close(t.stopCh)
+		t.wg.Wait()
+		logger.Info("🛑 WallTracker остановлен")
+	})
+}
+
+// StableWalls возвращает стабильные стены символа.
+// ok=false — символ не отслеживается или истории ещё мало: вызывающий
+// должен использовать разовый снимок стакана.
+func (t *Tracker) StableWalls(symbol string) ([]sr_zones.WallLevel, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	state, exists := t.symbols[symbol]
+	if !exists || state.samples < minSamples {
+		return nil, false
+	}
+
+	now := time.Now()
+	var walls []sr_zones.WallLevel
+	for _, w := range state.walls {
+		if w.Classify(now, t.cfg.MinStableLifetime) == ClassStable {
+			walls = append(walls, sr_zones.WallLevel{Side: w.Side, Price: w.Price, SizeUSD: w.SizeUSD})
+		}
+	}
+	return walls, true
+}
+
+// Walls возвращает копию истории стен символа (для диагностики)
+func (t *Tracker) Walls(symbol string) []Wall {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	state, exists := t.symbols[symbol]
+	if !exists {
+		return nil
+	}
+	walls := make([]Wall, 0, len(state.walls))
+	for _, w := range state.walls {
+		walls = append(walls, *w)
+	}
+	return walls
+}
+
+// updateTracked выбирает самые ликвидные символы из батча цен
+func (t *Tracker) updateTracked(priceList []storage.PriceData) {
+	sorted := make([]storage.PriceData, len(priceList))
+	copy(sorted, priceList)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].VolumeUSD > sorted[j].VolumeUSD })
+	if len(sorted) > t.cfg.MaxSymbols {
+		sorted = sorted[:t.cfg.MaxSymbols]
+	}
+
+	tracked := make([]string, 0, len(sorted))
+	keep := make(map[string]bool, len(sorted))
+	for _, p := range sorted {
+		tracked = append(tracked, p.Symbol)
+		keep[p.Symbol] = true
+	}
+
+	t.mu.Lock()
+	t.tracked = tracked
+	// Символы, выпавшие из списка, забываем: их история больше не обновляется
+	for symbol := range t.symbols {
+		if !keep[symbol] {
+			delete(t.symbols, symbol)
+		}
+	}
+	t.mu.Unlock()
+}
+
+// run — цикл снимков стакана
+func (t *Tracker) run() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.cfg.SampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case <-ticker.C:
+			t.sampleAll()
+		}
+	}
+}
+
+// sampleAll снимает стакан всех отслеживаемых символов пулом воркеров
+func (t *Tracker) sampleAll() {
+	t.mu.RLock()
+	symbols := make([]string, len(t.tracked))
+	copy(symbols, t.tracked)
+	t.mu.RUnlock()
+
+	symbolCh := make(chan string, len(symbols))
+	for _, s := range symbols {
+		symbolCh <- s
+	}
+	close(symbolCh)
+
+	var wg sync.WaitGroup
+	for i := 0; i < sampleWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range symbolCh {
+				t.sample(symbol)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// sample снимает стакан символа и обновляет историю стен
+func (t *Tracker) sample(symbol string) {
+	raw, err := t.fetcher.GetOrderBook(symbol, orderBookDepth)
+	if err != nil {
+		logger.Debug("⚠️ WallTracker: не удалось получить стакан %s: %v", symbol, err)
+		return
+	}
+	book := sr_zones.OrderBookFromBybit(raw)
+	top, ok := topOfBook(book)
+	if !ok {
+		return
+	}
+
+	var volume24h float64
+	if t.volumes != nil {
+		volume24h = t.volumes.GetVolume24hUSD(symbol)
+	}
+	detected := sr_zones.DetectWalls(book, volume24h)
+	now := time.Now()
+
+	t.mu.Lock()
+	state, exists := t.symbols[symbol]
+	if !exists {
+		state = &symbolWalls{}
+		t.symbols[symbol] = state
+	}
+	prevMid := state.lastMid
+	pulled := state.update(book, detected, top, now)
+	state.lastMid = top.mid
+
+	var events []PullEvent
+	for _, w := range pulled {
+		if event, ok := t.pullEvent(symbol, w, top.mid, prevMid, now); ok {
+			events = append(events, event)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, event := range events {
+		t.publishPull(event)
+	}
+}
+
+// pullEvent проверяет, что снятая стена крупная и цена шла к ней
+func (t *Tracker) pullEvent(symbol string, w *Wall, mid, prevMid float64, now time.Time) (PullEvent, bool) {
+	if !t.cfg.PullAlerts || prevMid <= 0 || w.SizeUSD < t.cfg.PullAlertMinUSD {
+		return PullEvent{}, false
+	}
+
+	distance := math.Abs(mid-w.Price) / mid * 100
+	prevDistance := math.Abs(prevMid-w.Price) / prevMid * 100
+	if distance > t.cfg.PullApproachPct || distance >= prevDistance {
+		return PullEvent{}, false
+	}
+
+	return PullEvent{
+		Symbol:      symbol,
+		Side:        w.Side,
+		Price:       w.Price,
+		SizeUSD:     w.SizeUSD,
+		Mid:         mid,
+		DistancePct: distance,
+		Lifetime:    w.Lifetime(now),
+		Timestamp:   now,
+	}, true
+}
+
+// publishPull публикует сигнал о снятии стены в EventBus
+func (t *Tracker) publishPull(event PullEvent) {
+	// Сняли поддержку — давление вниз, сняли сопротивление — вверх
+	direction := "fall"
+	if event.Side == sr_zones.WallSideAsk {
+		direction = "growth"
+	}
+
+	// Чем крупнее стена и ближе цена, тем значимее снятие
+	confidence := 60 + 20*math.Min(event.SizeUSD/(t.cfg.PullAlertMinUSD*4), 1) +
+		20*(1-event.DistancePct/t.cfg.PullApproachPct)
+
+	signal := analysis.Signal{
+		ID:         uuid.New().String(),
+		Symbol:     event.Symbol,
+		Type:       "wall_pull",
+		Direction:  direction,
+		Confidence: math.Round(math.Min(confidence, 100)),
+		DataPoints: 1,
+		StartPrice: event.Mid,
+		EndPrice:   event.Mid,
+		Timestamp:  event.Timestamp,
+		Metadata: analysis.Metadata{
+			Strategy: "wall_tracker",
+			Tags:     []string{"orderbook", "wall_pull", "spoof", string(event.Side)},
+			Indicators: map[string]float64{
+				"wall_price":    event.Price,
+				"wall_size_usd": event.SizeUSD,
+				"distance_pct":  event.DistancePct,
+				"lifetime_sec":  event.Lifetime.Seconds(),
+			},
+		},
+	}
+
+	// Сигнал — в журнал, событие снятия — для доставки подписанным пользователям
+	t.eventBus.Publish(types.Event{
+		Type:   types.EventSignalDetected,
+		Source: "wall_tracker",
+		Data:   signal,
+		Metadata: types.Metadata{
+			CorrelationID: signal.ID,
+			Priority:      int(signal.Confidence / 10),
+			Tags:          signal.Metadata.Tags,
+		},
+		Timestamp: event.Timestamp,
+	})
+	t.eventBus.Publish(types.Event{
+		Type:   types.EventWallPulled,
+		Source: "wall_tracker",
+		Data: types.WallPullData{
+			SignalID:    signal.ID,
+			Symbol:      event.Symbol,
+			Side:        string(event.Side),
+			Direction:   direction,
+			Price:       event.Price,
+			SizeUSD:     event.SizeUSD,
+			Mid:         event.Mid,
+			DistancePct: event.DistancePct,
+			Lifetime:    event.Lifetime,
+			Confidence:  signal.Confidence,
+			Timestamp:   event.Timestamp,
+		},
+		Timestamp: event.Timestamp,
+	})
+
+	logger.Info("🧱 WallTracker: %s снята %s-стена $%.0f на %.6f (до цены %.2f%%)",
+		event.Symbol, event.Side, event.SizeUSD, event.Price, event.DistancePct)
+}
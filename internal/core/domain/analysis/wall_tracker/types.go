@@ -0,0 +1,117 @@
+// internal/core/domain/analysis/wall_tracker/types.go
+package wall_tracker
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	"time"
+)
+
+// Class — классификация стены по её поведению во времени
+type Class string
+
+const (
+	ClassNew    Class = "new"    // стена появилась недавно, данных мало
+	ClassStable Class = "stable" // стоит долго и не снимается
+	ClassSpoof  Class = "spoof"  // снимается до исполнения или мигает
+)
+
+const (
+	// flickerAppearances — столько появлений одной стены считается «миганием»
+	flickerAppearances = 3
+	// spoofCancelRatio — доля снятого объёма (от снятого + исполненного), при которой стена — спуф
+	spoofCancelRatio = 0.7
+	// spoofCancelOfPeak — снятый объём должен быть не меньше этой доли пикового размера
+	spoofCancelOfPeak = 0.5
+)
+
+// Wall — история одной стены в стакане
+type Wall struct {
+	Side    sr_zones.WallSide
+	Price   float64
+	SizeUSD float64 // текущий (или последний виденный) размер
+	PeakUSD float64
+
+	FirstSeen    time.Time // первое появление
+	PresentSince time.Time // начало текущего непрерывного присутствия
+	LastSeen     time.Time
+	Present      bool
+
+	Appearances  int     // сколько раз стена появлялась (1 — стоит с момента появления)
+	FilledUSD    float64 // объём, ушедший при касании ценой (исполнение)
+	CancelledUSD float64 // объём, снятый без касания ценой
+}
+
+// Lifetime возвращает длительность текущего (или последнего) присутствия стены
+func (w *Wall) Lifetime(now time.Time) time.Duration {
+	if w.Present {
+		return now.Sub(w.PresentSince)
+	}
+	return w.LastSeen.Sub(w.PresentSince)
+}
+
+// FillRatio — доля исполненного объёма среди ушедшего
+func (w *Wall) FillRatio() float64 {
+	total := w.FilledUSD + w.CancelledUSD
+	if total == 0 {
+		return 0
+	}
+	return w.FilledUSD / total
+}
+
+// CancelRatio — доля снятого объёма среди ушедшего
+func (w *Wall) CancelRatio() float64 {
+	total := w.FilledUSD + w.CancelledUSD
+	if total == 0 {
+		return 0
+	}
+	return w.CancelledUSD / total
+}
+
+// Classify классифицирует стену: спуф — мигает или снимается без исполнения,
+// стабильная — стоит не меньше minStable и не замечена в спуфинге
+func (w *Wall) Classify(now time.Time, minStable time.Duration) Class {
+	if w.Appearances >= flickerAppearances {
+		return ClassSpoof
+	}
+	if w.CancelRatio() >= spoofCancelRatio && w.CancelledUSD >= w.PeakUSD*spoofCancelOfPeak {
+		return ClassSpoof
+	}
+	if w.Present && w.Lifetime(now) >= minStable {
+		return ClassStable
+	}
+	return ClassNew
+}
+
+// PullEvent — крупная стена снята, когда цена подошла к ней
+type PullEvent struct {
+	Symbol      string
+	Side        sr_zones.WallSide
+	Price       float64
+	SizeUSD     float64
+	Mid         float64
+	DistancePct float64 // расстояние от mid до стены в момент снятия, %
+	Lifetime    time.Duration
+	Timestamp   time.Time
+}
+
+// Config — параметры трекера стен
+type Config struct {
+	MaxSymbols        int           // сколько самых ликвидных символов отслеживать
+	SampleInterval    time.Duration // период снятия стакана
+	MinStableLifetime time.Duration // минимальное время жизни стабильной стены
+	PullAlerts        bool          // публиковать сигналы о снятии стен
+	PullAlertMinUSD   float64       // минимальный размер снятой стены для сигнала
+	PullApproachPct   float64       // снятие ближе этого расстояния от цены, %
+}
+
+// DefaultConfig возвращает параметры по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		MaxSymbols:        40,
+		SampleInterval:    15 * time.Second,
+		MinStableLifetime: 2 * time.Minute,
+		PullAlerts:        false,
+		PullAlertMinUSD:   250_000,
+		PullApproachPct:   0.5,
+	}
+}
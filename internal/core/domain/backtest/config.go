@@ -0,0 +1,104 @@
+// internal/core/domain/backtest/config.go
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+)
+
+const (
+	// defaultHorizon — горизонт, по которому считается hit rate
+	defaultHorizon = "1h"
+	// defaultAnalyzer — анализатор, который запускается без файла конфигурации
+	defaultAnalyzer = "counter"
+)
+
+// AnalyzerSettings настройки анализатора в конфигурации бэктеста.
+// Незаданные custom_settings берутся из схемы анализатора.
+type AnalyzerSettings struct {
+	Enabled        *bool                  `json:"enabled,omitempty"`
+	MinConfidence  float64                `json:"min_confidence,omitempty"`
+	CustomSettings map[string]interface{} `json:"custom_settings,omitempty"`
+}
+
+// IsEnabled возвращает true, если анализатор не выключен явно
+func (s AnalyzerSettings) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// Config конфигурация прогона бэктеста (JSON). Запускаются только перечисленные анализаторы.
+//
+//	{
+//	  "name": "aggressive",
+//	  "analyzers": {"counter": {"custom_settings": {"growth_threshold": 1.5, "fall_threshold": 1.5}}},
+//	  "confirmations": 3,
+//	  "horizon": "1h"
+//	}
+type Config struct {
+	Name      string                      `json:"name"`
+	Analyzers map[string]AnalyzerSettings `json:"analyzers"`
+	// Confirmations — сколько сигналов подряд по символу и периоду нужно для отправки
+	// (как COUNTER_REQUIRED_CONFIRMATIONS в боте); 1 — каждый сигнал анализатора
+	Confirmations int `json:"confirmations"`
+	// Horizon — горизонт журнала (5m, 15m, 1h, 4h, 24h) для hit rate
+	Horizon string `json:"horizon"`
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию: counter с настройками схемы
+func DefaultConfig() Config {
+	return Config{
+		Name:          "default",
+		Analyzers:     map[string]AnalyzerSettings{defaultAnalyzer: {}},
+		Confirmations: 1,
+		Horizon:       defaultHorizon,
+	}
+}
+
+// LoadConfig читает конфигурацию из JSON файла; имя по умолчанию — имя файла
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("ошибка чтения конфигурации бэктеста: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("ошибка разбора %s: %w", path, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return cfg, cfg.normalize()
+}
+
+// normalize проверяет конфигурацию и заполняет значения по умолчанию
+func (c *Config) normalize() error {
+	if c.Name == "" {
+		c.Name = "default"
+	}
+	if len(c.Analyzers) == 0 {
+		c.Analyzers = map[string]AnalyzerSettings{defaultAnalyzer: {}}
+	}
+	if c.Confirmations < 1 {
+		c.Confirmations = 1
+	}
+	if c.Horizon == "" {
+		c.Horizon = defaultHorizon
+	}
+
+	for _, h := range journal.Horizons {
+		if h.Name == c.Horizon {
+			return nil
+		}
+	}
+	names := make([]string, len(journal.Horizons))
+	for i, h := range journal.Horizons {
+		names[i] = h.Name
+	}
+	return fmt.Errorf("неизвестный горизонт %q, доступны: %s", c.Horizon, strings.Join(names, ", "))
+}
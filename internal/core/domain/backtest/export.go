@@ -0,0 +1,198 @@
+// internal/core/domain/backtest/export.go
+package backtest
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+)
+
+// requiredConfirmationsKey настройка counter, в которую экспортируется confirmations
+const requiredConfirmationsKey = "required_confirmations"
+
+// counterEnvKeys переменные окружения настроек counter (internal/infrastructure/config/loader.go)
+var counterEnvKeys = map[string]string{
+	"base_period_minutes":      "COUNTER_BASE_PERIOD_MINUTES",
+	"analysis_period":          "COUNTER_ANALYSIS_PERIOD",
+	"growth_threshold":         "COUNTER_GROWTH_THRESHOLD",
+	"fall_threshold":           "COUNTER_FALL_THRESHOLD",
+	"min_volume_usd":           "COUNTER_MIN_VOLUME_USD",
+	"track_growth":             "COUNTER_TRACK_GROWTH",
+	"track_fall":               "COUNTER_TRACK_FALL",
+	"notify_on_signal":         "COUNTER_NOTIFY_ON_SIGNAL",
+	"notification_enabled":     "COUNTER_NOTIFICATION_ENABLED",
+	"notification_threshold":   "COUNTER_NOTIFICATION_THRESHOLD",
+	"required_confirmations":   "COUNTER_REQUIRED_CONFIRMATIONS",
+	"chart_provider":           "COUNTER_CHART_PROVIDER",
+	"max_signals_5m":           "COUNTER_MAX_SIGNALS_5MIN",
+	"max_signals_15m":          "COUNTER_MAX_SIGNALS_15MIN",
+	"max_signals_30m":          "COUNTER_MAX_SIGNALS_30MIN",
+	"max_signals_1h":           "COUNTER_MAX_SIGNALS_1HOUR",
+	"max_signals_4h":           "COUNTER_MAX_SIGNALS_4HOURS",
+	"max_signals_1d":           "COUNTER_MAX_SIGNALS_1DAY",
+	"confluence_enabled":       "COUNTER_CONFLUENCE_ENABLED",
+	"market_context_enabled":   "COUNTER_MARKET_CONTEXT_ENABLED",
+	"market_benchmarks":        "COUNTER_MARKET_BENCHMARKS",
+	"market_beta_window":       "COUNTER_MARKET_BETA_WINDOW",
+	"regime_threshold_factors": "COUNTER_REGIME_THRESHOLD_FACTORS",
+	"liquidity_enabled":        "COUNTER_LIQUIDITY_ENABLED",
+	"episodes_enabled":         "COUNTER_EPISODES_ENABLED",
+	"episode_pump_pct":         "COUNTER_EPISODE_PUMP_PCT",
+	"episode_volume_spike":     "COUNTER_EPISODE_VOLUME_SPIKE",
+	"episode_delta_pct":        "COUNTER_EPISODE_DELTA_PCT",
+	"episode_dump_retrace_pct": "COUNTER_EPISODE_DUMP_RETRACE_PCT",
+	"episode_ttl_minutes":      "COUNTER_EPISODE_TTL_MINUTES",
+}
+
+// WriteEnv пишет фрагмент .env с настройками counter набора rank (1 — лучший):
+// настройки базовой конфигурации и подобранные параметры в порядке схемы
+func WriteEnv(w io.Writer, result *SweepResult, rank int) error {
+	candidate, err := result.Candidate(rank)
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]interface{})
+	for key, value := range result.Base.Analyzers[sweepAnalyzer].CustomSettings {
+		settings[key] = value
+	}
+	// Порог подтверждений бэктеста — это COUNTER_REQUIRED_CONFIRMATIONS бота
+	settings[requiredConfirmationsKey] = result.Base.Confirmations
+	for key, value := range candidate.Params {
+		if key == ConfirmationsKey {
+			key = requiredConfirmationsKey
+		}
+		settings[key] = value
+	}
+
+	// Порядок схемы, как в configs/prod/.env.example
+	var keys []string
+	if def, ok := analyzers.Lookup(sweepAnalyzer); ok {
+		for _, key := range def.Settings.Keys() {
+			if _, ok := settings[key]; ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "# Настройки counter: оптимизация %s, набор #%d из %d\n", result.Name, candidate.Rank, len(result.Candidates))
+	fmt.Fprintf(w, "# Период %s — %s, символов %d, окон walk-forward %d\n",
+		result.Period.From.Format("2006-01-02 15:04"), result.Period.To.Format("2006-01-02 15:04"),
+		len(result.Symbols), len(result.Folds))
+	fmt.Fprintf(w, "# Цель %s (горизонт %s): за период %s; сигналов в день %.1f, hit rate %.1f%%\n",
+		objectiveLabel(result), result.Horizon, formatScore(result.Objective, candidate.Score),
+		candidate.Full.SignalsPerDay, candidate.Full.HitRate)
+	if len(result.WalkForward) > 0 {
+		fmt.Fprintf(w, "# Ожидание вне выборки (walk-forward, выбор по обучению): %s\n",
+			formatScore(result.Objective, result.OutOfSample))
+	}
+	fmt.Fprintln(w, "# Замените соответствующие строки COUNTER_* в configs/prod/.env")
+	for _, key := range keys {
+		env, ok := counterEnvKeys[key]
+		if !ok {
+			fmt.Fprintf(w, "# %s=%s (нет переменной окружения)\n", key, envValue(settings[key]))
+			continue
+		}
+		fmt.Fprintf(w, "%s=%s\n", env, envValue(settings[key]))
+	}
+	return nil
+}
+
+// WriteSweepTable пишет рейтинг top наборов и выбор по окнам walk-forward.
+// Колонка «вне выборки» — только для отчета, рейтинг строится по всему периоду.
+func WriteSweepTable(w io.Writer, result *SweepResult, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "# %s: %s, наборов %d, цель %s, горизонт %s, потоков %d, время %s\n",
+		result.Name, result.Search, len(result.Candidates), objectiveLabel(result),
+		result.Horizon, result.Workers, result.Duration)
+	fmt.Fprintf(tw, "#   период %s — %s, символов %d\n",
+		result.Period.From.Format("2006-01-02 15:04"), result.Period.To.Format("2006-01-02 15:04"), len(result.Symbols))
+	for k, fold := range result.Folds {
+		fmt.Fprintf(tw, "#   окно %d: обучение до %s, проверка %s — %s\n", k+1,
+			fold.Train.To.Format("2006-01-02 15:04"),
+			fold.Test.From.Format("2006-01-02 15:04"), fold.Test.To.Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintln(tw)
+
+	header := []string{"#"}
+	header = append(header, result.Parameters...)
+	header = append(header, "за период", "обучение", "вне выборки", "сигналов/день", "hit%", "ret "+result.Horizon, "оценено")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	if top <= 0 || top > len(result.Candidates) {
+		top = len(result.Candidates)
+	}
+	for _, c := range result.Candidates[:top] {
+		row := []string{strconv.Itoa(c.Rank)}
+		for _, key := range result.Parameters {
+			row = append(row, envValue(c.Params[key]))
+		}
+		if c.Error != "" {
+			row = append(row, "ошибка: "+c.Error)
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+			continue
+		}
+		score := formatScore(result.Objective, c.Score)
+		if !c.Valid {
+			score += " (мало сигналов)"
+		}
+		row = append(row,
+			score,
+			formatScore(result.Objective, c.TrainScore),
+			formatScore(result.Objective, c.TestScore),
+			fmt.Sprintf("%.1f", c.Full.SignalsPerDay),
+			fmt.Sprintf("%.1f", c.Full.HitRate),
+			fmt.Sprintf("%+.3f", c.Full.AvgReturn),
+			strconv.Itoa(c.Full.Evaluated),
+		)
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(result.WalkForward) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Walk-forward (лучший на обучении → результат на проверке):")
+		for _, pick := range result.WalkForward {
+			fmt.Fprintf(w, "  окно %d: набор #%d, обучение %s → проверка %s\n", pick.Fold, pick.Candidate,
+				formatScore(result.Objective, pick.TrainScore), formatScore(result.Objective, pick.TestScore))
+		}
+		fmt.Fprintf(w, "  средняя оценка вне выборки: %s\n", formatScore(result.Objective, result.OutOfSample))
+	}
+	return nil
+}
+
+func objectiveLabel(result *SweepResult) string {
+	if result.Objective == ObjectivePrecision {
+		return fmt.Sprintf("precision@%g/день", result.SignalsPerDay)
+	}
+	return result.Objective
+}
+
+// formatScore форматирует цель: доходность в %, precision — hit rate в %
+func formatScore(objective string, score float64) string {
+	if objective == ObjectivePrecision {
+		return fmt.Sprintf("%.1f%%", score)
+	}
+	return fmt.Sprintf("%+.3f%%", score)
+}
+
+// envValue форматирует значение настройки для .env
+func envValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,81 @@
+// internal/core/domain/backtest/fetch.go
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+const (
+	// maxFetchLimit — максимум свечей за один вызов GetKline
+	maxFetchLimit = 1000
+	// fetchRateLimit — пауза между REST-запросами (Bybit public: 120 req/min)
+	fetchRateLimit = 120 * time.Millisecond
+)
+
+// KlineFetcher источник исторических свечей (bybit.BybitClient)
+type KlineFetcher interface {
+	GetKline(symbol, interval string, limit int) ([]bybit.KlineCandle, error)
+}
+
+// FetchResult итог загрузки свечей символа
+type FetchResult struct {
+	Symbol string
+	Loaded int   // получено от биржи
+	Added  int   // новых в хранилище
+	Err    error // ошибка загрузки или сохранения
+}
+
+// Download загружает последние limit минутных свечей по символам и дописывает их в хранилище.
+// GetKline отдает только последние свечи, поэтому длинная история копится
+// повторными запусками (например, по cron раз в несколько часов).
+func Download(fetcher KlineFetcher, store *KlineStore, symbols []string, limit int) []FetchResult {
+	if limit <= 0 || limit > maxFetchLimit {
+		limit = maxFetchLimit
+	}
+
+	results := make([]FetchResult, 0, len(symbols))
+	for i, symbol := range symbols {
+		if i > 0 {
+			time.Sleep(fetchRateLimit)
+		}
+
+		result := FetchResult{Symbol: symbol}
+		candles, err := fetcher.GetKline(symbol, "1", limit)
+		if err != nil {
+			result.Err = fmt.Errorf("ошибка загрузки свечей: %w", err)
+			results = append(results, result)
+			continue
+		}
+
+		now := time.Now()
+		klines := make([]Kline, 0, len(candles))
+		for _, c := range candles {
+			k := Kline{
+				StartTime: time.UnixMilli(c.StartTime).UTC(),
+				Open:      c.Open,
+				High:      c.High,
+				Low:       c.Low,
+				Close:     c.Close,
+				Volume:    c.Volume,
+				Turnover:  c.Turnover,
+			}
+			// Последняя свеча еще формируется — сохраняем только закрытые
+			if k.EndTime().After(now) {
+				continue
+			}
+			klines = append(klines, k)
+		}
+		result.Loaded = len(klines)
+
+		result.Added, result.Err = store.Save(symbol, klines)
+		if result.Err == nil {
+			logger.Debug("📥 Backtest: %s — получено %d свечей, новых %d", symbol, result.Loaded, result.Added)
+		}
+		results = append(results, result)
+	}
+	return results
+}
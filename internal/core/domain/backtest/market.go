@@ -0,0 +1,756 @@
+// internal/core/domain/backtest/market.go
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	candletracker "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/candle_tracker"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/price_storage"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+)
+
+// priceWindow — окно минутных свечей для 24h метрик снапшота
+const priceWindow = 24 * 60
+
+// Market рынок бэктеста в памяти. Минутные свечи подаются по одной (Feed),
+// из них строятся свечи всех периодов и снапшот цены. Анализаторы видят рынок
+// через те же интерфейсы, что и в боте (CandleStorageInterface, PriceStorageInterface,
+// CandleTrackerInterface), но только на момент симулированного времени.
+//
+// Активные свечи анализаторам не отдаются: в боте их оценка завязана на
+// time.Since, а в бэктесте сигналы строятся только по закрытым свечам.
+type Market struct {
+	mu         sync.RWMutex
+	periods    []string
+	maxHistory int
+	symbols    map[string]*symbolMarket
+	now        time.Time
+	processed  map[string]struct{}
+}
+
+// symbolMarket состояние одного символа
+type symbolMarket struct {
+	active   map[string]*storage.Candle   // строящиеся свечи по периодам
+	history  map[string][]*storage.Candle // закрытые свечи, старые -> новые
+	window   []Kline                      // последние сутки минутных свечей
+	volume   float64                      // объем окна
+	turnover float64                      // оборот окна
+	total    float64                      // накопленный оборот с начала прогона (для VWAP)
+	snapshot storage.PriceSnapshot
+}
+
+// NewMarket создает рынок с периодами свечей и глубиной истории на период
+func NewMarket(periods []string, maxHistory int) *Market {
+	if maxHistory <= 0 {
+		maxHistory = 500
+	}
+	return &Market{
+		periods:    periods,
+		maxHistory: maxHistory,
+		symbols:    make(map[string]*symbolMarket),
+		processed:  make(map[string]struct{}),
+	}
+}
+
+// Now возвращает текущее симулированное время
+func (m *Market) Now() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.now
+}
+
+// Feed добавляет закрытую минутную свечу символа, сдвигает время рынка на ее закрытие
+// и возвращает точку цены для анализаторов
+func (m *Market) Feed(symbol string, k Kline) *storage.PriceData {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sm, ok := m.symbols[symbol]
+	if !ok {
+		sm = &symbolMarket{
+			active:  make(map[string]*storage.Candle),
+			history: make(map[string][]*storage.Candle),
+		}
+		m.symbols[symbol] = sm
+	}
+
+	if end := k.EndTime(); end.After(m.now) {
+		m.now = end
+	}
+
+	m.updateCandles(symbol, sm, k)
+	m.updateSnapshot(symbol, sm, k)
+
+	return &storage.PriceData{
+		Symbol:    symbol,
+		Price:     sm.snapshot.Price,
+		Volume24h: sm.snapshot.Volume24h,
+		VolumeUSD: sm.snapshot.VolumeUSD,
+		Timestamp: sm.snapshot.Timestamp,
+		Change24h: sm.snapshot.Change24h,
+		High24h:   sm.snapshot.High24h,
+		Low24h:    sm.snapshot.Low24h,
+	}
+}
+
+// CumulativeTurnover возвращает накопленный оборот символа (вход VWAP трекера)
+func (m *Market) CumulativeTurnover(symbol string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if sm, ok := m.symbols[symbol]; ok {
+		return sm.total
+	}
+	return 0
+}
+
+// Candles возвращает хранилище свечей рынка для CandleSystem
+func (m *Market) Candles() storage.CandleStorageInterface {
+	return &candleView{market: m}
+}
+
+// Prices возвращает хранилище цен рынка для анализаторов
+func (m *Market) Prices() storage.PriceStorageInterface {
+	return &priceView{market: m}
+}
+
+// Tracker возвращает трекер обработанных свечей в памяти
+func (m *Market) Tracker() candletracker.CandleTrackerInterface {
+	return &memoryTracker{market: m}
+}
+
+// updateCandles добавляет минутную свечу в свечи всех периодов
+func (m *Market) updateCandles(symbol string, sm *symbolMarket, k Kline) {
+	for _, period := range m.periods {
+		duration := periodPkg.PeriodToDuration(period)
+		start := k.StartTime.Truncate(duration)
+
+		c := sm.active[period]
+		if c != nil && !c.StartTime.Equal(start) {
+			// Разрыв в данных: закрываем недостроенную свечу
+			m.archive(sm, c)
+			c = nil
+		}
+
+		if c == nil {
+			c = &storage.Candle{
+				Symbol:     symbol,
+				Period:     period,
+				Open:       k.Open,
+				High:       k.High,
+				Low:        k.Low,
+				StartTime:  start,
+				EndTime:    start.Add(duration),
+				IsRealFlag: true,
+			}
+			sm.active[period] = c
+		}
+
+		if k.High > c.High {
+			c.High = k.High
+		}
+		if k.Low < c.Low {
+			c.Low = k.Low
+		}
+		c.Close = k.Close
+		c.Volume += k.Volume
+		c.VolumeUSD += k.Turnover
+		c.Trades++
+
+		if !k.EndTime().Before(c.EndTime) {
+			m.archive(sm, c)
+		}
+	}
+}
+
+// archive закрывает свечу и переносит ее в историю
+func (m *Market) archive(sm *symbolMarket, c *storage.Candle) {
+	c.IsClosedFlag = true
+	delete(sm.active, c.Period)
+
+	history := append(sm.history[c.Period], c)
+	if len(history) > m.maxHistory {
+		history = history[len(history)-m.maxHistory:]
+	}
+	sm.history[c.Period] = history
+}
+
+// updateSnapshot пересчитывает снапшот цены по окну последних суток
+func (m *Market) updateSnapshot(symbol string, sm *symbolMarket, k Kline) {
+	sm.window = append(sm.window, k)
+	sm.volume += k.Volume
+	sm.turnover += k.Turnover
+	sm.total += k.Turnover
+	for len(sm.window) > 0 && !sm.window[0].StartTime.After(k.StartTime.Add(-priceWindow*time.Minute)) {
+		sm.volume -= sm.window[0].Volume
+		sm.turnover -= sm.window[0].Turnover
+		sm.window = sm.window[1:]
+	}
+
+	high, low := k.High, k.Low
+	for _, w := range sm.window {
+		if w.High > high {
+			high = w.High
+		}
+		if w.Low < low {
+			low = w.Low
+		}
+	}
+
+	var change float64
+	if first := sm.window[0]; first.Open > 0 {
+		change = (k.Close - first.Open) / first.Open * 100
+	}
+
+	sm.snapshot = storage.PriceSnapshot{
+		Symbol:    symbol,
+		Price:     k.Close,
+		Volume24h: sm.volume,
+		VolumeUSD: sm.turnover,
+		Timestamp: k.EndTime(),
+		Change24h: change,
+		High24h:   high,
+		Low24h:    low,
+	}
+}
+
+// closedHistory возвращает последние limit закрытых свечей (старые -> новые)
+func (m *Market) closedHistory(symbol, period string, limit int) []*storage.Candle {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sm, ok := m.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	history := sm.history[period]
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return append([]*storage.Candle(nil), history...)
+}
+
+// symbolList возвращает символы рынка по алфавиту
+func (m *Market) symbolList() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	symbols := make([]string, 0, len(m.symbols))
+	for symbol := range m.symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// snapshotOf возвращает снапшот символа
+func (m *Market) snapshotOf(symbol string) (storage.PriceSnapshot, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sm, ok := m.symbols[symbol]
+	if !ok {
+		return storage.PriceSnapshot{}, false
+	}
+	return sm.snapshot, true
+}
+
+// pricePoints возвращает цены закрытия окна как точки истории (старые -> новые)
+func (m *Market) pricePoints(symbol string) []storage.PriceDataInterface {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sm, ok := m.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	points := make([]storage.PriceDataInterface, 0, len(sm.window))
+	for _, k := range sm.window {
+		points = append(points, &storage.PriceData{
+			Symbol:    symbol,
+			Price:     k.Close,
+			VolumeUSD: k.Turnover,
+			Volume24h: k.Volume,
+			Timestamp: k.EndTime(),
+		})
+	}
+	return points
+}
+
+// ============================================
+// CandleStorageInterface
+// ============================================
+
+// candleView хранилище свечей рынка
+type candleView struct {
+	market *Market
+}
+
+// SaveActiveCandle не используется: свечи строит сам рынок
+func (v *candleView) SaveActiveCandle(candle storage.CandleInterface) error {
+	return nil
+}
+
+// GetActiveCandle всегда пуст: активные свечи анализаторам не отдаются
+func (v *candleView) GetActiveCandle(symbol, period string) (storage.CandleInterface, bool) {
+	return nil, false
+}
+
+// CloseAndArchiveCandle добавляет готовую свечу в историю
+func (v *candleView) CloseAndArchiveCandle(candle storage.CandleInterface) error {
+	v.market.mu.Lock()
+	defer v.market.mu.Unlock()
+
+	sm, ok := v.market.symbols[candle.GetSymbol()]
+	if !ok {
+		return storage.ErrSymbolNotFound
+	}
+	v.market.archive(sm, &storage.Candle{
+		Symbol:     candle.GetSymbol(),
+		Period:     candle.GetPeriod(),
+		Open:       candle.GetOpen(),
+		High:       candle.GetHigh(),
+		Low:        candle.GetLow(),
+		Close:      candle.GetClose(),
+		Volume:     candle.GetVolume(),
+		VolumeUSD:  candle.GetVolumeUSD(),
+		Trades:     candle.GetTrades(),
+		StartTime:  candle.GetStartTime(),
+		EndTime:    candle.GetEndTime(),
+		IsRealFlag: candle.IsReal(),
+	})
+	return nil
+}
+
+// GetHistory возвращает закрытые свечи (старые -> новые)
+func (v *candleView) GetHistory(symbol, period string, limit int) ([]storage.CandleInterface, error) {
+	history := v.market.closedHistory(symbol, period, limit)
+	result := make([]storage.CandleInterface, len(history))
+	for i, c := range history {
+		result[i] = c
+	}
+	return result, nil
+}
+
+// GetLatestCandle возвращает последнюю закрытую свечу
+func (v *candleView) GetLatestCandle(symbol, period string) (storage.CandleInterface, bool) {
+	history := v.market.closedHistory(symbol, period, 1)
+	if len(history) == 0 {
+		return nil, false
+	}
+	return history[0], true
+}
+
+// GetCandle возвращает последнюю закрытую свечу
+func (v *candleView) GetCandle(symbol, period string) (storage.CandleInterface, error) {
+	candle, ok := v.GetLatestCandle(symbol, period)
+	if !ok {
+		return nil, fmt.Errorf("свеча %s/%s не найдена", symbol, period)
+	}
+	return candle, nil
+}
+
+// CleanupOldCandles не нужен: история ограничена maxHistory
+func (v *candleView) CleanupOldCandles(maxAge time.Duration) int {
+	return 0
+}
+
+// GetSymbols возвращает символы рынка
+func (v *candleView) GetSymbols() []string {
+	return v.market.symbolList()
+}
+
+// GetPeriodsForSymbol возвращает периоды, по которым есть закрытые свечи
+func (v *candleView) GetPeriodsForSymbol(symbol string) []string {
+	v.market.mu.RLock()
+	defer v.market.mu.RUnlock()
+
+	sm, ok := v.market.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	var periods []string
+	for _, period := range v.market.periods {
+		if len(sm.history[period]) > 0 {
+			periods = append(periods, period)
+		}
+	}
+	return periods
+}
+
+// GetStats возвращает статистику свечей рынка
+func (v *candleView) GetStats() storage.CandleStatsInterface {
+	v.market.mu.RLock()
+	defer v.market.mu.RUnlock()
+
+	stats := &candleStats{
+		symbols: len(v.market.symbols),
+		periods: make(map[string]int),
+	}
+	for _, sm := range v.market.symbols {
+		stats.active += len(sm.active)
+		for period, history := range sm.history {
+			stats.total += len(history)
+			stats.periods[period] += len(history)
+			if len(history) == 0 {
+				continue
+			}
+			if first := history[0].StartTime; stats.oldest.IsZero() || first.Before(stats.oldest) {
+				stats.oldest = first
+			}
+			if last := history[len(history)-1].StartTime; last.After(stats.newest) {
+				stats.newest = last
+			}
+		}
+	}
+	return stats
+}
+
+// candleStats статистика свечей рынка
+type candleStats struct {
+	total, active, symbols int
+	oldest, newest         time.Time
+	periods                map[string]int
+}
+
+func (s *candleStats) GetTotalCandles() int            { return s.total }
+func (s *candleStats) GetActiveCandles() int           { return s.active }
+func (s *candleStats) GetSymbolsCount() int            { return s.symbols }
+func (s *candleStats) GetOldestCandle() time.Time      { return s.oldest }
+func (s *candleStats) GetNewestCandle() time.Time      { return s.newest }
+func (s *candleStats) GetPeriodsCount() map[string]int { return s.periods }
+
+// ============================================
+// PriceStorageInterface
+// ============================================
+
+// priceView хранилище цен рынка: текущий снапшот и минутная история за сутки.
+// Запись и подписки не поддерживаются — цены подает только Market.Feed.
+type priceView struct {
+	market *Market
+}
+
+func (v *priceView) Initialize() error { return nil }
+
+func (v *priceView) StorePrice(symbol string, price, volume24h, volumeUSD float64, timestamp time.Time,
+	openInterest, fundingRate, change24h, high24h, low24h float64) error {
+	return nil
+}
+
+func (v *priceView) StorePriceData(priceData storage.PriceDataInterface) error { return nil }
+
+func (v *priceView) StorePriceLegacy(symbol string, price, volume24h float64, timestamp time.Time) error {
+	return nil
+}
+
+func (v *priceView) GetCurrentPrice(symbol string) (float64, bool) {
+	snapshot, ok := v.market.snapshotOf(symbol)
+	return snapshot.Price, ok
+}
+
+func (v *priceView) GetCurrentSnapshot(symbol string) (storage.PriceSnapshotInterface, bool) {
+	snapshot, ok := v.market.snapshotOf(symbol)
+	if !ok {
+		return nil, false
+	}
+	return &snapshot, true
+}
+
+func (v *priceView) GetAllCurrentPrices() map[string]storage.PriceSnapshotInterface {
+	result := make(map[string]storage.PriceSnapshotInterface)
+	for _, symbol := range v.market.symbolList() {
+		if snapshot, ok := v.GetCurrentSnapshot(symbol); ok {
+			result[symbol] = snapshot
+		}
+	}
+	return result
+}
+
+func (v *priceView) GetSymbols() []string { return v.market.symbolList() }
+
+func (v *priceView) SymbolExists(symbol string) bool {
+	_, ok := v.market.snapshotOf(symbol)
+	return ok
+}
+
+// GetPriceHistory возвращает последние limit точек (новые -> старые, как Redis)
+func (v *priceView) GetPriceHistory(symbol string, limit int) ([]storage.PriceDataInterface, error) {
+	points := v.market.pricePoints(symbol)
+	if limit > 0 && len(points) > limit {
+		points = points[len(points)-limit:]
+	}
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return points, nil
+}
+
+// GetPriceHistoryRange возвращает точки в диапазоне (старые -> новые)
+func (v *priceView) GetPriceHistoryRange(symbol string, start, end time.Time) ([]storage.PriceDataInterface, error) {
+	var result []storage.PriceDataInterface
+	for _, p := range v.market.pricePoints(symbol) {
+		if ts := p.GetTimestamp(); !ts.Before(start) && !ts.After(end) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (v *priceView) GetLatestPrice(symbol string) (storage.PriceDataInterface, bool) {
+	snapshot, ok := v.market.snapshotOf(symbol)
+	if !ok {
+		return nil, false
+	}
+	return &storage.PriceData{
+		Symbol:    snapshot.Symbol,
+		Price:     snapshot.Price,
+		Volume24h: snapshot.Volume24h,
+		VolumeUSD: snapshot.VolumeUSD,
+		Timestamp: snapshot.Timestamp,
+		Change24h: snapshot.Change24h,
+		High24h:   snapshot.High24h,
+		Low24h:    snapshot.Low24h,
+	}, true
+}
+
+// CalculatePriceChange считает изменение цены за interval от симулированного времени
+func (v *priceView) CalculatePriceChange(symbol string, interval time.Duration) (storage.PriceChangeInterface, error) {
+	snapshot, ok := v.market.snapshotOf(symbol)
+	if !ok {
+		return nil, storage.ErrSymbolNotFound
+	}
+
+	target := snapshot.Timestamp.Add(-interval)
+	var previous storage.PriceDataInterface
+	for _, p := range v.market.pricePoints(symbol) {
+		if p.GetTimestamp().After(target) {
+			break
+		}
+		previous = p
+	}
+	if previous == nil || previous.GetPrice() == 0 {
+		return nil, storage.ErrSymbolNotFound
+	}
+
+	change := snapshot.Price - previous.GetPrice()
+	return &price_storage.PriceChange{
+		Symbol:        symbol,
+		CurrentPrice:  snapshot.Price,
+		PreviousPrice: previous.GetPrice(),
+		Change:        change,
+		ChangePercent: change / previous.GetPrice() * 100,
+		Interval:      interval.String(),
+		Timestamp:     snapshot.Timestamp,
+		VolumeUSD:     snapshot.VolumeUSD,
+	}, nil
+}
+
+func (v *priceView) GetAveragePrice(symbol string, period time.Duration) (float64, error) {
+	points := v.recentPoints(symbol, period)
+	if len(points) == 0 {
+		return 0, storage.ErrSymbolNotFound
+	}
+	var sum float64
+	for _, p := range points {
+		sum += p.GetPrice()
+	}
+	return sum / float64(len(points)), nil
+}
+
+func (v *priceView) GetMinMaxPrice(symbol string, period time.Duration) (min, max float64, err error) {
+	points := v.recentPoints(symbol, period)
+	if len(points) == 0 {
+		return 0, 0, storage.ErrSymbolNotFound
+	}
+	min, max = points[0].GetPrice(), points[0].GetPrice()
+	for _, p := range points[1:] {
+		if p.GetPrice() < min {
+			min = p.GetPrice()
+		}
+		if p.GetPrice() > max {
+			max = p.GetPrice()
+		}
+	}
+	return min, max, nil
+}
+
+// recentPoints возвращает точки за последние period от симулированного времени
+func (v *priceView) recentPoints(symbol string, period time.Duration) []storage.PriceDataInterface {
+	now := v.market.Now()
+	points, _ := v.GetPriceHistoryRange(symbol, now.Add(-period), now)
+	return points
+}
+
+// Open interest и фандинг в минутных свечах отсутствуют
+func (v *priceView) GetOpenInterest(symbol string) (float64, bool) { return 0, false }
+func (v *priceView) GetFundingRate(symbol string) (float64, bool)  { return 0, false }
+
+func (v *priceView) GetSymbolMetrics(symbol string) (storage.SymbolMetricsInterface, bool) {
+	snapshot, ok := v.market.snapshotOf(symbol)
+	if !ok {
+		return nil, false
+	}
+	return &storage.SymbolMetrics{
+		Symbol:    snapshot.Symbol,
+		Price:     snapshot.Price,
+		Volume24h: snapshot.Volume24h,
+		VolumeUSD: snapshot.VolumeUSD,
+		Change24h: snapshot.Change24h,
+		High24h:   snapshot.High24h,
+		Low24h:    snapshot.Low24h,
+		Timestamp: snapshot.Timestamp,
+	}, true
+}
+
+func (v *priceView) Subscribe(symbol string, subscriber storage.SubscriberInterface) error {
+	return nil
+}
+
+func (v *priceView) Unsubscribe(symbol string, subscriber storage.SubscriberInterface) error {
+	return nil
+}
+
+func (v *priceView) GetSubscriberCount(symbol string) int { return 0 }
+
+func (v *priceView) CleanOldData(maxAge time.Duration) (int, error)     { return 0, nil }
+func (v *priceView) TruncateHistory(symbol string, maxPoints int) error { return nil }
+func (v *priceView) RemoveSymbol(symbol string) error                   { return nil }
+func (v *priceView) Clear() error                                       { return nil }
+func (v *priceView) FindSymbolsByPattern(pattern string) ([]string, error) {
+	return v.filterSymbols(pattern), nil
+}
+func (v *priceView) GetTopSymbolsByVolume(limit int) ([]storage.SymbolVolumeInterface, error) {
+	return v.topByVolume(limit), nil
+}
+
+func (v *priceView) GetTopSymbolsByVolumeUSD(limit int) ([]storage.SymbolVolumeInterface, error) {
+	return v.topByVolume(limit), nil
+}
+
+func (v *priceView) GetStats() storage.StorageStatsInterface {
+	symbols := v.market.symbolList()
+	return &storage.StorageStats{
+		TotalSymbols:        len(symbols),
+		NewestTimestamp:     v.market.Now(),
+		StorageType:         "backtest",
+		MaxHistoryPerSymbol: priceWindow,
+		RetentionPeriod:     24 * time.Hour,
+	}
+}
+
+func (v *priceView) GetSymbolStats(symbol string) (storage.SymbolStatsInterface, error) {
+	snapshot, ok := v.market.snapshotOf(symbol)
+	if !ok {
+		return nil, storage.ErrSymbolNotFound
+	}
+	points := v.market.pricePoints(symbol)
+	return &storage.SymbolStats{
+		Symbol:         symbol,
+		DataPoints:     len(points),
+		FirstTimestamp: points[0].GetTimestamp(),
+		LastTimestamp:  snapshot.Timestamp,
+		CurrentPrice:   snapshot.Price,
+		AvgVolume24h:   snapshot.Volume24h,
+		AvgVolumeUSD:   snapshot.VolumeUSD,
+		PriceChange24h: snapshot.Change24h,
+		High24h:        snapshot.High24h,
+		Low24h:         snapshot.Low24h,
+	}, nil
+}
+
+// filterSymbols возвращает символы, содержащие pattern
+func (v *priceView) filterSymbols(pattern string) []string {
+	pattern = strings.ToUpper(strings.Trim(pattern, "*"))
+	var result []string
+	for _, symbol := range v.market.symbolList() {
+		if strings.Contains(symbol, pattern) {
+			result = append(result, symbol)
+		}
+	}
+	return result
+}
+
+// topByVolume возвращает символы по убыванию 24h оборота
+func (v *priceView) topByVolume(limit int) []storage.SymbolVolumeInterface {
+	var volumes []*storage.SymbolVolume
+	for _, symbol := range v.market.symbolList() {
+		snapshot, _ := v.market.snapshotOf(symbol)
+		volumes = append(volumes, &storage.SymbolVolume{
+			Symbol:    symbol,
+			Volume:    snapshot.Volume24h,
+			VolumeUSD: snapshot.VolumeUSD,
+		})
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].VolumeUSD > volumes[j].VolumeUSD })
+	if limit > 0 && len(volumes) > limit {
+		volumes = volumes[:limit]
+	}
+
+	result := make([]storage.SymbolVolumeInterface, len(volumes))
+	for i, vol := range volumes {
+		result[i] = vol
+	}
+	return result
+}
+
+// ============================================
+// CandleTrackerInterface
+// ============================================
+
+// memoryTracker трекер обработанных свечей в памяти
+type memoryTracker struct {
+	market *Market
+}
+
+func trackerKey(symbol, period string, startTime int64) string {
+	return fmt.Sprintf("%s:%s:%d", symbol, period, startTime)
+}
+
+func (t *memoryTracker) Initialize() error { return nil }
+
+func (t *memoryTracker) MarkCandleProcessedAtomically(symbol, period string, startTime int64) (bool, error) {
+	t.market.mu.Lock()
+	defer t.market.mu.Unlock()
+
+	key := trackerKey(symbol, period, startTime)
+	if _, ok := t.market.processed[key]; ok {
+		return false, nil
+	}
+	t.market.processed[key] = struct{}{}
+	return true, nil
+}
+
+func (t *memoryTracker) IsCandleProcessed(symbol, period string, startTime int64) (bool, error) {
+	t.market.mu.RLock()
+	defer t.market.mu.RUnlock()
+
+	_, ok := t.market.processed[trackerKey(symbol, period, startTime)]
+	return ok, nil
+}
+
+func (t *memoryTracker) MarkCandleProcessedUnsafe(symbol, period string, startTime int64) error {
+	t.market.mu.Lock()
+	defer t.market.mu.Unlock()
+
+	t.market.processed[trackerKey(symbol, period, startTime)] = struct{}{}
+	return nil
+}
+
+func (t *memoryTracker) CleanupOldEntries() (int64, error) { return 0, nil }
+
+func (t *memoryTracker) GetStats() (map[string]interface{}, error) {
+	t.market.mu.RLock()
+	defer t.market.mu.RUnlock()
+
+	return map[string]interface{}{
+		"type":            "memory",
+		"processed_count": len(t.market.processed),
+		"simulated_time":  t.market.now,
+	}, nil
+}
+
+func (t *memoryTracker) TestConnection() error { return nil }
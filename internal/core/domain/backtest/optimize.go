@@ -0,0 +1,757 @@
+// internal/core/domain/backtest/optimize.go
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+const (
+	SearchGrid   = "grid"
+	SearchRandom = "random"
+
+	// ObjectiveExpectancy — средняя доходность сигнала на горизонте, %
+	ObjectiveExpectancy = "expectancy"
+	// ObjectivePrecision — hit rate N самых уверенных сигналов в день (precision@N)
+	ObjectivePrecision = "precision"
+
+	// ConfirmationsKey — параметр перебора порога подтверждений (Config.Confirmations)
+	ConfirmationsKey = "confirmations"
+
+	// sweepAnalyzer — анализатор, настройки которого перебирает оптимизатор
+	sweepAnalyzer = "counter"
+
+	defaultSamples       = 50
+	defaultSplits        = 3
+	defaultMinSignals    = 10
+	defaultSignalsPerDay = 10
+	// maxCandidates ограничивает сетку: больше — используйте случайный поиск
+	maxCandidates = 5000
+)
+
+// confirmationsSpec описание параметра confirmations для приведения типов
+var confirmationsSpec = common.SettingSpec{
+	Key:         ConfirmationsKey,
+	Type:        common.SettingInt,
+	Default:     1,
+	Range:       &common.Range{Min: 1, Max: 100},
+	Description: "Сигналов подряд для отправки",
+}
+
+// Parameter измерение пространства поиска: настройка counter или confirmations.
+// Значения задаются списком values или диапазоном min..max с шагом step;
+// без шага диапазон доступен только случайному поиску.
+type Parameter struct {
+	Key    string        `json:"key"`
+	Values []interface{} `json:"values,omitempty"`
+	Min    float64       `json:"min,omitempty"`
+	Max    float64       `json:"max,omitempty"`
+	Step   float64       `json:"step,omitempty"`
+}
+
+// SweepConfig конфигурация оптимизации (JSON):
+//
+//	{
+//	  "name": "counter_sweep",
+//	  "base": {"analyzers": {"counter": {}}, "horizon": "1h"},
+//	  "parameters": [
+//	    {"key": "growth_threshold", "min": 0.5, "max": 3, "step": 0.5},
+//	    {"key": "confirmations", "values": [1, 2, 3]}
+//	  ],
+//	  "search": "grid",
+//	  "objective": "expectancy",
+//	  "splits": 3
+//	}
+type SweepConfig struct {
+	Name string `json:"name"`
+	// Base — конфигурация прогона, поверх которой подставляются параметры
+	Base       Config      `json:"base"`
+	Parameters []Parameter `json:"parameters"`
+	// Search — grid (все сочетания) или random (samples случайных сочетаний)
+	Search  string `json:"search"`
+	Samples int    `json:"samples"`
+	// Seed — зерно случайного поиска; 0 — от текущего времени (итоговое пишется в результат)
+	Seed int64 `json:"seed"`
+	// Objective — expectancy или precision
+	Objective string `json:"objective"`
+	// SignalsPerDay — N для precision@N
+	SignalsPerDay float64 `json:"signals_per_day"`
+	// MinSignals — минимум оцененных сигналов за период, чтобы набор участвовал в рейтинге
+	MinSignals int `json:"min_signals"`
+	// Splits — число окон walk-forward: период делится на splits+1 частей,
+	// окно k обучается на частях 0..k и проверяется на части k+1
+	Splits int `json:"splits"`
+}
+
+// LoadSweepConfig читает конфигурацию оптимизации; имя по умолчанию — имя файла
+func LoadSweepConfig(path string) (SweepConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SweepConfig{}, fmt.Errorf("ошибка чтения конфигурации оптимизации: %w", err)
+	}
+
+	var cfg SweepConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return SweepConfig{}, fmt.Errorf("ошибка разбора %s: %w", path, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return cfg, cfg.normalize()
+}
+
+// normalize проверяет конфигурацию и заполняет значения по умолчанию
+func (c *SweepConfig) normalize() error {
+	if c.Name == "" {
+		c.Name = "sweep"
+	}
+	if err := c.Base.normalize(); err != nil {
+		return err
+	}
+	if settings, ok := c.Base.Analyzers[sweepAnalyzer]; !ok || !settings.IsEnabled() {
+		return fmt.Errorf("базовая конфигурация должна включать анализатор %s", sweepAnalyzer)
+	}
+	if len(c.Parameters) == 0 {
+		return fmt.Errorf("не заданы параметры перебора")
+	}
+
+	switch c.Search {
+	case "":
+		c.Search = SearchGrid
+	case SearchGrid, SearchRandom:
+	default:
+		return fmt.Errorf("неизвестный поиск %q: %s, %s", c.Search, SearchGrid, SearchRandom)
+	}
+	if c.Samples <= 0 {
+		c.Samples = defaultSamples
+	}
+
+	switch c.Objective {
+	case "":
+		c.Objective = ObjectiveExpectancy
+	case ObjectiveExpectancy, ObjectivePrecision:
+	default:
+		return fmt.Errorf("неизвестная цель %q: %s, %s", c.Objective, ObjectiveExpectancy, ObjectivePrecision)
+	}
+	if c.SignalsPerDay <= 0 {
+		c.SignalsPerDay = defaultSignalsPerDay
+	}
+	if c.MinSignals <= 0 {
+		c.MinSignals = defaultMinSignals
+	}
+	if c.Splits <= 0 {
+		c.Splits = defaultSplits
+	}
+	return nil
+}
+
+// dimension параметр, приведенный к типу настройки
+type dimension struct {
+	key    string
+	spec   common.SettingSpec
+	values []interface{} // дискретные значения; пусто — непрерывный диапазон
+	min    float64
+	max    float64
+}
+
+// dimensions проверяет параметры по схеме counter и раскрывает диапазоны с шагом
+func (c *SweepConfig) dimensions() ([]dimension, error) {
+	def, ok := analyzers.Lookup(sweepAnalyzer)
+	if !ok {
+		return nil, fmt.Errorf("анализатор %s не зарегистрирован", sweepAnalyzer)
+	}
+
+	seen := make(map[string]bool, len(c.Parameters))
+	dims := make([]dimension, 0, len(c.Parameters))
+	for _, p := range c.Parameters {
+		if seen[p.Key] {
+			return nil, fmt.Errorf("параметр %s указан дважды", p.Key)
+		}
+		seen[p.Key] = true
+
+		spec := confirmationsSpec
+		if p.Key != ConfirmationsKey {
+			if spec, ok = def.Settings.Spec(p.Key); !ok {
+				return nil, fmt.Errorf("неизвестная настройка %s %q, доступны: %s, %s",
+					sweepAnalyzer, p.Key, strings.Join(def.Settings.Keys(), ", "), ConfirmationsKey)
+			}
+		}
+
+		d := dimension{key: p.Key, spec: spec, min: p.Min, max: p.Max}
+		raw := p.Values
+		if len(raw) == 0 {
+			if spec.Type != common.SettingInt && spec.Type != common.SettingFloat {
+				return nil, fmt.Errorf("%s: для нечисловой настройки нужен список values", p.Key)
+			}
+			if p.Max < p.Min {
+				return nil, fmt.Errorf("%s: max меньше min", p.Key)
+			}
+			step := p.Step
+			if spec.Type == common.SettingInt && step > 0 && step < 1 {
+				step = 1
+			}
+			if step > 0 {
+				for i := 0; ; i++ {
+					v := math.Round((p.Min+float64(i)*step)*1e8) / 1e8
+					if v > p.Max+1e-9 {
+						break
+					}
+					raw = append(raw, v)
+				}
+			}
+		}
+
+		for _, v := range raw {
+			converted, err := convertSetting(spec, v)
+			if err != nil {
+				return nil, err
+			}
+			d.values = append(d.values, converted)
+		}
+		if len(raw) == 0 {
+			// Концы непрерывного диапазона проверяются так же, как значения
+			for _, v := range []float64{p.Min, p.Max} {
+				if _, err := convertSetting(spec, v); err != nil {
+					return nil, err
+				}
+			}
+		}
+		dims = append(dims, d)
+	}
+	return dims, nil
+}
+
+// convertSetting приводит значение к типу настройки и проверяет диапазон схемы
+func convertSetting(spec common.SettingSpec, value interface{}) (interface{}, error) {
+	if spec.Type == common.SettingInt {
+		if f, ok := value.(float64); ok {
+			value = math.Round(f)
+		}
+	}
+	resolved, _, err := common.SettingsSchema{spec}.Resolve(map[string]interface{}{spec.Key: value})
+	if err != nil {
+		return nil, err
+	}
+	return resolved[spec.Key], nil
+}
+
+// candidates возвращает наборы параметров для прогона
+func (c *SweepConfig) candidates(dims []dimension) ([][]interface{}, error) {
+	if c.Search == SearchRandom {
+		return randomCandidates(dims, c.Samples, rand.New(rand.NewSource(c.Seed))), nil
+	}
+
+	total := 1
+	for _, d := range dims {
+		if len(d.values) == 0 {
+			return nil, fmt.Errorf("%s: для grid нужен step или values (или search: random)", d.key)
+		}
+		total *= len(d.values)
+		if total > maxCandidates {
+			return nil, fmt.Errorf("сетка больше %d сочетаний, уменьшите шаги или используйте search: random", maxCandidates)
+		}
+	}
+
+	sets := make([][]interface{}, 0, total)
+	current := make([]interface{}, len(dims))
+	var walk func(i int)
+	walk = func(i int) {
+		if i == len(dims) {
+			sets = append(sets, append([]interface{}(nil), current...))
+			return
+		}
+		for _, v := range dims[i].values {
+			current[i] = v
+			walk(i + 1)
+		}
+	}
+	walk(0)
+	return sets, nil
+}
+
+// randomCandidates выбирает до samples неповторяющихся случайных наборов
+func randomCandidates(dims []dimension, samples int, rng *rand.Rand) [][]interface{} {
+	seen := make(map[string]bool, samples)
+	var sets [][]interface{}
+	for attempt := 0; len(sets) < samples && attempt < samples*20; attempt++ {
+		set := make([]interface{}, len(dims))
+		for i, d := range dims {
+			switch {
+			case len(d.values) > 0:
+				set[i] = d.values[rng.Intn(len(d.values))]
+			case d.spec.Type == common.SettingInt:
+				set[i] = int(d.min) + rng.Intn(int(d.max)-int(d.min)+1)
+			default:
+				set[i] = math.Round((d.min+rng.Float64()*(d.max-d.min))*1e4) / 1e4
+			}
+		}
+		key := fmt.Sprint(set...)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// apply возвращает копию базовой конфигурации с параметрами набора
+func (c *SweepConfig) apply(dims []dimension, set []interface{}, name string) Config {
+	cfg := c.Base
+	cfg.Name = name
+	cfg.Analyzers = make(map[string]AnalyzerSettings, len(c.Base.Analyzers))
+	for analyzer, settings := range c.Base.Analyzers {
+		custom := make(map[string]interface{}, len(settings.CustomSettings)+len(dims))
+		for key, value := range settings.CustomSettings {
+			custom[key] = value
+		}
+		settings.CustomSettings = custom
+		cfg.Analyzers[analyzer] = settings
+	}
+
+	counter := cfg.Analyzers[sweepAnalyzer]
+	for i, d := range dims {
+		if d.key == ConfirmationsKey {
+			cfg.Confirmations = set[i].(int)
+			continue
+		}
+		counter.CustomSettings[d.key] = set[i]
+	}
+	cfg.Analyzers[sweepAnalyzer] = counter
+	return cfg
+}
+
+// Window интервал времени [From, To)
+type Window struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Days возвращает длину окна в днях
+func (w Window) Days() float64 {
+	return w.To.Sub(w.From).Hours() / 24
+}
+
+// Contains проверяет, попадает ли момент в окно
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.From) && t.Before(w.To)
+}
+
+// Fold окно walk-forward: обучение на Train, проверка на следующем за ним Test
+type Fold struct {
+	Train Window `json:"train"`
+	Test  Window `json:"test"`
+}
+
+// walkForward делит период на splits+1 равных частей с расширяющимся окном обучения
+func walkForward(from, to time.Time, splits int) []Fold {
+	segment := to.Sub(from) / time.Duration(splits+1)
+	folds := make([]Fold, splits)
+	for k := range folds {
+		trainEnd := from.Add(segment * time.Duration(k+1))
+		testEnd := trainEnd.Add(segment)
+		if k == splits-1 {
+			testEnd = to
+		}
+		folds[k] = Fold{
+			Train: Window{From: from, To: trainEnd},
+			Test:  Window{From: trainEnd, To: testEnd},
+		}
+	}
+	return folds
+}
+
+// WindowStats метрики набора параметров в одном окне
+type WindowStats struct {
+	Signals       int     `json:"signals"`
+	Evaluated     int     `json:"evaluated"`
+	SignalsPerDay float64 `json:"signals_per_day"`
+	HitRate       float64 `json:"hit_rate"`
+	AvgReturn     float64 `json:"avg_return"`
+	Score         float64 `json:"score"`
+}
+
+// FoldStats метрики набора параметров в окне walk-forward
+type FoldStats struct {
+	Train WindowStats `json:"train"`
+	Test  WindowStats `json:"test"`
+}
+
+// Candidate результат одного набора параметров
+type Candidate struct {
+	Rank   int                    `json:"rank"`
+	Params map[string]interface{} `json:"params"`
+	// Score — цель на всем периоде: последний шаг walk-forward (переобучение
+	// на всех данных), по нему строится рейтинг и выбирается набор для экспорта
+	Score float64 `json:"score"`
+	// TrainScore — среднее значение цели на окнах обучения
+	TrainScore float64 `json:"train_score"`
+	// TestScore — цель на всех тестовых окнах вместе; только для отчета,
+	// в выборе не участвует, иначе оценка вне выборки перестает быть честной
+	TestScore float64 `json:"test_score"`
+	// Valid — за период оценено не меньше min_signals сигналов
+	Valid bool        `json:"valid"`
+	Full  WindowStats `json:"full"`
+	Test  WindowStats `json:"test"`
+	Folds []FoldStats `json:"folds"`
+	Error string      `json:"error,omitempty"`
+}
+
+// FoldPick набор, лучший на обучении окна, и его результат на проверке
+type FoldPick struct {
+	Fold       int     `json:"fold"`
+	Candidate  int     `json:"candidate"` // rank в итоговом рейтинге
+	TrainScore float64 `json:"train_score"`
+	TestScore  float64 `json:"test_score"`
+}
+
+// SweepResult результат оптимизации, сохраняется в JSON для экспорта
+type SweepResult struct {
+	Name          string    `json:"name"`
+	Search        string    `json:"search"`
+	Seed          int64     `json:"seed,omitempty"`
+	Objective     string    `json:"objective"`
+	SignalsPerDay float64   `json:"signals_per_day,omitempty"`
+	MinSignals    int       `json:"min_signals"`
+	Horizon       string    `json:"horizon"`
+	Base          Config    `json:"base"`
+	Parameters    []string  `json:"parameters"`
+	Symbols       []string  `json:"symbols"`
+	Period        Window    `json:"period"`
+	Folds         []Fold    `json:"folds"`
+	Started       time.Time `json:"started"`
+	Duration      string    `json:"duration"`
+	Workers       int       `json:"workers"`
+	// WalkForward — выбор по обучению каждого окна; OutOfSample — средняя оценка этих выборов
+	// на проверке, т.е. ожидаемый результат набора, выбранного той же процедурой на всем периоде
+	WalkForward []FoldPick  `json:"walk_forward"`
+	OutOfSample float64     `json:"out_of_sample"`
+	Candidates  []Candidate `json:"candidates"`
+}
+
+// Best возвращает лучший допустимый набор по всему периоду (переобучение walk-forward)
+func (r *SweepResult) Best() (*Candidate, error) {
+	return r.Candidate(1)
+}
+
+// Candidate возвращает набор по месту в рейтинге; наборы без достаточного числа сигналов не выдаются
+func (r *SweepResult) Candidate(rank int) (*Candidate, error) {
+	for i := range r.Candidates {
+		c := &r.Candidates[i]
+		if c.Rank != rank {
+			continue
+		}
+		if !c.Valid {
+			return nil, fmt.Errorf("у набора #%d меньше %d оцененных сигналов за период", rank, r.MinSignals)
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("нет набора #%d (всего %d)", rank, len(r.Candidates))
+}
+
+// OptimizeOptions параметры оптимизации
+type OptimizeOptions struct {
+	Options
+	// Workers — параллельные прогоны; 0 — по числу ядер
+	Workers int
+	// Progress вызывается после каждого прогона
+	Progress func(done, total int)
+}
+
+// Optimize перебирает параметры counter, прогоняя каждый набор по всему периоду.
+// Сигналы делятся по окнам walk-forward по времени: параметры не подбираются
+// внутри прогона, поэтому один прогон дает метрики всех окон с одинаковым прогревом.
+// Walk-forward: в каждом окне набор выбирается по обучению и оценивается на проверке
+// (OutOfSample), затем выбор повторяется на всем периоде — по нему строится рейтинг.
+// Цель на тестовых окнах выводится только в отчете.
+func Optimize(store *KlineStore, sweep SweepConfig, opts OptimizeOptions) (*SweepResult, error) {
+	if err := sweep.normalize(); err != nil {
+		return nil, err
+	}
+	if sweep.Search == SearchRandom && sweep.Seed == 0 {
+		sweep.Seed = time.Now().UnixNano()
+	}
+
+	dims, err := sweep.dimensions()
+	if err != nil {
+		return nil, err
+	}
+	sets, err := sweep.candidates(dims)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := loadData(store, opts.Options)
+	if err != nil {
+		return nil, err
+	}
+	from, to := dataRange(data)
+	folds := walkForward(from, to, sweep.Splits)
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(sets) {
+		workers = len(sets)
+	}
+
+	result := &SweepResult{
+		Name:       sweep.Name,
+		Search:     sweep.Search,
+		Objective:  sweep.Objective,
+		MinSignals: sweep.MinSignals,
+		Horizon:    sweep.Base.Horizon,
+		Base:       sweep.Base,
+		Period:     Window{From: from, To: to},
+		Folds:      folds,
+		Started:    time.Now(),
+		Workers:    workers,
+		Candidates: make([]Candidate, len(sets)),
+	}
+	if sweep.Search == SearchRandom {
+		result.Seed = sweep.Seed
+	}
+	if sweep.Objective == ObjectivePrecision {
+		result.SignalsPerDay = sweep.SignalsPerDay
+	}
+	for _, d := range dims {
+		result.Parameters = append(result.Parameters, d.key)
+	}
+	for symbol := range data {
+		result.Symbols = append(result.Symbols, symbol)
+	}
+	sort.Strings(result.Symbols)
+
+	logger.Info("🔎 Оптимизация %s: %d наборов (%s), окон walk-forward %d, потоков %d",
+		sweep.Name, len(sets), sweep.Search, len(folds), workers)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+		jobs = make(chan int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result.Candidates[i] = sweep.evaluate(dims, sets[i], i, data, folds)
+				if opts.Progress != nil {
+					mu.Lock()
+					done++
+					opts.Progress(done, len(sets))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range sets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	rankCandidates(result.Candidates)
+	result.WalkForward, result.OutOfSample = pickPerFold(result.Candidates, len(folds), sweep.MinSignals)
+	result.Duration = time.Since(result.Started).Round(time.Second).String()
+	return result, nil
+}
+
+// evaluate прогоняет набор параметров и считает цель по окнам walk-forward
+func (c *SweepConfig) evaluate(dims []dimension, set []interface{}, index int, data map[string][]Kline, folds []Fold) Candidate {
+	candidate := Candidate{Params: make(map[string]interface{}, len(dims))}
+	for i, d := range dims {
+		candidate.Params[d.key] = set[i]
+	}
+
+	report, err := simulate(c.apply(dims, set, fmt.Sprintf("%s#%d", c.Name, index+1)), data)
+	if err != nil {
+		candidate.Error = err.Error()
+		return candidate
+	}
+
+	var testSignals []Signal
+	period := Window{From: folds[0].Train.From, To: folds[len(folds)-1].Test.To}
+	candidate.Full = c.score(signalsIn(report.Signals, period), period.Days())
+	candidate.Folds = make([]FoldStats, len(folds))
+	for k, fold := range folds {
+		test := signalsIn(report.Signals, fold.Test)
+		candidate.Folds[k] = FoldStats{
+			Train: c.score(signalsIn(report.Signals, fold.Train), fold.Train.Days()),
+			Test:  c.score(test, fold.Test.Days()),
+		}
+		candidate.TrainScore += candidate.Folds[k].Train.Score
+		testSignals = append(testSignals, test...)
+	}
+	candidate.TrainScore /= float64(len(folds))
+
+	testWindow := Window{From: folds[0].Test.From, To: folds[len(folds)-1].Test.To}
+	candidate.Test = c.score(testSignals, testWindow.Days())
+	candidate.TestScore = candidate.Test.Score
+	candidate.Score = candidate.Full.Score
+	candidate.Valid = candidate.Full.Evaluated >= c.MinSignals
+	return candidate
+}
+
+// signalsIn возвращает сигналы окна
+func signalsIn(signals []Signal, w Window) []Signal {
+	var result []Signal
+	for _, s := range signals {
+		if w.Contains(s.Record.SignalTime) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// score считает метрики окна и значение цели
+func (c *SweepConfig) score(signals []Signal, days float64) WindowStats {
+	stats := WindowStats{Signals: len(signals)}
+	if days > 0 {
+		stats.SignalsPerDay = float64(len(signals)) / days
+	}
+
+	type evaluated struct {
+		ret        float64
+		confidence float64
+	}
+	var rets []evaluated
+	var sum float64
+	hits := 0
+	for _, s := range signals {
+		ret, ok := directedReturn(s.Record, c.Base.Horizon)
+		if !ok {
+			continue
+		}
+		rets = append(rets, evaluated{ret: ret, confidence: s.Record.Confidence})
+		sum += ret
+		if ret > 0 {
+			hits++
+		}
+	}
+	stats.Evaluated = len(rets)
+	if stats.Evaluated == 0 {
+		return stats
+	}
+	stats.HitRate = float64(hits) / float64(stats.Evaluated) * 100
+	stats.AvgReturn = sum / float64(stats.Evaluated)
+
+	switch c.Objective {
+	case ObjectivePrecision:
+		// Бот отправил бы не больше N сигналов в день — берем самые уверенные
+		limit := int(math.Ceil(c.SignalsPerDay * days))
+		sort.SliceStable(rets, func(i, j int) bool { return rets[i].confidence > rets[j].confidence })
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > len(rets) {
+			limit = len(rets)
+		}
+		top := 0
+		for _, r := range rets[:limit] {
+			if r.ret > 0 {
+				top++
+			}
+		}
+		stats.Score = float64(top) / float64(limit) * 100
+	default:
+		stats.Score = stats.AvgReturn
+	}
+	return stats
+}
+
+// rankCandidates сортирует наборы: допустимые по убыванию цели на всем периоде, затем остальные.
+// Тестовые окна в сортировке не участвуют.
+func rankCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Valid != b.Valid {
+			return a.Valid
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Full.Evaluated > b.Full.Evaluated
+	})
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+}
+
+// pickPerFold выбирает в каждом окне лучший набор по обучению и берет его оценку на проверке.
+// Средняя оценка выборов — честная оценка процедуры подбора без заглядывания вперед.
+func pickPerFold(candidates []Candidate, folds, minSignals int) ([]FoldPick, float64) {
+	var (
+		picks []FoldPick
+		sum   float64
+	)
+	for k := 0; k < folds; k++ {
+		var best *Candidate
+		for i := range candidates {
+			c := &candidates[i]
+			if c.Error != "" || c.Folds[k].Train.Evaluated < minSignals {
+				continue
+			}
+			if best == nil || c.Folds[k].Train.Score > best.Folds[k].Train.Score {
+				best = c
+			}
+		}
+		if best == nil {
+			continue
+		}
+		picks = append(picks, FoldPick{
+			Fold:       k + 1,
+			Candidate:  best.Rank,
+			TrainScore: best.Folds[k].Train.Score,
+			TestScore:  best.Folds[k].Test.Score,
+		})
+		sum += best.Folds[k].Test.Score
+	}
+	if len(picks) == 0 {
+		return nil, 0
+	}
+	return picks, sum / float64(len(picks))
+}
+
+// SaveSweepResult сохраняет результат оптимизации в JSON
+func SaveSweepResult(path string, result *SweepResult) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("ошибка создания каталога результатов: %w", err)
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("ошибка записи результатов: %w", err)
+	}
+	return nil
+}
+
+// LoadSweepResult читает сохраненный результат оптимизации
+func LoadSweepResult(path string) (*SweepResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения результатов: %w", err)
+	}
+	var result SweepResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("ошибка разбора %s: %w", path, err)
+	}
+	return &result, nil
+}
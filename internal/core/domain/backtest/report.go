@@ -0,0 +1,354 @@
+// internal/core/domain/backtest/report.go
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+)
+
+// Stats метрики группы сигналов. Доходности, MFE и MAE — в % по направлению сигнала
+// (для сигналов на падение рост цены — отрицательная доходность).
+type Stats struct {
+	Signals int `json:"signals"`
+	// Evaluated — сигналы с доходностью на основном горизонте (остальным не хватило данных)
+	Evaluated int     `json:"evaluated"`
+	Hits      int     `json:"hits"`
+	HitRate   float64 `json:"hit_rate"`
+	// AvgReturn — средняя доходность по горизонтам журнала (5m, 15m, 1h, 4h, 24h)
+	AvgReturn map[string]float64 `json:"avg_return"`
+	AvgMFE    float64            `json:"avg_mfe"`
+	AvgMAE    float64            `json:"avg_mae"`
+}
+
+// GroupStats метрики сигналов одного анализатора, направления или символа
+type GroupStats struct {
+	Key string `json:"key"`
+	Stats
+}
+
+// Report результат прогона одной конфигурации
+type Report struct {
+	Config        string           `json:"config"`
+	Confirmations int              `json:"confirmations"`
+	Horizon       string           `json:"horizon"`
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	Symbols       int              `json:"symbols"`
+	Steps         int              `json:"steps"`
+	Analyzers     []AnalyzerStatus `json:"analyzers"`
+	// RawSignals — сигналы анализаторов до подтверждений
+	RawSignals     int `json:"raw_signals"`
+	AnalyzerErrors int `json:"analyzer_errors"`
+
+	Total       Stats        `json:"total"`
+	ByAnalyzer  []GroupStats `json:"by_analyzer"`
+	ByDirection []GroupStats `json:"by_direction"`
+	BySymbol    []GroupStats `json:"by_symbol"`
+
+	// Signals — подтвержденные сигналы с исходами (в JSON — по запросу)
+	Signals []Signal `json:"signals,omitempty"`
+}
+
+// accumulator суммы для расчета Stats
+type accumulator struct {
+	signals, evaluated, hits int
+	retSum                   map[string]float64
+	retCount                 map[string]int
+	mfeSum, maeSum           float64
+	extremes                 int
+}
+
+func newAccumulator() *accumulator {
+	return &accumulator{retSum: make(map[string]float64), retCount: make(map[string]int)}
+}
+
+// add учитывает сигнал; hit — доходность на горизонте horizon больше нуля
+func (a *accumulator) add(rec *models.SignalRecord, horizon string) {
+	a.signals++
+	for _, h := range journal.Horizons {
+		ret, ok := directedReturn(rec, h.Name)
+		if !ok {
+			continue
+		}
+		a.retSum[h.Name] += ret
+		a.retCount[h.Name]++
+		if h.Name == horizon {
+			a.evaluated++
+			if ret > 0 {
+				a.hits++
+			}
+		}
+	}
+	if rec.MFEPct != nil && rec.MAEPct != nil {
+		a.mfeSum += *rec.MFEPct
+		a.maeSum += *rec.MAEPct
+		a.extremes++
+	}
+}
+
+func (a *accumulator) stats() Stats {
+	s := Stats{
+		Signals:   a.signals,
+		Evaluated: a.evaluated,
+		Hits:      a.hits,
+		AvgReturn: make(map[string]float64, len(a.retSum)),
+	}
+	if a.evaluated > 0 {
+		s.HitRate = float64(a.hits) / float64(a.evaluated) * 100
+	}
+	for name, sum := range a.retSum {
+		s.AvgReturn[name] = sum / float64(a.retCount[name])
+	}
+	if a.extremes > 0 {
+		s.AvgMFE = a.mfeSum / float64(a.extremes)
+		s.AvgMAE = a.maeSum / float64(a.extremes)
+	}
+	return s
+}
+
+// directedReturn возвращает доходность горизонта по направлению сигнала
+func directedReturn(rec *models.SignalRecord, horizon string) (float64, bool) {
+	var value *float64
+	switch horizon {
+	case "5m":
+		value = rec.Ret5m
+	case "15m":
+		value = rec.Ret15m
+	case "1h":
+		value = rec.Ret1h
+	case "4h":
+		value = rec.Ret4h
+	case "24h":
+		value = rec.Ret24h
+	}
+	if value == nil {
+		return 0, false
+	}
+	if rec.IsShort() {
+		return -*value, true
+	}
+	return *value, true
+}
+
+// directionKey сводит направления анализаторов (growth/up/bullish...) к long/short
+func directionKey(rec *models.SignalRecord) string {
+	if rec.IsShort() {
+		return "short"
+	}
+	return "long"
+}
+
+// newReport считает метрики сигналов прогона
+func newReport(cfg Config, signals []Signal) *Report {
+	total := newAccumulator()
+	byAnalyzer := make(map[string]*accumulator)
+	byDirection := make(map[string]*accumulator)
+	bySymbol := make(map[string]*accumulator)
+
+	group := func(groups map[string]*accumulator, key string) *accumulator {
+		acc, ok := groups[key]
+		if !ok {
+			acc = newAccumulator()
+			groups[key] = acc
+		}
+		return acc
+	}
+
+	for _, s := range signals {
+		total.add(s.Record, cfg.Horizon)
+		group(byAnalyzer, s.Analyzer).add(s.Record, cfg.Horizon)
+		group(byDirection, directionKey(s.Record)).add(s.Record, cfg.Horizon)
+		group(bySymbol, s.Record.Symbol).add(s.Record, cfg.Horizon)
+	}
+
+	return &Report{
+		Config:        cfg.Name,
+		Confirmations: cfg.Confirmations,
+		Horizon:       cfg.Horizon,
+		Total:         total.stats(),
+		ByAnalyzer:    groupStats(byAnalyzer),
+		ByDirection:   groupStats(byDirection),
+		BySymbol:      groupStats(bySymbol),
+		Signals:       signals,
+	}
+}
+
+// groupStats возвращает метрики групп по убыванию числа сигналов
+func groupStats(groups map[string]*accumulator) []GroupStats {
+	result := make([]GroupStats, 0, len(groups))
+	for key, acc := range groups {
+		result = append(result, GroupStats{Key: key, Stats: acc.stats()})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Signals != result[j].Signals {
+			return result[i].Signals > result[j].Signals
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// sections возвращает группы отчета в порядке вывода
+func (r *Report) sections() []struct {
+	name   string
+	groups []GroupStats
+} {
+	return []struct {
+		name   string
+		groups []GroupStats
+	}{
+		{"total", []GroupStats{{Key: "all", Stats: r.Total}}},
+		{"analyzer", r.ByAnalyzer},
+		{"direction", r.ByDirection},
+		{"symbol", r.BySymbol},
+	}
+}
+
+// WriteJSON пишет отчеты в JSON: один отчет — объектом, несколько — массивом.
+// withSignals=false убирает из вывода список сигналов.
+func WriteJSON(w io.Writer, withSignals bool, reports ...*Report) error {
+	out := make([]Report, len(reports))
+	for i, r := range reports {
+		out[i] = *r
+		if !withSignals {
+			out[i].Signals = nil
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if len(out) == 1 {
+		return enc.Encode(out[0])
+	}
+	return enc.Encode(out)
+}
+
+// WriteCSV пишет метрики групп всех отчетов: строка на конфигурацию и группу
+func WriteCSV(w io.Writer, reports ...*Report) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"config", "group", "key", "signals", "evaluated", "hits", "hit_rate"}
+	for _, h := range journal.Horizons {
+		header = append(header, "avg_ret_"+h.Name)
+	}
+	header = append(header, "avg_mfe", "avg_mae")
+	cw.Write(header)
+
+	for _, r := range reports {
+		for _, section := range r.sections() {
+			for _, g := range section.groups {
+				row := []string{
+					r.Config, section.name, g.Key,
+					strconv.Itoa(g.Signals), strconv.Itoa(g.Evaluated), strconv.Itoa(g.Hits),
+					csvFloat(g.HitRate),
+				}
+				for _, h := range journal.Horizons {
+					row = append(row, csvFloat(g.AvgReturn[h.Name]))
+				}
+				row = append(row, csvFloat(g.AvgMFE), csvFloat(g.AvgMAE))
+				cw.Write(row)
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTable пишет отчеты таблицей: конфигурации рядом, по строке на группу
+func WriteTable(w io.Writer, reports ...*Report) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for _, r := range reports {
+		var active []string
+		for _, a := range r.Analyzers {
+			if a.Reason != "" {
+				active = append(active, fmt.Sprintf("%s (%s: %s)", a.Name, a.State, a.Reason))
+			} else {
+				active = append(active, fmt.Sprintf("%s (%s)", a.Name, a.State))
+			}
+		}
+		fmt.Fprintf(tw, "# %s: %s — %s, символов %d, подтверждений %d, горизонт %s\n",
+			r.Config, r.From.Format("2006-01-02 15:04"), r.To.Format("2006-01-02 15:04"),
+			r.Symbols, r.Confirmations, r.Horizon)
+		fmt.Fprintf(tw, "#   анализаторы: %s; сигналов до подтверждений: %d\n", strings.Join(active, ", "), r.RawSignals)
+	}
+	fmt.Fprintln(tw)
+
+	sectionNames := map[string]string{
+		"total":     "Итого",
+		"analyzer":  "По анализаторам",
+		"direction": "По направлениям",
+		"symbol":    "По символам",
+	}
+
+	header := []string{"группа"}
+	for _, r := range reports {
+		header = append(header,
+			r.Config+" сигналы",
+			"hit%",
+			"ret "+r.Horizon,
+			"MFE",
+			"MAE",
+		)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for i, section := range reports[0].sections() {
+		// Строка раздела с пустыми колонками, чтобы tabwriter выравнивал всю таблицу
+		fmt.Fprintln(tw, sectionNames[section.name]+strings.Repeat("\t", len(header)-1))
+
+		// Ключи всех отчетов: группа может быть только в одной конфигурации
+		var keys []string
+		seen := make(map[string]bool)
+		byReport := make([]map[string]GroupStats, len(reports))
+		for j, r := range reports {
+			byReport[j] = make(map[string]GroupStats)
+			for _, g := range r.sections()[i].groups {
+				byReport[j][g.Key] = g
+				if !seen[g.Key] {
+					seen[g.Key] = true
+					keys = append(keys, g.Key)
+				}
+			}
+		}
+
+		for _, key := range keys {
+			row := []string{"  " + key}
+			for j, r := range reports {
+				g, ok := byReport[j][key]
+				if !ok {
+					row = append(row, "—", "—", "—", "—", "—")
+					continue
+				}
+				row = append(row,
+					strconv.Itoa(g.Signals),
+					fmt.Sprintf("%.1f", g.HitRate),
+					fmt.Sprintf("%+.3f", g.AvgReturn[r.Horizon]),
+					fmt.Sprintf("%.3f", g.AvgMFE),
+					fmt.Sprintf("%.3f", g.AvgMAE),
+				)
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func csvFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
@@ -0,0 +1,359 @@
+// internal/core/domain/backtest/runner.go
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/confirmation"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/pkg/logger"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+
+	// Анализаторы регистрируются в реестре из init() своих пакетов
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/anomaly"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/divergence"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/liquidity"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/patterns"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/range_breakout"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/vwap"
+)
+
+const (
+	// candleHistory — глубина истории закрытых свечей на период в рынке бэктеста
+	candleHistory = 500
+	// outcomeLookahead — сколько минутных свечей после сигнала нужно для исхода (24h + запас)
+	outcomeLookahead = 24*time.Hour + 15*time.Minute
+)
+
+// candlePeriods — периоды свечей, как в CandleSystem бота
+var candlePeriods = []string{"1m", "5m", "15m", "30m", "1h", "4h", "1d"}
+
+// Options параметры прогона
+type Options struct {
+	Symbols []string  // пусто — все символы хранилища
+	From    time.Time // нулевое — с начала данных
+	To      time.Time // нулевое — до конца данных
+}
+
+// AnalyzerStatus итог создания анализатора для прогона
+type AnalyzerStatus struct {
+	Name   string               `json:"name"`
+	State  common.AnalyzerState `json:"state"`
+	Reason string               `json:"reason,omitempty"`
+}
+
+// Signal подтвержденный сигнал прогона с исходом
+type Signal struct {
+	Analyzer string               `json:"analyzer"`
+	Record   *models.SignalRecord `json:"record"`
+}
+
+// runAnalyzer анализатор прогона со своим счетчиком подтверждений
+type runAnalyzer struct {
+	analyzer      common.Analyzer
+	confirmations *confirmation.ConfirmationManager
+	raw           int
+	errors        int
+}
+
+// Run прогоняет минутные свечи хранилища через анализаторы конфигурации.
+// Время симулируется: свечи подаются по минутам, анализаторы видят только
+// уже закрытые данные, а исходы сигналов считаются по следующим свечам
+// так же, как в журнале сигналов.
+func Run(store *KlineStore, cfg Config, opts Options) (*Report, error) {
+	if err := cfg.normalize(); err != nil {
+		return nil, err
+	}
+
+	data, err := loadData(store, opts)
+	if err != nil {
+		return nil, err
+	}
+	return simulate(cfg, data)
+}
+
+// simulate прогоняет загруженные свечи через анализаторы конфигурации.
+// data только читается, поэтому прогоны можно запускать параллельно.
+func simulate(cfg Config, data map[string][]Kline) (*Report, error) {
+	market := NewMarket(candlePeriods, candleHistory)
+	system, err := candle.NewCandleSystemFactory().
+		WithSupportedPeriods(candlePeriods).
+		CreateSystem(market.Prices(), market.Candles(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания свечной системы: %w", err)
+	}
+	system.SetCandleTracker(market.Tracker())
+
+	ctx := &analyzers.BuildContext{
+		Storage:      market.Prices(),
+		CandleSystem: system,
+		Now:          market.Now,
+	}
+	active, statuses, err := buildAnalyzers(cfg, ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stopAnalyzers(active)
+
+	symbols := make([]string, 0, len(data))
+	for symbol := range data {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var (
+		signals []Signal
+		steps   int
+		cursor  = make(map[string]int, len(symbols))
+	)
+	for _, minute := range timeline(data) {
+		steps++
+		for _, symbol := range symbols {
+			klines := data[symbol]
+			i := cursor[symbol]
+			if i >= len(klines) || !klines[i].StartTime.Equal(minute) {
+				continue
+			}
+			cursor[symbol] = i + 1
+
+			point := market.Feed(symbol, klines[i])
+			if tracker := system.VWAP(); tracker != nil {
+				tracker.Update(symbol, point.Price, market.CumulativeTurnover(symbol), point.Timestamp)
+			}
+
+			points := []storage.PriceDataInterface{point}
+			for _, ra := range active {
+				found, err := ra.analyzer.Analyze(points, ra.analyzer.GetConfig())
+				if err != nil {
+					ra.errors++
+					continue
+				}
+				for _, s := range found {
+					ra.raw++
+					s.Timestamp = point.Timestamp
+					confirmed, _ := ra.confirmations.AddConfirmationAt(
+						s.Symbol, periodPkg.MinutesToString(s.Period), s.Direction, point.Timestamp)
+					if !confirmed {
+						continue
+					}
+					signals = append(signals, Signal{Analyzer: ra.analyzer.Name(), Record: newRecord(s)})
+				}
+			}
+		}
+	}
+
+	dataEnd := market.Now()
+	for _, s := range signals {
+		journal.Evaluate(s.Record, outcomeCandles(data[s.Record.Symbol], s.Record.SignalTime), dataEnd)
+	}
+
+	report := newReport(cfg, signals)
+	report.Symbols = len(symbols)
+	report.Steps = steps
+	report.From, report.To = dataRange(data)
+	report.Analyzers = statuses
+	for _, ra := range active {
+		report.RawSignals += ra.raw
+		report.AnalyzerErrors += ra.errors
+	}
+
+	logger.Info("✅ Backtest %s: %d символов, %d минут, сигналов %d (до подтверждений %d)",
+		cfg.Name, report.Symbols, steps, len(signals), report.RawSignals)
+	return report, nil
+}
+
+// loadData загружает свечи символов; символы без данных пропускаются
+func loadData(store *KlineStore, opts Options) (map[string][]Kline, error) {
+	symbols := opts.Symbols
+	if len(symbols) == 0 {
+		var err error
+		if symbols, err = store.Symbols(); err != nil {
+			return nil, err
+		}
+	}
+
+	data := make(map[string][]Kline, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" {
+			continue
+		}
+		klines, err := store.Load(symbol, opts.From, opts.To)
+		if err != nil {
+			return nil, err
+		}
+		if len(klines) == 0 {
+			logger.Warn("⚠️ Backtest: нет свечей %s в %s", symbol, store.Dir())
+			continue
+		}
+		data[symbol] = klines
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("нет свечей для бэктеста в %s (сначала выполните fetch)", store.Dir())
+	}
+	return data, nil
+}
+
+// buildAnalyzers создает анализаторы конфигурации в порядке реестра
+func buildAnalyzers(cfg Config, ctx *analyzers.BuildContext) ([]*runAnalyzer, []AnalyzerStatus, error) {
+	for name := range cfg.Analyzers {
+		if _, ok := analyzers.Lookup(name); !ok {
+			return nil, nil, fmt.Errorf("неизвестный анализатор %q, доступны: %s",
+				name, strings.Join(analyzers.RegisteredNames(), ", "))
+		}
+	}
+
+	definitions, err := analyzers.OrderedDefinitions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		active   []*runAnalyzer
+		statuses []AnalyzerStatus
+	)
+	for _, def := range definitions {
+		settings, ok := cfg.Analyzers[def.Name]
+		if !ok {
+			continue
+		}
+		status := AnalyzerStatus{Name: def.Name, State: common.AnalyzerDisabled}
+		if !settings.IsEnabled() {
+			statuses = append(statuses, status)
+			continue
+		}
+
+		analyzerConfig, unknown, err := def.BuildConfig(settings.MinConfidence, settings.CustomSettings)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(unknown) > 0 {
+			return nil, nil, fmt.Errorf("%s: неизвестные настройки: %s", def.Name, strings.Join(unknown, ", "))
+		}
+
+		// Биржевые данные (стакан, OI, фандинг) и Redis в бэктесте недоступны
+		if missing := def.MissingDependencies(ctx); len(missing) > 0 {
+			status.State = common.AnalyzerSkipped
+			status.Reason = "нет зависимостей: " + strings.Join(missing, ", ")
+			statuses = append(statuses, status)
+			continue
+		}
+
+		analyzer, err := def.New(analyzerConfig, ctx)
+		if err != nil {
+			status.State = common.AnalyzerInvalid
+			status.Reason = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status.State = common.AnalyzerActive
+		statuses = append(statuses, status)
+		active = append(active, &runAnalyzer{
+			analyzer:      analyzer,
+			confirmations: confirmation.NewConfirmationManagerWithThreshold(cfg.Confirmations),
+		})
+	}
+
+	if len(active) == 0 {
+		return nil, statuses, fmt.Errorf("ни один анализатор конфигурации %s не может работать в бэктесте", cfg.Name)
+	}
+	return active, statuses, nil
+}
+
+// stopAnalyzers останавливает фоновые горутины анализаторов
+func stopAnalyzers(active []*runAnalyzer) {
+	for _, ra := range active {
+		if stopper, ok := ra.analyzer.(interface{ Stop() error }); ok {
+			stopper.Stop()
+		}
+	}
+}
+
+// timeline возвращает все минуты данных по возрастанию
+func timeline(data map[string][]Kline) []time.Time {
+	seen := make(map[int64]struct{})
+	var minutes []time.Time
+	for _, klines := range data {
+		for _, k := range klines {
+			key := k.StartTime.Unix()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			minutes = append(minutes, k.StartTime)
+		}
+	}
+	sort.Slice(minutes, func(i, j int) bool { return minutes[i].Before(minutes[j]) })
+	return minutes
+}
+
+// dataRange возвращает начало первой и конец последней свечи
+func dataRange(data map[string][]Kline) (from, to time.Time) {
+	for _, klines := range data {
+		if first := klines[0].StartTime; from.IsZero() || first.Before(from) {
+			from = first
+		}
+		if last := klines[len(klines)-1].EndTime(); last.After(to) {
+			to = last
+		}
+	}
+	return from, to
+}
+
+// newRecord переводит сигнал в запись журнала (как Recorder)
+func newRecord(s analysis.Signal) *models.SignalRecord {
+	return &models.SignalRecord{
+		SignalID:      s.ID,
+		Symbol:        s.Symbol,
+		SignalType:    s.Type,
+		Direction:     s.Direction,
+		Strategy:      s.Metadata.Strategy,
+		PeriodMinutes: s.Period,
+		Confidence:    s.Confidence,
+		ChangePercent: s.ChangePercent,
+		EntryPrice:    s.EndPrice,
+		SignalTime:    s.Timestamp,
+		Tags:          s.Metadata.Tags,
+		OutcomeStatus: models.SignalOutcomePending,
+	}
+}
+
+// outcomeCandles возвращает минутные свечи от момента сигнала до конца окна исхода
+func outcomeCandles(klines []Kline, signalTime time.Time) []*storage.Candle {
+	from := signalTime.Add(-time.Minute)
+	until := signalTime.Add(outcomeLookahead)
+
+	start := sort.Search(len(klines), func(i int) bool { return !klines[i].StartTime.Before(from) })
+	var candles []*storage.Candle
+	for _, k := range klines[start:] {
+		if k.StartTime.After(until) {
+			break
+		}
+		candles = append(candles, &storage.Candle{
+			Period:       "1m",
+			Open:         k.Open,
+			High:         k.High,
+			Low:          k.Low,
+			Close:        k.Close,
+			Volume:       k.Volume,
+			VolumeUSD:    k.Turnover,
+			StartTime:    k.StartTime,
+			EndTime:      k.EndTime(),
+			IsClosedFlag: true,
+			IsRealFlag:   true,
+		})
+	}
+	return candles
+}
@@ -0,0 +1,236 @@
+// internal/core/domain/backtest/store.go
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// klineFileSuffix — суффикс файла минутных свечей символа в хранилище
+const klineFileSuffix = "_1m.csv"
+
+// klineHeader — заголовок CSV файла свечей
+var klineHeader = []string{"start_ms", "open", "high", "low", "close", "volume", "turnover"}
+
+// Kline минутная свеча биржи
+type Kline struct {
+	StartTime time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64 // объем в базовой валюте
+	Turnover  float64 // оборот в USD
+}
+
+// EndTime возвращает время закрытия минутной свечи
+func (k Kline) EndTime() time.Time {
+	return k.StartTime.Add(time.Minute)
+}
+
+// KlineStore локальное хранилище минутных свечей: по CSV файлу на символ
+// (<dir>/<SYMBOL>_1m.csv). Файлы можно скачать командой fetch или положить
+// записанные ранее данные в том же формате.
+type KlineStore struct {
+	dir string
+}
+
+// NewKlineStore создает хранилище в каталоге dir
+func NewKlineStore(dir string) *KlineStore {
+	return &KlineStore{dir: dir}
+}
+
+// Dir возвращает каталог хранилища
+func (s *KlineStore) Dir() string {
+	return s.dir
+}
+
+// Symbols возвращает символы, для которых есть файлы свечей
+func (s *KlineStore) Symbols() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ошибка чтения каталога %s: %w", s.dir, err)
+	}
+
+	var symbols []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, klineFileSuffix) {
+			continue
+		}
+		symbols = append(symbols, strings.TrimSuffix(name, klineFileSuffix))
+	}
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+// Load загружает свечи символа в диапазоне [from, to) (нулевые границы — без ограничения),
+// отсортированные от старых к новым
+func (s *KlineStore) Load(symbol string, from, to time.Time) ([]Kline, error) {
+	klines, err := s.readAll(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := klines[:0]
+	for _, k := range klines {
+		if !from.IsZero() && k.StartTime.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !k.StartTime.Before(to) {
+			continue
+		}
+		filtered = append(filtered, k)
+	}
+	return filtered, nil
+}
+
+// Save добавляет свечи к файлу символа. Свечи с совпадающим временем начала
+// заменяются новыми, поэтому повторная загрузка безопасна.
+// Возвращает количество новых свечей.
+func (s *KlineStore) Save(symbol string, klines []Kline) (int, error) {
+	existing, err := s.readAll(symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	byStart := make(map[int64]Kline, len(existing)+len(klines))
+	for _, k := range existing {
+		byStart[k.StartTime.UnixMilli()] = k
+	}
+	added := 0
+	for _, k := range klines {
+		key := k.StartTime.UnixMilli()
+		if _, ok := byStart[key]; !ok {
+			added++
+		}
+		byStart[key] = k
+	}
+
+	merged := make([]Kline, 0, len(byStart))
+	for _, k := range byStart {
+		merged = append(merged, k)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].StartTime.Before(merged[j].StartTime) })
+
+	if err := s.writeAll(symbol, merged); err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// path возвращает путь к файлу свечей символа
+func (s *KlineStore) path(symbol string) string {
+	return filepath.Join(s.dir, strings.ToUpper(symbol)+klineFileSuffix)
+}
+
+// readAll читает все свечи символа; отсутствующий файл — пустая история
+func (s *KlineStore) readAll(symbol string) ([]Kline, error) {
+	file, err := os.Open(s.path(symbol))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ошибка открытия свечей %s: %w", symbol, err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения свечей %s: %w", symbol, err)
+	}
+
+	klines := make([]Kline, 0, len(rows))
+	for i, row := range rows {
+		if i == 0 && len(row) > 0 && row[0] == klineHeader[0] {
+			continue
+		}
+		k, err := parseKlineRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("%s, строка %d: %w", s.path(symbol), i+1, err)
+		}
+		klines = append(klines, k)
+	}
+	sort.Slice(klines, func(i, j int) bool { return klines[i].StartTime.Before(klines[j].StartTime) })
+	return klines, nil
+}
+
+// writeAll перезаписывает файл символа через временный файл
+func (s *KlineStore) writeAll(symbol string, klines []Kline) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("ошибка создания каталога %s: %w", s.dir, err)
+	}
+
+	tmp := s.path(symbol) + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("ошибка создания файла свечей %s: %w", symbol, err)
+	}
+
+	w := csv.NewWriter(file)
+	w.Write(klineHeader)
+	for _, k := range klines {
+		w.Write([]string{
+			strconv.FormatInt(k.StartTime.UnixMilli(), 10),
+			formatFloat(k.Open),
+			formatFloat(k.High),
+			formatFloat(k.Low),
+			formatFloat(k.Close),
+			formatFloat(k.Volume),
+			formatFloat(k.Turnover),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("ошибка записи свечей %s: %w", symbol, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("ошибка записи свечей %s: %w", symbol, err)
+	}
+	return os.Rename(tmp, s.path(symbol))
+}
+
+// parseKlineRow разбирает строку CSV
+func parseKlineRow(row []string) (Kline, error) {
+	if len(row) < len(klineHeader) {
+		return Kline{}, fmt.Errorf("ожидается %d колонок, получено %d", len(klineHeader), len(row))
+	}
+
+	startMs, err := strconv.ParseInt(row[0], 10, 64)
+	if err != nil {
+		return Kline{}, fmt.Errorf("неверное время %q", row[0])
+	}
+
+	values := make([]float64, len(klineHeader)-1)
+	for i := range values {
+		v, err := strconv.ParseFloat(row[i+1], 64)
+		if err != nil {
+			return Kline{}, fmt.Errorf("неверное значение %s=%q", klineHeader[i+1], row[i+1])
+		}
+		values[i] = v
+	}
+
+	return Kline{
+		StartTime: time.UnixMilli(startMs).UTC(),
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+		Turnover:  values[5],
+	}, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
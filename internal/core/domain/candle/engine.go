@@ -2,6 +2,7 @@
 package candle
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
 	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
 	events "crypto-exchange-screener-bot/internal/infrastructure/transport/event_bus"
 	"crypto-exchange-screener-bot/internal/types"
@@ -35,6 +36,9 @@ type CandleEngine struct {
 
 	// Подписчик на события
 	priceSubscriber types.EventSubscriber
+
+	// VWAP сессии и привязанный VWAP, считаются по каждому тику
+	vwap *vwap.Tracker
 }
 
 // NewCandleEngine создает новый движок свечей
@@ -51,6 +55,7 @@ func NewCandleEngine(
 		stopCh:        make(chan struct{}),
 		lastStatsLog:  time.Now(),
 		statsInterval: 60 * time.Second,
+		vwap:          vwap.NewTracker(),
 	}
 
 	// Создаем подписчика на события цен
@@ -208,6 +213,13 @@ func (ce *CandleEngine) processPriceData(priceData storage.PriceData) {
 	startTime := time.Now()
 	symbol := priceData.Symbol
 
+	// VWAP обновляем по каждому тику, независимо от периодов свечей
+	at := priceData.Timestamp
+	if at.IsZero() {
+		at = startTime
+	}
+	ce.vwap.Update(symbol, priceData.Price, priceData.VolumeUSD, at)
+
 	// Для каждого поддерживаемого периода
 	for _, period := range ce.config.SupportedPeriods {
 		buildResult := ce.buildCandleForPeriod(symbol, period, priceData)
@@ -546,6 +558,9 @@ func (ce *CandleEngine) cleanupRoutine() {
 			if removed > 0 {
 				logger.Debug("🧹 CandleEngine: очищено %d старых свечей", removed)
 			}
+			if removed := ce.vwap.Cleanup(vwap.MaxAnchorAge); removed > 0 {
+				logger.Debug("🧹 CandleEngine: удалено %d символов без обновлений VWAP", removed)
+			}
 		case <-ce.stopCh:
 			logger.Debug("🧹 CandleEngine: остановка очистки")
 			return
@@ -553,6 +568,11 @@ func (ce *CandleEngine) cleanupRoutine() {
 	}
 }
 
+// VWAP возвращает трекер VWAP (сессия от 00:00 UTC и привязанный VWAP)
+func (ce *CandleEngine) VWAP() *vwap.Tracker {
+	return ce.vwap
+}
+
 // GetStats возвращает статистику движка
 func (ce *CandleEngine) GetStats() map[string]interface{} {
 	ce.statsMu.RLock()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
 	redis_service "crypto-exchange-screener-bot/internal/infrastructure/cache/redis"
 	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
 	candletracker "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/candle_tracker"
@@ -22,7 +23,7 @@ type CandleSystem struct {
 	Storage       storage.CandleStorageInterface
 	Engine        *CandleEngine
 	Calculator    *CandleCalculator
-	candleTracker *candletracker.CandleTracker
+	candleTracker candletracker.CandleTrackerInterface
 	priceStorage  storage.PriceStorageInterface
 	config        storage.CandleConfig
 	eventBus      *events.EventBus
@@ -135,14 +136,14 @@ func (f *CandleSystemFactory) CreateSystemWithRedis(
 	return system, nil
 }
 
-// SetCandleTracker устанавливает трекер свечей
-func (cs *CandleSystem) SetCandleTracker(tracker *candletracker.CandleTracker) {
+// SetCandleTracker устанавливает трекер свечей (Redis в боте, в памяти — в бэктесте)
+func (cs *CandleSystem) SetCandleTracker(tracker candletracker.CandleTrackerInterface) {
 	cs.candleTracker = tracker
 	logger.Info("✅ CandleTracker установлен в CandleSystem")
 }
 
 // GetCandleTracker возвращает трекер свечей
-func (cs *CandleSystem) GetCandleTracker() *candletracker.CandleTracker {
+func (cs *CandleSystem) GetCandleTracker() candletracker.CandleTrackerInterface {
 	return cs.candleTracker
 }
 
@@ -151,6 +152,14 @@ func (cs *CandleSystem) HasCandleTracker() bool {
 	return cs.candleTracker != nil
 }
 
+// VWAP возвращает трекер VWAP движка свечей (nil, если движок не создан)
+func (cs *CandleSystem) VWAP() *vwap.Tracker {
+	if cs == nil || cs.Engine == nil {
+		return nil
+	}
+	return cs.Engine.VWAP()
+}
+
 // Start запускает свечную систему
 func (cs *CandleSystem) Start() error {
 	logger.Info("🚀 Запуск свечной системы...")
@@ -325,6 +334,36 @@ func (cs *CandleSystem) GetCandleOrLatestClosed(symbol, period string) (*storage
 	return cs.GetLatestClosedCandle(symbol, period)
 }
 
+// historyScanner — хранилище, умеющее читать историю за период порциями (RedisCandleStorage)
+type historyScanner interface {
+	ScanHistory(symbol, period string, from, to time.Time, batch int, fn func(*storage.Candle) error) error
+}
+
+// scanFallbackLimit — сколько последних свечей читается, если хранилище не умеет сканировать историю
+const scanFallbackLimit = 1000
+
+// ScanHistory передает в fn закрытые свечи за [from, to) в порядке времени, не загружая
+// всю историю в память (для выгрузок); ошибка fn прерывает чтение
+func (cs *CandleSystem) ScanHistory(symbol, period string, from, to time.Time, fn func(*storage.Candle) error) error {
+	if scanner, ok := cs.Storage.(historyScanner); ok {
+		return scanner.ScanHistory(symbol, period, from, to, 500, fn)
+	}
+
+	candles, err := cs.GetHistory(symbol, period, scanFallbackLimit)
+	if err != nil {
+		return err
+	}
+	for _, c := range candles {
+		if c.StartTime.Before(from) || !c.StartTime.Before(to) {
+			continue
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetHistory возвращает историю свечей
 func (cs *CandleSystem) GetHistory(symbol, period string, limit int) ([]*storage.Candle, error) {
 	historyInterfaces, err := cs.Storage.GetHistory(symbol, period, limit)
@@ -0,0 +1,178 @@
+// internal/core/domain/digest/command.go
+package digest
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CommandHelp справка по команде /digest (без разметки)
+const CommandHelp = `📰 Дайджест рынка — сводка за сутки и за неделю
+
+/digest — текущие настройки
+/digest on 08:30 — получать суточный дайджест в 08:30 (время необязательно)
+/digest weekly пн 10:00 — получать и недельный дайджест (день и время необязательны)
+/digest weekly off — отключить недельный дайджест
+/digest time 21:00 — изменить время доставки
+/digest off — отключить дайджест
+/digest show | show weekly — последний дайджест сейчас
+
+В дайджесте: лидеры роста и падения, изменения OI, крайние фандинги,
+ликвидации, самые частые монеты в сигналах и исходы сигналов.
+Время — по вашему часовому поясу.`
+
+// weekdays названия дней недели для /digest weekly
+var weekdays = map[string]time.Weekday{
+	"вс": time.Sunday, "sun": time.Sunday,
+	"пн": time.Monday, "mon": time.Monday,
+	"вт": time.Tuesday, "tue": time.Tuesday,
+	"ср": time.Wednesday, "wed": time.Wednesday,
+	"чт": time.Thursday, "thu": time.Thursday,
+	"пт": time.Friday, "fri": time.Friday,
+	"сб": time.Saturday, "sat": time.Saturday,
+}
+
+// weekdayNames названия дней недели в настройках, с воскресенья
+var weekdayNames = []string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"}
+
+// Command выполняет команду /digest с аргументами и возвращает текст ответа (без разметки).
+// timezone — часовой пояс пользователя для отображения настроек.
+func (s *Service) Command(userID int, timezone, args string) string {
+	fields := strings.Fields(strings.ToLower(args))
+	action := ""
+	if len(fields) > 0 {
+		action = fields[0]
+	}
+
+	switch action {
+	case "show", "now", "last":
+		kind := models.DigestDaily
+		if len(fields) > 1 && (fields[1] == "weekly" || fields[1] == "week" || fields[1] == "неделя") {
+			kind = models.DigestWeekly
+		}
+		data, err := s.Latest(kind)
+		if err != nil {
+			return s.commandError(userID, err)
+		}
+		if data == nil {
+			return "Дайджест еще не построен — он появится после ближайшего построения"
+		}
+		return FormatDigest(*data)
+
+	case "help":
+		return CommandHelp
+	}
+
+	sub, err := s.Subscription(userID)
+	if err != nil {
+		return s.commandError(userID, err)
+	}
+
+	switch action {
+	case "", "status", "settings":
+		text := s.FormatSubscription(sub, timezone)
+		if !sub.Daily && !sub.Weekly {
+			text += "\n\nВключить: /digest on 09:00\nСправка: /digest help"
+		}
+		return text
+
+	case "on", "daily":
+		if err := applyTime(sub, fields[1:]); err != nil {
+			return "❌ " + err.Error()
+		}
+		sub.Daily = true
+
+	case "off":
+		sub.Daily, sub.Weekly = false, false
+
+	case "weekly", "week":
+		rest := fields[1:]
+		if len(rest) > 0 && rest[0] == "off" {
+			sub.Weekly = false
+			break
+		}
+		if len(rest) > 0 {
+			if day, ok := weekdays[rest[0]]; ok {
+				sub.Weekday = int(day)
+				rest = rest[1:]
+			}
+		}
+		if err := applyTime(sub, rest); err != nil {
+			return "❌ " + err.Error()
+		}
+		sub.Weekly = true
+
+	case "time":
+		if len(fields) < 2 {
+			return "Укажите время, например: /digest time 08:30"
+		}
+		if err := applyTime(sub, fields[1:]); err != nil {
+			return "❌ " + err.Error()
+		}
+
+	default:
+		return CommandHelp
+	}
+
+	if err := s.repo.SaveSubscription(sub); err != nil {
+		return s.commandError(userID, err)
+	}
+	return "✅ Настройки дайджеста сохранены\n\n" + s.FormatSubscription(sub, timezone)
+}
+
+// Subscription возвращает подписку пользователя или подписку по умолчанию (выключенную)
+func (s *Service) Subscription(userID int) (*models.DigestSubscription, error) {
+	sub, err := s.repo.GetSubscription(userID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		sub = &models.DigestSubscription{
+			UserID:     userID,
+			SendMinute: s.config.DefaultSendMinute,
+			Weekday:    int(weeklyDay),
+		}
+	}
+	return sub, nil
+}
+
+// FormatSubscription форматирует настройки дайджеста пользователя (без разметки)
+func (s *Service) FormatSubscription(sub *models.DigestSubscription, timezone string) string {
+	if timezone == "" {
+		timezone = s.config.DefaultTimezone
+	}
+	daily, weekly := "выключен", "выключен"
+	at := fmt.Sprintf("%02d:%02d", sub.SendMinute/60, sub.SendMinute%60)
+	if sub.Daily {
+		daily = "каждый день в " + at
+	}
+	if sub.Weekly && sub.Weekday >= 0 && sub.Weekday < len(weekdayNames) {
+		weekly = weekdayNames[sub.Weekday] + ", " + at
+	}
+	return fmt.Sprintf("📰 Дайджест рынка\n\nСуточный: %s\nНедельный: %s\nЧасовой пояс: %s", daily, weekly, label(timezone))
+}
+
+// applyTime применяет время доставки HH:MM, если оно указано
+func applyTime(sub *models.DigestSubscription, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("непонятные аргументы «%s»", strings.Join(args[1:], " "))
+	}
+	at, err := time.Parse("15:04", args[0])
+	if err != nil {
+		return fmt.Errorf("некорректное время «%s», ожидается ЧЧ:ММ", args[0])
+	}
+	sub.SendMinute = at.Hour()*60 + at.Minute()
+	return nil
+}
+
+// commandError логирует ошибку команды и возвращает текст для пользователя
+func (s *Service) commandError(userID int, err error) string {
+	logger.Warn("⚠️ Digest: user=%d: %v", userID, err)
+	return "❌ Не удалось выполнить команду, попробуйте позже"
+}
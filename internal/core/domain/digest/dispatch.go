@@ -0,0 +1,122 @@
+// internal/core/domain/digest/dispatch.go
+package digest
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+	"time"
+)
+
+// Насколько старый дайджест еще можно доставить
+const (
+	dailyMaxAge  = 48 * time.Hour
+	weeklyMaxAge = 8 * 24 * time.Hour
+)
+
+// Dispatch рассылает дайджесты подписчикам, у которых по их часовому поясу наступило
+// время доставки, а сегодня дайджест еще не доставлялся
+func (s *Service) Dispatch(now time.Time) error {
+	if s.eventBus == nil {
+		return fmt.Errorf("EventBus не задан")
+	}
+
+	subscriptions, err := s.repo.FindSubscribed()
+	if err != nil {
+		return err
+	}
+
+	due := map[string][]dueDelivery{}
+	for _, sub := range subscriptions {
+		local := now.In(s.location(sub.Timezone))
+		if local.Hour()*60+local.Minute() < sub.SendMinute {
+			continue
+		}
+		today := localDate(local)
+		if sub.Daily && !sameDate(sub.LastDailySent, today) {
+			due[models.DigestDaily] = append(due[models.DigestDaily], dueDelivery{userID: sub.UserID, day: today})
+		}
+		if sub.Weekly && int(local.Weekday()) == sub.Weekday && !sameDate(sub.LastWeeklySent, today) {
+			due[models.DigestWeekly] = append(due[models.DigestWeekly], dueDelivery{userID: sub.UserID, day: today})
+		}
+	}
+
+	for _, kind := range []string{models.DigestDaily, models.DigestWeekly} {
+		if len(due[kind]) == 0 {
+			continue
+		}
+		if err := s.deliver(kind, due[kind], now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dueDelivery пользователь, которому пора доставить дайджест, и его локальная дата
+type dueDelivery struct {
+	userID int
+	day    time.Time
+}
+
+// deliver публикует последний дайджест вида для получателей и отмечает доставку
+func (s *Service) deliver(kind string, recipients []dueDelivery, now time.Time) error {
+	data, err := s.Latest(kind)
+	if err != nil {
+		return err
+	}
+	maxAge := dailyMaxAge
+	if kind == models.DigestWeekly {
+		maxAge = weeklyMaxAge
+	}
+	if data == nil || now.Sub(data.To) > maxAge {
+		logger.Debug("📰 [Digest] Нет свежего дайджеста %s для %d получателей", kind, len(recipients))
+		return nil
+	}
+
+	for _, r := range recipients {
+		data.Recipients = append(data.Recipients, r.userID)
+	}
+	if err := s.eventBus.Publish(types.Event{
+		Type:      types.EventMarketDigest,
+		Source:    "market_digest",
+		Data:      *data,
+		Timestamp: now,
+	}); err != nil {
+		return fmt.Errorf("публикация дайджеста: %w", err)
+	}
+
+	for _, r := range recipients {
+		if err := s.repo.MarkSent(r.userID, kind, r.day); err != nil {
+			logger.Warn("⚠️ [Digest] user=%d: %v", r.userID, err)
+		}
+	}
+	logger.Info("📰 [Digest] Дайджест %s отправлен %d подписчикам", kind, len(recipients))
+	return nil
+}
+
+// location возвращает часовой пояс пользователя; неизвестный пояс — пояс по умолчанию
+func (s *Service) location(timezone string) *time.Location {
+	for _, name := range []string{timezone, s.config.DefaultTimezone} {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// localDate — дата местного времени (полночь UTC, как DATE из Postgres)
+func localDate(local time.Time) time.Time {
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sameDate проверяет, что дата последней доставки совпадает с day
+func sameDate(sent *time.Time, day time.Time) bool {
+	if sent == nil {
+		return false
+	}
+	return sent.Year() == day.Year() && sent.Month() == day.Month() && sent.Day() == day.Day()
+}
@@ -0,0 +1,95 @@
+// internal/core/domain/digest/format.go
+package digest
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"strings"
+)
+
+// FormatDigest форматирует дайджест рынка (без разметки)
+func FormatDigest(data types.MarketDigestData) string {
+	var b strings.Builder
+
+	title := "📰 Дайджест рынка за сутки"
+	if data.Kind == models.DigestWeekly {
+		title = "📰 Дайджест рынка за неделю"
+	}
+	b.WriteString(fmt.Sprintf("%s\n%s — %s UTC\n", title,
+		data.From.UTC().Format("02.01 15:04"), data.To.UTC().Format("02.01 15:04")))
+
+	writeValues(&b, "🚀 Рост", data.Gainers, "%+.2f%%")
+	writeValues(&b, "🔻 Падение", data.Losers, "%+.2f%%")
+	writeValues(&b, "📊 Открытый интерес", data.OIChanges, "%+.1f%%")
+	writeValues(&b, "💸 Фандинг выше всех", data.FundingHi, "%+.4f%%")
+	writeValues(&b, "💸 Фандинг ниже всех", data.FundingLo, "%+.4f%%")
+
+	if liq := data.Liquidations; liq.TotalUSD > 0 {
+		b.WriteString(fmt.Sprintf("\n💥 Ликвидации: %s (лонги %s, шорты %s)\n",
+			formatUSD(liq.TotalUSD), formatUSD(liq.LongUSD), formatUSD(liq.ShortUSD)))
+		parts := make([]string, 0, len(liq.Top))
+		for _, v := range liq.Top {
+			parts = append(parts, fmt.Sprintf("%s %s", v.Symbol, formatUSD(v.Value)))
+		}
+		if len(parts) > 0 {
+			b.WriteString(strings.Join(parts, ", ") + "\n")
+		}
+	}
+
+	if len(data.TopSignalled) > 0 {
+		b.WriteString("\n🔔 Чаще всего в сигналах\n")
+		for _, s := range data.TopSignalled {
+			b.WriteString(fmt.Sprintf("%s — %d %s\n", label(s.Key), s.Signals, formatOutcome(s)))
+		}
+	}
+
+	if data.Signals.Signals > 0 {
+		b.WriteString(fmt.Sprintf("\n🎯 Исходы сигналов (%s): %d сигналов %s\n",
+			data.Horizon, data.Signals.Signals, formatOutcome(data.Signals)))
+		for _, s := range data.Analyzers {
+			b.WriteString(fmt.Sprintf("• %s — %d %s\n", label(s.Key), s.Signals, formatOutcome(s)))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeValues пишет раздел «символ значение»; пустой раздел пропускается
+func writeValues(b *strings.Builder, title string, values []types.DigestValue, format string) {
+	if len(values) == 0 {
+		return
+	}
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, v.Symbol+" "+fmt.Sprintf(format, v.Value))
+	}
+	b.WriteString(fmt.Sprintf("\n%s: %s\n", title, strings.Join(parts, ", ")))
+}
+
+// formatOutcome — "(hit 55%, ср. +0.42%)" или пусто, если исходы еще не оценены
+func formatOutcome(s types.DigestSignalStats) string {
+	if s.Evaluated == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(hit %.0f%%, ср. %+.2f%%)", s.HitRate, s.AvgReturn)
+}
+
+// label — имя без «_» (анализатор, часовой пояс): текст отправляется и в Markdown-сообщениях
+func label(key string) string {
+	return strings.ReplaceAll(key, "_", " ")
+}
+
+// formatUSD — $1.2M, $350K
+func formatUSD(v float64) string {
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("$%.2fB", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("$%.1fM", v/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("$%.0fK", v/1e3)
+	default:
+		return fmt.Sprintf("$%.0f", v)
+	}
+}
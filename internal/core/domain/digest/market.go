@@ -0,0 +1,178 @@
+// internal/core/domain/digest/market.go
+package digest
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"math"
+	"sort"
+)
+
+// snapshotEntry цена и OI символа в снимке дайджеста
+type snapshotEntry struct {
+	Price float64 `json:"p"`
+	OI    float64 `json:"oi,omitempty"` // $
+}
+
+// marketSymbol текущие показатели символа
+type marketSymbol struct {
+	Symbol    string
+	Price     float64
+	OI        float64
+	Funding   float64 // доля за период фандинга
+	Change24h float64 // доля
+	Liquid    bool    // оборот не ниже MinVolumeUSD
+}
+
+// market — текущий срез рынка из хранилища цен
+type market []marketSymbol
+
+// collectMarket собирает срез рынка по последним снимкам цен
+func collectMarket(snapshots map[string]storage.PriceSnapshotInterface, minVolumeUSD float64) market {
+	result := make(market, 0, len(snapshots))
+	for symbol, snapshot := range snapshots {
+		if snapshot == nil || snapshot.GetPrice() <= 0 {
+			continue
+		}
+		result = append(result, marketSymbol{
+			Symbol:    symbol,
+			Price:     snapshot.GetPrice(),
+			OI:        snapshot.GetOpenInterest(),
+			Funding:   snapshot.GetFundingRate(),
+			Change24h: snapshot.GetChange24h(),
+			Liquid:    snapshot.GetVolumeUSD() >= minVolumeUSD,
+		})
+	}
+	return result
+}
+
+// snapshot — цены и OI для расчета изменений в следующих дайджестах
+func (m market) snapshot() map[string]snapshotEntry {
+	result := make(map[string]snapshotEntry, len(m))
+	for _, s := range m {
+		result[s.Symbol] = snapshotEntry{Price: s.Price, OI: s.OI}
+	}
+	return result
+}
+
+// movers — лидеры роста и падения за 24 часа, %
+func (m market) movers(topN int) (gainers, losers []types.DigestValue) {
+	var values []types.DigestValue
+	for _, s := range m {
+		if s.Liquid {
+			values = append(values, types.DigestValue{Symbol: s.Symbol, Value: s.Change24h * 100})
+		}
+	}
+	return extremes(values, topN)
+}
+
+// priceChanges — лидеры роста и падения относительно снимка, %
+func (m market) priceChanges(previous map[string]snapshotEntry, topN int) (gainers, losers []types.DigestValue) {
+	var values []types.DigestValue
+	for _, s := range m {
+		if prev, ok := previous[s.Symbol]; ok && s.Liquid && prev.Price > 0 {
+			values = append(values, types.DigestValue{Symbol: s.Symbol, Value: (s.Price/prev.Price - 1) * 100})
+		}
+	}
+	return extremes(values, topN)
+}
+
+// oiChanges — крупнейшие по модулю изменения OI относительно снимка, %
+func (m market) oiChanges(previous map[string]snapshotEntry, topN int) []types.DigestValue {
+	var values []types.DigestValue
+	for _, s := range m {
+		if prev, ok := previous[s.Symbol]; ok && s.Liquid && prev.OI > 0 && s.OI > 0 {
+			values = append(values, types.DigestValue{Symbol: s.Symbol, Value: (s.OI/prev.OI - 1) * 100})
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return math.Abs(values[i].Value) > math.Abs(values[j].Value) })
+	return head(values, topN)
+}
+
+// funding — самый высокий и самый низкий фандинг, %
+func (m market) funding(topN int) (highest, lowest []types.DigestValue) {
+	var values []types.DigestValue
+	for _, s := range m {
+		if s.Funding != 0 {
+			values = append(values, types.DigestValue{Symbol: s.Symbol, Value: s.Funding * 100})
+		}
+	}
+	return extremes(values, topN)
+}
+
+// extremes возвращает topN наибольших положительных и topN наименьших отрицательных значений
+func extremes(values []types.DigestValue, topN int) (top, bottom []types.DigestValue) {
+	sort.Slice(values, func(i, j int) bool { return values[i].Value > values[j].Value })
+	for _, v := range values {
+		if v.Value <= 0 || len(top) == topN {
+			break
+		}
+		top = append(top, v)
+	}
+	for i := len(values) - 1; i >= 0 && len(bottom) < topN; i-- {
+		if values[i].Value >= 0 {
+			break
+		}
+		bottom = append(bottom, values[i])
+	}
+	return top, bottom
+}
+
+// sumLiquidations суммирует ликвидации по символам
+func sumLiquidations(metrics []*bybit.LiquidationMetrics, topN int) types.DigestLiquidations {
+	var total types.DigestLiquidations
+	bySymbol := make(map[string]float64, len(metrics))
+	for _, m := range metrics {
+		if m == nil {
+			continue
+		}
+		total.TotalUSD += m.TotalVolumeUSD
+		total.LongUSD += m.LongLiqVolume
+		total.ShortUSD += m.ShortLiqVolume
+		bySymbol[m.Symbol] += m.TotalVolumeUSD
+	}
+	total.Top = topValues(bySymbol, topN)
+	return total
+}
+
+// topValues возвращает topN символов с наибольшими значениями
+func topValues(bySymbol map[string]float64, topN int) []types.DigestValue {
+	values := make([]types.DigestValue, 0, len(bySymbol))
+	for symbol, value := range bySymbol {
+		values = append(values, types.DigestValue{Symbol: symbol, Value: value})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Value > values[j].Value })
+	return head(values, topN)
+}
+
+// topBySignals возвращает topN групп с наибольшим числом сигналов
+func topBySignals(rows []*models.SignalGroupStats, topN int) []types.DigestSignalStats {
+	stats := make([]types.DigestSignalStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, newSignalStats(row))
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].Signals > stats[j].Signals })
+	if len(stats) > topN {
+		stats = stats[:topN]
+	}
+	return stats
+}
+
+func newSignalStats(row *models.SignalGroupStats) types.DigestSignalStats {
+	return types.DigestSignalStats{
+		Key:       row.Key,
+		Signals:   row.Signals,
+		Evaluated: row.Evaluated,
+		HitRate:   row.HitRate(),
+		AvgReturn: row.AvgReturn,
+	}
+}
+
+func head(values []types.DigestValue, n int) []types.DigestValue {
+	if len(values) > n {
+		return values[:n]
+	}
+	return values
+}
@@ -0,0 +1,296 @@
+// internal/core/domain/digest/service.go
+// Дайджест рынка: раз в сутки задача планировщика (DailyAt) строит сводку — лидеры роста
+// и падения, изменения OI, крайние фандинги, ликвидации, самые частые монеты в сигналах
+// и исходы сигналов за прошедшие сутки; по понедельникам — также недельную. Подписчики
+// получают сводку в выбранное время по своему часовому поясу (EventMarketDigest).
+package digest
+
+import (
+	"context"
+	"crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	market_digest_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/market_digest"
+	signal_journal_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_journal"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// weeklyDay — день, в который строится недельный дайджест (за прошедшие 7 суток)
+const weeklyDay = time.Monday
+
+// Config настройки дайджеста
+type Config struct {
+	// BuildHour, BuildMinute — время построения суточного дайджеста, UTC
+	BuildHour   int
+	BuildMinute int
+	// DefaultSendMinute — время доставки по умолчанию, минуты от полуночи по часовому поясу пользователя
+	DefaultSendMinute int
+	// DefaultTimezone — часовой пояс пользователей без заданного пояса
+	DefaultTimezone string
+	// TopN — сколько строк в каждом разделе
+	TopN int
+	// MinVolumeUSD — минимальный суточный оборот символа для лидеров и изменений OI
+	MinVolumeUSD float64
+	// Horizon — горизонт исходов сигналов (5m, 15m, 1h, 4h, 24h)
+	Horizon string
+}
+
+// DefaultConfig возвращает настройки по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		BuildHour:         0,
+		BuildMinute:       5,
+		DefaultSendMinute: 9 * 60,
+		DefaultTimezone:   "Europe/Moscow",
+		TopN:              5,
+		MinVolumeUSD:      1_000_000,
+		Horizon:           "4h",
+	}
+}
+
+// LiquidationSource суточные ликвидации по символам (LiquidationWatcher)
+type LiquidationSource interface {
+	DailyLiquidations() []*bybit.LiquidationMetrics
+}
+
+// Sources источники рыночных данных; функции вызываются при каждом построении,
+// так как CandleSystem и наблюдатель ликвидаций стартуют позже сервиса
+type Sources struct {
+	Prices       func() storage.PriceStorageInterface
+	Liquidations func() LiquidationSource
+}
+
+// Service строит, хранит и рассылает дайджесты рынка
+type Service struct {
+	repo     market_digest_repo.MarketDigestRepository
+	signals  signal_journal_repo.SignalJournalRepository
+	sources  Sources
+	eventBus types.EventBus
+	config   Config
+}
+
+// NewService создает сервис дайджеста; eventBus может быть nil, если сервис
+// используется только для команд (рассылка тогда недоступна)
+func NewService(db *sqlx.DB, sources Sources, eventBus types.EventBus, config Config) *Service {
+	defaults := DefaultConfig()
+	if config.TopN <= 0 {
+		config.TopN = defaults.TopN
+	}
+	if config.Horizon == "" {
+		config.Horizon = defaults.Horizon
+	}
+	if config.DefaultTimezone == "" {
+		config.DefaultTimezone = defaults.DefaultTimezone
+	}
+	if config.DefaultSendMinute <= 0 || config.DefaultSendMinute >= 24*60 {
+		config.DefaultSendMinute = defaults.DefaultSendMinute
+	}
+	return &Service{
+		repo:     market_digest_repo.NewMarketDigestRepository(db),
+		signals:  signal_journal_repo.NewSignalJournalRepository(db),
+		sources:  sources,
+		eventBus: eventBus,
+		config:   config,
+	}
+}
+
+// Config возвращает настройки дайджеста
+func (s *Service) Config() Config {
+	return s.config
+}
+
+// BuildTime возвращает время построения суточного дайджеста, UTC
+func (s *Service) BuildTime() (hour, minute int) {
+	return s.config.BuildHour, s.config.BuildMinute
+}
+
+// BuildDigests — задача планировщика: строит суточный и (по понедельникам) недельный дайджест
+func (s *Service) BuildDigests(ctx context.Context) error {
+	return s.Build(time.Now())
+}
+
+// DispatchDigests — задача планировщика: рассылает дайджесты подписчикам, у которых наступило время доставки
+func (s *Service) DispatchDigests(ctx context.Context) error {
+	return s.Dispatch(time.Now())
+}
+
+// Build строит и сохраняет дайджесты для последнего наступившего времени построения
+func (s *Service) Build(now time.Time) error {
+	end := s.periodEnd(now)
+
+	daily, err := s.build(models.DigestDaily, end.Add(-24*time.Hour), end)
+	if err != nil {
+		return fmt.Errorf("суточный дайджест: %w", err)
+	}
+	logger.Info("📰 [Digest] Суточный дайджест за %s построен: лидеров %d/%d, сигналов %d",
+		end.Format("2006-01-02 15:04"), len(daily.Gainers), len(daily.Losers), daily.Signals.Signals)
+
+	if end.Weekday() == weeklyDay {
+		weekly, err := s.build(models.DigestWeekly, end.Add(-7*24*time.Hour), end)
+		if err != nil {
+			return fmt.Errorf("недельный дайджест: %w", err)
+		}
+		logger.Info("📰 [Digest] Недельный дайджест построен: сигналов %d", weekly.Signals.Signals)
+	}
+	return nil
+}
+
+// periodEnd — последнее время построения (BuildHour:BuildMinute UTC), не позже now
+func (s *Service) periodEnd(now time.Time) time.Time {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), s.config.BuildHour, s.config.BuildMinute, 0, 0, time.UTC)
+	if end.After(now) {
+		end = end.Add(-24 * time.Hour)
+	}
+	return end
+}
+
+// build собирает дайджест вида за период и сохраняет его вместе со снимком рынка
+func (s *Service) build(kind string, from, to time.Time) (*types.MarketDigestData, error) {
+	var prices storage.PriceStorageInterface
+	if s.sources.Prices != nil {
+		prices = s.sources.Prices()
+	}
+	if prices == nil {
+		return nil, fmt.Errorf("хранилище цен недоступно")
+	}
+
+	market := collectMarket(prices.GetAllCurrentPrices(), s.config.MinVolumeUSD)
+	data := &types.MarketDigestData{Kind: kind, From: from, To: to, Horizon: s.config.Horizon}
+
+	// Изменения цен и OI — относительно снимка суточного дайджеста в начале периода
+	previous, err := s.snapshotAt(from)
+	if err != nil {
+		return nil, err
+	}
+	if kind == models.DigestDaily {
+		data.Gainers, data.Losers = market.movers(s.config.TopN)
+	} else if previous != nil {
+		data.Gainers, data.Losers = market.priceChanges(previous, s.config.TopN)
+	}
+	if previous != nil {
+		data.OIChanges = market.oiChanges(previous, s.config.TopN)
+	}
+	data.FundingHi, data.FundingLo = market.funding(s.config.TopN)
+
+	if data.Liquidations, err = s.liquidations(kind, from, to); err != nil {
+		return nil, err
+	}
+	if err := s.signalStats(data, from, to); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := json.Marshal(market.snapshot())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveDigest(&models.MarketDigest{
+		Kind:        kind,
+		PeriodStart: from,
+		PeriodEnd:   to,
+		Data:        payload,
+		Snapshot:    snapshot,
+	}); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// snapshotAt возвращает снимок рынка суточного дайджеста, построенного около момента at
+// (не раньше чем за 12 часов), или nil
+func (s *Service) snapshotAt(at time.Time) (map[string]snapshotEntry, error) {
+	digest, err := s.repo.DigestAt(models.DigestDaily, at.Add(12*time.Hour))
+	if err != nil || digest == nil || digest.PeriodEnd.Before(at.Add(-12*time.Hour)) {
+		return nil, err
+	}
+	var snapshot map[string]snapshotEntry
+	if err := json.Unmarshal(digest.Snapshot, &snapshot); err != nil {
+		return nil, fmt.Errorf("снимок дайджеста %d: %w", digest.ID, err)
+	}
+	return snapshot, nil
+}
+
+// liquidations — суточные ликвидации из скользящего окна; для недельного дайджеста
+// суммируются суточные дайджесты недели
+func (s *Service) liquidations(kind string, from, to time.Time) (types.DigestLiquidations, error) {
+	if kind == models.DigestDaily {
+		var source LiquidationSource
+		if s.sources.Liquidations != nil {
+			source = s.sources.Liquidations()
+		}
+		if source == nil {
+			return types.DigestLiquidations{}, nil
+		}
+		return sumLiquidations(source.DailyLiquidations(), s.config.TopN), nil
+	}
+
+	dailies, err := s.repo.DigestsBetween(models.DigestDaily, from, to)
+	if err != nil {
+		return types.DigestLiquidations{}, err
+	}
+	var total types.DigestLiquidations
+	bySymbol := make(map[string]float64)
+	for _, digest := range dailies {
+		var data types.MarketDigestData
+		if err := json.Unmarshal(digest.Data, &data); err != nil {
+			continue
+		}
+		total.TotalUSD += data.Liquidations.TotalUSD
+		total.LongUSD += data.Liquidations.LongUSD
+		total.ShortUSD += data.Liquidations.ShortUSD
+		for _, v := range data.Liquidations.Top {
+			bySymbol[v.Symbol] += v.Value
+		}
+	}
+	total.Top = topValues(bySymbol, s.config.TopN)
+	return total, nil
+}
+
+// signalStats заполняет самые частые монеты в сигналах и исходы сигналов периода
+func (s *Service) signalStats(data *types.MarketDigestData, from, to time.Time) error {
+	filter := signal_journal_repo.AnalyticsFilter{From: from, To: to, Horizon: s.config.Horizon}
+
+	total, err := s.signals.Aggregate(filter, signal_journal_repo.DimensionTotal)
+	if err != nil {
+		return err
+	}
+	if len(total) > 0 {
+		data.Signals = newSignalStats(total[0])
+	}
+
+	symbols, err := s.signals.Aggregate(filter, signal_journal_repo.DimensionSymbol)
+	if err != nil {
+		return err
+	}
+	data.TopSignalled = topBySignals(symbols, s.config.TopN)
+
+	analyzers, err := s.signals.Aggregate(filter, signal_journal_repo.DimensionAnalyzer)
+	if err != nil {
+		return err
+	}
+	data.Analyzers = topBySignals(analyzers, s.config.TopN)
+	return nil
+}
+
+// Latest возвращает последний дайджест вида или nil, если дайджестов еще не было
+func (s *Service) Latest(kind string) (*types.MarketDigestData, error) {
+	digest, err := s.repo.LatestDigest(kind)
+	if err != nil || digest == nil {
+		return nil, err
+	}
+	var data types.MarketDigestData
+	if err := json.Unmarshal(digest.Data, &data); err != nil {
+		return nil, fmt.Errorf("дайджест %d: %w", digest.ID, err)
+	}
+	return &data, nil
+}
@@ -0,0 +1,185 @@
+// internal/core/domain/export/args.go
+package export
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Типы выгрузок
+const (
+	KindSignals  = "signals"
+	KindCandles  = "candles"
+	KindActivity = "activity"
+)
+
+// Форматы выгрузок
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// defaultRange период выгрузки, если он не указан
+const defaultRange = 7 * 24 * time.Hour
+
+// candlePeriods периоды свечей candle_storage
+var candlePeriods = []string{"1m", "5m", "15m", "30m", "1h", "4h", "1d"}
+
+// Help справка по команде /export (без разметки)
+const Help = `📦 Выгрузка данных в CSV или JSON Lines
+
+/export signals 30d — сигналы с исходами за 30 дней
+/export candles BTC 1h 7d — свечи BTCUSDT 1h за 7 дней
+/export activity 30d — ваша активность за 30 дней
+
+Период: 12h, 7d или даты 2026-01-01 2026-02-01 (по умолчанию 7d)
+Формат: csv (по умолчанию) или jsonl
+Периоды свечей: 1m, 5m, 15m, 30m, 1h, 4h, 1d
+dir — сохранить файл в каталог выгрузок сервера (администраторы)`
+
+// Request параметры выгрузки
+type Request struct {
+	UserID int
+	Kind   string
+	Format string
+	From   time.Time
+	To     time.Time
+	// Symbol и Period — только для свечей
+	Symbol string
+	Period string
+	// ToDirectory — записать в каталог выгрузок вместо отправки документом
+	ToDirectory bool
+}
+
+// FileName имя файла выгрузки: signals_20260101_20260201.csv
+func (r Request) FileName() string {
+	name := r.Kind
+	if r.Kind == KindCandles {
+		name += "_" + r.Symbol + "_" + r.Period
+	}
+	return fmt.Sprintf("%s_%s_%s.%s", name, r.From.UTC().Format("20060102"), r.To.UTC().Format("20060102"), r.Format)
+}
+
+// Describe краткое описание выгрузки для подписи документа
+func (r Request) Describe() string {
+	what := map[string]string{
+		KindSignals:  "Сигналы с исходами",
+		KindCandles:  "Свечи " + r.Symbol + " " + r.Period,
+		KindActivity: "Активность",
+	}[r.Kind]
+	return fmt.Sprintf("%s за %s — %s UTC", what,
+		r.From.UTC().Format("02.01.2006 15:04"), r.To.UTC().Format("02.01.2006 15:04"))
+}
+
+// ParseArgs разбирает аргументы команды /export. Пустые аргументы — ошибка со справкой.
+func ParseArgs(args string, now time.Time) (Request, error) {
+	fields := strings.Fields(strings.ToLower(args))
+	if len(fields) == 0 {
+		return Request{}, fmt.Errorf("укажите тип выгрузки: signals, candles или activity")
+	}
+
+	req := Request{Format: FormatCSV, To: now}
+	switch fields[0] {
+	case "signals", "signal":
+		req.Kind = KindSignals
+	case "candles", "candle", "klines":
+		req.Kind = KindCandles
+	case "activity":
+		req.Kind = KindActivity
+	default:
+		return Request{}, fmt.Errorf("неизвестный тип выгрузки «%s»", fields[0])
+	}
+
+	var dates []time.Time
+	for _, field := range fields[1:] {
+		switch {
+		case field == "csv":
+			req.Format = FormatCSV
+		case field == "jsonl" || field == "json":
+			req.Format = FormatJSONL
+		case field == "dir":
+			req.ToDirectory = true
+		case req.Kind == KindCandles && req.Period == "" && isCandlePeriod(field):
+			// первый из 1h/1d у свечей — период свечей, второй — длительность выгрузки
+			req.Period = field
+		case isRange(field):
+			duration, _ := parseRange(field)
+			req.From = now.Add(-duration)
+		default:
+			if date, err := time.Parse("2006-01-02", field); err == nil {
+				dates = append(dates, date)
+				continue
+			}
+			if req.Kind != KindCandles || req.Symbol != "" {
+				return Request{}, fmt.Errorf("непонятный аргумент «%s»", field)
+			}
+			req.Symbol = alerts.NormalizeSymbol(field)
+			if req.Symbol == "" {
+				return Request{}, fmt.Errorf("некорректный символ «%s»", field)
+			}
+		}
+	}
+
+	switch len(dates) {
+	case 0:
+	case 1:
+		req.From = dates[0]
+	case 2:
+		req.From, req.To = dates[0], dates[1].Add(24*time.Hour)
+		if req.To.After(now) {
+			req.To = now
+		}
+	default:
+		return Request{}, fmt.Errorf("укажите не больше двух дат")
+	}
+	if req.From.IsZero() {
+		req.From = now.Add(-defaultRange)
+	}
+
+	if req.Kind == KindCandles {
+		if req.Symbol == "" {
+			return Request{}, fmt.Errorf("укажите символ, например: /export candles BTC 1h 7d")
+		}
+		if req.Period == "" {
+			req.Period = "1h"
+		}
+	}
+	return req, nil
+}
+
+// isCandlePeriod проверяет период свечей
+func isCandlePeriod(value string) bool {
+	for _, period := range candlePeriods {
+		if value == period {
+			return true
+		}
+	}
+	return false
+}
+
+// isRange проверяет длительность периода выгрузки (12h, 7d)
+func isRange(value string) bool {
+	_, ok := parseRange(value)
+	return ok
+}
+
+// parseRange разбирает длительность периода выгрузки: 12h или 7d
+func parseRange(value string) (time.Duration, bool) {
+	unit := time.Hour
+	number, ok := strings.CutSuffix(value, "h")
+	if !ok {
+		number, ok = strings.CutSuffix(value, "d")
+		unit = 24 * time.Hour
+	}
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
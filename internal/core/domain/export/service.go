@@ -0,0 +1,308 @@
+// internal/core/domain/export/service.go
+// Выгрузки данных для аналитиков: сигналы с исходами, свечи из candle_storage и активность
+// пользователя в CSV или JSON Lines. Строки пишутся потоком прямо в io.Writer (документ Telegram
+// или файл в каталоге выгрузок), поэтому память не растет с объемом выгрузки.
+package export
+
+import (
+	"bufio"
+	"context"
+	"crypto-exchange-screener-bot/internal/core/domain/subscription"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	data_export_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/data_export"
+	signal_journal_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_journal"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Цели доставки выгрузки
+const (
+	TargetTelegram  = "telegram"
+	TargetDirectory = "directory"
+)
+
+var (
+	// ErrExportLimit — выгрузки недоступны на тарифе или дневной лимит исчерпан
+	ErrExportLimit = errors.New("лимит выгрузок вашего тарифа исчерпан или выгрузки на нем недоступны")
+	// ErrNoDirectory — каталог выгрузок не настроен
+	ErrNoDirectory = errors.New("каталог выгрузок не настроен (EXPORT_DIR)")
+	// ErrNoCandles — свечная система недоступна
+	ErrNoCandles = errors.New("свечи недоступны")
+)
+
+// Config настройки выгрузок
+type Config struct {
+	// Dir — каталог для выгрузок в файл (пусто — только документы Telegram)
+	Dir string
+	// MaxRows — предел строк одной выгрузки; выгрузка сверх него обрезается
+	MaxRows int64
+	// MaxDocBytes — предел размера документа Telegram (бот API принимает до 50 МБ);
+	// документ обрезается по строкам, выгрузки в каталог не ограничиваются
+	MaxDocBytes int64
+	// MaxRangeDays — максимальная длина периода выгрузки
+	MaxRangeDays int
+}
+
+// DefaultConfig возвращает настройки по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		MaxRows:      500000,
+		MaxDocBytes:  45 << 20,
+		MaxRangeDays: 90,
+	}
+}
+
+// ActivitySource построчное чтение активности пользователя (ActivityRepository)
+type ActivitySource interface {
+	StreamByUser(ctx context.Context, userID int, from, to time.Time, fn func(*models.UserActivity) error) error
+}
+
+// CandleSource построчное чтение истории свечей (CandleSystem)
+type CandleSource interface {
+	ScanHistory(symbol, period string, from, to time.Time, fn func(*storage.Candle) error) error
+}
+
+// CandleSourceGetter — ленивое получение источника свечей (CandleSystem стартует позже)
+type CandleSourceGetter func() CandleSource
+
+// Result итог записи выгрузки
+type Result struct {
+	Rows  int64
+	Bytes int64
+	// Truncated — выгрузка обрезана по MaxRows или MaxDocBytes
+	Truncated bool
+}
+
+// Service выполняет выгрузки данных
+type Service struct {
+	signals  signal_journal_repo.SignalJournalRepository
+	jobs     data_export_repo.DataExportRepository
+	activity ActivitySource
+	candles  CandleSourceGetter
+	limits   subscription.LimitChecker
+	config   Config
+}
+
+// NewService создает сервис выгрузок.
+// activity, candles и limits могут быть nil — тогда соответствующие выгрузки недоступны,
+// а количество выгрузок не ограничивается.
+func NewService(db *sqlx.DB, activity ActivitySource, candles CandleSourceGetter, limits subscription.LimitChecker, config Config) *Service {
+	defaults := DefaultConfig()
+	if config.MaxRows <= 0 {
+		config.MaxRows = defaults.MaxRows
+	}
+	if config.MaxDocBytes <= 0 {
+		config.MaxDocBytes = defaults.MaxDocBytes
+	}
+	if config.MaxRangeDays <= 0 {
+		config.MaxRangeDays = defaults.MaxRangeDays
+	}
+	return &Service{
+		signals:  signal_journal_repo.NewSignalJournalRepository(db),
+		jobs:     data_export_repo.NewDataExportRepository(db),
+		activity: activity,
+		candles:  candles,
+		limits:   limits,
+		config:   config,
+	}
+}
+
+// Config возвращает настройки выгрузок
+func (s *Service) Config() Config {
+	return s.config
+}
+
+// Validate проверяет запрос на выгрузку
+func (s *Service) Validate(req Request) error {
+	if !req.To.After(req.From) {
+		return fmt.Errorf("пустой период выгрузки")
+	}
+	if req.To.Sub(req.From) > time.Duration(s.config.MaxRangeDays)*24*time.Hour {
+		return fmt.Errorf("период выгрузки больше %d дн.", s.config.MaxRangeDays)
+	}
+	switch req.Kind {
+	case KindSignals:
+	case KindCandles:
+		if s.candles == nil {
+			return ErrNoCandles
+		}
+	case KindActivity:
+		if s.activity == nil {
+			return fmt.Errorf("выгрузка активности недоступна")
+		}
+	default:
+		return fmt.Errorf("неизвестный тип выгрузки «%s»", req.Kind)
+	}
+	if req.ToDirectory && s.config.Dir == "" {
+		return ErrNoDirectory
+	}
+	return nil
+}
+
+// Begin проверяет запрос и дневной лимит тарифа и записывает начало выгрузки.
+// Подсчет выгрузок за сутки и запись выполняются в одной транзакции.
+// skipLimits — не проверять лимит (администраторы).
+func (s *Service) Begin(ctx context.Context, req Request, skipLimits bool) (*models.DataExport, error) {
+	if err := s.Validate(req); err != nil {
+		return nil, err
+	}
+
+	maxPerDay := -1
+	if s.limits != nil && !skipLimits {
+		// При нулевом использовании remaining равен лимиту тарифа
+		var err error
+		_, maxPerDay, err = s.limits.CheckUserLimit(ctx, req.UserID, subscription.LimitExports, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"from":   req.From,
+		"to":     req.To,
+		"symbol": req.Symbol,
+		"period": req.Period,
+	})
+	target := TargetTelegram
+	if req.ToDirectory {
+		target = TargetDirectory
+	}
+	job := &models.DataExport{
+		UserID: req.UserID,
+		Kind:   req.Kind,
+		Format: req.Format,
+		Target: target,
+		Params: params,
+		Status: models.DataExportRunning,
+	}
+	created, err := s.jobs.CreateWithinLimit(job, time.Now().Add(-24*time.Hour), maxPerDay)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrExportLimit
+	}
+	return job, nil
+}
+
+// Finish сохраняет итог выгрузки
+func (s *Service) Finish(job *models.DataExport, result Result, exportErr error) error {
+	now := time.Now()
+	job.Rows = result.Rows
+	job.Bytes = result.Bytes
+	job.Truncated = result.Truncated
+	job.FinishedAt = &now
+	job.Status = models.DataExportDone
+	if exportErr != nil {
+		job.Status = models.DataExportFailed
+		job.Error = exportErr.Error()
+	}
+	return s.jobs.Finish(job)
+}
+
+// Export пишет выгрузку в w потоком. Документ Telegram обрезается по MaxDocBytes,
+// чтобы загрузка не упала на лимите бот API и выгрузка не пропала после Begin.
+func (s *Service) Export(ctx context.Context, req Request, w io.Writer) (Result, error) {
+	counter := &countingWriter{w: w}
+	if !req.ToDirectory {
+		counter.limit = s.config.MaxDocBytes
+	}
+	rows, err := newRowWriter(req.Format, counter, columnsFor(req.Kind))
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	emit := func(csvRow []string, value interface{}) error {
+		if result.Rows >= s.config.MaxRows || counter.full() {
+			result.Truncated = true
+			return errRowLimit
+		}
+		if err := rows.Write(csvRow, value); err != nil {
+			return err
+		}
+		result.Rows++
+		return ctx.Err()
+	}
+
+	switch req.Kind {
+	case KindSignals:
+		err = s.signals.Stream(ctx, req.From, req.To, func(rec *models.SignalRecord) error {
+			return emit(signalRow(rec), rec)
+		})
+	case KindCandles:
+		source := s.candles()
+		if source == nil {
+			return Result{}, ErrNoCandles
+		}
+		err = source.ScanHistory(req.Symbol, req.Period, req.From, req.To, func(c *storage.Candle) error {
+			return emit(candleRow(c), newCandleRecord(c))
+		})
+	case KindActivity:
+		err = s.activity.StreamByUser(ctx, req.UserID, req.From, req.To, func(a *models.UserActivity) error {
+			return emit(activityRow(a), a)
+		})
+	default:
+		err = fmt.Errorf("неизвестный тип выгрузки «%s»", req.Kind)
+	}
+	if errors.Is(err, errRowLimit) {
+		err = nil
+	}
+	if flushErr := rows.Flush(); err == nil {
+		err = flushErr
+	}
+	result.Bytes = counter.n
+	return result, err
+}
+
+// ExportToDirectory выполняет начатую выгрузку в файл каталога выгрузок и сохраняет ее итог
+func (s *Service) ExportToDirectory(ctx context.Context, job *models.DataExport, req Request) (Result, error) {
+	result, err := s.exportToFile(ctx, job, req)
+	if finishErr := s.Finish(job, result, err); finishErr != nil && err == nil {
+		err = finishErr
+	}
+	return result, err
+}
+
+// exportToFile пишет выгрузку во временный файл и переименовывает его по завершении
+func (s *Service) exportToFile(ctx context.Context, job *models.DataExport, req Request) (Result, error) {
+	if s.config.Dir == "" {
+		return Result{}, ErrNoDirectory
+	}
+	if err := os.MkdirAll(s.config.Dir, 0o755); err != nil {
+		return Result{}, fmt.Errorf("не удалось создать каталог выгрузок: %w", err)
+	}
+
+	path := filepath.Join(s.config.Dir, fmt.Sprintf("%d_%s", job.ID, req.FileName()))
+	tmp := path + ".part"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return Result{}, fmt.Errorf("не удалось создать файл выгрузки: %w", err)
+	}
+
+	buffered := bufio.NewWriter(file)
+	result, err := s.Export(ctx, req, buffered)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return result, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return result, fmt.Errorf("не удалось сохранить файл выгрузки: %w", err)
+	}
+	job.FilePath = path
+	return result, nil
+}
@@ -0,0 +1,238 @@
+// internal/core/domain/export/writer.go
+package export
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errRowLimit останавливает чтение источника при достижении MaxRows или MaxDocBytes
+var errRowLimit = errors.New("превышен предел строк выгрузки")
+
+// rowWriter пишет строки выгрузки в выбранном формате
+type rowWriter interface {
+	// Write пишет строку: csvRow для CSV, value — для JSON Lines
+	Write(csvRow []string, value interface{}) error
+	Flush() error
+}
+
+// newRowWriter создает писатель строк для формата
+func newRowWriter(format string, w io.Writer, columns []string) (rowWriter, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvRowWriter{w: cw}, nil
+	case FormatJSONL:
+		return &jsonlRowWriter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("неизвестный формат выгрузки «%s»", format)
+	}
+}
+
+// csvRowWriter пишет строки CSV
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) Write(csvRow []string, _ interface{}) error {
+	return c.w.Write(csvRow)
+}
+
+func (c *csvRowWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlRowWriter пишет по одному JSON-объекту на строку
+type jsonlRowWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlRowWriter) Write(_ []string, value interface{}) error {
+	return j.enc.Encode(value)
+}
+
+func (j *jsonlRowWriter) Flush() error {
+	return nil
+}
+
+// countingWriter считает записанные байты. limit > 0 — предел размера:
+// строки проверяют full() перед записью, поэтому файл не обрывается посреди строки.
+// CSV буферизуется (до 4 КБ), поэтому предел может быть превышен на буфер и одну строку.
+type countingWriter struct {
+	w     io.Writer
+	n     int64
+	limit int64
+}
+
+// full сообщает, что записан предел байтов
+func (c *countingWriter) full() bool {
+	return c.limit > 0 && c.n >= c.limit
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Колонки CSV по типам выгрузки
+var (
+	signalColumns = []string{
+		"id", "signal_id", "symbol", "signal_type", "direction", "strategy", "period_minutes",
+		"confidence", "change_percent", "entry_price", "signal_time",
+		"ret_5m", "ret_15m", "ret_1h", "ret_4h", "ret_24h", "mfe_pct", "mae_pct",
+		"outcome_status", "tags", "context",
+	}
+	candleColumns = []string{
+		"symbol", "period", "start_time", "end_time", "open", "high", "low", "close",
+		"volume", "volume_usd", "trades", "closed",
+	}
+	activityColumns = []string{
+		"id", "created_at", "activity_type", "category", "severity",
+		"entity_type", "entity_id", "details",
+	}
+)
+
+// columnsFor возвращает колонки CSV для типа выгрузки
+func columnsFor(kind string) []string {
+	switch kind {
+	case KindCandles:
+		return candleColumns
+	case KindActivity:
+		return activityColumns
+	default:
+		return signalColumns
+	}
+}
+
+// signalRow строка CSV сигнала с исходом
+func signalRow(r *models.SignalRecord) []string {
+	return []string{
+		strconv.FormatInt(r.ID, 10),
+		r.SignalID,
+		r.Symbol,
+		r.SignalType,
+		r.Direction,
+		r.Strategy,
+		strconv.Itoa(r.PeriodMinutes),
+		formatFloat(r.Confidence),
+		formatFloat(r.ChangePercent),
+		formatFloat(r.EntryPrice),
+		formatTime(r.SignalTime),
+		formatOptional(r.Ret5m),
+		formatOptional(r.Ret15m),
+		formatOptional(r.Ret1h),
+		formatOptional(r.Ret4h),
+		formatOptional(r.Ret24h),
+		formatOptional(r.MFEPct),
+		formatOptional(r.MAEPct),
+		r.OutcomeStatus,
+		strings.Join(r.Tags, ";"),
+		string(r.Context),
+	}
+}
+
+// candleRecord свеча в JSON Lines
+type candleRecord struct {
+	Symbol    string    `json:"symbol"`
+	Period    string    `json:"period"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	VolumeUSD float64   `json:"volume_usd"`
+	Trades    int       `json:"trades"`
+	Closed    bool      `json:"closed"`
+}
+
+func newCandleRecord(c *storage.Candle) candleRecord {
+	return candleRecord{
+		Symbol:    c.Symbol,
+		Period:    c.Period,
+		StartTime: c.StartTime.UTC(),
+		EndTime:   c.EndTime.UTC(),
+		Open:      c.Open,
+		High:      c.High,
+		Low:       c.Low,
+		Close:     c.Close,
+		Volume:    c.Volume,
+		VolumeUSD: c.VolumeUSD,
+		Trades:    c.Trades,
+		Closed:    c.IsClosedFlag,
+	}
+}
+
+// candleRow строка CSV свечи
+func candleRow(c *storage.Candle) []string {
+	return []string{
+		c.Symbol,
+		c.Period,
+		formatTime(c.StartTime),
+		formatTime(c.EndTime),
+		formatFloat(c.Open),
+		formatFloat(c.High),
+		formatFloat(c.Low),
+		formatFloat(c.Close),
+		formatFloat(c.Volume),
+		formatFloat(c.VolumeUSD),
+		strconv.Itoa(c.Trades),
+		strconv.FormatBool(c.IsClosedFlag),
+	}
+}
+
+// activityRow строка CSV активности пользователя
+func activityRow(a *models.UserActivity) []string {
+	entityType, entityID := "", ""
+	if a.EntityType != nil {
+		entityType = *a.EntityType
+	}
+	if a.EntityID != nil {
+		entityID = strconv.Itoa(*a.EntityID)
+	}
+	details := ""
+	if len(a.Details) > 0 {
+		if data, err := json.Marshal(a.Details); err == nil {
+			details = string(data)
+		}
+	}
+	return []string{
+		strconv.FormatInt(a.ID, 10),
+		formatTime(a.CreatedAt),
+		string(a.ActivityType),
+		string(a.Category),
+		string(a.Severity),
+		entityType,
+		entityID,
+		details,
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatOptional(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return formatFloat(*v)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
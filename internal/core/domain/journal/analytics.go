@@ -0,0 +1,371 @@
+// internal/core/domain/journal/analytics.go
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	signal_journal_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_journal"
+)
+
+// Измерения аналитики (ключи AnalyticsReport.Dimensions и AnalyticsReport.Feedback)
+const (
+	DimensionTotal     = signal_journal_repo.DimensionTotal
+	DimensionAnalyzer  = signal_journal_repo.DimensionAnalyzer
+	DimensionPeriod    = signal_journal_repo.DimensionPeriod
+	DimensionDirection = signal_journal_repo.DimensionDirection
+	DimensionSymbol    = signal_journal_repo.DimensionSymbol
+	DimensionHour      = signal_journal_repo.DimensionHour
+	DimensionRegime    = signal_journal_repo.DimensionRegime
+)
+
+// AnalyticsDimensions — измерения отчета в порядке вывода
+var AnalyticsDimensions = []string{
+	DimensionAnalyzer, DimensionPeriod, DimensionDirection,
+	DimensionSymbol, DimensionHour, DimensionRegime,
+}
+
+// FeedbackDimensions — измерения голосов пользователей (ключи AnalyticsReport.Feedback)
+var FeedbackDimensions = []string{DimensionTotal, DimensionAnalyzer, DimensionPeriod}
+
+// Значения аналитики по умолчанию
+const (
+	DefaultAnalyticsDays       = 7
+	DefaultAnalyticsHorizon    = "1h"
+	DefaultAnalyticsMinSignals = 10
+	DefaultAnalyticsTop        = 5
+	maxAnalyticsDays           = 180
+)
+
+// AnalyticsQuery параметры отчета по исходам сигналов
+type AnalyticsQuery struct {
+	Days       int    // окно в днях до текущего момента
+	Horizon    string // горизонт доходности и hit rate
+	MinSignals int    // минимум оцененных сигналов для рейтинга лучших/худших
+	Top        int    // сколько групп в рейтингах
+}
+
+// Normalize подставляет значения по умолчанию и проверяет горизонт
+func (q *AnalyticsQuery) Normalize() error {
+	if q.Days <= 0 {
+		q.Days = DefaultAnalyticsDays
+	}
+	if q.Days > maxAnalyticsDays {
+		q.Days = maxAnalyticsDays
+	}
+	if q.Horizon == "" {
+		q.Horizon = DefaultAnalyticsHorizon
+	}
+	if q.MinSignals <= 0 {
+		q.MinSignals = DefaultAnalyticsMinSignals
+	}
+	if q.Top <= 0 {
+		q.Top = DefaultAnalyticsTop
+	}
+	for _, h := range Horizons {
+		if h.Name == q.Horizon {
+			return nil
+		}
+	}
+	return fmt.Errorf("неизвестный горизонт «%s»: %s", q.Horizon, horizonNames())
+}
+
+// ParseAnalyticsArgs разбирает аргументы команды аналитики в любом порядке:
+// окно (7d, 30d, неделя, месяц), горизонт (5m, 15m, 1h, 4h, 24h) и min=N
+func ParseAnalyticsArgs(args string) (AnalyticsQuery, error) {
+	var q AnalyticsQuery
+	for _, token := range strings.Fields(strings.ToLower(args)) {
+		switch token {
+		case "day", "день", "сутки":
+			q.Days = 1
+			continue
+		case "week", "неделя":
+			q.Days = 7
+			continue
+		case "month", "месяц":
+			q.Days = 30
+			continue
+		}
+		if isHorizon(token) {
+			q.Horizon = token
+			continue
+		}
+		if value, ok := strings.CutPrefix(token, "min="); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return q, fmt.Errorf("неверный минимум сигналов «%s»", value)
+			}
+			q.MinSignals = n
+			continue
+		}
+		if days, ok := strings.CutSuffix(token, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil || n < 1 {
+				return q, fmt.Errorf("неверное окно «%s»: ожидается, например, 7d", token)
+			}
+			q.Days = n
+			continue
+		}
+		return q, fmt.Errorf("не понял «%s»: ожидается окно (7d), горизонт (%s) или min=N", token, horizonNames())
+	}
+	return q, q.Normalize()
+}
+
+// GroupStats метрики группы сигналов
+type GroupStats struct {
+	Key       string  `json:"key"`
+	Signals   int     `json:"signals"`
+	Evaluated int     `json:"evaluated"`
+	Hits      int     `json:"hits"`
+	HitRate   float64 `json:"hit_rate"`
+	AvgReturn float64 `json:"avg_return"`
+	AvgMFE    float64 `json:"avg_mfe"`
+	AvgMAE    float64 `json:"avg_mae"`
+}
+
+// TrendStats изменение метрик группы относительно предыдущего окна той же длины
+type TrendStats struct {
+	Key          string     `json:"key"`
+	Current      GroupStats `json:"current"`
+	Previous     GroupStats `json:"previous"`
+	HitRateDelta float64    `json:"hit_rate_delta"` // п.п.
+	ReturnDelta  float64    `json:"return_delta"`
+}
+
+// FeedbackStats голоса пользователей за сигналы группы
+type FeedbackStats struct {
+	Key      string  `json:"key"`
+	Votes    int     `json:"votes"`
+	Up       int     `json:"up"`
+	Down     int     `json:"down"`
+	Late     int     `json:"late"`
+	Noise    int     `json:"noise"`
+	Approval float64 `json:"approval"` // доля 👍 среди голосов, %
+}
+
+// Performers лучшие и худшие группы измерения по средней доходности
+type Performers struct {
+	Top   []GroupStats `json:"top"`
+	Worst []GroupStats `json:"worst"`
+}
+
+// AnalyticsReport отчет по исходам сигналов журнала
+type AnalyticsReport struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Days       int       `json:"days"`
+	Horizon    string    `json:"horizon"`
+	MinSignals int       `json:"min_signals"`
+
+	Total    GroupStats `json:"total"`
+	Previous GroupStats `json:"previous"` // то же за предыдущее окно
+
+	// Dimensions — группы по измерениям AnalyticsDimensions
+	Dimensions map[string][]GroupStats `json:"dimensions"`
+	// Performers — рейтинги по анализаторам и символам (группы с min_signals оцененных)
+	Performers map[string]Performers `json:"performers"`
+	// Trend — анализаторы в сравнении с предыдущим окном
+	Trend []TrendStats `json:"trend"`
+	// Daily — метрики по дням окна (UTC)
+	Daily []GroupStats `json:"daily"`
+	// Feedback — голоса пользователей по измерениям FeedbackDimensions
+	Feedback map[string][]FeedbackStats `json:"feedback"`
+}
+
+// Analytics агрегирует исходы сигналов окна по анализаторам, периодам, направлениям,
+// символам, часу суток и режиму рынка, сравнивает окно с предыдущим
+// и добавляет голоса пользователей по анализаторам и периодам
+func (s *Service) Analytics(query AnalyticsQuery, now time.Time) (*AnalyticsReport, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	window := time.Duration(query.Days) * 24 * time.Hour
+	current := signal_journal_repo.AnalyticsFilter{From: now.Add(-window), To: now, Horizon: query.Horizon}
+	previous := signal_journal_repo.AnalyticsFilter{From: current.From.Add(-window), To: current.From, Horizon: query.Horizon}
+
+	report := &AnalyticsReport{
+		From:       current.From,
+		To:         now,
+		Days:       query.Days,
+		Horizon:    query.Horizon,
+		MinSignals: query.MinSignals,
+		Dimensions: make(map[string][]GroupStats, len(AnalyticsDimensions)),
+		Performers: make(map[string]Performers, 2),
+		Feedback:   make(map[string][]FeedbackStats, len(FeedbackDimensions)),
+	}
+
+	var err error
+	if report.Total, err = s.totalStats(current); err != nil {
+		return nil, err
+	}
+	if report.Previous, err = s.totalStats(previous); err != nil {
+		return nil, err
+	}
+
+	for _, dimension := range AnalyticsDimensions {
+		groups, err := s.groupStats(current, dimension)
+		if err != nil {
+			return nil, err
+		}
+		sortGroups(dimension, groups)
+		report.Dimensions[dimension] = groups
+	}
+	for _, dimension := range []string{DimensionAnalyzer, DimensionSymbol} {
+		report.Performers[dimension] = performers(report.Dimensions[dimension], query.MinSignals, query.Top)
+	}
+
+	previousAnalyzers, err := s.groupStats(previous, DimensionAnalyzer)
+	if err != nil {
+		return nil, err
+	}
+	report.Trend = trend(report.Dimensions[DimensionAnalyzer], previousAnalyzers)
+
+	if report.Daily, err = s.groupStats(current, signal_journal_repo.DimensionDay); err != nil {
+		return nil, err
+	}
+
+	for _, dimension := range FeedbackDimensions {
+		rows, err := s.feedback.Aggregate(current.From, current.To, dimension)
+		if err != nil {
+			return nil, err
+		}
+		report.Feedback[dimension] = feedbackStats(rows)
+	}
+	return report, nil
+}
+
+// feedbackStats переводит голоса групп в метрики отчета
+func feedbackStats(rows []*models.FeedbackGroupStats) []FeedbackStats {
+	stats := make([]FeedbackStats, len(rows))
+	for i, row := range rows {
+		stats[i] = FeedbackStats{
+			Key:   row.Key,
+			Votes: row.Votes,
+			Up:    row.Up,
+			Down:  row.Down,
+			Late:  row.Late,
+			Noise: row.Noise,
+		}
+		if row.Votes > 0 {
+			stats[i].Approval = float64(row.Up) / float64(row.Votes) * 100
+		}
+	}
+	return stats
+}
+
+// totalStats возвращает метрики всех сигналов окна
+func (s *Service) totalStats(filter signal_journal_repo.AnalyticsFilter) (GroupStats, error) {
+	groups, err := s.groupStats(filter, signal_journal_repo.DimensionTotal)
+	if err != nil || len(groups) == 0 {
+		return GroupStats{Key: "all"}, err
+	}
+	return groups[0], nil
+}
+
+// groupStats запрашивает группы измерения
+func (s *Service) groupStats(filter signal_journal_repo.AnalyticsFilter, dimension string) ([]GroupStats, error) {
+	rows, err := s.repo.Aggregate(filter, dimension)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]GroupStats, len(rows))
+	for i, row := range rows {
+		groups[i] = newGroupStats(row)
+	}
+	return groups, nil
+}
+
+func newGroupStats(row *models.SignalGroupStats) GroupStats {
+	return GroupStats{
+		Key:       row.Key,
+		Signals:   row.Signals,
+		Evaluated: row.Evaluated,
+		Hits:      row.Hits,
+		HitRate:   row.HitRate(),
+		AvgReturn: row.AvgReturn,
+		AvgMFE:    row.AvgMFE,
+		AvgMAE:    row.AvgMAE,
+	}
+}
+
+// sortGroups упорядочивает группы: периоды и часы по возрастанию, остальное — по числу сигналов
+func sortGroups(dimension string, groups []GroupStats) {
+	switch dimension {
+	case DimensionHour:
+		return // SQL уже сортирует по ключу 00-23
+	case DimensionPeriod:
+		sort.Slice(groups, func(i, j int) bool {
+			a, _ := strconv.Atoi(groups[i].Key)
+			b, _ := strconv.Atoi(groups[j].Key)
+			return a < b
+		})
+	default:
+		sort.SliceStable(groups, func(i, j int) bool { return groups[i].Signals > groups[j].Signals })
+	}
+}
+
+// performers выбирает группы с прибылью (лучшие) и с убытком (худшие)
+func performers(groups []GroupStats, minSignals, top int) Performers {
+	var ranked []GroupStats
+	for _, g := range groups {
+		if g.Evaluated >= minSignals {
+			ranked = append(ranked, g)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].AvgReturn > ranked[j].AvgReturn })
+
+	var result Performers
+	for _, g := range ranked {
+		if g.AvgReturn > 0 && len(result.Top) < top {
+			result.Top = append(result.Top, g)
+		}
+	}
+	for i := len(ranked) - 1; i >= 0 && len(result.Worst) < top; i-- {
+		if ranked[i].AvgReturn <= 0 {
+			result.Worst = append(result.Worst, ranked[i])
+		}
+	}
+	return result
+}
+
+// trend сопоставляет группы текущего и предыдущего окна
+func trend(current, previous []GroupStats) []TrendStats {
+	byKey := make(map[string]GroupStats, len(previous))
+	for _, g := range previous {
+		byKey[g.Key] = g
+	}
+
+	result := make([]TrendStats, 0, len(current))
+	for _, g := range current {
+		prev := byKey[g.Key]
+		t := TrendStats{Key: g.Key, Current: g, Previous: prev}
+		if prev.Evaluated > 0 && g.Evaluated > 0 {
+			t.HitRateDelta = g.HitRate - prev.HitRate
+			t.ReturnDelta = g.AvgReturn - prev.AvgReturn
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func isHorizon(name string) bool {
+	for _, h := range Horizons {
+		if h.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func horizonNames() string {
+	names := make([]string, len(Horizons))
+	for i, h := range Horizons {
+		names[i] = h.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,199 @@
+// internal/core/domain/journal/analytics_format.go
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+)
+
+// analyticsDailyRows — сколько последних дней показывать в сообщении
+const analyticsDailyRows = 7
+
+// regimeLabels подписи режимов рынка: классификатора (market_regime в контексте)
+// и ориентира на свече сигнала для сигналов без него (bull/bear/flat)
+var regimeLabels = map[string]string{
+	"trend_up":   "📈 восходящий тренд",
+	"trend_down": "📉 нисходящий тренд",
+	"range":      "↔️ боковик",
+	"panic":      "🔥 паника",
+	"bull":       "📈 рост рынка",
+	"bear":       "📉 падение рынка",
+	"flat":       "➖ боковик",
+	"unknown":    "❔ без контекста",
+}
+
+// FormatAnalytics — отчет аналитики для Telegram (Markdown)
+func FormatAnalytics(r *AnalyticsReport) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("📊 *Аналитика сигналов* — %d дн., горизонт %s\n", r.Days, r.Horizon))
+	b.WriteString(fmt.Sprintf("%s — %s UTC\n\n", r.From.UTC().Format("02.01 15:04"), r.To.UTC().Format("02.01 15:04")))
+
+	if r.Total.Signals == 0 {
+		b.WriteString("За окно сигналов в журнале нет")
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("Сигналов: %d (оценено %d)\n%s\n", r.Total.Signals, r.Total.Evaluated, statsLine(r.Total)))
+	if r.Previous.Evaluated > 0 && r.Total.Evaluated > 0 {
+		b.WriteString(fmt.Sprintf("Прошлое окно: %d сиг., %s (%s)\n",
+			r.Previous.Signals, statsLine(r.Previous), deltaLine(r.Total.HitRate-r.Previous.HitRate, r.Total.AvgReturn-r.Previous.AvgReturn)))
+	}
+
+	for _, section := range []struct {
+		dimension, title string
+	}{
+		{DimensionAnalyzer, "анализаторы"},
+		{DimensionSymbol, "символы"},
+	} {
+		p := r.Performers[section.dimension]
+		if len(p.Top) > 0 {
+			b.WriteString(fmt.Sprintf("\n🏆 *Лучшие %s*\n", section.title))
+			for _, g := range p.Top {
+				b.WriteString(groupLine(section.dimension, g))
+			}
+		}
+		if len(p.Worst) > 0 {
+			b.WriteString(fmt.Sprintf("\n⚠️ *Худшие %s*\n", section.title))
+			for _, g := range p.Worst {
+				b.WriteString(groupLine(section.dimension, g))
+			}
+		}
+	}
+	if len(r.Performers[DimensionAnalyzer].Top)+len(r.Performers[DimensionAnalyzer].Worst) == 0 {
+		b.WriteString(fmt.Sprintf("\nДля рейтинга нужно от %d оцененных сигналов в группе\n", r.MinSignals))
+	}
+
+	if len(r.Trend) > 0 {
+		b.WriteString("\n📈 *Тренд анализаторов* (к прошлому окну)\n")
+		for _, t := range r.Trend {
+			line := fmt.Sprintf("`%s` %s", t.Key, statsLine(t.Current))
+			if t.Previous.Evaluated > 0 && t.Current.Evaluated > 0 {
+				line += " — " + deltaLine(t.HitRateDelta, t.ReturnDelta)
+			} else if t.Previous.Signals == 0 {
+				line += " — новый"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if total := r.Feedback[DimensionTotal]; len(total) > 0 && total[0].Votes > 0 {
+		b.WriteString(fmt.Sprintf("\n🗳 *Оценки пользователей*: %s\n", feedbackLine(total[0])))
+		for _, dimension := range []string{DimensionAnalyzer, DimensionPeriod} {
+			for _, f := range r.Feedback[dimension] {
+				b.WriteString(fmt.Sprintf("%s — %s\n", groupLabel(dimension, f.Key), feedbackLine(f)))
+			}
+		}
+	}
+
+	for _, section := range []struct {
+		dimension, title string
+	}{
+		{DimensionPeriod, "⏱ *Периоды*"},
+		{DimensionDirection, "↕️ *Направления*"},
+		{DimensionRegime, "🌐 *Режим рынка*"},
+	} {
+		groups := r.Dimensions[section.dimension]
+		if len(groups) == 0 {
+			continue
+		}
+		b.WriteString("\n" + section.title + "\n")
+		for _, g := range groups {
+			b.WriteString(groupLine(section.dimension, g))
+		}
+	}
+
+	if best, worst := bestWorstHours(r.Dimensions[DimensionHour], r.MinSignals); best != nil {
+		b.WriteString("\n⏰ *Часы (UTC)*\n")
+		b.WriteString(fmt.Sprintf("Лучший: %s:00 — %s\n", best.Key, statsLine(*best)))
+		if worst != nil && worst.Key != best.Key {
+			b.WriteString(fmt.Sprintf("Худший: %s:00 — %s\n", worst.Key, statsLine(*worst)))
+		}
+	}
+
+	if len(r.Daily) > 1 {
+		b.WriteString("\n📅 *По дням*\n")
+		daily := r.Daily
+		if len(daily) > analyticsDailyRows {
+			daily = daily[len(daily)-analyticsDailyRows:]
+		}
+		for _, g := range daily {
+			day := g.Key
+			if len(day) == len("2006-01-02") {
+				day = day[8:10] + "." + day[5:7]
+			}
+			b.WriteString(fmt.Sprintf("%s — %d сиг., %s\n", day, g.Signals, statsLine(g)))
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// groupLine — строка группы с подписью ключа измерения
+func groupLine(dimension string, g GroupStats) string {
+	return fmt.Sprintf("%s — %d сиг., %s\n", groupLabel(dimension, g.Key), g.Signals, statsLine(g))
+}
+
+// groupLabel подпись ключа группы
+func groupLabel(dimension, key string) string {
+	switch dimension {
+	case DimensionAnalyzer:
+		return "`" + key + "`"
+	case DimensionPeriod:
+		if minutes, err := strconv.Atoi(key); err == nil {
+			return periodPkg.MinutesToString(minutes)
+		}
+	case DimensionDirection:
+		if key == "short" {
+			return "📉 short"
+		}
+		return "📈 long"
+	case DimensionRegime:
+		if label, ok := regimeLabels[key]; ok {
+			return label
+		}
+	}
+	return key
+}
+
+// statsLine — hit rate, средняя доходность и MFE/MAE
+func statsLine(g GroupStats) string {
+	if g.Evaluated == 0 {
+		return "исходы считаются"
+	}
+	return fmt.Sprintf("hit %.0f%%, ср. %+.2f%% (MFE %.2f / MAE %.2f)", g.HitRate, g.AvgReturn, g.AvgMFE, g.AvgMAE)
+}
+
+// feedbackLine — число голосов, доля 👍 и разбивка по оценкам
+func feedbackLine(f FeedbackStats) string {
+	return fmt.Sprintf("%d гол., 👍 %.0f%% (👍 %d · 👎 %d · ⏰ %d · 🔇 %d)", f.Votes, f.Approval, f.Up, f.Down, f.Late, f.Noise)
+}
+
+// deltaLine — изменение hit rate (п.п.) и доходности со стрелкой
+func deltaLine(hitDelta, returnDelta float64) string {
+	arrow := "➡️"
+	switch {
+	case returnDelta > 0.01:
+		arrow = "↗️"
+	case returnDelta < -0.01:
+		arrow = "↘️"
+	}
+	return fmt.Sprintf("%s hit %+.1f п.п., ср. %+.2f", arrow, hitDelta, returnDelta)
+}
+
+// bestWorstHours возвращает часы с наибольшей и наименьшей доходностью
+func bestWorstHours(hours []GroupStats, minSignals int) (*GroupStats, *GroupStats) {
+	var ranked []GroupStats
+	for _, g := range hours {
+		if g.Evaluated >= minSignals {
+			ranked = append(ranked, g)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].AvgReturn > ranked[j].AvgReturn })
+	return &ranked[0], &ranked[len(ranked)-1]
+}
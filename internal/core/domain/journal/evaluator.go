@@ -0,0 +1,165 @@
+// internal/core/domain/journal/evaluator.go
+package journal
+
+import (
+	"sync"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// CandleSource источник истории свечей (реализуется CandleSystem)
+type CandleSource interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+const (
+	// evalPeriod — период свечей для оценки исходов
+	evalPeriod = "5m"
+	// evalHistoryLimit — глубина истории 5m свечей (~83 ч, лимит хранилища)
+	evalHistoryLimit = 1000
+	// cleanupInterval — как часто удаляются сигналы старше срока хранения
+	cleanupInterval = time.Hour
+)
+
+// Evaluator фоновый расчет исходов сигналов журнала
+type Evaluator struct {
+	service *Service
+	candles CandleSource
+	config  Config
+
+	lastCleanup time.Time
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+	mu          sync.Mutex
+	running     bool
+}
+
+// NewEvaluator создает оценщик исходов
+func NewEvaluator(service *Service, candles CandleSource, config Config) *Evaluator {
+	defaults := DefaultConfig()
+	if config.EvalInterval <= 0 {
+		config.EvalInterval = defaults.EvalInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	return &Evaluator{
+		service: service,
+		candles: candles,
+		config:  config,
+	}
+}
+
+// Start запускает фоновый цикл
+func (e *Evaluator) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running {
+		return
+	}
+	e.running = true
+	e.stopCh = make(chan struct{})
+
+	e.wg.Add(1)
+	go e.loop()
+
+	logger.Info("✅ SignalJournal: оценка исходов запущена (интервал %v)", e.config.EvalInterval)
+}
+
+// Stop останавливает фоновый цикл
+func (e *Evaluator) Stop() {
+	e.mu.Lock()
+	if !e.running {
+		e.mu.Unlock()
+		return
+	}
+	e.running = false
+	close(e.stopCh)
+	e.mu.Unlock()
+
+	e.wg.Wait()
+	logger.Info("🛑 SignalJournal: оценка исходов остановлена")
+}
+
+func (e *Evaluator) loop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.config.EvalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.runOnce(time.Now())
+		case <-e.stopCh:
+			return
+		}
+	}
+}
+
+// runOnce оценивает пачку сигналов, у которых наступил хотя бы первый горизонт
+func (e *Evaluator) runOnce(now time.Time) {
+	pending, err := e.service.repo.FindPending(now.Add(-Horizons[0].Duration), now, e.config.BatchSize)
+	if err != nil {
+		logger.Warn("⚠️ SignalJournal: не удалось получить сигналы для оценки: %v", err)
+		return
+	}
+
+	bySymbol := make(map[string][]*models.SignalRecord)
+	for _, rec := range pending {
+		bySymbol[rec.Symbol] = append(bySymbol[rec.Symbol], rec)
+	}
+
+	updated := 0
+	for symbol, records := range bySymbol {
+		candles, err := e.candles.GetHistory(symbol, evalPeriod, evalHistoryLimit)
+		if err != nil {
+			logger.Debug("SignalJournal: нет истории %s: %v", symbol, err)
+			candles = nil
+		}
+		for _, rec := range records {
+			if !Evaluate(rec, candles, now) {
+				continue
+			}
+			if err := e.service.repo.UpdateOutcome(rec); err != nil {
+				logger.Warn("⚠️ SignalJournal: не удалось сохранить исход %s %s: %v", rec.Symbol, rec.SignalID, err)
+				continue
+			}
+			updated++
+		}
+	}
+	if updated > 0 {
+		logger.Debug("SignalJournal: обновлено исходов: %d из %d", updated, len(pending))
+	}
+
+	e.cleanup(now)
+}
+
+// cleanup удаляет сигналы, доставки и оценки старше срока хранения (не чаще раза в час)
+func (e *Evaluator) cleanup(now time.Time) {
+	if e.config.Retention <= 0 || now.Sub(e.lastCleanup) < cleanupInterval {
+		return
+	}
+	e.lastCleanup = now
+
+	before := now.Add(-e.config.Retention)
+	deleted, err := e.service.repo.DeleteOlderThan(before)
+	if err != nil {
+		logger.Warn("⚠️ SignalJournal: очистка журнала: %v", err)
+		return
+	}
+	deliveries, err := e.service.deliveries.DeleteOlderThan(before)
+	if err != nil {
+		logger.Warn("⚠️ SignalJournal: очистка истории доставок: %v", err)
+	}
+	votes, err := e.service.feedback.DeleteOlderThan(before)
+	if err != nil {
+		logger.Warn("⚠️ SignalJournal: очистка оценок сигналов: %v", err)
+	}
+	if deleted > 0 || deliveries > 0 || votes > 0 {
+		logger.Info("🧹 SignalJournal: удалено старых сигналов: %d, доставок: %d, оценок: %d", deleted, deliveries, votes)
+	}
+}
@@ -0,0 +1,150 @@
+// internal/core/domain/journal/feedback.go
+package journal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// noiseRefreshInterval — как часто перечитываются отметки «шум» для персональных порогов
+const noiseRefreshInterval = 5 * time.Minute
+
+// FeedbackConfig настройки оценок сигналов пользователями
+type FeedbackConfig struct {
+	// Enabled — показывать кнопки оценки под карточками сигналов
+	Enabled bool
+	// Window — за сколько последних дней голоса влияют на персональные пороги
+	Window time.Duration
+	// NoiseMinVotes — с какого числа отметок «шум» (за вычетом 👍) повышается порог по символу
+	NoiseMinVotes int
+	// NoiseStep — прибавка к множителю порога за каждую отметку начиная с NoiseMinVotes
+	NoiseStep float64
+	// NoiseMaxFactor — предельный множитель порога
+	NoiseMaxFactor float64
+}
+
+// DefaultFeedbackConfig возвращает настройки по умолчанию
+func DefaultFeedbackConfig() FeedbackConfig {
+	return FeedbackConfig{
+		Enabled:        true,
+		Window:         30 * 24 * time.Hour,
+		NoiseMinVotes:  3,
+		NoiseStep:      0.25,
+		NoiseMaxFactor: 2,
+	}
+}
+
+// FeedbackVotes — допустимые голоса в порядке кнопок
+var FeedbackVotes = []string{models.FeedbackUp, models.FeedbackDown, models.FeedbackLate, models.FeedbackNoise}
+
+// IsFeedbackVote проверяет, что голос допустим
+func IsFeedbackVote(vote string) bool {
+	for _, v := range FeedbackVotes {
+		if v == vote {
+			return true
+		}
+	}
+	return false
+}
+
+// noiseCache — чистые отметки «шум» по пользователям и символам
+type noiseCache struct {
+	mu       sync.Mutex
+	counts   map[int]map[string]int
+	loadedAt time.Time
+}
+
+// SetFeedbackConfig задает настройки оценок; нулевые значения заменяются значениями по умолчанию
+func (s *Service) SetFeedbackConfig(cfg FeedbackConfig) {
+	defaults := DefaultFeedbackConfig()
+	if cfg.Window <= 0 {
+		cfg.Window = defaults.Window
+	}
+	if cfg.NoiseMinVotes <= 0 {
+		cfg.NoiseMinVotes = defaults.NoiseMinVotes
+	}
+	if cfg.NoiseStep <= 0 {
+		cfg.NoiseStep = defaults.NoiseStep
+	}
+	if cfg.NoiseMaxFactor < 1 {
+		cfg.NoiseMaxFactor = defaults.NoiseMaxFactor
+	}
+
+	s.noise.mu.Lock()
+	s.feedbackConfig = cfg
+	s.noise.loadedAt = time.Time{}
+	s.noise.mu.Unlock()
+}
+
+// FeedbackEnabled сообщает, нужно ли показывать кнопки оценки
+func (s *Service) FeedbackEnabled() bool {
+	s.noise.mu.Lock()
+	defer s.noise.mu.Unlock()
+	return s.feedbackConfig.Enabled
+}
+
+// Vote сохраняет голос пользователя за доставленный ему сигнал.
+// Возвращает false, если сигнал пользователю на платформе не доставлялся.
+func (s *Service) Vote(userID int, platform, signalID, vote string) (bool, error) {
+	if !IsFeedbackVote(vote) {
+		return false, fmt.Errorf("неизвестная оценка «%s»", vote)
+	}
+	if signalID == "" {
+		return false, nil
+	}
+	found, err := s.feedback.Vote(userID, platform, signalID, vote)
+	if err != nil || !found {
+		return found, err
+	}
+
+	// Голос меняет персональные пороги — перечитываем отметки при следующей проверке
+	s.noise.mu.Lock()
+	s.noise.loadedAt = time.Time{}
+	s.noise.mu.Unlock()
+	return true, nil
+}
+
+// NoiseFactor возвращает множитель порогов пользователя для символа:
+// 1 — без изменений, больше 1 — символ часто отмечался «шумом»
+func (s *Service) NoiseFactor(userID int, symbol string) float64 {
+	s.noise.mu.Lock()
+	defer s.noise.mu.Unlock()
+
+	cfg := s.feedbackConfig
+	if !cfg.Enabled {
+		return 1
+	}
+	if time.Since(s.noise.loadedAt) > noiseRefreshInterval {
+		s.reloadNoiseLocked(cfg)
+	}
+
+	votes := s.noise.counts[userID][symbol]
+	if votes < cfg.NoiseMinVotes {
+		return 1
+	}
+	return math.Min(cfg.NoiseMaxFactor, 1+cfg.NoiseStep*float64(votes-cfg.NoiseMinVotes+1))
+}
+
+// reloadNoiseLocked перечитывает отметки «шум»; при ошибке сохраняет прежние до следующего интервала
+func (s *Service) reloadNoiseLocked(cfg FeedbackConfig) {
+	s.noise.loadedAt = time.Now()
+
+	rows, err := s.feedback.NoisySymbols(time.Now().Add(-cfg.Window), cfg.NoiseMinVotes)
+	if err != nil {
+		logger.Warn("⚠️ SignalJournal: не удалось загрузить отметки «шум»: %v", err)
+		return
+	}
+	counts := make(map[int]map[string]int)
+	for _, row := range rows {
+		if counts[row.UserID] == nil {
+			counts[row.UserID] = make(map[string]int)
+		}
+		counts[row.UserID][row.Symbol] = row.Noise
+	}
+	s.noise.counts = counts
+}
@@ -0,0 +1,328 @@
+// internal/core/domain/journal/history.go
+package journal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	signal_delivery_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_delivery"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+)
+
+// HistoryPageSize — сигналов на странице истории
+const HistoryPageSize = 8
+
+// Диапазоны дат истории; пустая строка — за все время
+const (
+	RangeDay   = "d"
+	RangeWeek  = "w"
+	RangeMonth = "m"
+)
+
+// historyPeriods — порядок переключения периода в истории (0 — все)
+var historyPeriods = []int{0, 5, 15, 30, 60, 240, 1440}
+
+// HistoryQuery фильтр и страница истории сигналов пользователя.
+// Кодируется в callback_data через Encode/ParseQuery, поэтому поля короткие.
+type HistoryQuery struct {
+	Page          int
+	Symbol        string
+	Direction     string // growth, fall; пусто — все
+	PeriodMinutes int    // 0 — все периоды
+	Range         string // RangeDay, RangeWeek, RangeMonth, "ДДММ-ДДММ" или пусто
+}
+
+// HistoryPage страница истории
+type HistoryPage struct {
+	Items []*models.SignalHistoryItem
+	Total int
+	Page  int
+	Pages int
+}
+
+// Encode упаковывает запрос в строку для callback_data: page|dir|period|range|symbol
+func (q HistoryQuery) Encode() string {
+	dir := ""
+	switch q.Direction {
+	case "growth":
+		dir = "g"
+	case "fall":
+		dir = "f"
+	}
+	return fmt.Sprintf("%d|%s|%d|%s|%s", q.Page, dir, q.PeriodMinutes, q.Range, q.Symbol)
+}
+
+// ParseQuery распаковывает запрос из Encode; неизвестные поля сбрасываются
+func ParseQuery(s string) HistoryQuery {
+	parts := strings.Split(s, "|")
+	for len(parts) < 5 {
+		parts = append(parts, "")
+	}
+
+	var q HistoryQuery
+	if page, err := strconv.Atoi(parts[0]); err == nil && page > 0 {
+		q.Page = page
+	}
+	switch parts[1] {
+	case "g":
+		q.Direction = "growth"
+	case "f":
+		q.Direction = "fall"
+	}
+	if minutes, err := strconv.Atoi(parts[2]); err == nil && minutes > 0 {
+		q.PeriodMinutes = minutes
+	}
+	if _, _, err := rangeBounds(parts[3], time.Now()); err == nil {
+		q.Range = parts[3]
+	}
+	q.Symbol = strings.ToUpper(parts[4])
+	return q
+}
+
+// WithPage возвращает копию запроса с другой страницей
+func (q HistoryQuery) WithPage(page int) HistoryQuery {
+	if page < 0 {
+		page = 0
+	}
+	q.Page = page
+	return q
+}
+
+// NextDirection переключает направление: все → рост → падение → все (страница сбрасывается)
+func (q HistoryQuery) NextDirection() HistoryQuery {
+	switch q.Direction {
+	case "":
+		q.Direction = "growth"
+	case "growth":
+		q.Direction = "fall"
+	default:
+		q.Direction = ""
+	}
+	q.Page = 0
+	return q
+}
+
+// NextPeriod переключает период по кругу (страница сбрасывается)
+func (q HistoryQuery) NextPeriod() HistoryQuery {
+	next := historyPeriods[0]
+	for i, minutes := range historyPeriods {
+		if minutes == q.PeriodMinutes {
+			next = historyPeriods[(i+1)%len(historyPeriods)]
+			break
+		}
+	}
+	q.PeriodMinutes = next
+	q.Page = 0
+	return q
+}
+
+// NextRange переключает диапазон: все время → 24ч → 7 дней → 30 дней → все время
+func (q HistoryQuery) NextRange() HistoryQuery {
+	switch q.Range {
+	case "":
+		q.Range = RangeDay
+	case RangeDay:
+		q.Range = RangeWeek
+	case RangeWeek:
+		q.Range = RangeMonth
+	default:
+		q.Range = ""
+	}
+	q.Page = 0
+	return q
+}
+
+// DirectionLabel подпись направления
+func (q HistoryQuery) DirectionLabel() string {
+	switch q.Direction {
+	case "growth":
+		return "📈 рост"
+	case "fall":
+		return "📉 падение"
+	}
+	return "↕️ все"
+}
+
+// PeriodLabel подпись периода
+func (q HistoryQuery) PeriodLabel() string {
+	if q.PeriodMinutes == 0 {
+		return "все ТФ"
+	}
+	return periodPkg.MinutesToString(q.PeriodMinutes)
+}
+
+// RangeLabel подпись диапазона дат
+func (q HistoryQuery) RangeLabel() string {
+	switch q.Range {
+	case "":
+		return "всё время"
+	case RangeDay:
+		return "24 ч"
+	case RangeWeek:
+		return "7 дней"
+	case RangeMonth:
+		return "30 дней"
+	}
+	from, to, err := rangeBounds(q.Range, time.Now())
+	if err != nil {
+		return "всё время"
+	}
+	return fmt.Sprintf("%s–%s", from.Format("02.01"), to.Add(-time.Second).Format("02.01"))
+}
+
+// Describe — строка с активными фильтрами
+func (q HistoryQuery) Describe() string {
+	parts := []string{q.DirectionLabel(), q.PeriodLabel(), q.RangeLabel()}
+	if q.Symbol != "" {
+		parts = append([]string{q.Symbol}, parts...)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// ParseHistoryArgs разбирает аргументы команды истории:
+// символ, направление (рост/падение), период (5m…1d) и диапазон
+// (день, неделя, месяц, все, ДД.ММ или ДД.ММ-ДД.ММ) в любом порядке
+func ParseHistoryArgs(args string, now time.Time) (HistoryQuery, error) {
+	var q HistoryQuery
+	for _, token := range strings.Fields(args) {
+		lower := strings.ToLower(token)
+		switch lower {
+		case "growth", "рост", "up", "long", "лонг":
+			q.Direction = "growth"
+			continue
+		case "fall", "падение", "down", "short", "шорт":
+			q.Direction = "fall"
+			continue
+		case "day", "день", "сутки", "24h":
+			q.Range = RangeDay
+			continue
+		case "week", "неделя", "7d":
+			q.Range = RangeWeek
+			continue
+		case "month", "месяц", "30d":
+			q.Range = RangeMonth
+			continue
+		case "all", "все", "всё":
+			q.Range = ""
+			q.Direction = ""
+			q.PeriodMinutes = 0
+			continue
+		}
+		if periodPkg.IsStandardPeriod(lower) {
+			minutes, _ := periodPkg.StringToMinutes(lower)
+			q.PeriodMinutes = minutes
+			continue
+		}
+		if strings.ContainsAny(lower, ".") {
+			code, err := parseDateRange(lower, now)
+			if err != nil {
+				return q, err
+			}
+			q.Range = code
+			continue
+		}
+		symbol := normalizeSymbol(token)
+		if symbol == "" {
+			return q, fmt.Errorf("не понял «%s»: ожидается тикер, рост/падение, период или диапазон дат", token)
+		}
+		q.Symbol = symbol
+	}
+	return q, nil
+}
+
+// Filter строит фильтр репозитория для пользователя и платформы
+func (q HistoryQuery) Filter(userID int, platform string, now time.Time) signal_delivery_repo.HistoryFilter {
+	filter := signal_delivery_repo.HistoryFilter{
+		UserID:        userID,
+		Platform:      platform,
+		Symbol:        q.Symbol,
+		Direction:     q.Direction,
+		PeriodMinutes: q.PeriodMinutes,
+	}
+	if from, to, err := rangeBounds(q.Range, now); err == nil {
+		filter.From, filter.To = from, to
+	}
+	return filter
+}
+
+// parseDateRange переводит «ДД.ММ» или «ДД.ММ-ДД.ММ» в код диапазона «ДДММ-ДДММ»
+func parseDateRange(s string, now time.Time) (string, error) {
+	fromStr, toStr, found := strings.Cut(s, "-")
+	if !found {
+		toStr = fromStr
+	}
+	from, err := time.Parse("02.01", fromStr)
+	if err != nil {
+		return "", fmt.Errorf("неверная дата «%s»: ожидается ДД.ММ", fromStr)
+	}
+	to, err := time.Parse("02.01", toStr)
+	if err != nil {
+		return "", fmt.Errorf("неверная дата «%s»: ожидается ДД.ММ", toStr)
+	}
+	code := from.Format("0201") + "-" + to.Format("0201")
+	if _, _, err := rangeBounds(code, now); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// rangeBounds возвращает границы диапазона [from, to) в UTC; для пустого кода — нулевые
+func rangeBounds(code string, now time.Time) (time.Time, time.Time, error) {
+	now = now.UTC()
+	switch code {
+	case "":
+		return time.Time{}, time.Time{}, nil
+	case RangeDay:
+		return now.Add(-24 * time.Hour), time.Time{}, nil
+	case RangeWeek:
+		return now.AddDate(0, 0, -7), time.Time{}, nil
+	case RangeMonth:
+		return now.AddDate(0, 0, -30), time.Time{}, nil
+	}
+
+	fromStr, toStr, found := strings.Cut(code, "-")
+	if !found || len(fromStr) != 4 || len(toStr) != 4 {
+		return time.Time{}, time.Time{}, fmt.Errorf("неверный диапазон дат")
+	}
+	from, err := time.Parse("0201", fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("неверный диапазон дат")
+	}
+	to, err := time.Parse("0201", toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("неверный диапазон дат")
+	}
+
+	// Даты без года относятся к последним 12 месяцам
+	year := now.Year()
+	from = time.Date(year, from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	if from.After(now) {
+		from = from.AddDate(-1, 0, 0)
+	}
+	to = time.Date(from.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		to = to.AddDate(1, 0, 0)
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// normalizeSymbol приводит тикер к виду SOLUSDT; пусто — не тикер
+func normalizeSymbol(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.NewReplacer("/", "", "-", "", "_", "").Replace(s)
+	if s == "" {
+		return ""
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	if !strings.HasSuffix(s, "USDT") && !strings.HasSuffix(s, "USDC") {
+		s += "USDT"
+	}
+	return s
+}
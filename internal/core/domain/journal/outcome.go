@@ -0,0 +1,215 @@
+// internal/core/domain/journal/outcome.go
+package journal
+
+import (
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+)
+
+// Horizon горизонт оценки доходности сигнала
+type Horizon struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Horizons горизонты, по которым журнал считает доходность
+var Horizons = []Horizon{
+	{Name: "5m", Duration: 5 * time.Minute},
+	{Name: "15m", Duration: 15 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+	{Name: "4h", Duration: 4 * time.Hour},
+	{Name: "24h", Duration: 24 * time.Hour},
+}
+
+const (
+	// outcomeWindow — окно, в котором считаются MFE/MAE (совпадает с максимальным горизонтом)
+	outcomeWindow = 24 * time.Hour
+	// outcomeGrace — сколько ждать свечи после окна, прежде чем признать данные отсутствующими
+	outcomeGrace = time.Hour
+	// maxCandleGap — свеча, открытая позже горизонта на большее время, считается разрывом истории
+	maxCandleGap = 10 * time.Minute
+	// outcomeRetry — через сколько повторить оценку, если свеча наступившего горизонта еще не пришла
+	outcomeRetry = 5 * time.Minute
+)
+
+// horizonReturn возвращает указатель на поле доходности записи для горизонта
+func horizonReturn(rec *models.SignalRecord, name string) **float64 {
+	switch name {
+	case "5m":
+		return &rec.Ret5m
+	case "15m":
+		return &rec.Ret15m
+	case "1h":
+		return &rec.Ret1h
+	case "4h":
+		return &rec.Ret4h
+	case "24h":
+		return &rec.Ret24h
+	}
+	return nil
+}
+
+// Evaluate дописывает исход сигнала по закрытым свечам (старые -> новые) на момент now
+// и для незавершенного исхода назначает следующую оценку (NextDueAt).
+// Возвращает false, если изменений нет. Журнал передает 5m свечи, бэктест — 1m.
+func Evaluate(rec *models.SignalRecord, candles []*storage.Candle, now time.Time) bool {
+	changed := evaluateOutcome(rec, candles, now)
+	if rec.OutcomeStatus != models.SignalOutcomePending {
+		return changed
+	}
+	due := nextDue(rec, now)
+	if rec.NextDueAt == nil || !rec.NextDueAt.Equal(due) {
+		rec.NextDueAt = &due
+		changed = true
+	}
+	return changed
+}
+
+// nextDue возвращает момент следующей оценки: наступление ближайшего незаполненного
+// горизонта, а если он уже наступил (свеча не пришла) — повтор через outcomeRetry
+func nextDue(rec *models.SignalRecord, now time.Time) time.Time {
+	for _, h := range Horizons {
+		field := horizonReturn(rec, h.Name)
+		if field == nil || *field != nil {
+			continue
+		}
+		if target := rec.SignalTime.Add(h.Duration); target.After(now) {
+			return target
+		}
+		break
+	}
+	return now.Add(outcomeRetry)
+}
+
+func evaluateOutcome(rec *models.SignalRecord, candles []*storage.Candle, now time.Time) bool {
+	start := rec.SignalTime
+	windowEnd := start.Add(outcomeWindow)
+	expired := now.After(windowEnd.Add(outcomeGrace))
+
+	entry := rec.EntryPrice
+	if entry <= 0 {
+		// Цена входа не пришла с сигналом — берем закрытие свечи, содержащей момент сигнала
+		for _, c := range candles {
+			if !c.EndTime.Before(start) && c.Close > 0 {
+				entry = c.Close
+				break
+			}
+		}
+	}
+	if entry <= 0 || len(candles) == 0 {
+		if expired {
+			rec.OutcomeStatus = models.SignalOutcomeNoData
+			return true
+		}
+		return false
+	}
+
+	changed := false
+
+	// Доходности: закрытие первой закрытой свечи, завершившейся не раньше горизонта
+	for _, h := range Horizons {
+		field := horizonReturn(rec, h.Name)
+		if field == nil || *field != nil {
+			continue
+		}
+		target := start.Add(h.Duration)
+		if now.Before(target) {
+			continue
+		}
+		for _, c := range candles {
+			if !c.IsClosedFlag || c.EndTime.Before(target) {
+				continue
+			}
+			if c.StartTime.After(target.Add(maxCandleGap)) {
+				break
+			}
+			ret := (c.Close - entry) / entry * 100
+			*field = &ret
+			changed = true
+			break
+		}
+	}
+
+	// MFE/MAE: экстремумы свечей внутри окна; у свечи сигнала учитываем только закрытие,
+	// так как ее high/low могли сформироваться до сигнала
+	until := now
+	if until.After(windowEnd) {
+		until = windowEnd
+	}
+	high, low := entry, entry
+	var lastEnd time.Time
+	for _, c := range candles {
+		if c.EndTime.Before(start) || c.StartTime.After(until) {
+			continue
+		}
+		if c.StartTime.Before(start) {
+			if c.Close > 0 {
+				high = maxFloat(high, c.Close)
+				low = minFloat(low, c.Close)
+			}
+		} else {
+			if c.High > 0 {
+				high = maxFloat(high, c.High)
+			}
+			if c.Low > 0 {
+				low = minFloat(low, c.Low)
+			}
+		}
+		if c.EndTime.After(lastEnd) {
+			lastEnd = c.EndTime
+		}
+	}
+	if !lastEnd.IsZero() {
+		up := (high - entry) / entry * 100
+		down := (entry - low) / entry * 100
+		mfe, mae := up, down
+		if rec.IsShort() {
+			mfe, mae = down, up
+		}
+		if rec.MFEPct == nil || *rec.MFEPct != mfe || rec.MAEPct == nil || *rec.MAEPct != mae {
+			rec.MFEPct, rec.MAEPct = &mfe, &mae
+			changed = true
+		}
+		if lastEnd.After(until) {
+			lastEnd = until
+		}
+		if rec.EvaluatedUntil == nil || lastEnd.After(*rec.EvaluatedUntil) {
+			rec.EvaluatedUntil = &lastEnd
+			changed = true
+		}
+	}
+
+	complete := true
+	for _, h := range Horizons {
+		if field := horizonReturn(rec, h.Name); field != nil && *field == nil {
+			complete = false
+			break
+		}
+	}
+	switch {
+	case complete:
+		rec.OutcomeStatus = models.SignalOutcomeComplete
+		changed = true
+	case expired:
+		// Часть горизонтов пришлась на разрыв истории — сохраняем то, что удалось посчитать
+		rec.OutcomeStatus = models.SignalOutcomeNoData
+		changed = true
+	}
+	return changed
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,76 @@
+// internal/core/domain/journal/outcome_format.go
+package journal
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+)
+
+// itemReturns доходности доставки по горизонтам в порядке Horizons
+func itemReturns(item *models.SignalHistoryItem) []*float64 {
+	return []*float64{item.Ret5m, item.Ret15m, item.Ret1h, item.Ret4h, item.Ret24h}
+}
+
+// isFall возвращает true для сигналов на падение
+func isFall(direction string) bool {
+	switch direction {
+	case "fall", "down", "bearish", "short":
+		return true
+	}
+	return false
+}
+
+// outcomeMark — ✅, если цена пошла по направлению сигнала, ❌ — против
+func outcomeMark(direction string, ret float64) string {
+	if ret == 0 {
+		return "➖"
+	}
+	if (ret > 0) != isFall(direction) {
+		return "✅"
+	}
+	return "❌"
+}
+
+// OutcomeSummary — короткий исход для строки списка: последний рассчитанный горизонт
+func OutcomeSummary(item *models.SignalHistoryItem) string {
+	returns := itemReturns(item)
+	for i := len(Horizons) - 1; i >= 0; i-- {
+		if ret := returns[i]; ret != nil {
+			return fmt.Sprintf("%s %+.2f%% за %s", outcomeMark(item.Direction, *ret), *ret, Horizons[i].Name)
+		}
+	}
+	if item.OutcomeStatus == nil {
+		return "исход не записан"
+	}
+	if *item.OutcomeStatus == models.SignalOutcomeNoData {
+		return "нет данных"
+	}
+	return "⏳ считается"
+}
+
+// OutcomeDetails — исход по всем горизонтам и MFE/MAE для карточки (без разметки)
+func OutcomeDetails(item *models.SignalHistoryItem) string {
+	if item.OutcomeStatus == nil {
+		return "Исход: сигнал не записан в журнал"
+	}
+
+	var b strings.Builder
+	b.WriteString("Исход после сигнала:\n")
+	returns := itemReturns(item)
+	for i, h := range Horizons {
+		if ret := returns[i]; ret != nil {
+			b.WriteString(fmt.Sprintf("%s %s: %+.2f%%\n", outcomeMark(item.Direction, *ret), h.Name, *ret))
+		} else {
+			b.WriteString(fmt.Sprintf("⏳ %s: —\n", h.Name))
+		}
+	}
+	if item.MFEPct != nil && item.MAEPct != nil {
+		b.WriteString(fmt.Sprintf("MFE: +%.2f%% • MAE: −%.2f%% (за 24ч)\n", *item.MFEPct, *item.MAEPct))
+	}
+	if *item.OutcomeStatus == models.SignalOutcomeNoData {
+		b.WriteString("Часть горизонтов без данных: нет свечей за окно сигнала\n")
+	}
+	return strings.TrimSpace(b.String())
+}
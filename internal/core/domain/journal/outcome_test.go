@@ -0,0 +1,213 @@
+// internal/core/domain/journal/outcome_test.go
+package journal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+)
+
+var signalTime = time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+// series строит закрытые 5m свечи: i-я свеча открыта в signalTime + from + i·5m
+func series(from time.Duration, closes ...float64) []*storage.Candle {
+	candles := make([]*storage.Candle, 0, len(closes))
+	for i, price := range closes {
+		start := signalTime.Add(from + time.Duration(i)*5*time.Minute)
+		candles = append(candles, &storage.Candle{
+			Period:       "5m",
+			Open:         price,
+			High:         price,
+			Low:          price,
+			Close:        price,
+			StartTime:    start,
+			EndTime:      start.Add(5 * time.Minute),
+			IsClosedFlag: true,
+			IsRealFlag:   true,
+		})
+	}
+	return candles
+}
+
+// ramp возвращает n цен от first с шагом step
+func ramp(first, step float64, n int) []float64 {
+	prices := make([]float64, n)
+	for i := range prices {
+		prices[i] = first + float64(i)*step
+	}
+	return prices
+}
+
+func record(direction string, entry float64) *models.SignalRecord {
+	return &models.SignalRecord{
+		SignalID:      "test",
+		Symbol:        "BTCUSDT",
+		Direction:     direction,
+		EntryPrice:    entry,
+		SignalTime:    signalTime,
+		OutcomeStatus: models.SignalOutcomePending,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// Свечи первых 30 минут, разрыв истории, затем свечи с 3ч до 25ч
+	gapped := append(series(0, ramp(100, 0.1, 6)...), series(3*time.Hour, ramp(110, 0, 12*22)...)...)
+
+	tests := []struct {
+		name        string
+		rec         *models.SignalRecord
+		candles     []*storage.Candle
+		now         time.Duration // момент оценки от signalTime
+		wantChanged bool
+		wantStatus  string
+		wantRet     map[string]float64 // ожидаемые доходности; горизонты вне карты должны быть nil
+		wantMFE     float64
+		wantMAE     float64
+		wantNextDue time.Duration // от signalTime; 0 — NextDueAt не проверяется
+	}{
+		{
+			name:        "лонг: все горизонты заполнены",
+			rec:         record("growth", 100),
+			candles:     series(0, ramp(100, 0.1, 12*25)...),
+			now:         25 * time.Hour,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomeComplete,
+			wantRet:     map[string]float64{"5m": 0, "15m": 0.2, "1h": 1.1, "4h": 4.7, "24h": 28.7},
+			wantMFE:     28.8,
+			wantMAE:     0,
+		},
+		{
+			name:        "шорт: MFE по падению, следующая оценка на горизонте 4ч",
+			rec:         record("fall", 100),
+			candles:     series(0, ramp(100, -0.1, 30)...),
+			now:         2 * time.Hour,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomePending,
+			wantRet:     map[string]float64{"5m": 0, "15m": -0.2, "1h": -1.1},
+			wantMFE:     2.4,
+			wantMAE:     0,
+			wantNextDue: 4 * time.Hour,
+		},
+		{
+			name:        "разрыв истории: горизонт 1ч пропущен, повтор через outcomeRetry",
+			rec:         record("growth", 100),
+			candles:     gapped,
+			now:         5 * time.Hour,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomePending,
+			wantRet:     map[string]float64{"5m": 0, "15m": 0.2, "4h": 10},
+			wantMFE:     10,
+			wantMAE:     0,
+			wantNextDue: 5*time.Hour + outcomeRetry,
+		},
+		{
+			name:        "разрыв истории: после окна исход закрывается как no_data",
+			rec:         record("growth", 100),
+			candles:     gapped,
+			now:         26 * time.Hour,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomeNoData,
+			wantRet:     map[string]float64{"5m": 0, "15m": 0.2, "4h": 10, "24h": 10},
+			wantMFE:     10,
+			wantMAE:     0,
+		},
+		{
+			name:        "нет цены входа: берется закрытие свечи сигнала",
+			rec:         record("growth", 0),
+			candles:     series(-time.Minute, 100, 101, 102),
+			now:         15 * time.Minute,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomePending,
+			wantRet:     map[string]float64{"5m": 1},
+			wantMFE:     2,
+			wantMAE:     0,
+			wantNextDue: 15*time.Minute + outcomeRetry, // свеча горизонта 15m еще не закрылась
+		},
+		{
+			name:        "нет цены входа и свечей: ждем до истечения окна",
+			rec:         record("growth", 0),
+			now:         time.Hour,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomePending,
+			wantRet:     map[string]float64{},
+			wantNextDue: time.Hour + outcomeRetry,
+		},
+		{
+			name:        "нет цены входа и свечей: после окна no_data",
+			rec:         record("growth", 0),
+			now:         26 * time.Hour,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomeNoData,
+			wantRet:     map[string]float64{},
+		},
+		{
+			name:        "до первого горизонта: только срок следующей оценки",
+			rec:         record("growth", 100),
+			candles:     series(0, 100),
+			now:         3 * time.Minute,
+			wantChanged: true,
+			wantStatus:  models.SignalOutcomePending,
+			wantRet:     map[string]float64{},
+			wantNextDue: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := signalTime.Add(tt.now)
+			if changed := Evaluate(tt.rec, tt.candles, now); changed != tt.wantChanged {
+				t.Fatalf("Evaluate() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			rec := tt.rec
+
+			if rec.OutcomeStatus != tt.wantStatus {
+				t.Errorf("OutcomeStatus = %q, want %q", rec.OutcomeStatus, tt.wantStatus)
+			}
+			for _, h := range Horizons {
+				got := *horizonReturn(rec, h.Name)
+				want, ok := tt.wantRet[h.Name]
+				switch {
+				case !ok && got != nil:
+					t.Errorf("ret %s = %.4f, want nil", h.Name, *got)
+				case ok && got == nil:
+					t.Errorf("ret %s = nil, want %.4f", h.Name, want)
+				case ok && !approx(*got, want):
+					t.Errorf("ret %s = %.4f, want %.4f", h.Name, *got, want)
+				}
+			}
+			if len(tt.candles) > 0 {
+				if rec.MFEPct == nil || !approx(*rec.MFEPct, tt.wantMFE) {
+					t.Errorf("MFE = %v, want %.4f", deref(rec.MFEPct), tt.wantMFE)
+				}
+				if rec.MAEPct == nil || !approx(*rec.MAEPct, tt.wantMAE) {
+					t.Errorf("MAE = %v, want %.4f", deref(rec.MAEPct), tt.wantMAE)
+				}
+			}
+			if tt.wantNextDue != 0 {
+				want := signalTime.Add(tt.wantNextDue)
+				if rec.NextDueAt == nil || !rec.NextDueAt.Equal(want) {
+					t.Errorf("NextDueAt = %v, want %v", rec.NextDueAt, want)
+				}
+			}
+
+			// Повторная оценка в тот же момент ничего не меняет
+			if Evaluate(rec, tt.candles, now) && rec.OutcomeStatus == models.SignalOutcomePending {
+				t.Errorf("повторный Evaluate() сообщил об изменениях")
+			}
+		})
+	}
+}
+
+func approx(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
+
+func deref(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,216 @@
+// internal/core/domain/journal/recorder.go
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// pendingContextTTL — сколько ждать сигнал для контекста, пришедшего раньше него
+const pendingContextTTL = 2 * time.Minute
+
+// Recorder сохраняет в журнал каждый EventSignalDetected и дописывает
+// контекст counter-сигналов из EventCounterSignalDetected (связь по signal_id).
+// Шина доставляет события асинхронно, поэтому контекст, опередивший сигнал,
+// ждет его в pending и дописывается сразу после записи сигнала.
+type Recorder struct {
+	service  *Service
+	eventBus types.EventBus
+
+	mu      sync.Mutex
+	pending map[string]pendingContext
+}
+
+type pendingContext struct {
+	rec        *models.SignalRecord
+	receivedAt time.Time
+}
+
+// NewRecorder создает подписчика журнала
+func NewRecorder(service *Service, eventBus types.EventBus) *Recorder {
+	return &Recorder{
+		service:  service,
+		eventBus: eventBus,
+		pending:  make(map[string]pendingContext),
+	}
+}
+
+// Start подписывается на сигналы
+func (r *Recorder) Start() {
+	for _, eventType := range r.GetSubscribedEvents() {
+		r.eventBus.Subscribe(eventType, r)
+	}
+	logger.Info("✅ SignalJournal: запись сигналов запущена")
+}
+
+// Stop отписывается от сигналов
+func (r *Recorder) Stop() {
+	for _, eventType := range r.GetSubscribedEvents() {
+		r.eventBus.Unsubscribe(eventType, r)
+	}
+	logger.Info("🛑 SignalJournal: запись сигналов остановлена")
+}
+
+// GetName возвращает имя подписчика
+func (r *Recorder) GetName() string {
+	return "signal_journal_recorder"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (r *Recorder) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventSignalDetected, types.EventCounterSignalDetected}
+}
+
+// HandleEvent сохраняет сигнал или его контекст
+func (r *Recorder) HandleEvent(event types.Event) error {
+	switch data := event.Data.(type) {
+	case analysis.Signal:
+		return r.recordSignal(data, event)
+	case *analysis.Signal:
+		if data != nil {
+			return r.recordSignal(*data, event)
+		}
+	case map[string]interface{}:
+		if event.Type == types.EventCounterSignalDetected {
+			return r.attachCounterContext(data)
+		}
+	}
+	return nil
+}
+
+// recordSignal сохраняет analysis.Signal
+func (r *Recorder) recordSignal(signal analysis.Signal, event types.Event) error {
+	if signal.ID == "" || signal.Symbol == "" {
+		return nil
+	}
+
+	rec := &models.SignalRecord{
+		SignalID:      signal.ID,
+		Symbol:        signal.Symbol,
+		SignalType:    signal.Type,
+		Direction:     signal.Direction,
+		Strategy:      signal.Metadata.Strategy,
+		PeriodMinutes: signal.Period,
+		Confidence:    finiteOrZero(signal.Confidence),
+		ChangePercent: finiteOrZero(signal.ChangePercent),
+		EntryPrice:    finiteOrZero(signal.EndPrice),
+		SignalTime:    signal.Timestamp,
+		Tags:          signal.Metadata.Tags,
+	}
+	if rec.Strategy == "" {
+		rec.Strategy = event.Source
+	}
+	if rec.SignalTime.IsZero() {
+		rec.SignalTime = time.Now()
+	}
+
+	indicators := make(map[string]interface{}, len(signal.Metadata.Indicators))
+	for k, v := range signal.Metadata.Indicators {
+		indicators[k] = v
+	}
+	rec.Indicators = marshalMap(indicators)
+
+	context := make(map[string]interface{}, len(signal.Metadata.Custom)+2)
+	for k, v := range signal.Metadata.Custom {
+		context[k] = v
+	}
+	if len(signal.Metadata.Patterns) > 0 {
+		context["patterns"] = signal.Metadata.Patterns
+	}
+	if signal.StartPrice > 0 {
+		context["start_price"] = signal.StartPrice
+	}
+	rec.Context = marshalMap(context)
+
+	if err := r.service.Record(rec); err != nil {
+		logger.Warn("⚠️ SignalJournal: не удалось сохранить сигнал %s %s: %v", signal.Symbol, signal.ID, err)
+		return err
+	}
+
+	r.mu.Lock()
+	waiting, ok := r.pending[signal.ID]
+	delete(r.pending, signal.ID)
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if _, err := r.service.AttachContext(waiting.rec); err != nil {
+		logger.Warn("⚠️ SignalJournal: не удалось сохранить контекст %s %s: %v", signal.Symbol, signal.ID, err)
+		return err
+	}
+	return nil
+}
+
+// attachCounterContext дописывает индикаторы, OI, дельту и S/R counter-сигнала.
+// Новую запись контекст не создает: если сигнал еще не сохранен, контекст ждет его в pending.
+func (r *Recorder) attachCounterContext(data map[string]interface{}) error {
+	signalID, _ := data["signal_id"].(string)
+	symbol, _ := data["symbol"].(string)
+	if signalID == "" || symbol == "" {
+		return nil
+	}
+
+	rec := &models.SignalRecord{
+		SignalID: signalID,
+		Symbol:   symbol,
+		Context:  marshalMap(data),
+	}
+	if v, ok := data["current_price"].(float64); ok {
+		rec.EntryPrice = finiteOrZero(v)
+	}
+
+	// Блокировка держится на время UPDATE, чтобы recordSignal не разминулся с pending
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attached, err := r.service.AttachContext(rec)
+	if err != nil {
+		logger.Warn("⚠️ SignalJournal: не удалось сохранить контекст %s %s: %v", symbol, signalID, err)
+		return err
+	}
+	if !attached {
+		now := time.Now()
+		for id, waiting := range r.pending {
+			if now.Sub(waiting.receivedAt) > pendingContextTTL {
+				delete(r.pending, id)
+			}
+		}
+		r.pending[signalID] = pendingContext{rec: rec, receivedAt: now}
+	}
+	return nil
+}
+
+// marshalMap сериализует map в JSON, пропуская значения, которые JSON не допускает (NaN, Inf, каналы)
+func marshalMap(m map[string]interface{}) json.RawMessage {
+	clean := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			continue
+		}
+		if _, err := json.Marshal(v); err != nil {
+			logger.Debug("SignalJournal: поле %s пропущено: %v", k, err)
+			continue
+		}
+		clean[k] = v
+	}
+	raw, err := json.Marshal(clean)
+	if err != nil {
+		return json.RawMessage(fmt.Sprintf(`{"marshal_error":%q}`, err.Error()))
+	}
+	return raw
+}
+
+func finiteOrZero(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,155 @@
+// internal/core/domain/journal/recorder_test.go
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	signal_journal_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_journal"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// memoryJournal — журнал в памяти с семантикой Postgres-репозитория:
+// Record — upsert по signal_id, AttachContext — только UPDATE
+type memoryJournal struct {
+	signal_journal_repo.SignalJournalRepository
+	rows map[string]*models.SignalRecord
+}
+
+func newMemoryJournal() *memoryJournal {
+	return &memoryJournal{rows: make(map[string]*models.SignalRecord)}
+}
+
+func (m *memoryJournal) Record(rec *models.SignalRecord) error {
+	row := *rec
+	if existing, ok := m.rows[rec.SignalID]; ok {
+		row.Context = mergeJSON(existing.Context, rec.Context)
+	}
+	m.rows[rec.SignalID] = &row
+	return nil
+}
+
+func (m *memoryJournal) AttachContext(rec *models.SignalRecord) (bool, error) {
+	row, ok := m.rows[rec.SignalID]
+	if !ok {
+		return false, nil
+	}
+	row.Context = mergeJSON(row.Context, rec.Context)
+	if row.EntryPrice <= 0 {
+		row.EntryPrice = rec.EntryPrice
+	}
+	return true, nil
+}
+
+func (m *memoryJournal) Stream(context.Context, time.Time, time.Time, func(*models.SignalRecord) error) error {
+	return nil
+}
+
+// mergeJSON повторяет jsonb-оператор ||: ключи b перекрывают ключи a
+func mergeJSON(a, b json.RawMessage) json.RawMessage {
+	merged := map[string]interface{}{}
+	_ = json.Unmarshal(a, &merged)
+	_ = json.Unmarshal(b, &merged)
+	raw, _ := json.Marshal(merged)
+	return raw
+}
+
+func counterSignalEvents(id string) (types.Event, types.Event) {
+	signal := analysis.Signal{
+		ID:            id,
+		Symbol:        "BTCUSDT",
+		Type:          "counter_candle",
+		Direction:     "growth",
+		ChangePercent: 2.5,
+		Period:        15,
+		Confidence:    80,
+		EndPrice:      50000,
+		Timestamp:     signalTime,
+		Metadata:      analysis.Metadata{Strategy: "counter_candle_analyzer"},
+	}
+	detected := types.Event{Type: types.EventSignalDetected, Source: "analysis_engine", Data: signal}
+	counter := types.Event{
+		Type:   types.EventCounterSignalDetected,
+		Source: "counter_analyzer_raw",
+		Data: map[string]interface{}{
+			"signal_id":      id,
+			"symbol":         "BTCUSDT",
+			"direction":      "growth",
+			"period":         "15m",
+			"change_percent": 2.5,
+			"current_price":  50010.0,
+			"rsi":            71.0,
+		},
+	}
+	return detected, counter
+}
+
+func TestRecorderCounterSignalIsOneRow(t *testing.T) {
+	tests := []struct {
+		name         string
+		contextFirst bool
+	}{
+		{name: "сигнал раньше контекста"},
+		{name: "контекст раньше сигнала", contextFirst: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryJournal()
+			recorder := NewRecorder(&Service{repo: repo}, nil)
+
+			detected, counter := counterSignalEvents("sig-1")
+			events := []types.Event{detected, counter}
+			if tt.contextFirst {
+				events = []types.Event{counter, detected}
+			}
+			for _, event := range events {
+				if err := recorder.HandleEvent(event); err != nil {
+					t.Fatalf("HandleEvent(%s): %v", event.Type, err)
+				}
+			}
+
+			if len(repo.rows) != 1 {
+				t.Fatalf("строк в журнале = %d, ожидалась 1", len(repo.rows))
+			}
+			row := repo.rows["sig-1"]
+			if row == nil {
+				t.Fatal("нет строки с signal_id sig-1")
+			}
+			if row.SignalType != "counter_candle" || row.Confidence != 80 {
+				t.Errorf("поля сигнала: type=%q confidence=%v", row.SignalType, row.Confidence)
+			}
+			if row.EntryPrice != 50000 {
+				t.Errorf("entry_price = %v, ожидалась цена сигнала 50000", row.EntryPrice)
+			}
+
+			var ctx map[string]interface{}
+			if err := json.Unmarshal(row.Context, &ctx); err != nil {
+				t.Fatalf("context: %v", err)
+			}
+			if ctx["rsi"] != 71.0 {
+				t.Errorf("контекст counter-события не дописан: %v", ctx)
+			}
+			if len(recorder.pending) != 0 {
+				t.Errorf("в ожидании осталось %d контекстов", len(recorder.pending))
+			}
+		})
+	}
+}
+
+func TestRecorderContextWithoutSignalCreatesNoRow(t *testing.T) {
+	repo := newMemoryJournal()
+	recorder := NewRecorder(&Service{repo: repo}, nil)
+
+	_, counter := counterSignalEvents("sig-2")
+	if err := recorder.HandleEvent(counter); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+	if len(repo.rows) != 0 {
+		t.Fatalf("контекст без сигнала создал %d строк", len(repo.rows))
+	}
+}
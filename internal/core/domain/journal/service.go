@@ -0,0 +1,94 @@
+// internal/core/domain/journal/service.go
+// Журнал сигналов: каждый опубликованный сигнал с контекстом сохраняется в Postgres,
+// а фоновый оценщик дописывает доходности на горизонтах и MFE/MAE по свечам.
+package journal
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	signal_delivery_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_delivery"
+	signal_feedback_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_feedback"
+	signal_journal_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_journal"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Config настройки журнала
+type Config struct {
+	// EvalInterval — как часто оценщик дописывает исходы
+	EvalInterval time.Duration
+	// BatchSize — сколько сигналов оценивается за один проход
+	BatchSize int
+	// Retention — сколько хранить сигналы (0 — бессрочно)
+	Retention time.Duration
+}
+
+// DefaultConfig возвращает настройки по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		EvalInterval: time.Minute,
+		BatchSize:    500,
+		Retention:    180 * 24 * time.Hour,
+	}
+}
+
+// Service доступ к журналу сигналов, истории доставок и оценкам пользователей
+type Service struct {
+	repo       signal_journal_repo.SignalJournalRepository
+	deliveries signal_delivery_repo.SignalDeliveryRepository
+	feedback   signal_feedback_repo.SignalFeedbackRepository
+
+	feedbackConfig FeedbackConfig // защищен noise.mu
+	noise          noiseCache
+}
+
+// NewService создает сервис журнала
+func NewService(db *sqlx.DB) *Service {
+	return &Service{
+		repo:           signal_journal_repo.NewSignalJournalRepository(db),
+		deliveries:     signal_delivery_repo.NewSignalDeliveryRepository(db),
+		feedback:       signal_feedback_repo.NewSignalFeedbackRepository(db),
+		feedbackConfig: DefaultFeedbackConfig(),
+	}
+}
+
+// Record сохраняет сигнал
+func (s *Service) Record(rec *models.SignalRecord) error {
+	return s.repo.Record(rec)
+}
+
+// AttachContext дополняет контекст сохраненного сигнала; false — сигнала еще нет
+func (s *Service) AttachContext(rec *models.SignalRecord) (bool, error) {
+	return s.repo.AttachContext(rec)
+}
+
+// RecordDelivery сохраняет факт доставки сигнала пользователю
+func (s *Service) RecordDelivery(delivery *models.SignalDelivery) error {
+	return s.deliveries.Create(delivery)
+}
+
+// History возвращает страницу истории сигналов пользователя на платформе.
+// Страница за пределами истории сдвигается на последнюю.
+func (s *Service) History(userID int, platform string, query HistoryQuery) (HistoryPage, error) {
+	filter := query.Filter(userID, platform, time.Now())
+	items, total, err := s.deliveries.FindHistory(filter, HistoryPageSize, query.Page*HistoryPageSize)
+	if err != nil {
+		return HistoryPage{}, err
+	}
+
+	pages := (total + HistoryPageSize - 1) / HistoryPageSize
+	page := HistoryPage{Items: items, Total: total, Page: query.Page, Pages: pages}
+	if total > 0 && query.Page >= pages {
+		page.Page = pages - 1
+		page.Items, _, err = s.deliveries.FindHistory(filter, HistoryPageSize, page.Page*HistoryPageSize)
+		if err != nil {
+			return HistoryPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// Delivery возвращает доставку пользователя с исходом; nil, если не найдена
+func (s *Service) Delivery(userID int, id int64) (*models.SignalHistoryItem, error) {
+	return s.deliveries.FindByID(userID, id)
+}
@@ -0,0 +1,82 @@
+// internal/core/domain/paper/atr.go
+package paper
+
+import (
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// atrLength — длина ATR для уровней TP/SL
+	atrLength = 14
+	// atrFallbackPeriod — период свечей, если у периода сигнала нет свечей
+	atrFallbackPeriod = "5m"
+)
+
+// ErrNoATR — по символу недостаточно свечей для расчета ATR
+var ErrNoATR = errors.New("недостаточно свечей для ATR")
+
+// atrPeriods — периоды свечей по длительности сигнала в минутах
+var atrPeriods = map[int]string{
+	1:    "1m",
+	5:    "5m",
+	15:   "15m",
+	30:   "30m",
+	60:   "1h",
+	240:  "4h",
+	1440: "1d",
+}
+
+// atr возвращает ATR(14) на свечах периода сигнала (или 5m, если их нет)
+func (s *Service) atr(symbol string, periodMinutes int) (float64, error) {
+	if s.candles == nil {
+		return 0, ErrNoATR
+	}
+	source := s.candles()
+	if source == nil {
+		return 0, ErrNoATR
+	}
+
+	periods := []string{atrFallbackPeriod}
+	if period, ok := atrPeriods[periodMinutes]; ok && period != atrFallbackPeriod {
+		periods = append([]string{period}, periods...)
+	}
+	for _, period := range periods {
+		candles, err := source.GetHistory(symbol, period, atrLength+1)
+		if err != nil {
+			continue
+		}
+		if value, ok := ATR(candles, atrLength); ok {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrNoATR, symbol)
+}
+
+// ATR рассчитывает средний истинный диапазон за length последних свечей
+func ATR(candles []*storage.Candle, length int) (float64, bool) {
+	valid := make([]*storage.Candle, 0, len(candles))
+	for _, c := range candles {
+		if c != nil && c.High > 0 && c.Low > 0 {
+			valid = append(valid, c)
+		}
+	}
+	if length <= 0 || len(valid) < length+1 {
+		return 0, false
+	}
+	sort.Slice(valid, func(i, j int) bool { return valid[i].StartTime.Before(valid[j].StartTime) })
+
+	valid = valid[len(valid)-length-1:]
+	sum := 0.0
+	for i := 1; i < len(valid); i++ {
+		prevClose := valid[i-1].Close
+		tr := math.Max(valid[i].High-valid[i].Low,
+			math.Max(math.Abs(valid[i].High-prevClose), math.Abs(valid[i].Low-prevClose)))
+		sum += tr
+	}
+	atr := sum / float64(length)
+	return atr, atr > 0
+}
@@ -0,0 +1,199 @@
+// internal/core/domain/paper/command.go
+package paper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// CommandHelp справка по команде /paper (без разметки)
+const CommandHelp = `📄 Paper trading — виртуальный портфель по вашим сигналам
+
+/paper — отчёт: капитал, кривая, статистика сделок
+/paper on | off — включить или выключить открытие позиций по сигналам
+/paper rules — текущие правила
+/paper set size=100 tp=2 sl=1 — изменить правила
+/paper reset — удалить все позиции и начать заново
+
+Правила:
+size=100 — $ на позицию, size=5% — процент капитала
+tp=2 / sl=1 — уровни в %, tp=3atr / sl=1.5atr — в ATR(14), 0 — без уровня
+timeout=4h — закрыть по времени (30m, 4h, 1d, 0 — без таймаута)
+max=5 — максимум открытых позиций
+dir=both | long | short — какие сигналы отрабатывать
+balance=10000 — стартовый капитал`
+
+// Command выполняет команду /paper с аргументами и возвращает текст ответа (без разметки)
+func (s *Service) Command(userID int, args string) string {
+	fields := strings.Fields(strings.ToLower(args))
+	action := ""
+	if len(fields) > 0 {
+		action = fields[0]
+	}
+
+	switch action {
+	case "", "report", "stats":
+		report, err := s.Report(userID)
+		if err != nil {
+			return s.commandError(userID, err)
+		}
+		text := FormatReport(report)
+		if !report.Settings.Enabled && report.Trades == 0 && len(report.Open) == 0 {
+			text += "\n\nВключите портфель: /paper on\nСправка: /paper help"
+		}
+		return text
+
+	case "on", "off":
+		settings, err := s.SetEnabled(userID, action == "on")
+		if err != nil {
+			return s.commandError(userID, err)
+		}
+		if settings.Enabled {
+			return "✅ Paper-портфель включен: новые сигналы будут открывать виртуальные позиции\n\n" + FormatSettings(settings)
+		}
+		return "⏸ Paper-портфель выключен. Открытые позиции сопровождаются до TP/SL/таймаута"
+
+	case "rules", "settings":
+		settings, err := s.Settings(userID)
+		if err != nil {
+			return s.commandError(userID, err)
+		}
+		return FormatSettings(settings)
+
+	case "set":
+		if len(fields) < 2 {
+			return "Укажите правила, например: /paper set size=5% tp=2atr sl=1atr\n\n" + CommandHelp
+		}
+		settings, err := s.Settings(userID)
+		if err != nil {
+			return s.commandError(userID, err)
+		}
+		if err := ApplyRules(settings, fields[1:], s.config.MaxOpenLimit); err != nil {
+			return "❌ " + err.Error()
+		}
+		if err := s.SaveSettings(settings); err != nil {
+			return s.commandError(userID, err)
+		}
+		return "✅ Правила сохранены\n\n" + FormatSettings(settings)
+
+	case "reset":
+		if err := s.Reset(userID); err != nil {
+			return s.commandError(userID, err)
+		}
+		return "🗑 Paper-портфель сброшен: позиции удалены, правила сохранены"
+	}
+	return CommandHelp
+}
+
+// commandError логирует ошибку команды и возвращает текст для пользователя
+func (s *Service) commandError(userID int, err error) string {
+	logger.Warn("⚠️ PaperTrading: user=%d: %v", userID, err)
+	return "❌ Не удалось выполнить команду, попробуйте позже"
+}
+
+// ApplyRules применяет к правилам аргументы вида key=value
+func ApplyRules(settings *models.PaperSettings, args []string, maxOpenLimit int) error {
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return fmt.Errorf("ожидается ключ=значение, получено «%s»", arg)
+		}
+
+		switch key {
+		case "size":
+			number, percent := strings.CutSuffix(value, "%")
+			size, err := parsePositive(number)
+			if err != nil || size == 0 || (percent && size > 100) {
+				return fmt.Errorf("size: ожидается сумма в $ или процент капитала до 100%%, например size=100 или size=5%%")
+			}
+			settings.SizeValue, settings.SizePercent = size, percent
+
+		case "tp", "sl":
+			level, inATR, err := parseLevel(value)
+			if err != nil {
+				return fmt.Errorf("%s: ожидается процент или ATR, например %s=2 или %s=1.5atr", key, key, key)
+			}
+			if key == "tp" {
+				settings.TakeProfit, settings.TakeProfitATR = level, inATR
+			} else {
+				settings.StopLoss, settings.StopLossATR = level, inATR
+			}
+
+		case "timeout":
+			minutes, err := parseMinutes(value)
+			if err != nil {
+				return fmt.Errorf("timeout: ожидается длительность, например timeout=30m, 4h или 0")
+			}
+			settings.TimeoutMinutes = minutes
+
+		case "max":
+			maxOpen, err := strconv.Atoi(value)
+			if err != nil || maxOpen < 1 || maxOpen > maxOpenLimit {
+				return fmt.Errorf("max: ожидается число от 1 до %d", maxOpenLimit)
+			}
+			settings.MaxOpen = maxOpen
+
+		case "dir", "direction":
+			switch value {
+			case models.PaperDirectionBoth, models.PaperSideLong, models.PaperSideShort:
+				settings.Direction = value
+			default:
+				return fmt.Errorf("dir: ожидается both, long или short")
+			}
+
+		case "balance":
+			balance, err := parsePositive(value)
+			if err != nil || balance == 0 {
+				return fmt.Errorf("balance: ожидается положительная сумма в $")
+			}
+			settings.StartBalance = balance
+
+		default:
+			return fmt.Errorf("неизвестное правило «%s»", key)
+		}
+	}
+	return nil
+}
+
+// parsePositive разбирает неотрицательное число (допускается запятая)
+func parsePositive(value string) (float64, error) {
+	number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("некорректное число %q", value)
+	}
+	return number, nil
+}
+
+// parseLevel разбирает уровень TP/SL: 2, 2% или 1.5atr
+func parseLevel(value string) (float64, bool, error) {
+	number, inATR := strings.CutSuffix(value, "atr")
+	if !inATR {
+		number = strings.TrimSuffix(number, "%")
+	}
+	level, err := parsePositive(number)
+	return level, inATR, err
+}
+
+// parseMinutes разбирает длительность: минуты числом или 30m, 4h, 1d
+func parseMinutes(value string) (int, error) {
+	if minutes, err := strconv.Atoi(value); err == nil && minutes >= 0 {
+		return minutes, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("некорректная длительность %q", value)
+		}
+		return n * 24 * 60, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("некорректная длительность %q", value)
+	}
+	return int(duration / time.Minute), nil
+}
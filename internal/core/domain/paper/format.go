@@ -0,0 +1,192 @@
+// internal/core/domain/paper/format.go
+package paper
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+)
+
+// sparkLevels — символы мини-графика кривой капитала
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sparkWidth — число точек мини-графика
+const sparkWidth = 24
+
+// exitReasonLabels — подписи причин закрытия
+var exitReasonLabels = map[string]string{
+	models.PaperExitTakeProfit: "TP",
+	models.PaperExitStopLoss:   "SL",
+	models.PaperExitTimeout:    "таймаут",
+	models.PaperExitManual:     "вручную",
+}
+
+// FormatReport форматирует отчёт портфеля (без разметки — общий для Telegram и MAX)
+func FormatReport(r *Report) string {
+	var b strings.Builder
+	b.WriteString("📄 Paper-портфель\n")
+	fmt.Fprintf(&b, "Статус: %s\n", enabledLabel(r.Settings.Enabled))
+	fmt.Fprintf(&b, "С %s\n\n", r.Settings.StartedAt.Format("02.01.2006 15:04"))
+
+	fmt.Fprintf(&b, "💰 Капитал: $%.2f (%+.2f%%)\n", r.Equity, r.ReturnPct)
+	fmt.Fprintf(&b, "Старт: $%.2f\n", r.StartBalance)
+	fmt.Fprintf(&b, "Реализовано: %s\n", usd(r.RealizedUSD))
+	if len(r.Open) > 0 {
+		fmt.Fprintf(&b, "Открытые позиции: %s\n", usd(r.UnrealizedUSD))
+	}
+	if len(r.Curve) > 1 {
+		fmt.Fprintf(&b, "Кривая: %s\n", Sparkline(r.Curve, sparkWidth))
+	}
+
+	b.WriteString("\n📊 Сделки\n")
+	if r.Trades == 0 {
+		b.WriteString("Закрытых сделок пока нет\n")
+	} else {
+		fmt.Fprintf(&b, "Всего: %d (✅ %d / ❌ %d), винрейт %.0f%%\n", r.Trades, r.Wins, r.Losses, r.WinRate)
+		fmt.Fprintf(&b, "Profit factor: %s\n", profitFactor(r.ProfitFactor))
+		fmt.Fprintf(&b, "Средняя прибыль: %s, средний убыток: %s\n", usd(r.AvgWinUSD), usd(r.AvgLossUSD))
+		fmt.Fprintf(&b, "Ожидание на сделку: %s\n", usd(r.Expectancy))
+		fmt.Fprintf(&b, "Лучшая: %+.2f%%, худшая: %+.2f%%\n", r.BestPct, r.WorstPct)
+		fmt.Fprintf(&b, "Макс. просадка: %.2f%%\n", r.MaxDrawdownPct)
+		fmt.Fprintf(&b, "Закрытия: %s\n", exitReasons(r.ExitReasons))
+	}
+
+	if len(r.Open) > 0 {
+		fmt.Fprintf(&b, "\n📂 Открыто: %d из %d\n", len(r.Open), r.Settings.MaxOpen)
+		for _, view := range r.Open {
+			b.WriteString(openLine(view))
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// FormatSettings форматирует правила портфеля (без разметки)
+func FormatSettings(s *models.PaperSettings) string {
+	var b strings.Builder
+	b.WriteString("⚙️ Правила paper-портфеля\n")
+	fmt.Fprintf(&b, "Статус: %s\n", enabledLabel(s.Enabled))
+	fmt.Fprintf(&b, "Направление: %s\n", directionLabel(s.Direction))
+	if s.SizePercent {
+		fmt.Fprintf(&b, "Размер позиции: %g%% капитала\n", s.SizeValue)
+	} else {
+		fmt.Fprintf(&b, "Размер позиции: $%g\n", s.SizeValue)
+	}
+	fmt.Fprintf(&b, "Take profit: %s\n", levelLabel(s.TakeProfit, s.TakeProfitATR))
+	fmt.Fprintf(&b, "Stop loss: %s\n", levelLabel(s.StopLoss, s.StopLossATR))
+	if s.TimeoutMinutes > 0 {
+		fmt.Fprintf(&b, "Таймаут: %s\n", minutesLabel(s.TimeoutMinutes))
+	} else {
+		b.WriteString("Таймаут: нет\n")
+	}
+	fmt.Fprintf(&b, "Макс. открытых позиций: %d\n", s.MaxOpen)
+	fmt.Fprintf(&b, "Стартовый капитал: $%g", s.StartBalance)
+	return b.String()
+}
+
+// Sparkline рисует мини-график значений, прореженный до width точек
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if len(values) > width && width > 1 {
+		sampled := make([]float64, width)
+		for i := range sampled {
+			sampled[i] = values[i*(len(values)-1)/(width-1)]
+		}
+		values = sampled
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparkLevels) / 2
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// openLine — строка открытой позиции
+func openLine(view OpenPositionView) string {
+	p := view.Position
+	side := "🟢 long"
+	if !p.IsLong() {
+		side = "🔴 short"
+	}
+	line := fmt.Sprintf("%s %s по %.8g ($%.0f)", side, p.Symbol, p.EntryPrice, p.SizeUSD)
+	if view.Price > 0 {
+		line += fmt.Sprintf(" → %.8g, %+.2f%% (%s)", view.Price, view.PnLPct, usd(view.PnLUSD))
+	} else {
+		line += ", нет цены"
+	}
+	return line
+}
+
+func usd(v float64) string {
+	if v < 0 {
+		return fmt.Sprintf("-$%.2f", -v)
+	}
+	return fmt.Sprintf("+$%.2f", v)
+}
+
+func profitFactor(pf float64) string {
+	if math.IsInf(pf, 1) {
+		return "∞"
+	}
+	return fmt.Sprintf("%.2f", pf)
+}
+
+func exitReasons(reasons map[string]int) string {
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range []string{models.PaperExitTakeProfit, models.PaperExitStopLoss, models.PaperExitTimeout, models.PaperExitManual} {
+		if n := reasons[reason]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", exitReasonLabels[reason], n))
+		}
+	}
+	if len(parts) == 0 {
+		return "—"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func enabledLabel(enabled bool) string {
+	if enabled {
+		return "✅ сигналы отрабатываются"
+	}
+	return "⏸ выключен"
+}
+
+func directionLabel(direction string) string {
+	switch direction {
+	case models.PaperSideLong:
+		return "только long"
+	case models.PaperSideShort:
+		return "только short"
+	}
+	return "long и short"
+}
+
+func levelLabel(value float64, inATR bool) string {
+	switch {
+	case value <= 0:
+		return "нет"
+	case inATR:
+		return fmt.Sprintf("%g ATR", value)
+	}
+	return fmt.Sprintf("%g%%", value)
+}
+
+func minutesLabel(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d ч", minutes/60)
+	}
+	return fmt.Sprintf("%d мин", minutes)
+}
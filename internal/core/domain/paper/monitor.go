@@ -0,0 +1,162 @@
+// internal/core/domain/paper/monitor.go
+package paper
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Monitor сопровождает открытые paper-позиции на каждом EventPriceUpdated:
+// закрывает по TP/SL и по таймауту. Позиции открываются доставкой сигналов
+// в другом экземпляре сервиса, поэтому список перечитывается из Postgres
+// не реже RefreshInterval.
+type Monitor struct {
+	service  *Service
+	eventBus types.EventBus
+
+	mu        sync.Mutex
+	positions map[string][]*models.PaperPosition // по символу
+	loadedAt  time.Time
+}
+
+// NewMonitor создает монитор paper-позиций
+func NewMonitor(service *Service, eventBus types.EventBus) *Monitor {
+	return &Monitor{
+		service:  service,
+		eventBus: eventBus,
+	}
+}
+
+// Start подписывается на обновления цен
+func (m *Monitor) Start() {
+	m.eventBus.Subscribe(types.EventPriceUpdated, m)
+	logger.Info("✅ PaperTradingMonitor запущен")
+}
+
+// Stop отписывается от обновлений цен
+func (m *Monitor) Stop() {
+	m.eventBus.Unsubscribe(types.EventPriceUpdated, m)
+	logger.Info("🛑 PaperTradingMonitor остановлен")
+}
+
+// GetName возвращает имя подписчика
+func (m *Monitor) GetName() string {
+	return "paper_trading_monitor"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (m *Monitor) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventPriceUpdated}
+}
+
+// HandleEvent проверяет уровни и таймауты открытых позиций
+func (m *Monitor) HandleEvent(event types.Event) error {
+	prices := make(map[string]float64)
+	switch data := event.Data.(type) {
+	case []storage.PriceData:
+		for _, p := range data {
+			prices[p.Symbol] = p.Price
+		}
+	case storage.PriceData:
+		prices[data.Symbol] = data.Price
+	default:
+		return nil
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.loadedAt) >= m.service.config.RefreshInterval {
+		if err := m.reload(now); err != nil {
+			logger.Warn("⚠️ PaperTradingMonitor: не удалось загрузить позиции: %v", err)
+			return err
+		}
+	}
+
+	for symbol, positions := range m.positions {
+		price, ok := prices[symbol]
+		kept := positions[:0]
+		for _, position := range positions {
+			if !m.check(position, price, ok, now) {
+				kept = append(kept, position)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m.positions, symbol)
+		} else {
+			m.positions[symbol] = kept
+		}
+	}
+	return nil
+}
+
+// reload перечитывает открытые позиции
+func (m *Monitor) reload(now time.Time) error {
+	open, err := m.service.repo.FindOpen()
+	if err != nil {
+		return err
+	}
+	positions := make(map[string][]*models.PaperPosition)
+	for _, position := range open {
+		positions[position.Symbol] = append(positions[position.Symbol], position)
+	}
+	m.positions = positions
+	m.loadedAt = now
+	return nil
+}
+
+// check закрывает позицию при достижении уровня или таймаута; возвращает true, если позиция
+// больше не сопровождается. hasPrice — есть ли цена символа в текущем обновлении.
+func (m *Monitor) check(position *models.PaperPosition, price float64, hasPrice bool, now time.Time) bool {
+	if hasPrice && price > 0 {
+		// Стоп проверяется первым (консервативно) и исполняется по рыночной цене,
+		// тейк — по цене лимитного ордера
+		if position.StopLossPrice > 0 && crossed(position, price, position.StopLossPrice, false) {
+			return m.close(position, price, models.PaperExitStopLoss, now)
+		}
+		if position.TakeProfitPrice > 0 && crossed(position, price, position.TakeProfitPrice, true) {
+			return m.close(position, position.TakeProfitPrice, models.PaperExitTakeProfit, now)
+		}
+	}
+
+	if position.ExpiresAt == nil || now.Before(*position.ExpiresAt) {
+		return false
+	}
+	if !hasPrice || price <= 0 {
+		var ok bool
+		if price, ok = m.service.currentPrice(position.Symbol); !ok {
+			return false
+		}
+	}
+	return m.close(position, price, models.PaperExitTimeout, now)
+}
+
+// close закрывает позицию; при ошибке позиция остаётся в сопровождении
+func (m *Monitor) close(position *models.PaperPosition, price float64, reason string, now time.Time) bool {
+	closed, err := m.service.closePosition(position, price, reason, now)
+	if err != nil {
+		logger.Warn("⚠️ PaperTradingMonitor: %v", err)
+		return false
+	}
+	if closed {
+		logger.Debug("📄 PaperTrading: user=%d закрыт %s %s по %.8g (%s), PnL $%.2f",
+			position.UserID, position.Side, position.Symbol, price, reason, *position.PnLUSD)
+	}
+	return true
+}
+
+// crossed проверяет, достигла ли цена уровня: profit — уровень в сторону прибыли
+func crossed(position *models.PaperPosition, price, level float64, profit bool) bool {
+	if position.IsLong() == profit {
+		return price >= level
+	}
+	return price <= level
+}
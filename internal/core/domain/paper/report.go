@@ -0,0 +1,152 @@
+// internal/core/domain/paper/report.go
+package paper
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"math"
+	"time"
+)
+
+// OpenPositionView открытая позиция с оценкой по текущей цене
+type OpenPositionView struct {
+	Position *models.PaperPosition
+	// Price — текущая цена (0, если цены нет — позиция оценивается по входу)
+	Price  float64
+	PnLUSD float64
+	PnLPct float64
+}
+
+// Report состояние paper-портфеля и статистика кривой капитала
+type Report struct {
+	Settings    *models.PaperSettings
+	GeneratedAt time.Time
+
+	StartBalance float64
+	RealizedUSD  float64
+	// UnrealizedUSD — оценка открытых позиций по текущим ценам
+	UnrealizedUSD float64
+	Equity        float64
+	ReturnPct     float64
+
+	Trades       int
+	Wins         int
+	Losses       int
+	WinRate      float64
+	AvgWinUSD    float64
+	AvgLossUSD   float64
+	ProfitFactor float64 // +Inf, если убыточных сделок нет
+	Expectancy   float64 // средний результат сделки, $
+	BestPct      float64
+	WorstPct     float64
+	// MaxDrawdownPct — максимальная просадка реализованной кривой капитала от пика
+	MaxDrawdownPct float64
+	// ExitReasons — число закрытий по причинам (models.PaperExit*)
+	ExitReasons map[string]int
+	// Curve — реализованный капитал после каждой сделки, начиная со стартового
+	Curve []float64
+
+	Open []OpenPositionView
+}
+
+// Report строит отчёт по портфелю пользователя
+func (s *Service) Report(userID int) (*Report, error) {
+	settings, err := s.Settings(userID)
+	if err != nil {
+		return nil, err
+	}
+	closed, err := s.repo.FindClosedByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.repo.FindOpenByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]OpenPositionView, 0, len(open))
+	for _, position := range open {
+		view := OpenPositionView{Position: position}
+		if price, ok := s.currentPrice(position.Symbol); ok {
+			view.Price = price
+			view.PnLUSD, view.PnLPct = s.pnl(position, price)
+		}
+		views = append(views, view)
+	}
+	return BuildReport(settings, closed, views, time.Now()), nil
+}
+
+// BuildReport считает статистику по закрытым (в порядке закрытия) и открытым позициям
+func BuildReport(settings *models.PaperSettings, closed []*models.PaperPosition, open []OpenPositionView, now time.Time) *Report {
+	report := &Report{
+		Settings:     settings,
+		GeneratedAt:  now,
+		StartBalance: settings.StartBalance,
+		ExitReasons:  make(map[string]int),
+		Curve:        []float64{settings.StartBalance},
+		Open:         open,
+	}
+
+	equity := settings.StartBalance
+	peak := equity
+	grossWin, grossLoss := 0.0, 0.0
+	for _, position := range closed {
+		if position.PnLUSD == nil {
+			continue
+		}
+		pnl := *position.PnLUSD
+		report.Trades++
+		if pnl > 0 {
+			report.Wins++
+			grossWin += pnl
+		} else {
+			report.Losses++
+			grossLoss += -pnl
+		}
+		if position.PnLPct != nil {
+			pct := *position.PnLPct
+			if report.Trades == 1 || pct > report.BestPct {
+				report.BestPct = pct
+			}
+			if report.Trades == 1 || pct < report.WorstPct {
+				report.WorstPct = pct
+			}
+		}
+		if position.ExitReason != nil {
+			report.ExitReasons[*position.ExitReason]++
+		}
+
+		equity += pnl
+		report.Curve = append(report.Curve, equity)
+		peak = math.Max(peak, equity)
+		if peak > 0 {
+			report.MaxDrawdownPct = math.Max(report.MaxDrawdownPct, (peak-equity)/peak*100)
+		}
+	}
+
+	report.RealizedUSD = equity - settings.StartBalance
+	for _, view := range open {
+		report.UnrealizedUSD += view.PnLUSD
+	}
+	report.Equity = equity + report.UnrealizedUSD
+	if settings.StartBalance > 0 {
+		report.ReturnPct = (report.Equity - settings.StartBalance) / settings.StartBalance * 100
+	}
+
+	if report.Trades > 0 {
+		report.WinRate = float64(report.Wins) / float64(report.Trades) * 100
+		report.Expectancy = report.RealizedUSD / float64(report.Trades)
+	}
+	if report.Wins > 0 {
+		report.AvgWinUSD = grossWin / float64(report.Wins)
+	}
+	if report.Losses > 0 {
+		report.AvgLossUSD = -grossLoss / float64(report.Losses)
+	}
+	switch {
+	case grossLoss > 0:
+		report.ProfitFactor = grossWin / grossLoss
+	case grossWin > 0:
+		report.ProfitFactor = math.Inf(1)
+	}
+	return report
+}
@@ -0,0 +1,298 @@
+// internal/core/domain/paper/service.go
+// Paper trading: виртуальный портфель пользователя открывает позиции по полученным сигналам
+// по его правилам (направление, размер, TP/SL в % или ATR, лимит открытых позиций),
+// монитор закрывает их по TP/SL/таймауту, отчёт считает кривую капитала. Всё хранится в Postgres.
+package paper
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	paper_trading_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/paper_trading"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Config настройки paper trading
+type Config struct {
+	// StartBalance — стартовый капитал нового портфеля, $
+	StartBalance float64
+	// FeePct — комиссия за сторону сделки, % от объёма
+	FeePct float64
+	// MaxOpenLimit — верхняя граница лимита открытых позиций, который может задать пользователь
+	MaxOpenLimit int
+	// RefreshInterval — как часто монитор перечитывает открытые позиции из Postgres
+	RefreshInterval time.Duration
+}
+
+// DefaultConfig возвращает настройки по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		StartBalance:    10000,
+		FeePct:          0.055,
+		MaxOpenLimit:    20,
+		RefreshInterval: 15 * time.Second,
+	}
+}
+
+// Правила нового портфеля по умолчанию
+const (
+	defaultSizeUSD        = 100
+	defaultTakeProfitPct  = 2
+	defaultStopLossPct    = 1
+	defaultTimeoutMinutes = 240
+	defaultMaxOpen        = 5
+)
+
+// ErrNoPrice — нет цены входа по символу сигнала
+var ErrNoPrice = errors.New("нет текущей цены для символа")
+
+// PriceStorageGetter — ленивое получение хранилища цен (CandleSystem стартует позже)
+type PriceStorageGetter func() storage.PriceStorageInterface
+
+// CandleSource источник истории свечей (реализуется CandleSystem)
+type CandleSource interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+// CandleSourceGetter — ленивое получение источника свечей
+type CandleSourceGetter func() CandleSource
+
+// Service управляет paper-портфелями пользователей
+type Service struct {
+	repo    paper_trading_repo.PaperTradingRepository
+	prices  PriceStorageGetter
+	candles CandleSourceGetter
+	config  Config
+}
+
+// NewService создает сервис paper trading.
+// prices и candles могут быть nil — тогда вход берется по цене сигнала, а правила в ATR недоступны.
+func NewService(db *sqlx.DB, prices PriceStorageGetter, candles CandleSourceGetter, config Config) *Service {
+	defaults := DefaultConfig()
+	if config.StartBalance <= 0 {
+		config.StartBalance = defaults.StartBalance
+	}
+	if config.FeePct < 0 {
+		config.FeePct = defaults.FeePct
+	}
+	if config.MaxOpenLimit <= 0 {
+		config.MaxOpenLimit = defaults.MaxOpenLimit
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaults.RefreshInterval
+	}
+	return &Service{
+		repo:    paper_trading_repo.NewPaperTradingRepository(db),
+		prices:  prices,
+		candles: candles,
+		config:  config,
+	}
+}
+
+// defaultSettings возвращает правила нового портфеля
+func (s *Service) defaultSettings(userID int) *models.PaperSettings {
+	return &models.PaperSettings{
+		UserID:         userID,
+		Direction:      models.PaperDirectionBoth,
+		SizeValue:      defaultSizeUSD,
+		TakeProfit:     defaultTakeProfitPct,
+		StopLoss:       defaultStopLossPct,
+		TimeoutMinutes: defaultTimeoutMinutes,
+		MaxOpen:        min(defaultMaxOpen, s.config.MaxOpenLimit),
+		StartBalance:   s.config.StartBalance,
+		StartedAt:      time.Now(),
+	}
+}
+
+// Settings возвращает правила портфеля пользователя (по умолчанию, если не заданы)
+func (s *Service) Settings(userID int) (*models.PaperSettings, error) {
+	settings, err := s.repo.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = s.defaultSettings(userID)
+	}
+	return settings, nil
+}
+
+// SaveSettings сохраняет правила портфеля
+func (s *Service) SaveSettings(settings *models.PaperSettings) error {
+	return s.repo.SaveSettings(settings)
+}
+
+// SetEnabled включает или выключает автоматическое открытие позиций по сигналам.
+// Открытые позиции при выключении продолжают сопровождаться до TP/SL/таймаута.
+func (s *Service) SetEnabled(userID int, enabled bool) (*models.PaperSettings, error) {
+	settings, err := s.Settings(userID)
+	if err != nil {
+		return nil, err
+	}
+	settings.Enabled = enabled
+	if err := s.repo.SaveSettings(settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Reset удаляет все позиции пользователя; правила сохраняются
+func (s *Service) Reset(userID int) error {
+	return s.repo.Reset(userID)
+}
+
+// SideFromDirection переводит направление сигнала в сторону позиции ("" — направление неизвестно)
+func SideFromDirection(direction string) string {
+	switch strings.ToLower(direction) {
+	case "growth", "up", "long", "bullish", "bull", "rise":
+		return models.PaperSideLong
+	case "fall", "down", "short", "bearish", "bear", "drop":
+		return models.PaperSideShort
+	}
+	return ""
+}
+
+// FollowSignal открывает виртуальную позицию по доставленному пользователю сигналу,
+// если его paper-портфель включен и сигнал проходит правила
+func (s *Service) FollowSignal(delivery *models.SignalDelivery) {
+	if delivery == nil || delivery.UserID == 0 {
+		return
+	}
+	settings, err := s.repo.GetSettings(delivery.UserID)
+	if err != nil {
+		logger.Warn("⚠️ PaperTrading: не удалось загрузить правила user=%d: %v", delivery.UserID, err)
+		return
+	}
+	if settings == nil || !settings.Enabled {
+		return
+	}
+
+	side := SideFromDirection(delivery.Direction)
+	if side == "" || (settings.Direction != models.PaperDirectionBoth && settings.Direction != side) {
+		return
+	}
+
+	position, err := s.buildPosition(settings, delivery, side, time.Now())
+	if err != nil {
+		logger.Debug("PaperTrading: позиция user=%d %s не открыта: %v", delivery.UserID, delivery.Symbol, err)
+		return
+	}
+	opened, err := s.repo.OpenPosition(position, settings.MaxOpen)
+	if err != nil {
+		logger.Warn("⚠️ PaperTrading: %v", err)
+		return
+	}
+	if opened {
+		logger.Debug("📄 PaperTrading: user=%d открыт %s %s по %.8g на $%.2f (TP %.8g, SL %.8g)",
+			position.UserID, position.Side, position.Symbol, position.EntryPrice, position.SizeUSD,
+			position.TakeProfitPrice, position.StopLossPrice)
+	}
+}
+
+// buildPosition рассчитывает вход, размер и уровни позиции по правилам пользователя
+func (s *Service) buildPosition(settings *models.PaperSettings, delivery *models.SignalDelivery, side string, now time.Time) (*models.PaperPosition, error) {
+	entry, ok := s.currentPrice(delivery.Symbol)
+	if !ok {
+		entry = delivery.Price
+	}
+	if entry <= 0 {
+		return nil, ErrNoPrice
+	}
+
+	sizeUSD := settings.SizeValue
+	if settings.SizePercent {
+		realized, err := s.repo.RealizedPnL(settings.UserID)
+		if err != nil {
+			return nil, err
+		}
+		sizeUSD = (settings.StartBalance + realized) * settings.SizeValue / 100
+	}
+	if sizeUSD <= 0 {
+		return nil, fmt.Errorf("размер позиции $%.2f", sizeUSD)
+	}
+
+	var atr float64
+	if (settings.TakeProfitATR && settings.TakeProfit > 0) || (settings.StopLossATR && settings.StopLoss > 0) {
+		var err error
+		if atr, err = s.atr(delivery.Symbol, delivery.PeriodMinutes); err != nil {
+			return nil, err
+		}
+	}
+	distance := func(value float64, inATR bool) float64 {
+		if inATR {
+			return value * atr
+		}
+		return entry * value / 100
+	}
+
+	position := &models.PaperPosition{
+		UserID:        settings.UserID,
+		SignalID:      delivery.SignalID,
+		Platform:      delivery.Platform,
+		Symbol:        delivery.Symbol,
+		Side:          side,
+		PeriodMinutes: delivery.PeriodMinutes,
+		EntryPrice:    entry,
+		Quantity:      sizeUSD / entry,
+		SizeUSD:       sizeUSD,
+		OpenedAt:      now,
+		Status:        models.PaperStatusOpen,
+	}
+	sign := 1.0
+	if side == models.PaperSideShort {
+		sign = -1
+	}
+	if settings.TakeProfit > 0 {
+		position.TakeProfitPrice = max(0, entry+sign*distance(settings.TakeProfit, settings.TakeProfitATR))
+	}
+	if settings.StopLoss > 0 {
+		position.StopLossPrice = max(0, entry-sign*distance(settings.StopLoss, settings.StopLossATR))
+	}
+	if settings.TimeoutMinutes > 0 {
+		expires := now.Add(time.Duration(settings.TimeoutMinutes) * time.Minute)
+		position.ExpiresAt = &expires
+	}
+	return position, nil
+}
+
+// currentPrice возвращает текущую цену символа из PriceStorage
+func (s *Service) currentPrice(symbol string) (float64, bool) {
+	if s.prices == nil {
+		return 0, false
+	}
+	ps := s.prices()
+	if ps == nil {
+		return 0, false
+	}
+	price, ok := ps.GetCurrentPrice(symbol)
+	return price, ok && price > 0
+}
+
+// closePosition фиксирует выход из позиции; возвращает false, если позиция уже закрыта
+func (s *Service) closePosition(position *models.PaperPosition, price float64, reason string, now time.Time) (bool, error) {
+	pnlUSD, pnlPct := s.pnl(position, price)
+	position.ExitPrice = &price
+	position.ExitReason = &reason
+	position.ClosedAt = &now
+	position.PnLUSD = &pnlUSD
+	position.PnLPct = &pnlPct
+	return s.repo.ClosePosition(position)
+}
+
+// pnl рассчитывает результат позиции по цене выхода с учетом комиссии за обе стороны
+func (s *Service) pnl(position *models.PaperPosition, price float64) (usd, pct float64) {
+	gross := (price - position.EntryPrice) * position.Quantity
+	if !position.IsLong() {
+		gross = -gross
+	}
+	fees := (position.EntryPrice + price) * position.Quantity * s.config.FeePct / 100
+	usd = gross - fees
+	if position.SizeUSD > 0 {
+		pct = usd / position.SizeUSD * 100
+	}
+	return usd, pct
+}
@@ -0,0 +1,161 @@
+// internal/core/domain/rules/ast.go
+package rules
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errNoData — метрика недоступна (нет свечей, стакана, зон и т.п.): правило не срабатывает
+var errNoData = errors.New("нет данных для метрики")
+
+// metricResolver возвращает значение метрики для символа, на котором проверяется правило
+type metricResolver interface {
+	resolve(ref MetricRef) (float64, error)
+}
+
+// MetricRef — ссылка на метрику в выражении: имя и период (пустой для мгновенных метрик)
+type MetricRef struct {
+	Name   string
+	Period string
+}
+
+// String возвращает запись метрики в синтаксисе языка: change(15m), funding()
+func (m MetricRef) String() string {
+	return fmt.Sprintf("%s(%s)", m.Name, m.Period)
+}
+
+// numNode — числовое выражение
+type numNode interface {
+	num(r metricResolver) (float64, error)
+}
+
+// boolNode — логическое выражение
+type boolNode interface {
+	test(r metricResolver) (bool, error)
+}
+
+// numberLit — числовая константа
+type numberLit struct {
+	value float64
+}
+
+func (n numberLit) num(metricResolver) (float64, error) {
+	return n.value, nil
+}
+
+// metricCall — обращение к метрике
+type metricCall struct {
+	ref MetricRef
+}
+
+func (n metricCall) num(r metricResolver) (float64, error) {
+	return r.resolve(n.ref)
+}
+
+// negate — унарный минус
+type negate struct {
+	operand numNode
+}
+
+func (n negate) num(r metricResolver) (float64, error) {
+	v, err := n.operand.num(r)
+	return -v, err
+}
+
+// arith — арифметическая операция
+type arith struct {
+	op          string
+	left, right numNode
+}
+
+func (n arith) num(r metricResolver) (float64, error) {
+	a, err := n.left.num(r)
+	if err != nil {
+		return 0, err
+	}
+	b, err := n.right.num(r)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	default:
+		if b == 0 {
+			return 0, errNoData
+		}
+		return a / b, nil
+	}
+}
+
+// compare — сравнение двух числовых выражений
+type compare struct {
+	op          string
+	left, right numNode
+}
+
+func (n compare) test(r metricResolver) (bool, error) {
+	a, err := n.left.num(r)
+	if err != nil {
+		return false, err
+	}
+	b, err := n.right.num(r)
+	if err != nil {
+		return false, err
+	}
+	switch n.op {
+	case ">":
+		return a > b, nil
+	case ">=":
+		return a >= b, nil
+	case "<":
+		return a < b, nil
+	case "<=":
+		return a <= b, nil
+	case "==":
+		return a == b, nil
+	default:
+		return a != b, nil
+	}
+}
+
+// logicalAnd — конъюнкция; правая часть не вычисляется, если левая ложна
+type logicalAnd struct {
+	left, right boolNode
+}
+
+func (n logicalAnd) test(r metricResolver) (bool, error) {
+	ok, err := n.left.test(r)
+	if err != nil || !ok {
+		return false, err
+	}
+	return n.right.test(r)
+}
+
+// logicalOr — дизъюнкция; правая часть не вычисляется, если левая истинна
+type logicalOr struct {
+	left, right boolNode
+}
+
+func (n logicalOr) test(r metricResolver) (bool, error) {
+	ok, err := n.left.test(r)
+	if err != nil || ok {
+		return ok, err
+	}
+	return n.right.test(r)
+}
+
+// logicalNot — отрицание
+type logicalNot struct {
+	operand boolNode
+}
+
+func (n logicalNot) test(r metricResolver) (bool, error) {
+	ok, err := n.operand.test(r)
+	return !ok, err
+}
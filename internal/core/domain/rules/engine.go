@@ -0,0 +1,292 @@
+// internal/core/domain/rules/engine.go
+package rules
+
+import (
+	"context"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// cooldownRetention — сколько хранить отметки о срабатываниях (дольше любого кулдауна)
+const cooldownRetention = 24 * time.Hour
+
+// compiledRule — активное правило с разобранным выражением
+type compiledRule struct {
+	rule *models.SignalRule
+	expr *Expression
+}
+
+// Engine проверяет пользовательские правила при закрытии свечей.
+// Правило проверяется по символу закрывшейся свечи, если её период совпадает
+// с TriggerPeriod выражения. Сработавшее правило публикует EventRuleTriggered.
+type Engine struct {
+	cfg      EngineConfig
+	service  *Service
+	src      Sources
+	eventBus types.EventBus
+
+	mu       sync.RWMutex
+	byPeriod map[string][]*compiledRule
+
+	firedMu   sync.Mutex
+	lastFired map[string]time.Time // "ruleID:symbol" → время срабатывания
+
+	deltas *deltaCache
+
+	jobs     chan types.CandleClosedData
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewEngine создает движок правил
+func NewEngine(cfg EngineConfig, service *Service, src Sources, eventBus types.EventBus) *Engine {
+	defaults := DefaultEngineConfig()
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaults.RefreshInterval
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaults.Workers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaults.QueueSize
+	}
+
+	return &Engine{
+		cfg:       cfg,
+		service:   service,
+		src:       src,
+		eventBus:  eventBus,
+		byPeriod:  make(map[string][]*compiledRule),
+		lastFired: make(map[string]time.Time),
+		deltas:    newDeltaCache(),
+		jobs:      make(chan types.CandleClosedData, cfg.QueueSize),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start загружает активные правила и подписывается на EventCandleClosed
+func (e *Engine) Start() {
+	e.refresh()
+
+	e.eventBus.Subscribe(types.EventCandleClosed, e)
+
+	for i := 0; i < e.cfg.Workers; i++ {
+		e.wg.Add(1)
+		go e.worker()
+	}
+	e.wg.Add(1)
+	go e.refreshLoop()
+
+	logger.Info("✅ RulesEngine запущен (воркеров: %d, обновление правил: %v)", e.cfg.Workers, e.cfg.RefreshInterval)
+}
+
+// Stop останавливает движок; повторный вызов ничего не делает
+func (e *Engine) Stop() {
+	e.stopOnce.Do(func() {
+		e.eventBus.Unsubscribe(types.EventCandleClosed, e)
+		close(e.stopCh)
+		e.wg.Wait()
+		logger.Info("🛑 RulesEngine остановлен")
+	})
+}
+
+// HandleEvent ставит закрытую свечу в очередь проверки, если для её периода есть правила
+func (e *Engine) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.CandleClosedData)
+	if !ok || !e.hasRulesFor(data.Period) {
+		return nil
+	}
+	select {
+	case e.jobs <- data:
+	default:
+		logger.Debug("⚠️ RulesEngine: очередь переполнена, пропуск %s/%s", data.Symbol, data.Period)
+	}
+	return nil
+}
+
+// GetName возвращает имя подписчика
+func (e *Engine) GetName() string {
+	return "rules_engine"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (e *Engine) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventCandleClosed}
+}
+
+// hasRulesFor — есть ли правила, проверяемые на закрытии свечи периода
+func (e *Engine) hasRulesFor(period string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.byPeriod[period]) > 0
+}
+
+func (e *Engine) refreshLoop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			e.refresh()
+		}
+	}
+}
+
+// refresh перечитывает активные правила и применяет лимит тарифа:
+// после понижения тарифа проверяются только первые N правил пользователя
+func (e *Engine) refresh() {
+	active, err := e.service.repo.FindAllActive()
+	if err != nil {
+		logger.Warn("⚠️ RulesEngine: не удалось загрузить правила: %v", err)
+		return
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+
+	ctx := context.Background()
+	limits := make(map[int]int)
+	counts := make(map[int]int)
+	byPeriod := make(map[string][]*compiledRule)
+	total := 0
+
+	for _, rule := range active {
+		limit, ok := limits[rule.UserID]
+		if !ok {
+			limit = e.service.maxRulesFor(ctx, rule.UserID)
+			limits[rule.UserID] = limit
+		}
+		if limit >= 0 && counts[rule.UserID] >= limit {
+			continue
+		}
+
+		expr, err := Parse(rule.Expression)
+		if err != nil {
+			logger.Warn("⚠️ RulesEngine: правило #%d не разобрано: %v", rule.ID, err)
+			continue
+		}
+		counts[rule.UserID]++
+		byPeriod[expr.TriggerPeriod] = append(byPeriod[expr.TriggerPeriod], &compiledRule{rule: rule, expr: expr})
+		total++
+	}
+
+	e.mu.Lock()
+	e.byPeriod = byPeriod
+	e.mu.Unlock()
+
+	e.pruneFired()
+	e.deltas.prune()
+	logger.Debug("📐 RulesEngine: активных правил %d (пользователей: %d)", total, len(counts))
+}
+
+func (e *Engine) worker() {
+	defer e.wg.Done()
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case data := <-e.jobs:
+			e.evaluate(data.Symbol, data.Period)
+		}
+	}
+}
+
+// evaluate проверяет правила периода по символу; метрики считаются один раз на символ
+func (e *Engine) evaluate(symbol, period string) {
+	e.mu.RLock()
+	rules := e.byPeriod[period]
+	e.mu.RUnlock()
+	if len(rules) == 0 {
+		return
+	}
+
+	metrics := newSymbolMetrics(symbol, e.src, e.deltas)
+	now := time.Now()
+
+	for _, cr := range rules {
+		key := fmt.Sprintf("%d:%s", cr.rule.ID, symbol)
+		if e.inCooldown(key, cr.rule.CooldownMinutes, now) {
+			continue
+		}
+
+		matched, err := cr.expr.evaluate(metrics)
+		if err != nil || !matched {
+			continue
+		}
+		if !e.markFired(key, cr.rule.CooldownMinutes, now) {
+			continue
+		}
+		e.publish(cr, symbol, period, metrics, now)
+	}
+}
+
+func (e *Engine) inCooldown(key string, cooldownMinutes int, now time.Time) bool {
+	e.firedMu.Lock()
+	defer e.firedMu.Unlock()
+	last, ok := e.lastFired[key]
+	return ok && now.Sub(last) < time.Duration(cooldownMinutes)*time.Minute
+}
+
+// markFired атомарно фиксирует срабатывание; false — другой воркер успел раньше
+func (e *Engine) markFired(key string, cooldownMinutes int, now time.Time) bool {
+	e.firedMu.Lock()
+	defer e.firedMu.Unlock()
+	if last, ok := e.lastFired[key]; ok && now.Sub(last) < time.Duration(cooldownMinutes)*time.Minute {
+		return false
+	}
+	e.lastFired[key] = now
+	return true
+}
+
+func (e *Engine) pruneFired() {
+	e.firedMu.Lock()
+	defer e.firedMu.Unlock()
+	for key, at := range e.lastFired {
+		if time.Since(at) > cooldownRetention {
+			delete(e.lastFired, key)
+		}
+	}
+}
+
+// publish публикует срабатывание правила и отмечает его в БД
+func (e *Engine) publish(cr *compiledRule, symbol, period string, metrics *symbolMetrics, now time.Time) {
+	// Дозапрашиваем метрики, пропущенные из-за короткого замыкания AND/OR,
+	// чтобы в уведомлении были все значения выражения
+	for _, ref := range cr.expr.Metrics {
+		_, _ = metrics.resolve(ref)
+	}
+	price, _ := metrics.resolve(MetricRef{Name: "price"})
+
+	data := types.RuleTriggeredData{
+		RuleID:     cr.rule.ID,
+		UserID:     cr.rule.UserID,
+		RuleName:   cr.rule.Name,
+		Expression: cr.rule.Expression,
+		Symbol:     symbol,
+		Period:     period,
+		Price:      price,
+		Metrics:    metrics.snapshot(cr.expr.Metrics),
+		Timestamp:  now,
+	}
+
+	_ = e.eventBus.Publish(types.Event{
+		Type:      types.EventRuleTriggered,
+		Source:    "rules_engine",
+		Data:      data,
+		Timestamp: now,
+	})
+
+	if err := e.service.repo.MarkTriggered(cr.rule.ID, now); err != nil {
+		logger.Warn("⚠️ RulesEngine: %v", err)
+	}
+	logger.Info("📐 RulesEngine: правило #%d (user=%d) сработало на %s/%s", cr.rule.ID, cr.rule.UserID, symbol, period)
+}
@@ -0,0 +1,139 @@
+// internal/core/domain/rules/lexer.go
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind — вид лексемы языка правил
+type tokenKind int
+
+const (
+	tokEOF     tokenKind = iota
+	tokNumber            // 3, 0.5
+	tokPeriod            // 15m, 1h, 4h, 1d
+	tokIdent             // change, rsi, oi_change
+	tokAnd               // AND, &&
+	tokOr                // OR, ||
+	tokNot               // NOT, !
+	tokCompare           // > >= < <= == !=
+	tokPlus
+	tokMinus
+	tokMul
+	tokDiv
+	tokLParen
+	tokRParen
+)
+
+// token — лексема с позицией (1-based) для сообщений об ошибках
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize разбивает выражение на лексемы
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// Число с буквенным суффиксом — период свечи (15m, 1h)
+			if i < len(runes) && unicode.IsLetter(runes[i]) {
+				for i < len(runes) && unicode.IsLetter(runes[i]) {
+					i++
+				}
+				tokens = append(tokens, token{kind: tokPeriod, text: strings.ToLower(string(runes[start:i])), pos: pos})
+				continue
+			}
+			text := string(runes[start:i])
+			if strings.Count(text, ".") > 1 {
+				return nil, fmt.Errorf("позиция %d: некорректное число %q", pos, text)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: text, pos: pos})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			word := string(runes[start:i])
+			switch strings.ToUpper(word) {
+			case "AND":
+				tokens = append(tokens, token{kind: tokAnd, text: word, pos: pos})
+			case "OR":
+				tokens = append(tokens, token{kind: tokOr, text: word, pos: pos})
+			case "NOT":
+				tokens = append(tokens, token{kind: tokNot, text: word, pos: pos})
+			default:
+				tokens = append(tokens, token{kind: tokIdent, text: strings.ToLower(word), pos: pos})
+			}
+
+		default:
+			next := rune(0)
+			if i+1 < len(runes) {
+				next = runes[i+1]
+			}
+			two := string([]rune{r, next})
+
+			switch {
+			case two == "&&":
+				tokens = append(tokens, token{kind: tokAnd, text: two, pos: pos})
+				i += 2
+			case two == "||":
+				tokens = append(tokens, token{kind: tokOr, text: two, pos: pos})
+				i += 2
+			case two == ">=" || two == "<=" || two == "==" || two == "!=":
+				tokens = append(tokens, token{kind: tokCompare, text: two, pos: pos})
+				i += 2
+			case r == '>' || r == '<':
+				tokens = append(tokens, token{kind: tokCompare, text: string(r), pos: pos})
+				i++
+			case r == '=':
+				// Одиночное "=" трактуем как сравнение на равенство
+				tokens = append(tokens, token{kind: tokCompare, text: "==", pos: pos})
+				i++
+			case r == '!':
+				tokens = append(tokens, token{kind: tokNot, text: "!", pos: pos})
+				i++
+			case r == '+':
+				tokens = append(tokens, token{kind: tokPlus, text: "+", pos: pos})
+				i++
+			case r == '-':
+				tokens = append(tokens, token{kind: tokMinus, text: "-", pos: pos})
+				i++
+			case r == '*':
+				tokens = append(tokens, token{kind: tokMul, text: "*", pos: pos})
+				i++
+			case r == '/':
+				tokens = append(tokens, token{kind: tokDiv, text: "/", pos: pos})
+				i++
+			case r == '(':
+				tokens = append(tokens, token{kind: tokLParen, text: "(", pos: pos})
+				i++
+			case r == ')':
+				tokens = append(tokens, token{kind: tokRParen, text: ")", pos: pos})
+				i++
+			default:
+				return nil, fmt.Errorf("позиция %d: неожиданный символ %q", pos, string(r))
+			}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
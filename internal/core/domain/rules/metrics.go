@@ -0,0 +1,397 @@
+// internal/core/domain/rules/metrics.go
+package rules
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/indicators"
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	candleHistoryLimit = 100
+	rsiPeriod          = 14
+	volumeRatioWindow  = 20
+	// deltaCacheTTL — сколько держать ответ API дельты: свечи разных периодов
+	// закрываются одновременно, и в пределах одного тика символ запрашивается один раз
+	deltaCacheTTL = 30 * time.Second
+)
+
+// metricSpec — описание метрики языка правил
+type metricSpec struct {
+	periodic    bool // метрика требует период свечи: change(15m)
+	description string
+}
+
+// metricCatalog — метрики, доступные в выражениях
+var metricCatalog = map[string]metricSpec{
+	"price":         {false, "текущая цена"},
+	"change":        {true, "изменение цены за последнюю закрытую свечу, %"},
+	"volume":        {true, "объём последней закрытой свечи, $"},
+	"volume_ratio":  {true, "объём последней свечи к среднему за 20 свечей"},
+	"oi":            {false, "открытый интерес"},
+	"oi_change":     {true, "изменение открытого интереса за период, %"},
+	"funding":       {false, "ставка финансирования, %"},
+	"rsi":           {true, "RSI(14) по закрытым свечам"},
+	"macd":          {true, "гистограмма MACD(12,26,9)"},
+	"delta":         {true, "дельта объёма (покупки − продажи) за период, $"},
+	"delta_pct":     {true, "дельта объёма за период, %"},
+	"liq":           {false, "объём ликвидаций, $"},
+	"liq_long":      {false, "ликвидации лонгов, $"},
+	"liq_short":     {false, "ликвидации шортов, $"},
+	"sr_support":    {true, "расстояние до ближайшей поддержки, %"},
+	"sr_resistance": {true, "расстояние до ближайшего сопротивления, %"},
+}
+
+// MetricHelp возвращает список метрик для справки: "change(15m) — описание"
+func MetricHelp() []string {
+	names := make([]string, 0, len(metricCatalog))
+	for name := range metricCatalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		spec := metricCatalog[name]
+		call := name + "()"
+		if spec.periodic {
+			call = name + "(15m)"
+		}
+		lines = append(lines, fmt.Sprintf("%s — %s", call, spec.description))
+	}
+	return lines
+}
+
+// deltaCache кэширует дельту объёма по символу и периоду между проверками одного тика
+type deltaCache struct {
+	mu      sync.Mutex
+	entries map[string]deltaEntry
+}
+
+type deltaEntry struct {
+	delta *bybit.VolumeDelta // nil — API не вернул дельту
+	at    time.Time
+}
+
+func newDeltaCache() *deltaCache {
+	return &deltaCache{entries: make(map[string]deltaEntry)}
+}
+
+// get возвращает дельту из кэша или запрашивает её у fetcher
+func (c *deltaCache) get(fetcher VolumeDeltaFetcher, symbol string, period time.Duration) *bybit.VolumeDelta {
+	key := fmt.Sprintf("%s:%s", symbol, period)
+	now := time.Now()
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && now.Sub(entry.at) < deltaCacheTTL {
+		c.mu.Unlock()
+		return entry.delta
+	}
+	c.mu.Unlock()
+
+	delta, err := fetcher.GetVolumeDelta(symbol, period)
+	if err != nil {
+		delta = nil
+	}
+
+	c.mu.Lock()
+	c.entries[key] = deltaEntry{delta: delta, at: now}
+	c.mu.Unlock()
+	return delta
+}
+
+// prune удаляет устаревшие записи
+func (c *deltaCache) prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if time.Since(entry.at) >= deltaCacheTTL {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// symbolMetrics вычисляет метрики одного символа с мемоизацией:
+// одна и та же метрика в нескольких правилах считается один раз
+type symbolMetrics struct {
+	symbol  string
+	src     Sources
+	deltas  *deltaCache
+	values  map[MetricRef]float64
+	errs    map[MetricRef]error
+	candles map[string][]*storage.Candle
+}
+
+func newSymbolMetrics(symbol string, src Sources, deltas *deltaCache) *symbolMetrics {
+	return &symbolMetrics{
+		symbol:  symbol,
+		src:     src,
+		deltas:  deltas,
+		values:  make(map[MetricRef]float64),
+		errs:    make(map[MetricRef]error),
+		candles: make(map[string][]*storage.Candle),
+	}
+}
+
+// resolve реализует metricResolver
+func (m *symbolMetrics) resolve(ref MetricRef) (float64, error) {
+	if v, ok := m.values[ref]; ok {
+		return v, nil
+	}
+	if err, ok := m.errs[ref]; ok {
+		return 0, err
+	}
+
+	v, err := m.compute(ref)
+	if err == nil && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		err = errNoData
+	}
+	if err != nil {
+		m.errs[ref] = err
+		return 0, err
+	}
+	m.values[ref] = v
+	return v, nil
+}
+
+// snapshot возвращает уже вычисленные метрики (для текста уведомления)
+func (m *symbolMetrics) snapshot(refs []MetricRef) map[string]float64 {
+	out := make(map[string]float64, len(refs))
+	for _, ref := range refs {
+		if v, ok := m.values[ref]; ok {
+			out[ref.String()] = v
+		}
+	}
+	return out
+}
+
+func (m *symbolMetrics) compute(ref MetricRef) (float64, error) {
+	switch ref.Name {
+	case "price":
+		return m.price()
+	case "change":
+		last, err := m.lastCandle(ref.Period)
+		if err != nil {
+			return 0, err
+		}
+		if last.Open <= 0 {
+			return 0, errNoData
+		}
+		return (last.Close - last.Open) / last.Open * 100, nil
+	case "volume":
+		last, err := m.lastCandle(ref.Period)
+		if err != nil {
+			return 0, err
+		}
+		return last.VolumeUSD, nil
+	case "volume_ratio":
+		return m.volumeRatio(ref.Period)
+	case "oi":
+		return m.openInterest()
+	case "oi_change":
+		return m.oiChange(ref.Period)
+	case "funding":
+		if m.src.Prices == nil {
+			return 0, errNoData
+		}
+		rate, ok := m.src.Prices.GetFundingRate(m.symbol)
+		if !ok {
+			return 0, errNoData
+		}
+		return rate * 100, nil
+	case "rsi":
+		return m.lastOfSeries(ref.Period, func(closes []float64) []float64 {
+			return indicators.RSISeries(closes, rsiPeriod)
+		})
+	case "macd":
+		return m.lastOfSeries(ref.Period, func(closes []float64) []float64 {
+			return indicators.MACDHistogramSeries(closes, 12, 26, 9)
+		})
+	case "delta", "delta_pct":
+		if m.src.Deltas == nil {
+			return 0, errNoData
+		}
+		d := m.deltas.get(m.src.Deltas, m.symbol, periodPkg.PeriodToDuration(ref.Period))
+		if d == nil {
+			return 0, errNoData
+		}
+		if ref.Name == "delta_pct" {
+			return d.DeltaPercent, nil
+		}
+		return d.Delta, nil
+	case "liq", "liq_long", "liq_short":
+		if m.src.Liquidations == nil {
+			return 0, errNoData
+		}
+		liq, ok := m.src.Liquidations.GetLiquidationMetrics(m.symbol)
+		if !ok || liq == nil {
+			return 0, errNoData
+		}
+		switch ref.Name {
+		case "liq_long":
+			return liq.LongLiqVolume, nil
+		case "liq_short":
+			return liq.ShortLiqVolume, nil
+		}
+		return liq.TotalVolumeUSD, nil
+	case "sr_support", "sr_resistance":
+		return m.zoneDistance(ref)
+	default:
+		return 0, fmt.Errorf("метрика %s не поддерживается", ref.Name)
+	}
+}
+
+func (m *symbolMetrics) price() (float64, error) {
+	if m.src.Prices == nil {
+		return 0, errNoData
+	}
+	price, ok := m.src.Prices.GetCurrentPrice(m.symbol)
+	if !ok || price <= 0 {
+		return 0, errNoData
+	}
+	return price, nil
+}
+
+func (m *symbolMetrics) openInterest() (float64, error) {
+	if m.src.Prices == nil {
+		return 0, errNoData
+	}
+	oi, ok := m.src.Prices.GetOpenInterest(m.symbol)
+	if !ok || oi <= 0 {
+		return 0, errNoData
+	}
+	return oi, nil
+}
+
+// closedCandles возвращает закрытые реальные свечи периода (от старых к новым)
+func (m *symbolMetrics) closedCandles(period string) ([]*storage.Candle, error) {
+	if cached, ok := m.candles[period]; ok {
+		return cached, nil
+	}
+	if m.src.Candles == nil {
+		return nil, errNoData
+	}
+
+	history, err := m.src.Candles.GetHistory(m.symbol, period, candleHistoryLimit)
+	if err != nil {
+		return nil, errNoData
+	}
+	closed := make([]*storage.Candle, 0, len(history))
+	for _, c := range history {
+		if c != nil && c.IsClosedFlag && c.IsRealFlag && c.Close > 0 {
+			closed = append(closed, c)
+		}
+	}
+	m.candles[period] = closed
+	if len(closed) == 0 {
+		return nil, errNoData
+	}
+	return closed, nil
+}
+
+func (m *symbolMetrics) lastCandle(period string) (*storage.Candle, error) {
+	closed, err := m.closedCandles(period)
+	if err != nil {
+		return nil, err
+	}
+	return closed[len(closed)-1], nil
+}
+
+func (m *symbolMetrics) volumeRatio(period string) (float64, error) {
+	closed, err := m.closedCandles(period)
+	if err != nil {
+		return 0, err
+	}
+	if len(closed) < 6 {
+		return 0, errNoData
+	}
+
+	prev := closed[:len(closed)-1]
+	if len(prev) > volumeRatioWindow {
+		prev = prev[len(prev)-volumeRatioWindow:]
+	}
+	sum := 0.0
+	for _, c := range prev {
+		sum += c.VolumeUSD
+	}
+	avg := sum / float64(len(prev))
+	if avg <= 0 {
+		return 0, errNoData
+	}
+	return closed[len(closed)-1].VolumeUSD / avg, nil
+}
+
+func (m *symbolMetrics) lastOfSeries(period string, series func([]float64) []float64) (float64, error) {
+	closed, err := m.closedCandles(period)
+	if err != nil {
+		return 0, err
+	}
+	closes := make([]float64, len(closed))
+	for i, c := range closed {
+		closes[i] = c.Close
+	}
+	values := series(closes)
+	if len(values) == 0 {
+		return 0, errNoData
+	}
+	return values[len(values)-1], nil
+}
+
+// oiChange — изменение OI за период по истории цен
+func (m *symbolMetrics) oiChange(period string) (float64, error) {
+	if m.src.Prices == nil {
+		return 0, errNoData
+	}
+	now := time.Now()
+	history, err := m.src.Prices.GetPriceHistoryRange(m.symbol, now.Add(-periodPkg.PeriodToDuration(period)), now)
+	if err != nil {
+		return 0, errNoData
+	}
+
+	var first, last float64
+	for _, p := range history {
+		oi := p.GetOpenInterest()
+		if oi <= 0 {
+			continue
+		}
+		if first == 0 {
+			first = oi
+		}
+		last = oi
+	}
+	if first <= 0 || last <= 0 {
+		return 0, errNoData
+	}
+	return (last - first) / first * 100, nil
+}
+
+// zoneDistance — расстояние до ближайшей зоны S/R периода, %
+func (m *symbolMetrics) zoneDistance(ref MetricRef) (float64, error) {
+	if m.src.Zones == nil {
+		return 0, errNoData
+	}
+	price, err := m.resolve(MetricRef{Name: "price"})
+	if err != nil {
+		return 0, err
+	}
+	nearest, err := m.src.Zones.GetNearestZones(m.symbol, ref.Period, price)
+	if err != nil {
+		return 0, errNoData
+	}
+	if ref.Name == "sr_support" {
+		if nearest.Support == nil {
+			return 0, errNoData
+		}
+		return nearest.DistToSupportPct, nil
+	}
+	if nearest.Resistance == nil {
+		return 0, errNoData
+	}
+	return nearest.DistToResistPct, nil
+}
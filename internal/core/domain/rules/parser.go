@@ -0,0 +1,325 @@
+// internal/core/domain/rules/parser.go
+package rules
+
+import (
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxExpressionLength — максимальная длина выражения правила
+	MaxExpressionLength = 500
+	// maxMetricCalls — максимум обращений к метрикам в одном правиле
+	maxMetricCalls = 12
+	// defaultTriggerPeriod — период проверки правил без метрик с периодом (funding(), liq())
+	defaultTriggerPeriod = "5m"
+)
+
+// Expression — разобранное и проверенное выражение правила
+type Expression struct {
+	Source string
+	// Metrics — уникальные метрики, на которые ссылается выражение
+	Metrics []MetricRef
+	// TriggerPeriod — правило проверяется при закрытии свечи этого периода
+	// (наименьший период среди метрик выражения)
+	TriggerPeriod string
+
+	root boolNode
+}
+
+// Parse разбирает выражение и проверяет типы, метрики и периоды.
+// Пример: change(15m) > 3 AND oi_change(1h) > 5 AND rsi(1h) < 70 AND delta(15m) > 0
+func Parse(src string) (*Expression, error) {
+	src = strings.TrimSpace(src)
+	if src == "" {
+		return nil, fmt.Errorf("пустое выражение")
+	}
+	if len([]rune(src)) > MaxExpressionLength {
+		return nil, fmt.Errorf("выражение длиннее %d символов", MaxExpressionLength)
+	}
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, seen: make(map[MetricRef]bool)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("позиция %d: лишняя лексема %q", t.pos, t.text)
+	}
+	if len(p.metrics) == 0 {
+		return nil, fmt.Errorf("выражение не использует ни одной метрики")
+	}
+
+	return &Expression{
+		Source:        src,
+		Metrics:       p.metrics,
+		TriggerPeriod: triggerPeriod(p.metrics),
+		root:          root,
+	}, nil
+}
+
+// evaluate вычисляет выражение; errNoData — метрика недоступна
+func (e *Expression) evaluate(r metricResolver) (bool, error) {
+	return e.root.test(r)
+}
+
+// triggerPeriod выбирает наименьший период среди метрик выражения
+func triggerPeriod(metrics []MetricRef) string {
+	best := ""
+	for _, m := range metrics {
+		if m.Period == "" {
+			continue
+		}
+		if best == "" || periodPkg.PeriodToDuration(m.Period) < periodPkg.PeriodToDuration(best) {
+			best = m.Period
+		}
+	}
+	if best == "" {
+		return defaultTriggerPeriod
+	}
+	return best
+}
+
+// parser — рекурсивный спуск:
+//
+//	or      := and { OR and }
+//	and     := not { AND not }
+//	not     := NOT not | cmp
+//	cmp     := sum CMP sum | "(" or ")"
+//	sum     := product { (+|-) product }
+//	product := unary { (*|/) unary }
+//	unary   := - unary | NUMBER | IDENT "(" [PERIOD] ")" | "(" sum ")"
+type parser struct {
+	tokens  []token
+	pos     int
+	metrics []MetricRef
+	seen    map[MetricRef]bool
+	calls   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("позиция %d: ожидается %s, получено %s", t.pos, what, describe(t))
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (boolNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (boolNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (boolNode, error) {
+	if p.peek().kind == tokNot {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return logicalNot{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison различает логическую группу "(a > 1 OR b < 2)"
+// и арифметическую "(a + b) > 1": пробуем первое, при неудаче откатываемся
+func (p *parser) parseComparison() (boolNode, error) {
+	if p.peek().kind == tokLParen {
+		saved := p.pos
+		savedMetrics, savedCalls := len(p.metrics), p.calls
+		p.next()
+		if inner, err := p.parseOr(); err == nil && p.peek().kind == tokRParen {
+			p.next()
+			if p.peek().kind != tokCompare {
+				return inner, nil
+			}
+		}
+		p.pos = saved
+		p.rollbackMetrics(savedMetrics)
+		p.calls = savedCalls
+	}
+
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.expect(tokCompare, "оператор сравнения (>, <, >=, <=, ==, !=)")
+	if err != nil {
+		return nil, err
+	}
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return compare{op: op.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseSum() (numNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().kind; k == tokPlus || k == tokMinus; k = p.peek().kind {
+		op := p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = arith{op: op.text, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseProduct() (numNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().kind; k == tokMul || k == tokDiv; k = p.peek().kind {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = arith{op: op.text, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (numNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokMinus:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{operand: operand}, nil
+
+	case tokNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("позиция %d: некорректное число %q", t.pos, t.text)
+		}
+		return numberLit{value: v}, nil
+
+	case tokIdent:
+		return p.parseMetric(t)
+
+	case tokLParen:
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, "\")\""); err != nil {
+			return nil, err
+		}
+		return inner, nil
+
+	default:
+		return nil, fmt.Errorf("позиция %d: ожидается число или метрика, получено %s", t.pos, describe(t))
+	}
+}
+
+// parseMetric разбирает вызов метрики name(period) и проверяет его по каталогу
+func (p *parser) parseMetric(name token) (numNode, error) {
+	spec, ok := metricCatalog[name.text]
+	if !ok {
+		return nil, fmt.Errorf("позиция %d: неизвестная метрика %q", name.pos, name.text)
+	}
+	if _, err := p.expect(tokLParen, "\"(\" после "+name.text); err != nil {
+		return nil, err
+	}
+
+	ref := MetricRef{Name: name.text}
+	if t := p.peek(); t.kind == tokPeriod {
+		p.next()
+		if !spec.periodic {
+			return nil, fmt.Errorf("позиция %d: метрика %s() не принимает период", t.pos, name.text)
+		}
+		if !periodPkg.IsStandardPeriod(t.text) {
+			return nil, fmt.Errorf("позиция %d: неподдерживаемый период %q (доступны: %s)",
+				t.pos, t.text, strings.Join(periodPkg.AllPeriods, ", "))
+		}
+		ref.Period = t.text
+	} else if spec.periodic {
+		return nil, fmt.Errorf("позиция %d: метрике %s нужен период, например %s(15m)", t.pos, name.text, name.text)
+	}
+
+	if _, err := p.expect(tokRParen, "\")\""); err != nil {
+		return nil, err
+	}
+
+	p.calls++
+	if p.calls > maxMetricCalls {
+		return nil, fmt.Errorf("слишком много метрик в правиле (максимум %d)", maxMetricCalls)
+	}
+	if !p.seen[ref] {
+		p.seen[ref] = true
+		p.metrics = append(p.metrics, ref)
+	}
+	return metricCall{ref: ref}, nil
+}
+
+// rollbackMetrics отменяет метрики, добавленные при неудачной попытке разбора
+func (p *parser) rollbackMetrics(n int) {
+	for _, ref := range p.metrics[n:] {
+		delete(p.seen, ref)
+	}
+	p.metrics = p.metrics[:n]
+}
+
+// describe — человекочитаемое описание лексемы для ошибок
+func describe(t token) string {
+	if t.kind == tokEOF {
+		return "конец выражения"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
@@ -0,0 +1,189 @@
+// internal/core/domain/rules/parser_test.go
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeMetrics — значения метрик для проверки выражений без свечей и стакана
+type fakeMetrics map[MetricRef]float64
+
+func (m fakeMetrics) resolve(ref MetricRef) (float64, error) {
+	v, ok := m[ref]
+	if !ok {
+		return 0, errNoData
+	}
+	return v, nil
+}
+
+func TestParse(t *testing.T) {
+	values := fakeMetrics{
+		{Name: "change", Period: "15m"}:   5,
+		{Name: "change", Period: "1h"}:    3,
+		{Name: "rsi", Period: "1h"}:       80,
+		{Name: "oi_change", Period: "1h"}: 0,
+		{Name: "funding"}:                 0.02,
+	}
+
+	tests := []struct {
+		name        string
+		expr        string
+		want        bool
+		wantMetrics int
+		wantTrigger string
+	}{
+		{
+			name:        "AND связывает сильнее OR",
+			expr:        "change(15m) > 3 OR rsi(1h) < 70 AND oi_change(1h) > 5",
+			want:        true, // a OR (b AND c); при (a OR b) AND c было бы false
+			wantMetrics: 3,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "NOT связывает сильнее AND",
+			expr:        "NOT change(15m) > 3 AND rsi(1h) < 70",
+			want:        false, // (NOT a) AND b; при NOT (a AND b) было бы true
+			wantMetrics: 2,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "NOT над логической группой",
+			expr:        "NOT (change(15m) > 3 AND rsi(1h) < 70)",
+			want:        true,
+			wantMetrics: 2,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "умножение раньше сложения",
+			expr:        "change(15m) + change(1h) * 2 > 12",
+			want:        false, // 5 + 6 = 11; слева направо было бы 16
+			wantMetrics: 2,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "вычитание левоассоциативно",
+			expr:        "change(15m) - 4 - 3 == -2",
+			want:        true,
+			wantMetrics: 1,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "арифметическая группа (a + b) > 1",
+			expr:        "(change(15m) + change(1h)) > 7",
+			want:        true,
+			wantMetrics: 2,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "арифметическая группа внутри произведения",
+			expr:        "(change(15m) + change(1h)) * 2 > 12",
+			want:        true,
+			wantMetrics: 2,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "логическая группа (a > 1 OR b < 2)",
+			expr:        "(change(15m) > 10 OR change(1h) > 2) AND rsi(1h) > 70",
+			want:        true,
+			wantMetrics: 3,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "вложенные группы",
+			expr:        "((change(1h) + 1) > 3 OR (rsi(1h) < 50)) AND funding() > 0.01",
+			want:        true,
+			wantMetrics: 3,
+			wantTrigger: "1h",
+		},
+		{
+			name:        "унарный минус",
+			expr:        "-change(15m) < -4",
+			want:        true,
+			wantMetrics: 1,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "метрика без периода проверяется по умолчанию на 5m",
+			expr:        "funding() > 0.01",
+			want:        true,
+			wantMetrics: 1,
+			wantTrigger: defaultTriggerPeriod,
+		},
+		{
+			name:        "повтор метрики учитывается один раз",
+			expr:        "change(15m) > 1 AND change(15m) < 10",
+			want:        true,
+			wantMetrics: 1,
+			wantTrigger: "15m",
+		},
+		{
+			name:        "операторы && || !",
+			expr:        "!(change(15m) < 1) && (rsi(1h) < 50 || change(1h) >= 3)",
+			want:        true,
+			wantMetrics: 3,
+			wantTrigger: "15m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			got, err := expr.evaluate(values)
+			if err != nil {
+				t.Fatalf("evaluate(%q): %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("evaluate(%q) = %v, ожидалось %v", tt.expr, got, tt.want)
+			}
+			if len(expr.Metrics) != tt.wantMetrics {
+				t.Errorf("метрик = %d (%v), ожидалось %d", len(expr.Metrics), expr.Metrics, tt.wantMetrics)
+			}
+			if expr.TriggerPeriod != tt.wantTrigger {
+				t.Errorf("TriggerPeriod = %q, ожидался %q", expr.TriggerPeriod, tt.wantTrigger)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{name: "пустое выражение", expr: "   ", wantErr: "пустое выражение"},
+		{name: "слишком длинное", expr: strings.Repeat("1", MaxExpressionLength+1), wantErr: "длиннее"},
+		{name: "нет сравнения", expr: "change(15m)", wantErr: "оператор сравнения"},
+		{name: "арифметическая группа без сравнения", expr: "(change(15m) + 1)", wantErr: "оператор сравнения"},
+		{name: "неизвестная метрика", expr: "foo(15m) > 1", wantErr: "неизвестная метрика"},
+		{name: "метрике нужен период", expr: "change() > 1", wantErr: "нужен период"},
+		{name: "метрика без периода", expr: "funding(1h) > 1", wantErr: "не принимает период"},
+		{name: "неподдерживаемый период", expr: "change(7m) > 1", wantErr: "неподдерживаемый период"},
+		{name: "нет метрик", expr: "3 > 1", wantErr: "ни одной метрики"},
+		{name: "лишняя скобка", expr: "change(15m) > 1 )", wantErr: "лишняя лексема"},
+		{name: "незакрытая скобка", expr: "(change(15m) > 1", wantErr: "ожидается"},
+		{name: "оборванный AND", expr: "change(15m) > 1 AND", wantErr: "ожидается число или метрика"},
+		{name: "два сравнения подряд", expr: "change(15m) > 1 > 0", wantErr: "лишняя лексема"},
+		{
+			name:    "слишком много метрик",
+			expr:    strings.TrimSuffix(strings.Repeat("change(15m) + ", maxMetricCalls+1), " + ") + " > 1",
+			wantErr: "слишком много метрик",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %v, ожидалась ошибка", tt.expr, expr.Source)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q): ошибка %q не содержит %q", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
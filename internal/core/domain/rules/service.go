@@ -0,0 +1,151 @@
+// internal/core/domain/rules/service.go
+package rules
+
+import (
+	"context"
+	"crypto-exchange-screener-bot/internal/core/domain/subscription"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	signal_rule_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_rule"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// defaultCooldownMinutes — пауза между срабатываниями правила по одному символу
+	defaultCooldownMinutes = 60
+	maxRuleNameLength      = 100
+)
+
+var (
+	// ErrRuleNotFound — правило не найдено или принадлежит другому пользователю
+	ErrRuleNotFound = errors.New("правило не найдено")
+	// ErrRuleLimit — достигнут лимит правил тарифа
+	ErrRuleLimit = errors.New("достигнут лимит правил вашего тарифа")
+)
+
+// Service управляет пользовательскими правилами сигналов
+type Service struct {
+	repo   signal_rule_repo.SignalRuleRepository
+	limits subscription.LimitChecker
+}
+
+// NewService создает сервис правил.
+// limits может быть nil — тогда количество правил не ограничивается.
+func NewService(db *sqlx.DB, limits subscription.LimitChecker) *Service {
+	return &Service{
+		repo:   signal_rule_repo.NewSignalRuleRepository(db),
+		limits: limits,
+	}
+}
+
+// AddRule проверяет выражение и сохраняет правило, если активных правил
+// меньше лимита тарифа. Проверка лимита и вставка выполняются в одной транзакции.
+func (s *Service) AddRule(ctx context.Context, userID int, name, expression string) (*models.SignalRule, error) {
+	expr, err := Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	maxActive, err := s.checkMaxRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if len([]rune(name)) > maxRuleNameLength {
+		name = string([]rune(name)[:maxRuleNameLength])
+	}
+
+	rule := &models.SignalRule{
+		UserID:          userID,
+		Name:            name,
+		Expression:      expr.Source,
+		IsActive:        true,
+		CooldownMinutes: defaultCooldownMinutes,
+	}
+	created, err := s.repo.CreateWithinLimit(rule, maxActive)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, fmt.Errorf("%w (%d)", ErrRuleLimit, maxActive)
+	}
+
+	logger.Info("📐 Правило #%d создано для user=%d: %s", rule.ID, userID, rule.Expression)
+	return rule, nil
+}
+
+// ListRules возвращает правила пользователя
+func (s *Service) ListRules(userID int) ([]*models.SignalRule, error) {
+	return s.repo.FindByUser(userID)
+}
+
+// DeleteRule удаляет правило пользователя
+func (s *Service) DeleteRule(userID, ruleID int) error {
+	found, err := s.repo.Delete(userID, ruleID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrRuleNotFound
+	}
+	return nil
+}
+
+// SetRuleActive включает или выключает правило пользователя.
+// Включение проверяет лимит тарифа так же, как AddRule.
+func (s *Service) SetRuleActive(ctx context.Context, userID, ruleID int, active bool) error {
+	if !active {
+		found, err := s.repo.SetActive(userID, ruleID, false)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrRuleNotFound
+		}
+		return nil
+	}
+
+	maxActive, err := s.checkMaxRules(ctx, userID)
+	if err != nil {
+		return err
+	}
+	found, activated, err := s.repo.ActivateWithinLimit(userID, ruleID, maxActive)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrRuleNotFound
+	}
+	if !activated {
+		return fmt.Errorf("%w (%d)", ErrRuleLimit, maxActive)
+	}
+	return nil
+}
+
+// checkMaxRules возвращает лимит активных правил тарифа (-1 — без ограничений)
+func (s *Service) checkMaxRules(ctx context.Context, userID int) (int, error) {
+	if s.limits == nil {
+		return -1, nil
+	}
+	// При нулевом использовании remaining равен лимиту тарифа
+	_, maxActive, err := s.limits.CheckUserLimit(ctx, userID, subscription.LimitRules, 0)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось проверить лимит правил: %w", err)
+	}
+	return maxActive, nil
+}
+
+// maxRulesFor возвращает лимит правил пользователя (-1 — без ограничений)
+func (s *Service) maxRulesFor(ctx context.Context, userID int) int {
+	maxActive, err := s.checkMaxRules(ctx, userID)
+	if err != nil {
+		// Не блокируем уже созданные правила из-за временной ошибки БД
+		return -1
+	}
+	return maxActive
+}
@@ -0,0 +1,55 @@
+// internal/core/domain/rules/types.go
+package rules
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"time"
+)
+
+// CandleHistoryProvider — история свечей (CandleSystem)
+type CandleHistoryProvider interface {
+	GetHistory(symbol, period string, limit int) ([]*storage.Candle, error)
+}
+
+// VolumeDeltaFetcher — реальная дельта объёма из API (BybitPriceFetcher)
+type VolumeDeltaFetcher interface {
+	GetVolumeDelta(symbol string, period time.Duration) (*bybit.VolumeDelta, error)
+}
+
+// LiquidationSource — метрики ликвидаций (BybitPriceFetcher)
+type LiquidationSource interface {
+	GetLiquidationMetrics(symbol string) (*bybit.LiquidationMetrics, bool)
+}
+
+// ZoneProvider — ближайшие зоны S/R (SRZoneStorage)
+type ZoneProvider interface {
+	GetNearestZones(symbol, period string, currentPrice float64) (sr_zones.NearestZones, error)
+}
+
+// Sources — источники метрик для вычисления правил.
+// Любой источник может быть nil: метрики из него считаются недоступными.
+type Sources struct {
+	Candles      CandleHistoryProvider
+	Prices       storage.PriceStorageInterface
+	Deltas       VolumeDeltaFetcher
+	Liquidations LiquidationSource
+	Zones        ZoneProvider
+}
+
+// EngineConfig — параметры движка правил
+type EngineConfig struct {
+	RefreshInterval time.Duration // период перечитывания активных правил из БД
+	Workers         int           // воркеры проверки закрытых свечей
+	QueueSize       int           // очередь закрытых свечей; при переполнении события пропускаются
+}
+
+// DefaultEngineConfig возвращает параметры по умолчанию
+func DefaultEngineConfig() EngineConfig {
+	return EngineConfig{
+		RefreshInterval: time.Minute,
+		Workers:         4,
+		QueueSize:       2000,
+	}
+}
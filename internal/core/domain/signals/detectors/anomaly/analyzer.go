@@ -0,0 +1,321 @@
+// internal/core/domain/signals/detectors/anomaly/analyzer.go
+package anomaly
+
+import (
+	anom "crypto-exchange-screener-bot/internal/core/domain/analysis/anomaly"
+	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	returns_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/returns_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPeriods    = "5m,15m,1h"
+	defaultWindow     = 200
+	defaultMinSamples = 50
+	defaultMinZ       = 2.0
+
+	// recentCandles — сколько свечей читать при обычном обновлении окна
+	recentCandles = 5
+	// windowTTLPeriods — окно живет столько периодов без обновлений
+	windowTTLPeriods = 3
+)
+
+// Dependencies зависимости для AnomalyAnalyzer
+type Dependencies struct {
+	CandleSystem   *candle.CandleSystem
+	ReturnsStorage *returns_storage.ReturnsStorage
+	Publisher      *analyzers.Publisher // опционально: доставка EventReturnAnomaly через фильтры движка
+}
+
+// AnomalyAnalyzer ведет распределение доходностей закрытых свечей по каждому
+// символу и периоду и сигнализирует, когда новая свеча выходит за min_z сигм.
+// Так 3% на BTC и 3% на мем-коине оцениваются относительно их собственной истории.
+type AnomalyAnalyzer struct {
+	config common.AnalyzerConfig
+	deps   Dependencies
+
+	statsMu sync.RWMutex
+	stats   common.AnalyzerStats
+
+	mu sync.Mutex
+	// lastClosed — время начала последней обработанной закрытой свечи по symbol:period
+	lastClosed map[string]time.Time
+}
+
+// NewAnomalyAnalyzer создает анализатор аномальных доходностей
+func NewAnomalyAnalyzer(config common.AnalyzerConfig, deps Dependencies) *AnomalyAnalyzer {
+	minZ := common.SafeGetFloat(config.CustomSettings, "min_z", defaultMinZ)
+	// Пользовательская чувствительность не может быть ниже порога анализатора
+	anom.SetAnalyzerMinZ(minZ)
+
+	logger.Info("✅ [AnomalyAnalyzer] Создан анализатор z-score доходностей (периоды: %s, окно: %d, min_z: %.1f)",
+		strings.Join(common.Periods(config, defaultPeriods), ","),
+		common.SafeGetInt(config.CustomSettings, "window", defaultWindow),
+		minZ)
+
+	return &AnomalyAnalyzer{
+		config:     config,
+		deps:       deps,
+		lastClosed: make(map[string]time.Time),
+	}
+}
+
+// Analyze обновляет окна доходностей по новым закрытым свечам и ищет аномалии
+func (a *AnomalyAnalyzer) Analyze(data []storage.PriceDataInterface, config common.AnalyzerConfig) ([]analysis.Signal, error) {
+	startTime := time.Now()
+	defer a.updateStats(startTime)
+
+	a.config = config
+	if a.deps.CandleSystem == nil || a.deps.ReturnsStorage == nil {
+		return nil, nil
+	}
+
+	var signals []analysis.Signal
+	for _, point := range data {
+		for _, period := range common.Periods(config, defaultPeriods) {
+			signal, err := a.analyzeSymbolPeriod(point.GetSymbol(), period)
+			if err != nil {
+				logger.Debug("⚠️ [AnomalyAnalyzer] %s/%s: %v", point.GetSymbol(), period, err)
+				continue
+			}
+			if signal != nil {
+				signals = append(signals, *signal)
+			}
+		}
+	}
+	return signals, nil
+}
+
+// analyzeSymbolPeriod дописывает в окно доходности свечей, закрывшихся после
+// последнего обновления, и оценивает по окну самую свежую из них
+func (a *AnomalyAnalyzer) analyzeSymbolPeriod(symbol, period string) (*analysis.Signal, error) {
+	closed, err := a.closedCandles(symbol, period, recentCandles)
+	if err != nil || len(closed) < 2 {
+		return nil, err
+	}
+
+	lastStart := closed[len(closed)-1].StartTime
+	key := symbol + ":" + period
+
+	a.mu.Lock()
+	if prev, ok := a.lastClosed[key]; ok && !lastStart.After(prev) {
+		a.mu.Unlock()
+		return nil, nil
+	}
+	a.lastClosed[key] = lastStart
+	a.mu.Unlock()
+
+	window := common.SafeGetInt(a.config.CustomSettings, "window", defaultWindow)
+	history, storedAt, err := a.deps.ReturnsStorage.Load(symbol, period, window)
+	if err != nil {
+		return nil, err
+	}
+	if !lastStart.After(storedAt) {
+		// Окно уже обновлено по этой свече (например, до перезапуска)
+		return nil, nil
+	}
+
+	// Окно пустое или пропущено больше свечей, чем прочитали, — засеваем из истории
+	if storedAt.IsZero() || !closed[0].StartTime.Before(storedAt) {
+		if closed, err = a.closedCandles(symbol, period, window+1); err != nil {
+			return nil, err
+		}
+		if storedAt.IsZero() {
+			history = nil
+		}
+	}
+
+	pending := candleReturns(closed, storedAt)
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	ttl := periodPkg.PeriodToDuration(period) * time.Duration(window*windowTTLPeriods)
+	if err := a.deps.ReturnsStorage.Append(symbol, period, pending, lastStart, window, ttl); err != nil {
+		return nil, err
+	}
+
+	// Последнюю доходность сравниваем с окном без нее самой
+	latest := pending[len(pending)-1]
+	sample := append(history, pending[:len(pending)-1]...)
+	if len(sample) > window {
+		sample = sample[len(sample)-window:]
+	}
+	if len(sample) < common.SafeGetInt(a.config.CustomSettings, "min_samples", defaultMinSamples) {
+		return nil, nil
+	}
+
+	dist := anom.NewDistribution(sample)
+	z, ok := dist.ZScore(latest)
+	if !ok || math.Abs(z) < common.SafeGetFloat(a.config.CustomSettings, "min_z", defaultMinZ) {
+		return nil, nil
+	}
+
+	last := closed[len(closed)-1]
+	signal := a.createSignal(symbol, period, last, latest, z, dist)
+	if signal.Confidence < a.config.MinConfidence {
+		return nil, nil
+	}
+	if !a.publishAnomaly(signal, period, last, latest, z, dist) {
+		return nil, nil
+	}
+	return &signal, nil
+}
+
+// closedCandles возвращает закрытые реальные свечи в хронологическом порядке
+func (a *AnomalyAnalyzer) closedCandles(symbol, period string, limit int) ([]*storage.Candle, error) {
+	history, err := a.deps.CandleSystem.GetHistory(symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]*storage.Candle, 0, len(history))
+	for _, c := range history {
+		if c != nil && c.IsClosedFlag && c.IsRealFlag && c.Close > 0 {
+			closed = append(closed, c)
+		}
+	}
+	return closed, nil
+}
+
+// candleReturns считает доходности (close к предыдущему close, %) свечей, начавшихся после since
+func candleReturns(closed []*storage.Candle, since time.Time) []float64 {
+	var returns []float64
+	for i := 1; i < len(closed); i++ {
+		if !closed[i].StartTime.After(since) {
+			continue
+		}
+		prev := closed[i-1].Close
+		returns = append(returns, (closed[i].Close-prev)/prev*100)
+	}
+	return returns
+}
+
+// createSignal строит сигнал аномальной доходности
+func (a *AnomalyAnalyzer) createSignal(symbol, period string, last *storage.Candle, change, z float64, dist anom.Distribution) analysis.Signal {
+	direction := "fall"
+	if change > 0 {
+		direction = "growth"
+	}
+
+	periodMinutes, err := periodPkg.StringToMinutes(period)
+	if err != nil {
+		periodMinutes = periodPkg.DefaultMinutes
+	}
+
+	// 2σ — 50, каждая следующая сигма добавляет 15
+	confidence := math.Min(50+(math.Abs(z)-2)*15, 100)
+
+	return analysis.Signal{
+		ID:            uuid.New().String(),
+		Symbol:        symbol,
+		Type:          "return_anomaly",
+		Direction:     direction,
+		ChangePercent: change,
+		Period:        periodMinutes,
+		Confidence:    confidence,
+		DataPoints:    dist.Samples,
+		StartPrice:    last.Close / (1 + change/100),
+		EndPrice:      last.Close,
+		Timestamp:     time.Now(),
+		Metadata: analysis.Metadata{
+			Strategy: "anomaly_analyzer",
+			Tags:     []string{"return_anomaly", fmt.Sprintf("z:%.1f", z), period},
+			Custom: map[string]interface{}{
+				"period_string": period,
+				"z_score":       z,
+				"median":        dist.Median,
+				"mad":           dist.MAD,
+				"sigma":         dist.Scale,
+				"samples":       dist.Samples,
+				"candle_time":   last.StartTime,
+			},
+		},
+	}
+}
+
+// publishAnomaly отправляет событие для доставки пользователям с подходящей чувствительностью;
+// false — сигнал отброшен фильтрами
+func (a *AnomalyAnalyzer) publishAnomaly(signal analysis.Signal, period string, last *storage.Candle, change, z float64, dist anom.Distribution) bool {
+	symbol := signal.Symbol
+	direction := "fall"
+	if change > 0 {
+		direction = "growth"
+	}
+
+	event := types.Event{
+		Type:   types.EventReturnAnomaly,
+		Source: "anomaly_analyzer",
+		Data: types.ReturnAnomalyData{
+			Symbol:     symbol,
+			Period:     period,
+			Direction:  direction,
+			ChangePct:  change,
+			ZScore:     z,
+			Median:     dist.Median,
+			Sigma:      dist.Scale,
+			Samples:    dist.Samples,
+			Price:      last.Close,
+			CandleTime: last.StartTime,
+			Timestamp:  time.Now(),
+		},
+		Timestamp: time.Now(),
+	}
+
+	if !a.deps.Publisher.Publish(signal, event) {
+		return false
+	}
+	logger.Debug("📐 [AnomalyAnalyzer] %s/%s: %+.2f%% (z=%.1f, σ=%.2f%%)", symbol, period, change, z, dist.Scale)
+	return true
+}
+
+func (a *AnomalyAnalyzer) updateStats(startTime time.Time) {
+	a.statsMu.Lock()
+	defer a.statsMu.Unlock()
+
+	a.stats.TotalCalls++
+	a.stats.SuccessCount++
+	a.stats.TotalTime += time.Since(startTime)
+	a.stats.AverageTime = a.stats.TotalTime / time.Duration(a.stats.TotalCalls)
+	a.stats.LastCallTime = time.Now()
+}
+
+// GetConfig возвращает конфигурацию
+func (a *AnomalyAnalyzer) GetConfig() common.AnalyzerConfig {
+	return a.config
+}
+
+// GetStats возвращает статистику
+func (a *AnomalyAnalyzer) GetStats() common.AnalyzerStats {
+	a.statsMu.RLock()
+	defer a.statsMu.RUnlock()
+	return a.stats
+}
+
+// Name возвращает имя анализатора
+func (a *AnomalyAnalyzer) Name() string {
+	return "anomaly"
+}
+
+// Version возвращает версию анализатора
+func (a *AnomalyAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// Supports проверяет, поддерживается ли символ
+func (a *AnomalyAnalyzer) Supports(symbol string) bool {
+	return true
+}
@@ -0,0 +1,38 @@
+// internal/core/domain/signals/detectors/anomaly/registry.go
+package anomaly
+
+import (
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+)
+
+// Schema - настройки AnomalyAnalyzer (ANOMALY_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "periods", Type: common.SettingString, Default: defaultPeriods,
+		Description: "Периоды свечей через запятую"},
+	{Key: "window", Type: common.SettingInt, Default: defaultWindow, Range: &common.Range{Min: 20, Max: 1000},
+		Description: "Размер окна доходностей для оценки распределения"},
+	{Key: "min_samples", Type: common.SettingInt, Default: defaultMinSamples, Range: &common.Range{Min: 10, Max: 1000},
+		Description: "Минимум доходностей в окне, прежде чем выдавать сигналы"},
+	{Key: "min_z", Type: common.SettingFloat, Default: defaultMinZ, Range: &common.Range{Min: 1, Max: 10},
+		Description: "Минимальный |z-score| для сигнала (пользовательские пороги не ниже)"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:          "anomaly",
+		Description:   "Аномальные доходности по z-score относительно истории символа",
+		Requires:      []string{analyzers.DependencyCandleSystem, analyzers.DependencyReturns},
+		Weight:        0.5,
+		MinConfidence: 50.0,
+		MinDataPoints: 1,
+		Settings:      Schema,
+		New: func(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+			return NewAnomalyAnalyzer(config, Dependencies{
+				CandleSystem:   ctx.CandleSystem,
+				ReturnsStorage: ctx.ReturnsStorage,
+				Publisher:      ctx.Publisher,
+			}), nil
+		},
+	})
+}
@@ -0,0 +1,46 @@
+// internal/core/domain/signals/detectors/common/closed_candles.go
+package common
+
+import (
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"sync"
+	"time"
+)
+
+// ClosedCandles запоминает время начала последней обработанной закрытой свечи
+// по symbol:period, чтобы анализаторы закрытых свечей не читали историю на каждом тике
+type ClosedCandles struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// NewClosedCandles создает журнал обработанных свечей
+func NewClosedCandles() *ClosedCandles {
+	return &ClosedCandles{last: make(map[string]time.Time)}
+}
+
+// Due сообщает, могла ли после последней обработанной свечи закрыться новая:
+// следующая свеча закрывается не раньше чем через два периода от начала обработанной
+func (c *ClosedCandles) Due(symbol, period string, now time.Time) bool {
+	c.mu.Lock()
+	last, ok := c.last[symbol+":"+period]
+	c.mu.Unlock()
+	if !ok {
+		return true
+	}
+	duration := periodPkg.PeriodToDuration(period)
+	return duration <= 0 || !now.Before(last.Add(2*duration))
+}
+
+// Mark запоминает обработанную свечу; false — она не новее уже обработанной
+func (c *ClosedCandles) Mark(symbol, period string, start time.Time) bool {
+	key := symbol + ":" + period
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev, ok := c.last[key]; ok && !start.After(prev) {
+		return false
+	}
+	c.last[key] = start
+	return true
+}
@@ -0,0 +1,226 @@
+// internal/core/domain/signals/detectors/common/settings.go
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SettingType - тип значения настройки анализатора
+type SettingType string
+
+const (
+	SettingInt    SettingType = "int"
+	SettingFloat  SettingType = "float"
+	SettingBool   SettingType = "bool"
+	SettingString SettingType = "string"
+)
+
+// Range - допустимый диапазон числовой настройки (включительно)
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// SettingSpec - описание одной настройки из CustomSettings
+type SettingSpec struct {
+	Key         string      `json:"key"`
+	Type        SettingType `json:"type"`
+	Default     interface{} `json:"default"`
+	Range       *Range      `json:"range,omitempty"`   // только для int/float
+	Options     []string    `json:"options,omitempty"` // только для string
+	Description string      `json:"description"`
+}
+
+// SettingsSchema - схема настроек анализатора
+type SettingsSchema []SettingSpec
+
+// Spec возвращает описание настройки по ключу
+func (s SettingsSchema) Spec(key string) (SettingSpec, bool) {
+	for _, spec := range s {
+		if spec.Key == key {
+			return spec, true
+		}
+	}
+	return SettingSpec{}, false
+}
+
+// Keys возвращает ключи настроек в порядке объявления
+func (s SettingsSchema) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for _, spec := range s {
+		keys = append(keys, spec.Key)
+	}
+	return keys
+}
+
+// Defaults возвращает настройки со значениями по умолчанию
+func (s SettingsSchema) Defaults() map[string]interface{} {
+	result := make(map[string]interface{}, len(s))
+	for _, spec := range s {
+		result[spec.Key] = spec.Default
+	}
+	return result
+}
+
+// Resolve приводит пользовательские настройки к типам схемы, подставляет
+// значения по умолчанию и проверяет диапазоны. Ключи, которых нет в схеме,
+// отбрасываются и возвращаются в unknown. Все ошибки собираются в одну.
+func (s SettingsSchema) Resolve(raw map[string]interface{}) (resolved map[string]interface{}, unknown []string, err error) {
+	resolved = make(map[string]interface{}, len(s))
+	var errs []error
+
+	for _, spec := range s {
+		value, ok := raw[spec.Key]
+		if !ok || value == nil {
+			resolved[spec.Key] = spec.Default
+			continue
+		}
+		converted, convErr := spec.convert(value)
+		if convErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", spec.Key, convErr))
+			continue
+		}
+		if checkErr := spec.check(converted); checkErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", spec.Key, checkErr))
+			continue
+		}
+		resolved[spec.Key] = converted
+	}
+
+	for key := range raw {
+		if _, ok := s.Spec(key); !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	return resolved, unknown, errors.Join(errs...)
+}
+
+// convert приводит значение к типу настройки
+func (spec SettingSpec) convert(value interface{}) (interface{}, error) {
+	switch spec.Type {
+	case SettingInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("ожидается целое число, получено %v", v)
+			}
+			return int(v), nil
+		case string:
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("ожидается целое число, получено %q", v)
+			}
+			return n, nil
+		}
+	case SettingFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("ожидается число, получено %q", v)
+			}
+			return f, nil
+		}
+	case SettingBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("ожидается true/false, получено %q", v)
+			}
+			return b, nil
+		}
+	case SettingString:
+		if v, ok := value.(string); ok {
+			return strings.TrimSpace(v), nil
+		}
+	default:
+		return nil, fmt.Errorf("неизвестный тип настройки %s", spec.Type)
+	}
+	return nil, fmt.Errorf("ожидается %s, получено %T", spec.Type, value)
+}
+
+// check проверяет диапазон и список допустимых значений
+func (spec SettingSpec) check(value interface{}) error {
+	if spec.Range != nil {
+		var n float64
+		switch v := value.(type) {
+		case int:
+			n = float64(v)
+		case float64:
+			n = v
+		}
+		if n < spec.Range.Min || n > spec.Range.Max {
+			return fmt.Errorf("значение %v вне диапазона [%v, %v]", value, spec.Range.Min, spec.Range.Max)
+		}
+	}
+	if len(spec.Options) > 0 {
+		v, _ := value.(string)
+		for _, option := range spec.Options {
+			if v == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("значение %q не из списка: %s", v, strings.Join(spec.Options, ", "))
+	}
+	return nil
+}
+
+// Describe возвращает описание настройки с типом, диапазоном и значением по умолчанию
+func (spec SettingSpec) Describe() string {
+	var sb strings.Builder
+	sb.WriteString(spec.Description)
+	sb.WriteString(fmt.Sprintf(" (%s", spec.Type))
+	if spec.Range != nil {
+		sb.WriteString(fmt.Sprintf(", %v…%v", spec.Range.Min, spec.Range.Max))
+	}
+	if len(spec.Options) > 0 {
+		sb.WriteString(", " + strings.Join(spec.Options, "/"))
+	}
+	sb.WriteString(fmt.Sprintf(", по умолчанию: %v)", spec.Default))
+	return sb.String()
+}
+
+// AnalyzerState - состояние анализатора после запуска движка
+type AnalyzerState string
+
+const (
+	AnalyzerActive   AnalyzerState = "active"   // создан и зарегистрирован в движке
+	AnalyzerDisabled AnalyzerState = "disabled" // выключен в конфигурации
+	AnalyzerSkipped  AnalyzerState = "skipped"  // нет обязательной зависимости
+	AnalyzerInvalid  AnalyzerState = "invalid"  // ошибка в настройках
+	AnalyzerFailed   AnalyzerState = "failed"   // ошибка создания или регистрации
+)
+
+// AnalyzerInfo - сведения об анализаторе для статуса и админ-команд
+type AnalyzerInfo struct {
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	State         AnalyzerState          `json:"state"`
+	Reason        string                 `json:"reason,omitempty"`
+	Requires      []string               `json:"requires,omitempty"`
+	After         []string               `json:"after,omitempty"`
+	Weight        float64                `json:"weight"`
+	MinConfidence float64                `json:"min_confidence"`
+	Schema        SettingsSchema         `json:"schema"`
+	Settings      map[string]interface{} `json:"settings"` // действующие значения
+}
@@ -0,0 +1,70 @@
+// internal/core/domain/signals/detectors/common/utils.go
+package common
+
+import (
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"strings"
+)
+
+// SafeGetInt безопасно получает int из CustomSettings
+func SafeGetInt(settings map[string]interface{}, key string, defaultValue int) int {
+	if settings == nil {
+		return defaultValue
+	}
+	switch v := settings[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return defaultValue
+}
+
+// SafeGetFloat безопасно получает float64 из CustomSettings
+func SafeGetFloat(settings map[string]interface{}, key string, defaultValue float64) float64 {
+	if settings == nil {
+		return defaultValue
+	}
+	switch v := settings[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return defaultValue
+}
+
+// SafeGetBool безопасно получает bool из CustomSettings
+func SafeGetBool(settings map[string]interface{}, key string, defaultValue bool) bool {
+	if settings == nil {
+		return defaultValue
+	}
+	if v, ok := settings[key].(bool); ok {
+		return v
+	}
+	return defaultValue
+}
+
+// Periods возвращает валидные периоды из настройки "periods" ("15m,1h,4h");
+// defaultPeriods — если настройка не задана
+func Periods(config AnalyzerConfig, defaultPeriods string) []string {
+	raw := defaultPeriods
+	if config.CustomSettings != nil {
+		if v, ok := config.CustomSettings["periods"].(string); ok && v != "" {
+			raw = v
+		}
+	}
+
+	var periods []string
+	for _, p := range strings.Split(raw, ",") {
+		p = strings.TrimSpace(p)
+		if periodPkg.IsValidPeriod(p) {
+			periods = append(periods, p)
+		}
+	}
+	return periods
+}
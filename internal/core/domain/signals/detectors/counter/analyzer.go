@@ -2,10 +2,19 @@
 package counter
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/confluence"
+	div "crypto-exchange-screener-bot/internal/core/domain/analysis/divergence"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/episodes"
+	liq "crypto-exchange-screener-bot/internal/core/domain/analysis/liquidity"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/market_context"
+	pat "crypto-exchange-screener-bot/internal/core/domain/analysis/patterns"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
 	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
 	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
 	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
 	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/calculator"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/confirmation"
 	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
 	sr_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/sr_storage"
 	"crypto-exchange-screener-bot/internal/types"
@@ -14,15 +23,27 @@ import (
 	"time"
 )
 
+// episodeCleanupInterval — как часто удалять эпизоды замолчавших символов
+const episodeCleanupInterval = 10 * time.Minute
+
 // Dependencies зависимости для CounterAnalyzer
 type Dependencies struct {
 	Storage             storage.PriceStorageInterface
 	EventBus            types.EventBus
+	Publisher           *analyzers.Publisher // доставка через фильтры движка; nil — из EventBus без фильтров
 	CandleSystem        *candle.CandleSystem
 	MarketFetcher       interface{}
 	VolumeCalculator    *calculator.VolumeDeltaCalculator
 	TechnicalCalculator *calculator.TechnicalCalculator
-	SRZoneStorage       *sr_storage.SRZoneStorage // опционально: зоны S/R
+	SRZoneStorage       *sr_storage.SRZoneStorage  // опционально: зоны S/R
+	DivergenceStore     *div.Store                 // опционально: дивергенции RSI/MACD
+	PatternStore        *pat.Store                 // опционально: свечные паттерны
+	Confluence          *confluence.Evaluator      // опционально: согласованность со старшими ТФ
+	MarketContext       *market_context.Calculator // опционально: бета относительно BTC/ETH
+	Liquidity           *liq.Provider              // опционально: глубина и дисбаланс стакана
+	Episodes            *episodes.Tracker          // опционально: стадии памп/дамп
+	Regime              regime.Source              // опционально: режим рынка для порогов и метаданных
+	Now                 func() time.Time           // опционально: часы (бэктест — время рынка), nil — time.Now
 }
 
 // CounterAnalyzer - анализатор счетчика сигналов
@@ -30,6 +51,12 @@ type CounterAnalyzer struct {
 	config common.AnalyzerConfig
 	deps   Dependencies
 
+	// Множители порогов роста/падения по режимам рынка (regime_threshold_factors)
+	regimeFactors regime.Factors
+
+	// Подтверждения подряд перед публикацией сигнала (required_confirmations)
+	confirmations *confirmation.ConfirmationManager
+
 	// Статистика отправленных сигналов
 	stats              common.AnalyzerStats
 	sentStatsMu        sync.RWMutex
@@ -37,6 +64,10 @@ type CounterAnalyzer struct {
 	sentStatsStartTime time.Time
 	lastLogTime        time.Time
 
+	// Последняя очистка эпизодов памп/дамп (episodeCleanupInterval)
+	episodeCleanupMu sync.Mutex
+	lastEpisodeClean time.Time
+
 	// Статистика вызовов Analyze()
 	analyzeCallsCount  int
 	analyzeTotalPoints int
@@ -115,6 +146,15 @@ func NewCounterAnalyzer(
 		deps.VolumeCalculator = calculator.NewVolumeDeltaCalculator(deps.MarketFetcher, deps.Storage)
 	}
 
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+
+	// Анализатор, собранный фабрикой без движка, публикует в EventBus без фильтров
+	if deps.Publisher == nil && deps.EventBus != nil {
+		deps.Publisher = analyzers.NewPublisher(deps.EventBus)
+	}
+
 	// Проверяем и создаем TechnicalCalculator если не передан
 	if deps.TechnicalCalculator == nil {
 		logger.Info("🔧 [CounterAnalyzer] Создаем TechnicalCalculator")
@@ -152,6 +192,14 @@ func NewCounterAnalyzer(
 		},
 	}
 
+	factors, err := regime.ParseFactors(SafeGetString(config.CustomSettings, "regime_threshold_factors", ""))
+	if err != nil {
+		logger.Warn("⚠️ [CounterAnalyzer] regime_threshold_factors игнорируется: %v", err)
+	}
+	analyzer.regimeFactors = factors
+	analyzer.confirmations = confirmation.NewConfirmationManagerWithThreshold(
+		SafeGetInt(config.CustomSettings, "required_confirmations", confirmation.DefaultSignalThreshold))
+
 	logger.Info("✅ [CounterAnalyzer] Создан анализатор счетчика с разделенной статистикой")
 	return analyzer
 }
@@ -217,10 +265,20 @@ func (a *CounterAnalyzer) Analyze(data []storage.PriceDataInterface, config comm
 
 			if signal != nil {
 				candleAnalyzeSuccess++
-				signals = append(signals, *signal)
 
-				// Публикуем сигнал в EventBus
-				a.PublishRawCounterSignal(*signal, period)
+				// Без доставки (бэктест) возвращаем сырые сигналы — подтверждения раннер считает сам
+				if a.deps.Publisher == nil {
+					signals = append(signals, *signal)
+					continue
+				}
+
+				// Публикуем сигнал после required_confirmations подтверждений подряд и фильтров движка;
+				// движок (и журнал) получает только опубликованные сигналы
+				confirmed, _ := a.confirmations.AddConfirmationAt(signal.Symbol, period, signal.Direction, a.deps.Now())
+				if !confirmed || !a.PublishRawCounterSignal(*signal, period) {
+					continue
+				}
+				signals = append(signals, *signal)
 
 				// Увеличиваем локальный счетчик
 				localSentCount++
@@ -253,9 +311,31 @@ func (a *CounterAnalyzer) Analyze(data []storage.PriceDataInterface, config comm
 	// ТОЛЬКО АГРЕГИРОВАННОЕ ЛОГИРОВАНИЕ РАЗ В 5 СЕКУНД
 	a.logAggregatedStatsIfNeeded(5 * time.Second)
 
+	a.cleanupEpisodesIfNeeded(a.deps.Now())
+
 	return signals, nil
 }
 
+// cleanupEpisodesIfNeeded раз в episodeCleanupInterval удаляет эпизоды
+// символов, по которым давно не было сигналов
+func (a *CounterAnalyzer) cleanupEpisodesIfNeeded(now time.Time) {
+	if a.deps.Episodes == nil {
+		return
+	}
+
+	a.episodeCleanupMu.Lock()
+	if now.Sub(a.lastEpisodeClean) < episodeCleanupInterval {
+		a.episodeCleanupMu.Unlock()
+		return
+	}
+	a.lastEpisodeClean = now
+	a.episodeCleanupMu.Unlock()
+
+	if removed := a.deps.Episodes.Cleanup(now); removed > 0 {
+		logger.Debug("🧹 [CounterAnalyzer] Удалено устаревших эпизодов: %d", removed)
+	}
+}
+
 // logAggregatedStatsIfNeeded логирует агрегированную статистику с разделением закрытые/активные
 func (a *CounterAnalyzer) logAggregatedStatsIfNeeded(interval time.Duration) {
 	now := time.Now()
@@ -20,6 +20,7 @@ type CounterSettings struct {
 	AnalysisPeriod        string // Изменено с CounterPeriod на string
 	GrowthThreshold       float64
 	FallThreshold         float64
+	MinVolumeUSD          float64 // минимальный оборот закрытой свечи, 0 — без фильтра
 	TrackGrowth           bool
 	TrackFall             bool
 	NotifyOnSignal        bool
@@ -46,6 +47,7 @@ func DefaultConfig() CounterConfig {
 			AnalysisPeriod:        "15m", // Строка вместо CounterPeriod
 			GrowthThreshold:       0.1,
 			FallThreshold:         0.1,
+			MinVolumeUSD:          0,
 			TrackGrowth:           true,
 			TrackFall:             true,
 			NotifyOnSignal:        true,
@@ -74,6 +76,7 @@ func (c *CounterConfig) ToAnalyzerConfig() common.AnalyzerConfig {
 			"analysis_period":        c.Settings.AnalysisPeriod,
 			"growth_threshold":       c.Settings.GrowthThreshold,
 			"fall_threshold":         c.Settings.FallThreshold,
+			"min_volume_usd":         c.Settings.MinVolumeUSD,
 			"track_growth":           c.Settings.TrackGrowth,
 			"track_fall":             c.Settings.TrackFall,
 			"notify_on_signal":       c.Settings.NotifyOnSignal,
@@ -107,6 +110,7 @@ func FromAnalyzerConfig(analyzerConfig common.AnalyzerConfig) CounterConfig {
 			AnalysisPeriod:        getString(custom, "analysis_period", "15m"),
 			GrowthThreshold:       getFloat(custom, "growth_threshold", 0.1),
 			FallThreshold:         getFloat(custom, "fall_threshold", 0.1),
+			MinVolumeUSD:          getFloat(custom, "min_volume_usd", 0),
 			TrackGrowth:           getBool(custom, "track_growth", true),
 			TrackFall:             getBool(custom, "track_fall", true),
 			NotifyOnSignal:        getBool(custom, "notify_on_signal", true),
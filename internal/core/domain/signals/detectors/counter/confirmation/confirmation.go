@@ -18,14 +18,29 @@ type PeriodCounter struct {
 
 // ConfirmationManager - менеджер подтверждений для CounterAnalyzer
 type ConfirmationManager struct {
-	counters map[string]*PeriodCounter // ключ: "symbol:period"
-	mu       sync.RWMutex
+	counters  map[string]*PeriodCounter // ключ: "symbol:period"
+	threshold int                       // порог отправки сигнала
+	mu        sync.RWMutex
 }
 
-// NewConfirmationManager создает новый менеджер подтверждений
+// DefaultSignalThreshold порог подтверждений по умолчанию: каждый сигнал
+// (COUNTER_REQUIRED_CONFIRMATIONS)
+const DefaultSignalThreshold = 1
+
+// NewConfirmationManager создает менеджер с порогом по умолчанию
 func NewConfirmationManager() *ConfirmationManager {
+	return NewConfirmationManagerWithThreshold(DefaultSignalThreshold)
+}
+
+// NewConfirmationManagerWithThreshold создает менеджер с порогом подтверждений:
+// в боте — COUNTER_REQUIRED_CONFIRMATIONS, в бэктесте — confirmations конфигурации
+func NewConfirmationManagerWithThreshold(threshold int) *ConfirmationManager {
+	if threshold < 1 {
+		threshold = 1
+	}
 	return &ConfirmationManager{
-		counters: make(map[string]*PeriodCounter),
+		counters:  make(map[string]*PeriodCounter),
+		threshold: threshold,
 	}
 }
 
@@ -35,20 +50,24 @@ func GetRequiredConfirmations(period string) int {
 	return 6 // Визуальная цель всегда 6
 }
 
-// GetSignalThreshold возвращает порог отправки сигнала (каждые 3 подтверждения)
-func GetSignalThreshold() int {
-	return 3
+// Threshold возвращает порог отправки сигнала
+func (cm *ConfirmationManager) Threshold() int {
+	return cm.threshold
 }
 
 // AddConfirmation добавляет подтверждение для символа и периода
 // direction: "growth" (рост) или "fall" (падение)
-// Возвращает true, если достигнут порог сигнала (3, 6, 9... подтверждений ПОДРЯД)
+// Возвращает true, если достигнут порог сигнала (threshold, 2·threshold... подтверждений ПОДРЯД)
 func (cm *ConfirmationManager) AddConfirmation(symbol, period, direction string) (bool, int) {
+	return cm.AddConfirmationAt(symbol, period, direction, time.Now())
+}
+
+// AddConfirmationAt — AddConfirmation на момент now (симулированное время бэктеста)
+func (cm *ConfirmationManager) AddConfirmationAt(symbol, period, direction string, now time.Time) (bool, int) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
 	key := symbol + ":" + period
-	now := time.Now()
 
 	// Получаем или создаем счетчик
 	counter, exists := cm.counters[key]
@@ -80,8 +99,8 @@ func (cm *ConfirmationManager) AddConfirmation(symbol, period, direction string)
 	counter.Confirmations++
 	counter.LastUpdate = now
 
-	// Проверяем, достигнут ли порог сигнала (каждые 3 подтверждения)
-	if counter.Confirmations >= GetSignalThreshold() && counter.Confirmations%GetSignalThreshold() == 0 {
+	// Проверяем, достигнут ли порог сигнала (каждые threshold подтверждений)
+	if counter.Confirmations >= cm.threshold && counter.Confirmations%cm.threshold == 0 {
 		return true, counter.Confirmations
 	}
 
@@ -2,6 +2,7 @@
 package counter
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/episodes"
 	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
 	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/calculator"
 	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
@@ -155,6 +156,9 @@ func (a *CounterAnalyzer) analyzeClosedCandle(symbol, period string) (*analysis.
 	// Проверяем пороги
 	growthThreshold := SafeGetFloat(a.config.CustomSettings, "growth_threshold", 0.01) // 0.01%
 	fallThreshold := SafeGetFloat(a.config.CustomSettings, "fall_threshold", 0.01)     // 0.01%
+	regimeFactor := a.regimeThresholdFactor()
+	growthThreshold *= regimeFactor
+	fallThreshold *= regimeFactor
 
 	var shouldCreateSignal bool
 	var direction string
@@ -174,6 +178,14 @@ func (a *CounterAnalyzer) analyzeClosedCandle(symbol, period string) (*analysis.
 		return nil, fmt.Errorf("ниже порога")
 	}
 
+	// Фильтр по обороту: движения на пустой свече чаще оказываются шумом
+	if minVolume := SafeGetFloat(a.config.CustomSettings, "min_volume_usd", 0); candleData.VolumeUSD < minVolume {
+		a.candleStatsMu.Lock()
+		a.candleStats.ClosedCandleStats.BelowThreshold++
+		a.candleStatsMu.Unlock()
+		return nil, fmt.Errorf("оборот ниже порога")
+	}
+
 	// Создаем сигнал
 	signal := a.CreateSignal(symbol, period, direction, changePercent, candleData)
 	signal.Metadata.Tags = append(signal.Metadata.Tags, "closed_candle")
@@ -243,7 +255,7 @@ func (a *CounterAnalyzer) analyzeActiveCandle(symbol, period string) (*analysis.
 	}
 
 	// Проверяем минимальное время свечи для анализа
-	elapsed := time.Since(candle.StartTime)
+	elapsed := a.deps.Now().Sub(candle.StartTime)
 	minTimePercent := SafeGetFloat(a.config.CustomSettings, "active_candle_min_time_percent", 0.3) // 30%
 
 	expectedDuration := periodToDuration(period)
@@ -270,6 +282,8 @@ func (a *CounterAnalyzer) analyzeActiveCandle(symbol, period string) (*analysis.
 	// Более строгие пороги для активных свечей
 	activeGrowthThreshold := SafeGetFloat(a.config.CustomSettings, "active_growth_threshold", 0.02) // 0.02%
 	activeFallThreshold := SafeGetFloat(a.config.CustomSettings, "active_fall_threshold", 0.02)
+	activeGrowthThreshold *= a.regimeThresholdFactor()
+	activeFallThreshold *= a.regimeThresholdFactor()
 
 	// Дополнительный критерий: объем должен быть значительным
 	var volumeOK bool
@@ -370,7 +384,7 @@ func (a *CounterAnalyzer) CreateSignal(symbol, period, direction string, changeP
 		StartPrice:    candleData.Open,
 		EndPrice:      candleData.Close,
 		Volume:        candleData.VolumeUSD,
-		Timestamp:     time.Now(),
+		Timestamp:     a.deps.Now(),
 		Metadata: analysis.Metadata{
 			Strategy: "counter_candle_analyzer",
 			Tags:     []string{"candle_analysis", period},
@@ -382,14 +396,55 @@ func (a *CounterAnalyzer) CreateSignal(symbol, period, direction string, changeP
 		Progress: nil,
 	}
 
+	// Прикрепляем теги дивергенций, найденных по закрытым свечам того же периода
+	if a.deps.DivergenceStore != nil {
+		if tags := a.deps.DivergenceStore.Tags(symbol, period); len(tags) > 0 {
+			signal.Metadata.Tags = append(signal.Metadata.Tags, tags...)
+			signal.Metadata.Custom["divergences"] = tags
+		}
+	}
+
+	// Свечные паттерны последней закрытой свечи того же периода
+	if a.deps.PatternStore != nil {
+		if names := a.deps.PatternStore.Names(symbol, period); len(names) > 0 {
+			signal.Metadata.Patterns = append(signal.Metadata.Patterns, names...)
+			signal.Metadata.Tags = append(signal.Metadata.Tags, a.deps.PatternStore.Tags(symbol, period)...)
+			signal.Metadata.Custom["patterns"] = names
+		}
+	}
+
+	// Оцениваем согласованность со старшими таймфреймами
+	if a.deps.Confluence != nil {
+		if result := a.deps.Confluence.Evaluate(symbol, period, direction, candleData.Close); result.Available {
+			signal.Metadata.Custom["confluence"] = result.ToMap()
+			signal.Metadata.Custom["confluence_score"] = result.Score
+		}
+	}
+
+	// Раскладываем движение на рыночное (бета к BTC/ETH) и собственное
+	if a.deps.MarketContext != nil {
+		if ctx, ok := a.deps.MarketContext.Evaluate(symbol, period, candleData, changePercent); ok {
+			signal.Metadata.Custom["market_context"] = ctx.ToMap()
+			signal.Metadata.Custom["idiosyncratic_change"] = ctx.IdiosyncraticChange
+			if ctx.Explained {
+				signal.Metadata.Tags = append(signal.Metadata.Tags, "market_driven")
+			}
+		}
+	}
+
+	// Режим рынка на момент сигнала: для фильтров пользователей и аналитики журнала
+	if marketRegime := a.currentRegime(); marketRegime != "" {
+		signal.Metadata.Custom["market_regime"] = marketRegime
+	}
+
 	return signal
 }
 
-// PublishRawCounterSignal публикует сигнал (только отправка)
-func (a *CounterAnalyzer) PublishRawCounterSignal(signal analysis.Signal, period string) {
-	if a.deps.EventBus == nil {
+// PublishRawCounterSignal публикует сигнал через фильтры движка; false — сигнал не отправлен
+func (a *CounterAnalyzer) PublishRawCounterSignal(signal analysis.Signal, period string) bool {
+	if a.deps.Publisher == nil {
 		logger.Error("❌ EventBus не инициализирован")
-		return
+		return false
 	}
 
 	// Валидируем период перед отправкой
@@ -407,15 +462,15 @@ func (a *CounterAnalyzer) PublishRawCounterSignal(signal analysis.Signal, period
 		Type:      types.EventCounterSignalDetected,
 		Source:    "counter_analyzer_raw",
 		Data:      eventData,
-		Timestamp: time.Now(),
+		Timestamp: a.deps.Now(),
 	}
 
-	if err := a.deps.EventBus.Publish(event); err != nil {
-		logger.Error("❌ Ошибка публикации сигнала %s: %v", signal.Symbol, err)
-	} else {
-		logger.Debug("✅ Сигнал опубликован: %s %s %.2f%% (%s)",
-			signal.Symbol, signal.Direction, signal.ChangePercent, period)
+	if !a.deps.Publisher.Publish(signal, event) {
+		return false
 	}
+	logger.Debug("✅ Сигнал опубликован: %s %s %.2f%% (%s)",
+		signal.Symbol, signal.Direction, signal.ChangePercent, period)
+	return true
 }
 
 // getPriceHistoryForAnalysis получает историю цен для технического анализа
@@ -521,6 +576,8 @@ func (a *CounterAnalyzer) CreateCounterEventData(signal analysis.Signal, period
 	eventData["period"] = normalizedPeriod
 
 	eventData["timestamp"] = signal.Timestamp
+	// ID связывает контекст события с записью сигнала в журнале
+	eventData["signal_id"] = signal.ID
 
 	// 2. Подтверждения (1 поле) - заглушка
 	eventData["confirmations"] = 3
@@ -570,7 +627,7 @@ func (a *CounterAnalyzer) CreateCounterEventData(signal analysis.Signal, period
 
 	// ⭐ ДОБАВЛЯЕМ ВРЕМЯ СЛЕДУЮЩЕГО ФАНДИНГА (заглушка, нужно получить реальное)
 	// В Bybit фандинг обычно каждые 8 часов: 00:00, 08:00, 16:00 UTC
-	now := time.Now().UTC()
+	now := a.deps.Now().UTC()
 	nextFunding := time.Date(now.Year(), now.Month(), now.Day(),
 		(now.Hour()/8+1)*8, 0, 0, 0, time.UTC)
 	if nextFunding.Before(now) {
@@ -634,7 +691,50 @@ func (a *CounterAnalyzer) CreateCounterEventData(signal analysis.Signal, period
 		"percentage":    50.0, // Заглушка
 	}
 
-	// 5. Зоны S/R (если хранилище доступно)
+	// 5. Дивергенции RSI/MACD (теги вида "divergence:rsi:regular_bullish")
+	if tags, ok := signal.Metadata.Custom["divergences"].([]string); ok && len(tags) > 0 {
+		eventData["divergences"] = tags
+	}
+	if len(signal.Metadata.Patterns) > 0 {
+		eventData["patterns"] = signal.Metadata.Patterns
+	}
+
+	// 6. Согласованность со старшими таймфреймами (0..100, 50 — нейтрально)
+	if score, ok := signal.Metadata.Custom["confluence_score"].(float64); ok {
+		eventData["confluence_score"] = score
+		eventData["confluence"] = signal.Metadata.Custom["confluence"]
+	}
+
+	// 7. Рыночный контекст: сырое и собственное (за вычетом беты) изменение
+	if ctx, ok := signal.Metadata.Custom["market_context"].(map[string]interface{}); ok {
+		eventData["market_context"] = ctx
+		eventData["market_benchmark"] = ctx["benchmark"]
+		eventData["market_benchmark_change"] = ctx["benchmark_change"]
+		eventData["market_beta"] = ctx["beta"]
+		eventData["market_correlation"] = ctx["correlation"]
+		eventData["idiosyncratic_change"] = ctx["idiosyncratic_change"]
+		eventData["market_explained"] = ctx["explained"]
+	}
+	if marketRegime, ok := signal.Metadata.Custom["market_regime"].(string); ok {
+		eventData["market_regime"] = marketRegime
+	}
+
+	// 8. Ликвидность стакана: стоимость сдвига цены на 1% и дисбаланс
+	// (последний снимок; стакан запрашивается в фоне, без ожидания).
+	// Если стакан не доходит до ±1%, метрики были бы заниженными — не показываем
+	if a.deps.Liquidity != nil {
+		if snapshot, ok := a.deps.Liquidity.Snapshot(signal.Symbol); ok {
+			if band, ok := snapshot.Band(1.0); ok && band.Covered {
+				eventData["liquidity_cost_up_1pct"] = snapshot.CostUp1Pct
+				eventData["liquidity_cost_down_1pct"] = snapshot.CostDown1Pct
+				eventData["liquidity_imbalance_1pct"] = band.Imbalance
+				eventData["liquidity_thin_book"] = snapshot.ThinBook
+			}
+			eventData["liquidity_warnings"] = snapshot.Warnings()
+		}
+	}
+
+	// 9. Зоны S/R (если хранилище доступно)
 	// Используем fallback по более старшим периодам, если для текущего зон нет.
 	// Причина: зоны пересчитываются только при закрытии свечи (EventCandleClosed),
 	// а сигналы генерируются каждые 30 секунд — возникает временной разрыв.
@@ -666,6 +766,43 @@ func (a *CounterAnalyzer) CreateCounterEventData(signal analysis.Signal, period
 		}
 	}
 
+	// 10. Эпизод памп/дамп: связываем сигнал с предыдущими по символу
+	if a.deps.Episodes != nil {
+		volumeRatio := 0.0
+		if a.deps.CandleSystem != nil {
+			volumeRatio = episodes.VolumeRatio(a.deps.CandleSystem, signal.Symbol, normalizedPeriod)
+		}
+		update, ok := a.deps.Episodes.Observe(episodes.Observation{
+			Symbol:        signal.Symbol,
+			Direction:     signal.Direction,
+			ChangePercent: signal.ChangePercent,
+			Price:         signal.EndPrice,
+			VolumeRatio:   volumeRatio,
+			DeltaPercent:  deltaData.DeltaPercent,
+			OIChange:      oiChange24h,
+			Time:          signal.Timestamp,
+		})
+		if ok {
+			for k, v := range update.ToMap() {
+				eventData[k] = v
+			}
+			if update.StageChanged {
+				logger.Info("🎢 Эпизод %s: %s → %s (пик %.6g, откат %.2f%%)",
+					update.Episode.ID, update.Previous, update.Episode.Stage,
+					update.Episode.PeakPrice, update.Episode.RetracePct())
+			}
+		}
+	}
+
+	// 11. VWAP дневной сессии: расстояние цены и отклонение в σ
+	if tracker := a.deps.CandleSystem.VWAP(); tracker != nil && signal.EndPrice > 0 {
+		if snap, ok := tracker.Session(signal.Symbol); ok {
+			eventData["vwap_session"] = snap.VWAP
+			eventData["vwap_distance_pct"] = snap.DistancePct(signal.EndPrice)
+			eventData["vwap_sigmas"] = snap.Sigmas(signal.EndPrice)
+		}
+	}
+
 	logger.Debug("📊 CounterAnalyzer: реальные индикаторы для %s/%s - RSI: %.1f (%s), MACD: %.4f (%s), ликвидации: $%.0f",
 		signal.Symbol, period, rsi, rsiStatus, macdSignal, macdStatus, liquidationVolume)
 
@@ -738,3 +875,16 @@ func parseCandleKey(candleKey string) (symbol, period string, startTime int64, e
 }
 
 // SafeGetFloat безопасно получает float из map
+
+// currentRegime возвращает текущий режим рынка ("" — классификатор выключен)
+func (a *CounterAnalyzer) currentRegime() string {
+	if a.deps.Regime == nil {
+		return ""
+	}
+	return a.deps.Regime.Current()
+}
+
+// regimeThresholdFactor — множитель порогов для текущего режима рынка (1 — без изменений)
+func (a *CounterAnalyzer) regimeThresholdFactor() float64 {
+	return a.regimeFactors.For(a.currentRegime())
+}
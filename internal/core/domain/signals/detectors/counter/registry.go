@@ -0,0 +1,167 @@
+// internal/core/domain/signals/detectors/counter/registry.go
+package counter
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/confluence"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/episodes"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/market_context"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/calculator"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/confirmation"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"strings"
+	"time"
+)
+
+// Schema - настройки CounterAnalyzer (COUNTER_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "base_period_minutes", Type: common.SettingInt, Default: 1, Range: &common.Range{Min: 1, Max: 60},
+		Description: "Базовый период в минутах"},
+	{Key: "analysis_period", Type: common.SettingString, Default: "15m", Options: []string{"5m", "15m", "30m", "1h", "4h", "1d"},
+		Description: "Период анализа"},
+	{Key: "growth_threshold", Type: common.SettingFloat, Default: 0.1, Range: &common.Range{Min: 0, Max: 100},
+		Description: "Порог роста в процентах"},
+	{Key: "fall_threshold", Type: common.SettingFloat, Default: 0.1, Range: &common.Range{Min: 0, Max: 100},
+		Description: "Порог падения в процентах"},
+	{Key: "min_volume_usd", Type: common.SettingFloat, Default: 0.0, Range: &common.Range{Min: 0, Max: 1e12},
+		Description: "Минимальный оборот закрытой свечи в USD (0 — без фильтра)"},
+	{Key: "track_growth", Type: common.SettingBool, Default: true, Description: "Отслеживать рост"},
+	{Key: "track_fall", Type: common.SettingBool, Default: true, Description: "Отслеживать падение"},
+	{Key: "notify_on_signal", Type: common.SettingBool, Default: true, Description: "Отправлять уведомления"},
+	{Key: "notification_enabled", Type: common.SettingBool, Default: true, Description: "Уведомления счетчика включены"},
+	{Key: "notification_threshold", Type: common.SettingInt, Default: 1, Range: &common.Range{Min: 1, Max: 100},
+		Description: "Порог для уведомлений"},
+	{Key: "required_confirmations", Type: common.SettingInt, Default: confirmation.DefaultSignalThreshold, Range: &common.Range{Min: 1, Max: 100},
+		Description: "Сигналов подряд по символу и периоду для отправки"},
+	{Key: "chart_provider", Type: common.SettingString, Default: "coinglass", Options: []string{"coinglass", "tradingview"},
+		Description: "Провайдер графиков"},
+	{Key: "max_signals_5m", Type: common.SettingInt, Default: 5, Range: &common.Range{Min: 1, Max: 1000},
+		Description: "Лимит сигналов за 5m"},
+	{Key: "max_signals_15m", Type: common.SettingInt, Default: 8, Range: &common.Range{Min: 1, Max: 1000},
+		Description: "Лимит сигналов за 15m"},
+	{Key: "max_signals_30m", Type: common.SettingInt, Default: 10, Range: &common.Range{Min: 1, Max: 1000},
+		Description: "Лимит сигналов за 30m"},
+	{Key: "max_signals_1h", Type: common.SettingInt, Default: 12, Range: &common.Range{Min: 1, Max: 1000},
+		Description: "Лимит сигналов за 1h"},
+	{Key: "max_signals_4h", Type: common.SettingInt, Default: 15, Range: &common.Range{Min: 1, Max: 1000},
+		Description: "Лимит сигналов за 4h"},
+	{Key: "max_signals_1d", Type: common.SettingInt, Default: 20, Range: &common.Range{Min: 1, Max: 1000},
+		Description: "Лимит сигналов за 1d"},
+	{Key: "confluence_enabled", Type: common.SettingBool, Default: true,
+		Description: "Оценка согласованности со старшими таймфреймами"},
+	{Key: "market_context_enabled", Type: common.SettingBool, Default: true,
+		Description: "Бета и корреляция к ориентирам рынка"},
+	{Key: "market_benchmarks", Type: common.SettingString, Default: "BTCUSDT,ETHUSDT",
+		Description: "Ориентиры рынка через запятую"},
+	{Key: "market_beta_window", Type: common.SettingInt, Default: 100, Range: &common.Range{Min: 0, Max: 1000},
+		Description: "Окно расчета беты в свечах"},
+	{Key: "regime_threshold_factors", Type: common.SettingString, Default: "",
+		Description: "Множители порогов по режимам рынка: trend_up=0.8,range=1.2,panic=2"},
+	{Key: "liquidity_enabled", Type: common.SettingBool, Default: true,
+		Description: "Глубина и дисбаланс стакана в сигнале"},
+	{Key: "episodes_enabled", Type: common.SettingBool, Default: true,
+		Description: "Связывать сигналы в эпизоды памп/дамп"},
+	{Key: "episode_pump_pct", Type: common.SettingFloat, Default: 3.0, Range: &common.Range{Min: 0.1, Max: 100},
+		Description: "Рост за сигнал для стадии пампа, %"},
+	{Key: "episode_volume_spike", Type: common.SettingFloat, Default: 2.0, Range: &common.Range{Min: 1, Max: 50},
+		Description: "Всплеск объёма к среднему для пампа/дампа"},
+	{Key: "episode_delta_pct", Type: common.SettingFloat, Default: 15.0, Range: &common.Range{Min: 1, Max: 100},
+		Description: "Дельта объёма, подтверждающая стадию, %"},
+	{Key: "episode_dump_retrace_pct", Type: common.SettingFloat, Default: 3.0, Range: &common.Range{Min: 0.1, Max: 100},
+		Description: "Откат от пика для стадии дампа, %"},
+	{Key: "episode_ttl_minutes", Type: common.SettingInt, Default: 360, Range: &common.Range{Min: 5, Max: 10080},
+		Description: "Время жизни эпизода без сигналов, мин"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:        "counter",
+		Description: "Счетчик роста/падения цены по периодам",
+		Requires:    []string{analyzers.DependencyStorage},
+		// Теги дивергенций, паттерны и провайдер стакана создаются этими анализаторами
+		After:         []string{"divergence", "liquidity", "patterns"},
+		Weight:        0.7,
+		MinConfidence: 10.0,
+		MinDataPoints: 2,
+		Settings:      Schema,
+		New:           newFromRegistry,
+	})
+}
+
+// newFromRegistry создает CounterAnalyzer из общего контекста движка
+func newFromRegistry(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+	settings := config.CustomSettings
+
+	deps := Dependencies{
+		Storage:          ctx.Storage,
+		EventBus:         ctx.EventBus,
+		Publisher:        ctx.Publisher,
+		CandleSystem:     ctx.CandleSystem,
+		MarketFetcher:    ctx.PriceFetcher,
+		VolumeCalculator: calculator.NewVolumeDeltaCalculator(ctx.PriceFetcher, ctx.Storage),
+		SRZoneStorage:    ctx.SRZoneStorage,
+		DivergenceStore:  ctx.DivergenceStore,
+		PatternStore:     ctx.PatternStore,
+		Confluence:       newConfluenceEvaluator(ctx, settings),
+		MarketContext:    newMarketContextCalculator(ctx, settings),
+		Episodes:         newEpisodeTracker(settings),
+		Regime:           ctx.Regime,
+		Now:              ctx.Now,
+	}
+	if SafeGetBool(settings, "liquidity_enabled", true) {
+		deps.Liquidity = ctx.LiquidityProvider()
+	}
+
+	logger.Warn("⚠️ CandleTracker временно не используется, нужен RedisService")
+	return NewCounterAnalyzer(config, deps), nil
+}
+
+// newConfluenceEvaluator создает оценщик согласованности со старшими таймфреймами
+func newConfluenceEvaluator(ctx *analyzers.BuildContext, settings map[string]interface{}) *confluence.Evaluator {
+	if ctx.CandleSystem == nil || !SafeGetBool(settings, "confluence_enabled", true) {
+		return nil
+	}
+	// Передаем nil-интерфейс, а не типизированный nil, если зон S/R нет
+	var zones confluence.ZoneProvider
+	if ctx.SRZoneStorage != nil {
+		zones = ctx.SRZoneStorage
+	}
+	logger.Info("✅ Confluence: оценка по старшим таймфреймам включена (S/R: %v)", zones != nil)
+	return confluence.NewEvaluator(ctx.CandleSystem, zones)
+}
+
+// newMarketContextCalculator создает калькулятор беты относительно ориентиров рынка
+func newMarketContextCalculator(ctx *analyzers.BuildContext, settings map[string]interface{}) *market_context.Calculator {
+	if ctx.CandleSystem == nil || !SafeGetBool(settings, "market_context_enabled", true) {
+		return nil
+	}
+	var benchmarks []string
+	for _, s := range strings.Split(SafeGetString(settings, "market_benchmarks", ""), ",") {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			benchmarks = append(benchmarks, s)
+		}
+	}
+	window := SafeGetInt(settings, "market_beta_window", 0)
+	calc := market_context.NewCalculator(ctx.CandleSystem, benchmarks, window)
+	logger.Info("✅ MarketContext: бета/корреляция к ориентирам рынка включена")
+	return calc
+}
+
+// newEpisodeTracker создает автомат стадий памп/дамп
+func newEpisodeTracker(settings map[string]interface{}) *episodes.Tracker {
+	if !SafeGetBool(settings, "episodes_enabled", true) {
+		return nil
+	}
+	cfg := episodes.DefaultConfig()
+	cfg.PumpChangePct = SafeGetFloat(settings, "episode_pump_pct", cfg.PumpChangePct)
+	cfg.VolumeSpike = SafeGetFloat(settings, "episode_volume_spike", cfg.VolumeSpike)
+	cfg.DeltaPct = SafeGetFloat(settings, "episode_delta_pct", cfg.DeltaPct)
+	cfg.DumpRetracePct = SafeGetFloat(settings, "episode_dump_retrace_pct", cfg.DumpRetracePct)
+	if minutes := SafeGetInt(settings, "episode_ttl_minutes", 0); minutes > 0 {
+		cfg.TTL = time.Duration(minutes) * time.Minute
+	}
+	logger.Info("✅ Episodes: эпизоды памп/дамп включены (памп ≥%.1f%%, откат ≥%.1f%%, TTL %v)",
+		cfg.PumpChangePct, cfg.DumpRetracePct, cfg.TTL)
+	return episodes.NewTracker(cfg)
+}
@@ -0,0 +1,269 @@
+// internal/core/domain/signals/detectors/divergence/analyzer.go
+package divergence
+
+import (
+	div "crypto-exchange-screener-bot/internal/core/domain/analysis/divergence"
+	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/pkg/logger"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPeriods      = "15m,1h,4h"
+	defaultHistoryLimit = 150
+	// maxEmittedKeys — после этого размера журнал отправленных дивергенций чистится
+	maxEmittedKeys = 5000
+)
+
+// Dependencies зависимости для DivergenceAnalyzer
+type Dependencies struct {
+	CandleSystem *candle.CandleSystem
+	Store        *div.Store           // общее хранилище, из которого CounterAnalyzer берёт теги
+	Publisher    *analyzers.Publisher // опционально: фильтры движка
+}
+
+// DivergenceAnalyzer ищет дивергенции RSI/MACD по закрытым свечам
+type DivergenceAnalyzer struct {
+	config   common.AnalyzerConfig
+	deps     Dependencies
+	detector *div.Detector
+	// maxBarsAgo — сколько закрытых свечей дивергенция остаётся актуальной
+	maxBarsAgo int
+
+	statsMu sync.RWMutex
+	stats   common.AnalyzerStats
+
+	// closed — последние обработанные закрытые свечи по symbol:period
+	closed *common.ClosedCandles
+
+	mu sync.Mutex
+	// emitted — ключи уже отправленных дивергенций (чтобы не дублировать сигнал)
+	emitted map[string]time.Time
+}
+
+// NewDivergenceAnalyzer создает анализатор дивергенций
+func NewDivergenceAnalyzer(config common.AnalyzerConfig, deps Dependencies) *DivergenceAnalyzer {
+	if deps.Store == nil {
+		deps.Store = div.NewStore()
+	}
+
+	detectorConfig := div.DefaultConfig()
+	detectorConfig.PivotLookback = common.SafeGetInt(config.CustomSettings, "pivot_lookback", detectorConfig.PivotLookback)
+	detectorConfig.RSIPeriod = common.SafeGetInt(config.CustomSettings, "rsi_period", detectorConfig.RSIPeriod)
+	detectorConfig.MaxBarsAgo = common.SafeGetInt(config.CustomSettings, "max_bars_ago", detectorConfig.MaxBarsAgo)
+	detectorConfig.MinPivotGap = common.SafeGetInt(config.CustomSettings, "min_pivot_gap", detectorConfig.MinPivotGap)
+	detectorConfig.MaxPivotGap = common.SafeGetInt(config.CustomSettings, "max_pivot_gap", detectorConfig.MaxPivotGap)
+
+	logger.Info("✅ [DivergenceAnalyzer] Создан анализатор дивергенций (периоды: %s)",
+		strings.Join(common.Periods(config, defaultPeriods), ","))
+
+	return &DivergenceAnalyzer{
+		config:     config,
+		deps:       deps,
+		detector:   div.NewDetector(detectorConfig),
+		maxBarsAgo: detectorConfig.MaxBarsAgo,
+		closed:     common.NewClosedCandles(),
+		emitted:    make(map[string]time.Time),
+	}
+}
+
+// Analyze проверяет новые закрытые свечи по каждому символу и периоду
+func (a *DivergenceAnalyzer) Analyze(data []storage.PriceDataInterface, config common.AnalyzerConfig) ([]analysis.Signal, error) {
+	startTime := time.Now()
+	defer a.updateStats(startTime)
+
+	a.config = config
+	if a.deps.CandleSystem == nil {
+		return nil, nil
+	}
+
+	var signals []analysis.Signal
+	for _, point := range data {
+		for _, period := range common.Periods(config, defaultPeriods) {
+			found, err := a.analyzeSymbolPeriod(point.GetSymbol(), period)
+			if err != nil {
+				logger.Debug("⚠️ [DivergenceAnalyzer] %s/%s: %v", point.GetSymbol(), period, err)
+				continue
+			}
+			signals = append(signals, found...)
+		}
+	}
+	return signals, nil
+}
+
+// analyzeSymbolPeriod запускает детектор, только если закрылась новая свеча
+func (a *DivergenceAnalyzer) analyzeSymbolPeriod(symbol, period string) ([]analysis.Signal, error) {
+	if !a.closed.Due(symbol, period, time.Now()) {
+		return nil, nil
+	}
+
+	limit := common.SafeGetInt(a.config.CustomSettings, "history_limit", defaultHistoryLimit)
+	history, err := a.deps.CandleSystem.GetHistory(symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]storage.CandleInterface, 0, len(history))
+	for _, c := range history {
+		if c != nil && c.IsClosedFlag && c.IsRealFlag && c.Close > 0 {
+			closed = append(closed, c)
+		}
+	}
+	if len(closed) == 0 {
+		return nil, nil
+	}
+
+	if !a.closed.Mark(symbol, period, closed[len(closed)-1].GetStartTime()) {
+		return nil, nil
+	}
+
+	divergences := a.detector.Detect(symbol, period, closed)
+
+	// Дивергенция актуальна, пока не закроется ещё несколько свечей
+	validFor := periodPkg.PeriodToDuration(period) * time.Duration(a.maxBarsAgo+1)
+	a.deps.Store.Set(symbol, period, divergences, validFor)
+
+	var signals []analysis.Signal
+	for _, d := range divergences {
+		if !a.markEmitted(d.Key()) {
+			continue
+		}
+		signal := a.createSignal(d, divergences)
+		if signal.Confidence < a.config.MinConfidence || !a.deps.Publisher.Allow(signal) {
+			continue
+		}
+		signals = append(signals, signal)
+	}
+	return signals, nil
+}
+
+// markEmitted возвращает false, если сигнал по этой дивергенции уже отправлялся
+func (a *DivergenceAnalyzer) markEmitted(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.emitted[key]; ok {
+		return false
+	}
+	if len(a.emitted) >= maxEmittedKeys {
+		cutoff := time.Now().Add(-48 * time.Hour)
+		for k, t := range a.emitted {
+			if t.Before(cutoff) {
+				delete(a.emitted, k)
+			}
+		}
+	}
+	a.emitted[key] = time.Now()
+	return true
+}
+
+// createSignal строит сигнал; all — все дивергенции этой свечи (для подтверждения вторым индикатором)
+func (a *DivergenceAnalyzer) createSignal(d div.Divergence, all []div.Divergence) analysis.Signal {
+	confidence := 55.0
+	if d.Kind.IsRegular() {
+		confidence = 65.0
+	}
+	confirmed := false
+	for _, other := range all {
+		if other.Kind == d.Kind && other.Indicator != d.Indicator {
+			confirmed = true
+			confidence += 15
+			break
+		}
+	}
+	confidence = math.Min(confidence, 100)
+
+	direction := "fall"
+	if d.Kind.IsBullish() {
+		direction = "growth"
+	}
+
+	periodMinutes, err := periodPkg.StringToMinutes(d.Period)
+	if err != nil {
+		periodMinutes = periodPkg.DefaultMinutes
+	}
+
+	return analysis.Signal{
+		ID:            uuid.New().String(),
+		Symbol:        d.Symbol,
+		Type:          "divergence",
+		Direction:     direction,
+		ChangePercent: d.PriceChangePercent(),
+		Period:        periodMinutes,
+		Confidence:    confidence,
+		DataPoints:    2,
+		StartPrice:    d.FromPrice,
+		EndPrice:      d.ToPrice,
+		Timestamp:     time.Now(),
+		Metadata: analysis.Metadata{
+			Strategy: "divergence_analyzer",
+			Tags:     []string{"divergence", d.Tag(), d.Period},
+			Indicators: map[string]float64{
+				string(d.Indicator) + "_from": d.FromIndicatorValue,
+				string(d.Indicator) + "_to":   d.ToIndicatorValue,
+			},
+			Custom: map[string]interface{}{
+				"period_string":   d.Period,
+				"indicator":       string(d.Indicator),
+				"kind":            string(d.Kind),
+				"pivot_from_time": d.FromTime,
+				"pivot_to_time":   d.ToTime,
+				"bars_ago":        d.BarsAgo,
+				"confirmed":       confirmed,
+			},
+		},
+	}
+}
+
+func (a *DivergenceAnalyzer) updateStats(startTime time.Time) {
+	a.statsMu.Lock()
+	defer a.statsMu.Unlock()
+
+	a.stats.TotalCalls++
+	a.stats.SuccessCount++
+	a.stats.TotalTime += time.Since(startTime)
+	a.stats.AverageTime = a.stats.TotalTime / time.Duration(a.stats.TotalCalls)
+	a.stats.LastCallTime = time.Now()
+}
+
+// GetStore возвращает хранилище найденных дивергенций
+func (a *DivergenceAnalyzer) GetStore() *div.Store {
+	return a.deps.Store
+}
+
+// GetConfig возвращает конфигурацию
+func (a *DivergenceAnalyzer) GetConfig() common.AnalyzerConfig {
+	return a.config
+}
+
+// GetStats возвращает статистику
+func (a *DivergenceAnalyzer) GetStats() common.AnalyzerStats {
+	a.statsMu.RLock()
+	defer a.statsMu.RUnlock()
+	return a.stats
+}
+
+// Name возвращает имя анализатора
+func (a *DivergenceAnalyzer) Name() string {
+	return "divergence"
+}
+
+// Version возвращает версию анализатора
+func (a *DivergenceAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// Supports проверяет, поддерживается ли символ
+func (a *DivergenceAnalyzer) Supports(symbol string) bool {
+	return true
+}
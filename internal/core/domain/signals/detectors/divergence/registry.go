@@ -0,0 +1,43 @@
+// internal/core/domain/signals/detectors/divergence/registry.go
+package divergence
+
+import (
+	div "crypto-exchange-screener-bot/internal/core/domain/analysis/divergence"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+)
+
+// Schema - настройки DivergenceAnalyzer (DIVERGENCE_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "periods", Type: common.SettingString, Default: defaultPeriods,
+		Description: "Периоды свечей через запятую"},
+	{Key: "history_limit", Type: common.SettingInt, Default: defaultHistoryLimit, Range: &common.Range{Min: 30, Max: 1000},
+		Description: "Сколько закрытых свечей загружать"},
+	{Key: "pivot_lookback", Type: common.SettingInt, Default: 3, Range: &common.Range{Min: 1, Max: 10},
+		Description: "Свечей с каждой стороны для пивота"},
+	{Key: "rsi_period", Type: common.SettingInt, Default: 14, Range: &common.Range{Min: 2, Max: 100},
+		Description: "Период RSI"},
+	{Key: "max_bars_ago", Type: common.SettingInt, Default: 3, Range: &common.Range{Min: 1, Max: 50},
+		Description: "Сколько свечей дивергенция остается актуальной"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:          "divergence",
+		Description:   "Дивергенции RSI/MACD по закрытым свечам",
+		Requires:      []string{analyzers.DependencyCandleSystem},
+		Weight:        0.5,
+		MinConfidence: 55.0,
+		MinDataPoints: 1,
+		Settings:      Schema,
+		New: func(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+			// Хранилище общее: CounterAnalyzer берет из него теги дивергенций
+			ctx.DivergenceStore = div.NewStore()
+			return NewDivergenceAnalyzer(config, Dependencies{
+				CandleSystem: ctx.CandleSystem,
+				Store:        ctx.DivergenceStore,
+				Publisher:    ctx.Publisher,
+			}), nil
+		},
+	})
+}
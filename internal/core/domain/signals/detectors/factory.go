@@ -1,4 +1,4 @@
-// internal/core/domain/signals/detectors/analyzers/factory.go
+// internal/core/domain/signals/detectors/factory.go
 package analyzers
 
 import (
@@ -14,26 +14,34 @@ func NewAnalyzerFactory() *AnalyzerFactory {
 	return &AnalyzerFactory{}
 }
 
-// CreateAnalyzer создает анализатор по имени
+// CreateAnalyzer создает анализатор по имени через реестр.
+// Контекст пустой, поэтому анализаторы с обязательными зависимостями
+// (свечи, стакан) здесь не создаются - их собирает фабрика движка.
 func (f *AnalyzerFactory) CreateAnalyzer(name string, config common.AnalyzerConfig) common.Analyzer {
-	switch name {
-	case "counter_analyzer":
-		// CounterAnalyzer требует дополнительные параметры
-		// Он должен создаваться отдельно через NewCounterAnalyzer
-		// с передачей storage и telegramBot
+	def, ok := Lookup(name)
+	if !ok {
 		return nil
-	default:
-		// Возвращаем анализатор по умолчанию
+	}
+	ctx := &BuildContext{}
+	if len(def.MissingDependencies(ctx)) > 0 {
+		return nil
+	}
+	analyzer, err := def.New(config, ctx)
+	if err != nil {
 		return nil
 	}
+	return analyzer
 }
 
-// GetAllcommon.AnalyzerConfigs возвращает конфигурации всех анализаторов
+// GetAllAnalyzerConfigs возвращает конфигурации по умолчанию всех зарегистрированных анализаторов
 func GetAllAnalyzerConfigs() map[string]common.AnalyzerConfig {
-	return map[string]common.AnalyzerConfig{
-
-		"counter_analyzer": DefaultCounterConfig,
+	configs := make(map[string]common.AnalyzerConfig)
+	for _, name := range RegisteredNames() {
+		if def, ok := Lookup(name); ok {
+			configs[name] = def.DefaultConfig()
+		}
 	}
+	return configs
 }
 
 // Вспомогательные функции для получения конфигураций
@@ -51,11 +59,9 @@ func getContinuousConfig() common.AnalyzerConfig {
 	}
 }
 
-// GetAnalyzerNames возвращает список всех доступных анализаторов
+// GetAnalyzerNames возвращает список всех зарегистрированных анализаторов
 func GetAnalyzerNames() []string {
-	return []string{
-		"counter_analyzer",
-	}
+	return RegisteredNames()
 }
 
 // GetEnabledAnalyzers возвращает список включенных анализаторов на основе конфигурации
@@ -136,49 +142,16 @@ func ValidateConfig(config common.AnalyzerConfig) error {
 }
 
 // GetDefaultConfig возвращает конфигурацию по умолчанию для анализатора
+// (для незарегистрированного - выключенную пустую конфигурацию)
 func GetDefaultConfig(analyzerName string) common.AnalyzerConfig {
-	configs := GetAllAnalyzerConfigs()
-	if config, exists := configs[analyzerName]; exists {
-		return config
+	if def, ok := Lookup(analyzerName); ok {
+		return def.DefaultConfig()
 	}
-
-	// Возвращаем конфигурацию по умолчанию для growth_analyzer
-	return DefaultCounterConfig
+	return common.AnalyzerConfig{}
 }
 
-// IsAnalyzerAvailable проверяет, доступен ли анализатор
+// IsAnalyzerAvailable проверяет, зарегистрирован ли анализатор
 func IsAnalyzerAvailable(analyzerName string) bool {
-	availableAnalyzers := GetAnalyzerNames()
-	for _, name := range availableAnalyzers {
-		if name == analyzerName {
-			return true
-		}
-	}
-	return false
-}
-
-// DefaultCounterConfig - конфигурация по умолчанию для CounterAnalyzer
-var DefaultCounterConfig = common.AnalyzerConfig{
-	Enabled:       true,
-	Weight:        0.7,
-	MinConfidence: 10.0,
-	MinDataPoints: 2,
-	CustomSettings: map[string]interface{}{
-		"base_period_minutes":    1,
-		"analysis_period":        "15m",
-		"growth_threshold":       0.1,
-		"fall_threshold":         0.1,
-		"track_growth":           true,
-		"track_fall":             true,
-		"notify_on_signal":       true,
-		"notification_threshold": 1,
-		"chart_provider":         "coinglass",
-		"exchange":               "bybit",
-		"include_oi":             true,
-		"include_volume":         true,
-		"include_funding":        true,
-		"volume_delta_ttl":       30,
-		"delta_fallback_enabled": true,
-		"show_delta_source":      true,
-	},
+	_, ok := Lookup(analyzerName)
+	return ok
 }
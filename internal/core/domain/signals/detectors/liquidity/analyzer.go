@@ -0,0 +1,241 @@
+// internal/core/domain/signals/detectors/liquidity/analyzer.go
+package liquidity
+
+import (
+	liq "crypto-exchange-screener-bot/internal/core/domain/analysis/liquidity"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSampleInterval     = 60 * time.Second
+	defaultImbalanceThreshold = 0.6
+	defaultPersistenceSamples = 3
+	defaultBandPct            = 1.0
+	defaultCooldown           = 30 * time.Minute
+	defaultMinVolume24h       = 5e6
+)
+
+// Dependencies зависимости для LiquidityAnalyzer
+type Dependencies struct {
+	Provider  *liq.Provider
+	Publisher *analyzers.Publisher // опционально: фильтры движка
+}
+
+// symbolState — история дисбаланса по символу
+type symbolState struct {
+	lastSample time.Time // время снимка последнего замера
+	imbalances []float64 // последние значения дисбаланса в основной полосе
+	lastSignal time.Time
+}
+
+// LiquidityAnalyzer отслеживает дисбаланс стакана и вакуум ликвидности.
+// Сигнал формируется, когда дисбаланс экстремален несколько замеров подряд.
+type LiquidityAnalyzer struct {
+	config common.AnalyzerConfig
+	deps   Dependencies
+
+	statsMu sync.RWMutex
+	stats   common.AnalyzerStats
+
+	mu     sync.Mutex
+	states map[string]*symbolState
+}
+
+// NewLiquidityAnalyzer создает анализатор ликвидности стакана
+func NewLiquidityAnalyzer(config common.AnalyzerConfig, deps Dependencies) *LiquidityAnalyzer {
+	logger.Info("✅ [LiquidityAnalyzer] Создан анализатор дисбаланса стакана (порог: %.2f, замеров: %d)",
+		common.SafeGetFloat(config.CustomSettings, "imbalance_threshold", defaultImbalanceThreshold),
+		common.SafeGetInt(config.CustomSettings, "persistence_samples", defaultPersistenceSamples))
+
+	return &LiquidityAnalyzer{
+		config: config,
+		deps:   deps,
+		states: make(map[string]*symbolState),
+	}
+}
+
+// Analyze делает замеры стакана не чаще sample_interval и проверяет устойчивость дисбаланса
+func (a *LiquidityAnalyzer) Analyze(data []storage.PriceDataInterface, config common.AnalyzerConfig) ([]analysis.Signal, error) {
+	startTime := time.Now()
+	defer a.updateStats(startTime)
+
+	a.config = config
+	if a.deps.Provider == nil {
+		return nil, nil
+	}
+
+	minVolume := common.SafeGetFloat(config.CustomSettings, "min_volume_24h", defaultMinVolume24h)
+
+	var signals []analysis.Signal
+	for _, point := range data {
+		if point.GetVolumeUSD() < minVolume {
+			continue
+		}
+		if signal, ok := a.analyzeSymbol(point.GetSymbol()); ok && a.deps.Publisher.Allow(signal) {
+			signals = append(signals, signal)
+		}
+	}
+	return signals, nil
+}
+
+// analyzeSymbol делает замер по последнему снимку стакана и возвращает сигнал, если дисбаланс устойчив
+func (a *LiquidityAnalyzer) analyzeSymbol(symbol string) (analysis.Signal, bool) {
+	interval := time.Duration(common.SafeGetInt(a.config.CustomSettings, "sample_interval_sec",
+		int(defaultSampleInterval/time.Second))) * time.Second
+	samples := common.SafeGetInt(a.config.CustomSettings, "persistence_samples", defaultPersistenceSamples)
+	threshold := common.SafeGetFloat(a.config.CustomSettings, "imbalance_threshold", defaultImbalanceThreshold)
+	bandPct := common.SafeGetFloat(a.config.CustomSettings, "band_pct", defaultBandPct)
+	cooldown := time.Duration(common.SafeGetInt(a.config.CustomSettings, "cooldown_minutes",
+		int(defaultCooldown/time.Minute))) * time.Minute
+
+	// Снимок обновляется провайдером в фоне; замер — не чаще interval по времени снимка
+	snapshot, ok := a.deps.Provider.Snapshot(symbol)
+	if !ok {
+		return analysis.Signal{}, false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, exists := a.states[symbol]
+	if !exists {
+		state = &symbolState{}
+		a.states[symbol] = state
+	}
+	if snapshot.Timestamp.Sub(state.lastSample) < interval {
+		return analysis.Signal{}, false
+	}
+	state.lastSample = snapshot.Timestamp
+
+	// Стакан не доходит до границы полосы — дисбаланс неизвестен, серия замеров прерывается
+	band, ok := snapshot.Band(bandPct)
+	if !ok || !band.Covered {
+		state.imbalances = nil
+		return analysis.Signal{}, false
+	}
+
+	state.imbalances = append(state.imbalances, band.Imbalance)
+	if len(state.imbalances) > samples {
+		state.imbalances = state.imbalances[len(state.imbalances)-samples:]
+	}
+	if len(state.imbalances) < samples || time.Since(state.lastSignal) < cooldown {
+		return analysis.Signal{}, false
+	}
+
+	// Все последние замеры — экстремальный дисбаланс в одну сторону
+	sign := math.Copysign(1, band.Imbalance)
+	minAbs := math.Abs(band.Imbalance)
+	for _, imb := range state.imbalances {
+		if math.Copysign(1, imb) != sign || math.Abs(imb) < threshold {
+			return analysis.Signal{}, false
+		}
+		minAbs = math.Min(minAbs, math.Abs(imb))
+	}
+
+	signal := a.createSignal(snapshot, band, minAbs, threshold, samples)
+	if signal.Confidence < a.config.MinConfidence {
+		return analysis.Signal{}, false
+	}
+	state.lastSignal = time.Now()
+	return signal, true
+}
+
+// createSignal строит сигнал дисбаланса; minAbs — минимальный дисбаланс среди замеров
+func (a *LiquidityAnalyzer) createSignal(snapshot liq.Snapshot, band liq.Band, minAbs, threshold float64, samples int) analysis.Signal {
+	// Перевес покупателей — давление вверх, продавцов — вниз
+	direction := "growth"
+	vacuum := snapshot.VacuumUpPct
+	if band.Imbalance < 0 {
+		direction = "fall"
+		vacuum = snapshot.VacuumDownPct
+	}
+
+	confidence := 60.0
+	if threshold < 1 {
+		confidence += 30 * (minAbs - threshold) / (1 - threshold)
+	}
+	// Вакуум по ходу дисбаланса — цене нечему мешать
+	if vacuum >= 0.3 {
+		confidence += 10
+	}
+	confidence = math.Min(math.Round(confidence), 100)
+
+	tags := []string{"orderbook", "imbalance"}
+	tags = append(tags, snapshot.Warnings()...)
+
+	return analysis.Signal{
+		ID:            uuid.New().String(),
+		Symbol:        snapshot.Symbol,
+		Type:          "orderbook_imbalance",
+		Direction:     direction,
+		ChangePercent: 0,
+		Confidence:    confidence,
+		DataPoints:    samples,
+		StartPrice:    snapshot.Mid,
+		EndPrice:      snapshot.Mid,
+		Timestamp:     time.Now(),
+		Metadata: analysis.Metadata{
+			Strategy: "liquidity_analyzer",
+			Tags:     tags,
+			Indicators: map[string]float64{
+				"imbalance":      band.Imbalance,
+				"bid_usd":        band.BidUSD,
+				"ask_usd":        band.AskUSD,
+				"cost_up_1pct":   snapshot.CostUp1Pct,
+				"cost_down_1pct": snapshot.CostDown1Pct,
+			},
+			Custom: map[string]interface{}{
+				"band_pct":  band.RangePct,
+				"samples":   samples,
+				"liquidity": snapshot.ToMap(),
+			},
+		},
+	}
+}
+
+func (a *LiquidityAnalyzer) updateStats(startTime time.Time) {
+	a.statsMu.Lock()
+	defer a.statsMu.Unlock()
+
+	a.stats.TotalCalls++
+	a.stats.SuccessCount++
+	a.stats.TotalTime += time.Since(startTime)
+	a.stats.AverageTime = a.stats.TotalTime / time.Duration(a.stats.TotalCalls)
+	a.stats.LastCallTime = time.Now()
+}
+
+// GetConfig возвращает конфигурацию
+func (a *LiquidityAnalyzer) GetConfig() common.AnalyzerConfig {
+	return a.config
+}
+
+// GetStats возвращает статистику
+func (a *LiquidityAnalyzer) GetStats() common.AnalyzerStats {
+	a.statsMu.RLock()
+	defer a.statsMu.RUnlock()
+	return a.stats
+}
+
+// Name возвращает имя анализатора
+func (a *LiquidityAnalyzer) Name() string {
+	return "liquidity"
+}
+
+// Version возвращает версию анализатора
+func (a *LiquidityAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// Supports проверяет, поддерживается ли символ
+func (a *LiquidityAnalyzer) Supports(symbol string) bool {
+	return true
+}
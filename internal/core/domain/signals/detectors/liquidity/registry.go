@@ -0,0 +1,41 @@
+// internal/core/domain/signals/detectors/liquidity/registry.go
+package liquidity
+
+import (
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+)
+
+// Schema - настройки LiquidityAnalyzer (LIQUIDITY_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "sample_interval_sec", Type: common.SettingInt, Default: 60, Range: &common.Range{Min: 5, Max: 3600},
+		Description: "Интервал снятия стакана по символу, сек"},
+	{Key: "imbalance_threshold", Type: common.SettingFloat, Default: defaultImbalanceThreshold, Range: &common.Range{Min: 0.05, Max: 1},
+		Description: "Порог дисбаланса bid/ask"},
+	{Key: "persistence_samples", Type: common.SettingInt, Default: defaultPersistenceSamples, Range: &common.Range{Min: 1, Max: 50},
+		Description: "Сколько замеров подряд дисбаланс должен держаться"},
+	{Key: "band_pct", Type: common.SettingFloat, Default: defaultBandPct, Range: &common.Range{Min: 0.1, Max: 10},
+		Description: "Полоса стакана от mid-цены, %"},
+	{Key: "cooldown_minutes", Type: common.SettingInt, Default: 30, Range: &common.Range{Min: 0, Max: 1440},
+		Description: "Пауза между сигналами по символу, мин"},
+	{Key: "min_volume_24h", Type: common.SettingFloat, Default: defaultMinVolume24h, Range: &common.Range{Min: 0, Max: 1e12},
+		Description: "Минимальный оборот за 24ч, $"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:          "liquidity",
+		Description:   "Дисбаланс стакана и вакуум ликвидности",
+		Requires:      []string{analyzers.DependencyOrderBook},
+		Weight:        0.5,
+		MinConfidence: 60.0,
+		MinDataPoints: 1,
+		Settings:      Schema,
+		New: func(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+			return NewLiquidityAnalyzer(config, Dependencies{
+				Provider:  ctx.LiquidityProvider(),
+				Publisher: ctx.Publisher,
+			}), nil
+		},
+	})
+}
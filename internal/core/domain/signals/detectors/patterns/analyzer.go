@@ -0,0 +1,329 @@
+// internal/core/domain/signals/detectors/patterns/analyzer.go
+package patterns
+
+import (
+	pat "crypto-exchange-screener-bot/internal/core/domain/analysis/patterns"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/sr_zones"
+	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	sr_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/sr_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPeriods      = "15m,1h,4h"
+	defaultHistoryLimit = 30
+)
+
+// Dependencies зависимости для PatternAnalyzer
+type Dependencies struct {
+	CandleSystem  *candle.CandleSystem
+	Store         *pat.Store                // общее хранилище, из которого CounterAnalyzer берёт паттерны
+	SRZoneStorage *sr_storage.SRZoneStorage // опционально: алерты "паттерн у зоны"
+	Publisher     *analyzers.Publisher      // опционально: доставка EventPatternAtZone через фильтры движка
+}
+
+// PatternAnalyzer распознает свечные паттерны на закрытых свечах и
+// сообщает о разворотных паттернах у сильных зон поддержки/сопротивления
+type PatternAnalyzer struct {
+	config   common.AnalyzerConfig
+	deps     Dependencies
+	detector *pat.Detector
+
+	statsMu sync.RWMutex
+	stats   common.AnalyzerStats
+
+	// closed — последние обработанные закрытые свечи по symbol:period
+	closed *common.ClosedCandles
+}
+
+// NewPatternAnalyzer создает анализатор свечных паттернов
+func NewPatternAnalyzer(config common.AnalyzerConfig, deps Dependencies) *PatternAnalyzer {
+	if deps.Store == nil {
+		deps.Store = pat.NewStore()
+	}
+
+	detectorConfig := pat.DefaultConfig()
+	detectorConfig.TrendLookback = common.SafeGetInt(config.CustomSettings, "trend_lookback", detectorConfig.TrendLookback)
+
+	logger.Info("✅ [PatternAnalyzer] Создан анализатор свечных паттернов (периоды: %s, алерты у зон: %v)",
+		strings.Join(common.Periods(config, defaultPeriods), ","), deps.SRZoneStorage != nil && common.SafeGetBool(config.CustomSettings, "zone_alerts", true))
+
+	return &PatternAnalyzer{
+		config:   config,
+		deps:     deps,
+		detector: pat.NewDetector(detectorConfig),
+		closed:   common.NewClosedCandles(),
+	}
+}
+
+// Analyze проверяет новые закрытые свечи по каждому символу и периоду
+func (a *PatternAnalyzer) Analyze(data []storage.PriceDataInterface, config common.AnalyzerConfig) ([]analysis.Signal, error) {
+	startTime := time.Now()
+	defer a.updateStats(startTime)
+
+	a.config = config
+	if a.deps.CandleSystem == nil {
+		return nil, nil
+	}
+
+	var signals []analysis.Signal
+	for _, point := range data {
+		for _, period := range common.Periods(config, defaultPeriods) {
+			found, err := a.analyzeSymbolPeriod(point.GetSymbol(), period)
+			if err != nil {
+				logger.Debug("⚠️ [PatternAnalyzer] %s/%s: %v", point.GetSymbol(), period, err)
+				continue
+			}
+			signals = append(signals, found...)
+		}
+	}
+	return signals, nil
+}
+
+// analyzeSymbolPeriod запускает детектор, только если закрылась новая свеча
+func (a *PatternAnalyzer) analyzeSymbolPeriod(symbol, period string) ([]analysis.Signal, error) {
+	if !a.closed.Due(symbol, period, time.Now()) {
+		return nil, nil
+	}
+
+	limit := common.SafeGetInt(a.config.CustomSettings, "history_limit", defaultHistoryLimit)
+	history, err := a.deps.CandleSystem.GetHistory(symbol, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]storage.CandleInterface, 0, len(history))
+	for _, c := range history {
+		if c != nil && c.IsClosedFlag && c.IsRealFlag && c.Close > 0 {
+			closed = append(closed, c)
+		}
+	}
+	if len(closed) == 0 {
+		return nil, nil
+	}
+
+	if !a.closed.Mark(symbol, period, closed[len(closed)-1].GetStartTime()) {
+		return nil, nil
+	}
+
+	matches := a.detector.Detect(symbol, period, closed)
+
+	// Паттерн описывает последнюю закрытую свечу — актуален до закрытия следующей
+	a.deps.Store.Set(symbol, period, matches, periodPkg.PeriodToDuration(period))
+
+	if len(matches) == 0 || a.deps.SRZoneStorage == nil || !common.SafeGetBool(a.config.CustomSettings, "zone_alerts", true) {
+		return nil, nil
+	}
+
+	zones, zonePeriod := a.loadZones(symbol, period)
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	var signals []analysis.Signal
+	for _, m := range matches {
+		zone, ok := a.findZone(m, zones)
+		if !ok {
+			continue
+		}
+		signal := a.createSignal(m, zone, zonePeriod)
+		if signal.Confidence < a.config.MinConfidence {
+			continue
+		}
+		if !a.publishZoneEvent(signal, m, zone, zonePeriod) {
+			continue
+		}
+		signals = append(signals, signal)
+	}
+	return signals, nil
+}
+
+// loadZones возвращает зоны S/R периода паттерна, а если их нет — ближайшего старшего периода
+func (a *PatternAnalyzer) loadZones(symbol, period string) ([]sr_zones.Zone, string) {
+	for _, p := range append([]string{period}, zoneFallbackPeriods(period)...) {
+		zones, err := a.deps.SRZoneStorage.GetZones(symbol, p)
+		if err != nil {
+			logger.Debug("⚠️ [PatternAnalyzer] нет зон %s/%s: %v", symbol, p, err)
+			continue
+		}
+		if len(zones) > 0 {
+			return zones, p
+		}
+	}
+	return nil, ""
+}
+
+// findZone ищет самую сильную зону, которой коснулся паттерн: бычий — поддержку,
+// медвежий — сопротивление. Закрытие должно остаться по "правильную" сторону зоны.
+func (a *PatternAnalyzer) findZone(m pat.Match, zones []sr_zones.Zone) (sr_zones.Zone, bool) {
+	var wantType sr_zones.ZoneType
+	switch m.Bias {
+	case pat.BiasBullish:
+		wantType = sr_zones.ZoneTypeSupport
+	case pat.BiasBearish:
+		wantType = sr_zones.ZoneTypeResistance
+	default:
+		return sr_zones.Zone{}, false
+	}
+
+	tolerance := common.SafeGetFloat(a.config.CustomSettings, "zone_tolerance_pct", 0.3) / 100
+	minStrength := common.SafeGetFloat(a.config.CustomSettings, "min_zone_strength", 50)
+
+	var best sr_zones.Zone
+	found := false
+	for _, z := range zones {
+		if z.Type != wantType || z.Strength < minStrength {
+			continue
+		}
+		low, high := z.PriceLow*(1-tolerance), z.PriceHigh*(1+tolerance)
+		if m.Low > high || m.High < low {
+			continue
+		}
+		if wantType == sr_zones.ZoneTypeSupport && m.Close < low {
+			continue
+		}
+		if wantType == sr_zones.ZoneTypeResistance && m.Close > high {
+			continue
+		}
+		if !found || z.Strength > best.Strength {
+			best, found = z, true
+		}
+	}
+	return best, found
+}
+
+// createSignal строит сигнал "паттерн у зоны"
+func (a *PatternAnalyzer) createSignal(m pat.Match, zone sr_zones.Zone, zonePeriod string) analysis.Signal {
+	direction := "fall"
+	if m.Bias == pat.BiasBullish {
+		direction = "growth"
+	}
+
+	periodMinutes, err := periodPkg.StringToMinutes(m.Period)
+	if err != nil {
+		periodMinutes = periodPkg.DefaultMinutes
+	}
+
+	// Чем сильнее зона и длиннее паттерн, тем выше уверенность
+	confidence := math.Min(40+zone.Strength*0.4+float64(m.Candles-1)*5, 100)
+
+	return analysis.Signal{
+		ID:         uuid.New().String(),
+		Symbol:     m.Symbol,
+		Type:       "candle_pattern",
+		Direction:  direction,
+		Period:     periodMinutes,
+		Confidence: confidence,
+		DataPoints: m.Candles,
+		StartPrice: zone.PriceCenter,
+		EndPrice:   m.Close,
+		Timestamp:  time.Now(),
+		Metadata: analysis.Metadata{
+			Strategy: "pattern_analyzer",
+			Tags:     []string{"candle_pattern", m.Tag(), "sr_zone:" + string(zone.Type), m.Period},
+			Patterns: []string{string(m.Name)},
+			Custom: map[string]interface{}{
+				"period_string": m.Period,
+				"pattern":       string(m.Name),
+				"candle_time":   m.CandleTime,
+				"zone_type":     string(zone.Type),
+				"zone_period":   zonePeriod,
+				"zone_low":      zone.PriceLow,
+				"zone_high":     zone.PriceHigh,
+				"zone_strength": zone.Strength,
+			},
+		},
+	}
+}
+
+// publishZoneEvent отправляет событие для доставки подписанным пользователям;
+// false — сигнал отброшен фильтрами
+func (a *PatternAnalyzer) publishZoneEvent(signal analysis.Signal, m pat.Match, zone sr_zones.Zone, zonePeriod string) bool {
+	direction := "fall"
+	if m.Bias == pat.BiasBullish {
+		direction = "growth"
+	}
+
+	event := types.Event{
+		Type:   types.EventPatternAtZone,
+		Source: "pattern_analyzer",
+		Data: types.PatternZoneData{
+			Symbol:       m.Symbol,
+			Period:       m.Period,
+			Pattern:      string(m.Name),
+			PatternTitle: m.Name.Title(),
+			Direction:    direction,
+			Price:        m.Close,
+			ZoneType:     string(zone.Type),
+			ZonePeriod:   zonePeriod,
+			ZoneLow:      zone.PriceLow,
+			ZoneHigh:     zone.PriceHigh,
+			ZoneStrength: zone.Strength,
+			ZoneTouches:  zone.TouchCount,
+			Timestamp:    time.Now(),
+		},
+		Timestamp: time.Now(),
+	}
+
+	if !a.deps.Publisher.Publish(signal, event) {
+		return false
+	}
+	logger.Debug("🕯️ [PatternAnalyzer] %s/%s: %s у зоны %s (сила %.0f)",
+		m.Symbol, m.Period, m.Name, zone.Type, zone.Strength)
+	return true
+}
+
+func (a *PatternAnalyzer) updateStats(startTime time.Time) {
+	a.statsMu.Lock()
+	defer a.statsMu.Unlock()
+
+	a.stats.TotalCalls++
+	a.stats.SuccessCount++
+	a.stats.TotalTime += time.Since(startTime)
+	a.stats.AverageTime = a.stats.TotalTime / time.Duration(a.stats.TotalCalls)
+	a.stats.LastCallTime = time.Now()
+}
+
+// GetStore возвращает хранилище найденных паттернов
+func (a *PatternAnalyzer) GetStore() *pat.Store {
+	return a.deps.Store
+}
+
+// GetConfig возвращает конфигурацию
+func (a *PatternAnalyzer) GetConfig() common.AnalyzerConfig {
+	return a.config
+}
+
+// GetStats возвращает статистику
+func (a *PatternAnalyzer) GetStats() common.AnalyzerStats {
+	a.statsMu.RLock()
+	defer a.statsMu.RUnlock()
+	return a.stats
+}
+
+// Name возвращает имя анализатора
+func (a *PatternAnalyzer) Name() string {
+	return "patterns"
+}
+
+// Version возвращает версию анализатора
+func (a *PatternAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// Supports проверяет, поддерживается ли символ
+func (a *PatternAnalyzer) Supports(symbol string) bool {
+	return true
+}
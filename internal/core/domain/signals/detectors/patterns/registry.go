@@ -0,0 +1,46 @@
+// internal/core/domain/signals/detectors/patterns/registry.go
+package patterns
+
+import (
+	pat "crypto-exchange-screener-bot/internal/core/domain/analysis/patterns"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+)
+
+// Schema - настройки PatternAnalyzer (PATTERN_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "periods", Type: common.SettingString, Default: defaultPeriods,
+		Description: "Периоды свечей через запятую"},
+	{Key: "history_limit", Type: common.SettingInt, Default: defaultHistoryLimit, Range: &common.Range{Min: 10, Max: 500},
+		Description: "Сколько закрытых свечей загружать"},
+	{Key: "trend_lookback", Type: common.SettingInt, Default: 5, Range: &common.Range{Min: 2, Max: 50},
+		Description: "Свечей для определения тренда перед разворотным паттерном"},
+	{Key: "zone_alerts", Type: common.SettingBool, Default: true,
+		Description: "Алерты о разворотных паттернах у зон S/R"},
+	{Key: "zone_tolerance_pct", Type: common.SettingFloat, Default: 0.3, Range: &common.Range{Min: 0, Max: 5},
+		Description: "Допуск касания зоны в процентах"},
+	{Key: "min_zone_strength", Type: common.SettingFloat, Default: 50.0, Range: &common.Range{Min: 0, Max: 100},
+		Description: "Минимальная сила зоны для алерта"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:          "patterns",
+		Description:   "Свечные паттерны по закрытым свечам и алерты у зон S/R",
+		Requires:      []string{analyzers.DependencyCandleSystem},
+		Weight:        0.4,
+		MinConfidence: 60.0,
+		MinDataPoints: 1,
+		Settings:      Schema,
+		New: func(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+			// Хранилище общее: CounterAnalyzer берет из него паттерны
+			ctx.PatternStore = pat.NewStore()
+			return NewPatternAnalyzer(config, Dependencies{
+				CandleSystem:  ctx.CandleSystem,
+				Store:         ctx.PatternStore,
+				SRZoneStorage: ctx.SRZoneStorage,
+				Publisher:     ctx.Publisher,
+			}), nil
+		},
+	})
+}
@@ -0,0 +1,14 @@
+// internal/core/domain/signals/detectors/patterns/utils.go
+package patterns
+
+// zoneFallbackPeriods возвращает периоды старше primaryPeriod: зоны пересчитываются
+// при закрытии свечи, и для младшего периода их может еще не быть
+func zoneFallbackPeriods(primaryPeriod string) []string {
+	ordered := []string{"1m", "5m", "15m", "30m", "1h", "4h", "1d"}
+	for i, p := range ordered {
+		if p == primaryPeriod {
+			return ordered[i+1:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// internal/core/domain/signals/detectors/publisher.go
+package analyzers
+
+import (
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/filters"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"sync"
+)
+
+// Publisher - общий путь доставки сигналов анализаторов: сигнал проходит цепочку
+// фильтров движка (blacklist, min_turnover, cooldown...), и только после этого
+// его событие доставки уходит в EventBus. Анализатор возвращает движку лишь
+// пропущенные сигналы, поэтому журнал видит то же, что получили пользователи.
+//
+// nil-Publisher (бэктест) ничего не фильтрует и не публикует.
+type Publisher struct {
+	bus types.EventBus
+
+	mu    sync.RWMutex
+	chain *filters.Chain
+}
+
+// NewPublisher создает публикатор поверх шины событий
+func NewPublisher(bus types.EventBus) *Publisher {
+	return &Publisher{bus: bus}
+}
+
+// SetChain устанавливает цепочку фильтров (nil - сигналы не фильтруются)
+func (p *Publisher) SetChain(chain *filters.Chain) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.chain = chain
+}
+
+// Allow пропускает сигнал через цепочку фильтров. Для анализаторов, у которых
+// нет собственного события доставки; фильтры с состоянием (cooldown) запоминают сигнал.
+func (p *Publisher) Allow(signal analysis.Signal) bool {
+	if p == nil {
+		return true
+	}
+
+	p.mu.RLock()
+	chain := p.chain
+	p.mu.RUnlock()
+
+	return chain == nil || len(chain.Apply([]analysis.Signal{signal})) == 1
+}
+
+// Publish пропускает сигнал через цепочку фильтров и публикует событие доставки.
+// false - сигнал отброшен фильтром или событие не принято шиной.
+func (p *Publisher) Publish(signal analysis.Signal, event types.Event) bool {
+	if p == nil {
+		return true
+	}
+	if !p.Allow(signal) {
+		logger.Debug("🔇 Сигнал %s %s %s отброшен фильтрами", signal.Symbol, signal.Type, signal.Direction)
+		return false
+	}
+	if err := p.bus.Publish(event); err != nil {
+		logger.Error("❌ Ошибка публикации %s %s: %v", event.Type, signal.Symbol, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,284 @@
+// internal/core/domain/signals/detectors/range_breakout/analyzer.go
+package range_breakout
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultHistoryDays     = 1000
+	defaultCooldownMinutes = 240
+
+	// seedQueueSize — сколько символов может ждать загрузки истории
+	seedQueueSize = 1024
+	// seedRateLimit — пауза между запросами GetKline (Bybit public: 120 req/min)
+	seedRateLimit = 600 * time.Millisecond
+	// seedRetryAfter — повторная попытка засева после ошибки
+	seedRetryAfter = 10 * time.Minute
+)
+
+// confidenceByHorizon — уверенность сигнала по самому длинному пробитому горизонту
+var confidenceByHorizon = map[ranges.Horizon]float64{
+	ranges.Horizon1d:  40,
+	ranges.Horizon7d:  55,
+	ranges.Horizon30d: 70,
+	ranges.Horizon90d: 80,
+	ranges.HorizonAll: 90,
+}
+
+// Dependencies зависимости для RangeBreakoutAnalyzer
+type Dependencies struct {
+	Klines    KlineSource
+	Publisher *analyzers.Publisher // опционально: доставка EventRangeBreakout через фильтры движка
+}
+
+// RangeBreakoutAnalyzer ведет скользящие максимумы/минимумы за 1/7/30/90 дней
+// и всю загруженную историю и сигнализирует, когда цена выходит за них.
+// История засевается дневными свечами GetKline в фоне, по одному символу.
+type RangeBreakoutAnalyzer struct {
+	config  common.AnalyzerConfig
+	deps    Dependencies
+	tracker *ranges.Tracker
+
+	// historyDays читается один раз в конструкторе: seedLoop работает в своей
+	// горутине, а Analyze заменяет config без блокировки
+	historyDays int
+
+	statsMu sync.RWMutex
+	stats   common.AnalyzerStats
+
+	seedOnce  sync.Once
+	seedQueue chan string
+	seedMu    sync.Mutex
+	pending   map[string]bool
+	failedAt  map[string]time.Time
+}
+
+// NewRangeBreakoutAnalyzer создает анализатор пробоев диапазона
+func NewRangeBreakoutAnalyzer(config common.AnalyzerConfig, deps Dependencies) *RangeBreakoutAnalyzer {
+	cooldown := time.Duration(common.SafeGetInt(config.CustomSettings, "cooldown_minutes", defaultCooldownMinutes)) * time.Minute
+	minDistance := common.SafeGetFloat(config.CustomSettings, "min_distance_pct", 0)
+	historyDays := common.SafeGetInt(config.CustomSettings, "history_days", defaultHistoryDays)
+
+	logger.Info("✅ [RangeBreakoutAnalyzer] Создан анализатор пробоев диапазона (история: %d дн., пауза: %v)",
+		historyDays, cooldown)
+
+	return &RangeBreakoutAnalyzer{
+		config:      config,
+		deps:        deps,
+		tracker:     ranges.NewTracker(cooldown, minDistance),
+		historyDays: historyDays,
+		seedQueue:   make(chan string, seedQueueSize),
+		pending:     make(map[string]bool),
+		failedAt:    make(map[string]time.Time),
+	}
+}
+
+// Analyze сравнивает текущую цену с границами диапазонов символа
+func (a *RangeBreakoutAnalyzer) Analyze(data []storage.PriceDataInterface, config common.AnalyzerConfig) ([]analysis.Signal, error) {
+	startTime := time.Now()
+	defer a.updateStats(startTime)
+
+	a.config = config
+	if a.deps.Klines == nil {
+		return nil, nil
+	}
+
+	var signals []analysis.Signal
+	for _, point := range data {
+		symbol := point.GetSymbol()
+		if !a.tracker.Seeded(symbol) {
+			a.requestSeed(symbol)
+			continue
+		}
+
+		breaks := a.tracker.Observe(symbol, point.GetPrice(), point.GetTimestamp())
+		if len(breaks) == 0 {
+			continue
+		}
+
+		signal := a.createSignal(breaks)
+		if signal.Confidence < a.config.MinConfidence {
+			continue
+		}
+		if !a.publishBreakout(signal, breaks) {
+			continue
+		}
+		signals = append(signals, signal)
+	}
+	return signals, nil
+}
+
+// requestSeed ставит символ в очередь загрузки дневной истории
+func (a *RangeBreakoutAnalyzer) requestSeed(symbol string) {
+	a.seedOnce.Do(func() { go a.seedLoop() })
+
+	a.seedMu.Lock()
+	defer a.seedMu.Unlock()
+	if a.pending[symbol] {
+		return
+	}
+	if failed, ok := a.failedAt[symbol]; ok && time.Since(failed) < seedRetryAfter {
+		return
+	}
+	select {
+	case a.seedQueue <- symbol:
+		a.pending[symbol] = true
+	default:
+		// Очередь заполнена — символ попадет в нее на следующем цикле анализа
+	}
+}
+
+// seedLoop загружает историю символов по очереди с паузой между запросами
+func (a *RangeBreakoutAnalyzer) seedLoop() {
+	for symbol := range a.seedQueue {
+		bars, err := a.deps.Klines(symbol, a.historyDays)
+
+		a.seedMu.Lock()
+		delete(a.pending, symbol)
+		if err != nil || len(bars) == 0 {
+			a.failedAt[symbol] = time.Now()
+		} else {
+			delete(a.failedAt, symbol)
+		}
+		a.seedMu.Unlock()
+
+		if err != nil {
+			logger.Debug("⚠️ [RangeBreakoutAnalyzer] %s: не удалось загрузить дневные свечи: %v", symbol, err)
+		} else if len(bars) > 0 {
+			a.tracker.Seed(symbol, bars, time.Now())
+			logger.Debug("📏 [RangeBreakoutAnalyzer] %s: загружено %d дневных свечей", symbol, len(bars))
+		}
+		time.Sleep(seedRateLimit)
+	}
+}
+
+// createSignal строит сигнал по самому длинному пробитому горизонту
+func (a *RangeBreakoutAnalyzer) createSignal(breaks []ranges.Breakout) analysis.Signal {
+	longest := breaks[len(breaks)-1]
+
+	direction := "growth"
+	if longest.Kind == ranges.KindLow {
+		direction = "fall"
+	}
+
+	// Для всей истории период сигнала — глубина загруженной истории
+	days := longest.Horizon.Days()
+	if days == 0 {
+		days = a.historyDays
+	}
+
+	horizons := make([]string, 0, len(breaks))
+	for _, b := range breaks {
+		horizons = append(horizons, string(b.Horizon))
+	}
+
+	return analysis.Signal{
+		ID:            uuid.New().String(),
+		Symbol:        longest.Symbol,
+		Type:          "range_breakout",
+		Direction:     direction,
+		ChangePercent: longest.DistancePct,
+		Period:        days * 24 * 60,
+		Confidence:    confidenceByHorizon[longest.Horizon],
+		DataPoints:    1,
+		StartPrice:    longest.Previous.Price,
+		EndPrice:      longest.Price,
+		Timestamp:     time.Now(),
+		Metadata: analysis.Metadata{
+			Strategy: "range_breakout_analyzer",
+			Tags:     []string{"range_breakout", fmt.Sprintf("%s:%s", longest.Kind, longest.Horizon)},
+			Custom: map[string]interface{}{
+				"horizon":         string(longest.Horizon),
+				"horizons":        horizons,
+				"kind":            string(longest.Kind),
+				"prev_extreme":    longest.Previous.Price,
+				"prev_extreme_at": longest.Previous.Time,
+				"distance_pct":    longest.DistancePct,
+			},
+		},
+	}
+}
+
+// publishBreakout отправляет событие для доставки пользователям, подписанным на горизонты;
+// false — сигнал отброшен фильтрами
+func (a *RangeBreakoutAnalyzer) publishBreakout(signal analysis.Signal, breaks []ranges.Breakout) bool {
+	longest := breaks[len(breaks)-1]
+	data := types.RangeBreakoutData{
+		Symbol:    longest.Symbol,
+		Kind:      string(longest.Kind),
+		Price:     longest.Price,
+		Breaks:    make([]types.RangeBreak, 0, len(breaks)),
+		Timestamp: longest.Time,
+	}
+	for _, b := range breaks {
+		data.Breaks = append(data.Breaks, types.RangeBreak{
+			Horizon:       string(b.Horizon),
+			PrevPrice:     b.Previous.Price,
+			PrevTime:      b.Previous.Time,
+			DistancePct:   b.DistancePct,
+			DaysSincePrev: b.DaysSincePrevious(),
+		})
+	}
+
+	event := types.Event{
+		Type:      types.EventRangeBreakout,
+		Source:    "range_breakout_analyzer",
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	if !a.deps.Publisher.Publish(signal, event) {
+		return false
+	}
+	logger.Debug("📏 [RangeBreakoutAnalyzer] %s", longest)
+	return true
+}
+
+func (a *RangeBreakoutAnalyzer) updateStats(startTime time.Time) {
+	a.statsMu.Lock()
+	defer a.statsMu.Unlock()
+
+	a.stats.TotalCalls++
+	a.stats.SuccessCount++
+	a.stats.TotalTime += time.Since(startTime)
+	a.stats.AverageTime = a.stats.TotalTime / time.Duration(a.stats.TotalCalls)
+	a.stats.LastCallTime = time.Now()
+}
+
+// GetConfig возвращает конфигурацию
+func (a *RangeBreakoutAnalyzer) GetConfig() common.AnalyzerConfig {
+	return a.config
+}
+
+// GetStats возвращает статистику
+func (a *RangeBreakoutAnalyzer) GetStats() common.AnalyzerStats {
+	a.statsMu.RLock()
+	defer a.statsMu.RUnlock()
+	return a.stats
+}
+
+// Name возвращает имя анализатора
+func (a *RangeBreakoutAnalyzer) Name() string {
+	return "range_breakout"
+}
+
+// Version возвращает версию анализатора
+func (a *RangeBreakoutAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// Supports проверяет, поддерживается ли символ
+func (a *RangeBreakoutAnalyzer) Supports(symbol string) bool {
+	return true
+}
@@ -0,0 +1,42 @@
+// internal/core/domain/signals/detectors/range_breakout/klines.go
+package range_breakout
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	bybit "crypto-exchange-screener-bot/internal/infrastructure/api/exchanges/bybit"
+	"time"
+)
+
+// KlineSource загружает дневные свечи символа (от старых к новым)
+type KlineSource func(symbol string, limit int) ([]ranges.DailyBar, error)
+
+type bybitClientProvider interface {
+	GetBybitClient() *bybit.BybitClient
+}
+
+// newKlineSource строит загрузчик дневных свечей поверх GetKline клиента Bybit
+func newKlineSource(fetcher interface{}) (KlineSource, bool) {
+	provider, ok := fetcher.(bybitClientProvider)
+	if !ok {
+		return nil, false
+	}
+	return func(symbol string, limit int) ([]ranges.DailyBar, error) {
+		client := provider.GetBybitClient()
+		if client == nil {
+			return nil, errNoClient
+		}
+		klines, err := client.GetKline(symbol, "D", limit)
+		if err != nil {
+			return nil, err
+		}
+		bars := make([]ranges.DailyBar, 0, len(klines))
+		for _, k := range klines {
+			bars = append(bars, ranges.DailyBar{
+				Start: time.UnixMilli(k.StartTime).UTC(),
+				High:  k.High,
+				Low:   k.Low,
+			})
+		}
+		return bars, nil
+	}, true
+}
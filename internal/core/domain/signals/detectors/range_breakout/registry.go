@@ -0,0 +1,41 @@
+// internal/core/domain/signals/detectors/range_breakout/registry.go
+package range_breakout
+
+import (
+	"fmt"
+
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+)
+
+// Schema - настройки RangeBreakoutAnalyzer (RANGE_BREAKOUT_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "history_days", Type: common.SettingInt, Default: defaultHistoryDays, Range: &common.Range{Min: 90, Max: 1000},
+		Description: "Дневных свечей истории для засева (горизонт \"all\")"},
+	{Key: "cooldown_minutes", Type: common.SettingInt, Default: defaultCooldownMinutes, Range: &common.Range{Min: 1, Max: 1440},
+		Description: "Пауза между алертами по одному горизонту и границе, мин"},
+	{Key: "min_distance_pct", Type: common.SettingFloat, Default: 0.0, Range: &common.Range{Min: 0, Max: 10},
+		Description: "Минимальный выход за прежний экстремум, %"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:          "range_breakout",
+		Description:   "Пробои максимумов/минимумов за 1/7/30/90 дней и всей истории",
+		Requires:      []string{analyzers.DependencyMarketFetcher},
+		Weight:        0.5,
+		MinConfidence: 40.0,
+		MinDataPoints: 1,
+		Settings:      Schema,
+		New: func(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+			klines, ok := newKlineSource(ctx.PriceFetcher)
+			if !ok {
+				return nil, fmt.Errorf("фетчер цен не умеет загружать дневные свечи")
+			}
+			return NewRangeBreakoutAnalyzer(config, Dependencies{
+				Klines:    klines,
+				Publisher: ctx.Publisher,
+			}), nil
+		},
+	})
+}
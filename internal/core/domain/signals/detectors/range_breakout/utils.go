@@ -0,0 +1,6 @@
+// internal/core/domain/signals/detectors/range_breakout/utils.go
+package range_breakout
+
+import "errors"
+
+var errNoClient = errors.New("клиент Bybit не инициализирован")
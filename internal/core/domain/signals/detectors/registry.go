@@ -0,0 +1,240 @@
+// internal/core/domain/signals/detectors/registry.go
+package analyzers
+
+import (
+	div "crypto-exchange-screener-bot/internal/core/domain/analysis/divergence"
+	liq "crypto-exchange-screener-bot/internal/core/domain/analysis/liquidity"
+	pat "crypto-exchange-screener-bot/internal/core/domain/analysis/patterns"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	returns_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/returns_storage"
+	sr_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/sr_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Имена зависимостей, которые анализатор может объявить в Definition.Requires
+const (
+	DependencyStorage       = "storage"        // хранилище цен
+	DependencyEventBus      = "event_bus"      // шина событий
+	DependencyCandleSystem  = "candle_system"  // свечная система
+	DependencyMarketFetcher = "market_fetcher" // фетчер биржи (OI, фандинг, дельта)
+	DependencyOrderBook     = "order_book"     // фетчер умеет получать стакан
+	DependencySRZones       = "sr_zones"       // хранилище зон S/R
+	DependencyReturns       = "returns"        // хранилище распределений доходностей
+)
+
+// BuildContext - общие зависимости, из которых анализаторы создаются движком.
+// Один контекст проходит через все конструкторы по порядку, поэтому
+// анализатор может оставить в нём данные для следующих (DivergenceStore, PatternStore).
+type BuildContext struct {
+	Storage  storage.PriceStorageInterface
+	EventBus types.EventBus
+	// Publisher - доставка сигналов через цепочку фильтров движка (nil - бэктест)
+	Publisher     *Publisher
+	CandleSystem  *candle.CandleSystem
+	PriceFetcher  interface{}
+	SRZoneStorage *sr_storage.SRZoneStorage
+	// ReturnsStorage - окна доходностей по symbol+period (Redis)
+	ReturnsStorage *returns_storage.ReturnsStorage
+
+	// DivergenceStore заполняет DivergenceAnalyzer; CounterAnalyzer берёт из него теги
+	DivergenceStore *div.Store
+	// PatternStore заполняет PatternAnalyzer; CounterAnalyzer берёт из него паттерны
+	PatternStore *pat.Store
+
+	// Regime - текущий режим рынка (nil - классификатор выключен)
+	Regime regime.Source
+
+	// Now - часы анализаторов (nil - time.Now); бэктест подставляет время рынка
+	Now func() time.Time
+
+	liquidity *liq.Provider
+}
+
+// Has проверяет, доступна ли зависимость
+func (c *BuildContext) Has(dependency string) bool {
+	switch dependency {
+	case DependencyStorage:
+		return c.Storage != nil
+	case DependencyEventBus:
+		return c.EventBus != nil
+	case DependencyCandleSystem:
+		return c.CandleSystem != nil
+	case DependencyMarketFetcher:
+		return c.PriceFetcher != nil
+	case DependencyOrderBook:
+		_, ok := c.PriceFetcher.(liq.OrderBookFetcher)
+		return ok
+	case DependencySRZones:
+		return c.SRZoneStorage != nil
+	case DependencyReturns:
+		return c.ReturnsStorage != nil
+	}
+	return false
+}
+
+// LiquidityProvider создает (один раз) провайдер метрик стакана поверх фетчера цен
+func (c *BuildContext) LiquidityProvider() *liq.Provider {
+	if c.liquidity != nil {
+		return c.liquidity
+	}
+	fetcher, ok := c.PriceFetcher.(liq.OrderBookFetcher)
+	if !ok {
+		return nil
+	}
+	volumes, _ := c.PriceFetcher.(liq.Volume24hProvider)
+	c.liquidity = liq.NewProvider(fetcher, volumes)
+	return c.liquidity
+}
+
+// Definition - описание анализатора в реестре
+type Definition struct {
+	Name        string
+	Description string
+	// Requires - обязательные зависимости (Dependency*); без них анализатор не создается
+	Requires []string
+	// After - анализаторы, которые должны быть созданы раньше, если включены
+	After []string
+
+	Weight        float64
+	MinConfidence float64 // используется, если в конфигурации не задано
+	MinDataPoints int
+	Settings      common.SettingsSchema
+
+	New func(config common.AnalyzerConfig, ctx *BuildContext) (common.Analyzer, error)
+}
+
+// DefaultConfig возвращает конфигурацию анализатора по умолчанию
+func (d Definition) DefaultConfig() common.AnalyzerConfig {
+	return common.AnalyzerConfig{
+		Enabled:        true,
+		Weight:         d.Weight,
+		MinConfidence:  d.MinConfidence,
+		MinDataPoints:  d.MinDataPoints,
+		CustomSettings: d.Settings.Defaults(),
+	}
+}
+
+// BuildConfig собирает действующую конфигурацию из настроек пользователя.
+// unknown - ключи, которых нет в схеме (они отбрасываются).
+func (d Definition) BuildConfig(minConfidence float64, custom map[string]interface{}) (config common.AnalyzerConfig, unknown []string, err error) {
+	config = d.DefaultConfig()
+	if minConfidence > 0 {
+		config.MinConfidence = minConfidence
+	}
+
+	settings, unknown, err := d.Settings.Resolve(custom)
+	if err != nil {
+		return config, unknown, fmt.Errorf("%s: %w", d.Name, err)
+	}
+	config.CustomSettings = settings
+
+	if err := ValidateConfig(config); err != nil {
+		return config, unknown, fmt.Errorf("%s: %w", d.Name, err)
+	}
+	return config, unknown, nil
+}
+
+// MissingDependencies возвращает обязательные зависимости, которых нет в контексте
+func (d Definition) MissingDependencies(ctx *BuildContext) []string {
+	var missing []string
+	for _, dep := range d.Requires {
+		if !ctx.Has(dep) {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
+
+var (
+	registryMu  sync.RWMutex
+	definitions = make(map[string]Definition)
+)
+
+// Register добавляет анализатор в реестр. Вызывается из init() пакета анализатора;
+// повторная регистрация имени - ошибка программиста, поэтому panic.
+func Register(def Definition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if def.Name == "" || def.New == nil {
+		panic("analyzers: Register без имени или конструктора")
+	}
+	if _, exists := definitions[def.Name]; exists {
+		panic("analyzers: повторная регистрация анализатора " + def.Name)
+	}
+	definitions[def.Name] = def
+}
+
+// Lookup возвращает описание анализатора по имени
+func Lookup(name string) (Definition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	def, ok := definitions[name]
+	return def, ok
+}
+
+// RegisteredNames возвращает имена зарегистрированных анализаторов по алфавиту
+func RegisteredNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OrderedDefinitions возвращает анализаторы в порядке создания: каждый после
+// тех, что указаны в его After. Неизвестные имена в After игнорируются.
+func OrderedDefinitions() ([]Definition, error) {
+	names := RegisteredNames()
+
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[string]int, len(names))
+	ordered := make([]Definition, 0, len(names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		def, ok := Lookup(name)
+		if !ok {
+			return nil
+		}
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("циклическая зависимость анализаторов: %s", strings.Join(append(path, name), " → "))
+		}
+		state[name] = visiting
+		after := append([]string(nil), def.After...)
+		sort.Strings(after)
+		for _, dep := range after {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, def)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
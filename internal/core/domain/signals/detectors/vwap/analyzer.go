@@ -0,0 +1,276 @@
+// internal/core/domain/signals/detectors/vwap/analyzer.go
+package vwap
+
+import (
+	vw "crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDeviationSigma    = 2.0
+	defaultCrossBufferPct    = 0.1
+	defaultMinSessionMinutes = 30
+	defaultCooldownMinutes   = 60
+
+	// minSessionTicks — минимум тиков с приростом оборота для устойчивой σ
+	minSessionTicks = 20
+)
+
+// Dependencies зависимости для VWAPAnalyzer
+type Dependencies struct {
+	Tracker   *vw.Tracker          // VWAP из CandleEngine
+	Publisher *analyzers.Publisher // опционально: доставка EventVWAPSignal через фильтры движка
+}
+
+// symbolState — положение цены относительно VWAP на прошлой проверке
+type symbolState struct {
+	session   time.Time
+	side      int // +1 выше VWAP, −1 ниже, 0 — ещё не определено
+	band      int // +1 выше верхней полосы, −1 ниже нижней, 0 — внутри
+	lastAlert map[string]time.Time
+}
+
+// VWAPAnalyzer сравнивает цену с VWAP дневной сессии (от 00:00 UTC) и сигнализирует
+// о выходе за полосы ±Nσ, возврате цены выше VWAP (reclaim) и уходе под него (loss).
+type VWAPAnalyzer struct {
+	config common.AnalyzerConfig
+	deps   Dependencies
+
+	statsMu sync.RWMutex
+	stats   common.AnalyzerStats
+
+	mu     sync.Mutex
+	states map[string]*symbolState
+}
+
+// NewVWAPAnalyzer создает анализатор отклонений от VWAP
+func NewVWAPAnalyzer(config common.AnalyzerConfig, deps Dependencies) *VWAPAnalyzer {
+	logger.Info("✅ [VWAPAnalyzer] Создан анализатор VWAP (полосы ±%.1fσ, буфер пересечения %.2f%%)",
+		common.SafeGetFloat(config.CustomSettings, "deviation_sigma", defaultDeviationSigma),
+		common.SafeGetFloat(config.CustomSettings, "cross_buffer_pct", defaultCrossBufferPct))
+
+	return &VWAPAnalyzer{
+		config: config,
+		deps:   deps,
+		states: make(map[string]*symbolState),
+	}
+}
+
+// Analyze проверяет положение текущей цены относительно VWAP сессии
+func (a *VWAPAnalyzer) Analyze(data []storage.PriceDataInterface, config common.AnalyzerConfig) ([]analysis.Signal, error) {
+	startTime := time.Now()
+	defer a.updateStats(startTime)
+
+	a.config = config
+	if a.deps.Tracker == nil {
+		return nil, nil
+	}
+
+	minSession := time.Duration(common.SafeGetInt(config.CustomSettings, "min_session_minutes", defaultMinSessionMinutes)) * time.Minute
+
+	var signals []analysis.Signal
+	for _, point := range data {
+		symbol := point.GetSymbol()
+		snap, ok := a.deps.Tracker.Session(symbol)
+		if !ok || snap.Ticks < minSessionTicks || snap.UpdatedAt.Sub(snap.Anchor) < minSession {
+			continue
+		}
+
+		for _, kind := range a.observe(symbol, point.GetPrice(), snap) {
+			signal := a.createSignal(kind, point.GetPrice(), snap)
+			if signal.Confidence < a.config.MinConfidence {
+				continue
+			}
+			if !a.publishSignal(signal, kind, point.GetPrice(), snap) {
+				continue
+			}
+			signals = append(signals, signal)
+		}
+	}
+	return signals, nil
+}
+
+// observe обновляет положение цены и возвращает сработавшие виды сигналов
+func (a *VWAPAnalyzer) observe(symbol string, price float64, snap vw.Snapshot) []string {
+	k := common.SafeGetFloat(a.config.CustomSettings, "deviation_sigma", defaultDeviationSigma)
+	buffer := common.SafeGetFloat(a.config.CustomSettings, "cross_buffer_pct", defaultCrossBufferPct)
+	cooldown := time.Duration(common.SafeGetInt(a.config.CustomSettings, "cooldown_minutes", defaultCooldownMinutes)) * time.Minute
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	st, ok := a.states[symbol]
+	if !ok || !st.session.Equal(snap.Anchor) {
+		// Новая сессия — положение относительно прошлого VWAP не переносим
+		st = &symbolState{session: snap.Anchor, lastAlert: make(map[string]time.Time)}
+		a.states[symbol] = st
+	}
+
+	var kinds []string
+
+	// Пересечение VWAP с гистерезисом: сторона меняется, только если цена ушла за буфер
+	side := st.side
+	switch distance := snap.DistancePct(price); {
+	case distance >= buffer:
+		side = 1
+	case distance <= -buffer:
+		side = -1
+	}
+	if st.side == -1 && side == 1 {
+		kinds = append(kinds, types.VWAPReclaim)
+	} else if st.side == 1 && side == -1 {
+		kinds = append(kinds, types.VWAPLoss)
+	}
+	st.side = side
+
+	// Выход за полосы: сигнал только при входе в зону, а не на каждом тике внутри нее
+	band := 0
+	if sigmas := snap.Sigmas(price); sigmas >= k {
+		band = 1
+	} else if sigmas <= -k {
+		band = -1
+	}
+	if band != 0 && band != st.band {
+		if band > 0 {
+			kinds = append(kinds, types.VWAPDeviationUp)
+		} else {
+			kinds = append(kinds, types.VWAPDeviationDown)
+		}
+	}
+	st.band = band
+
+	now := snap.UpdatedAt
+	allowed := kinds[:0]
+	for _, kind := range kinds {
+		if last, ok := st.lastAlert[kind]; ok && now.Sub(last) < cooldown {
+			continue
+		}
+		st.lastAlert[kind] = now
+		allowed = append(allowed, kind)
+	}
+	return allowed
+}
+
+// createSignal строит сигнал VWAP
+func (a *VWAPAnalyzer) createSignal(kind string, price float64, snap vw.Snapshot) analysis.Signal {
+	direction := "growth"
+	if kind == types.VWAPLoss || kind == types.VWAPDeviationDown {
+		direction = "fall"
+	}
+
+	sigmas := snap.Sigmas(price)
+	confidence := 50.0
+	if kind == types.VWAPDeviationUp || kind == types.VWAPDeviationDown {
+		// Nσ — 50, каждая следующая сигма добавляет 15
+		k := common.SafeGetFloat(a.config.CustomSettings, "deviation_sigma", defaultDeviationSigma)
+		confidence = math.Min(50+(math.Abs(sigmas)-k)*15, 100)
+	}
+
+	// Период сигнала — длительность сессии на момент срабатывания
+	minutes := int(snap.UpdatedAt.Sub(snap.Anchor).Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	return analysis.Signal{
+		ID:            uuid.New().String(),
+		Symbol:        snap.Symbol,
+		Type:          "vwap_" + kind,
+		Direction:     direction,
+		ChangePercent: snap.DistancePct(price),
+		Period:        minutes,
+		Confidence:    confidence,
+		DataPoints:    snap.Ticks,
+		StartPrice:    snap.VWAP,
+		EndPrice:      price,
+		Timestamp:     time.Now(),
+		Metadata: analysis.Metadata{
+			Strategy: "vwap_analyzer",
+			Tags:     []string{"vwap", kind, fmt.Sprintf("sigma:%.1f", sigmas)},
+			Custom: map[string]interface{}{
+				"vwap":          snap.VWAP,
+				"vwap_std":      snap.StdDev,
+				"vwap_sigmas":   sigmas,
+				"session_start": snap.Anchor,
+				"kind":          kind,
+			},
+		},
+	}
+}
+
+// publishSignal отправляет событие для доставки подписанным пользователям;
+// false — сигнал отброшен фильтрами
+func (a *VWAPAnalyzer) publishSignal(signal analysis.Signal, kind string, price float64, snap vw.Snapshot) bool {
+	event := types.Event{
+		Type:   types.EventVWAPSignal,
+		Source: "vwap_analyzer",
+		Data: types.VWAPSignalData{
+			Symbol:       snap.Symbol,
+			Kind:         kind,
+			Price:        price,
+			VWAP:         snap.VWAP,
+			StdDev:       snap.StdDev,
+			Sigmas:       snap.Sigmas(price),
+			DistancePct:  snap.DistancePct(price),
+			SessionStart: snap.Anchor,
+			Timestamp:    time.Now(),
+		},
+		Timestamp: time.Now(),
+	}
+
+	if !a.deps.Publisher.Publish(signal, event) {
+		return false
+	}
+	logger.Debug("📏 [VWAPAnalyzer] %s %s: цена %.6g, VWAP %.6g (%+.1fσ)",
+		snap.Symbol, kind, price, snap.VWAP, snap.Sigmas(price))
+	return true
+}
+
+func (a *VWAPAnalyzer) updateStats(startTime time.Time) {
+	a.statsMu.Lock()
+	defer a.statsMu.Unlock()
+
+	a.stats.TotalCalls++
+	a.stats.SuccessCount++
+	a.stats.TotalTime += time.Since(startTime)
+	a.stats.AverageTime = a.stats.TotalTime / time.Duration(a.stats.TotalCalls)
+	a.stats.LastCallTime = time.Now()
+}
+
+// GetConfig возвращает конфигурацию
+func (a *VWAPAnalyzer) GetConfig() common.AnalyzerConfig {
+	return a.config
+}
+
+// GetStats возвращает статистику
+func (a *VWAPAnalyzer) GetStats() common.AnalyzerStats {
+	a.statsMu.RLock()
+	defer a.statsMu.RUnlock()
+	return a.stats
+}
+
+// Name возвращает имя анализатора
+func (a *VWAPAnalyzer) Name() string {
+	return "vwap"
+}
+
+// Version возвращает версию анализатора
+func (a *VWAPAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// Supports проверяет, поддерживается ли символ
+func (a *VWAPAnalyzer) Supports(symbol string) bool {
+	return true
+}
@@ -0,0 +1,37 @@
+// internal/core/domain/signals/detectors/vwap/registry.go
+package vwap
+
+import (
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+)
+
+// Schema - настройки VWAPAnalyzer (VWAP_* в .env)
+var Schema = common.SettingsSchema{
+	{Key: "deviation_sigma", Type: common.SettingFloat, Default: defaultDeviationSigma, Range: &common.Range{Min: 1, Max: 5},
+		Description: "Отклонение от VWAP сессии в σ для сигнала"},
+	{Key: "cross_buffer_pct", Type: common.SettingFloat, Default: defaultCrossBufferPct, Range: &common.Range{Min: 0, Max: 2},
+		Description: "Насколько цена должна уйти за VWAP, чтобы засчитать возврат/потерю, %"},
+	{Key: "min_session_minutes", Type: common.SettingInt, Default: defaultMinSessionMinutes, Range: &common.Range{Min: 0, Max: 720},
+		Description: "Сколько минут сессии накапливать VWAP до первых сигналов"},
+	{Key: "cooldown_minutes", Type: common.SettingInt, Default: defaultCooldownMinutes, Range: &common.Range{Min: 1, Max: 1440},
+		Description: "Пауза между сигналами одного вида по символу, мин"},
+}
+
+func init() {
+	analyzers.Register(analyzers.Definition{
+		Name:          "vwap",
+		Description:   "Отклонения ±Nσ от VWAP сессии, возврат и потеря VWAP",
+		Requires:      []string{analyzers.DependencyCandleSystem},
+		Weight:        0.5,
+		MinConfidence: 50.0,
+		MinDataPoints: 1,
+		Settings:      Schema,
+		New: func(config common.AnalyzerConfig, ctx *analyzers.BuildContext) (common.Analyzer, error) {
+			return NewVWAPAnalyzer(config, Dependencies{
+				Tracker:   ctx.CandleSystem.VWAP(),
+				Publisher: ctx.Publisher,
+			}), nil
+		},
+	})
+}
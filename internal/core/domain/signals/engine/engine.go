@@ -2,8 +2,11 @@
 package engine
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
 	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
 	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/filters"
 	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
 	events "crypto-exchange-screener-bot/internal/infrastructure/transport/event_bus"
 	"crypto-exchange-screener-bot/internal/types"
@@ -21,12 +24,18 @@ import (
 
 // AnalyzerConfigs - конфигурация анализаторов
 type AnalyzerConfigs struct {
-	GrowthAnalyzer       AnalyzerConfig `json:"growth_analyzer"`
-	FallAnalyzer         AnalyzerConfig `json:"fall_analyzer"`
-	ContinuousAnalyzer   AnalyzerConfig `json:"continuous_analyzer"`
-	VolumeAnalyzer       AnalyzerConfig `json:"volume_analyzer"`
-	OpenInterestAnalyzer AnalyzerConfig `json:"open_interest_analyzer"`
-	CounterAnalyzer      AnalyzerConfig `json:"counter_analyzer"`
+	GrowthAnalyzer        AnalyzerConfig `json:"growth_analyzer"`
+	FallAnalyzer          AnalyzerConfig `json:"fall_analyzer"`
+	ContinuousAnalyzer    AnalyzerConfig `json:"continuous_analyzer"`
+	VolumeAnalyzer        AnalyzerConfig `json:"volume_analyzer"`
+	OpenInterestAnalyzer  AnalyzerConfig `json:"open_interest_analyzer"`
+	CounterAnalyzer       AnalyzerConfig `json:"counter_analyzer"`
+	DivergenceAnalyzer    AnalyzerConfig `json:"divergence_analyzer"`
+	LiquidityAnalyzer     AnalyzerConfig `json:"liquidity_analyzer"`
+	PatternAnalyzer       AnalyzerConfig `json:"pattern_analyzer"`
+	AnomalyAnalyzer       AnalyzerConfig `json:"anomaly_analyzer"`
+	RangeBreakoutAnalyzer AnalyzerConfig `json:"range_breakout_analyzer"`
+	VWAPAnalyzer          AnalyzerConfig `json:"vwap_analyzer"`
 }
 
 // AnalysisEngine - основной движок анализа (оркестратор)
@@ -42,6 +51,17 @@ type AnalysisEngine struct {
 	wg           sync.WaitGroup
 	running      bool
 
+	// Цепочка фильтров между анализаторами и EventBus (nil — без фильтрации)
+	filterChain *filters.Chain
+	// Доставка сигналов анализаторов через filterChain (nil — без EventBus)
+	publisher *analyzers.Publisher
+
+	// Источник режима рынка для метаданных сигналов (nil — режим не добавляется)
+	regime regime.Source
+
+	// Итог создания анализаторов из реестра (включая выключенные и пропущенные)
+	analyzerInfos []common.AnalyzerInfo
+
 	// Накопление статистики для логирования
 	logStatsMu       sync.RWMutex
 	logStats         map[int]*periodStats // период → статистика
@@ -131,6 +151,9 @@ func NewAnalysisEngine(storage storage.PriceStorageInterface, eventBus *events.E
 		logLastFlush:     time.Now(),
 		logFlushInterval: 10 * time.Second,
 	}
+	if eventBus != nil {
+		engine.publisher = analyzers.NewPublisher(eventBus)
+	}
 
 	// НЕ регистрируем стандартные анализаторы здесь
 	// Они будут созданы через фабрику с реальными зависимостями
@@ -224,6 +247,42 @@ func (e *AnalysisEngine) RegisterAnalyzer(analyzer common.Analyzer) error {
 	return nil
 }
 
+// SetFilterChain устанавливает цепочку фильтров сигналов. Цепочку применяет
+// Publisher анализаторов перед публикацией события доставки.
+func (e *AnalysisEngine) SetFilterChain(chain *filters.Chain) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.filterChain = chain
+	if e.publisher != nil {
+		e.publisher.SetChain(chain)
+	}
+}
+
+func (e *AnalysisEngine) getFilterChain() *filters.Chain {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.filterChain
+}
+
+// SetRegimeSource устанавливает источник режима рынка: режим добавляется
+// в Metadata.Custom["market_regime"] каждого сигнала
+func (e *AnalysisEngine) SetRegimeSource(source regime.Source) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.regime = source
+}
+
+// currentRegime возвращает текущий режим рынка ("" — источник не задан)
+func (e *AnalysisEngine) currentRegime() string {
+	e.mu.RLock()
+	source := e.regime
+	e.mu.RUnlock()
+	if source == nil {
+		return ""
+	}
+	return source.Current()
+}
+
 // UnregisterAnalyzer удаляет анализатор из оркестратора
 func (e *AnalysisEngine) UnregisterAnalyzer(name string) error {
 	e.mu.Lock()
@@ -251,6 +310,7 @@ func (e *AnalysisEngine) AnalyzeSymbol(symbol string, periods []time.Duration) (
 	}
 
 	var allSignals []analysis.Signal
+	marketRegime := e.currentRegime()
 
 	// ЗАПУСКАЕМ ВСЕ ЗАРЕГИСТРИРОВАННЫЕ АНАЛИЗАТОРЫ
 	e.mu.RLock()
@@ -280,32 +340,38 @@ func (e *AnalysisEngine) AnalyzeSymbol(symbol string, periods []time.Duration) (
 			for i := range signals {
 				signals[i].Symbol = symbol
 				signals[i].Timestamp = time.Now()
-				signals[i].ID = uuid.New().String()
+				// ID, выданный анализатором, связывает сигнал с его событием доставки
+				if signals[i].ID == "" {
+					signals[i].ID = uuid.New().String()
+				}
+				if marketRegime != "" {
+					if signals[i].Metadata.Custom == nil {
+						signals[i].Metadata.Custom = make(map[string]interface{})
+					}
+					if _, exists := signals[i].Metadata.Custom["market_regime"]; !exists {
+						signals[i].Metadata.Custom["market_regime"] = marketRegime
+					}
+				}
 			}
 
 			allSignals = append(allSignals, signals...)
 		}
 	}
 
-	// УДАЛЕНО: Применение фильтров
-	// AnalysisEngine теперь только оркестратор, не фильтрует сигналы
-
-	// Используем все сигналы (без фильтрации)
-	filteredSignals := allSignals
-
-	// Обновляем статистику
-	e.updateStats(symbol, len(allSignals), len(filteredSignals), time.Since(startTime))
+	// Цепочку фильтров сигналы уже прошли в Publisher анализаторов:
+	// сюда попадают только доставленные пользователям
+	e.updateStats(symbol, len(allSignals), time.Since(startTime))
 
 	result := &analysis.AnalysisResult{
 		Symbol:    symbol,
-		Signals:   filteredSignals,
+		Signals:   allSignals,
 		Timestamp: time.Now(),
 		Duration:  time.Since(startTime),
 	}
 
 	// Публикуем событие если есть сигналы
-	if len(filteredSignals) > 0 {
-		e.publishSignals(filteredSignals)
+	if len(allSignals) > 0 {
+		e.publishSignals(allSignals)
 	}
 
 	return result, nil
@@ -454,12 +520,12 @@ func (e *AnalysisEngine) passesVolumeFilter(symbol string) bool {
 }
 
 // updateStats обновляет статистику
-func (e *AnalysisEngine) updateStats(symbol string, totalSignals, filteredSignals int, duration time.Duration) {
+func (e *AnalysisEngine) updateStats(symbol string, signals int, duration time.Duration) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	e.stats.TotalAnalyses++
-	e.stats.TotalSignals += int64(filteredSignals)
+	e.stats.TotalSignals += int64(signals)
 	e.stats.AnalysisTime += duration
 	e.stats.LastRunTime = time.Now()
 	e.stats.SymbolsAnalyzed[symbol]++
@@ -749,6 +815,23 @@ func (e *AnalysisEngine) GetAnalyzers() []string {
 	return names
 }
 
+// setAnalyzerInfos сохраняет итог создания анализаторов из реестра
+func (e *AnalysisEngine) setAnalyzerInfos(infos []common.AnalyzerInfo) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.analyzerInfos = infos
+}
+
+// GetAnalyzerInfos возвращает анализаторы реестра с состоянием и действующими настройками
+func (e *AnalysisEngine) GetAnalyzerInfos() []common.AnalyzerInfo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	infos := make([]common.AnalyzerInfo, len(e.analyzerInfos))
+	copy(infos, e.analyzerInfos)
+	return infos
+}
+
 // saveStats сохраняет статистику (заглушка)
 func (e *AnalysisEngine) saveStats() {
 	// В будущем можно сохранять в файл или базу данных
@@ -813,7 +896,19 @@ func (e *AnalysisEngine) GetStatus() map[string]interface{} {
 		"total_stats": stats,
 	}
 
-	// УДАЛЕНО: filter_stats - AnalysisEngine теперь только оркестратор
+	// Состояние анализаторов реестра
+	analyzerStates := make(map[string]string)
+	for _, info := range e.GetAnalyzerInfos() {
+		analyzerStates[info.Name] = string(info.State)
+	}
+	status["analyzer_states"] = analyzerStates
+
+	// Статистика цепочки фильтров
+	if chain := e.getFilterChain(); chain != nil {
+		status["filters"] = chain.Names()
+		status["filter_chain_stats"] = chain.GetStats()
+		status["filter_stats"] = chain.GetFilterStats()
+	}
 
 	// Информация о конфигурации
 	status["config"] = map[string]interface{}{
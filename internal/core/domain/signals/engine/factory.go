@@ -2,23 +2,38 @@
 package engine
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
 	candle "crypto-exchange-screener-bot/internal/core/domain/candle"
+	analyzers "crypto-exchange-screener-bot/internal/core/domain/signals/detectors"
 	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
-	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter"
-	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter/calculator"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/filters"
 	"crypto-exchange-screener-bot/internal/infrastructure/config"
 	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	returns_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/returns_storage"
 	sr_storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/sr_storage"
 	events "crypto-exchange-screener-bot/internal/infrastructure/transport/event_bus"
 	"crypto-exchange-screener-bot/pkg/logger"
 	"log"
+	"sort"
+	"strings"
 	"time"
+
+	// Анализаторы регистрируются в реестре из init() своих пакетов
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/anomaly"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/counter"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/divergence"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/liquidity"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/patterns"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/range_breakout"
+	_ "crypto-exchange-screener-bot/internal/core/domain/signals/detectors/vwap"
 )
 
 type Factory struct {
 	priceFetcher  interface{}
 	candleSystem  *candle.CandleSystem
 	srZoneStorage *sr_storage.SRZoneStorage
+	returns       *returns_storage.ReturnsStorage
+	regime        regime.Source
 }
 
 // NewFactory создает фабрику
@@ -57,145 +72,250 @@ func (f *Factory) NewAnalysisEngineFromConfig(
 			CounterAnalyzer: AnalyzerConfig{
 				Enabled: analyzerConfigs.CounterAnalyzer.Enabled,
 			},
+			DivergenceAnalyzer: AnalyzerConfig{
+				Enabled:       analyzerConfigs.DivergenceAnalyzer.Enabled,
+				MinConfidence: analyzerConfigs.DivergenceAnalyzer.MinConfidence,
+			},
+			LiquidityAnalyzer: AnalyzerConfig{
+				Enabled:       analyzerConfigs.LiquidityAnalyzer.Enabled,
+				MinConfidence: analyzerConfigs.LiquidityAnalyzer.MinConfidence,
+			},
+			PatternAnalyzer: AnalyzerConfig{
+				Enabled:       analyzerConfigs.PatternAnalyzer.Enabled,
+				MinConfidence: analyzerConfigs.PatternAnalyzer.MinConfidence,
+			},
+			AnomalyAnalyzer: AnalyzerConfig{
+				Enabled:       analyzerConfigs.AnomalyAnalyzer.Enabled,
+				MinConfidence: analyzerConfigs.AnomalyAnalyzer.MinConfidence,
+			},
+			RangeBreakoutAnalyzer: AnalyzerConfig{
+				Enabled:       analyzerConfigs.RangeBreakoutAnalyzer.Enabled,
+				MinConfidence: analyzerConfigs.RangeBreakoutAnalyzer.MinConfidence,
+			},
+			VWAPAnalyzer: AnalyzerConfig{
+				Enabled:       analyzerConfigs.VWAPAnalyzer.Enabled,
+				MinConfidence: analyzerConfigs.VWAPAnalyzer.MinConfidence,
+			},
 		},
 		// УДАЛЕНО: FilterConfigs - AnalysisEngine теперь только оркестратор
 	}
 
 	engine := NewAnalysisEngine(storage, eventBus, engineConfig)
+	if f.regime != nil {
+		engine.SetRegimeSource(f.regime)
+	}
 	f.configureAnalyzers(engine, cfg)
-	// УДАЛЕНО: f.configureFilters(engine, cfg) - фильтров больше нет
+	f.configureFilters(engine, storage, cfg)
 	return engine
 }
 
-func getFloatFromCustomSettings(customSettings map[string]interface{}, key string, defaultValue float64) float64 {
-	if customSettings == nil {
-		return defaultValue
-	}
-	if val, ok := customSettings[key].(float64); ok {
-		return val
-	}
-	if val, ok := customSettings[key].(int); ok {
-		return float64(val)
-	}
-	return defaultValue
-}
+// defaultFilterOrder — порядок фильтров, не указанных в SIGNAL_FILTER_ORDER.
+// Фильтры с состоянием (cooldown, rate_limit) стоят последними.
+var defaultFilterOrder = []string{"blacklist", "pattern", "min_turnover", "volume", "confidence", "cooldown", "rate_limit"}
 
-func getBoolFromCustomSettings(customSettings map[string]interface{}, key string, defaultValue bool) bool {
-	if customSettings == nil {
-		return defaultValue
-	}
-	if val, ok := customSettings[key].(bool); ok {
-		return val
+// configureFilters собирает цепочку фильтров сигналов из конфигурации:
+// порядок — SIGNAL_FILTER_ORDER, включение — SIGNAL_FILTER_*_ENABLED.
+// Фильтры с пустыми параметрами (нет шаблонов, нулевой порог) не добавляются.
+func (f *Factory) configureFilters(
+	engine *AnalysisEngine,
+	snapshots filters.SnapshotSource,
+	cfg *config.Config,
+) {
+	fc := cfg.SignalFilters
+	if !fc.Enabled {
+		logger.Info("ℹ️ Фильтры сигналов отключены (SIGNAL_FILTERS_ENABLED=false)")
+		return
 	}
-	return defaultValue
-}
 
-func getStringFromCustomSettings(customSettings map[string]interface{}, key string, defaultValue string) string {
-	if customSettings == nil {
-		return defaultValue
-	}
-	if val, ok := customSettings[key].(string); ok {
-		return val
+	builders := map[string]func() filters.Filter{
+		"blacklist": func() filters.Filter {
+			if !fc.BlacklistEnabled || len(fc.Blacklist) == 0 {
+				return nil
+			}
+			return filters.NewBlacklistFilter(fc.Blacklist)
+		},
+		"pattern": func() filters.Filter {
+			if !fc.PatternEnabled || (len(fc.IncludePatterns) == 0 && len(fc.ExcludePatterns) == 0) {
+				return nil
+			}
+			return filters.NewPatternFilter(fc.IncludePatterns, fc.ExcludePatterns)
+		},
+		"min_turnover": func() filters.Filter {
+			if !fc.MinTurnoverEnabled || fc.MinTurnover <= 0 {
+				return nil
+			}
+			return filters.NewMinTurnoverFilter(fc.MinTurnover, snapshots)
+		},
+		"volume": func() filters.Filter {
+			if !fc.VolumeEnabled || fc.MinVolume <= 0 {
+				return nil
+			}
+			return filters.NewVolumeFilter(fc.MinVolume)
+		},
+		"confidence": func() filters.Filter {
+			if !fc.ConfidenceEnabled || fc.MinConfidence <= 0 {
+				return nil
+			}
+			return filters.NewConfidenceFilter(fc.MinConfidence)
+		},
+		"cooldown": func() filters.Filter {
+			if !fc.CooldownEnabled || fc.Cooldown <= 0 {
+				return nil
+			}
+			return filters.NewCooldownFilter(fc.Cooldown)
+		},
+		"rate_limit": func() filters.Filter {
+			if !fc.RateLimitEnabled || fc.MaxSignalsPerMin <= 0 {
+				return nil
+			}
+			return filters.NewRateLimitFilter(time.Minute / time.Duration(fc.MaxSignalsPerMin))
+		},
 	}
-	return defaultValue
-}
 
-func getIntFromCustomSettings(customSettings map[string]interface{}, key string, defaultValue int) int {
-	if customSettings == nil {
-		return defaultValue
+	// Сначала фильтры в порядке из конфигурации, затем остальные по умолчанию
+	order := make([]string, 0, len(defaultFilterOrder))
+	seen := make(map[string]bool)
+	for _, name := range append(append([]string{}, fc.Order...), defaultFilterOrder...) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+		if _, ok := builders[name]; !ok {
+			logger.Warn("⚠️ Неизвестный фильтр сигналов в SIGNAL_FILTER_ORDER: %s", name)
+			continue
+		}
+		seen[name] = true
+		order = append(order, name)
 	}
-	if val, ok := customSettings[key].(int); ok {
-		return val
+
+	var chainFilters []filters.Filter
+	for _, name := range order {
+		if filter := builders[name](); filter != nil {
+			chainFilters = append(chainFilters, filter)
+		}
 	}
-	if val, ok := customSettings[key].(float64); ok {
-		return int(val)
+
+	if len(chainFilters) == 0 {
+		logger.Info("ℹ️ Цепочка фильтров сигналов пуста: все фильтры выключены или без параметров")
+		return
 	}
-	return defaultValue
+
+	chain := filters.NewChain(chainFilters...)
+	engine.SetFilterChain(chain)
+	logger.Info("✅ Цепочка фильтров сигналов: %s", strings.Join(chain.Names(), " → "))
 }
 
+// configureAnalyzers создает включенные в конфигурации анализаторы из реестра.
+// Настройки проверяются по схеме анализатора; при ошибке анализатор не создается,
+// остальные продолжают работать. Итог по каждому анализатору сохраняется в движке.
 func (f *Factory) configureAnalyzers(
 	engine *AnalysisEngine,
 	cfg *config.Config,
 ) {
-	analyzerConfigs := cfg.AnalyzerConfigs
+	definitions, err := analyzers.OrderedDefinitions()
+	if err != nil {
+		logger.Error("❌ Реестр анализаторов: %v", err)
+		return
+	}
+
+	ctx := &analyzers.BuildContext{
+		Storage:        engine.GetStorage(),
+		CandleSystem:   f.candleSystem,
+		PriceFetcher:   f.priceFetcher,
+		SRZoneStorage:  f.srZoneStorage,
+		ReturnsStorage: f.returns,
+		Regime:         f.regime,
+	}
+	// Передаем nil-интерфейс, а не типизированный nil
+	if engine.eventBus != nil {
+		ctx.EventBus = engine.eventBus
+		ctx.Publisher = engine.publisher
+	}
 
-	// Оставляем только CounterAnalyzer если он включен
-	if analyzerConfigs.CounterAnalyzer.Enabled {
-		f.configureCounterAnalyzer(engine, cfg)
+	configs := cfg.AnalyzerConfigs.ByName()
+	infos := make([]common.AnalyzerInfo, 0, len(definitions))
+	for _, def := range definitions {
+		infos = append(infos, f.buildAnalyzer(engine, def, configs[def.Name], ctx))
+		delete(configs, def.Name)
 	}
+	engine.setAnalyzerInfos(infos)
 
-	logger.Warn("ℹ️ Анализаторы отключены через фабрику: Growth, Fall, Continuous, Volume, OpenInterest")
-	logger.Debug("ℹ️ Активные анализаторы: %s", func() string {
-		if analyzerConfigs.CounterAnalyzer.Enabled {
-			return "CounterAnalyzer"
+	// Включены в конфигурации, но реализации в реестре нет
+	var missing []string
+	for name, analyzerCfg := range configs {
+		if analyzerCfg.Enabled {
+			missing = append(missing, name)
 		}
-		return "нет"
-	}())
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		logger.Warn("ℹ️ Анализаторы без реализации в реестре пропущены: %s", strings.Join(missing, ", "))
+	}
+	logger.Debug("ℹ️ Активные анализаторы: %v", engine.GetAnalyzers())
 }
 
-func (f *Factory) configureCounterAnalyzer(
+// buildAnalyzer проверяет настройки и зависимости анализатора и регистрирует его в движке
+func (f *Factory) buildAnalyzer(
 	engine *AnalysisEngine,
-	cfg *config.Config,
-) {
-	logger.Info("🔧 Настройка CounterAnalyzer с CandleTracker...")
-	analyzerConfigs := cfg.AnalyzerConfigs
-	customSettings := analyzerConfigs.CounterAnalyzer.CustomSettings
-
-	counterConfig := common.AnalyzerConfig{
-		Enabled:       true,
-		Weight:        0.7,
-		MinConfidence: 10.0,
-		MinDataPoints: 2,
-		CustomSettings: map[string]interface{}{
-			"base_period_minutes":    getIntFromCustomSettings(customSettings, "base_period_minutes", 1),
-			"analysis_period":        getStringFromCustomSettings(customSettings, "analysis_period", "15m"),
-			"growth_threshold":       getFloatFromCustomSettings(customSettings, "growth_threshold", 0.1),
-			"fall_threshold":         getFloatFromCustomSettings(customSettings, "fall_threshold", 0.1),
-			"track_growth":           getBoolFromCustomSettings(customSettings, "track_growth", true),
-			"track_fall":             getBoolFromCustomSettings(customSettings, "track_fall", true),
-			"notify_on_signal":       getBoolFromCustomSettings(customSettings, "notify_on_signal", true),
-			"notification_threshold": getIntFromCustomSettings(customSettings, "notification_threshold", 1),
-			"chart_provider":         getStringFromCustomSettings(customSettings, "chart_provider", "coinglass"),
-			"max_signals_5m":         getIntFromCustomSettings(customSettings, "max_signals_5m", 5),
-			"max_signals_15m":        getIntFromCustomSettings(customSettings, "max_signals_15m", 8),
-			"max_signals_30m":        getIntFromCustomSettings(customSettings, "max_signals_30m", 10),
-			"max_signals_1h":         getIntFromCustomSettings(customSettings, "max_signals_1h", 12),
-			"max_signals_4h":         getIntFromCustomSettings(customSettings, "max_signals_4h", 15),
-			"max_signals_1d":         getIntFromCustomSettings(customSettings, "max_signals_1d", 20),
-		},
+	def analyzers.Definition,
+	analyzerCfg config.AnalyzerConfig,
+	ctx *analyzers.BuildContext,
+) common.AnalyzerInfo {
+	info := common.AnalyzerInfo{
+		Name:          def.Name,
+		Description:   def.Description,
+		Requires:      def.Requires,
+		After:         def.After,
+		Weight:        def.Weight,
+		MinConfidence: def.MinConfidence,
+		Schema:        def.Settings,
+		Settings:      def.Settings.Defaults(),
 	}
 
-	storage := engine.GetStorage()
-
-	// Здесь нужно получить RedisService для создания трекера
-	// Пока создаем без трекера если не можем получить RedisService
-	// В реальном коде нужно будет передать RedisService из зависимостей
-
-	logger.Warn("⚠️ CandleTracker временно не используется, нужен RedisService")
+	if !analyzerCfg.Enabled {
+		info.State = common.AnalyzerDisabled
+		logger.Info("ℹ️ Анализатор %s выключен в конфигурации", def.Name)
+		return info
+	}
 
-	// Создаем зависимости
-	deps := counter.Dependencies{
-		Storage:          storage,
-		EventBus:         engine.eventBus,
-		CandleSystem:     f.candleSystem,
-		MarketFetcher:    f.priceFetcher,
-		VolumeCalculator: calculator.NewVolumeDeltaCalculator(f.priceFetcher, storage),
-		SRZoneStorage:    f.srZoneStorage,
+	analyzerConfig, unknown, err := def.BuildConfig(analyzerCfg.MinConfidence, analyzerCfg.CustomSettings)
+	for _, key := range unknown {
+		logger.Warn("⚠️ Анализатор %s: неизвестная настройка %s игнорируется", def.Name, key)
 	}
+	if err != nil {
+		info.State = common.AnalyzerInvalid
+		info.Reason = err.Error()
+		logger.Error("❌ Анализатор %s не создан, неверные настройки:", def.Name)
+		for _, line := range strings.Split(err.Error(), "\n") {
+			logger.Error("   %s", line)
+		}
+		return info
+	}
+	info.MinConfidence = analyzerConfig.MinConfidence
+	info.Settings = analyzerConfig.CustomSettings
 
-	counterAnalyzer := counter.NewCounterAnalyzer(counterConfig, deps)
+	if missing := def.MissingDependencies(ctx); len(missing) > 0 {
+		info.State = common.AnalyzerSkipped
+		info.Reason = "нет зависимостей: " + strings.Join(missing, ", ")
+		logger.Warn("⚠️ Анализатор %s не создан: %s", def.Name, info.Reason)
+		return info
+	}
 
-	if err := engine.RegisterAnalyzer(counterAnalyzer); err != nil {
-		logger.Warn("⚠️ Не удалось зарегистрировать CounterAnalyzer: %v", err)
-	} else {
-		logger.Info("✅ CounterAnalyzer успешно добавлен в AnalysisEngine")
-		logger.Info("   Storage: %v", storage != nil)
-		logger.Info("   MarketFetcher: %v", f.priceFetcher != nil)
-		logger.Info("   CandleSystem: %v", f.candleSystem != nil)
+	analyzer, err := def.New(analyzerConfig, ctx)
+	if err == nil {
+		err = engine.RegisterAnalyzer(analyzer)
+	}
+	if err != nil {
+		info.State = common.AnalyzerFailed
+		info.Reason = err.Error()
+		logger.Warn("⚠️ Не удалось зарегистрировать анализатор %s: %v", def.Name, err)
+		return info
 	}
-}
 
-// УДАЛЕНО: configureFilters метод - AnalysisEngine теперь только оркестратор
+	info.State = common.AnalyzerActive
+	logger.Info("✅ Анализатор %s добавлен в AnalysisEngine", def.Name)
+	return info
+}
 
 func (e *AnalysisEngine) GetStorage() storage.PriceStorageInterface {
 	return e.storage
@@ -211,3 +331,14 @@ func (f *Factory) SetCandleSystem(candleSystem *candle.CandleSystem) {
 func (f *Factory) SetSRZoneStorage(storage *sr_storage.SRZoneStorage) {
 	f.srZoneStorage = storage
 }
+
+// SetReturnsStorage устанавливает хранилище доходностей для AnomalyAnalyzer
+func (f *Factory) SetReturnsStorage(storage *returns_storage.ReturnsStorage) {
+	f.returns = storage
+}
+
+// SetRegimeSource устанавливает источник режима рынка для метаданных сигналов
+// и порогов анализаторов
+func (f *Factory) SetRegimeSource(source regime.Source) {
+	f.regime = source
+}
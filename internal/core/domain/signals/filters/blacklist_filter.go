@@ -0,0 +1,51 @@
+// internal/core/domain/signals/filters/blacklist_filter.go
+package filters
+
+import (
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"strings"
+	"sync"
+)
+
+// BlacklistFilter - фильтр, отбрасывающий сигналы по символам из черного списка
+type BlacklistFilter struct {
+	symbols map[string]bool
+	stats   FilterStats
+	mu      sync.RWMutex
+}
+
+// NewBlacklistFilter создает новый BlacklistFilter
+func NewBlacklistFilter(symbols []string) *BlacklistFilter {
+	set := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			set[s] = true
+		}
+	}
+	return &BlacklistFilter{symbols: set}
+}
+
+func (f *BlacklistFilter) Name() string {
+	return "blacklist_filter"
+}
+
+func (f *BlacklistFilter) Apply(signal analysis.Signal) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stats.TotalProcessed++
+
+	if f.symbols[strings.ToUpper(signal.Symbol)] {
+		f.stats.FilteredOut++
+		return false
+	}
+
+	f.stats.PassedThrough++
+	return true
+}
+
+func (f *BlacklistFilter) GetStats() FilterStats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.stats
+}
@@ -0,0 +1,83 @@
+// internal/core/domain/signals/filters/chain.go
+package filters
+
+import (
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"sync"
+)
+
+// Chain - упорядоченная цепочка фильтров между анализаторами и EventBus.
+// Сигнал проходит фильтры по порядку до первого отказа, поэтому фильтры
+// с состоянием (cooldown, rate_limit) должны стоять в конце: иначе они
+// запомнят сигнал, который потом отбросит следующий фильтр.
+type Chain struct {
+	filters []Filter
+	stats   FilterStats
+	mu      sync.RWMutex
+}
+
+// NewChain создает цепочку из фильтров в заданном порядке
+func NewChain(filters ...Filter) *Chain {
+	return &Chain{filters: filters}
+}
+
+// Apply возвращает сигналы, прошедшие все фильтры цепочки
+func (c *Chain) Apply(signals []analysis.Signal) []analysis.Signal {
+	if len(signals) == 0 {
+		return signals
+	}
+
+	passed := make([]analysis.Signal, 0, len(signals))
+	for _, signal := range signals {
+		if c.applyOne(signal) {
+			passed = append(passed, signal)
+		}
+	}
+
+	c.mu.Lock()
+	c.stats.TotalProcessed += int64(len(signals))
+	c.stats.PassedThrough += int64(len(passed))
+	c.stats.FilteredOut += int64(len(signals) - len(passed))
+	c.mu.Unlock()
+
+	return passed
+}
+
+func (c *Chain) applyOne(signal analysis.Signal) bool {
+	for _, filter := range c.filters {
+		if !filter.Apply(signal) {
+			return false
+		}
+	}
+	return true
+}
+
+// Names возвращает имена фильтров в порядке применения
+func (c *Chain) Names() []string {
+	names := make([]string, 0, len(c.filters))
+	for _, filter := range c.filters {
+		names = append(names, filter.Name())
+	}
+	return names
+}
+
+// Len возвращает количество фильтров в цепочке
+func (c *Chain) Len() int {
+	return len(c.filters)
+}
+
+// GetStats возвращает итоговую статистику цепочки
+func (c *Chain) GetStats() FilterStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stats
+}
+
+// GetFilterStats возвращает статистику каждого фильтра по имени
+func (c *Chain) GetFilterStats() map[string]FilterStats {
+	stats := make(map[string]FilterStats, len(c.filters))
+	for _, filter := range c.filters {
+		stats[filter.Name()] = filter.GetStats()
+	}
+	return stats
+}
@@ -0,0 +1,68 @@
+// internal/core/domain/signals/filters/cooldown_filter.go
+package filters
+
+import (
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CooldownFilter - фильтр повторов: сигнал того же типа, направления и периода
+// по символу пропускается не чаще одного раза за cooldown.
+// В отличие от RateLimitFilter не глушит разные сигналы одного символа.
+type CooldownFilter struct {
+	cooldown   time.Duration
+	lastSignal map[string]time.Time
+	lastPrune  time.Time
+	stats      FilterStats
+	mu         sync.RWMutex
+}
+
+// NewCooldownFilter создает новый CooldownFilter
+func NewCooldownFilter(cooldown time.Duration) *CooldownFilter {
+	return &CooldownFilter{
+		cooldown:   cooldown,
+		lastSignal: make(map[string]time.Time),
+		lastPrune:  time.Now(),
+	}
+}
+
+func (f *CooldownFilter) Name() string {
+	return "cooldown_filter"
+}
+
+func (f *CooldownFilter) Apply(signal analysis.Signal) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	key := fmt.Sprintf("%s|%s|%s|%d", signal.Symbol, signal.Type, signal.Direction, signal.Period)
+
+	f.stats.TotalProcessed++
+
+	if last, exists := f.lastSignal[key]; exists && now.Sub(last) < f.cooldown {
+		f.stats.FilteredOut++
+		return false
+	}
+
+	f.lastSignal[key] = now
+	f.stats.PassedThrough++
+
+	// Периодически удаляем истекшие отметки, чтобы карта не росла бесконечно
+	if now.Sub(f.lastPrune) >= f.cooldown {
+		for k, at := range f.lastSignal {
+			if now.Sub(at) >= f.cooldown {
+				delete(f.lastSignal, k)
+			}
+		}
+		f.lastPrune = now
+	}
+	return true
+}
+
+func (f *CooldownFilter) GetStats() FilterStats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.stats
+}
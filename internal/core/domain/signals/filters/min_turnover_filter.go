@@ -0,0 +1,63 @@
+// internal/core/domain/signals/filters/min_turnover_filter.go
+package filters
+
+import (
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	storage "crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage"
+	"sync"
+)
+
+// SnapshotSource - источник текущих снимков цены (PriceStorage)
+type SnapshotSource interface {
+	GetCurrentSnapshot(symbol string) (storage.PriceSnapshotInterface, bool)
+}
+
+// MinTurnoverFilter - фильтр по обороту символа за 24ч в USD.
+// В отличие от VolumeFilter смотрит не на объем сигнала, а на ликвидность монеты.
+type MinTurnoverFilter struct {
+	MinTurnover float64
+	source      SnapshotSource
+	stats       FilterStats
+	mu          sync.RWMutex
+}
+
+// NewMinTurnoverFilter создает новый MinTurnoverFilter
+func NewMinTurnoverFilter(minTurnover float64, source SnapshotSource) *MinTurnoverFilter {
+	return &MinTurnoverFilter{
+		MinTurnover: minTurnover,
+		source:      source,
+	}
+}
+
+func (f *MinTurnoverFilter) Name() string {
+	return "min_turnover_filter"
+}
+
+func (f *MinTurnoverFilter) Apply(signal analysis.Signal) bool {
+	// Снимок читаем до блокировки: запрос к хранилищу может быть долгим
+	turnover := 0.0
+	if f.source != nil {
+		if snapshot, ok := f.source.GetCurrentSnapshot(signal.Symbol); ok && snapshot != nil {
+			turnover = snapshot.GetVolumeUSD()
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stats.TotalProcessed++
+
+	if turnover < f.MinTurnover {
+		f.stats.FilteredOut++
+		return false
+	}
+
+	f.stats.PassedThrough++
+	return true
+}
+
+func (f *MinTurnoverFilter) GetStats() FilterStats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.stats
+}
@@ -0,0 +1,71 @@
+// internal/core/domain/signals/filters/pattern_filter.go
+package filters
+
+import (
+	analysis "crypto-exchange-screener-bot/internal/core/domain/signals"
+	"path"
+	"strings"
+	"sync"
+)
+
+// PatternFilter - фильтр символов по шаблонам включения/исключения (BTC*, *USDC).
+// Пустой список включения пропускает все символы.
+type PatternFilter struct {
+	include []string
+	exclude []string
+	stats   FilterStats
+	mu      sync.RWMutex
+}
+
+// NewPatternFilter создает новый PatternFilter
+func NewPatternFilter(include, exclude []string) *PatternFilter {
+	return &PatternFilter{
+		include: normalizePatterns(include),
+		exclude: normalizePatterns(exclude),
+	}
+}
+
+func (f *PatternFilter) Name() string {
+	return "pattern_filter"
+}
+
+func (f *PatternFilter) Apply(signal analysis.Signal) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stats.TotalProcessed++
+
+	symbol := strings.ToUpper(signal.Symbol)
+	if (len(f.include) > 0 && !matchAny(f.include, symbol)) || matchAny(f.exclude, symbol) {
+		f.stats.FilteredOut++
+		return false
+	}
+
+	f.stats.PassedThrough++
+	return true
+}
+
+func (f *PatternFilter) GetStats() FilterStats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.stats
+}
+
+func normalizePatterns(patterns []string) []string {
+	result := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if p = strings.ToUpper(strings.TrimSpace(p)); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func matchAny(patterns []string, symbol string) bool {
+	for _, p := range patterns {
+		if ok, err := path.Match(p, symbol); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
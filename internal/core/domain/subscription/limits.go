@@ -0,0 +1,24 @@
+// internal/core/domain/subscription/limits.go
+package subscription
+
+import "context"
+
+// Типы лимитов тарифа для CheckUserLimit
+const (
+	LimitSymbols     = "symbols"
+	LimitSignals     = "signals"
+	LimitAPIRequests = "api_requests"
+	LimitRules       = "rules"
+	LimitAlerts      = "alerts"
+	LimitExports     = "exports" // выгрузок в сутки
+)
+
+// LimitChecker — проверка лимитов тарифа. Реализуется Service; доменные
+// сервисы (правила, алерты, выгрузки) зависят от интерфейса, а не от Service.
+type LimitChecker interface {
+	// CheckUserLimit возвращает, можно ли превысить currentUsage, и остаток лимита
+	// (-1 — без ограничений). При нулевом использовании остаток равен лимиту тарифа.
+	CheckUserLimit(ctx context.Context, userID int, limitType string, currentUsage int) (bool, int, error)
+}
+
+var _ LimitChecker = (*Service)(nil)
@@ -565,12 +565,18 @@ func (s *Service) CheckUserLimit(ctx context.Context, userID int, limitType stri
 
 	var maxLimit int
 	switch strings.ToLower(limitType) {
-	case "symbols":
+	case LimitSymbols:
 		maxLimit = plan.MaxSymbols
-	case "signals":
+	case LimitSignals:
 		maxLimit = plan.MaxSignalsPerDay
-	case "api_requests":
+	case LimitAPIRequests:
 		maxLimit = plan.GetMaxAPIRequests()
+	case LimitRules:
+		maxLimit = plan.GetMaxRules()
+	case LimitAlerts:
+		maxLimit = plan.GetMaxAlerts()
+	case LimitExports:
+		maxLimit = plan.GetMaxExportsPerDay()
 	default:
 		return false, 0, fmt.Errorf("неизвестный тип лимита: %s", limitType)
 	}
@@ -309,13 +309,23 @@ func (s *Service) UpdateSettings(userID int, settings map[string]interface{}) er
 
 	// Сохраняем старые настройки для логирования
 	oldSettings := map[string]interface{}{
-		"min_growth_threshold":  user.MinGrowthThreshold,
-		"min_fall_threshold":    user.MinFallThreshold,
-		"max_signals_per_day":   user.MaxSignalsPerDay,
-		"notifications_enabled": user.NotificationsEnabled,
-		"notify_growth":         user.NotifyGrowth,
-		"notify_fall":           user.NotifyFall,
-		"preferred_periods":     user.PreferredPeriods, // ← ДОБАВЛЯЕМ
+		"min_growth_threshold":    user.MinGrowthThreshold,
+		"min_fall_threshold":      user.MinFallThreshold,
+		"max_signals_per_day":     user.MaxSignalsPerDay,
+		"notifications_enabled":   user.NotificationsEnabled,
+		"notify_growth":           user.NotifyGrowth,
+		"notify_fall":             user.NotifyFall,
+		"preferred_periods":       user.PreferredPeriods, // ← ДОБАВЛЯЕМ
+		"min_confluence_score":    user.MinConfluenceScore,
+		"suppress_market_moves":   user.SuppressMarketMoves,
+		"notify_pattern_zones":    user.NotifyPatternZones,
+		"sensitivity_sigma":       user.SensitivitySigma,
+		"notify_sector_digest":    user.NotifySectorDigest,
+		"range_breakout_horizons": user.RangeBreakoutHorizons,
+		"notify_vwap":             user.NotifyVWAP,
+		"vwap_anchor_at":          user.VWAPAnchorAt,
+		"regime_thresholds":       user.RegimeThresholds,
+		"notify_wall_pulls":       user.NotifyWallPulls,
 	}
 
 	// Применяем новые настройки
@@ -353,6 +363,47 @@ func (s *Service) UpdateSettings(userID int, settings map[string]interface{}) er
 			if val, ok := value.([]int); ok {
 				user.PreferredPeriods = val
 			}
+		case "min_confluence_score":
+			if val, ok := value.(float64); ok {
+				user.MinConfluenceScore = val
+			}
+		case "suppress_market_moves":
+			if val, ok := value.(bool); ok {
+				user.SuppressMarketMoves = val
+			}
+		case "notify_pattern_zones":
+			if val, ok := value.(bool); ok {
+				user.NotifyPatternZones = val
+			}
+		case "sensitivity_sigma":
+			if val, ok := value.(float64); ok {
+				user.SensitivitySigma = val
+			}
+		case "notify_sector_digest":
+			if val, ok := value.(bool); ok {
+				user.NotifySectorDigest = val
+			}
+		case "range_breakout_horizons":
+			if val, ok := value.([]string); ok {
+				user.RangeBreakoutHorizons = val
+			}
+		case "notify_vwap":
+			if val, ok := value.(bool); ok {
+				user.NotifyVWAP = val
+			}
+		case "vwap_anchor_at":
+			// nil снимает якорь
+			if val, ok := value.(*time.Time); ok {
+				user.VWAPAnchorAt = val
+			}
+		case "regime_thresholds":
+			if val, ok := value.(string); ok {
+				user.RegimeThresholds = val
+			}
+		case "notify_wall_pulls":
+			if val, ok := value.(bool); ok {
+				user.NotifyWallPulls = val
+			}
 		}
 	}
 
@@ -616,16 +667,20 @@ func (s *Service) SearchUsers(query string, limit, offset int) ([]*models.User,
 	return s.repo.Search(query, limit, offset)
 }
 
-// GetAllUsers возвращает всех пользователей с пагинацией
+// GetAllUsers возвращает всех пользователей с пагинацией.
+// Кэшируется только первая страница: следующие читаются из БД.
 func (s *Service) GetAllUsers(limit, offset int) ([]*models.User, error) {
 	ctx := context.Background()
 	cacheKey := "all_users_for_notify"
+	cached := offset == 0
 
 	// Пробуем получить из кэша (TTL 1 минута)
 	var cachedUsers []*models.User
-	if err := s.cache.Get(ctx, cacheKey, &cachedUsers); err == nil && len(cachedUsers) > 0 {
-		// logger.Info("👥 GetAllUsers: из кэша Redis (%d пользователей)", len(cachedUsers))
-		return cachedUsers, nil
+	if cached {
+		if err := s.cache.Get(ctx, cacheKey, &cachedUsers); err == nil && len(cachedUsers) > 0 {
+			// logger.Info("👥 GetAllUsers: из кэша Redis (%d пользователей)", len(cachedUsers))
+			return cachedUsers, nil
+		}
 	}
 
 	// Кэш пуст — идём в БД
@@ -643,7 +698,9 @@ func (s *Service) GetAllUsers(limit, offset int) ([]*models.User, error) {
 	}
 
 	// Сохраняем в кэш на 1 минуту
-	_ = s.cache.Set(ctx, cacheKey, users, 1*time.Minute)
+	if cached {
+		_ = s.cache.Set(ctx, cacheKey, users, 1*time.Minute)
+	}
 
 	return users, nil
 }
@@ -2,11 +2,21 @@
 package core_factory
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/export"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
 	"crypto-exchange-screener-bot/internal/core/domain/payment"
+	"crypto-exchange-screener-bot/internal/core/domain/rules"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/http_client"
 	infrastructure_factory "crypto-exchange-screener-bot/internal/infrastructure/package"
+	symbol_sector_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/symbol_sector"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/redis_storage/alert_index"
+	"crypto-exchange-screener-bot/internal/types"
 	"crypto-exchange-screener-bot/pkg/logger"
 	"fmt"
 	"sync"
@@ -316,6 +326,184 @@ func (f *CoreServiceFactory) CreateSubscriptionService() (*subscription.Service,
 	return f.subscriptionFactory.CreateSubscriptionService(databaseService.GetDB())
 }
 
+// CreateRulesService создает сервис пользовательских правил сигналов.
+// limits — проверка лимита правил по тарифу (обычно SubscriptionService), может быть nil.
+func (f *CoreServiceFactory) CreateRulesService(limits subscription.LimitChecker) (*rules.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	databaseService, err := f.infrastructureFactory.CreateDatabaseService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить DatabaseService: %w", err)
+	}
+
+	db := databaseService.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("соединение с базой данных не установлено")
+	}
+
+	return rules.NewService(db, limits), nil
+}
+
+// CreatePriceAlertService создает сервис ценовых алертов (Postgres + индекс в Redis).
+// limits — проверка лимита алертов по тарифу, может быть nil;
+// prices — ленивое получение хранилища цен для текущей цены при создании алерта.
+func (f *CoreServiceFactory) CreatePriceAlertService(limits subscription.LimitChecker, prices alerts.PriceStorageGetter) (*alerts.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	databaseService, err := f.infrastructureFactory.CreateDatabaseService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить DatabaseService: %w", err)
+	}
+
+	db := databaseService.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("соединение с базой данных не установлено")
+	}
+
+	redisService, err := f.infrastructureFactory.CreateRedisService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить RedisService: %w", err)
+	}
+
+	index, err := alert_index.NewAlertIndex(redisService)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать индекс алертов: %w", err)
+	}
+
+	return alerts.NewService(db, index, limits, prices), nil
+}
+
+// CreateStrengthService создает сервис рейтинга относительной силы.
+// Секторы читаются из Postgres; без базы используются секторы из конфигурации.
+func (f *CoreServiceFactory) CreateStrengthService(cfg strength.Config, sources strength.Sources) (*strength.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	var repo symbol_sector_repo.SymbolSectorRepository
+	if databaseService, err := f.infrastructureFactory.CreateDatabaseService(); err != nil {
+		logger.Warn("⚠️ StrengthService: DatabaseService недоступен (%v), секторы берутся из конфигурации", err)
+	} else if db := databaseService.GetDB(); db != nil {
+		repo = symbol_sector_repo.NewSymbolSectorRepository(db)
+	} else {
+		logger.Warn("⚠️ StrengthService: нет соединения с базой, секторы берутся из конфигурации")
+	}
+
+	return strength.NewService(cfg, repo, sources), nil
+}
+
+// CreateSignalJournalService создает сервис журнала сигналов
+func (f *CoreServiceFactory) CreateSignalJournalService() (*journal.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	databaseService, err := f.infrastructureFactory.CreateDatabaseService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить DatabaseService: %w", err)
+	}
+
+	db := databaseService.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("соединение с базой данных не установлено")
+	}
+
+	return journal.NewService(db), nil
+}
+
+// CreatePaperTradingService создает сервис paper trading.
+// prices и candles — ленивое получение хранилища цен и истории свечей (CandleSystem стартует позже).
+func (f *CoreServiceFactory) CreatePaperTradingService(prices paper.PriceStorageGetter, candles paper.CandleSourceGetter, config paper.Config) (*paper.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	databaseService, err := f.infrastructureFactory.CreateDatabaseService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить DatabaseService: %w", err)
+	}
+
+	db := databaseService.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("соединение с базой данных не установлено")
+	}
+
+	return paper.NewService(db, prices, candles, config), nil
+}
+
+// CreateExportService создает сервис выгрузок данных.
+// candles — ленивое получение истории свечей, limits — проверка лимита выгрузок тарифа (может быть nil).
+func (f *CoreServiceFactory) CreateExportService(candles export.CandleSourceGetter, limits subscription.LimitChecker, config export.Config) (*export.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	databaseService, err := f.infrastructureFactory.CreateDatabaseService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить DatabaseService: %w", err)
+	}
+
+	db := databaseService.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("соединение с базой данных не установлено")
+	}
+
+	var activity export.ActivitySource
+	if activityRepo, err := f.infrastructureFactory.GetActivityRepository(); err == nil && activityRepo != nil {
+		activity = activityRepo
+	} else {
+		logger.Warn("⚠️ Выгрузка активности недоступна: %v", err)
+	}
+
+	return export.NewService(db, activity, candles, limits, config), nil
+}
+
+// CreateMarketDigestService создает сервис дайджестов рынка.
+// sources — ленивое получение хранилища цен и ликвидаций, eventBus — публикация готовых дайджестов
+// (может быть nil, если сервис используется только для команд).
+func (f *CoreServiceFactory) CreateMarketDigestService(sources digest.Sources, eventBus types.EventBus, config digest.Config) (*digest.Service, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.initialized {
+		return nil, fmt.Errorf("фабрика ядра не инициализирована")
+	}
+
+	databaseService, err := f.infrastructureFactory.CreateDatabaseService()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить DatabaseService: %w", err)
+	}
+
+	db := databaseService.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("соединение с базой данных не установлено")
+	}
+
+	return digest.NewService(db, sources, eventBus, config), nil
+}
+
 // CreateAllServices создает все сервисы ядра
 func (f *CoreServiceFactory) CreateAllServices() (map[string]interface{}, error) {
 	f.mu.Lock()
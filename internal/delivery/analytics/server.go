@@ -0,0 +1,133 @@
+// internal/delivery/analytics/server.go
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// Server — внутренний HTTP-сервер аналитики сигналов для внешних дашбордов.
+// Слушает только на 127.0.0.1, защищён X-Internal-Secret.
+type Server struct {
+	journal *journal.Service
+	secret  string
+	port    int
+	server  *http.Server
+}
+
+// NewServer создаёт сервер аналитики
+func NewServer(journalSvc *journal.Service, port int, secret string) *Server {
+	return &Server{journal: journalSvc, port: port, secret: secret}
+}
+
+// Start запускает сервер. Неблокирующий.
+func (s *Server) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/analytics/signals", s.withSecret(s.handleSignals))
+	mux.HandleFunc("/health", s.handleHealth)
+
+	addr := fmt.Sprintf("127.0.0.1:%d", s.port)
+	s.server = &http.Server{
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("❌ Ошибка сервера аналитики: %v", err)
+		}
+	}()
+
+	logger.Info("✅ Сервер аналитики сигналов запущен на %s", addr)
+	return nil
+}
+
+// Stop останавливает сервер
+func (s *Server) Stop() error {
+	if s.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return s.server.Shutdown(ctx)
+	}
+	return nil
+}
+
+// withSecret проверяет заголовок X-Internal-Secret
+func (s *Server) withSecret(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Internal-Secret") != s.secret {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// GET /analytics/signals?days=7&horizon=1h&min_signals=10&top=5
+// Response: journal.AnalyticsReport
+func (s *Server) handleSignals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	values := r.URL.Query()
+	query := journal.AnalyticsQuery{Horizon: values.Get("horizon")}
+	for name, target := range map[string]*int{
+		"days":        &query.Days,
+		"min_signals": &query.MinSignals,
+		"top":         &query.Top,
+	} {
+		raw := values.Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s: ожидается целое число", name))
+			return
+		}
+		*target = n
+	}
+
+	if err := query.Normalize(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.journal.Analytics(query, time.Now())
+	if err != nil {
+		logger.Warn("⚠️ Аналитика сигналов: %v", err)
+		writeError(w, http.StatusInternalServerError, "не удалось построить отчет")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// GET /health
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "ok",
+		"server": "signal-analytics",
+		"time":   time.Now().Format(time.RFC3339),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]interface{}{"ok": false, "error": msg})
+}
@@ -0,0 +1,97 @@
+// internal/delivery/broadcast/broadcaster.go
+package broadcast
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"strings"
+)
+
+// PageSize — сколько пользователей загружается за один запрос
+const PageSize = 1000
+
+// Channel — платформа доставки (Telegram, MAX)
+type Channel interface {
+	// Platform возвращает имя платформы для логов ("telegram", "max")
+	Platform() string
+	// Reachable — пользователь активен, уведомления платформы включены и чат известен
+	Reachable(user *models.User) bool
+	// Send отправляет текст пользователю
+	Send(user *models.User, text string) error
+}
+
+// Broadcaster постранично обходит всех пользователей и отправляет сообщение тем,
+// кто доступен на платформе и прошел фильтр вызывающего контроллера.
+// Каждая успешная отправка передается в хук Deliveries.
+type Broadcaster struct {
+	userService *users.Service
+	channel     Channel
+	deliveries  *Deliveries
+}
+
+// New создает рассыльщик для платформы (deliveries может быть nil)
+func New(userService *users.Service, channel Channel, deliveries *Deliveries) *Broadcaster {
+	return &Broadcaster{
+		userService: userService,
+		channel:     channel,
+		deliveries:  deliveries,
+	}
+}
+
+// Platform возвращает имя платформы рассыльщика
+func (b *Broadcaster) Platform() string {
+	return b.channel.Platform()
+}
+
+// Broadcast отправляет сообщение всем подходящим пользователям.
+// message возвращает текст для пользователя и false, если ему отправлять не нужно;
+// delivery — шаблон записи доставки (nil — не записывать).
+// Возвращает число успешных отправок.
+func (b *Broadcaster) Broadcast(name string, delivery *models.SignalDelivery, message func(user *models.User) (string, bool)) (int, error) {
+	sent := 0
+	err := b.ForEachUser(func(user *models.User) {
+		if !b.channel.Reachable(user) {
+			return
+		}
+		text, ok := message(user)
+		if !ok {
+			return
+		}
+		if err := b.channel.Send(user, text); err != nil {
+			logger.Warn("⚠️ %s: ошибка отправки user=%d: %v", name, user.ID, err)
+			return
+		}
+		b.deliveries.Delivered(user.ID, b.Platform(), delivery, text)
+		sent++
+	})
+	return sent, err
+}
+
+// ForEachUser вызывает fn для каждого пользователя, загружая их страницами по PageSize
+func (b *Broadcaster) ForEachUser(fn func(user *models.User)) error {
+	for offset := 0; ; offset += PageSize {
+		page, err := b.userService.GetAllUsers(PageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, user := range page {
+			if user != nil {
+				fn(user)
+			}
+		}
+		if len(page) < PageSize {
+			return nil
+		}
+	}
+}
+
+// TracksSymbol проверяет исключения и вотчлист пользователя для символа
+func TracksSymbol(user *models.User, symbol string) bool {
+	for _, pattern := range user.ExcludePatterns {
+		if pattern != "" && strings.Contains(symbol, pattern) {
+			return false
+		}
+	}
+	return !user.HasWatchlist() || user.ShouldTrackSymbol(symbol)
+}
@@ -0,0 +1,71 @@
+// internal/delivery/broadcast/channels.go
+package broadcast
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"fmt"
+)
+
+// TelegramSender — отправка текста в Telegram (message_sender.MessageSender)
+type TelegramSender interface {
+	SendTextMessage(chatID int64, text string, keyboard interface{}) error
+}
+
+// MaxSender — отправка текста в MAX (max.Client)
+type MaxSender interface {
+	SendMessage(chatID int64, text string) error
+}
+
+type telegramChannel struct {
+	sender TelegramSender
+}
+
+// Telegram создает канал доставки в Telegram. MAX-only пользователи
+// в нем недоступны: их обслуживает канал MAX.
+func Telegram(sender TelegramSender) Channel {
+	return &telegramChannel{sender: sender}
+}
+
+func (c *telegramChannel) Platform() string {
+	return "telegram"
+}
+
+func (c *telegramChannel) Reachable(user *models.User) bool {
+	if user.IsMaxOnlyUser() || user.ChatID == "" {
+		return false
+	}
+	return user.IsActive && user.NotificationsEnabled
+}
+
+func (c *telegramChannel) Send(user *models.User, text string) error {
+	var chatID int64
+	if _, err := fmt.Sscanf(user.ChatID, "%d", &chatID); err != nil {
+		return fmt.Errorf("неверный chat_id %q", user.ChatID)
+	}
+	return c.sender.SendTextMessage(chatID, text, nil)
+}
+
+type maxChannel struct {
+	sender MaxSender
+}
+
+// Max создает канал доставки в MAX
+func Max(sender MaxSender) Channel {
+	return &maxChannel{sender: sender}
+}
+
+func (c *maxChannel) Platform() string {
+	return "max"
+}
+
+func (c *maxChannel) Reachable(user *models.User) bool {
+	return user.IsActive && user.MaxNotificationsEnabled && user.MaxChatID != ""
+}
+
+func (c *maxChannel) Send(user *models.User, text string) error {
+	var chatID int64
+	if _, err := fmt.Sscanf(user.MaxChatID, "%d", &chatID); err != nil {
+		return fmt.Errorf("невалидный MaxChatID %q", user.MaxChatID)
+	}
+	return c.sender.SendMessage(chatID, text)
+}
@@ -0,0 +1,90 @@
+// internal/delivery/broadcast/controller.go
+package broadcast
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+)
+
+// Spec описывает рассылку событий одного типа
+type Spec[T any] struct {
+	// Name — имя контроллера (для EventBus и логов)
+	Name string
+	// Event — тип события
+	Event types.EventType
+	// Filter — подписка и фильтры пользователя; доступность на платформе проверяет Channel
+	Filter func(user *models.User, data T) bool
+	// Format — текст сообщения, общий для всех получателей
+	Format func(data T) string
+	// FormatFor — текст, зависящий от настроек пользователя; если задан, используется вместо Format
+	FormatFor func(user *models.User, data T) (string, bool)
+	// Describe — краткое описание события для лога
+	Describe func(data T) string
+	// Delivery — шаблон записи доставки для истории и paper-портфеля; nil — не записывать
+	Delivery func(data T) *models.SignalDelivery
+}
+
+// Controller — подписчик EventBus, рассылающий событие через Broadcaster
+type Controller[T any] struct {
+	broadcaster *Broadcaster
+	spec        Spec[T]
+}
+
+// NewController создает контроллер рассылки
+func NewController[T any](broadcaster *Broadcaster, spec Spec[T]) *Controller[T] {
+	return &Controller[T]{
+		broadcaster: broadcaster,
+		spec:        spec,
+	}
+}
+
+// HandleEvent рассылает событие подходящим пользователям
+func (c *Controller[T]) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(T)
+	if !ok {
+		return fmt.Errorf("%s: неверный формат данных: %T", c.spec.Name, event.Data)
+	}
+
+	var text string
+	if c.spec.FormatFor == nil {
+		text = c.spec.Format(data)
+	}
+	var delivery *models.SignalDelivery
+	if c.spec.Delivery != nil {
+		delivery = c.spec.Delivery(data)
+	}
+
+	sent, err := c.broadcaster.Broadcast(c.spec.Name, delivery, func(user *models.User) (string, bool) {
+		if c.spec.Filter != nil && !c.spec.Filter(user, data) {
+			return "", false
+		}
+		if c.spec.FormatFor != nil {
+			return c.spec.FormatFor(user, data)
+		}
+		return text, true
+	})
+	if err != nil {
+		return fmt.Errorf("%s: ошибка получения пользователей: %w", c.spec.Name, err)
+	}
+
+	if sent > 0 {
+		description := string(c.spec.Event)
+		if c.spec.Describe != nil {
+			description = c.spec.Describe(data)
+		}
+		logger.Debug("📣 %s: %s — отправлено %d (%s)", c.spec.Name, description, sent, c.broadcaster.Platform())
+	}
+	return nil
+}
+
+// GetName возвращает имя контроллера
+func (c *Controller[T]) GetName() string {
+	return c.spec.Name
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (c *Controller[T]) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{c.spec.Event}
+}
@@ -0,0 +1,157 @@
+// internal/delivery/broadcast/deliveries.go
+package broadcast
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+	"time"
+)
+
+// DeliveryRecorder сохраняет доставленные пользователю сигналы для истории
+// (реализуется journal.Service)
+type DeliveryRecorder interface {
+	RecordDelivery(delivery *models.SignalDelivery) error
+}
+
+// SignalFollower отрабатывает доставленные сигналы в paper-портфеле пользователя
+// (реализуется paper.Service)
+type SignalFollower interface {
+	FollowSignal(delivery *models.SignalDelivery)
+}
+
+// Deliveries — общий хук доставки всех уведомлений (счетчик, паттерны, аномалии,
+// пробои, VWAP, алерты, правила): записывает доставку в историю пользователя
+// и передает ее в paper-портфель. Методы безопасны для nil.
+type Deliveries struct {
+	recorder DeliveryRecorder
+	follower SignalFollower
+}
+
+// NewDeliveries создает хук доставки. recorder и follower опциональны;
+// если оба nil, возвращается nil — доставки не записываются.
+func NewDeliveries(recorder DeliveryRecorder, follower SignalFollower) *Deliveries {
+	if recorder == nil && follower == nil {
+		return nil
+	}
+	return &Deliveries{
+		recorder: recorder,
+		follower: follower,
+	}
+}
+
+// Delivered фиксирует отправку text пользователю. template описывает сигнал
+// (символ, направление, период, цена); nil — событие без символа, не записывается.
+func (d *Deliveries) Delivered(userID int, platform string, template *models.SignalDelivery, text string) {
+	if d == nil || template == nil || template.Symbol == "" {
+		return
+	}
+
+	delivery := *template
+	delivery.UserID = userID
+	delivery.Platform = platform
+	delivery.CardText = text
+	if delivery.DeliveredAt.IsZero() {
+		delivery.DeliveredAt = time.Now()
+	}
+
+	if d.recorder != nil {
+		if err := d.recorder.RecordDelivery(&delivery); err != nil {
+			logger.Warn("⚠️ Не удалось сохранить доставку %s пользователю %d (%s): %v",
+				delivery.Symbol, userID, platform, err)
+		}
+	}
+	if d.follower != nil {
+		d.follower.FollowSignal(&delivery)
+	}
+}
+
+// Шаблоны доставок для событий рассылки. SignalID пуст: события анализаторов
+// не несут идентификатор записи журнала. Направление пусто у алертов и правил —
+// это условия пользователя, а не торговые сигналы, и paper-портфель их пропускает.
+
+// PatternDelivery — паттерн у зоны S/R
+func PatternDelivery(data types.PatternZoneData) *models.SignalDelivery {
+	return &models.SignalDelivery{
+		Symbol:        data.Symbol,
+		Direction:     data.Direction,
+		PeriodMinutes: periodMinutes(data.Period),
+		Price:         data.Price,
+	}
+}
+
+// AnomalyDelivery — аномальная доходность свечи
+func AnomalyDelivery(data types.ReturnAnomalyData) *models.SignalDelivery {
+	return &models.SignalDelivery{
+		Symbol:        data.Symbol,
+		Direction:     data.Direction,
+		PeriodMinutes: periodMinutes(data.Period),
+		ChangePercent: data.ChangePct,
+		Price:         data.Price,
+	}
+}
+
+// RangeDelivery — пробой диапазона; изменение — выход за экстремум самого длинного горизонта
+func RangeDelivery(data types.RangeBreakoutData) *models.SignalDelivery {
+	direction := "growth"
+	if data.Kind == "low" {
+		direction = "fall"
+	}
+	var distance float64
+	if longest, ok := data.Longest(nil); ok {
+		distance = longest.DistancePct
+	}
+	return &models.SignalDelivery{
+		Symbol:        data.Symbol,
+		Direction:     direction,
+		ChangePercent: distance,
+		Price:         data.Price,
+	}
+}
+
+// VWAPDelivery — сигнал VWAP; рост — цена выше VWAP (отклонение вверх или возврат)
+func VWAPDelivery(data types.VWAPSignalData) *models.SignalDelivery {
+	direction := "growth"
+	if data.Kind == types.VWAPDeviationDown || data.Kind == types.VWAPLoss {
+		direction = "fall"
+	}
+	return &models.SignalDelivery{
+		Symbol:        data.Symbol,
+		Direction:     direction,
+		ChangePercent: data.DistancePct,
+		Price:         data.Price,
+	}
+}
+
+// WallPullDelivery — снятие стены стакана; SignalID связывает доставку с записью журнала
+func WallPullDelivery(data types.WallPullData) *models.SignalDelivery {
+	return &models.SignalDelivery{
+		SignalID:  data.SignalID,
+		Symbol:    data.Symbol,
+		Direction: data.Direction,
+		Price:     data.Mid,
+	}
+}
+
+// AlertDelivery — срабатывание ценового алерта
+func AlertDelivery(data types.PriceAlertTriggeredData) *models.SignalDelivery {
+	return &models.SignalDelivery{
+		Symbol: data.Symbol,
+		Price:  data.Price,
+	}
+}
+
+// RuleDelivery — срабатывание пользовательского правила
+func RuleDelivery(data types.RuleTriggeredData) *models.SignalDelivery {
+	return &models.SignalDelivery{
+		Symbol:        data.Symbol,
+		PeriodMinutes: periodMinutes(data.Period),
+		Price:         data.Price,
+	}
+}
+
+func periodMinutes(period string) int {
+	minutes, _ := periodPkg.StringToMinutes(period)
+	return minutes
+}
@@ -0,0 +1,79 @@
+// internal/delivery/max/alert_controller.go
+package max
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// AlertController доставляет срабатывания ценовых алертов владельцу
+// алерта в MAX (если у него включены MAX-уведомления).
+type AlertController struct {
+	client      *Client
+	userService *users.Service
+	deliveries  *broadcast.Deliveries
+}
+
+// NewAlertController создаёт контроллер
+func NewAlertController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *AlertController {
+	return &AlertController{
+		client:      client,
+		userService: userSvc,
+		deliveries:  deliveries,
+	}
+}
+
+// GetName возвращает имя контроллера
+func (c *AlertController) GetName() string {
+	return "max_alert_controller"
+}
+
+// GetSubscribedEvents возвращает список подписанных событий
+func (c *AlertController) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventPriceAlertTriggered}
+}
+
+// HandleEvent обрабатывает событие срабатывания ценового алерта
+func (c *AlertController) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.PriceAlertTriggeredData)
+	if !ok {
+		return fmt.Errorf("max alert_controller: неверный формат данных события")
+	}
+
+	user, err := c.userService.GetUserByID(data.UserID)
+	if err != nil || user == nil {
+		return fmt.Errorf("max alert_controller: пользователь %d не найден: %v", data.UserID, err)
+	}
+	if !user.IsActive || !user.MaxNotificationsEnabled || user.MaxChatID == "" {
+		return nil
+	}
+
+	chatID, err := maxChatIDInt64(user.MaxChatID)
+	if err != nil {
+		logger.Warn("⚠️ MAX AlertController: невалидный MaxChatID user=%d: %v", user.ID, err)
+		return nil
+	}
+
+	text := formatAlertText(data)
+	if err := c.client.SendMessage(chatID, text); err != nil {
+		logger.Warn("⚠️ MAX AlertController: ошибка отправки алерта #%d user=%d: %v", data.AlertID, user.ID, err)
+		return err
+	}
+	c.deliveries.Delivered(user.ID, "max", broadcast.AlertDelivery(data), text)
+	return nil
+}
+
+// formatAlertText форматирует уведомление о срабатывании алерта
+func formatAlertText(data types.PriceAlertTriggeredData) string {
+	text := fmt.Sprintf("🔔 Алерт #%d сработал\n📛 %s — %s\n💰 Цена: %s\n",
+		data.AlertID, data.Symbol, data.Description, alerts.FormatPrice(data.Price))
+	if data.Recurring {
+		text += "🔁 Алерт остаётся активным\n"
+	}
+	return text + fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05"))
+}
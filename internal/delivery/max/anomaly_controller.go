@@ -0,0 +1,60 @@
+// internal/delivery/max/anomaly_controller.go
+package max
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// AnomalyController рассылает z-score сигналы MAX-пользователям, чей порог
+// чувствительности (в сигмах) не выше z-score свечи.
+type AnomalyController = broadcast.Controller[types.ReturnAnomalyData]
+
+// NewAnomalyController создаёт контроллер
+func NewAnomalyController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *AnomalyController {
+	return broadcast.NewController(broadcast.New(userSvc, broadcast.Max(client), deliveries),
+		broadcast.Spec[types.ReturnAnomalyData]{
+			Name:   "max_anomaly_controller",
+			Event:  types.EventReturnAnomaly,
+			Filter: shouldSendAnomalyToUser,
+			Format: formatAnomalyText,
+			Describe: func(data types.ReturnAnomalyData) string {
+				return fmt.Sprintf("%s/%s z=%.1f", data.Symbol, data.Period, data.ZScore)
+			},
+			Delivery: broadcast.AnomalyDelivery,
+		})
+}
+
+// shouldSendAnomalyToUser проверяет порог в сигмах, направление и фильтры символов
+func shouldSendAnomalyToUser(user *models.User, data types.ReturnAnomalyData) bool {
+	if user.SensitivitySigma <= 0 || math.Abs(data.ZScore) < user.SensitivitySigma {
+		return false
+	}
+	if data.Direction == "growth" && !user.NotifyGrowth || data.Direction == "fall" && !user.NotifyFall {
+		return false
+	}
+	return broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatAnomalyText форматирует уведомление о необычном движении
+func formatAnomalyText(data types.ReturnAnomalyData) string {
+	var b strings.Builder
+
+	icon := "🔴"
+	if data.Direction == "growth" {
+		icon = "🟢"
+	}
+
+	b.WriteString(fmt.Sprintf("📐 Необычное движение: %.1fσ\n", math.Abs(data.ZScore)))
+	b.WriteString(fmt.Sprintf("%s %s • %s • %+.2f%% • цена %.6g\n",
+		icon, data.Symbol, data.Period, data.ChangePct, data.Price))
+	b.WriteString(fmt.Sprintf("📊 Обычно: %+.2f%% ± %.2f%% (по %d свечам)\n", data.Median, data.Sigma, data.Samples))
+	b.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return b.String()
+}
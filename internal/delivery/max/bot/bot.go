@@ -10,10 +10,13 @@ import (
 	"crypto-exchange-screener-bot/internal/delivery/auth"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/router"
+	cbAlertNew "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/alert_new"
+	cmdAlert "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/alert"
 	cbWatchlistToggle "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/watchlist_toggle"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/message_sender"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/middleware"
 	"crypto-exchange-screener-bot/internal/delivery/max/transport"
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
 	watchlistSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/watchlist"
 	"crypto-exchange-screener-bot/pkg/logger"
@@ -31,6 +34,7 @@ type Bot struct {
 	mode             string // "polling" или "webhook"
 	userService      *users.Service
 	watchlistService watchlistSvc.Service
+	alertService     *alerts.Service
 }
 
 // NewBot создаёт MAX бота с зарегистрированными хэндлерами
@@ -45,6 +49,7 @@ func NewBot(client *maxpkg.Client, deps Dependencies) *Bot {
 		mode:             "polling", // По умолчанию polling
 		userService:      deps.UserService,
 		watchlistService: deps.WatchlistService,
+		alertService:     deps.AlertService,
 	}
 
 	// Регистрируем все хэндлеры
@@ -89,6 +94,18 @@ func (b *Bot) HandleUpdate(upd maxpkg.Update) {
 				return
 			}
 		}
+
+		// FSM: ввод условия нового ценового алерта
+		if text != "" && !strings.HasPrefix(text, "/") &&
+			b.userService != nil && b.alertService != nil {
+			state, _ := b.userService.GetUserState(params.User.ID)
+			if state == cbAlertNew.StateAlertCreate {
+				_ = b.userService.ClearUserState(params.User.ID)
+				result := cmdAlert.ExecuteCreate(b.alertService, params.User.ID, text)
+				_ = b.sender.SendMenuMessage(params.ChatID, result.Message, result.Keyboard)
+				return
+			}
+		}
 	}
 
 	// Определяем команду/callback для маршрутизации
@@ -111,7 +128,7 @@ func (b *Bot) HandleUpdate(upd maxpkg.Update) {
 
 	// Отвечаем на callback (убираем loading spinner)
 	if params.CallbackID != "" {
-		if cbErr := b.sender.AnswerCallback(params.CallbackID, ""); cbErr != nil {
+		if cbErr := b.sender.AnswerCallback(params.CallbackID, result.Notification); cbErr != nil {
 			logger.Debug("⚠️ MAX AnswerCallback: %v", cbErr)
 		}
 	}
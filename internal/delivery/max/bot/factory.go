@@ -4,10 +4,20 @@ package bot
 import (
 	"time"
 
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
 	"crypto-exchange-screener-bot/internal/delivery/auth"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/message_sender"
+	cbAlertDelete "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/alert_delete"
+	cbAlertNew "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/alert_new"
+	cbAlertsMenu "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/alerts_menu"
 	cbAuthLogin "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/auth_login"
 	cbAuthLogout "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/auth_logout"
 	cbHelp "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/help"
@@ -26,6 +36,16 @@ import (
 	cbResetMenu "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/reset_menu"
 	cbResetSettings "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/reset_settings"
 	cbSettingsMain "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/settings_main"
+	cbSignalToggleMarket "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_toggle_market_filter"
+	cbSignalTogglePatterns "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_toggle_pattern_zones"
+	cbSignalToggleSectors "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_toggle_sector_digest"
+	cbRangeHorizons "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/range_horizons"
+	cbSignalHistory "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_history"
+	cbSignalFeedback "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_feedback"
+	cbSignalToggleVWAP "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_toggle_vwap"
+	cbSignalToggleWalls "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_toggle_walls"
+	cbSignalSetConfluence "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_set_confluence"
+	cbSignalSetSensitivity "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_set_sensitivity"
 	cbSignalSetFall "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_set_fall_threshold"
 	cbSignalSetGrowth "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_set_growth_threshold"
 	cbSignalToggleFall "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/signal_toggle_fall"
@@ -49,6 +69,12 @@ import (
 	cbWatchlistReset   "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/watchlist_reset"
 	cbWatchlistSearch  "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/watchlist_search"
 	cbWatchlistToggle  "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/watchlist_toggle"
+	cmdAlert       "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/alert"
+	cmdTop         "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/top"
+	cmdVWAP        "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/vwap"
+	cmdPaper       "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/paper"
+	cmdDigest      "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/digest"
+	cmdRegime      "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/regime"
 	cmdHelp        "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/help"
 	cmdLink       "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/link"
 	cmdPaysupport "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/paysupport"
@@ -82,6 +108,13 @@ type Dependencies struct {
 	TBankService        tbankSvc.Service        // nil — если Т-Банк не настроен
 	SubscriptionService *subscription.Service   // nil — если проверка подписки отключена
 	WatchlistService    watchlistSvc.Service    // nil — если вотчлист не настроен
+	AlertService        *alerts.Service         // nil — если ценовые алерты отключены
+	StrengthService     *strength.Service       // nil — если рейтинг силы отключён
+	VWAPTracker         func() *vwap.Tracker    // nil — если CandleSystem недоступна
+	SignalJournal       *journal.Service        // nil — если журнал сигналов отключён
+	PaperTrading        *paper.Service          // nil — если paper trading отключён
+	MarketDigest        *digest.Service         // nil — если дайджест рынка отключён
+	MarketRegime        func() *regime.Classifier // nil — если классификатор режима отключён
 	MaxTBankSuccessURL  string                  // URL редиректа после успешной оплаты (MAX)
 	MaxTBankFailURL     string                  // URL редиректа после неудачной оплаты (MAX)
 	AuthConfig          *AuthConfig             // nil — если auth-сервер отключён
@@ -157,6 +190,15 @@ func RegisterAll(r router.Router, deps Dependencies) {
 	r.RegisterCallback(kb.CbSignalToggleFall, protect(cbSignalToggleFall.New(deps.SignalService)))
 	r.RegisterCallback(kb.CbSignalSetGrowthThreshold, protect(cbSignalSetGrowth.New(deps.SignalService)))
 	r.RegisterCallback(kb.CbSignalSetFallThreshold, protect(cbSignalSetFall.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalSetConfluence, protect(cbSignalSetConfluence.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalSetSensitivity, protect(cbSignalSetSensitivity.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalToggleMarket, protect(cbSignalToggleMarket.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalTogglePatterns, protect(cbSignalTogglePatterns.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalToggleSectors, protect(cbSignalToggleSectors.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbRangeHorizonsMenu, protect(cbRangeHorizons.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbRangeHorizonToggleWildcard, protect(cbRangeHorizons.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalToggleVWAP, protect(cbSignalToggleVWAP.New(deps.SignalService)))
+	r.RegisterCallback(kb.CbSignalToggleWalls, protect(cbSignalToggleWalls.New(deps.SignalService)))
 
 	// ── Callback: периоды (защищённые) ──────────────────────
 	r.RegisterCallback(kb.CbPeriodsMenu, protect(cbPeriodsMenu.New()))
@@ -218,4 +260,49 @@ func RegisterAll(r router.Router, deps Dependencies) {
 		r.RegisterCallback(kb.CbWatchlistToggleWildcard, protect(cbWatchlistToggle.New(deps.WatchlistService)))
 		r.RegisterCallback(kb.CbWatchlistLetterWildcard, protect(cbWatchlistToggle.NewLetterHandler(deps.WatchlistService)))
 	}
+
+	// Команды и callback: ценовые алерты (защищённые)
+	if deps.AlertService != nil {
+		r.RegisterCommand("alert", protect(cmdAlert.New(deps.AlertService)))
+		r.RegisterCommand("alerts", protect(cbAlertsMenu.New(deps.AlertService)))
+		r.RegisterCallback(kb.CbAlertsMenu, protect(cbAlertsMenu.New(deps.AlertService)))
+		r.RegisterCallback(kb.CbAlertNew, protect(cbAlertNew.New(deps.UserService)))
+		r.RegisterCallback(kb.CbAlertDeleteWildcard, protect(cbAlertDelete.New(deps.AlertService)))
+	}
+
+	// Команда и callback: рейтинг относительной силы (защищённые)
+	if deps.StrengthService != nil {
+		r.RegisterCommand("top", protect(cmdTop.New(deps.StrengthService)))
+		r.RegisterCallback(kb.CbTopPeriodWildcard, protect(cmdTop.NewPeriodHandler(deps.StrengthService)))
+	}
+
+	// Команда: VWAP сессии и якоря (защищённая)
+	if deps.VWAPTracker != nil {
+		r.RegisterCommand("vwap", protect(cmdVWAP.New(deps.VWAPTracker, deps.SignalService)))
+	}
+
+	// Команда и callback: история сигналов (защищённые)
+	if deps.SignalJournal != nil {
+		r.RegisterCommand("history", protect(cbSignalHistory.NewCommand(deps.SignalJournal)))
+		r.RegisterCallback(kb.CbSignalHistory, protect(cbSignalHistory.New(deps.SignalJournal)))
+		r.RegisterCallback(kb.CbSignalHistoryPageWildcard, protect(cbSignalHistory.NewPage(deps.SignalJournal)))
+		r.RegisterCallback(kb.CbSignalCardWildcard, protect(cbSignalHistory.NewCard(deps.SignalJournal)))
+		// Оценка полученного сигнала — без проверки подписки
+		r.RegisterCallback(kb.CbSignalFeedbackWildcard, cbSignalFeedback.New(deps.SignalJournal))
+	}
+
+	// Команда: paper trading по сигналам (защищённая)
+	if deps.PaperTrading != nil {
+		r.RegisterCommand("paper", protect(cmdPaper.New(deps.PaperTrading)))
+	}
+
+	// Команда: дайджест рынка (защищённая)
+	if deps.MarketDigest != nil {
+		r.RegisterCommand("digest", protect(cmdDigest.New(deps.MarketDigest)))
+	}
+
+	// Команда: режим рынка и пороги по режимам (защищённая)
+	if deps.MarketRegime != nil {
+		r.RegisterCommand("regime", protect(cmdRegime.New(deps.MarketRegime, deps.SignalService)))
+	}
 }
@@ -0,0 +1,56 @@
+// internal/delivery/max/bot/handlers/callbacks/alert_delete/handler.go
+// Обрабатывает: alert_del_{ID}
+package alert_delete
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	cbAlertsMenu "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/callbacks/alerts_menu"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+)
+
+// Handler удаляет алерт и показывает обновлённый список
+type Handler struct {
+	*base.BaseHandler
+	alertService *alertsDomain.Service
+}
+
+// New создаёт обработчик alert_del_{ID}
+func New(alertService *alertsDomain.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler:  base.New("alert_delete", kb.CbAlertDeleteWildcard, handlers.TypeCallback),
+		alertService: alertService,
+	}
+}
+
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	alertID, err := strconv.Atoi(strings.TrimPrefix(params.Data, kb.CbAlertDeleteBase))
+	if err != nil || alertID <= 0 {
+		return handlers.HandlerResult{}, fmt.Errorf("неверный номер алерта: %s", params.Data)
+	}
+
+	header := fmt.Sprintf("🗑 Алерт #%d удалён", alertID)
+	if err := h.alertService.DeleteAlert(params.User.ID, alertID); err != nil {
+		if !errors.Is(err, alertsDomain.ErrAlertNotFound) {
+			return handlers.HandlerResult{}, err
+		}
+		header = fmt.Sprintf("❌ Алерт #%d не найден", alertID)
+	}
+
+	result, err := cbAlertsMenu.BuildMenu(h.alertService, params.User.ID, header)
+	if err != nil {
+		return handlers.HandlerResult{}, err
+	}
+	result.EditMessage = params.MessageID != ""
+	return result, nil
+}
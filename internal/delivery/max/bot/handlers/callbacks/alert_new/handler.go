@@ -0,0 +1,42 @@
+// internal/delivery/max/bot/handlers/callbacks/alert_new/handler.go
+// Устанавливает состояние FSM "alert_create"
+package alert_new
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	cmdAlert "crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/commands/alert"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+)
+
+// StateAlertCreate — ключ FSM состояния
+const StateAlertCreate = "alert_create"
+
+// Handler устанавливает состояние ввода условия алерта
+type Handler struct {
+	*base.BaseHandler
+	userService *users.Service
+}
+
+// New создаёт обработчик кнопки «Новый алерт»
+func New(userService *users.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("alert_new", kb.CbAlertNew, handlers.TypeCallback),
+		userService: userService,
+	}
+}
+
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if err := h.userService.SetUserState(params.User.ID, StateAlertCreate); err != nil {
+		return handlers.HandlerResult{}, err
+	}
+
+	return handlers.HandlerResult{
+		Message: "🔔 Отправьте условие алерта.\n\n" + cmdAlert.UsageText,
+		Keyboard: kb.Keyboard([][]map[string]string{
+			{kb.B("🔙 Отмена", kb.CbAlertsMenu)},
+		}),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
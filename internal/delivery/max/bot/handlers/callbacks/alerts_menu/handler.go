@@ -0,0 +1,83 @@
+// internal/delivery/max/bot/handlers/callbacks/alerts_menu/handler.go
+// Список ценовых алертов пользователя с кнопками удаления (кнопка и /alerts)
+package alerts_menu
+
+import (
+	"fmt"
+	"strings"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+)
+
+// Handler показывает алерты пользователя
+type Handler struct {
+	*base.BaseHandler
+	alertService *alertsDomain.Service
+}
+
+// New создаёт обработчик alerts_menu
+func New(alertService *alertsDomain.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler:  base.New("alerts_menu", kb.CbAlertsMenu, handlers.TypeCallback),
+		alertService: alertService,
+	}
+}
+
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+	result, err := BuildMenu(h.alertService, params.User.ID, "")
+	if err != nil {
+		return handlers.HandlerResult{}, err
+	}
+	result.EditMessage = params.MessageID != ""
+	return result, nil
+}
+
+// BuildMenu формирует список алертов; header — необязательная строка над списком
+func BuildMenu(alertService *alertsDomain.Service, userID int, header string) (handlers.HandlerResult, error) {
+	list, err := alertService.ListAlerts(userID)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("не удалось загрузить алерты: %w", err)
+	}
+
+	var sb strings.Builder
+	if header != "" {
+		sb.WriteString(header + "\n\n")
+	}
+	sb.WriteString("🔔 Ценовые алерты\n\n")
+
+	var rows [][]map[string]string
+	if len(list) == 0 {
+		sb.WriteString("У вас нет активных алертов.\n\nНажмите «➕ Новый алерт» или отправьте /alert SOL 180.")
+	} else {
+		var row []map[string]string
+		for _, alert := range list {
+			sb.WriteString(fmt.Sprintf("#%d %s — %s\n", alert.ID, alert.Symbol, alertsDomain.Describe(alert)))
+
+			row = append(row, kb.B(fmt.Sprintf("🗑 #%d", alert.ID), fmt.Sprintf("%s%d", kb.CbAlertDeleteBase, alert.ID)))
+			if len(row) == 3 {
+				rows = append(rows, row)
+				row = nil
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+		sb.WriteString("\nНажмите 🗑, чтобы удалить алерт.")
+	}
+
+	rows = append(rows,
+		[]map[string]string{kb.B("➕ Новый алерт", kb.CbAlertNew)},
+		kb.BackRow(kb.CbMenuMain),
+	)
+
+	return handlers.HandlerResult{
+		Message:  sb.String(),
+		Keyboard: kb.Keyboard(rows),
+	}, nil
+}
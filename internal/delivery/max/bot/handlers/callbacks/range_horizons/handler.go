@@ -0,0 +1,97 @@
+// internal/delivery/max/bot/handlers/callbacks/range_horizons/handler.go
+// Обрабатывает: range_horizons и range_horizon_{HORIZON}
+package range_horizons
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+)
+
+// Handler — меню и переключатели горизонтов пробоев диапазона
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("range_horizons", kb.CbRangeHorizonsMenu, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	status := ""
+	if strings.HasPrefix(params.Data, kb.CbRangeHorizonToggleBase) {
+		result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+			Action: "toggle_range_horizon",
+			UserID: user.ID,
+			Value:  strings.TrimPrefix(params.Data, kb.CbRangeHorizonToggleBase),
+		})
+		if err != nil {
+			return handlers.HandlerResult{
+				Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+				Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+				EditMessage: params.MessageID != "",
+			}, nil
+		}
+		if updated, ok := result.NewValue.([]string); ok {
+			user.RangeBreakoutHorizons = updated
+		}
+		status = result.Message + "\n\n"
+	}
+
+	msg := fmt.Sprintf(
+		"📐 Пробои диапазона\n\n%s"+
+			"Бот следит за максимумами и минимумами монет за 1, 7, 30 и 90 дней и за всю "+
+			"загруженную историю. В алерте — прежний экстремум, его дата и расстояние до него. "+
+			"При пробое нескольких горизонтов приходит одно сообщение по самому длинному из выбранных.\n\n"+
+			"Выбрано: %s",
+		status,
+		describeHorizons(user),
+	)
+
+	rows := make([][]map[string]string, 0, len(ranges.Horizons)+1)
+	for _, horizon := range ranges.Horizons {
+		mark := "❌"
+		if user.SubscribesRangeHorizon(string(horizon)) {
+			mark = "✅"
+		}
+		rows = append(rows, []map[string]string{
+			kb.B(horizon.Label()+" "+mark, kb.CbRangeHorizonToggleBase+string(horizon)),
+		})
+	}
+	rows = append(rows, kb.BackRow(kb.CbSignalsMenu))
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard(rows),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
+
+// describeHorizons возвращает выбранные горизонты через запятую
+func describeHorizons(user *models.User) string {
+	if len(user.RangeBreakoutHorizons) == 0 {
+		return "ничего (алерты выключены)"
+	}
+	labels := make([]string, 0, len(user.RangeBreakoutHorizons))
+	for _, code := range user.RangeBreakoutHorizons {
+		labels = append(labels, ranges.Horizon(code).Label())
+	}
+	return strings.Join(labels, ", ")
+}
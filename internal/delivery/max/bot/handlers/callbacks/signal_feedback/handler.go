@@ -0,0 +1,63 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_feedback/handler.go
+// Оценка сигнала кнопками под карточкой (signal_fb_{VOTE}_{SIGNAL_ID}).
+// Ответ — всплывающее уведомление, карточка сигнала не меняется.
+package signal_feedback
+
+import (
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// platform — платформа доставок в журнале
+const platform = "max"
+
+// voteAnswers — ответы на голоса
+var voteAnswers = map[string]string{
+	models.FeedbackUp:    "👍 Спасибо! Сигнал отмечен полезным",
+	models.FeedbackDown:  "👎 Спасибо! Учтём при настройке сигналов",
+	models.FeedbackLate:  "⏰ Спасибо! Отмечено: сигнал пришёл поздно",
+	models.FeedbackNoise: "🔇 Отмечено как шум. Если символ часто шумит, порог по нему для вас повысится",
+}
+
+// Handler сохраняет оценку сигнала пользователем
+type Handler struct {
+	*base.BaseHandler
+	journal *journal.Service
+}
+
+// New создаёт обработчик оценки сигнала
+func New(journalService *journal.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_feedback", kb.CbSignalFeedbackWildcard, handlers.TypeCallback),
+		journal:     journalService,
+	}
+}
+
+// Execute сохраняет голос и отвечает всплывающим уведомлением
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Notification: "❌ Пользователь не найден"}, nil
+	}
+
+	vote, signalID, _ := strings.Cut(strings.TrimPrefix(params.Data, kb.CbSignalFeedbackBase), "_")
+	answer, ok := voteAnswers[vote]
+	if !ok || signalID == "" {
+		return handlers.HandlerResult{Notification: "❌ Неизвестная оценка"}, nil
+	}
+
+	found, err := h.journal.Vote(params.User.ID, platform, signalID, vote)
+	if err != nil {
+		logger.Warn("⚠️ MAX: не удалось сохранить оценку сигнала %s пользователя %d: %v", signalID, params.User.ID, err)
+		return handlers.HandlerResult{Notification: "❌ Не удалось сохранить оценку, попробуйте позже"}, nil
+	}
+	if !found {
+		return handlers.HandlerResult{Notification: "Сигнал уже удалён из истории"}, nil
+	}
+	return handlers.HandlerResult{Notification: answer}, nil
+}
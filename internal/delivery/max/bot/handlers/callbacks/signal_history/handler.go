@@ -0,0 +1,214 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_history/handler.go
+// История полученных сигналов (/history [фильтры], кнопка меню сигналов,
+// страницы signal_history_{QUERY} и карточки signal_card_{ID}_{QUERY})
+package signal_history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+)
+
+// platform — платформа доставок в журнале
+const platform = "max"
+
+// Handler показывает историю сигналов пользователя с исходами
+type Handler struct {
+	*base.BaseHandler
+	journal *journal.Service
+}
+
+// New создаёт обработчик кнопки истории сигналов
+func New(journalService *journal.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_history", kb.CbSignalHistory, handlers.TypeCallback),
+		journal:     journalService,
+	}
+}
+
+// NewCommand создаёт обработчик команды /history [фильтры]
+func NewCommand(journalService *journal.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("history_command", "/history", handlers.TypeCommand),
+		journal:     journalService,
+	}
+}
+
+// NewPage создаёт обработчик страниц и фильтров истории
+func NewPage(journalService *journal.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_history_page", kb.CbSignalHistoryPageWildcard, handlers.TypeCallback),
+		journal:     journalService,
+	}
+}
+
+// NewCard создаёт обработчик карточки сигнала
+func NewCard(journalService *journal.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_card", kb.CbSignalCardWildcard, handlers.TypeCallback),
+		journal:     journalService,
+	}
+}
+
+// Execute показывает список или карточку в зависимости от callback
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+	if h.journal == nil {
+		return handlers.HandlerResult{
+			Message:  "❌ История сигналов недоступна",
+			Keyboard: kb.Keyboard([][]map[string]string{kb.BackRow(kb.CbSignalsMenu)}),
+		}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(params.Data, kb.CbSignalCardBase):
+		return h.showCard(params)
+	case strings.HasPrefix(params.Data, kb.CbSignalHistoryPageBase):
+		query := journal.ParseQuery(strings.TrimPrefix(params.Data, kb.CbSignalHistoryPageBase))
+		return h.showList(params, query)
+	case params.Data == kb.CbSignalHistory:
+		return h.showList(params, journal.HistoryQuery{})
+	default:
+		query, err := journal.ParseHistoryArgs(params.Data, time.Now())
+		if err != nil {
+			return handlers.HandlerResult{
+				Message: fmt.Sprintf("❌ %v\n\nПример: /history BTC рост 15m неделя или /history 01.03-15.03", err),
+			}, nil
+		}
+		return h.showList(params, query)
+	}
+}
+
+// showList показывает страницу истории
+func (h *Handler) showList(params handlers.HandlerParams, query journal.HistoryQuery) (handlers.HandlerResult, error) {
+	page, err := h.journal.History(params.User.ID, platform, query)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка загрузки истории сигналов: %w", err)
+	}
+	query = query.WithPage(page.Page)
+
+	return handlers.HandlerResult{
+		Message:     formatList(page, query),
+		Keyboard:    listKeyboard(page, query),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
+
+// showCard показывает сохранённую карточку сигнала с исходом
+func (h *Handler) showCard(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	rest := strings.TrimPrefix(params.Data, kb.CbSignalCardBase)
+	idPart, encoded, _ := strings.Cut(rest, "_")
+	query := journal.ParseQuery(encoded)
+
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return h.showList(params, query)
+	}
+
+	item, err := h.journal.Delivery(params.User.ID, id)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка загрузки сигнала: %w", err)
+	}
+
+	back := kb.Keyboard([][]map[string]string{{kb.B("🔙 К истории", listCallback(query))}})
+	if item == nil {
+		return handlers.HandlerResult{
+			Message:     "❌ Сигнал не найден — возможно, он удалён по сроку хранения",
+			Keyboard:    back,
+			EditMessage: params.MessageID != "",
+		}, nil
+	}
+
+	return handlers.HandlerResult{
+		Message: fmt.Sprintf("%s\n\n📈 %s\n🕐 Получен: %s",
+			item.CardText, journal.OutcomeDetails(item), item.DeliveredAt.Format("02.01.2006 15:04")),
+		Keyboard:    back,
+		EditMessage: params.MessageID != "",
+	}, nil
+}
+
+// formatList форматирует страницу истории
+func formatList(page journal.HistoryPage, query journal.HistoryQuery) string {
+	var b strings.Builder
+
+	b.WriteString("📊 История сигналов\n")
+	b.WriteString(fmt.Sprintf("🔎 %s\n\n", query.Describe()))
+
+	if page.Total == 0 {
+		b.WriteString("Сигналов по этим фильтрам нет.\n\n")
+		b.WriteString("Фильтры: /history BTC рост 15m неделя, даты — /history 01.03-15.03")
+		return b.String()
+	}
+
+	for i, item := range page.Items {
+		icon := "🟢"
+		if item.Direction == "fall" {
+			icon = "🔴"
+		}
+		b.WriteString(fmt.Sprintf("%d. %s %s %+.2f%% за %s • %s\n   %s\n",
+			i+1, icon, item.Symbol, item.ChangePercent,
+			periodPkg.MinutesToString(item.PeriodMinutes),
+			item.DeliveredAt.Format("02.01 15:04"),
+			journal.OutcomeSummary(item),
+		))
+	}
+
+	b.WriteString(fmt.Sprintf("\nСтраница %d из %d • всего %d", page.Page+1, page.Pages, page.Total))
+	return b.String()
+}
+
+// listKeyboard — карточки, фильтры и навигация по страницам
+func listKeyboard(page journal.HistoryPage, query journal.HistoryQuery) interface{} {
+	var rows [][]map[string]string
+
+	var cards []map[string]string
+	for i, item := range page.Items {
+		cards = append(cards, kb.B(strconv.Itoa(i+1), fmt.Sprintf("%s%d_%s", kb.CbSignalCardBase, item.ID, query.Encode())))
+		if len(cards) == 4 {
+			rows = append(rows, cards)
+			cards = nil
+		}
+	}
+	if len(cards) > 0 {
+		rows = append(rows, cards)
+	}
+
+	rows = append(rows, []map[string]string{
+		kb.B("↕️ "+query.DirectionLabel(), listCallback(query.NextDirection())),
+		kb.B("⏱ "+query.PeriodLabel(), listCallback(query.NextPeriod())),
+		kb.B("📅 "+query.RangeLabel(), listCallback(query.NextRange())),
+	})
+	if query.Symbol != "" {
+		reset := query
+		reset.Symbol = ""
+		rows = append(rows, []map[string]string{kb.B("✖️ "+query.Symbol, listCallback(reset.WithPage(0)))})
+	}
+
+	var nav []map[string]string
+	if page.Page > 0 {
+		nav = append(nav, kb.B("⬅️", listCallback(query.WithPage(page.Page-1))))
+	}
+	if page.Page+1 < page.Pages {
+		nav = append(nav, kb.B("➡️", listCallback(query.WithPage(page.Page+1))))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, kb.BackRow(kb.CbSignalsMenu))
+	return kb.Keyboard(rows)
+}
+
+// listCallback — payload страницы истории
+func listCallback(query journal.HistoryQuery) string {
+	return kb.CbSignalHistoryPageBase + query.Encode()
+}
@@ -0,0 +1,111 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_set_confluence/handler.go
+package signal_set_confluence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// Handler — обработчик настройки минимальной согласованности со старшими таймфреймами
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_set_confluence", kb.CbSignalSetConfluence, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+// Если Data содержит значение ("signal_set_confluence:60"), сохраняет его.
+// Иначе показывает кнопки с вариантами.
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	backKeyboard := kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}})
+
+	if strings.Contains(params.Data, ":") {
+		parts := strings.SplitN(params.Data, ":", 2)
+		if len(parts) == 2 {
+			val, err := strconv.ParseFloat(parts[1], 64)
+			if err == nil {
+				result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+					Action: "set_min_confluence",
+					UserID: user.ID,
+					Value:  val,
+				})
+				if err != nil {
+					return handlers.HandlerResult{
+						Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+						Keyboard:    backKeyboard,
+						EditMessage: params.MessageID != "",
+					}, nil
+				}
+				msg := fmt.Sprintf("✅ Фильтр обновлён\n\n%s", result.Message)
+				if val > 0 {
+					msg += fmt.Sprintf("\n\nСигналы с оценкой старших таймфреймов ниже %.0f не будут приходить.", val)
+				}
+				return handlers.HandlerResult{
+					Message:     msg,
+					Keyboard:    backKeyboard,
+					EditMessage: params.MessageID != "",
+				}, nil
+			}
+		}
+	}
+
+	current := "выключен"
+	if user.MinConfluenceScore > 0 {
+		current = fmt.Sprintf("от %.0f/100", user.MinConfluenceScore)
+	}
+	msg := fmt.Sprintf(
+		"🧭 Согласованность со старшими таймфреймами\n\n"+
+			"Для каждого сигнала оцениваются тренд, RSI и зоны S/R на старших "+
+			"таймфреймах (15m/1h/4h/1d). 50 — нейтрально, выше — старшие ТФ поддерживают сигнал.\n\n"+
+			"Текущий фильтр: %s\n\n"+
+			"Рекомендуемые значения:\n"+
+			"· 50 — отсекать сигналы против старших ТФ\n"+
+			"· 60-70 — только подтверждённые сигналы\n"+
+			"· 80 — только сильное совпадение",
+		current,
+	)
+
+	scores := []float64{0, 50, 60, 70, 80}
+	var rows [][]map[string]string
+	var row []map[string]string
+	for i, s := range scores {
+		marker := ""
+		if s == user.MinConfluenceScore {
+			marker = "✅ "
+		}
+		label := fmt.Sprintf("%.0f", s)
+		if s == 0 {
+			label = "Выкл"
+		}
+		row = append(row, kb.B(marker+label, fmt.Sprintf("%s:%.0f", kb.CbSignalSetConfluence, s)))
+		if len(row) == 3 || i == len(scores)-1 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	rows = append(rows, kb.BackRow(kb.CbSignalsMenu))
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard(rows),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
@@ -0,0 +1,108 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_set_sensitivity/handler.go
+package signal_set_sensitivity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/anomaly"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// Handler — обработчик настройки чувствительности z-score сигналов (в сигмах)
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_set_sensitivity", kb.CbSignalSetSensitivity, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+// Если Data содержит значение ("signal_set_sensitivity:3"), сохраняет его.
+// Иначе показывает кнопки с вариантами.
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	backKeyboard := kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}})
+
+	if strings.Contains(params.Data, ":") {
+		parts := strings.SplitN(params.Data, ":", 2)
+		if len(parts) == 2 {
+			val, err := strconv.ParseFloat(parts[1], 64)
+			if err == nil {
+				result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+					Action: "set_sensitivity",
+					UserID: user.ID,
+					Value:  val,
+				})
+				if err != nil {
+					return handlers.HandlerResult{
+						Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+						Keyboard:    backKeyboard,
+						EditMessage: params.MessageID != "",
+					}, nil
+				}
+				msg := fmt.Sprintf("✅ Чувствительность обновлена\n\n%s", result.Message)
+				if val > 0 {
+					msg += fmt.Sprintf("\n\nВы будете получать свечи, доходность которых отклоняется от обычной для монеты на %.1fσ и более.", val)
+				}
+				return handlers.HandlerResult{
+					Message:     msg,
+					Keyboard:    backKeyboard,
+					EditMessage: params.MessageID != "",
+				}, nil
+			}
+		}
+	}
+
+	msg := fmt.Sprintf(
+		"🎯 Чувствительность (z-score)\n\n"+
+			"Бот хранит распределение доходностей свечей по каждой монете и периоду "+
+			"и оценивает новое движение в сигмах (σ) относительно истории самой монеты.\n\n"+
+			"Текущая чувствительность: %s\n\n"+
+			"Уровни:\n"+
+			"· 2σ — высокая: больше сигналов\n"+
+			"· 3σ — средняя: заметные для монеты движения\n"+
+			"· 4σ — низкая: только исключительные свечи",
+		anomaly.DescribeSensitivity(user.SensitivitySigma),
+	)
+
+	options := append([]float64{0}, anomaly.Presets()...)
+	var rows [][]map[string]string
+	var row []map[string]string
+	for i, s := range options {
+		marker := ""
+		if s == user.SensitivitySigma {
+			marker = "✅ "
+		}
+		label := strconv.FormatFloat(s, 'f', -1, 64) + "σ"
+		if s == 0 {
+			label = "Выкл"
+		}
+		row = append(row, kb.B(marker+label, kb.CbSignalSetSensitivity+":"+strconv.FormatFloat(s, 'f', -1, 64)))
+		if len(row) == 3 || i == len(options)-1 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	rows = append(rows, kb.BackRow(kb.CbSignalsMenu))
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard(rows),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
@@ -0,0 +1,58 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_toggle_market_filter/handler.go
+package signal_toggle_market_filter
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// Handler — обработчик переключения фильтра движений вслед за BTC/ETH
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_toggle_market_filter", kb.CbSignalToggleMarket, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+		Action: "toggle_market_filter",
+		UserID: user.ID,
+	})
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+			Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+			EditMessage: params.MessageID != "",
+		}, nil
+	}
+
+	msg := fmt.Sprintf(
+		"🌐 Движения вслед за рынком\n\n%s\n\n"+
+			"Если движение монеты почти полностью повторяет движение BTC/ETH с учётом беты, "+
+			"сигнал считается рыночным шумом. Монеты, которые двигаются независимо, приходят как обычно.",
+		result.Message,
+	)
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
@@ -0,0 +1,58 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_toggle_pattern_zones/handler.go
+package signal_toggle_pattern_zones
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// Handler — обработчик подписки на свечные паттерны у зон S/R
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_toggle_pattern_zones", kb.CbSignalTogglePatterns, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+		Action: "toggle_pattern_zones",
+		UserID: user.ID,
+	})
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+			Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+			EditMessage: params.MessageID != "",
+		}, nil
+	}
+
+	msg := fmt.Sprintf(
+		"🕯️ Паттерны у зон S/R\n\n%s\n\n"+
+			"Бот присылает разворотный свечной паттерн, если он сформировался у сильной зоны: "+
+			"бычий (молот, поглощение, утренняя звезда) — у поддержки, медвежий — у сопротивления.",
+		result.Message,
+	)
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
@@ -0,0 +1,58 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_toggle_sector_digest/handler.go
+package signal_toggle_sector_digest
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// Handler — обработчик подписки на дайджест ротаций секторов
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_toggle_sector_digest", kb.CbSignalToggleSectors, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+		Action: "toggle_sector_digest",
+		UserID: user.ID,
+	})
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+			Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+			EditMessage: params.MessageID != "",
+		}, nil
+	}
+
+	msg := fmt.Sprintf(
+		"🧩 Дайджест секторов\n\n%s\n\n"+
+			"Бот по расписанию присылает рейтинг секторов (L1, мемы, AI, DeFi), перестановки мест "+
+			"с прошлого дайджеста и лидеров/аутсайдеров против BTC. Текущий рейтинг — команда /top.",
+		result.Message,
+	)
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
@@ -0,0 +1,59 @@
+// internal/delivery/max/bot/handlers/callbacks/signal_toggle_vwap/handler.go
+package signal_toggle_vwap
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// Handler — обработчик подписки на сигналы VWAP
+type Handler struct {
+	*base.BaseHandler
+	service signalSvc.Service
+}
+
+// New создаёт обработчик
+func New(svc signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("signal_toggle_vwap", kb.CbSignalToggleVWAP, handlers.TypeCallback),
+		service:     svc,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	result, err := h.service.Exec(signalSvc.SignalSettingsParams{
+		Action: "toggle_vwap",
+		UserID: user.ID,
+	})
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:     fmt.Sprintf("❌ Ошибка: %v", err),
+			Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+			EditMessage: params.MessageID != "",
+		}, nil
+	}
+
+	msg := fmt.Sprintf(
+		"📏 Сигналы VWAP\n\n%s\n\n"+
+			"VWAP дневной сессии считается от 00:00 UTC. Бот сообщает о выходе цены за полосу ±2σ, "+
+			"возврате выше VWAP (reclaim) и уходе под него (loss).\n\n"+
+			"Текущий VWAP монеты: /vwap BTC, свой якорь: /vwap anchor 14:30",
+		result.Message,
+	)
+
+	return handlers.HandlerResult{
+		Message:     msg,
+		Keyboard:    kb.Keyboard([][]map[string]string{{kb.B(kb.Btn.Back, kb.CbSignalsMenu)}}),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/anomaly"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
 	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
 	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
@@ -49,6 +50,47 @@ func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult
 	growthBtn := fmt.Sprintf(kb.Btn.ThresholdFormat, "📈", growthThreshold)
 	fallBtn := fmt.Sprintf(kb.Btn.ThresholdFormat, "📉", fallThreshold)
 
+	marketStr := "❌"
+	if user != nil && user.SuppressMarketMoves {
+		marketStr = "✅"
+	}
+
+	patternsStr := "❌"
+	if user != nil && user.NotifyPatternZones {
+		patternsStr = "✅"
+	}
+
+	sectorsStr := "❌"
+	if user != nil && user.NotifySectorDigest {
+		sectorsStr = "✅"
+	}
+
+	vwapStr := "❌"
+	if user != nil && user.NotifyVWAP {
+		vwapStr = "✅"
+	}
+
+	wallsStr := "❌"
+	if user != nil && user.NotifyWallPulls {
+		wallsStr = "✅"
+	}
+
+	rangesStr := "выкл"
+	if user != nil && len(user.RangeBreakoutHorizons) > 0 {
+		rangesStr = strings.Join(user.RangeBreakoutHorizons, ", ")
+	}
+
+	confluenceBtn := kb.Btn.Confluence + ": выкл"
+	if user != nil && user.MinConfluenceScore > 0 {
+		confluenceBtn = fmt.Sprintf("%s: от %.0f", kb.Btn.Confluence, user.MinConfluenceScore)
+	}
+
+	sensitivity := 0.0
+	if user != nil {
+		sensitivity = user.SensitivitySigma
+	}
+	sensitivityStr := anomaly.DescribeSensitivity(sensitivity)
+
 	var signalTypes []string
 	if user != nil && user.NotifyGrowth {
 		signalTypes = append(signalTypes, "📈 Рост")
@@ -66,11 +108,13 @@ func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult
 			"📊 Статус отслеживания:\n"+
 			"· Типы сигналов: %s\n"+
 			"· Мин. рост: %.1f%%\n"+
-			"· Мин. падение: %.1f%%\n\n"+
+			"· Мин. падение: %.1f%%\n"+
+			"· Чувствительность: %s\n\n"+
 			"Выберите действие:",
 		signalsStatus,
 		growthThreshold,
 		fallThreshold,
+		sensitivityStr,
 	)
 
 	rows := [][]map[string]string{
@@ -82,6 +126,15 @@ func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult
 			kb.B(growthBtn, kb.CbSignalSetGrowthThreshold),
 			kb.B(fallBtn, kb.CbSignalSetFallThreshold),
 		},
+		{kb.B(kb.Btn.Sensitivity+": "+sensitivityStr, kb.CbSignalSetSensitivity)},
+		{kb.B(confluenceBtn, kb.CbSignalSetConfluence)},
+		{kb.B(kb.Btn.MarketFilter+" "+marketStr, kb.CbSignalToggleMarket)},
+		{kb.B(kb.Btn.PatternZones+" "+patternsStr, kb.CbSignalTogglePatterns)},
+		{kb.B(kb.Btn.SectorDigest+" "+sectorsStr, kb.CbSignalToggleSectors)},
+		{kb.B(kb.Btn.RangeBreakouts+": "+rangesStr, kb.CbRangeHorizonsMenu)},
+		{kb.B(kb.Btn.VWAP+" "+vwapStr, kb.CbSignalToggleVWAP)},
+		{kb.B(kb.Btn.WallPulls+" "+wallsStr, kb.CbSignalToggleWalls)},
+		{kb.B(kb.Btn.History, kb.CbSignalHistory)},
 		kb.BackRow(kb.CbMenuMain),
 	}
 
@@ -0,0 +1,31 @@
+// internal/delivery/max/bot/handlers/commands/digest/handler.go
+// Дайджест рынка за сутки и неделю (/digest, /digest on|off|weekly|time|show)
+package digest
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+)
+
+// Handler выполняет подкоманды /digest
+type Handler struct {
+	*base.BaseHandler
+	service *digest.Service
+}
+
+// New создаёт обработчик команды /digest
+func New(service *digest.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("digest_command", "/digest", handlers.TypeCommand),
+		service:     service,
+	}
+}
+
+// Execute показывает настройки дайджеста, меняет их или присылает последний дайджест
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+	return handlers.HandlerResult{Message: h.service.Command(params.User.ID, params.User.Timezone, params.Data)}, nil
+}
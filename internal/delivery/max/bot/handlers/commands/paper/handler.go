@@ -0,0 +1,31 @@
+// internal/delivery/max/bot/handlers/commands/paper/handler.go
+// Paper trading по полученным сигналам (/paper, /paper on|off|rules|set|reset)
+package paper
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+)
+
+// Handler выполняет подкоманды /paper
+type Handler struct {
+	*base.BaseHandler
+	service *paper.Service
+}
+
+// New создаёт обработчик команды /paper
+func New(service *paper.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler: base.New("paper_command", "/paper", handlers.TypeCommand),
+		service:     service,
+	}
+}
+
+// Execute показывает отчёт портфеля или меняет его правила
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+	return handlers.HandlerResult{Message: h.service.Command(params.User.ID, params.Data)}, nil
+}
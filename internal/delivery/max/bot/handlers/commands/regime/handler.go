@@ -0,0 +1,96 @@
+// internal/delivery/max/bot/handlers/commands/regime/handler.go
+// Режим рынка и пороги пользователя по режимам (/regime, /regime panic=2 range=1.5, /regime off)
+package regime
+
+import (
+	"fmt"
+	"strings"
+
+	rg "crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+const usageText = "/regime panic=2 range=1.5 — порог ×2 в панике и ×1.5 в боковике\n" +
+	"/regime off — пороги не зависят от режима\n\n" +
+	"Режимы: trend_up, trend_down, range, panic. Множитель от 1 до 10 " +
+	"умножает ваши пороги роста и падения, пока рынок в этом режиме."
+
+// Handler показывает режим рынка и управляет множителями порогов пользователя
+type Handler struct {
+	*base.BaseHandler
+	classifier    func() *rg.Classifier
+	signalService signalSvc.Service
+}
+
+// New создаёт обработчик команды /regime (classifier вызывается лениво)
+func New(classifier func() *rg.Classifier, signalService signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler:   base.New("regime_command", "/regime", handlers.TypeCommand),
+		classifier:    classifier,
+		signalService: signalService,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	args := strings.TrimSpace(params.Data)
+	if args == "" {
+		return handlers.HandlerResult{
+			Message:  h.status(user.RegimeThresholds),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	value := rg.NormalizeFactorsArg(args)
+	switch strings.ToLower(args) {
+	case "off", "reset", "выкл":
+		value = ""
+	}
+
+	result, err := h.signalService.Exec(signalSvc.SignalSettingsParams{
+		Action: "set_regime_thresholds",
+		UserID: user.ID,
+		Value:  value,
+	})
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:  fmt.Sprintf("❌ %s\n\n%s", err.Error(), usageText),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	return handlers.HandlerResult{
+		Message:  fmt.Sprintf("🧭 %s\n\nТекущий режим: /regime", result.Message),
+		Keyboard: menuKeyboard(),
+	}, nil
+}
+
+// status — режим рынка, множители пользователя и подсказка
+func (h *Handler) status(userFactors string) string {
+	var b strings.Builder
+	if classifier := h.classifier(); classifier != nil {
+		b.WriteString(rg.FormatStatus(classifier.Snapshot()))
+	} else {
+		b.WriteString("🧭 Режим рынка: классификатор не запущен")
+	}
+
+	if factors := rg.FormatFactors(userFactors); factors != "" {
+		b.WriteString("\n\nВаши пороги по режимам\n" + factors)
+	} else {
+		b.WriteString("\n\nВаши пороги не зависят от режима")
+	}
+	b.WriteString("\n\n" + usageText)
+	return b.String()
+}
+
+func menuKeyboard() interface{} {
+	return kb.Keyboard([][]map[string]string{kb.BackRow(kb.CbMenuMain)})
+}
@@ -0,0 +1,111 @@
+// internal/delivery/max/bot/handlers/commands/top/handler.go
+// Рейтинг относительной силы (/top [1h|4h|1d] и кнопки периодов top_period_{PERIOD})
+package top
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+)
+
+// Handler показывает лидеров, аутсайдеров и секторы за период
+type Handler struct {
+	*base.BaseHandler
+	strengthService *strength.Service
+}
+
+// New создаёт обработчик команды /top
+func New(strengthService *strength.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler:     base.New("top_command", "/top", handlers.TypeCommand),
+		strengthService: strengthService,
+	}
+}
+
+// NewPeriodHandler создаёт обработчик кнопок периода
+func NewPeriodHandler(strengthService *strength.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler:     base.New("top_period", kb.CbTopPeriodWildcard, handlers.TypeCallback),
+		strengthService: strengthService,
+	}
+}
+
+// Execute показывает рейтинг за выбранный период
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	period := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(params.Data, kb.CbTopPeriodBase)))
+	if period == "" {
+		period = h.strengthService.Config().DigestPeriod
+	}
+	if !strength.IsValidPeriod(period) {
+		return handlers.HandlerResult{
+			Message:  "❌ Неизвестный период. Доступны: 1h, 4h, 1d\n\nПример: /top 4h",
+			Keyboard: periodKeyboard(""),
+		}, nil
+	}
+
+	report, err := h.strengthService.Rank(period)
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:     fmt.Sprintf("⏳ Рейтинг пока не готов: %v", err),
+			Keyboard:    periodKeyboard(period),
+			EditMessage: params.MessageID != "",
+		}, nil
+	}
+
+	return handlers.HandlerResult{
+		Message:     formatReport(report, h.strengthService.Config().TopN),
+		Keyboard:    periodKeyboard(period),
+		EditMessage: params.MessageID != "",
+	}, nil
+}
+
+// formatReport форматирует рейтинг
+func formatReport(report *strength.Report, topN int) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("🏆 Относительная сила за %s\n", report.Period))
+	b.WriteString(fmt.Sprintf("₿ BTC: %+.2f%% • медиана рынка: %+.2f%% • монет: %d\n\n",
+		report.BTCReturn, report.MedianReturn, len(report.Entries)))
+
+	b.WriteString("🚀 Лидеры\n")
+	for i, e := range report.Leaders(topN) {
+		b.WriteString(fmt.Sprintf("%d. %s %+.2f%% (vs BTC %+.2f)\n", i+1, e.Symbol, e.Return, e.VsBTC))
+	}
+
+	b.WriteString("\n🐢 Аутсайдеры\n")
+	for i, e := range report.Laggards(topN) {
+		b.WriteString(fmt.Sprintf("%d. %s %+.2f%% (vs BTC %+.2f)\n", i+1, e.Symbol, e.Return, e.VsBTC))
+	}
+
+	if len(report.Sectors) > 0 {
+		b.WriteString("\n🧩 Секторы\n")
+		for i, s := range report.Sectors {
+			b.WriteString(fmt.Sprintf("%d. %s %+.2f%% (vs рынок %+.2f) • лидер %s\n",
+				i+1, s.Title, s.Return, s.VsMedian, s.Leader))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\n🕐 %s", report.GeneratedAt.Format("15:04:05")))
+	return b.String()
+}
+
+// periodKeyboard — кнопки периодов; текущий период отмечен точкой
+func periodKeyboard(current string) interface{} {
+	var row []map[string]string
+	for _, period := range strength.Periods {
+		text := period
+		if period == current {
+			text = "• " + period
+		}
+		row = append(row, kb.B(text, kb.CbTopPeriodBase+period))
+	}
+	return kb.Keyboard([][]map[string]string{row, kb.BackRow(kb.CbMenuMain)})
+}
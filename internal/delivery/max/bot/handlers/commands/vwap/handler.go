@@ -0,0 +1,149 @@
+// internal/delivery/max/bot/handlers/commands/vwap/handler.go
+// VWAP дневной сессии и якоря пользователя (/vwap SOL, /vwap anchor 14:30|DD.MM HH:MM|now|off)
+package vwap
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	vw "crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/max/bot/handlers/base"
+	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
+	signalSvc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+const usageText = "/vwap SOL — VWAP сессии и вашего якоря\n" +
+	"/vwap anchor 14:30 — якорь сегодня в 14:30 UTC\n" +
+	"/vwap anchor 17.10 09:00 — якорь на дату (UTC)\n" +
+	"/vwap anchor now — якорь от текущего момента\n" +
+	"/vwap anchor off — снять якорь\n\n" +
+	"Якорь можно поставить не дальше 72 ч назад."
+
+// Handler показывает VWAP монеты и управляет якорем пользователя
+type Handler struct {
+	*base.BaseHandler
+	tracker       func() *vw.Tracker
+	signalService signalSvc.Service
+}
+
+// New создаёт обработчик команды /vwap (tracker вызывается лениво)
+func New(tracker func() *vw.Tracker, signalService signalSvc.Service) handlers.Handler {
+	return &Handler{
+		BaseHandler:   base.New("vwap_command", "/vwap", handlers.TypeCommand),
+		tracker:       tracker,
+		signalService: signalService,
+	}
+}
+
+// Execute выполняет обработку
+func (h *Handler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	user := params.User
+	if user == nil {
+		return handlers.HandlerResult{Message: "❌ Пользователь не найден"}, nil
+	}
+
+	args := strings.TrimSpace(params.Data)
+	if args == "" {
+		anchorStr := "не задан"
+		if user.VWAPAnchorAt != nil {
+			anchorStr = user.VWAPAnchorAt.UTC().Format("02.01 15:04") + " UTC"
+		}
+		return handlers.HandlerResult{
+			Message:  "📏 VWAP\n\n" + usageText + "\n\n⚓ Якорь: " + anchorStr,
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	fields := strings.Fields(args)
+	if strings.EqualFold(fields[0], "anchor") {
+		return h.setAnchor(user.ID, strings.Join(fields[1:], " ")), nil
+	}
+
+	tracker := h.tracker()
+	if tracker == nil {
+		return handlers.HandlerResult{Message: "❌ VWAP недоступен: свечная система не запущена"}, nil
+	}
+
+	symbol := alertsDomain.NormalizeSymbol(fields[0])
+	if symbol == "" {
+		return handlers.HandlerResult{Message: "❌ Неверный тикер\n\n" + usageText, Keyboard: menuKeyboard()}, nil
+	}
+
+	session, ok := tracker.Session(symbol)
+	if !ok {
+		return handlers.HandlerResult{
+			Message:  fmt.Sprintf("⏳ VWAP %s пока не рассчитан: нет тиков с приростом оборота с начала сессии", symbol),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("📏 VWAP %s\n💰 Цена: %s\n\n", symbol, alertsDomain.FormatPrice(session.LastPrice)))
+	b.WriteString(fmt.Sprintf("Сессия (с %s UTC)\n", session.Anchor.Format("02.01 15:04")))
+	writeSnapshot(&b, session)
+
+	if anchor := user.VWAPAnchorAt; anchor != nil {
+		b.WriteString(fmt.Sprintf("\nЯкорь (с %s UTC)\n", anchor.UTC().Format("02.01 15:04")))
+		if anchored, ok := tracker.Anchored(symbol, *anchor); ok {
+			writeSnapshot(&b, anchored)
+		} else {
+			b.WriteString("нет данных: якорь старше 72 ч или монета не торговалась\n")
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\n🕐 %s UTC", session.UpdatedAt.UTC().Format("15:04:05")))
+
+	return handlers.HandlerResult{
+		Message:  b.String(),
+		Keyboard: menuKeyboard(),
+	}, nil
+}
+
+// setAnchor задаёт или снимает якорь пользователя
+func (h *Handler) setAnchor(userID int, arg string) handlers.HandlerResult {
+	var value interface{}
+	switch strings.ToLower(strings.TrimSpace(arg)) {
+	case "":
+		return handlers.HandlerResult{Message: "❌ Укажите время якоря\n\n" + usageText, Keyboard: menuKeyboard()}
+	case "off", "выкл":
+		value = nil
+	default:
+		anchor, err := vw.ParseAnchor(arg, time.Now())
+		if err != nil {
+			return handlers.HandlerResult{Message: fmt.Sprintf("❌ %s\n\n%s", err.Error(), usageText), Keyboard: menuKeyboard()}
+		}
+		value = anchor
+	}
+
+	result, err := h.signalService.Exec(signalSvc.SignalSettingsParams{
+		Action: "set_vwap_anchor",
+		UserID: userID,
+		Value:  value,
+	})
+	if err != nil {
+		return handlers.HandlerResult{Message: fmt.Sprintf("❌ %s", err.Error()), Keyboard: menuKeyboard()}
+	}
+
+	return handlers.HandlerResult{
+		Message:  fmt.Sprintf("📏 %s\n\nПосмотреть VWAP: /vwap SOL", result.Message),
+		Keyboard: menuKeyboard(),
+	}
+}
+
+// writeSnapshot — VWAP, полосы и положение цены
+func writeSnapshot(b *strings.Builder, snap vw.Snapshot) {
+	upper1, lower1 := snap.Band(1)
+	upper2, lower2 := snap.Band(2)
+
+	b.WriteString(fmt.Sprintf("VWAP: %s\n", alertsDomain.FormatPrice(snap.VWAP)))
+	b.WriteString(fmt.Sprintf("±1σ: %s – %s\n", alertsDomain.FormatPrice(lower1), alertsDomain.FormatPrice(upper1)))
+	b.WriteString(fmt.Sprintf("±2σ: %s – %s\n", alertsDomain.FormatPrice(lower2), alertsDomain.FormatPrice(upper2)))
+	b.WriteString(fmt.Sprintf("Цена: %+.2f%% от VWAP (%+.1fσ)\n", snap.DistancePct(snap.LastPrice), snap.Sigmas(snap.LastPrice)))
+}
+
+func menuKeyboard() interface{} {
+	return kb.Keyboard([][]map[string]string{kb.BackRow(kb.CbMenuMain)})
+}
@@ -45,6 +45,8 @@ type HandlerResult struct {
 	EditMessage bool
 	// AutoDeleteAfter — если > 0, удалить отправленное сообщение через указанный интервал
 	AutoDeleteAfter time.Duration
+	// Notification — всплывающий текст ответа на callback (без Message новое сообщение не отправляется)
+	Notification string
 }
 
 // HandlerFactory — фабрика хэндлеров
@@ -45,6 +45,13 @@ const (
 	CbSignalToggleFall         = "signal_toggle_fall"
 	CbSignalSetGrowthThreshold = "signal_set_growth_threshold"
 	CbSignalSetFallThreshold   = "signal_set_fall_threshold"
+	CbSignalSetConfluence      = "signal_set_confluence"
+	CbSignalSetSensitivity     = "signal_set_sensitivity"
+	CbSignalToggleMarket       = "signal_toggle_market_filter"
+	CbSignalTogglePatterns     = "signal_toggle_pattern_zones"
+	CbSignalToggleSectors      = "signal_toggle_sector_digest"
+	CbSignalToggleVWAP         = "signal_toggle_vwap"
+	CbSignalToggleWalls        = "signal_toggle_walls"
 
 	// Periods
 	CbPeriod1m  = "period_1m"
@@ -88,6 +95,32 @@ const (
 	CbWatchlistToggleWildcard = "watchlist_toggle_*"
 	CbWatchlistLetterWildcard = "watchlist_letter_*"
 	CbWatchlistPageWildcard   = "watchlist_page_*"
+
+	// Price alerts
+	CbAlertsMenu          = "alerts_menu"
+	CbAlertNew            = "alert_new"
+	CbAlertDeleteBase     = "alert_del_"
+	CbAlertDeleteWildcard = "alert_del_*"
+
+	// Relative strength
+	CbTopPeriodBase     = "top_period_"
+	CbTopPeriodWildcard = "top_period_*"
+
+	// Signal history
+	CbSignalHistory             = "signal_history"
+	CbSignalHistoryPageBase     = "signal_history_"
+	CbSignalHistoryPageWildcard = "signal_history_*"
+	CbSignalCardBase            = "signal_card_"
+	CbSignalCardWildcard        = "signal_card_*"
+
+	// Signal feedback: signal_fb_{VOTE}_{SIGNAL_ID}
+	CbSignalFeedbackBase     = "signal_fb_"
+	CbSignalFeedbackWildcard = "signal_fb_*"
+
+	// Range breakouts
+	CbRangeHorizonsMenu          = "range_horizons"
+	CbRangeHorizonToggleBase     = "range_horizon_"
+	CbRangeHorizonToggleWildcard = "range_horizon_*"
 )
 
 // ──────────────────────────────────────────────
@@ -124,6 +157,21 @@ var Btn = struct {
 	SignalToggleGrowth string
 	SignalToggleFall   string
 	ThresholdFormat    string
+	Confluence         string
+	Sensitivity        string
+	MarketFilter       string
+	PatternZones       string
+	SectorDigest       string
+	RangeBreakouts     string
+	VWAP               string
+	WallPulls          string
+	History            string
+
+	// Signal feedback
+	FeedbackUp    string
+	FeedbackDown  string
+	FeedbackLate  string
+	FeedbackNoise string
 
 	// Periods
 	Period1m  string
@@ -185,6 +233,20 @@ var Btn = struct {
 	SignalToggleGrowth: "📈 Рост",
 	SignalToggleFall:   "📉 Падение",
 	ThresholdFormat:    "%s Порог: %.1f%%",
+	Confluence:         "🧭 Согласованность ТФ",
+	Sensitivity:        "🎯 Чувствительность",
+	MarketFilter:       "🌐 Без движений за BTC",
+	PatternZones:       "🕯️ Паттерны у зон S/R",
+	SectorDigest:       "🧩 Дайджест секторов",
+	RangeBreakouts:     "📐 Пробои диапазона",
+	VWAP:               "📏 Сигналы VWAP",
+	WallPulls:          "🧱 Снятие стен",
+	History:            "📊 История сигналов",
+
+	FeedbackUp:    "👍",
+	FeedbackDown:  "👎",
+	FeedbackLate:  "⏰ Поздно",
+	FeedbackNoise: "🔇 Шум",
 
 	Period1m:  "1 минута",
 	Period5m:  "5 минут",
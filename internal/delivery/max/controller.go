@@ -2,6 +2,7 @@
 package max
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/episodes"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/formatters/recommendation"
 	"crypto-exchange-screener-bot/internal/types"
 	"crypto-exchange-screener-bot/pkg/logger"
@@ -138,8 +139,9 @@ func formatSignalText(data map[string]interface{}) string {
 			deltaIcon, formatDollarValue(math.Abs(volDelta)), volDeltaPct))
 	}
 
-	// 8. Технический анализ (RSI + MACD)
-	if rsi > 0 || macdSignal != 0 {
+	// 8. Технический анализ (RSI + MACD + VWAP сессии)
+	vwap := getFloat64(data, "vwap_session")
+	if rsi > 0 || macdSignal != 0 || vwap > 0 {
 		b.WriteString("📊 Тех. анализ:\n")
 		if rsi > 0 {
 			b.WriteString(maxFormatRSI(rsi, rsiStatus) + "\n")
@@ -147,10 +149,51 @@ func formatSignalText(data map[string]interface{}) string {
 		if macdSignal != 0 {
 			b.WriteString(maxFormatMACD(macdSignal, macdStatus, macdDescription) + "\n")
 		}
+		if vwap > 0 {
+			b.WriteString(fmt.Sprintf("VWAP: $%s • %+.2f%% (%+.1fσ)\n",
+				formatPrice(vwap), getFloat64(data, "vwap_distance_pct"), getFloat64(data, "vwap_sigmas")))
+		}
 		b.WriteString("\n")
 	}
 
-	// 9. Зоны поддержки/сопротивления
+	// 9. Согласованность со старшими таймфреймами
+	if _, ok := data["confluence_score"]; ok {
+		b.WriteString(fmt.Sprintf("🧭 Старшие ТФ: %.0f/100\n\n", getFloat64(data, "confluence_score")))
+	}
+
+	if _, ok := data["market_benchmark"]; ok {
+		b.WriteString(fmt.Sprintf("🌐 %s: %+.2f%% • β %.2f • своё: %+.2f%%\n\n",
+			strings.TrimSuffix(getString(data, "market_benchmark"), "USDT"),
+			getFloat64(data, "market_benchmark_change"),
+			getFloat64(data, "market_beta"),
+			getFloat64(data, "idiosyncratic_change")))
+	}
+
+	if _, ok := data["liquidity_cost_up_1pct"]; ok {
+		line := fmt.Sprintf("💧 +1%%: $%s • −1%%: $%s • дисбаланс %+.0f%%",
+			formatDollarValue(getFloat64(data, "liquidity_cost_up_1pct")),
+			formatDollarValue(getFloat64(data, "liquidity_cost_down_1pct")),
+			getFloat64(data, "liquidity_imbalance_1pct")*100)
+		if getBool(data, "liquidity_thin_book") {
+			line += " ⚠️ тонкий стакан"
+		}
+		b.WriteString(line + "\n\n")
+	}
+
+	// Эпизод памп/дамп: в MAX без ответов на сообщение, стадия пишется в карточке
+	if getString(data, "episode_id") != "" {
+		stage := episodes.Stage(getString(data, "episode_stage"))
+		line := fmt.Sprintf("%s Эпизод: %s • пик $%s (%+.1f%%)",
+			stage.Emoji(), stage.Label(),
+			formatPrice(getFloat64(data, "episode_peak_price")),
+			getFloat64(data, "episode_gain_pct"))
+		if retrace := getFloat64(data, "episode_retrace_pct"); retrace > 0 {
+			line += fmt.Sprintf(" • откат −%.2f%%", retrace)
+		}
+		b.WriteString(line + "\n\n")
+	}
+
+	// 10. Зоны поддержки/сопротивления
 	hasSRSupport := srSupportPrice > 0
 	hasSRResistance := srResistancePrice > 0
 	if hasSRSupport || hasSRResistance {
@@ -174,7 +217,7 @@ func formatSignalText(data map[string]interface{}) string {
 		b.WriteString("\n")
 	}
 
-	// 10. Торговая рекомендация с уровнями
+	// 11. Торговая рекомендация с уровнями
 	if tradingRec := recommFormatter.GetTradingRecommendationOnly(
 		direction, rsi, macdSignal, volDelta, volDeltaPct,
 		liqLong, liqShort, price, change,
@@ -182,7 +225,7 @@ func formatSignalText(data map[string]interface{}) string {
 		b.WriteString(tradingRec + "\n\n")
 	}
 
-	// 11. Фандинг
+	// 12. Фандинг
 	if funding != 0 {
 		fundIcon := "🟢"
 		if funding < 0 {
@@ -191,7 +234,7 @@ func formatSignalText(data map[string]interface{}) string {
 		b.WriteString(fmt.Sprintf("🎯 Фандинг: %s %.4f%%\n", fundIcon, funding*100))
 	}
 
-	// 12. Ликвидации
+	// 13. Ликвидации
 	if liqTotal > 0 {
 		b.WriteString(fmt.Sprintf("\n💥 Ликвидации: $%s\n", formatDollarValue(liqTotal)))
 		if liqLong > 0 || liqShort > 0 {
@@ -0,0 +1,82 @@
+// internal/delivery/max/digest_controller.go
+package max
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// DigestController доставляет дайджест рынка MAX-пользователям из списка получателей события
+type DigestController struct {
+	client      *Client
+	userService *users.Service
+}
+
+// NewDigestController создаёт контроллер
+func NewDigestController(client *Client, userSvc *users.Service) *DigestController {
+	return &DigestController{
+		client:      client,
+		userService: userSvc,
+	}
+}
+
+// GetName возвращает имя контроллера
+func (c *DigestController) GetName() string {
+	return "max_digest_controller"
+}
+
+// GetSubscribedEvents возвращает список подписанных событий
+func (c *DigestController) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventMarketDigest}
+}
+
+// HandleEvent обрабатывает событие дайджеста рынка
+func (c *DigestController) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.MarketDigestData)
+	if !ok {
+		return fmt.Errorf("max digest_controller: неверный формат данных события")
+	}
+
+	text := digest.FormatDigest(data) + "\n\nНастройки: /digest"
+	sent := 0
+	for _, userID := range data.Recipients {
+		user, err := c.userService.GetUserByID(userID)
+		if err != nil {
+			logger.Warn("⚠️ MAX DigestController: ошибка получения пользователя %d: %v", userID, err)
+			continue
+		}
+		if !c.shouldSendToUser(user) {
+			continue
+		}
+
+		chatID, err := maxChatIDInt64(user.MaxChatID)
+		if err != nil {
+			logger.Warn("⚠️ MAX DigestController: невалидный MaxChatID user=%d: %v", user.ID, err)
+			continue
+		}
+
+		if err := c.client.SendMessage(chatID, text); err != nil {
+			logger.Warn("⚠️ MAX DigestController: ошибка отправки дайджеста user=%d: %v", user.ID, err)
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		logger.Debug("✅ MAX DigestController: дайджест %s — отправлено=%d", data.Kind, sent)
+	}
+	return nil
+}
+
+// shouldSendToUser проверяет MAX-уведомления пользователя (подписка проверена при рассылке)
+func (c *DigestController) shouldSendToUser(user *models.User) bool {
+	if user == nil {
+		return false
+	}
+	return user.IsActive && user.MaxNotificationsEnabled && user.MaxChatID != ""
+}
@@ -5,21 +5,35 @@ import (
 	"fmt"
 	"sync"
 
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
 	events "crypto-exchange-screener-bot/internal/infrastructure/transport/event_bus"
 	"crypto-exchange-screener-bot/pkg/logger"
 )
 
 // Package упаковывает всё необходимое для доставки сигналов через MAX
 type Package struct {
-	mu             sync.RWMutex
-	client         *Client
-	controller     *Controller
-	userController *UserController
-	chatID         int64
-	eventBus       *events.EventBus
-	initialized    bool
-	running        bool
+	mu                 sync.RWMutex
+	client             *Client
+	controller         *Controller
+	userController     *UserController
+	ruleController     *RuleController
+	alertController    *AlertController
+	patternController  *PatternController
+	anomalyController  *AnomalyController
+	strengthController *StrengthController
+	digestController   *DigestController
+	rangeController    *RangeController
+	vwapController     *VWAPController
+	wallsController    *WallsController
+	signalJournal      *journal.Service
+	paperTrading       *paper.Service
+	chatID             int64
+	eventBus           *events.EventBus
+	initialized        bool
+	running            bool
 }
 
 // NewPackage создаёт новый пакет доставки MAX
@@ -78,6 +92,36 @@ func (p *Package) Start() error {
 	return nil
 }
 
+// SetSignalJournal задаёт журнал сигналов для записи доставок;
+// вызывается до RegisterUserController
+func (p *Package) SetSignalJournal(journalSvc *journal.Service) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.signalJournal = journalSvc
+}
+
+// SetPaperTrading задаёт сервис paper trading для отработки доставленных сигналов;
+// вызывается до RegisterUserController
+func (p *Package) SetPaperTrading(paperSvc *paper.Service) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paperTrading = paperSvc
+}
+
+// newDeliveries создаёт общий хук доставки для всех контроллеров рассылки.
+// nil *journal.Service / *paper.Service не передаются в интерфейсы.
+func (p *Package) newDeliveries() *broadcast.Deliveries {
+	var recorder broadcast.DeliveryRecorder
+	if p.signalJournal != nil {
+		recorder = p.signalJournal
+	}
+	var follower broadcast.SignalFollower
+	if p.paperTrading != nil {
+		follower = p.paperTrading
+	}
+	return broadcast.NewDeliveries(recorder, follower)
+}
+
 // RegisterUserController создаёт UserController и подписывает его на EventBus.
 // Должен вызываться после Initialize.
 func (p *Package) RegisterUserController(userSvc *users.Service) {
@@ -97,13 +141,59 @@ func (p *Package) RegisterUserController(userSvc *users.Service) {
 		return
 	}
 
-	p.userController = NewUserController(p.client, userSvc)
+	deliveries := p.newDeliveries()
+	p.userController = NewUserController(p.client, userSvc, p.signalJournal, deliveries)
+	p.ruleController = NewRuleController(p.client, userSvc, deliveries)
+	p.alertController = NewAlertController(p.client, userSvc, deliveries)
+	p.patternController = NewPatternController(p.client, userSvc, deliveries)
+	p.anomalyController = NewAnomalyController(p.client, userSvc, deliveries)
+	p.strengthController = NewStrengthController(p.client, userSvc, deliveries)
+	p.digestController = NewDigestController(p.client, userSvc)
+	p.rangeController = NewRangeController(p.client, userSvc, deliveries)
+	p.vwapController = NewVWAPController(p.client, userSvc, deliveries)
+	p.wallsController = NewWallsController(p.client, userSvc, deliveries)
 
 	if p.eventBus != nil {
 		for _, eventType := range p.userController.GetSubscribedEvents() {
 			p.eventBus.Subscribe(eventType, p.userController)
 			logger.Debug("📬 MAX: UserController подписан на событие %s", eventType)
 		}
+		for _, eventType := range p.ruleController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.ruleController)
+			logger.Debug("📬 MAX: RuleController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.alertController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.alertController)
+			logger.Debug("📬 MAX: AlertController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.patternController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.patternController)
+			logger.Debug("📬 MAX: PatternController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.anomalyController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.anomalyController)
+			logger.Debug("📬 MAX: AnomalyController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.strengthController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.strengthController)
+			logger.Debug("📬 MAX: StrengthController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.digestController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.digestController)
+			logger.Debug("📬 MAX: DigestController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.rangeController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.rangeController)
+			logger.Debug("📬 MAX: RangeController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.vwapController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.vwapController)
+			logger.Debug("📬 MAX: VWAPController подписан на событие %s", eventType)
+		}
+		for _, eventType := range p.wallsController.GetSubscribedEvents() {
+			p.eventBus.Subscribe(eventType, p.wallsController)
+			logger.Debug("📬 MAX: WallsController подписан на событие %s", eventType)
+		}
 	}
 
 	logger.Info("✅ MAX UserController зарегистрирован")
@@ -130,6 +220,52 @@ func (p *Package) Stop() {
 		}
 	}
 
+	if p.eventBus != nil && p.ruleController != nil {
+		for _, eventType := range p.ruleController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.ruleController)
+		}
+	}
+	if p.eventBus != nil && p.alertController != nil {
+		for _, eventType := range p.alertController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.alertController)
+		}
+	}
+	if p.eventBus != nil && p.patternController != nil {
+		for _, eventType := range p.patternController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.patternController)
+		}
+	}
+	if p.eventBus != nil && p.anomalyController != nil {
+		for _, eventType := range p.anomalyController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.anomalyController)
+		}
+	}
+	if p.eventBus != nil && p.strengthController != nil {
+		for _, eventType := range p.strengthController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.strengthController)
+		}
+	}
+	if p.eventBus != nil && p.digestController != nil {
+		for _, eventType := range p.digestController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.digestController)
+		}
+	}
+	if p.eventBus != nil && p.rangeController != nil {
+		for _, eventType := range p.rangeController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.rangeController)
+		}
+	}
+	if p.eventBus != nil && p.vwapController != nil {
+		for _, eventType := range p.vwapController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.vwapController)
+		}
+	}
+	if p.eventBus != nil && p.wallsController != nil {
+		for _, eventType := range p.wallsController.GetSubscribedEvents() {
+			p.eventBus.Unsubscribe(eventType, p.wallsController)
+		}
+	}
+
 	p.running = false
 	logger.Info("🛑 MAX Package остановлен")
 }
@@ -0,0 +1,53 @@
+// internal/delivery/max/pattern_controller.go
+package max
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// PatternController рассылает алерты "свечной паттерн у зоны S/R" MAX-пользователям,
+// включившим подписку на паттерны.
+type PatternController = broadcast.Controller[types.PatternZoneData]
+
+// NewPatternController создаёт контроллер
+func NewPatternController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *PatternController {
+	return broadcast.NewController(broadcast.New(userSvc, broadcast.Max(client), deliveries),
+		broadcast.Spec[types.PatternZoneData]{
+			Name:   "max_pattern_controller",
+			Event:  types.EventPatternAtZone,
+			Filter: shouldSendPatternToUser,
+			Format: formatPatternText,
+			Describe: func(data types.PatternZoneData) string {
+				return fmt.Sprintf("%s %s", data.Symbol, data.Pattern)
+			},
+			Delivery: broadcast.PatternDelivery,
+		})
+}
+
+// shouldSendPatternToUser проверяет подписку на паттерны и фильтры символов пользователя
+func shouldSendPatternToUser(user *models.User, data types.PatternZoneData) bool {
+	return user.NotifyPatternZones && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatPatternText форматирует уведомление о паттерне у зоны
+func formatPatternText(data types.PatternZoneData) string {
+	var b strings.Builder
+
+	icon, zone := "🟢", "поддержки"
+	if data.ZoneType == "resistance" {
+		icon, zone = "🔴", "сопротивления"
+	}
+
+	b.WriteString(fmt.Sprintf("🕯️ %s у %s\n", data.PatternTitle, zone))
+	b.WriteString(fmt.Sprintf("%s %s • %s • цена %.6g\n", icon, data.Symbol, data.Period, data.Price))
+	b.WriteString(fmt.Sprintf("📐 Зона %.6g – %.6g (%s) • сила %.0f • касаний %d\n",
+		data.ZoneLow, data.ZoneHigh, data.ZonePeriod, data.ZoneStrength, data.ZoneTouches))
+	b.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return b.String()
+}
@@ -0,0 +1,72 @@
+// internal/delivery/max/range_controller.go
+package max
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// RangeController рассылает алерты о пробое диапазона MAX-пользователям.
+// Каждый получает одно сообщение — по самому длинному из выбранных горизонтов.
+type RangeController = broadcast.Controller[types.RangeBreakoutData]
+
+// NewRangeController создаёт контроллер
+func NewRangeController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *RangeController {
+	return broadcast.NewController(broadcast.New(userSvc, broadcast.Max(client), deliveries),
+		broadcast.Spec[types.RangeBreakoutData]{
+			Name:   "max_range_controller",
+			Event:  types.EventRangeBreakout,
+			Filter: shouldSendRangeToUser,
+			FormatFor: func(user *models.User, data types.RangeBreakoutData) (string, bool) {
+				brk, ok := data.Longest(user.SubscribesRangeHorizon)
+				if !ok {
+					return "", false
+				}
+				return formatRangeBreakoutText(data, brk), true
+			},
+			Describe: func(data types.RangeBreakoutData) string {
+				return fmt.Sprintf("%s %s", data.Symbol, data.Kind)
+			},
+			Delivery: broadcast.RangeDelivery,
+		})
+}
+
+// shouldSendRangeToUser проверяет наличие горизонтов и фильтры символов
+func shouldSendRangeToUser(user *models.User, data types.RangeBreakoutData) bool {
+	return len(user.RangeBreakoutHorizons) > 0 && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatRangeBreakoutText форматирует уведомление о пробое диапазона
+func formatRangeBreakoutText(data types.RangeBreakoutData, brk types.RangeBreak) string {
+	var b strings.Builder
+
+	icon, what, prev := "🚀", "новый максимум", "Прежний максимум"
+	if data.Kind == string(ranges.KindLow) {
+		icon, what, prev = "🕳️", "новый минимум", "Прежний минимум"
+	}
+
+	b.WriteString(fmt.Sprintf("%s %s — %s за %s\n", icon, data.Symbol, what, ranges.Horizon(brk.Horizon).Label()))
+	b.WriteString(fmt.Sprintf("💰 Цена %.6g • %+.2f%% к прежнему экстремуму\n", data.Price, brk.DistancePct))
+	b.WriteString(fmt.Sprintf("📌 %s %.6g от %s", prev, brk.PrevPrice, brk.PrevTime.Format("02.01.2006")))
+	if brk.DaysSincePrev > 0 {
+		b.WriteString(fmt.Sprintf(" (%d дн. назад)", brk.DaysSincePrev))
+	}
+	b.WriteString("\n")
+
+	if len(data.Breaks) > 1 {
+		labels := make([]string, 0, len(data.Breaks))
+		for _, br := range data.Breaks {
+			labels = append(labels, br.Horizon)
+		}
+		b.WriteString(fmt.Sprintf("📐 Пробиты горизонты: %s\n", strings.Join(labels, ", ")))
+	}
+
+	b.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return b.String()
+}
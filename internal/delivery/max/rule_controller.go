@@ -0,0 +1,102 @@
+// internal/delivery/max/rule_controller.go
+package max
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// RuleController доставляет срабатывания пользовательских правил владельцу
+// правила в MAX (если у него включены MAX-уведомления).
+type RuleController struct {
+	client      *Client
+	userService *users.Service
+	deliveries  *broadcast.Deliveries
+}
+
+// NewRuleController создаёт контроллер
+func NewRuleController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *RuleController {
+	return &RuleController{
+		client:      client,
+		userService: userSvc,
+		deliveries:  deliveries,
+	}
+}
+
+// GetName возвращает имя контроллера
+func (c *RuleController) GetName() string {
+	return "max_rule_controller"
+}
+
+// GetSubscribedEvents возвращает список подписанных событий
+func (c *RuleController) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventRuleTriggered}
+}
+
+// HandleEvent обрабатывает событие срабатывания правила
+func (c *RuleController) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.RuleTriggeredData)
+	if !ok {
+		return fmt.Errorf("max rule_controller: неверный формат данных события")
+	}
+
+	user, err := c.userService.GetUserByID(data.UserID)
+	if err != nil || user == nil {
+		return fmt.Errorf("max rule_controller: пользователь %d не найден: %v", data.UserID, err)
+	}
+	if !user.IsActive || !user.MaxNotificationsEnabled || user.MaxChatID == "" {
+		return nil
+	}
+
+	chatID, err := maxChatIDInt64(user.MaxChatID)
+	if err != nil {
+		logger.Warn("⚠️ MAX RuleController: невалидный MaxChatID user=%d: %v", user.ID, err)
+		return nil
+	}
+
+	text := formatRuleText(data)
+	if err := c.client.SendMessage(chatID, text); err != nil {
+		logger.Warn("⚠️ MAX RuleController: ошибка отправки правила #%d user=%d: %v", data.RuleID, user.ID, err)
+		return err
+	}
+	c.deliveries.Delivered(user.ID, "max", broadcast.RuleDelivery(data), text)
+	return nil
+}
+
+// formatRuleText форматирует уведомление о срабатывании правила
+func formatRuleText(data types.RuleTriggeredData) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("📐 Правило #%d", data.RuleID))
+	if data.RuleName != "" {
+		b.WriteString(" «" + data.RuleName + "»")
+	}
+	b.WriteString(" сработало\n")
+
+	b.WriteString(fmt.Sprintf("📛 %s • %s", data.Symbol, data.Period))
+	if data.Price > 0 {
+		b.WriteString(fmt.Sprintf(" • цена %.6g", data.Price))
+	}
+	b.WriteString("\n")
+	b.WriteString(data.Expression + "\n")
+
+	if len(data.Metrics) > 0 {
+		keys := make([]string, 0, len(data.Metrics))
+		for k := range data.Metrics {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteString(fmt.Sprintf("📊 %s = %.2f\n", k, data.Metrics[k]))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return b.String()
+}
@@ -0,0 +1,77 @@
+// internal/delivery/max/strength_controller.go
+package max
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// StrengthController рассылает дайджест ротаций секторов MAX-пользователям,
+// включившим подписку на дайджест.
+type StrengthController = broadcast.Controller[types.SectorDigestData]
+
+// NewStrengthController создаёт контроллер
+func NewStrengthController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *StrengthController {
+	return broadcast.NewController(broadcast.New(userSvc, broadcast.Max(client), deliveries),
+		broadcast.Spec[types.SectorDigestData]{
+			Name:   "max_strength_controller",
+			Event:  types.EventSectorDigest,
+			Filter: shouldSendSectorDigestToUser,
+			Format: formatSectorDigestText,
+			Describe: func(data types.SectorDigestData) string {
+				return "дайджест за " + data.Period
+			},
+		})
+}
+
+// shouldSendSectorDigestToUser проверяет подписку на дайджест
+func shouldSendSectorDigestToUser(user *models.User, _ types.SectorDigestData) bool {
+	return user.NotifySectorDigest
+}
+
+// formatSectorDigestText форматирует дайджест ротаций секторов
+func formatSectorDigestText(data types.SectorDigestData) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("🧩 Ротация секторов за %s\n", data.Period))
+	b.WriteString(fmt.Sprintf("₿ BTC: %+.2f%% • медиана рынка: %+.2f%%\n\n", data.BTCReturn, data.MedianReturn))
+
+	for i, s := range data.Sectors {
+		b.WriteString(fmt.Sprintf("%d. %s %+.2f%% (vs рынок %+.2f) • лидер %s\n",
+			i+1, s.Title, s.Return, s.VsMedian, s.Leader))
+	}
+
+	if len(data.Rotations) > 0 {
+		b.WriteString("\n🔄 Ротации\n")
+		for _, r := range data.Rotations {
+			arrow := "⬆️"
+			if r.ToRank > r.FromRank {
+				arrow = "⬇️"
+			}
+			b.WriteString(fmt.Sprintf("%s %s: %d → %d место\n", arrow, r.Title, r.FromRank, r.ToRank))
+		}
+	}
+
+	if len(data.Leaders) > 0 {
+		b.WriteString("\n🚀 Лидеры: " + formatStrengthSymbols(data.Leaders))
+	}
+	if len(data.Laggards) > 0 {
+		b.WriteString("\n🐢 Аутсайдеры: " + formatStrengthSymbols(data.Laggards))
+	}
+
+	b.WriteString(fmt.Sprintf("\n\n🕐 %s • подробнее: /top", data.Timestamp.Format("15:04")))
+	return b.String()
+}
+
+func formatStrengthSymbols(list []types.SymbolStrength) string {
+	parts := make([]string, 0, len(list))
+	for _, s := range list {
+		parts = append(parts, fmt.Sprintf("%s %+.2f%%", s.Symbol, s.Return))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -7,7 +7,10 @@ import (
 	"strings"
 	"time"
 
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
 	kb "crypto-exchange-screener-bot/internal/delivery/max/bot/keyboard"
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
 	"crypto-exchange-screener-bot/internal/types"
@@ -25,14 +28,18 @@ const (
 type UserController struct {
 	client      *Client
 	userService *users.Service
+	journal     *journal.Service      // nil — без кнопок оценки и персональных порогов
+	deliveries  *broadcast.Deliveries // nil — доставки не записываются и не отрабатываются в paper-портфеле
 	rateLimiter *maxRateLimiter
 }
 
 // NewUserController создаёт контроллер
-func NewUserController(client *Client, userSvc *users.Service) *UserController {
+func NewUserController(client *Client, userSvc *users.Service, journalSvc *journal.Service, deliveries *broadcast.Deliveries) *UserController {
 	return &UserController{
 		client:      client,
 		userService: userSvc,
+		journal:     journalSvc,
+		deliveries:  deliveries,
 		rateLimiter: newMaxRateLimiter(),
 	}
 }
@@ -87,7 +94,11 @@ func (c *UserController) HandleEvent(event types.Event) error {
 		}
 
 		expiresAt, hasSession := sessionMap[user.ID]
-		keyboard := signalKeyboard(symbol, hasSession, expiresAt)
+		var feedbackSignalID string
+		if c.journal != nil && c.journal.FeedbackEnabled() {
+			feedbackSignalID = getString(dataMap, "signal_id")
+		}
+		keyboard := signalKeyboard(symbol, hasSession, expiresAt, feedbackSignalID)
 
 		if sendErr := c.client.SendMessageWithKeyboard(chatID, text, keyboard); sendErr != nil {
 			logger.Warn("⚠️ MAX UserController: ошибка отправки user=%d: %v", user.ID, sendErr)
@@ -97,6 +108,7 @@ func (c *UserController) HandleEvent(event types.Event) error {
 			c.rateLimiter.record(int64(user.ID), symbol,
 				getString(dataMap, "direction"),
 				rl.SignalPeriod, rl.RateLimitPeriod)
+			c.recordDelivery(user.ID, dataMap, text)
 		}
 	}
 
@@ -107,6 +119,20 @@ func (c *UserController) HandleEvent(event types.Event) error {
 	return nil
 }
 
+// recordDelivery передаёт отправленную карточку в общий хук доставки:
+// история сигналов пользователя и paper-портфель
+func (c *UserController) recordDelivery(userID int, data map[string]interface{}, text string) {
+	periodMinutes, _ := period.StringToMinutes(getString(data, "period"))
+	c.deliveries.Delivered(userID, "max", &models.SignalDelivery{
+		SignalID:      getString(data, "signal_id"),
+		Symbol:        getString(data, "symbol"),
+		Direction:     getString(data, "direction"),
+		PeriodMinutes: periodMinutes,
+		ChangePercent: getFloat64(data, "change_percent"),
+		Price:         getFloat64(data, "current_price"),
+	}, text)
+}
+
 // shouldSendToUser проверяет, нужно ли отправлять сигнал конкретному пользователю
 func (c *UserController) shouldSendToUser(user *models.User, data map[string]interface{}) bool {
 	if user == nil {
@@ -127,20 +153,30 @@ func (c *UserController) shouldSendToUser(user *models.User, data map[string]int
 	direction := getString(data, "direction")
 	changePercent := getFloat64(data, "change_percent")
 
+	// Порог повышается для символов, которые пользователь отмечает «шумом»,
+	// и в режимах рынка, для которых он задал множитель (/regime)
+	thresholdFactor := 1.0
+	if c.journal != nil {
+		thresholdFactor = c.journal.NoiseFactor(user.ID, getString(data, "symbol"))
+	}
+	if factor := regime.FactorFor(user.RegimeThresholds, getString(data, "market_regime")); factor > thresholdFactor {
+		thresholdFactor = factor
+	}
+
 	// Тип сигнала и настройки пользователя
 	switch direction {
 	case "growth":
 		if !user.NotifyGrowth {
 			return false
 		}
-		if changePercent < user.MinGrowthThreshold {
+		if changePercent < user.MinGrowthThreshold*thresholdFactor {
 			return false
 		}
 	case "fall":
 		if !user.NotifyFall {
 			return false
 		}
-		if math.Abs(changePercent) < user.MinFallThreshold {
+		if math.Abs(changePercent) < user.MinFallThreshold*thresholdFactor {
 			return false
 		}
 	default:
@@ -171,6 +207,18 @@ func (c *UserController) shouldSendToUser(user *models.User, data map[string]int
 		return false
 	}
 
+	// Согласованность со старшими таймфреймами (если оценка есть в сигнале)
+	if _, ok := data["confluence_score"]; ok && user.MinConfluenceScore > 0 {
+		if getFloat64(data, "confluence_score") < user.MinConfluenceScore {
+			return false
+		}
+	}
+
+	// Движение полностью объясняется движением BTC/ETH с учётом беты
+	if user.SuppressMarketMoves && getBool(data, "market_explained") {
+		return false
+	}
+
 	// Предпочтительные периоды
 	periodStr := getString(data, "period")
 	if !c.isPeriodAllowed(user, periodStr) {
@@ -224,7 +272,8 @@ func (c *UserController) buildMaxSessionMap() map[int]time.Time {
 // signalKeyboard формирует MAX inline-keyboard:
 //   - строка 1: «🛒 Торговать» | «📊 График» (URL-кнопки)
 //   - строка 2: кнопка сессии — завершить с оставшимся временем или начать
-func signalKeyboard(symbol string, hasSession bool, expiresAt time.Time) interface{} {
+//   - при непустом feedbackSignalID: 👍 | 👎 | «Поздно» | «Шум» (оценка сигнала)
+func signalKeyboard(symbol string, hasSession bool, expiresAt time.Time, feedbackSignalID string) interface{} {
 	clean := strings.ToUpper(strings.ReplaceAll(symbol, "/", ""))
 	tradeURL := fmt.Sprintf("https://www.bybit.com/trade/usdt/%s", clean)
 	chartURL := fmt.Sprintf("https://www.coinglass.com/tv/ru/Bybit_%s", clean)
@@ -240,14 +289,26 @@ func signalKeyboard(symbol string, hasSession bool, expiresAt time.Time) interfa
 		sessionBtn = kb.B(kb.Btn.SessionStart, kb.CbSessionStart)
 	}
 
-	return kb.Keyboard([][]map[string]string{
+	rows := [][]map[string]string{
 		{
 			kb.BUrl("🛒 Торговать", tradeURL),
 			kb.BUrl("📊 График", chartURL),
 		},
 		{kb.B(kb.Btn.MainMenu, kb.CbMenuMain)},
 		{sessionBtn},
-	})
+	}
+	if feedbackSignalID != "" {
+		feedback := func(text, vote string) map[string]string {
+			return kb.B(text, kb.CbSignalFeedbackBase+vote+"_"+feedbackSignalID)
+		}
+		rows = append(rows, []map[string]string{
+			feedback(kb.Btn.FeedbackUp, "up"),
+			feedback(kb.Btn.FeedbackDown, "down"),
+			feedback(kb.Btn.FeedbackLate, "late"),
+			feedback(kb.Btn.FeedbackNoise, "noise"),
+		})
+	}
+	return kb.Keyboard(rows)
 }
 
 // formatRemaining форматирует оставшееся время сессии: «2ч 34м», «45м», «<1м»
@@ -0,0 +1,60 @@
+// internal/delivery/max/vwap_controller.go
+package max
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// VWAPController рассылает сигналы анализатора VWAP MAX-пользователям
+type VWAPController = broadcast.Controller[types.VWAPSignalData]
+
+// NewVWAPController создаёт контроллер
+func NewVWAPController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *VWAPController {
+	return broadcast.NewController(broadcast.New(userSvc, broadcast.Max(client), deliveries),
+		broadcast.Spec[types.VWAPSignalData]{
+			Name:   "max_vwap_controller",
+			Event:  types.EventVWAPSignal,
+			Filter: shouldSendVWAPToUser,
+			Format: formatVWAPSignalText,
+			Describe: func(data types.VWAPSignalData) string {
+				return fmt.Sprintf("%s %s", data.Symbol, data.Kind)
+			},
+			Delivery: broadcast.VWAPDelivery,
+		})
+}
+
+// shouldSendVWAPToUser проверяет подписку на VWAP и фильтры символов
+func shouldSendVWAPToUser(user *models.User, data types.VWAPSignalData) bool {
+	return user.NotifyVWAP && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatVWAPSignalText форматирует уведомление о сигнале VWAP
+func formatVWAPSignalText(data types.VWAPSignalData) string {
+	var b strings.Builder
+
+	switch data.Kind {
+	case types.VWAPDeviationUp:
+		b.WriteString(fmt.Sprintf("📏🔺 %s — выше VWAP на %.1fσ\n", data.Symbol, data.Sigmas))
+	case types.VWAPDeviationDown:
+		b.WriteString(fmt.Sprintf("📏🔻 %s — ниже VWAP на %.1fσ\n", data.Symbol, -data.Sigmas))
+	case types.VWAPReclaim:
+		b.WriteString(fmt.Sprintf("📏🟢 %s — цена вернулась выше VWAP\n", data.Symbol))
+	case types.VWAPLoss:
+		b.WriteString(fmt.Sprintf("📏🔴 %s — цена ушла под VWAP\n", data.Symbol))
+	default:
+		b.WriteString(fmt.Sprintf("📏 %s — сигнал VWAP\n", data.Symbol))
+	}
+
+	upper, lower := data.VWAP+2*data.StdDev, data.VWAP-2*data.StdDev
+	b.WriteString(fmt.Sprintf("💰 Цена %.6g • VWAP %.6g (%+.2f%%, %+.1fσ)\n",
+		data.Price, data.VWAP, data.DistancePct, data.Sigmas))
+	b.WriteString(fmt.Sprintf("📐 ±2σ: %.6g – %.6g\n", lower, upper))
+	b.WriteString(fmt.Sprintf("🕐 Сессия с %s UTC • %s", data.SessionStart.UTC().Format("15:04"), data.Timestamp.Format("15:04:05")))
+	return b.String()
+}
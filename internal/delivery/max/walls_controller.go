@@ -0,0 +1,64 @@
+// internal/delivery/max/walls_controller.go
+package max
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+)
+
+// WallsController рассылает алерты о снятии стен стакана MAX-пользователям
+type WallsController = broadcast.Controller[types.WallPullData]
+
+// NewWallsController создаёт контроллер
+func NewWallsController(client *Client, userSvc *users.Service, deliveries *broadcast.Deliveries) *WallsController {
+	return broadcast.NewController(broadcast.New(userSvc, broadcast.Max(client), deliveries),
+		broadcast.Spec[types.WallPullData]{
+			Name:   "max_walls_controller",
+			Event:  types.EventWallPulled,
+			Filter: shouldSendWallPullToUser,
+			Format: formatWallPullText,
+			Describe: func(data types.WallPullData) string {
+				return fmt.Sprintf("%s %s $%.0f", data.Symbol, data.Side, data.SizeUSD)
+			},
+			Delivery: broadcast.WallPullDelivery,
+		})
+}
+
+// shouldSendWallPullToUser проверяет подписку на снятие стен и фильтры символов
+func shouldSendWallPullToUser(user *models.User, data types.WallPullData) bool {
+	return user.NotifyWallPulls && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatWallPullText форматирует уведомление о снятии стены
+func formatWallPullText(data types.WallPullData) string {
+	var b strings.Builder
+
+	if data.Side == "ask" {
+		b.WriteString(fmt.Sprintf("🧱🟢 %s — снято сопротивление $%s\n", data.Symbol, formatDollarValue(data.SizeUSD)))
+	} else {
+		b.WriteString(fmt.Sprintf("🧱🔴 %s — снята поддержка $%s\n", data.Symbol, formatDollarValue(data.SizeUSD)))
+	}
+
+	b.WriteString(fmt.Sprintf("💰 Стена %.6g • цена %.6g (%.2f%% до стены)\n", data.Price, data.Mid, data.DistancePct))
+	b.WriteString(fmt.Sprintf("⏳ Простояла %s • уверенность %.0f%%\n", formatWallLifetime(data.Lifetime), data.Confidence))
+	b.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return b.String()
+}
+
+// formatWallLifetime форматирует время жизни стены: 45с, 12м, 2ч 05м
+func formatWallLifetime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%dс", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dм", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dч %02dм", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
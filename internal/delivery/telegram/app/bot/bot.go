@@ -2,6 +2,16 @@
 package bot
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/export"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/internal/core/domain/rules"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
 	"crypto-exchange-screener-bot/internal/delivery/telegram"
@@ -20,6 +30,8 @@ import (
 	trading_session "crypto-exchange-screener-bot/internal/delivery/telegram/services/trading_session"
 	watchlist_service "crypto-exchange-screener-bot/internal/delivery/telegram/services/watchlist"
 	watchlist_toggle_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/watchlist_toggle"
+	alert_new_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/alert_new"
+	alert_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/alert"
 	"crypto-exchange-screener-bot/internal/infrastructure/config"
 	currency_client "crypto-exchange-screener-bot/internal/infrastructure/http/currency"
 	tbank_client "crypto-exchange-screener-bot/internal/infrastructure/http/tbank"
@@ -34,6 +46,16 @@ import (
 type Dependencies struct {
 	ServiceFactory   *services_factory.ServiceFactory
 	WatchlistService watchlist_service.Service
+	RulesService     *rules.Service // опционально, для /rules
+	AlertService     *alerts.Service // опционально, для /alert и /alerts
+	AnalyzerInfos    func() []common.AnalyzerInfo // опционально, для /analyzers
+	StrengthService  *strength.Service            // опционально, для /top
+	VWAPTracker      func() *vwap.Tracker         // опционально, для /vwap
+	SignalJournal    *journal.Service             // опционально, для истории сигналов
+	PaperTrading     *paper.Service               // опционально, для /paper
+	ExportService    *export.Service              // опционально, для /export
+	MarketDigest     *digest.Service              // опционально, для /digest
+	MarketRegime     func() *regime.Classifier    // опционально, для /regime
 }
 
 // TelegramBot - бот для отправки уведомлений в Telegram
@@ -64,6 +86,7 @@ type TelegramBot struct {
 	// Для FSM поиска вотчлиста
 	userService      *users.Service
 	watchlistService watchlist_service.Service
+	alertService     *alerts.Service
 }
 
 // NewTelegramBot создает новый экземпляр TelegramBot
@@ -180,6 +203,18 @@ func NewTelegramBot(config *config.Config, deps *Dependencies) *TelegramBot {
 		currencyClient:             currencyClient,
 		paymentCoreService:         deps.ServiceFactory.GetPaymentCoreService(),
 		watchlistService:           deps.WatchlistService,
+		rulesService:               deps.RulesService,
+		priceAlertService:          deps.AlertService,
+		analyzerInfos:              deps.AnalyzerInfos,
+		strengthService:            deps.StrengthService,
+		vwapTracker:                deps.VWAPTracker,
+		signalJournal:              deps.SignalJournal,
+		paperTrading:               deps.PaperTrading,
+		exportService:              deps.ExportService,
+		marketDigest:               deps.MarketDigest,
+		marketRegime:               deps.MarketRegime,
+		telegramClient:             telegramClient,
+		messageSender:              ms,
 	}
 
 	// Инициализируем фабрику с сервисами
@@ -200,6 +235,7 @@ func NewTelegramBot(config *config.Config, deps *Dependencies) *TelegramBot {
 		startupTime:            time.Now(),
 		userService:            userService,
 		watchlistService:       services.watchlistService,
+		alertService:           services.priceAlertService,
 	}
 
 	// Определяем текущий режим работы
@@ -422,6 +458,19 @@ func (b *TelegramBot) HandleUpdate(update *telegram.TelegramUpdate) error {
 		}
 	}
 
+	// FSM: ввод условия нового ценового алерта
+	if update.Message != nil && update.Message.Text != "" &&
+		!strings.HasPrefix(update.Message.Text, "/") &&
+		b.userService != nil && b.alertService != nil {
+
+		state, _ := b.userService.GetUserState(handlerParams.User.ID)
+		if state == alert_new_handler.StateAlertCreate {
+			_ = b.userService.ClearUserState(handlerParams.User.ID)
+			result := alert_command.ExecuteCreate(b.alertService, handlerParams.User.ID, update.Message.Text)
+			return b.messageSender.SendTextMessage(handlerParams.ChatID, result.Message, result.Keyboard)
+		}
+	}
+
 	var command string
 	if update.Message != nil && update.Message.Text != "" {
 		command = update.Message.Text
@@ -437,6 +486,16 @@ func (b *TelegramBot) HandleUpdate(update *telegram.TelegramUpdate) error {
 		return b.messageSender.SendTextMessage(handlerParams.ChatID, "Ошибка: "+errText, nil)
 	}
 
+	// Всплывающий ответ на callback (например, оценка сигнала) без нового сообщения
+	if answer, ok := result.Metadata["callback_answer"].(string); ok && update.CallbackQuery != nil {
+		if err := b.messageSender.AnswerCallback(update.CallbackQuery.ID, answer, false); err != nil {
+			logger.Debug("⚠️ AnswerCallback: %v", err)
+		}
+		if result.Message == "" {
+			return nil
+		}
+	}
+
 	return b.messageSender.SendTextMessage(handlerParams.ChatID, result.Message, result.Keyboard)
 }
 
@@ -594,6 +653,15 @@ func (b *TelegramBot) SetMyCommands() error {
 		{Command: "/thresholds", Description: constants.CommandDescriptions.Thresholds},
 		{Command: "/commands", Description: constants.CommandDescriptions.Commands},
 		{Command: "/stats", Description: constants.CommandDescriptions.Stats},
+		{Command: "/rules", Description: constants.CommandDescriptions.Rules},
+		{Command: "/alerts", Description: constants.CommandDescriptions.Alerts},
+		{Command: "/top", Description: constants.CommandDescriptions.Top},
+		{Command: "/vwap", Description: constants.CommandDescriptions.VWAP},
+		{Command: "/history", Description: constants.CommandDescriptions.History},
+		{Command: "/paper", Description: constants.CommandDescriptions.Paper},
+		{Command: "/export", Description: constants.CommandDescriptions.Export},
+		{Command: "/digest", Description: constants.CommandDescriptions.Digest},
+		{Command: "/regime", Description: constants.CommandDescriptions.Regime},
 	}
 
 	logger.Debug("Подготовлено %d команд для отправки", len(commands))
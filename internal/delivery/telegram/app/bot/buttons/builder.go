@@ -47,6 +47,22 @@ func (b *ButtonBuilder) CreateSignalKeyboard(symbol, sessionText, sessionCb stri
 	}
 }
 
+// CreateSignalFeedbackRow создает ряд кнопок оценки сигнала signalID: 👍/👎/«поздно»/«шум»
+func (b *ButtonBuilder) CreateSignalFeedbackRow(signalID string) []telegram.InlineKeyboardButton {
+	feedback := func(text, vote string) telegram.InlineKeyboardButton {
+		return telegram.InlineKeyboardButton{
+			Text:         text,
+			CallbackData: constants.CallbackSignalFeedbackPrefix + vote + ":" + signalID,
+		}
+	}
+	return []telegram.InlineKeyboardButton{
+		feedback(constants.FeedbackButtonTexts.Up, "up"),
+		feedback(constants.FeedbackButtonTexts.Down, "down"),
+		feedback(constants.FeedbackButtonTexts.Late, "late"),
+		feedback(constants.FeedbackButtonTexts.Noise, "noise"),
+	}
+}
+
 // CreateNotificationKeyboard создает клавиатуру для уведомлений
 func (b *ButtonBuilder) CreateNotificationKeyboard() telegram.InlineKeyboardMarkup {
 	return telegram.InlineKeyboardMarkup{
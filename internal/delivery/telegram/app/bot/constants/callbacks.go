@@ -78,6 +78,12 @@ const (
 	CallbackSignalSetGrowthThreshold = "signal_set_growth_threshold" // 📈 Установить порог роста
 	CallbackSignalSetFallThreshold   = "signal_set_fall_threshold"   // 📉 Установить порог падения
 	CallbackSignalSetSensitivity     = "signal_set_sensitivity"      // 🎯 Настроить чувствительность
+	CallbackSignalSetConfluence      = "signal_set_confluence"       // 🧭 Минимальная согласованность ТФ
+	CallbackSignalToggleMarketFilter = "signal_toggle_market_filter" // 🌐 Скрывать движения вслед за BTC
+	CallbackSignalTogglePatternZones = "signal_toggle_pattern_zones" // 🕯️ Паттерны у зон S/R
+	CallbackSignalToggleSectorDigest = "signal_toggle_sector_digest" // 🧩 Дайджест ротаций секторов
+	CallbackSignalToggleVWAP         = "signal_toggle_vwap"          // 📏 Сигналы VWAP
+	CallbackSignalToggleWalls        = "signal_toggle_walls"         // 🧱 Снятие стен стакана
 	CallbackSignalHistory            = "signal_history"              // 📊 История сигналов
 	CallbackSignalTest               = "signal_test"                 // ⚡ Тестовый сигнал
 
@@ -100,6 +106,29 @@ const (
 	// Wildcard: watchlist_page:{PAGE}
 	CallbackWatchlistPagePrefix = "watchlist_page:"
 
+	// ============== PRICE ALERTS ==============
+	CallbackAlertsMenu = "alerts_menu" // 🔔 Ценовые алерты
+	CallbackAlertNew   = "alert_new"   // ➕ Новый алерт
+	// Wildcard: alert_del:{ID}
+	CallbackAlertDeletePrefix = "alert_del:"
+
+	// ============== RELATIVE STRENGTH ==============
+	// Wildcard: top_period:{PERIOD}
+	CallbackTopPeriodPrefix = "top_period:"
+
+	// ============== SIGNAL HISTORY ==============
+	// Wildcard: signal_history:{QUERY} — страница и фильтры (journal.HistoryQuery.Encode)
+	CallbackSignalHistoryPrefix = "signal_history:"
+	// Wildcard: signal_card:{ID}:{QUERY} — карточка доставленного сигнала
+	CallbackSignalCardPrefix = "signal_card:"
+	// Wildcard: signal_feedback:{VOTE}:{SIGNAL_ID} — оценка сигнала под карточкой
+	CallbackSignalFeedbackPrefix = "signal_feedback:"
+
+	// ============== RANGE BREAKOUTS ==============
+	CallbackRangeHorizonsMenu = "range_horizons" // 📐 Пробои диапазона
+	// Wildcard: range_horizon_toggle:{HORIZON}
+	CallbackRangeHorizonTogglePrefix = "range_horizon_toggle:"
+
 	// ============== TEST & DEBUG ==============
 	CallbackTest           = "test"             // 🧪 Тестовое сообщение
 	CallbackTestOK         = "test_ok"          // ✅ Тест OK
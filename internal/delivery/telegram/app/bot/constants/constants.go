@@ -259,6 +259,19 @@ var SessionButtonTexts = struct {
 	DurationDay: "🕐 Весь день",
 }
 
+// FeedbackButtonTexts содержит тексты кнопок оценки сигнала
+var FeedbackButtonTexts = struct {
+	Up    string
+	Down  string
+	Late  string
+	Noise string
+}{
+	Up:    "👍",
+	Down:  "👎",
+	Late:  "⏰ Поздно",
+	Noise: "🔇 Шум",
+}
+
 // TestButtonTexts содержит тексты для тестовых кнопок
 var TestButtonTexts = struct {
 	Test       string
@@ -284,6 +297,13 @@ var SignalButtonTexts = struct {
 	History         string
 	TestSignal      string
 	ThresholdFormat string
+	Confluence      string
+	MarketFilter    string
+	PatternZones    string
+	SectorDigest    string
+	RangeBreakouts  string
+	VWAP            string
+	WallPulls       string
 }{
 	ToggleGrowth:    "📈 Рост",
 	ToggleFall:      "📉 Падение",
@@ -293,6 +313,13 @@ var SignalButtonTexts = struct {
 	History:         "📊 История сигналов",
 	TestSignal:      "⚡ Тестовый сигнал",
 	ThresholdFormat: "%s Порог: %.1f%%",
+	Confluence:      "🧭 Согласованность ТФ",
+	MarketFilter:    "🌐 Без движений за BTC",
+	PatternZones:    "🕯️ Паттерны у зон S/R",
+	SectorDigest:    "🧩 Дайджест секторов",
+	RangeBreakouts:  "📐 Пробои диапазона",
+	VWAP:            "📏 Сигналы VWAP",
+	WallPulls:       "🧱 Снятие стен",
 }
 
 // CommandButtonTexts содержит тексты для кнопок команд
@@ -334,6 +361,15 @@ var CommandDescriptions = struct {
 	Stats         string
 	PaySupport    string
 	Terms         string
+	Rules         string
+	Alerts        string
+	Top           string
+	VWAP          string
+	History       string
+	Paper         string
+	Export        string
+	Digest        string
+	Regime        string
 }{
 	Start:         "Запустить бота",
 	Help:          "Помощь и инструкции",
@@ -347,6 +383,15 @@ var CommandDescriptions = struct {
 	Stats:         "Статистика системы",
 	PaySupport:    "Поддержка по платежам",
 	Terms:         "Условия использования",
+	Rules:         "Мои правила сигналов",
+	Alerts:        "Ценовые алерты",
+	Top:           "Лидеры и аутсайдеры рынка",
+	VWAP:          "VWAP сессии и якоря",
+	History:       "История полученных сигналов",
+	Paper:         "Paper trading по сигналам",
+	Export:        "Выгрузка данных в CSV/JSON",
+	Digest:        "Дайджест рынка за сутки и неделю",
+	Regime:        "Режим рынка и пороги по режимам",
 }
 
 // PaymentButtonTexts содержит тексты для кнопок платежей
@@ -39,6 +39,16 @@ import (
 	session_stop_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/session_stop"
 	settings_main "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/settings_main"
 	signal_set_fall_threshold_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_set_fall_threshold"
+	signal_toggle_market_filter_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_market_filter"
+	signal_toggle_pattern_zones_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_pattern_zones"
+	signal_toggle_vwap_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_vwap"
+	signal_toggle_walls_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_walls"
+	signal_toggle_sector_digest_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_sector_digest"
+	range_horizons_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/range_horizons"
+	signal_set_confluence_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_set_confluence"
+	signal_feedback_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_feedback"
+	signal_history_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_history"
+	signal_set_sensitivity_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_set_sensitivity"
 	signal_set_growth_threshold_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_set_growth_threshold"
 	signal_toggle_fall_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_fall"
 	signal_toggle_growth_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_growth"
@@ -54,6 +64,19 @@ import (
 	paysupport_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/paysupport"
 	periods_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/periods"
 	profile_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/profile"
+	rules_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/rules"
+	analyzers_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/analyzers"
+	analytics_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/analytics"
+	paper_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/paper"
+	export_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/export"
+	digest_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/digest"
+	alert_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/alert"
+	top_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/top"
+	vwap_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/vwap"
+	regime_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/regime"
+	alert_delete_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/alert_delete"
+	alert_new_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/alert_new"
+	alerts_menu_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/alerts_menu"
 	settings_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/settings"
 	terms_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/terms"
 	thresholds_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/thresholds"
@@ -61,13 +84,24 @@ import (
 	successful_payment_handler "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/events/payment/successful_payment"
 	start_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/start"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/middlewares"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
 	telegram_http "crypto-exchange-screener-bot/internal/delivery/telegram/app/http_client"
 	notifications_toggle_service "crypto-exchange-screener-bot/internal/delivery/telegram/services/notifications_toggle"
 	payment_service "crypto-exchange-screener-bot/internal/delivery/telegram/services/payment"
 	profile_service "crypto-exchange-screener-bot/internal/delivery/telegram/services/profile"
 	signal_settings_service "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
 	trading_session_service "crypto-exchange-screener-bot/internal/delivery/telegram/services/trading_session"
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/export"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
 	"crypto-exchange-screener-bot/internal/core/domain/payment"
+	"crypto-exchange-screener-bot/internal/core/domain/rules"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
 	"crypto-exchange-screener-bot/internal/infrastructure/config"
 	currency_client "crypto-exchange-screener-bot/internal/infrastructure/http/currency"
@@ -86,6 +120,18 @@ type Services struct {
 	currencyClient             *currency_client.Client
 	paymentCoreService         *payment.PaymentService
 	watchlistService           watchlist_service.Service
+	rulesService               *rules.Service
+	priceAlertService          *alerts.Service
+	analyzerInfos              func() []common.AnalyzerInfo
+	strengthService            *strength.Service
+	vwapTracker                func() *vwap.Tracker
+	signalJournal              *journal.Service
+	paperTrading               *paper.Service
+	exportService              *export.Service
+	marketDigest               *digest.Service
+	marketRegime               func() *regime.Classifier
+	telegramClient             *telegram_http.TelegramClient
+	messageSender              message_sender.MessageSender
 }
 
 // InitHandlerFactory инициализирует фабрику хэндлеров
@@ -167,6 +213,191 @@ func InitHandlerFactory(
 		return handler
 	})
 
+	if services.rulesService != nil {
+		factory.RegisterHandlerCreator("rules", func() handlers.Handler {
+			handler := rules_command.NewHandler(services.rulesService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// АДМИН: анализаторы реестра (проверка роли внутри хэндлера)
+	if services.analyzerInfos != nil {
+		factory.RegisterHandlerCreator("analyzers", func() handlers.Handler {
+			return analyzers_command.NewHandler(services.analyzerInfos)
+		})
+	}
+
+	// АДМИН: аналитика исходов сигналов журнала (проверка роли внутри хэндлера)
+	if services.signalJournal != nil {
+		factory.RegisterHandlerCreator("analytics", func() handlers.Handler {
+			return analytics_command.NewHandler(services.signalJournal)
+		})
+	}
+
+	// РЕЙТИНГ ОТНОСИТЕЛЬНОЙ СИЛЫ (требует подписки)
+	if services.strengthService != nil {
+		factory.RegisterHandlerCreator("top", func() handlers.Handler {
+			handler := top_command.NewHandler(services.strengthService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		// Wildcard: top_period:{PERIOD}
+		factory.RegisterHandlerCreator(constants.CallbackTopPeriodPrefix+"*", func() handlers.Handler {
+			handler := top_command.NewPeriodHandler(services.strengthService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// VWAP СЕССИИ И ЯКОРЯ (требует подписки)
+	if services.vwapTracker != nil {
+		factory.RegisterHandlerCreator("vwap", func() handlers.Handler {
+			handler := vwap_command.NewHandler(services.vwapTracker, services.signalSettingsService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// ИСТОРИЯ СИГНАЛОВ (требует подписки)
+	if services.signalJournal != nil {
+		factory.RegisterHandlerCreator("history", func() handlers.Handler {
+			handler := signal_history_handler.NewCommandHandler(services.signalJournal)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		factory.RegisterHandlerCreator(constants.CallbackSignalHistory, func() handlers.Handler {
+			handler := signal_history_handler.NewHandler(services.signalJournal)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		// Wildcard: signal_history:{QUERY}
+		factory.RegisterHandlerCreator(constants.CallbackSignalHistoryPrefix+"*", func() handlers.Handler {
+			handler := signal_history_handler.NewPageHandler(services.signalJournal)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		// Wildcard: signal_card:{ID}:{QUERY}
+		factory.RegisterHandlerCreator(constants.CallbackSignalCardPrefix+"*", func() handlers.Handler {
+			handler := signal_history_handler.NewCardHandler(services.signalJournal)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		// Wildcard: signal_feedback:{VOTE}:{SIGNAL_ID} — оценка полученного сигнала (без проверки подписки)
+		factory.RegisterHandlerCreator(constants.CallbackSignalFeedbackPrefix+"*", func() handlers.Handler {
+			return signal_feedback_handler.NewHandler(services.signalJournal)
+		})
+	}
+
+	// PAPER TRADING (требует подписки)
+	if services.paperTrading != nil {
+		factory.RegisterHandlerCreator("paper", func() handlers.Handler {
+			handler := paper_command.NewHandler(services.paperTrading)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// ВЫГРУЗКИ ДАННЫХ (требуют подписки, число выгрузок ограничено тарифом)
+	if services.exportService != nil && services.telegramClient != nil && services.messageSender != nil {
+		factory.RegisterHandlerCreator("export", func() handlers.Handler {
+			handler := export_command.NewHandler(services.exportService, services.telegramClient, services.messageSender)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// ДАЙДЖЕСТ РЫНКА (требует подписки)
+	if services.marketDigest != nil {
+		factory.RegisterHandlerCreator("digest", func() handlers.Handler {
+			handler := digest_command.NewHandler(services.marketDigest)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// РЕЖИМ РЫНКА И ПОРОГИ ПО РЕЖИМАМ (требует подписки)
+	if services.marketRegime != nil {
+		factory.RegisterHandlerCreator("regime", func() handlers.Handler {
+			handler := regime_command.NewHandler(services.marketRegime, services.signalSettingsService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
+	// ЦЕНОВЫЕ АЛЕРТЫ (требуют подписки)
+	if services.priceAlertService != nil {
+		factory.RegisterHandlerCreator("alert", func() handlers.Handler {
+			handler := alert_command.NewHandler(services.priceAlertService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		factory.RegisterHandlerCreator("alerts", func() handlers.Handler {
+			handler := alerts_menu_handler.NewCommandHandler(services.priceAlertService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		factory.RegisterHandlerCreator(constants.CallbackAlertsMenu, func() handlers.Handler {
+			handler := alerts_menu_handler.NewHandler(services.priceAlertService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		factory.RegisterHandlerCreator(constants.CallbackAlertNew, func() handlers.Handler {
+			handler := alert_new_handler.NewHandler(services.userService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+
+		// Wildcard: alert_del:{ID}
+		factory.RegisterHandlerCreator(constants.CallbackAlertDeletePrefix+"*", func() handlers.Handler {
+			handler := alert_delete_handler.NewHandler(services.priceAlertService)
+			if subscriptionMiddleware != nil {
+				return subscriptionMiddleware.RequireSubscription(handler)
+			}
+			return handler
+		})
+	}
+
 	// Регистрируем создателей CALLBACKS (без подписки)
 	factory.RegisterHandlerCreator(constants.CallbackHelp, func() handlers.Handler {
 		return help_callback.NewHandler()
@@ -352,6 +583,79 @@ func InitHandlerFactory(
 		return handler
 	})
 
+	factory.RegisterHandlerCreator(constants.CallbackSignalSetConfluence, func() handlers.Handler {
+		handler := signal_set_confluence_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackSignalSetSensitivity, func() handlers.Handler {
+		handler := signal_set_sensitivity_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackSignalToggleMarketFilter, func() handlers.Handler {
+		handler := signal_toggle_market_filter_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackSignalTogglePatternZones, func() handlers.Handler {
+		handler := signal_toggle_pattern_zones_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackSignalToggleSectorDigest, func() handlers.Handler {
+		handler := signal_toggle_sector_digest_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackSignalToggleVWAP, func() handlers.Handler {
+		handler := signal_toggle_vwap_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackSignalToggleWalls, func() handlers.Handler {
+		handler := signal_toggle_walls_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	factory.RegisterHandlerCreator(constants.CallbackRangeHorizonsMenu, func() handlers.Handler {
+		handler := range_horizons_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
+	// Wildcard: range_horizon_toggle:{HORIZON}
+	factory.RegisterHandlerCreator(constants.CallbackRangeHorizonTogglePrefix+"*", func() handlers.Handler {
+		handler := range_horizons_handler.NewHandler(services.signalSettingsService)
+		if subscriptionMiddleware != nil {
+			return subscriptionMiddleware.RequireSubscription(handler)
+		}
+		return handler
+	})
+
 	// Регистрируем универсальный обработчик для параметризованных callback-ов (требует подписки)
 	factory.RegisterHandlerCreator("with_params", func() handlers.Handler {
 		handler := with_params_handler.NewHandler(services.signalSettingsService)
@@ -2,8 +2,10 @@
 package formatters
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/episodes"
 	"fmt"
 	"math"
+	"strings"
 )
 
 // MetricsFormatter отвечает за форматирование рыночных метрик
@@ -154,3 +156,36 @@ func (f *MetricsFormatter) FormatVolumeDelta(delta float64, deltaPercent float64
 
 	return fmt.Sprintf("%s%s", deltaIcon, deltaStr)
 }
+
+// FormatMarketContext форматирует движение ориентира рынка и собственное движение монеты.
+// Формат: 🌐 BTC: -3.10% • β 1.20 • своё: -0.38%
+func (f *MetricsFormatter) FormatMarketContext(benchmark string, benchmarkChange, beta, idiosyncratic float64) string {
+	name := strings.TrimSuffix(benchmark, "USDT")
+	return fmt.Sprintf("🌐 %s: %+.2f%% • β %.2f • своё: %+.2f%%",
+		name, benchmarkChange, beta, idiosyncratic)
+}
+
+// FormatLiquidity форматирует стоимость сдвига цены на 1% в обе стороны и дисбаланс стакана.
+// Формат: 💧 +1%: $420K • −1%: $310K • дисбаланс +15% [⚠️ тонкий стакан]
+func (f *MetricsFormatter) FormatLiquidity(costUp, costDown, imbalance float64, thinBook bool) string {
+	result := fmt.Sprintf("💧 +1%%: $%s • −1%%: $%s • дисбаланс %+.0f%%",
+		f.numberFormatter.FormatDollarValue(costUp),
+		f.numberFormatter.FormatDollarValue(costDown),
+		imbalance*100)
+	if thinBook {
+		result += " ⚠️ тонкий стакан"
+	}
+	return result
+}
+
+// FormatEpisode форматирует стадию эпизода памп/дамп, пик и откат от пика.
+// Формат: 💥 Эпизод: дамп • пик 0.0123 (+18.4%) • откат −7.20%
+func (f *MetricsFormatter) FormatEpisode(stage string, peak, gainPct, retracePct float64) string {
+	st := episodes.Stage(stage)
+	result := fmt.Sprintf("%s Эпизод: %s • пик %s (%+.1f%%)",
+		st.Emoji(), st.Label(), f.numberFormatter.FormatPrice(peak), gainPct)
+	if retracePct > 0 {
+		result += fmt.Sprintf(" • откат −%.2f%%", retracePct)
+	}
+	return result
+}
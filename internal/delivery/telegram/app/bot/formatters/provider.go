@@ -5,6 +5,7 @@ import (
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/formatters/recommendation"
 	"crypto-exchange-screener-bot/pkg/logger"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 )
@@ -41,6 +42,7 @@ func NewFormatterProvider(exchange string) *FormatterProvider {
 
 // CounterData данные для форматирования counter сигнала
 type CounterData struct {
+	SignalID           string // ID сигнала в журнале (для истории, в карточку не выводится)
 	Symbol             string
 	Direction          string
 	ChangePercent      float64
@@ -79,6 +81,37 @@ type CounterData struct {
 	// Зоны поддержки/сопротивления
 	SRSupport    *SRZoneData
 	SRResistance *SRZoneData
+
+	// Согласованность со старшими таймфреймами (0..100)
+	ConfluenceScore float64
+	HasConfluence   bool
+
+	// Рыночный контекст (бета к BTC/ETH)
+	HasMarketContext    bool
+	MarketBenchmark     string
+	MarketBenchmarkMove float64
+	MarketBeta          float64
+	IdiosyncraticChange float64
+
+	// Ликвидность стакана (±1% от mid)
+	HasLiquidity          bool
+	LiquidityCostUp1Pct   float64
+	LiquidityCostDown1Pct float64
+	LiquidityImbalance    float64
+	LiquidityThinBook     bool
+
+	// Эпизод памп/дамп (пустой EpisodeID — сигнал вне эпизода)
+	EpisodeID         string
+	EpisodeStage      string
+	EpisodePeakPrice  float64
+	EpisodeGainPct    float64
+	EpisodeRetracePct float64
+
+	// VWAP дневной сессии
+	HasVWAP         bool
+	VWAPSession     float64
+	VWAPDistancePct float64
+	VWAPSigmas      float64
 }
 
 // FormatCounterSignal форматирует counter сигнал для отправки в Telegram
@@ -140,7 +173,8 @@ func (p *FormatterProvider) FormatCounterSignal(data CounterData) string {
 	// 6. ТЕХНИЧЕСКИЙ АНАЛИЗ (если есть данные)
 	// 📊 Тех. анализ:
 	// RSI: 50.0 ⚪ (нейтральный)
-	if data.RSI > 0 || data.MACDSignal != 0 {
+	// VWAP: 0.1234 • +1.85% (+2.1σ)
+	if data.RSI > 0 || data.MACDSignal != 0 || data.HasVWAP {
 		builder.WriteString("📊 Тех. анализ:\n")
 
 		// ⭐ ИСПОЛЬЗУЕМ РЕАЛЬНЫЕ ДАННЫЕ С СТАТУСАМИ
@@ -168,10 +202,47 @@ func (p *FormatterProvider) FormatCounterSignal(data CounterData) string {
 			}
 			builder.WriteString("\n")
 		}
+
+		if data.HasVWAP {
+			builder.WriteString(p.TechnicalFormatter.FormatVWAP(
+				p.NumberFormatter.FormatPrice(data.VWAPSession), data.VWAPDistancePct, data.VWAPSigmas))
+			builder.WriteString("\n")
+		}
 		builder.WriteString("\n")
 	}
 
-	// 7. ЗОНЫ S/R (если есть данные)
+	// 7. СТАРШИЕ ТАЙМФРЕЙМЫ (если оценка рассчитана)
+	// 🧭 Старшие ТФ: 72/100 (поддерживают)
+	if data.HasConfluence {
+		builder.WriteString(p.TechnicalFormatter.FormatConfluence(data.ConfluenceScore))
+		builder.WriteString("\n\n")
+	}
+
+	// РЫНОЧНЫЙ КОНТЕКСТ (если бета рассчитана)
+	// 🌐 BTC: -3.10% • β 1.20 • своё: -0.38%
+	if data.HasMarketContext {
+		builder.WriteString(p.MetricsFormatter.FormatMarketContext(
+			data.MarketBenchmark, data.MarketBenchmarkMove, data.MarketBeta, data.IdiosyncraticChange))
+		builder.WriteString("\n\n")
+	}
+
+	// ЛИКВИДНОСТЬ СТАКАНА (если стакан получен)
+	// 💧 +1%: $420K • −1%: $310K • дисбаланс +15%
+	if data.HasLiquidity {
+		builder.WriteString(p.MetricsFormatter.FormatLiquidity(
+			data.LiquidityCostUp1Pct, data.LiquidityCostDown1Pct, data.LiquidityImbalance, data.LiquidityThinBook))
+		builder.WriteString("\n\n")
+	}
+
+	// ЭПИЗОД ПАМП/ДАМП (если сигнал привязан к эпизоду)
+	// 🚀 Эпизод: памп • пик 0.0123 (+18.4%)
+	if data.EpisodeID != "" {
+		builder.WriteString(p.MetricsFormatter.FormatEpisode(
+			data.EpisodeStage, data.EpisodePeakPrice, data.EpisodeGainPct, data.EpisodeRetracePct))
+		builder.WriteString("\n\n")
+	}
+
+	// 8. ЗОНЫ S/R (если есть данные)
 	if srBlock := p.SRZonesFormatter.FormatSRZonesBlock(
 		data.Period, data.SRSupport, data.SRResistance,
 	); srBlock != "" {
@@ -224,6 +295,21 @@ func (p *FormatterProvider) FormatCounterSignal(data CounterData) string {
 	return strings.TrimSpace(builder.String())
 }
 
+// FormatEpisodeUpdate форматирует продолжение эпизода — ответ на первое сообщение эпизода
+func (p *FormatterProvider) FormatEpisodeUpdate(data CounterData) string {
+	icon, directionText, prefix := p.SignalFormatter.GetDirectionInfo(data.Direction)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s %s %s%.2f%% • %s\n",
+		icon, directionText, data.Symbol, prefix, math.Abs(data.ChangePercent),
+		p.HeaderFormatter.ExtractTimeframe(data.Period)))
+	builder.WriteString(fmt.Sprintf("💰 Цена: %s\n", p.NumberFormatter.FormatPrice(data.CurrentPrice)))
+	builder.WriteString(p.MetricsFormatter.FormatEpisode(
+		data.EpisodeStage, data.EpisodePeakPrice, data.EpisodeGainPct, data.EpisodeRetracePct))
+	builder.WriteString(fmt.Sprintf("\n🕐 %s", data.Timestamp.Format("15:04:05")))
+	return builder.String()
+}
+
 // FormatCompactCounterSignal форматирует компактный counter сигнал
 func (p *FormatterProvider) FormatCompactCounterSignal(data CounterData) string {
 	icon, directionText, _ := p.SignalFormatter.GetDirectionInfo(data.Direction)
@@ -93,3 +93,43 @@ func (f *TechnicalFormatter) FormatMACD(macdSignal float64) string {
 func (f *TechnicalFormatter) FormatMACDWithDescription(macdDescription string) string {
 	return fmt.Sprintf("MACD: %s", macdDescription)
 }
+
+// FormatConfluence форматирует оценку согласованности со старшими таймфреймами (0..100)
+func (f *TechnicalFormatter) FormatConfluence(score float64) string {
+	var emoji, description string
+
+	switch {
+	case score >= 70:
+		emoji = "🟢"
+		description = "поддерживают"
+	case score >= 55:
+		emoji = "🟡"
+		description = "скорее поддерживают"
+	case score > 45:
+		emoji = "⚪"
+		description = "нейтрально"
+	case score > 30:
+		emoji = "🟠"
+		description = "скорее против"
+	default:
+		emoji = "🔴"
+		description = "против сигнала"
+	}
+
+	return fmt.Sprintf("🧭 Старшие ТФ: %.0f/100 %s (%s)", score, emoji, description)
+}
+
+// FormatVWAP форматирует расстояние цены от VWAP дневной сессии.
+// Формат: VWAP: 0.1234 • +1.85% 🟢 (+2.1σ)
+func (f *TechnicalFormatter) FormatVWAP(vwap string, distancePct, sigmas float64) string {
+	emoji := "⚪"
+	switch {
+	case sigmas >= 2 || sigmas <= -2:
+		emoji = "🔴" // цена растянута от VWAP за полосу 2σ
+	case distancePct > 0:
+		emoji = "🟢"
+	case distancePct < 0:
+		emoji = "🟠"
+	}
+	return fmt.Sprintf("VWAP: %s • %+.2f%% %s (%+.1fσ)", vwap, distancePct, emoji, sigmas)
+}
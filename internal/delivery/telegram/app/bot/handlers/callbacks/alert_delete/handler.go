@@ -0,0 +1,55 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/alert_delete/handler.go
+// Обрабатывает нажатие кнопки удаления алерта (alert_del:{ID})
+// и показывает обновлённый список.
+package alert_delete
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/callbacks/alerts_menu"
+)
+
+type alertDeleteHandler struct {
+	*base.BaseHandler
+	alertService *alertsDomain.Service
+}
+
+// NewHandler создаёт обработчик удаления алерта
+func NewHandler(alertService *alertsDomain.Service) handlers.Handler {
+	return &alertDeleteHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "alert_delete_handler",
+			Command: constants.CallbackAlertDeletePrefix + "*",
+			Type:    handlers.TypeCallback,
+		},
+		alertService: alertService,
+	}
+}
+
+func (h *alertDeleteHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	alertID, err := strconv.Atoi(strings.TrimPrefix(params.Data, constants.CallbackAlertDeletePrefix))
+	if err != nil || alertID <= 0 {
+		return handlers.HandlerResult{}, fmt.Errorf("неверный номер алерта: %s", params.Data)
+	}
+
+	header := fmt.Sprintf("🗑 Алерт #%d удалён", alertID)
+	if err := h.alertService.DeleteAlert(params.User.ID, alertID); err != nil {
+		if !errors.Is(err, alertsDomain.ErrAlertNotFound) {
+			return handlers.HandlerResult{}, err
+		}
+		header = fmt.Sprintf("❌ Алерт #%d не найден", alertID)
+	}
+
+	return alerts_menu.BuildMenu(h.alertService, params.User.ID, header)
+}
@@ -0,0 +1,48 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/alert_new/handler.go
+// Устанавливает состояние FSM "alert_create", чтобы следующее текстовое
+// сообщение пользователя было интерпретировано как условие алерта.
+package alert_new
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	alert_command "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/commands/alert"
+)
+
+// StateAlertCreate — ключ FSM-состояния ввода условия алерта
+const StateAlertCreate = "alert_create"
+
+type alertNewHandler struct {
+	*base.BaseHandler
+	userService *users.Service
+}
+
+// NewHandler создаёт обработчик кнопки «Новый алерт»
+func NewHandler(userService *users.Service) handlers.Handler {
+	return &alertNewHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "alert_new_handler",
+			Command: constants.CallbackAlertNew,
+			Type:    handlers.TypeCallback,
+		},
+		userService: userService,
+	}
+}
+
+func (h *alertNewHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if err := h.userService.SetUserState(params.User.ID, StateAlertCreate); err != nil {
+		return handlers.HandlerResult{}, err
+	}
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{{"text": "🔙 Отмена", "callback_data": constants.CallbackAlertsMenu}},
+		},
+	}
+	return handlers.HandlerResult{
+		Message:  "🔔 Отправьте условие алерта.\n\n" + alert_command.UsageText,
+		Keyboard: keyboard,
+	}, nil
+}
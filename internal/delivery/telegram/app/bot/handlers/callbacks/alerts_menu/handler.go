@@ -0,0 +1,98 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/alerts_menu/handler.go
+// Список ценовых алертов пользователя с кнопками удаления.
+// Доступен по кнопке (alerts_menu) и командой /alerts.
+package alerts_menu
+
+import (
+	"fmt"
+	"strings"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+)
+
+type alertsMenuHandler struct {
+	*base.BaseHandler
+	alertService *alertsDomain.Service
+}
+
+// NewHandler создаёт обработчик кнопки «Мои алерты»
+func NewHandler(alertService *alertsDomain.Service) handlers.Handler {
+	return &alertsMenuHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "alerts_menu_handler",
+			Command: constants.CallbackAlertsMenu,
+			Type:    handlers.TypeCallback,
+		},
+		alertService: alertService,
+	}
+}
+
+// NewCommandHandler создаёт обработчик команды /alerts
+func NewCommandHandler(alertService *alertsDomain.Service) handlers.Handler {
+	return &alertsMenuHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "alerts_command_handler",
+			Command: "alerts",
+			Type:    handlers.TypeCommand,
+		},
+		alertService: alertService,
+	}
+}
+
+func (h *alertsMenuHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	return BuildMenu(h.alertService, params.User.ID, "")
+}
+
+// BuildMenu формирует список алертов; header — необязательная строка над списком
+func BuildMenu(alertService *alertsDomain.Service, userID int, header string) (handlers.HandlerResult, error) {
+	list, err := alertService.ListAlerts(userID)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("не удалось загрузить алерты: %w", err)
+	}
+
+	var sb strings.Builder
+	if header != "" {
+		sb.WriteString(header + "\n\n")
+	}
+	sb.WriteString("🔔 *Ценовые алерты*\n\n")
+
+	var rows [][]map[string]string
+	if len(list) == 0 {
+		sb.WriteString("У вас нет активных алертов.\n\nНажмите «➕ Новый алерт» или отправьте `/alert SOL 180`.")
+	} else {
+		var row []map[string]string
+		for _, alert := range list {
+			sb.WriteString(fmt.Sprintf("*#%d* %s — %s\n", alert.ID, alert.Symbol, alertsDomain.Describe(alert)))
+
+			row = append(row, map[string]string{
+				"text":          fmt.Sprintf("🗑 #%d", alert.ID),
+				"callback_data": fmt.Sprintf("%s%d", constants.CallbackAlertDeletePrefix, alert.ID),
+			})
+			if len(row) == 3 {
+				rows = append(rows, row)
+				row = nil
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+		sb.WriteString("\nНажмите 🗑, чтобы удалить алерт.")
+	}
+
+	rows = append(rows,
+		[]map[string]string{{"text": "➕ Новый алерт", "callback_data": constants.CallbackAlertNew}},
+		[]map[string]string{{"text": "🔙 Главное меню", "callback_data": constants.CallbackMenuMain}},
+	)
+
+	return handlers.HandlerResult{
+		Message:  sb.String(),
+		Keyboard: map[string]interface{}{"inline_keyboard": rows},
+		Metadata: map[string]interface{}{"user_id": userID, "alerts": len(list)},
+	}, nil
+}
@@ -92,6 +92,7 @@ func (h *menuMainHandler) createMainMenuKeyboard(isAuth bool) interface{} {
 				},
 				{
 					{"text": "📋 Мои монеты", "callback_data": constants.CallbackWatchlistMenu},
+					{"text": "🔔 Алерты", "callback_data": constants.CallbackAlertsMenu},
 				},
 				{
 					{"text": constants.MenuButtonTexts.Reset, "callback_data": constants.CallbackResetMenu},
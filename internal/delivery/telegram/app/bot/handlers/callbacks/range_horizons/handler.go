@@ -0,0 +1,115 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/range_horizons/handler.go
+package range_horizons
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+)
+
+// rangeHorizonsHandler реализация обработчика меню горизонтов пробоев диапазона
+type rangeHorizonsHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает обработчик меню и переключателей горизонтов пробоев.
+// Обслуживает range_horizons и range_horizon_toggle:{HORIZON}.
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &rangeHorizonsHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "range_horizons_handler",
+			Command: constants.CallbackRangeHorizonsMenu,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback горизонтов пробоев
+func (h *rangeHorizonsHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	status := ""
+	if strings.HasPrefix(params.Data, constants.CallbackRangeHorizonTogglePrefix) {
+		horizon := strings.TrimPrefix(params.Data, constants.CallbackRangeHorizonTogglePrefix)
+		result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+			Action: "toggle_range_horizon",
+			UserID: params.User.ID,
+			ChatID: params.ChatID,
+			Value:  horizon,
+		})
+		if err != nil {
+			return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+		}
+		if updated, ok := result.NewValue.([]string); ok {
+			params.User.RangeBreakoutHorizons = updated
+		}
+		status = result.Message + "\n\n"
+	}
+
+	return handlers.HandlerResult{
+		Message:  h.createMessage(params.User, status),
+		Keyboard: h.createKeyboard(params.User),
+		Metadata: map[string]interface{}{
+			"user_id":                 params.User.ID,
+			"range_breakout_horizons": params.User.RangeBreakoutHorizons,
+		},
+	}, nil
+}
+
+// createMessage формирует описание меню
+func (h *rangeHorizonsHandler) createMessage(user *models.User, status string) string {
+	return fmt.Sprintf(
+		"📐 *Пробои диапазона*\n\n%s"+
+			"Бот следит за максимумами и минимумами каждой монеты за 1, 7, 30 и 90 дней, "+
+			"а также за всю загруженную историю, и сообщает, когда цена их обновляет. "+
+			"В алерте указаны прошлый экстремум, его дата и расстояние до него.\n\n"+
+			"Если пробито сразу несколько горизонтов, приходит одно сообщение "+
+			"по самому длинному из выбранных.\n\n"+
+			"Выбрано: *%s*",
+		status,
+		h.describeSelected(user),
+	)
+}
+
+// describeSelected возвращает список выбранных горизонтов
+func (h *rangeHorizonsHandler) describeSelected(user *models.User) string {
+	if len(user.RangeBreakoutHorizons) == 0 {
+		return "ничего (алерты выключены)"
+	}
+	labels := make([]string, 0, len(user.RangeBreakoutHorizons))
+	for _, code := range user.RangeBreakoutHorizons {
+		labels = append(labels, ranges.Horizon(code).Label())
+	}
+	return strings.Join(labels, ", ")
+}
+
+// createKeyboard создает клавиатуру переключателей по горизонтам
+func (h *rangeHorizonsHandler) createKeyboard(user *models.User) interface{} {
+	keyboard := make([][]map[string]string, 0, len(ranges.Horizons)+1)
+	for _, horizon := range ranges.Horizons {
+		code := string(horizon)
+		keyboard = append(keyboard, []map[string]string{
+			{
+				"text":          h.BaseHandler.GetToggleText(horizon.Label(), user.SubscribesRangeHorizon(code)),
+				"callback_data": constants.CallbackRangeHorizonTogglePrefix + code,
+			},
+		})
+	}
+	keyboard = append(keyboard, []map[string]string{
+		{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+	})
+
+	return map[string]interface{}{
+		"inline_keyboard": keyboard,
+	}
+}
@@ -0,0 +1,8 @@
+package range_horizons
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// RangeHorizonsHandler интерфейс обработчика горизонтов пробоев диапазона
+type RangeHorizonsHandler interface {
+	handlers.Handler
+}
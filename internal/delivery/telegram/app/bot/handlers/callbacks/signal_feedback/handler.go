@@ -0,0 +1,75 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_feedback/handler.go
+// Оценка сигнала кнопками под карточкой (signal_feedback:{VOTE}:{SIGNAL_ID}).
+// Ответ — всплывающее уведомление, карточка сигнала не меняется.
+package signal_feedback
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// platform — платформа доставок в журнале
+const platform = "telegram"
+
+// voteAnswers — ответы на голоса
+var voteAnswers = map[string]string{
+	models.FeedbackUp:    "👍 Спасибо! Сигнал отмечен полезным",
+	models.FeedbackDown:  "👎 Спасибо! Учтем при настройке сигналов",
+	models.FeedbackLate:  "⏰ Спасибо! Отмечено: сигнал пришел поздно",
+	models.FeedbackNoise: "🔇 Отмечено как шум. Если символ часто шумит, порог по нему для вас повысится",
+}
+
+// signalFeedbackHandler реализация обработчика оценки сигнала
+type signalFeedbackHandler struct {
+	*base.BaseHandler
+	journal *journal.Service
+}
+
+// NewHandler создает обработчик оценки сигнала
+func NewHandler(journalService *journal.Service) handlers.Handler {
+	return &signalFeedbackHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_feedback_handler",
+			Command: constants.CallbackSignalFeedbackPrefix + "*",
+			Type:    handlers.TypeCallback,
+		},
+		journal: journalService,
+	}
+}
+
+// Execute сохраняет голос пользователя
+func (h *signalFeedbackHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	vote, signalID, _ := strings.Cut(strings.TrimPrefix(params.Data, constants.CallbackSignalFeedbackPrefix), ":")
+	answer, ok := voteAnswers[vote]
+	if !ok || signalID == "" {
+		return answerOnly("❌ Неизвестная оценка"), nil
+	}
+
+	found, err := h.journal.Vote(params.User.ID, platform, signalID, vote)
+	if err != nil {
+		logger.Warn("⚠️ Не удалось сохранить оценку сигнала %s пользователя %d: %v", signalID, params.User.ID, err)
+		return answerOnly("❌ Не удалось сохранить оценку, попробуйте позже"), nil
+	}
+	if !found {
+		return answerOnly("Сигнал уже удален из истории"), nil
+	}
+	return answerOnly(answer), nil
+}
+
+// answerOnly — результат без нового сообщения: только всплывающий ответ на callback
+func answerOnly(text string) handlers.HandlerResult {
+	return handlers.HandlerResult{
+		Metadata: map[string]interface{}{"callback_answer": text},
+	}
+}
@@ -1,60 +1,259 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_history/handler.go
+// История полученных сигналов: список с фильтрами и исходами, карточка сигнала.
+// Доступна кнопкой меню сигналов, командой /history [фильтры],
+// кнопками страниц (signal_history:{QUERY}) и карточек (signal_card:{ID}:{QUERY}).
 package signal_history
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
 )
 
+// platform — платформа доставок в журнале
+const platform = "telegram"
+
 // signalHistoryHandler реализация обработчика истории сигналов
 type signalHistoryHandler struct {
 	*base.BaseHandler
+	journal *journal.Service
 }
 
-// NewHandler создает новый обработчик истории сигналов
-func NewHandler() handlers.Handler {
+// NewHandler создает обработчик кнопки истории сигналов
+func NewHandler(journalService *journal.Service) handlers.Handler {
 	return &signalHistoryHandler{
 		BaseHandler: &base.BaseHandler{
 			Name:    "signal_history_handler",
 			Command: constants.CallbackSignalHistory,
 			Type:    handlers.TypeCallback,
 		},
+		journal: journalService,
+	}
+}
+
+// NewCommandHandler создает обработчик команды /history [фильтры]
+func NewCommandHandler(journalService *journal.Service) handlers.Handler {
+	return &signalHistoryHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "history_command_handler",
+			Command: "history",
+			Type:    handlers.TypeCommand,
+		},
+		journal: journalService,
+	}
+}
+
+// NewPageHandler создает обработчик страниц и фильтров (signal_history:{QUERY})
+func NewPageHandler(journalService *journal.Service) handlers.Handler {
+	return &signalHistoryHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_history_page_handler",
+			Command: constants.CallbackSignalHistoryPrefix + "*",
+			Type:    handlers.TypeCallback,
+		},
+		journal: journalService,
+	}
+}
+
+// NewCardHandler создает обработчик карточки сигнала (signal_card:{ID}:{QUERY})
+func NewCardHandler(journalService *journal.Service) handlers.Handler {
+	return &signalHistoryHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_card_handler",
+			Command: constants.CallbackSignalCardPrefix + "*",
+			Type:    handlers.TypeCallback,
+		},
+		journal: journalService,
 	}
 }
 
-// Execute выполняет обработку callback истории сигналов
+// Execute выполняет обработку истории сигналов
 func (h *signalHistoryHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
 	if params.User == nil {
 		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
 	}
+	if h.journal == nil {
+		return handlers.HandlerResult{
+			Message:  "❌ История сигналов недоступна",
+			Keyboard: backKeyboard(),
+		}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(params.Data, constants.CallbackSignalCardPrefix):
+		return h.showCard(params)
+	case strings.HasPrefix(params.Data, constants.CallbackSignalHistoryPrefix):
+		query := journal.ParseQuery(strings.TrimPrefix(params.Data, constants.CallbackSignalHistoryPrefix))
+		return h.showList(params, query)
+	case h.Type == handlers.TypeCommand:
+		query, err := journal.ParseHistoryArgs(params.Data, time.Now())
+		if err != nil {
+			return handlers.HandlerResult{
+				Message: fmt.Sprintf("❌ %v\n\nПример: `/history BTC рост 15m неделя` или `/history 01.03-15.03`", err),
+			}, nil
+		}
+		return h.showList(params, query)
+	default:
+		return h.showList(params, journal.HistoryQuery{})
+	}
+}
+
+// showList показывает страницу истории
+func (h *signalHistoryHandler) showList(params handlers.HandlerParams, query journal.HistoryQuery) (handlers.HandlerResult, error) {
+	page, err := h.journal.History(params.User.ID, platform, query)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка загрузки истории сигналов: %w", err)
+	}
+	query = query.WithPage(page.Page)
 
-	message := fmt.Sprintf(
-		"📊 *История сигналов*\n\n"+
-			"Здесь будет отображаться история полученных сигналов.\n\n"+
-			"*Функции в разработке:*\n"+
-			"• Просмотр последних сигналов\n"+
-			"• Фильтрация по типу и дате\n"+
-			"• Статистика эффективности\n"+
-			"• Экспорт данных\n\n"+
-			"Эта функция появится в следующем обновлении.",
+	return handlers.HandlerResult{
+		Message:  formatList(page, query),
+		Keyboard: listKeyboard(page, query),
+		Metadata: map[string]interface{}{"user_id": params.User.ID, "total": page.Total},
+	}, nil
+}
+
+// showCard показывает сохраненную карточку сигнала с исходом
+func (h *signalHistoryHandler) showCard(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	rest := strings.TrimPrefix(params.Data, constants.CallbackSignalCardPrefix)
+	idPart, encoded, _ := strings.Cut(rest, ":")
+	query := journal.ParseQuery(encoded)
+
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return h.showList(params, query)
+	}
+
+	item, err := h.journal.Delivery(params.User.ID, id)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка загрузки сигнала: %w", err)
+	}
+	if item == nil {
+		return handlers.HandlerResult{
+			Message:  "❌ Сигнал не найден — возможно, он удален по сроку хранения",
+			Keyboard: cardKeyboard(query),
+		}, nil
+	}
+
+	message := fmt.Sprintf("%s\n\n📈 *%s*\n🕐 Получен: %s",
+		item.CardText,
+		journal.OutcomeDetails(item),
+		item.DeliveredAt.Format("02.01.2006 15:04"),
 	)
 
-	// Простая клавиатура
-	keyboard := map[string]interface{}{
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: cardKeyboard(query),
+		Metadata: map[string]interface{}{"user_id": params.User.ID, "delivery_id": id},
+	}, nil
+}
+
+// formatList форматирует страницу истории (Markdown)
+func formatList(page journal.HistoryPage, query journal.HistoryQuery) string {
+	var sb strings.Builder
+
+	sb.WriteString("📊 *История сигналов*\n")
+	sb.WriteString(fmt.Sprintf("🔎 %s\n\n", query.Describe()))
+
+	if page.Total == 0 {
+		sb.WriteString("Сигналов по этим фильтрам нет.\n\n")
+		sb.WriteString("Фильтры: `/history BTC рост 15m неделя`, даты — `/history 01.03-15.03`")
+		return sb.String()
+	}
+
+	for i, item := range page.Items {
+		icon := "🟢"
+		if item.Direction == "fall" {
+			icon = "🔴"
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s `%s` %+.2f%% за %s • %s\n   %s\n",
+			i+1, icon, item.Symbol, item.ChangePercent,
+			periodPkg.MinutesToString(item.PeriodMinutes),
+			item.DeliveredAt.Format("02.01 15:04"),
+			journal.OutcomeSummary(item),
+		))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nСтраница %d из %d • всего %d", page.Page+1, page.Pages, page.Total))
+	return sb.String()
+}
+
+// listKeyboard — карточки, фильтры и навигация по страницам
+func listKeyboard(page journal.HistoryPage, query journal.HistoryQuery) interface{} {
+	var rows [][]map[string]string
+
+	var cards []map[string]string
+	for i, item := range page.Items {
+		cards = append(cards, map[string]string{
+			"text":          strconv.Itoa(i + 1),
+			"callback_data": fmt.Sprintf("%s%d:%s", constants.CallbackSignalCardPrefix, item.ID, query.Encode()),
+		})
+		if len(cards) == 4 {
+			rows = append(rows, cards)
+			cards = nil
+		}
+	}
+	if len(cards) > 0 {
+		rows = append(rows, cards)
+	}
+
+	rows = append(rows, []map[string]string{
+		{"text": "↕️ " + query.DirectionLabel(), "callback_data": listCallback(query.NextDirection())},
+		{"text": "⏱ " + query.PeriodLabel(), "callback_data": listCallback(query.NextPeriod())},
+		{"text": "📅 " + query.RangeLabel(), "callback_data": listCallback(query.NextRange())},
+	})
+	if query.Symbol != "" {
+		reset := query
+		reset.Symbol = ""
+		rows = append(rows, []map[string]string{
+			{"text": "✖️ " + query.Symbol, "callback_data": listCallback(reset.WithPage(0))},
+		})
+	}
+
+	var nav []map[string]string
+	if page.Page > 0 {
+		nav = append(nav, map[string]string{"text": "⬅️", "callback_data": listCallback(query.WithPage(page.Page - 1))})
+	}
+	if page.Page+1 < page.Pages {
+		nav = append(nav, map[string]string{"text": "➡️", "callback_data": listCallback(query.WithPage(page.Page + 1))})
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, []map[string]string{
+		{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+	})
+
+	return map[string]interface{}{"inline_keyboard": rows}
+}
+
+// cardKeyboard — возврат к списку с теми же фильтрами
+func cardKeyboard(query journal.HistoryQuery) interface{} {
+	return map[string]interface{}{
 		"inline_keyboard": [][]map[string]string{
-			{
-				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
-			},
+			{{"text": "🔙 К истории", "callback_data": listCallback(query)}},
 		},
 	}
+}
 
-	return handlers.HandlerResult{
-		Message:  message,
-		Keyboard: keyboard,
-		Metadata: map[string]interface{}{
-			"user_id": params.User.ID,
+// backKeyboard — возврат в меню сигналов
+func backKeyboard() interface{} {
+	return map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu}},
 		},
-	}, nil
+	}
+}
+
+// listCallback — callback_data страницы истории
+func listCallback(query journal.HistoryQuery) string {
+	return constants.CallbackSignalHistoryPrefix + query.Encode()
 }
@@ -0,0 +1,136 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_set_confluence/handler.go
+package signal_set_confluence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// signalSetConfluenceHandler реализация обработчика настройки минимальной согласованности
+type signalSetConfluenceHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает новый обработчик настройки минимальной согласованности
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &signalSetConfluenceHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_set_confluence_handler",
+			Command: constants.CallbackSignalSetConfluence,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback настройки согласованности
+func (h *signalSetConfluenceHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	// Формат: "signal_set_confluence:60"
+	if strings.Contains(params.Data, ":") {
+		parts := strings.Split(params.Data, ":")
+		if len(parts) == 2 && parts[0] == constants.CallbackSignalSetConfluence {
+			return h.handleScoreSelection(params, parts[1])
+		}
+	}
+
+	return h.showConfluenceMenu(params)
+}
+
+// showConfluenceMenu показывает меню выбора минимальной согласованности
+func (h *signalSetConfluenceHandler) showConfluenceMenu(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	current := "выключен"
+	if params.User.MinConfluenceScore > 0 {
+		current = fmt.Sprintf("от %.0f/100", params.User.MinConfluenceScore)
+	}
+
+	message := fmt.Sprintf(
+		"🧭 *Согласованность со старшими таймфреймами*\n\n"+
+			"Для каждого сигнала бот оценивает тренд, RSI и зоны S/R на старших "+
+			"таймфреймах (15m/1h/4h/1d) и считает оценку от 0 до 100.\n"+
+			"50 — нейтрально, выше — старшие ТФ поддерживают сигнал.\n\n"+
+			"Текущий фильтр: *%s*\n\n"+
+			"*Рекомендуемые значения:*\n"+
+			"• 50 - отсекать сигналы против старших ТФ\n"+
+			"• 60-70 - только подтвержденные сигналы\n"+
+			"• 80 - только сильное совпадение",
+		current,
+	)
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": "Выкл", "callback_data": constants.CallbackSignalSetConfluence + ":0"},
+				{"text": "50", "callback_data": constants.CallbackSignalSetConfluence + ":50"},
+				{"text": "60", "callback_data": constants.CallbackSignalSetConfluence + ":60"},
+			},
+			{
+				{"text": "70", "callback_data": constants.CallbackSignalSetConfluence + ":70"},
+				{"text": "80", "callback_data": constants.CallbackSignalSetConfluence + ":80"},
+			},
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":       params.User.ID,
+			"current_score": params.User.MinConfluenceScore,
+		},
+	}, nil
+}
+
+// handleScoreSelection обрабатывает выбор минимальной согласованности
+func (h *signalSetConfluenceHandler) handleScoreSelection(params handlers.HandlerParams, scoreStr string) (handlers.HandlerResult, error) {
+	score, err := strconv.ParseFloat(scoreStr, 64)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("неверное значение согласованности: %w", err)
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "set_min_confluence",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  score,
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf("✅ *Фильтр обновлен*\n\n%s", result.Message)
+	if score > 0 {
+		message += fmt.Sprintf("\n\nСигналы с оценкой старших таймфреймов ниже %.0f не будут приходить.", score)
+	}
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":       params.User.ID,
+			"new_score":     score,
+			"updated_field": result.UpdatedField,
+		},
+	}, nil
+}
@@ -0,0 +1,8 @@
+package signal_set_confluence
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// SignalSetConfluenceHandler интерфейс обработчика настройки согласованности таймфреймов
+type SignalSetConfluenceHandler interface {
+	handlers.Handler
+}
@@ -1,26 +1,33 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_set_sensitivity/handler.go
 package signal_set_sensitivity
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/anomaly"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
 )
 
 // signalSetSensitivityHandler реализация обработчика настройки чувствительности
 type signalSetSensitivityHandler struct {
 	*base.BaseHandler
+	service signal_settings_svc.Service
 }
 
 // NewHandler создает новый обработчик настройки чувствительности
-func NewHandler() handlers.Handler {
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
 	return &signalSetSensitivityHandler{
 		BaseHandler: &base.BaseHandler{
 			Name:    "signal_set_sensitivity_handler",
 			Command: constants.CallbackSignalSetSensitivity,
 			Type:    handlers.TypeCallback,
 		},
+		service: service,
 	}
 }
 
@@ -30,26 +37,87 @@ func (h *signalSetSensitivityHandler) Execute(params handlers.HandlerParams) (ha
 		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
 	}
 
-	// TODO: Когда добавится поле чувствительности в модель User, показывать текущее значение
+	// Формат: "signal_set_sensitivity:3"
+	if strings.Contains(params.Data, ":") {
+		parts := strings.Split(params.Data, ":")
+		if len(parts) == 2 && parts[0] == constants.CallbackSignalSetSensitivity {
+			return h.handleSigmaSelection(params, parts[1])
+		}
+	}
+
+	return h.showSensitivityMenu(params)
+}
 
+// showSensitivityMenu показывает меню выбора порога в сигмах
+func (h *signalSetSensitivityHandler) showSensitivityMenu(params handlers.HandlerParams) (handlers.HandlerResult, error) {
 	message := fmt.Sprintf(
-		"🎯 *Настройка чувствительности*\n\n"+
-			"Чувствительность определяет, насколько агрессивно система будет реагировать на изменения цены.\n\n"+
-			"*Уровни чувствительности:*\n"+
-			"• 🔴 Низкая - только сильные сигналы, меньше ложных срабатываний\n"+
-			"• 🟡 Средняя - баланс между качеством и количеством сигналов\n"+
-			"• 🟢 Высокая - больше сигналов, но возможны ложные срабатывания\n\n"+
-			"Выберите уровень чувствительности:",
+		"🎯 *Чувствительность (z-score)*\n\n"+
+			"Бот хранит распределение доходностей свечей по каждой монете и периоду "+
+			"и оценивает новое движение в сигмах (σ) относительно истории самой монеты. "+
+			"Так 3%% на BTC и 3%% на мем-коине оцениваются по-разному.\n\n"+
+			"Текущая чувствительность: *%s*\n\n"+
+			"*Уровни:*\n"+
+			"• 2σ - высокая: больше сигналов\n"+
+			"• 3σ - средняя: заметные для монеты движения\n"+
+			"• 4σ - низкая: только исключительные свечи",
+		anomaly.DescribeSensitivity(params.User.SensitivitySigma),
 	)
 
-	// Клавиатура с вариантами чувствительности
+	presets := anomaly.Presets()
+	row := make([]map[string]string, 0, len(presets))
+	for _, sigma := range presets {
+		row = append(row, map[string]string{
+			"text":          strconv.FormatFloat(sigma, 'f', -1, 64) + "σ",
+			"callback_data": constants.CallbackSignalSetSensitivity + ":" + strconv.FormatFloat(sigma, 'f', -1, 64),
+		})
+	}
+
 	keyboard := map[string]interface{}{
 		"inline_keyboard": [][]map[string]string{
+			row,
+			{
+				{"text": "Выкл", "callback_data": constants.CallbackSignalSetSensitivity + ":0"},
+			},
 			{
-				{"text": "🔴 Низкая", "callback_data": "sensitivity:low"},
-				{"text": "🟡 Средняя", "callback_data": "sensitivity:medium"},
-				{"text": "🟢 Высокая", "callback_data": "sensitivity:high"},
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
 			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":       params.User.ID,
+			"current_sigma": params.User.SensitivitySigma,
+		},
+	}, nil
+}
+
+// handleSigmaSelection обрабатывает выбор порога в сигмах
+func (h *signalSetSensitivityHandler) handleSigmaSelection(params handlers.HandlerParams, sigmaStr string) (handlers.HandlerResult, error) {
+	sigma, err := strconv.ParseFloat(sigmaStr, 64)
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("неверное значение чувствительности: %w", err)
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "set_sensitivity",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  sigma,
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf("✅ *Чувствительность обновлена*\n\n%s", result.Message)
+	if sigma > 0 {
+		message += fmt.Sprintf("\n\nВы будете получать свечи, доходность которых отклоняется от обычной для монеты на %.1fσ и более.", sigma)
+	}
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
 			{
 				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
 			},
@@ -60,8 +128,9 @@ func (h *signalSetSensitivityHandler) Execute(params handlers.HandlerParams) (ha
 		Message:  message,
 		Keyboard: keyboard,
 		Metadata: map[string]interface{}{
-			"user_id":          params.User.ID,
-			"expecting_sensitivity": true,
+			"user_id":       params.User.ID,
+			"new_sigma":     sigma,
+			"updated_field": result.UpdatedField,
 		},
 	}, nil
 }
@@ -0,0 +1,72 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_market_filter/handler.go
+package signal_toggle_market_filter
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// signalToggleMarketFilterHandler реализация обработчика переключения фильтра рыночных движений
+type signalToggleMarketFilterHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает новый обработчик переключения фильтра рыночных движений
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &signalToggleMarketFilterHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_toggle_market_filter_handler",
+			Command: constants.CallbackSignalToggleMarketFilter,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback переключения фильтра рыночных движений
+func (h *signalToggleMarketFilterHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "toggle_market_filter",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  !params.User.SuppressMarketMoves, // Переключаем на противоположное
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf(
+		"🌐 *Движения вслед за рынком*\n\n%s\n\n"+
+			"Бот считает бету и корреляцию монеты к BTC и ETH. Если падение или рост "+
+			"монеты почти полностью повторяет движение BTC с учётом беты, сигнал считается "+
+			"рыночным шумом. Монеты, которые двигаются независимо, приходят как обычно.",
+		result.Message,
+	)
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":               params.User.ID,
+			"suppress_market_moves": result.NewValue,
+			"updated_field":         result.UpdatedField,
+		},
+	}, nil
+}
@@ -0,0 +1,8 @@
+package signal_toggle_market_filter
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// SignalToggleMarketFilterHandler интерфейс обработчика переключения фильтра рыночных движений
+type SignalToggleMarketFilterHandler interface {
+	handlers.Handler
+}
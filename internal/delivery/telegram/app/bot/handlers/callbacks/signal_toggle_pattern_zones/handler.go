@@ -0,0 +1,73 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_pattern_zones/handler.go
+package signal_toggle_pattern_zones
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// signalTogglePatternZonesHandler реализация обработчика подписки на паттерны у зон S/R
+type signalTogglePatternZonesHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает новый обработчик подписки на паттерны у зон S/R
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &signalTogglePatternZonesHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_toggle_pattern_zones_handler",
+			Command: constants.CallbackSignalTogglePatternZones,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback переключения подписки на паттерны у зон S/R
+func (h *signalTogglePatternZonesHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "toggle_pattern_zones",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  !params.User.NotifyPatternZones, // Переключаем на противоположное
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf(
+		"🕯️ *Паттерны у зон S/R*\n\n%s\n\n"+
+			"Бот распознает свечные паттерны на закрытых свечах (поглощение, молот, "+
+			"падающая звезда, утренняя/вечерняя звезда, три солдата/вороны) и присылает "+
+			"разворотный паттерн, если он сформировался у сильной зоны: бычий — у поддержки, "+
+			"медвежий — у сопротивления. Учитывается ваш вотчлист.",
+		result.Message,
+	)
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":              params.User.ID,
+			"notify_pattern_zones": result.NewValue,
+			"updated_field":        result.UpdatedField,
+		},
+	}, nil
+}
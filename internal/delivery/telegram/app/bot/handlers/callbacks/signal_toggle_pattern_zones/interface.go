@@ -0,0 +1,8 @@
+package signal_toggle_pattern_zones
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// SignalTogglePatternZonesHandler интерфейс обработчика подписки на паттерны у зон S/R
+type SignalTogglePatternZonesHandler interface {
+	handlers.Handler
+}
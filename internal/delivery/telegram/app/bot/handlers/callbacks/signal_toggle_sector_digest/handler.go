@@ -0,0 +1,72 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_sector_digest/handler.go
+package signal_toggle_sector_digest
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// signalToggleSectorDigestHandler реализация обработчика подписки на дайджест ротаций секторов
+type signalToggleSectorDigestHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает новый обработчик подписки на дайджест ротаций секторов
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &signalToggleSectorDigestHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_toggle_sector_digest_handler",
+			Command: constants.CallbackSignalToggleSectorDigest,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback переключения подписки на дайджест секторов
+func (h *signalToggleSectorDigestHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "toggle_sector_digest",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  !params.User.NotifySectorDigest, // Переключаем на противоположное
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf(
+		"🧩 *Дайджест секторов*\n\n%s\n\n"+
+			"Бот по расписанию присылает рейтинг секторов (L1, мемы, AI, DeFi) по силе "+
+			"относительно рынка, перестановки мест с прошлого дайджеста, а также лидеров и "+
+			"аутсайдеров против BTC. Текущий рейтинг всегда доступен командой /top.",
+		result.Message,
+	)
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":              params.User.ID,
+			"notify_sector_digest": result.NewValue,
+			"updated_field":        result.UpdatedField,
+		},
+	}, nil
+}
@@ -0,0 +1,8 @@
+package signal_toggle_sector_digest
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// SignalToggleSectorDigestHandler интерфейс обработчика подписки на дайджест ротаций секторов
+type SignalToggleSectorDigestHandler interface {
+	handlers.Handler
+}
@@ -0,0 +1,74 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_vwap/handler.go
+package signal_toggle_vwap
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// signalToggleVWAPHandler реализация обработчика подписки на сигналы VWAP
+type signalToggleVWAPHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает новый обработчик подписки на сигналы VWAP
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &signalToggleVWAPHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_toggle_vwap_handler",
+			Command: constants.CallbackSignalToggleVWAP,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback переключения подписки на сигналы VWAP
+func (h *signalToggleVWAPHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "toggle_vwap",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  !params.User.NotifyVWAP, // Переключаем на противоположное
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf(
+		"📏 *Сигналы VWAP*\n\n%s\n\n"+
+			"VWAP дневной сессии считается от 00:00 UTC по приросту оборота. Бот сообщает, "+
+			"когда цена выходит за полосу ±2σ, возвращается выше VWAP (reclaim) или "+
+			"уходит под него (loss). Учитывается ваш вотчлист.\n\n"+
+			"Текущий VWAP монеты и VWAP от своего якоря: `/vwap BTCUSDT`, "+
+			"якорь задается командой `/vwap anchor 14:30`.",
+		result.Message,
+	)
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":       params.User.ID,
+			"notify_vwap":   result.NewValue,
+			"updated_field": result.UpdatedField,
+		},
+	}, nil
+}
@@ -0,0 +1,8 @@
+package signal_toggle_vwap
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// SignalToggleVWAPHandler интерфейс обработчика подписки на сигналы VWAP
+type SignalToggleVWAPHandler interface {
+	handlers.Handler
+}
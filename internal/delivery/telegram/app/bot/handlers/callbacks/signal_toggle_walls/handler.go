@@ -0,0 +1,72 @@
+// internal/delivery/telegram/app/bot/handlers/callbacks/signal_toggle_walls/handler.go
+package signal_toggle_walls
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// signalToggleWallsHandler реализация обработчика подписки на снятие стен
+type signalToggleWallsHandler struct {
+	*base.BaseHandler
+	service signal_settings_svc.Service
+}
+
+// NewHandler создает новый обработчик подписки на снятие стен
+func NewHandler(service signal_settings_svc.Service) handlers.Handler {
+	return &signalToggleWallsHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "signal_toggle_walls_handler",
+			Command: constants.CallbackSignalToggleWalls,
+			Type:    handlers.TypeCallback,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет обработку callback переключения подписки на снятие стен
+func (h *signalToggleWallsHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	result, err := h.service.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "toggle_wall_pulls",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  !params.User.NotifyWallPulls, // Переключаем на противоположное
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf(
+		"🧱 *Снятие стен*\n\n%s\n\n"+
+			"Бот следит за крупными заявками в стакане самых ликвидных монет и сообщает, "+
+			"когда стену снимают, не дав цене до нее дойти: снятая поддержка — давление вниз, "+
+			"снятое сопротивление — вверх. Учитывается ваш вотчлист.",
+		result.Message,
+	)
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{
+				{"text": constants.ButtonTexts.Back, "callback_data": constants.CallbackSignalsMenu},
+			},
+		},
+	}
+
+	return handlers.HandlerResult{
+		Message:  message,
+		Keyboard: keyboard,
+		Metadata: map[string]interface{}{
+			"user_id":           params.User.ID,
+			"notify_wall_pulls": result.NewValue,
+			"updated_field":     result.UpdatedField,
+		},
+	}, nil
+}
@@ -0,0 +1,8 @@
+package signal_toggle_walls
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// SignalToggleWallsHandler интерфейс обработчика подписки на снятие стен
+type SignalToggleWallsHandler interface {
+	handlers.Handler
+}
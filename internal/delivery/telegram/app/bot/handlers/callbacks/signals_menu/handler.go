@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/anomaly"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
@@ -76,9 +77,9 @@ func (h *signalsMenuHandler) createSignalsMessage(user *models.User) string {
 		signalsStatus,
 		user.MinGrowthThreshold,
 		user.MinFallThreshold,
-		h.getSensitivityText(0.5), // TODO: Добавить поле sensitivity в модель User
-		0,                         // TODO: Получить реальное количество сигналов
-		"недавно",                 // TODO: Получить время последнего сигнала
+		anomaly.DescribeSensitivity(user.SensitivitySigma),
+		0,         // TODO: Получить реальное количество сигналов
+		"недавно", // TODO: Получить время последнего сигнала
 	)
 }
 
@@ -101,6 +102,41 @@ func (h *signalsMenuHandler) createSignalsKeyboard(user *models.User) interface{
 			{"text": fmt.Sprintf(constants.SignalButtonTexts.ThresholdFormat, constants.DirectionIcons.Down, user.MinFallThreshold),
 				"callback_data": constants.CallbackSignalSetFallThreshold},
 		},
+		// Порог z-score в сигмах
+		{
+			{"text": constants.SignalButtonTexts.Sensitivity + ": " + anomaly.DescribeSensitivity(user.SensitivitySigma),
+				"callback_data": constants.CallbackSignalSetSensitivity},
+		},
+		// Фильтр по старшим таймфреймам
+		{
+			{"text": h.getConfluenceButtonText(user.MinConfluenceScore), "callback_data": constants.CallbackSignalSetConfluence},
+		},
+		{
+			{"text": h.BaseHandler.GetToggleText(constants.SignalButtonTexts.MarketFilter, user.SuppressMarketMoves),
+				"callback_data": constants.CallbackSignalToggleMarketFilter},
+		},
+		{
+			{"text": h.BaseHandler.GetToggleText(constants.SignalButtonTexts.PatternZones, user.NotifyPatternZones),
+				"callback_data": constants.CallbackSignalTogglePatternZones},
+		},
+		{
+			{"text": h.BaseHandler.GetToggleText(constants.SignalButtonTexts.SectorDigest, user.NotifySectorDigest),
+				"callback_data": constants.CallbackSignalToggleSectorDigest},
+		},
+		{
+			{"text": h.getRangeBreakoutsButtonText(user), "callback_data": constants.CallbackRangeHorizonsMenu},
+		},
+		{
+			{"text": h.BaseHandler.GetToggleText(constants.SignalButtonTexts.VWAP, user.NotifyVWAP),
+				"callback_data": constants.CallbackSignalToggleVWAP},
+		},
+		{
+			{"text": h.BaseHandler.GetToggleText(constants.SignalButtonTexts.WallPulls, user.NotifyWallPulls),
+				"callback_data": constants.CallbackSignalToggleWalls},
+		},
+		{
+			{"text": constants.SignalButtonTexts.History, "callback_data": constants.CallbackSignalHistory},
+		},
 
 		// Навигация
 		{
@@ -114,13 +150,18 @@ func (h *signalsMenuHandler) createSignalsKeyboard(user *models.User) interface{
 	}
 }
 
-// getSensitivityText возвращает текстовое описание чувствительности
-func (h *signalsMenuHandler) getSensitivityText(sensitivity float64) string {
-	if sensitivity <= 0.3 {
-		return "Низкая"
-	} else if sensitivity <= 0.7 {
-		return "Средняя"
-	} else {
-		return "Высокая"
+// getConfluenceButtonText возвращает текст кнопки фильтра согласованности
+func (h *signalsMenuHandler) getConfluenceButtonText(minScore float64) string {
+	if minScore <= 0 {
+		return constants.SignalButtonTexts.Confluence + ": выкл"
+	}
+	return fmt.Sprintf("%s: от %.0f", constants.SignalButtonTexts.Confluence, minScore)
+}
+
+// getRangeBreakoutsButtonText возвращает текст кнопки горизонтов пробоев
+func (h *signalsMenuHandler) getRangeBreakoutsButtonText(user *models.User) string {
+	if len(user.RangeBreakoutHorizons) == 0 {
+		return constants.SignalButtonTexts.RangeBreakouts + ": выкл"
 	}
+	return constants.SignalButtonTexts.RangeBreakouts + ": " + strings.Join(user.RangeBreakoutHorizons, ", ")
 }
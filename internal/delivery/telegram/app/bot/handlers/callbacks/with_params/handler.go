@@ -143,7 +143,7 @@ func (h *withParamsHandler) handleThreshold(params handlers.HandlerParams, data
 	}, nil
 }
 
-// handleSensitivity обрабатывает установку чувствительности
+// handleSensitivity обрабатывает уровни чувствительности из старого меню ("sensitivity:medium")
 func (h *withParamsHandler) handleSensitivity(params handlers.HandlerParams, data string) (handlers.HandlerResult, error) {
 	parts := strings.Split(data, ":")
 	if len(parts) != 2 {
@@ -152,13 +152,17 @@ func (h *withParamsHandler) handleSensitivity(params handlers.HandlerParams, dat
 
 	sensitivityLevel := parts[1]
 
-	// TODO: Реализовать после добавления поддержки чувствительности
-	message := fmt.Sprintf(
-		"🎯 *Чувствительность*\n\n"+
-			"Уровень чувствительности: %s\n\n"+
-			"Эта функция появится в следующем обновлении.",
-		sensitivityLevel,
-	)
+	result, err := h.signalService.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "set_sensitivity",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  sensitivityLevel,
+	})
+	if err != nil {
+		return handlers.HandlerResult{}, fmt.Errorf("ошибка в сервисе настройки сигналов: %w", err)
+	}
+
+	message := fmt.Sprintf("🎯 *Чувствительность обновлена*\n\n%s", result.Message)
 
 	// Создаем клавиатуру
 	keyboard := map[string]interface{}{
@@ -175,6 +179,7 @@ func (h *withParamsHandler) handleSensitivity(params handlers.HandlerParams, dat
 		Metadata: map[string]interface{}{
 			"user_id":           params.User.ID,
 			"sensitivity_level": sensitivityLevel,
+			"updated_field":     result.UpdatedField,
 		},
 	}, nil
 }
@@ -0,0 +1,99 @@
+// internal/delivery/telegram/app/bot/handlers/commands/alert/handler.go
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+)
+
+// UsageText — подсказка по формату условия алерта (Markdown)
+const UsageText = "Формат: `МОНЕТА УСЛОВИЕ [повтор]`\n\n" +
+	"`SOL 180` — пересечение уровня\n" +
+	"`SOL >180` / `SOL <180` — пересечение вверх / вниз\n" +
+	"`SOL 170-180` — вход цены в диапазон\n" +
+	"`SOL 5%` — отклонение на 5% от текущей цены\n\n" +
+	"Слово `повтор` в конце оставляет алерт активным после срабатывания."
+
+// alertCommandHandler — обработчик команды /alert <условие>
+type alertCommandHandler struct {
+	*base.BaseHandler
+	alertService *alertsDomain.Service
+}
+
+// NewHandler создает обработчик команды /alert
+func NewHandler(alertService *alertsDomain.Service) handlers.Handler {
+	return &alertCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "alert_command_handler",
+			Command: "alert",
+			Type:    handlers.TypeCommand,
+		},
+		alertService: alertService,
+	}
+}
+
+// Execute создает алерт из аргумента команды
+func (h *alertCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	if h.alertService == nil {
+		return handlers.HandlerResult{Message: "❌ Сервис алертов недоступен"}, nil
+	}
+	if params.Data == "" {
+		return handlers.HandlerResult{
+			Message:  "🔔 *Новый ценовой алерт*\n\n" + UsageText + "\n\nПример: `/alert SOL 180`",
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+	return ExecuteCreate(h.alertService, params.User.ID, params.Data), nil
+}
+
+// ExecuteCreate разбирает условие и создает алерт.
+// Используется командой /alert и FSM-состоянием ввода алерта в bot.go.
+func ExecuteCreate(alertService *alertsDomain.Service, userID int, text string) handlers.HandlerResult {
+	spec, err := alertsDomain.ParseSpec(text)
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:  fmt.Sprintf("❌ %s\n\n%s", err.Error(), UsageText),
+			Keyboard: menuKeyboard(),
+		}
+	}
+
+	result, err := alertService.CreateAlert(context.Background(), userID, spec)
+	switch {
+	case errors.Is(err, alertsDomain.ErrAlertLimit):
+		return handlers.HandlerResult{Message: fmt.Sprintf("⚠️ %s\n\nУвеличить лимит: /buy", err.Error()), Keyboard: menuKeyboard()}
+	case errors.Is(err, alertsDomain.ErrNoPrice):
+		return handlers.HandlerResult{Message: fmt.Sprintf("❌ %s. Проверьте тикер монеты.", err.Error()), Keyboard: menuKeyboard()}
+	case err != nil:
+		return handlers.HandlerResult{Message: "❌ Не удалось создать алерт", Keyboard: menuKeyboard()}
+	}
+
+	alert := result.Alert
+	msg := fmt.Sprintf("✅ Алерт *#%d* создан\n🪙 *%s* — %s\n💰 Текущая цена: %s",
+		alert.ID, alert.Symbol, alertsDomain.Describe(alert), alertsDomain.FormatPrice(result.CurrentPrice))
+	if result.Waiting {
+		msg += "\n\n⏳ Цена уже в зоне условия — алерт сработает, когда она выйдет из неё и вернётся."
+	}
+	return handlers.HandlerResult{
+		Message:  msg,
+		Keyboard: menuKeyboard(),
+		Metadata: map[string]interface{}{"user_id": userID, "alert_id": alert.ID},
+	}
+}
+
+func menuKeyboard() interface{} {
+	return map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{{"text": "🔔 Мои алерты", "callback_data": constants.CallbackAlertsMenu}},
+			{{"text": "🔙 Главное меню", "callback_data": constants.CallbackMenuMain}},
+		},
+	}
+}
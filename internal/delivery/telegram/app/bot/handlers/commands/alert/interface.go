@@ -0,0 +1,9 @@
+// internal/delivery/telegram/app/bot/handlers/commands/alert/interface.go
+package alert
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// AlertCommandHandler интерфейс обработчика команды /alert
+type AlertCommandHandler interface {
+	handlers.Handler
+}
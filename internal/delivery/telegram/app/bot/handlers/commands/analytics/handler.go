@@ -0,0 +1,56 @@
+// internal/delivery/telegram/app/bot/handlers/commands/analytics/handler.go
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// analyticsCommandHandler — админ-команда /analytics
+//
+//	/analytics              — исходы сигналов за 7 дней на горизонте 1h
+//	/analytics 30d 4h       — окно и горизонт
+//	/analytics 7d min=30    — минимум оцененных сигналов для рейтингов
+type analyticsCommandHandler struct {
+	*base.BaseHandler
+	journal *journal.Service
+}
+
+// NewHandler создает обработчик команды /analytics
+func NewHandler(journalSvc *journal.Service) handlers.Handler {
+	return &analyticsCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "analytics_command_handler",
+			Command: "analytics",
+			Type:    handlers.TypeCommand,
+		},
+		journal: journalSvc,
+	}
+}
+
+// Execute строит отчет по исходам сигналов журнала
+func (h *analyticsCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	if !params.User.IsAdmin() {
+		return handlers.HandlerResult{Message: "⛔ Команда доступна только администраторам"}, nil
+	}
+
+	query, err := journal.ParseAnalyticsArgs(params.Data)
+	if err != nil {
+		return handlers.HandlerResult{Message: fmt.Sprintf("❓ %v\n\nПример: `/analytics 30d 4h min=20`", err)}, nil
+	}
+
+	report, err := h.journal.Analytics(query, time.Now())
+	if err != nil {
+		logger.Warn("⚠️ /analytics: не удалось построить отчет: %v", err)
+		return handlers.HandlerResult{Message: "❌ Не удалось построить отчет, попробуйте позже"}, nil
+	}
+	return handlers.HandlerResult{Message: journal.FormatAnalytics(report)}, nil
+}
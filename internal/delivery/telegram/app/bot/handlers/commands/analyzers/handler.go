@@ -0,0 +1,127 @@
+// internal/delivery/telegram/app/bot/handlers/commands/analyzers/handler.go
+package analyzers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+)
+
+// analyzersCommandHandler — админ-команда /analyzers
+//
+//	/analyzers        — анализаторы реестра, состояние и действующие настройки
+//	/analyzers <имя>  — схема настроек анализатора (тип, диапазон, по умолчанию)
+type analyzersCommandHandler struct {
+	*base.BaseHandler
+	infos func() []common.AnalyzerInfo
+}
+
+// NewHandler создает обработчик команды /analyzers.
+// infos вызывается на каждый запрос: движок анализа создается позже бота.
+func NewHandler(infos func() []common.AnalyzerInfo) handlers.Handler {
+	return &analyzersCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "analyzers_command_handler",
+			Command: "analyzers",
+			Type:    handlers.TypeCommand,
+		},
+		infos: infos,
+	}
+}
+
+// Execute выводит список анализаторов или схему одного из них
+func (h *analyzersCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	if !params.User.IsAdmin() {
+		return handlers.HandlerResult{Message: "⛔ Команда доступна только администраторам"}, nil
+	}
+
+	infos := h.infos()
+	if len(infos) == 0 {
+		return handlers.HandlerResult{Message: "⏳ Движок анализа еще не запущен"}, nil
+	}
+
+	name := strings.ToLower(strings.TrimSpace(params.Data))
+	if name == "" {
+		return handlers.HandlerResult{Message: listMessage(infos)}, nil
+	}
+	for _, info := range infos {
+		if info.Name == name {
+			return handlers.HandlerResult{Message: detailsMessage(info)}, nil
+		}
+	}
+	return handlers.HandlerResult{Message: fmt.Sprintf("❓ Анализатор `%s` не найден. Список: /analyzers", name)}, nil
+}
+
+// listMessage формирует список анализаторов с действующими настройками
+func listMessage(infos []common.AnalyzerInfo) string {
+	var sb strings.Builder
+	sb.WriteString("🧩 *Анализаторы*\n\n")
+	for _, info := range infos {
+		sb.WriteString(fmt.Sprintf("%s *%s* — %s\n", stateIcon(info.State), info.Name, info.Description))
+		sb.WriteString(fmt.Sprintf("Состояние: `%s`", info.State))
+		if info.Reason != "" {
+			sb.WriteString(fmt.Sprintf(" (`%s`)", info.Reason))
+		}
+		sb.WriteString(fmt.Sprintf("\nВес: %.2f, мин. уверенность: %.0f%%\n", info.Weight, info.MinConfidence))
+		if len(info.Requires) > 0 {
+			sb.WriteString(fmt.Sprintf("Зависимости: `%s`\n", strings.Join(info.Requires, ", ")))
+		}
+
+		keys := make([]string, 0, len(info.Settings))
+		for key := range info.Settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, info.Settings[key]))
+		}
+		if len(pairs) > 0 {
+			sb.WriteString("`" + strings.Join(pairs, " ") + "`\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Схема настроек: `/analyzers <имя>`")
+	return sb.String()
+}
+
+// detailsMessage формирует схему настроек анализатора с текущими значениями
+func detailsMessage(info common.AnalyzerInfo) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s *%s* — %s\n", stateIcon(info.State), info.Name, info.Description))
+	sb.WriteString(fmt.Sprintf("Состояние: `%s`\n", info.State))
+	if info.Reason != "" {
+		sb.WriteString(fmt.Sprintf("Причина: `%s`\n", info.Reason))
+	}
+	if len(info.After) > 0 {
+		sb.WriteString(fmt.Sprintf("Создается после: `%s`\n", strings.Join(info.After, ", ")))
+	}
+	sb.WriteString("\n")
+
+	for _, spec := range info.Schema {
+		sb.WriteString(fmt.Sprintf("`%s` = `%v`\n", spec.Key, info.Settings[spec.Key]))
+		sb.WriteString("  " + spec.Describe() + "\n")
+	}
+	return sb.String()
+}
+
+// stateIcon возвращает значок состояния анализатора
+func stateIcon(state common.AnalyzerState) string {
+	switch state {
+	case common.AnalyzerActive:
+		return "🟢"
+	case common.AnalyzerDisabled:
+		return "⚪"
+	case common.AnalyzerSkipped:
+		return "🟡"
+	default:
+		return "🔴"
+	}
+}
@@ -0,0 +1,9 @@
+// internal/delivery/telegram/app/bot/handlers/commands/analyzers/interface.go
+package analyzers
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// AnalyzersCommandHandler интерфейс обработчика команды /analyzers
+type AnalyzersCommandHandler interface {
+	handlers.Handler
+}
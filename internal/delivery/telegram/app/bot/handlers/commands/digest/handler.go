@@ -0,0 +1,42 @@
+// internal/delivery/telegram/app/bot/handlers/commands/digest/handler.go
+package digest
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+)
+
+// digestCommandHandler — команда /digest: суточный и недельный дайджест рынка
+//
+//	/digest                   — текущие настройки
+//	/digest on 08:30          — суточный дайджест в 08:30 по часовому поясу пользователя
+//	/digest weekly пн 10:00   — недельный дайджест
+//	/digest off               — отключить
+//	/digest show [weekly]     — последний дайджест сейчас
+type digestCommandHandler struct {
+	*base.BaseHandler
+	service *digest.Service
+}
+
+// NewHandler создает обработчик команды /digest
+func NewHandler(service *digest.Service) handlers.Handler {
+	return &digestCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "digest_command_handler",
+			Command: "digest",
+			Type:    handlers.TypeCommand,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет подкоманду /digest
+func (h *digestCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	return handlers.HandlerResult{Message: h.service.Command(params.User.ID, params.User.Timezone, params.Data)}, nil
+}
@@ -0,0 +1,140 @@
+// internal/delivery/telegram/app/bot/handlers/commands/export/handler.go
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/export"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// exportTimeout предельное время одной выгрузки
+const exportTimeout = 30 * time.Minute
+
+// DocumentSender отправка документа, записываемого потоком (TelegramClient)
+type DocumentSender interface {
+	SendDocument(chatID int64, fileName string, write func(w io.Writer) (string, error)) error
+}
+
+// TextSender отправка текстового сообщения (MessageSender)
+type TextSender interface {
+	SendTextMessage(chatID int64, text string, keyboard interface{}) error
+}
+
+// exportCommandHandler — команда /export: выгрузка данных в CSV или JSON Lines
+//
+//	/export signals 30d          — сигналы с исходами
+//	/export candles BTC 1h 7d    — свечи
+//	/export activity 30d jsonl   — своя активность
+//	/export signals 30d dir      — в каталог выгрузок (администраторы)
+type exportCommandHandler struct {
+	*base.BaseHandler
+	service   *export.Service
+	documents DocumentSender
+	texts     TextSender
+}
+
+// NewHandler создает обработчик команды /export
+func NewHandler(service *export.Service, documents DocumentSender, texts TextSender) handlers.Handler {
+	return &exportCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "export_command_handler",
+			Command: "export",
+			Type:    handlers.TypeCommand,
+		},
+		service:   service,
+		documents: documents,
+		texts:     texts,
+	}
+}
+
+// Execute проверяет запрос и лимит тарифа и запускает выгрузку в фоне
+func (h *exportCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	if params.Data == "" {
+		return handlers.HandlerResult{Message: export.Help}, nil
+	}
+
+	req, err := export.ParseArgs(params.Data, time.Now())
+	if err != nil {
+		return handlers.HandlerResult{Message: fmt.Sprintf("❓ %v\n\n%s", err, export.Help)}, nil
+	}
+	req.UserID = params.User.ID
+	isAdmin := params.User.IsAdmin()
+	if req.ToDirectory && !isAdmin {
+		return handlers.HandlerResult{Message: "⛔ Выгрузка в каталог доступна только администраторам"}, nil
+	}
+
+	if err := h.service.Validate(req); err != nil {
+		return handlers.HandlerResult{Message: "❌ " + err.Error()}, nil
+	}
+
+	job, err := h.service.Begin(context.Background(), req, isAdmin)
+	if errors.Is(err, export.ErrExportLimit) {
+		return handlers.HandlerResult{Message: "⛔ " + err.Error()}, nil
+	}
+	if err != nil {
+		logger.Warn("⚠️ /export: user=%d: %v", req.UserID, err)
+		return handlers.HandlerResult{Message: "❌ Не удалось начать выгрузку, попробуйте позже"}, nil
+	}
+
+	go h.run(job.ID, req, params.ChatID, func(ctx context.Context) (export.Result, string, error) {
+		if req.ToDirectory {
+			result, err := h.service.ExportToDirectory(ctx, job, req)
+			return result, job.FilePath, err
+		}
+		var result export.Result
+		err := h.documents.SendDocument(params.ChatID, req.FileName(), func(w io.Writer) (string, error) {
+			var err error
+			result, err = h.service.Export(ctx, req, w)
+			return caption(req, result), err
+		})
+		if finishErr := h.service.Finish(job, result, err); finishErr != nil {
+			logger.Warn("⚠️ /export: не удалось сохранить итог выгрузки %d: %v", job.ID, finishErr)
+		}
+		return result, "", err
+	})
+
+	return handlers.HandlerResult{Message: "⏳ Готовлю выгрузку: " + req.Describe()}, nil
+}
+
+// run выполняет выгрузку и сообщает пользователю об ошибке, обрезке или пути к файлу
+func (h *exportCommandHandler) run(jobID int64, req export.Request, chatID int64, do func(ctx context.Context) (export.Result, string, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	result, path, err := do(ctx)
+	var text string
+	switch {
+	case err != nil:
+		logger.Warn("⚠️ /export: выгрузка %d не удалась: %v", jobID, err)
+		text = "❌ Не удалось выполнить выгрузку, попробуйте позже или сократите период"
+	case path != "":
+		text = fmt.Sprintf("✅ Выгрузка сохранена: %s\n%s", path, caption(req, result))
+	case result.Truncated:
+		text = fmt.Sprintf("⚠️ Выгрузка обрезана до %d строк (%.1f МБ) — сократите период",
+			result.Rows, float64(result.Bytes)/(1<<20))
+	default:
+		return
+	}
+	if err := h.texts.SendTextMessage(chatID, text, nil); err != nil {
+		logger.Warn("⚠️ /export: не удалось отправить сообщение: %v", err)
+	}
+}
+
+// caption подпись к выгрузке
+func caption(req export.Request, result export.Result) string {
+	text := fmt.Sprintf("📦 %s\nСтрок: %d, %.1f КБ", req.Describe(), result.Rows, float64(result.Bytes)/1024)
+	if result.Truncated {
+		text += " (обрезано)"
+	}
+	return text
+}
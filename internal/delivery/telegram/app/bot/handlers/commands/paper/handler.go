@@ -0,0 +1,42 @@
+// internal/delivery/telegram/app/bot/handlers/commands/paper/handler.go
+package paper
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+)
+
+// paperCommandHandler — команда /paper: виртуальный портфель по полученным сигналам
+//
+//	/paper                    — отчёт с кривой капитала
+//	/paper on | off           — открытие позиций по сигналам
+//	/paper rules              — текущие правила
+//	/paper set size=5% tp=2atr sl=1atr — изменить правила
+//	/paper reset              — начать заново
+type paperCommandHandler struct {
+	*base.BaseHandler
+	service *paper.Service
+}
+
+// NewHandler создает обработчик команды /paper
+func NewHandler(service *paper.Service) handlers.Handler {
+	return &paperCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "paper_command_handler",
+			Command: "paper",
+			Type:    handlers.TypeCommand,
+		},
+		service: service,
+	}
+}
+
+// Execute выполняет подкоманду /paper
+func (h *paperCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	return handlers.HandlerResult{Message: h.service.Command(params.User.ID, params.Data)}, nil
+}
@@ -0,0 +1,118 @@
+// internal/delivery/telegram/app/bot/handlers/commands/regime/handler.go
+// Режим рынка и пороги пользователя по режимам.
+//
+//	/regime                      — текущий режим, метрики и ваши множители
+//	/regime panic=2 range=1.5    — повысить пороги в панике и боковике
+//	/regime off                  — пороги не зависят от режима
+package regime
+
+import (
+	"fmt"
+	"strings"
+
+	rg "crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// usageText — подсказка по команде (Markdown)
+const usageText = "`/regime panic=2 range=1.5` — порог ×2 в панике и ×1.5 в боковике\n" +
+	"`/regime off` — пороги не зависят от режима\n\n" +
+	"Режимы: `trend_up`, `trend_down`, `range`, `panic`. Множитель от 1 до 10 " +
+	"умножает ваши пороги роста и падения, пока рынок в этом режиме."
+
+type regimeHandler struct {
+	*base.BaseHandler
+	classifier      func() *rg.Classifier
+	settingsService signal_settings_svc.Service
+}
+
+// NewHandler создает обработчик команды /regime.
+// classifier вызывается лениво: классификатор стартует в CoreLayer позже бота.
+func NewHandler(classifier func() *rg.Classifier, settingsService signal_settings_svc.Service) handlers.Handler {
+	return &regimeHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "regime_command_handler",
+			Command: "regime",
+			Type:    handlers.TypeCommand,
+		},
+		classifier:      classifier,
+		settingsService: settingsService,
+	}
+}
+
+// Execute показывает режим рынка или меняет множители порогов пользователя
+func (h *regimeHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	args := strings.TrimSpace(params.Data)
+	if args == "" {
+		return handlers.HandlerResult{
+			Message:  h.status(params.User.RegimeThresholds),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	value := rg.NormalizeFactorsArg(args)
+	switch strings.ToLower(args) {
+	case "off", "reset", "выкл":
+		value = ""
+	}
+
+	result, err := h.settingsService.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "set_regime_thresholds",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  value,
+	})
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:  fmt.Sprintf("❌ %s\n\n%s", escapeMarkdown(err.Error()), usageText),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	return handlers.HandlerResult{
+		Message:  fmt.Sprintf("🧭 %s\n\nТекущий режим: `/regime`", result.Message),
+		Keyboard: menuKeyboard(),
+		Metadata: map[string]interface{}{
+			"user_id":       params.User.ID,
+			"updated_field": result.UpdatedField,
+		},
+	}, nil
+}
+
+// status — режим рынка, множители пользователя и подсказка
+func (h *regimeHandler) status(userFactors string) string {
+	var sb strings.Builder
+	if classifier := h.classifier(); classifier != nil {
+		sb.WriteString(rg.FormatStatus(classifier.Snapshot()))
+	} else {
+		sb.WriteString("🧭 Режим рынка: классификатор не запущен")
+	}
+
+	if factors := rg.FormatFactors(userFactors); factors != "" {
+		sb.WriteString("\n\n*Ваши пороги по режимам*\n" + factors)
+	} else {
+		sb.WriteString("\n\n*Ваши пороги* не зависят от режима")
+	}
+	sb.WriteString("\n\n" + usageText)
+	return sb.String()
+}
+
+// escapeMarkdown убирает символы разметки Markdown из текста ошибки
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("*", "", "_", " ", "`", "", "[", "(", "]", ")").Replace(s)
+}
+
+func menuKeyboard() interface{} {
+	return map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{{"text": "🔙 Главное меню", "callback_data": constants.CallbackMenuMain}},
+		},
+	}
+}
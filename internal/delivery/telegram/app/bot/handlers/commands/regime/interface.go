@@ -0,0 +1,9 @@
+// internal/delivery/telegram/app/bot/handlers/commands/regime/interface.go
+package regime
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// RegimeCommandHandler интерфейс обработчика команды /regime
+type RegimeCommandHandler interface {
+	handlers.Handler
+}
@@ -0,0 +1,193 @@
+// internal/delivery/telegram/app/bot/handlers/commands/rules/handler.go
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	rulesDomain "crypto-exchange-screener-bot/internal/core/domain/rules"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+)
+
+// rulesCommandHandler — обработчик команды /rules
+//
+//	/rules                      — список правил
+//	/rules add [имя:] выражение — добавить правило
+//	/rules del|on|off <id>      — удалить, включить, выключить
+//	/rules help                 — справка по метрикам
+type rulesCommandHandler struct {
+	*base.BaseHandler
+	rulesService *rulesDomain.Service
+}
+
+// NewHandler создает обработчик команды /rules
+func NewHandler(rulesService *rulesDomain.Service) handlers.Handler {
+	return &rulesCommandHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "rules_command_handler",
+			Command: "rules",
+			Type:    handlers.TypeCommand,
+		},
+		rulesService: rulesService,
+	}
+}
+
+// Execute разбирает подкоманду и выполняет её
+func (h *rulesCommandHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	if h.rulesService == nil {
+		return handlers.HandlerResult{Message: "❌ Сервис правил недоступен"}, nil
+	}
+
+	sub, arg := splitSubcommand(params.Data)
+	switch sub {
+	case "", "list":
+		return h.list(params.User)
+	case "help":
+		return handlers.HandlerResult{Message: helpMessage()}, nil
+	case "add":
+		return h.add(params.User, arg)
+	case "del", "delete", "rm":
+		return h.changeRule(params.User, arg, func(userID, ruleID int) error {
+			return h.rulesService.DeleteRule(userID, ruleID)
+		}, "🗑 Правило #%d удалено")
+	case "on":
+		return h.changeRule(params.User, arg, func(userID, ruleID int) error {
+			return h.rulesService.SetRuleActive(context.Background(), userID, ruleID, true)
+		}, "✅ Правило #%d включено")
+	case "off":
+		return h.changeRule(params.User, arg, func(userID, ruleID int) error {
+			return h.rulesService.SetRuleActive(context.Background(), userID, ruleID, false)
+		}, "⏸ Правило #%d выключено")
+	default:
+		return handlers.HandlerResult{Message: "❓ Неизвестная подкоманда. Справка: /rules help"}, nil
+	}
+}
+
+// list выводит правила пользователя
+func (h *rulesCommandHandler) list(user *models.User) (handlers.HandlerResult, error) {
+	list, err := h.rulesService.ListRules(user.ID)
+	if err != nil {
+		return handlers.HandlerResult{Message: "❌ Не удалось загрузить правила"}, fmt.Errorf("rules: ListRules: %w", err)
+	}
+
+	if len(list) == 0 {
+		return handlers.HandlerResult{Message: "📐 *Мои правила*\n\n" +
+			"У вас пока нет правил.\n\n" +
+			"Пример:\n`/rules add change(15m) > 3 AND rsi(1h) < 70`\n\n" +
+			"Справка по метрикам: /rules help"}, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📐 *Мои правила*\n\n")
+	for _, rule := range list {
+		status := "✅"
+		if !rule.IsActive {
+			status = "⏸"
+		}
+		sb.WriteString(fmt.Sprintf("%s *#%d*", status, rule.ID))
+		if rule.Name != "" {
+			sb.WriteString(" " + escapeMarkdown(rule.Name))
+		}
+		sb.WriteString(fmt.Sprintf(" (срабатываний: %d)\n`%s`\n\n", rule.TriggerCount, rule.Expression))
+	}
+	sb.WriteString("Управление: `/rules on|off|del <id>`")
+
+	return handlers.HandlerResult{
+		Message:  sb.String(),
+		Metadata: map[string]interface{}{"user_id": user.ID, "rules": len(list)},
+	}, nil
+}
+
+// add создает правило: "/rules add [имя:] выражение"
+func (h *rulesCommandHandler) add(user *models.User, arg string) (handlers.HandlerResult, error) {
+	if arg == "" {
+		return handlers.HandlerResult{Message: "Укажите выражение:\n`/rules add change(15m) > 3 AND oi_change(1h) > 5`"}, nil
+	}
+
+	// Двоеточие не входит в язык выражений, поэтому однозначно отделяет имя
+	name, expression := "", arg
+	if idx := strings.Index(arg, ":"); idx >= 0 {
+		name, expression = strings.TrimSpace(arg[:idx]), strings.TrimSpace(arg[idx+1:])
+	}
+
+	rule, err := h.rulesService.AddRule(context.Background(), user.ID, name, expression)
+	if err != nil {
+		if errors.Is(err, rulesDomain.ErrRuleLimit) {
+			return handlers.HandlerResult{Message: fmt.Sprintf("⚠️ %s\n\nУвеличить лимит: /buy", err.Error())}, nil
+		}
+		return handlers.HandlerResult{Message: fmt.Sprintf("❌ Ошибка в выражении: %s\n\nСправка: /rules help", escapeMarkdown(err.Error()))}, nil
+	}
+
+	return handlers.HandlerResult{
+		Message: fmt.Sprintf("✅ Правило *#%d* создано\n`%s`\n\nПроверяется на закрытии свечей, пауза между уведомлениями — %d мин.",
+			rule.ID, rule.Expression, rule.CooldownMinutes),
+		Metadata: map[string]interface{}{"user_id": user.ID, "rule_id": rule.ID},
+	}, nil
+}
+
+// changeRule применяет действие к правилу по id
+func (h *rulesCommandHandler) changeRule(user *models.User, arg string, action func(userID, ruleID int) error, okFormat string) (handlers.HandlerResult, error) {
+	ruleID, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(arg), "#"))
+	if err != nil || ruleID <= 0 {
+		return handlers.HandlerResult{Message: "Укажите номер правила, например: `/rules off 12`"}, nil
+	}
+
+	if err := action(user.ID, ruleID); err != nil {
+		if errors.Is(err, rulesDomain.ErrRuleNotFound) {
+			return handlers.HandlerResult{Message: fmt.Sprintf("❌ Правило #%d не найдено", ruleID)}, nil
+		}
+		if errors.Is(err, rulesDomain.ErrRuleLimit) {
+			return handlers.HandlerResult{Message: fmt.Sprintf("⚠️ %s\n\nВыключите другое правило или увеличьте лимит: /buy", err.Error())}, nil
+		}
+		return handlers.HandlerResult{Message: "❌ Не удалось изменить правило"}, fmt.Errorf("rules: правило #%d: %w", ruleID, err)
+	}
+
+	return handlers.HandlerResult{Message: fmt.Sprintf(okFormat, ruleID)}, nil
+}
+
+// helpMessage — справка по языку правил
+func helpMessage() string {
+	var sb strings.Builder
+	sb.WriteString("📐 *Правила сигналов*\n\n")
+	sb.WriteString("Правило — условие над метриками монеты. Оно проверяется по каждой монете ")
+	sb.WriteString("на закрытии свечи наименьшего периода в выражении.\n\n")
+	sb.WriteString("*Операторы:* `AND OR NOT > >= < <= == != + - * /` и скобки\n")
+	sb.WriteString("*Периоды:* " + strings.Join(periodPkg.GetStandardPeriods(), " ") + "\n\n")
+	sb.WriteString("*Метрики:*\n")
+	for _, line := range rulesDomain.MetricHelp() {
+		sb.WriteString("• `" + strings.Replace(line, " — ", "` — ", 1) + "\n")
+	}
+	sb.WriteString("\n*Команды:*\n")
+	sb.WriteString("`/rules add [имя:] выражение` — добавить\n")
+	sb.WriteString("`/rules on|off|del <id>` — включить, выключить, удалить\n\n")
+	sb.WriteString("Пример:\n`/rules add Импульс: change(15m) > 3 AND oi_change(1h) > 5 AND rsi(1h) < 70`")
+	return sb.String()
+}
+
+// splitSubcommand разделяет "add expr" на подкоманду и аргумент
+func splitSubcommand(data string) (string, string) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return "", ""
+	}
+	parts := strings.SplitN(data, " ", 2)
+	sub := strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return sub, ""
+	}
+	return sub, strings.TrimSpace(parts[1])
+}
+
+// escapeMarkdown убирает символы разметки Markdown из пользовательского текста
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("*", "", "_", " ", "`", "", "[", "(", "]", ")").Replace(s)
+}
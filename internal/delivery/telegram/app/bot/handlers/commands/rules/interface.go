@@ -0,0 +1,9 @@
+// internal/delivery/telegram/app/bot/handlers/commands/rules/interface.go
+package rules
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// RulesCommandHandler интерфейс обработчика команды /rules
+type RulesCommandHandler interface {
+	handlers.Handler
+}
@@ -0,0 +1,130 @@
+// internal/delivery/telegram/app/bot/handlers/commands/top/handler.go
+// Рейтинг относительной силы: лидеры, аутсайдеры и секторы за период.
+// Доступен командой /top [1h|4h|1d] и кнопками периодов (top_period:{PERIOD}).
+package top
+
+import (
+	"fmt"
+	"strings"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+)
+
+type topHandler struct {
+	*base.BaseHandler
+	strengthService *strength.Service
+}
+
+// NewHandler создает обработчик команды /top
+func NewHandler(strengthService *strength.Service) handlers.Handler {
+	return &topHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "top_command_handler",
+			Command: "top",
+			Type:    handlers.TypeCommand,
+		},
+		strengthService: strengthService,
+	}
+}
+
+// NewPeriodHandler создает обработчик кнопок переключения периода (top_period:{PERIOD})
+func NewPeriodHandler(strengthService *strength.Service) handlers.Handler {
+	return &topHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "top_period_handler",
+			Command: constants.CallbackTopPeriodPrefix + "*",
+			Type:    handlers.TypeCallback,
+		},
+		strengthService: strengthService,
+	}
+}
+
+// Execute показывает рейтинг за выбранный период
+func (h *topHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+	if h.strengthService == nil {
+		return handlers.HandlerResult{Message: "❌ Рейтинг недоступен"}, nil
+	}
+
+	period := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(params.Data, constants.CallbackTopPeriodPrefix)))
+	if period == "" {
+		period = h.strengthService.Config().DigestPeriod
+	}
+	if !strength.IsValidPeriod(period) {
+		return handlers.HandlerResult{
+			Message:  "❌ Неизвестный период. Доступны: `1h`, `4h`, `1d`\n\nПример: `/top 4h`",
+			Keyboard: periodKeyboard(""),
+		}, nil
+	}
+
+	report, err := h.strengthService.Rank(period)
+	if err != nil {
+		return handlers.HandlerResult{
+			Message:  fmt.Sprintf("⏳ Рейтинг пока не готов: %v", err),
+			Keyboard: periodKeyboard(period),
+		}, nil
+	}
+
+	return handlers.HandlerResult{
+		Message:  formatReport(report, h.strengthService.Config().TopN),
+		Keyboard: periodKeyboard(period),
+		Metadata: map[string]interface{}{"user_id": params.User.ID, "period": period},
+	}, nil
+}
+
+// formatReport форматирует рейтинг (Markdown)
+func formatReport(report *strength.Report, topN int) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🏆 *Относительная сила за %s*\n", report.Period))
+	sb.WriteString(fmt.Sprintf("₿ BTC: %+.2f%% • медиана рынка: %+.2f%% • монет: %d\n\n",
+		report.BTCReturn, report.MedianReturn, len(report.Entries)))
+
+	sb.WriteString("🚀 *Лидеры*\n")
+	for i, e := range report.Leaders(topN) {
+		sb.WriteString(fmt.Sprintf("%d. `%s` %+.2f%% (vs BTC %+.2f)\n", i+1, e.Symbol, e.Return, e.VsBTC))
+	}
+
+	sb.WriteString("\n🐢 *Аутсайдеры*\n")
+	for i, e := range report.Laggards(topN) {
+		sb.WriteString(fmt.Sprintf("%d. `%s` %+.2f%% (vs BTC %+.2f)\n", i+1, e.Symbol, e.Return, e.VsBTC))
+	}
+
+	if len(report.Sectors) > 0 {
+		sb.WriteString("\n🧩 *Секторы*\n")
+		for i, s := range report.Sectors {
+			sb.WriteString(fmt.Sprintf("%d. *%s* %+.2f%% (vs рынок %+.2f) • лидер `%s`\n",
+				i+1, s.Title, s.Return, s.VsMedian, s.Leader))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n🕐 %s", report.GeneratedAt.Format("15:04:05")))
+	return sb.String()
+}
+
+// periodKeyboard — кнопки периодов; текущий период отмечен точкой
+func periodKeyboard(current string) interface{} {
+	var row []map[string]string
+	for _, period := range strength.Periods {
+		text := period
+		if period == current {
+			text = "• " + period
+		}
+		row = append(row, map[string]string{
+			"text":          text,
+			"callback_data": constants.CallbackTopPeriodPrefix + period,
+		})
+	}
+
+	return map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			row,
+			{{"text": "🔙 Главное меню", "callback_data": constants.CallbackMenuMain}},
+		},
+	}
+}
@@ -0,0 +1,9 @@
+// internal/delivery/telegram/app/bot/handlers/commands/top/interface.go
+package top
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// TopCommandHandler интерфейс обработчика команды /top
+type TopCommandHandler interface {
+	handlers.Handler
+}
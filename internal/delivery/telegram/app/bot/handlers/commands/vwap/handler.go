@@ -0,0 +1,171 @@
+// internal/delivery/telegram/app/bot/handlers/commands/vwap/handler.go
+// VWAP дневной сессии и пользовательского якоря с полосами ±1σ/±2σ.
+//
+//	/vwap SOL            — VWAP монеты
+//	/vwap anchor 14:30   — якорь сегодня в 14:30 UTC (DD.MM HH:MM, now)
+//	/vwap anchor off     — снять якорь
+package vwap
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	vw "crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers/base"
+	signal_settings_svc "crypto-exchange-screener-bot/internal/delivery/telegram/services/signal_settings"
+)
+
+// usageText — подсказка по команде (Markdown)
+const usageText = "`/vwap SOL` — VWAP сессии и вашего якоря\n" +
+	"`/vwap anchor 14:30` — якорь сегодня в 14:30 UTC\n" +
+	"`/vwap anchor 17.10 09:00` — якорь на дату (UTC)\n" +
+	"`/vwap anchor now` — якорь от текущего момента\n" +
+	"`/vwap anchor off` — снять якорь\n\n" +
+	"Якорь можно поставить не дальше 72 ч назад."
+
+type vwapHandler struct {
+	*base.BaseHandler
+	tracker         func() *vw.Tracker
+	settingsService signal_settings_svc.Service
+}
+
+// NewHandler создает обработчик команды /vwap.
+// tracker вызывается лениво: CandleSystem может стартовать позже бота.
+func NewHandler(tracker func() *vw.Tracker, settingsService signal_settings_svc.Service) handlers.Handler {
+	return &vwapHandler{
+		BaseHandler: &base.BaseHandler{
+			Name:    "vwap_command_handler",
+			Command: "vwap",
+			Type:    handlers.TypeCommand,
+		},
+		tracker:         tracker,
+		settingsService: settingsService,
+	}
+}
+
+// Execute показывает VWAP монеты или управляет якорем
+func (h *vwapHandler) Execute(params handlers.HandlerParams) (handlers.HandlerResult, error) {
+	if params.User == nil {
+		return handlers.HandlerResult{}, fmt.Errorf("пользователь не авторизован")
+	}
+
+	args := strings.TrimSpace(params.Data)
+	if args == "" {
+		return handlers.HandlerResult{
+			Message:  "📏 *VWAP*\n\n" + usageText + h.describeAnchor(params.User.VWAPAnchorAt),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	fields := strings.Fields(args)
+	if strings.EqualFold(fields[0], "anchor") {
+		return h.setAnchor(params, strings.Join(fields[1:], " ")), nil
+	}
+
+	tracker := h.tracker()
+	if tracker == nil {
+		return handlers.HandlerResult{Message: "❌ VWAP недоступен: свечная система не запущена"}, nil
+	}
+
+	symbol := alertsDomain.NormalizeSymbol(fields[0])
+	if symbol == "" {
+		return handlers.HandlerResult{Message: "❌ Неверный тикер\n\n" + usageText, Keyboard: menuKeyboard()}, nil
+	}
+
+	session, ok := tracker.Session(symbol)
+	if !ok {
+		return handlers.HandlerResult{
+			Message:  fmt.Sprintf("⏳ VWAP *%s* пока не рассчитан: нет тиков с приростом оборота с начала сессии", symbol),
+			Keyboard: menuKeyboard(),
+		}, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📏 *VWAP %s*\n💰 Цена: %s\n\n", symbol, alertsDomain.FormatPrice(session.LastPrice)))
+	sb.WriteString(fmt.Sprintf("*Сессия* (с %s UTC)\n", session.Anchor.Format("02.01 15:04")))
+	writeSnapshot(&sb, session)
+
+	if anchor := params.User.VWAPAnchorAt; anchor != nil {
+		sb.WriteString(fmt.Sprintf("\n*Якорь* (с %s UTC)\n", anchor.UTC().Format("02.01 15:04")))
+		if anchored, ok := tracker.Anchored(symbol, *anchor); ok {
+			writeSnapshot(&sb, anchored)
+		} else {
+			sb.WriteString("нет данных: якорь старше 72 ч или монета не торговалась\n")
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n🕐 %s UTC", session.UpdatedAt.UTC().Format("15:04:05")))
+
+	return handlers.HandlerResult{
+		Message:  sb.String(),
+		Keyboard: menuKeyboard(),
+		Metadata: map[string]interface{}{"user_id": params.User.ID, "symbol": symbol},
+	}, nil
+}
+
+// setAnchor задает или снимает якорь пользователя
+func (h *vwapHandler) setAnchor(params handlers.HandlerParams, arg string) handlers.HandlerResult {
+	var value interface{}
+	switch strings.ToLower(strings.TrimSpace(arg)) {
+	case "":
+		return handlers.HandlerResult{Message: "❌ Укажите время якоря\n\n" + usageText, Keyboard: menuKeyboard()}
+	case "off", "выкл":
+		value = nil
+	default:
+		anchor, err := vw.ParseAnchor(arg, time.Now())
+		if err != nil {
+			return handlers.HandlerResult{Message: fmt.Sprintf("❌ %s\n\n%s", err.Error(), usageText), Keyboard: menuKeyboard()}
+		}
+		value = anchor
+	}
+
+	result, err := h.settingsService.Exec(signal_settings_svc.SignalSettingsParams{
+		Action: "set_vwap_anchor",
+		UserID: params.User.ID,
+		ChatID: params.ChatID,
+		Value:  value,
+	})
+	if err != nil {
+		return handlers.HandlerResult{Message: fmt.Sprintf("❌ %s", err.Error()), Keyboard: menuKeyboard()}
+	}
+
+	return handlers.HandlerResult{
+		Message:  fmt.Sprintf("📏 %s\n\nПосмотреть VWAP: `/vwap SOL`", result.Message),
+		Keyboard: menuKeyboard(),
+		Metadata: map[string]interface{}{
+			"user_id":       params.User.ID,
+			"updated_field": result.UpdatedField,
+		},
+	}
+}
+
+// describeAnchor — строка о текущем якоре пользователя
+func (h *vwapHandler) describeAnchor(anchor *time.Time) string {
+	if anchor == nil {
+		return "\n\n⚓ Якорь не задан"
+	}
+	return fmt.Sprintf("\n\n⚓ Якорь: %s UTC", anchor.UTC().Format("02.01 15:04"))
+}
+
+// writeSnapshot — VWAP, полосы и положение цены (Markdown)
+func writeSnapshot(sb *strings.Builder, snap vw.Snapshot) {
+	upper1, lower1 := snap.Band(1)
+	upper2, lower2 := snap.Band(2)
+
+	sb.WriteString(fmt.Sprintf("VWAP: %s\n", alertsDomain.FormatPrice(snap.VWAP)))
+	sb.WriteString(fmt.Sprintf("±1σ: %s – %s\n", alertsDomain.FormatPrice(lower1), alertsDomain.FormatPrice(upper1)))
+	sb.WriteString(fmt.Sprintf("±2σ: %s – %s\n", alertsDomain.FormatPrice(lower2), alertsDomain.FormatPrice(upper2)))
+	sb.WriteString(fmt.Sprintf("Цена: %+.2f%% от VWAP (%+.1fσ)\n", snap.DistancePct(snap.LastPrice), snap.Sigmas(snap.LastPrice)))
+}
+
+func menuKeyboard() interface{} {
+	return map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{{"text": "🔙 Главное меню", "callback_data": constants.CallbackMenuMain}},
+		},
+	}
+}
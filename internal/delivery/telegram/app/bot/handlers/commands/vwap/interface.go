@@ -0,0 +1,9 @@
+// internal/delivery/telegram/app/bot/handlers/commands/vwap/interface.go
+package vwap
+
+import "crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/handlers"
+
+// VWAPCommandHandler интерфейс обработчика команды /vwap
+type VWAPCommandHandler interface {
+	handlers.Handler
+}
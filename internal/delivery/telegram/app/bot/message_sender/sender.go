@@ -20,6 +20,8 @@ type MessageSender interface {
 	SendMenuMessage(chatID int64, text string, keyboard interface{}) error
 	// Отправка menu сообщения с возвратом message_id (для последующего удаления)
 	SendMenuMessageWithID(chatID int64, text string, keyboard interface{}) (int64, error)
+	// Отправка ответом на сообщение replyToID (0 — обычное сообщение) с возвратом message_id
+	SendReplyMessage(chatID, replyToID int64, text string, keyboard interface{}) (int64, error)
 
 	// Управление сообщениями
 	EditMessageText(chatID, messageID int64, text string, keyboard interface{}) error
@@ -95,6 +97,33 @@ func (ms *MessageSenderImpl) SendMenuMessageWithID(chatID int64, text string, ke
 	return ms.sendTelegramRequestGetMsgID("sendMessage", request)
 }
 
+// SendReplyMessage отправляет сообщение ответом на replyToID и возвращает его message_id.
+// Если исходное сообщение удалено, Telegram отправит его как обычное.
+func (ms *MessageSenderImpl) SendReplyMessage(chatID, replyToID int64, text string, keyboard interface{}) (int64, error) {
+	if !ms.enabled {
+		return 0, nil
+	}
+	if ms.testMode {
+		log.Printf("[TEST] SendReplyMessage to %d (reply to %d): %s", chatID, replyToID, text[:min(50, len(text))])
+		return 0, nil
+	}
+
+	request := map[string]interface{}{
+		"chat_id":    chatID,
+		"text":       text,
+		"parse_mode": "Markdown",
+	}
+	if replyToID > 0 {
+		request["reply_to_message_id"] = replyToID
+		request["allow_sending_without_reply"] = true
+	}
+	if keyboard != nil {
+		request["reply_markup"] = keyboard
+	}
+
+	return ms.sendTelegramRequestGetMsgID("sendMessage", request)
+}
+
 // sendMessageWithoutRateLimit внутренний метод без rate limiting
 func (ms *MessageSenderImpl) sendMessageWithoutRateLimit(chatID int64, text string, keyboard interface{}, msgType string) error {
 	// Проверяем включен ли Telegram
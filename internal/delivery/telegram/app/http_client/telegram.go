@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -94,6 +96,59 @@ func (c *TelegramClient) SetMyCommands(commands []telegram.BotCommand) error {
 	return nil
 }
 
+// SendDocument отправляет документ, содержимое которого пишется потоком.
+// write пишет содержимое файла и возвращает подпись к документу: подпись отправляется
+// после файла, поэтому может включать итоги записи (число строк и т.п.).
+// Запрос не ограничен таймаутом клиента — большие выгрузки передаются дольше 30 секунд.
+func (c *TelegramClient) SendDocument(chatID int64, fileName string, write func(w io.Writer) (string, error)) error {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	go func() {
+		err := func() error {
+			if err := form.WriteField("chat_id", strconv.FormatInt(chatID, 10)); err != nil {
+				return err
+			}
+			part, err := form.CreateFormFile("document", fileName)
+			if err != nil {
+				return err
+			}
+			caption, err := write(part)
+			if err != nil {
+				return err
+			}
+			if caption != "" {
+				if err := form.WriteField("caption", caption); err != nil {
+					return err
+				}
+			}
+			return form.Close()
+		}()
+		writer.CloseWithError(err)
+	}()
+
+	client := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := client.Post(c.baseURL+"sendDocument", form.FormDataContentType(), reader)
+	if err != nil {
+		// Закрываем канал, чтобы пишущая горутина не зависла
+		reader.CloseWithError(err)
+		return fmt.Errorf("ошибка отправки документа: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("ошибка чтения ответа sendDocument: %w", err)
+	}
+	if !response.OK {
+		return fmt.Errorf("telegram API ошибка: %s", response.Description)
+	}
+	return nil
+}
+
 // validateBotCommand проверяет валидность команды бота
 func validateBotCommand(cmd telegram.BotCommand) error {
 	// Проверяем команду
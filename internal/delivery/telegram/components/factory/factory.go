@@ -148,6 +148,11 @@ func (s *stubMessageSender) SendMenuMessageWithID(chatID int64, text string, key
 	return 0, nil
 }
 
+func (s *stubMessageSender) SendReplyMessage(chatID, replyToID int64, text string, keyboard interface{}) (int64, error) {
+	logger.Debug("[STUB] Отправка ответа на %d в %d: %s", replyToID, chatID, text[:min(50, len(text))])
+	return 0, nil
+}
+
 func (s *stubMessageSender) EditMessageText(chatID, messageID int64, text string, keyboard interface{}) error {
 	logger.Debug("[STUB] Редактирование сообщения %d в %d: %s", messageID, chatID, text[:min(50, len(text))])
 	return nil
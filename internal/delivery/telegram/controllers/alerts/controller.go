@@ -0,0 +1,83 @@
+// internal/delivery/telegram/controllers/alerts/controller.go
+package alerts
+
+import (
+	alertsDomain "crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+)
+
+// alertsControllerImpl отправляет владельцу алерта уведомление о срабатывании
+type alertsControllerImpl struct {
+	userService   *users.Service
+	messageSender message_sender.MessageSender
+	deliveries    *broadcast.Deliveries
+}
+
+// NewController создает контроллер ценовых алертов
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return &alertsControllerImpl{
+		userService:   userService,
+		messageSender: messageSender,
+		deliveries:    deliveries,
+	}
+}
+
+// HandleEvent обрабатывает EventPriceAlertTriggered
+func (c *alertsControllerImpl) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.PriceAlertTriggeredData)
+	if !ok {
+		return fmt.Errorf("alerts controller: неверный формат данных: %T", event.Data)
+	}
+
+	user, err := c.userService.GetUserByID(data.UserID)
+	if err != nil || user == nil {
+		return fmt.Errorf("alerts controller: пользователь %d не найден: %v", data.UserID, err)
+	}
+	if !user.IsActive || !user.NotificationsEnabled || user.ChatID == "" {
+		return nil
+	}
+
+	var chatID int64
+	if _, err := fmt.Sscanf(user.ChatID, "%d", &chatID); err != nil {
+		return fmt.Errorf("alerts controller: неверный chat_id у пользователя %d: %s", user.ID, user.ChatID)
+	}
+
+	keyboard := map[string]interface{}{
+		"inline_keyboard": [][]map[string]string{
+			{{"text": "🔔 Мои алерты", "callback_data": constants.CallbackAlertsMenu}},
+		},
+	}
+	text := formatAlertMessage(data)
+	if err := c.messageSender.SendTextMessage(chatID, text, keyboard); err != nil {
+		logger.Warn("⚠️ Alerts controller: ошибка отправки алерта #%d user=%d: %v", data.AlertID, user.ID, err)
+		return err
+	}
+	c.deliveries.Delivered(user.ID, "telegram", broadcast.AlertDelivery(data), text)
+	return nil
+}
+
+// GetName возвращает имя контроллера
+func (c *alertsControllerImpl) GetName() string {
+	return "alerts_controller"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (c *alertsControllerImpl) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventPriceAlertTriggered}
+}
+
+// formatAlertMessage форматирует уведомление (Markdown)
+func formatAlertMessage(data types.PriceAlertTriggeredData) string {
+	msg := fmt.Sprintf("🔔 *Алерт #%d сработал*\n🪙 *%s* — %s\n💰 Цена: %s\n",
+		data.AlertID, data.Symbol, data.Description, alertsDomain.FormatPrice(data.Price))
+	if data.Recurring {
+		msg += "🔁 Алерт остаётся активным\n"
+	}
+	return msg + fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05"))
+}
@@ -0,0 +1,57 @@
+// internal/delivery/telegram/controllers/anomaly/controller.go
+package anomaly
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// NewController создает контроллер, рассылающий z-score сигналы пользователям,
+// чей порог чувствительности (в сигмах) не выше z-score свечи
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return broadcast.NewController(broadcast.New(userService, broadcast.Telegram(messageSender), deliveries),
+		broadcast.Spec[types.ReturnAnomalyData]{
+			Name:   "anomaly_controller",
+			Event:  types.EventReturnAnomaly,
+			Filter: shouldSendToUser,
+			Format: formatAnomalyMessage,
+			Describe: func(data types.ReturnAnomalyData) string {
+				return fmt.Sprintf("%s/%s z=%.1f", data.Symbol, data.Period, data.ZScore)
+			},
+			Delivery: broadcast.AnomalyDelivery,
+		})
+}
+
+// shouldSendToUser — порог в сигмах, направление и фильтры символов пользователя
+func shouldSendToUser(user *models.User, data types.ReturnAnomalyData) bool {
+	if user.SensitivitySigma <= 0 || math.Abs(data.ZScore) < user.SensitivitySigma {
+		return false
+	}
+	if data.Direction == "growth" && !user.NotifyGrowth || data.Direction == "fall" && !user.NotifyFall {
+		return false
+	}
+	return broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatAnomalyMessage форматирует уведомление (Markdown)
+func formatAnomalyMessage(data types.ReturnAnomalyData) string {
+	var sb strings.Builder
+
+	icon := "🔴"
+	if data.Direction == "growth" {
+		icon = "🟢"
+	}
+
+	sb.WriteString(fmt.Sprintf("📐 *Необычное движение: %.1fσ*\n", math.Abs(data.ZScore)))
+	sb.WriteString(fmt.Sprintf("%s *%s* • %s • %+.2f%% • цена %.6g\n",
+		icon, data.Symbol, data.Period, data.ChangePct, data.Price))
+	sb.WriteString(fmt.Sprintf("📊 Обычно: %+.2f%% ± %.2f%% (по %d свечам)\n", data.Median, data.Sigma, data.Samples))
+	sb.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return sb.String()
+}
@@ -111,5 +111,52 @@ func convertEventToParams(event types.Event) (counterService.CounterParams, erro
 	params.SRResistanceHasWall = getBool(dataMap, "sr_resistance_has_wall")
 	params.SRResistanceWallUSD = getFloat64(dataMap, "sr_resistance_wall_usd")
 
+	// Согласованность со старшими таймфреймами (ключ есть, только если оценка рассчитана)
+	if _, ok := dataMap["confluence_score"]; ok {
+		params.HasConfluence = true
+		params.ConfluenceScore = getFloat64(dataMap, "confluence_score")
+	}
+
+	// Рыночный контекст (ключи есть, только если бета рассчитана)
+	if _, ok := dataMap["market_benchmark"]; ok {
+		params.HasMarketContext = true
+		params.MarketBenchmark = getString(dataMap, "market_benchmark")
+		params.MarketBenchmarkMove = getFloat64(dataMap, "market_benchmark_change")
+		params.MarketBeta = getFloat64(dataMap, "market_beta")
+		params.IdiosyncraticChange = getFloat64(dataMap, "idiosyncratic_change")
+		params.MarketExplained = getBool(dataMap, "market_explained")
+	}
+	params.MarketRegime = getString(dataMap, "market_regime")
+
+	// Ликвидность стакана (ключи есть, только если стакан получен)
+	if _, ok := dataMap["liquidity_cost_up_1pct"]; ok {
+		params.HasLiquidity = true
+		params.LiquidityCostUp1Pct = getFloat64(dataMap, "liquidity_cost_up_1pct")
+		params.LiquidityCostDown1Pct = getFloat64(dataMap, "liquidity_cost_down_1pct")
+		params.LiquidityImbalance = getFloat64(dataMap, "liquidity_imbalance_1pct")
+		params.LiquidityThinBook = getBool(dataMap, "liquidity_thin_book")
+	}
+
+	// ID сигнала для истории доставок и исходов из журнала
+	params.SignalID = getString(dataMap, "signal_id")
+
+	// Эпизод памп/дамп (ключи есть, только если сигнал привязан к эпизоду)
+	if id := getString(dataMap, "episode_id"); id != "" {
+		params.EpisodeID = id
+		params.EpisodeStage = getString(dataMap, "episode_stage")
+		params.EpisodeStageChanged = getBool(dataMap, "episode_stage_changed")
+		params.EpisodePeakPrice = getFloat64(dataMap, "episode_peak_price")
+		params.EpisodeGainPct = getFloat64(dataMap, "episode_gain_pct")
+		params.EpisodeRetracePct = getFloat64(dataMap, "episode_retrace_pct")
+	}
+
+	// VWAP дневной сессии (ключи есть, только если VWAP рассчитан)
+	if vwap := getFloat64(dataMap, "vwap_session"); vwap > 0 {
+		params.HasVWAP = true
+		params.VWAPSession = vwap
+		params.VWAPDistancePct = getFloat64(dataMap, "vwap_distance_pct")
+		params.VWAPSigmas = getFloat64(dataMap, "vwap_sigmas")
+	}
+
 	return params, nil
 }
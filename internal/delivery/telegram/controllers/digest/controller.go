@@ -0,0 +1,86 @@
+// internal/delivery/telegram/controllers/digest/controller.go
+package digest
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+)
+
+// digestControllerImpl доставляет дайджест рынка получателям из события
+type digestControllerImpl struct {
+	userService   *users.Service
+	messageSender message_sender.MessageSender
+}
+
+// NewController создает контроллер дайджеста рынка
+func NewController(userService *users.Service, messageSender message_sender.MessageSender) Controller {
+	return &digestControllerImpl{
+		userService:   userService,
+		messageSender: messageSender,
+	}
+}
+
+// HandleEvent обрабатывает EventMarketDigest
+func (c *digestControllerImpl) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.MarketDigestData)
+	if !ok {
+		return fmt.Errorf("digest controller: неверный формат данных: %T", event.Data)
+	}
+
+	text := digest.FormatDigest(data) + "\n\nНастройки: /digest"
+	sent := 0
+	for _, userID := range data.Recipients {
+		user, err := c.userService.GetUserByID(userID)
+		if err != nil {
+			logger.Warn("⚠️ Digest controller: ошибка получения пользователя %d: %v", userID, err)
+			continue
+		}
+		if !shouldSendToUser(user) {
+			continue
+		}
+
+		var chatID int64
+		if _, err := fmt.Sscanf(user.ChatID, "%d", &chatID); err != nil {
+			logger.Warn("⚠️ Digest controller: неверный chat_id у пользователя %d: %s", user.ID, user.ChatID)
+			continue
+		}
+
+		if err := c.messageSender.SendTextMessage(chatID, text, nil); err != nil {
+			logger.Warn("⚠️ Digest controller: ошибка отправки дайджеста user=%d: %v", user.ID, err)
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		logger.Debug("📰 Digest controller: дайджест %s — отправлено %d", data.Kind, sent)
+	}
+	return nil
+}
+
+// GetName возвращает имя контроллера
+func (c *digestControllerImpl) GetName() string {
+	return "digest_controller"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (c *digestControllerImpl) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventMarketDigest}
+}
+
+// shouldSendToUser — базовые условия доставки (подписка проверена при рассылке)
+func shouldSendToUser(user *models.User) bool {
+	if user == nil {
+		return false
+	}
+	// MAX-only пользователи обрабатываются MAX контроллером
+	if user.IsMaxOnlyUser() || user.ChatID == "" {
+		return false
+	}
+	return user.IsActive && user.NotificationsEnabled
+}
@@ -2,8 +2,20 @@
 package controllers_factory
 
 import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	alertsctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/alerts"
+	anomalyctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/anomaly"
 	counterctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/counter"
+	digestctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/digest"
+	patternsctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/patterns"
 	paymentctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/payment" // ⭐ ДОБАВЛЕНО
+	rangesctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/ranges"
+	rulesctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/rules"
+	strengthctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/strength"
+	vwapctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/vwap"
+	wallsctrl "crypto-exchange-screener-bot/internal/delivery/telegram/controllers/walls"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/services/counter"
 	"crypto-exchange-screener-bot/internal/types"
 	"crypto-exchange-screener-bot/pkg/logger"
@@ -12,12 +24,18 @@ import (
 // ControllerFactory фабрика контроллеров для EventBus
 type ControllerFactory struct {
 	counterService counter.Service
+	userService    *users.Service
+	messageSender  message_sender.MessageSender
+	deliveries     *broadcast.Deliveries
 	// Добавляем другие сервисы по мере необходимости
 }
 
 // ControllerDependencies зависимости для фабрики контроллеров
 type ControllerDependencies struct {
 	CounterService counter.Service
+	UserService    *users.Service               // для Rules, Alerts, Patterns, Anomaly, Strength, Digest, Ranges, VWAP и WallsController
+	MessageSender  message_sender.MessageSender // для Rules, Alerts, Patterns, Anomaly, Strength, Digest, Ranges, VWAP и WallsController
+	Deliveries     *broadcast.Deliveries        // опционально, nil — доставки уведомлений не записываются
 	// Здесь можно добавить другие зависимости позже
 }
 
@@ -27,6 +45,9 @@ func NewControllerFactory(deps ControllerDependencies) *ControllerFactory {
 
 	return &ControllerFactory{
 		counterService: deps.CounterService,
+		userService:    deps.UserService,
+		messageSender:  deps.MessageSender,
+		deliveries:     deps.Deliveries,
 	}
 }
 
@@ -40,6 +61,51 @@ func (f *ControllerFactory) CreatePaymentController() types.EventSubscriber {
 	return paymentctrl.NewController()
 }
 
+// CreateRulesController создает контроллер уведомлений пользовательских правил
+func (f *ControllerFactory) CreateRulesController() types.EventSubscriber {
+	return rulesctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreateAlertsController создает контроллер уведомлений ценовых алертов
+func (f *ControllerFactory) CreateAlertsController() types.EventSubscriber {
+	return alertsctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreatePatternsController создает контроллер алертов свечных паттернов у зон S/R
+func (f *ControllerFactory) CreatePatternsController() types.EventSubscriber {
+	return patternsctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreateAnomalyController создает контроллер z-score сигналов
+func (f *ControllerFactory) CreateAnomalyController() types.EventSubscriber {
+	return anomalyctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreateStrengthController создает контроллер дайджеста ротаций секторов
+func (f *ControllerFactory) CreateStrengthController() types.EventSubscriber {
+	return strengthctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreateDigestController создает контроллер дайджеста рынка
+func (f *ControllerFactory) CreateDigestController() types.EventSubscriber {
+	return digestctrl.NewController(f.userService, f.messageSender)
+}
+
+// CreateRangesController создает контроллер алертов о пробое диапазона
+func (f *ControllerFactory) CreateRangesController() types.EventSubscriber {
+	return rangesctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreateVWAPController создает контроллер сигналов VWAP
+func (f *ControllerFactory) CreateVWAPController() types.EventSubscriber {
+	return vwapctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
+// CreateWallsController создает контроллер алертов о снятии стен стакана
+func (f *ControllerFactory) CreateWallsController() types.EventSubscriber {
+	return wallsctrl.NewController(f.userService, f.messageSender, f.deliveries)
+}
+
 // GetAllControllers создает все контроллеры
 func (f *ControllerFactory) GetAllControllers() map[string]types.EventSubscriber {
 	controllers := make(map[string]types.EventSubscriber)
@@ -51,6 +117,18 @@ func (f *ControllerFactory) GetAllControllers() map[string]types.EventSubscriber
 	// ⭐ Добавляем PaymentController (не требует зависимостей)
 	controllers["PaymentController"] = f.CreatePaymentController()
 
+	if f.userService != nil && f.messageSender != nil {
+		controllers["RulesController"] = f.CreateRulesController()
+		controllers["AlertsController"] = f.CreateAlertsController()
+		controllers["PatternsController"] = f.CreatePatternsController()
+		controllers["AnomalyController"] = f.CreateAnomalyController()
+		controllers["StrengthController"] = f.CreateStrengthController()
+		controllers["DigestController"] = f.CreateDigestController()
+		controllers["RangesController"] = f.CreateRangesController()
+		controllers["VWAPController"] = f.CreateVWAPController()
+		controllers["WallsController"] = f.CreateWallsController()
+	}
+
 	logger.Info("✅ ControllerFactory создала %d контроллеров", len(controllers))
 	return controllers
 }
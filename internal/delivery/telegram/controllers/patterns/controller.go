@@ -0,0 +1,49 @@
+// internal/delivery/telegram/controllers/patterns/controller.go
+package patterns
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"strings"
+)
+
+// NewController создает контроллер, рассылающий алерты о паттернах у зон S/R подписанным пользователям
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return broadcast.NewController(broadcast.New(userService, broadcast.Telegram(messageSender), deliveries),
+		broadcast.Spec[types.PatternZoneData]{
+			Name:   "patterns_controller",
+			Event:  types.EventPatternAtZone,
+			Filter: shouldSendToUser,
+			Format: formatPatternMessage,
+			Describe: func(data types.PatternZoneData) string {
+				return fmt.Sprintf("%s %s у зоны %s", data.Symbol, data.Pattern, data.ZoneType)
+			},
+			Delivery: broadcast.PatternDelivery,
+		})
+}
+
+// shouldSendToUser — подписка на паттерны и фильтры символов пользователя
+func shouldSendToUser(user *models.User, data types.PatternZoneData) bool {
+	return user.NotifyPatternZones && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatPatternMessage форматирует уведомление (Markdown)
+func formatPatternMessage(data types.PatternZoneData) string {
+	var sb strings.Builder
+
+	icon, zone := "🟢", "поддержки"
+	if data.ZoneType == "resistance" {
+		icon, zone = "🔴", "сопротивления"
+	}
+
+	sb.WriteString(fmt.Sprintf("🕯️ *%s* у %s\n", data.PatternTitle, zone))
+	sb.WriteString(fmt.Sprintf("%s *%s* • %s • цена %.6g\n", icon, data.Symbol, data.Period, data.Price))
+	sb.WriteString(fmt.Sprintf("📐 Зона %.6g – %.6g (%s) • сила %.0f • касаний %d\n",
+		data.ZoneLow, data.ZoneHigh, data.ZonePeriod, data.ZoneStrength, data.ZoneTouches))
+	sb.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return sb.String()
+}
@@ -0,0 +1,71 @@
+// internal/delivery/telegram/controllers/ranges/controller.go
+package ranges
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"strings"
+)
+
+// NewController создает контроллер алертов о пробое диапазона по выбранным горизонтам.
+// Каждый пользователь получает одно сообщение — по самому длинному из выбранных им горизонтов.
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return broadcast.NewController(broadcast.New(userService, broadcast.Telegram(messageSender), deliveries),
+		broadcast.Spec[types.RangeBreakoutData]{
+			Name:   "ranges_controller",
+			Event:  types.EventRangeBreakout,
+			Filter: shouldSendToUser,
+			FormatFor: func(user *models.User, data types.RangeBreakoutData) (string, bool) {
+				brk, ok := data.Longest(user.SubscribesRangeHorizon)
+				if !ok {
+					return "", false
+				}
+				return formatBreakoutMessage(data, brk), true
+			},
+			Describe: func(data types.RangeBreakoutData) string {
+				return fmt.Sprintf("%s %s (%d горизонтов)", data.Symbol, data.Kind, len(data.Breaks))
+			},
+			Delivery: broadcast.RangeDelivery,
+		})
+}
+
+// shouldSendToUser — выбранные горизонты и фильтры символов; совпадение горизонтов проверяется при форматировании
+func shouldSendToUser(user *models.User, data types.RangeBreakoutData) bool {
+	return len(user.RangeBreakoutHorizons) > 0 && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatBreakoutMessage форматирует уведомление о пробое (Markdown)
+func formatBreakoutMessage(data types.RangeBreakoutData, brk types.RangeBreak) string {
+	var sb strings.Builder
+
+	icon, what, prev := "🚀", "новый максимум", "Прежний максимум"
+	if data.Kind == string(ranges.KindLow) {
+		icon, what, prev = "🕳️", "новый минимум", "Прежний минимум"
+	}
+
+	sb.WriteString(fmt.Sprintf("%s *%s* — %s за %s\n",
+		icon, data.Symbol, what, ranges.Horizon(brk.Horizon).Label()))
+	sb.WriteString(fmt.Sprintf("💰 Цена %.6g • %+.2f%% к прежнему экстремуму\n", data.Price, brk.DistancePct))
+	sb.WriteString(fmt.Sprintf("📌 %s %.6g от %s", prev, brk.PrevPrice, brk.PrevTime.Format("02.01.2006")))
+	if brk.DaysSincePrev > 0 {
+		sb.WriteString(fmt.Sprintf(" (%d дн. назад)", brk.DaysSincePrev))
+	}
+	sb.WriteString("\n")
+
+	// Остальные пробитые горизонты — одной строкой
+	if len(data.Breaks) > 1 {
+		labels := make([]string, 0, len(data.Breaks))
+		for _, b := range data.Breaks {
+			labels = append(labels, b.Horizon)
+		}
+		sb.WriteString(fmt.Sprintf("📐 Пробиты горизонты: %s\n", strings.Join(labels, ", ")))
+	}
+
+	sb.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+// internal/delivery/telegram/controllers/rules/controller.go
+package rules
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/types"
+	"crypto-exchange-screener-bot/pkg/logger"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// rulesControllerImpl отправляет владельцу правила уведомление о срабатывании
+type rulesControllerImpl struct {
+	userService   *users.Service
+	messageSender message_sender.MessageSender
+	deliveries    *broadcast.Deliveries
+}
+
+// NewController создает контроллер пользовательских правил
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return &rulesControllerImpl{
+		userService:   userService,
+		messageSender: messageSender,
+		deliveries:    deliveries,
+	}
+}
+
+// HandleEvent обрабатывает EventRuleTriggered
+func (c *rulesControllerImpl) HandleEvent(event types.Event) error {
+	data, ok := event.Data.(types.RuleTriggeredData)
+	if !ok {
+		return fmt.Errorf("rules controller: неверный формат данных: %T", event.Data)
+	}
+
+	user, err := c.userService.GetUserByID(data.UserID)
+	if err != nil || user == nil {
+		return fmt.Errorf("rules controller: пользователь %d не найден: %v", data.UserID, err)
+	}
+	if !user.IsActive || !user.NotificationsEnabled || user.ChatID == "" {
+		return nil
+	}
+
+	var chatID int64
+	if _, err := fmt.Sscanf(user.ChatID, "%d", &chatID); err != nil {
+		return fmt.Errorf("rules controller: неверный chat_id у пользователя %d: %s", user.ID, user.ChatID)
+	}
+
+	text := formatRuleMessage(data)
+	if err := c.messageSender.SendTextMessage(chatID, text, nil); err != nil {
+		logger.Warn("⚠️ Rules controller: ошибка отправки правила #%d user=%d: %v", data.RuleID, user.ID, err)
+		return err
+	}
+	c.deliveries.Delivered(user.ID, "telegram", broadcast.RuleDelivery(data), text)
+	return nil
+}
+
+// GetName возвращает имя контроллера
+func (c *rulesControllerImpl) GetName() string {
+	return "rules_controller"
+}
+
+// GetSubscribedEvents возвращает типы событий для подписки
+func (c *rulesControllerImpl) GetSubscribedEvents() []types.EventType {
+	return []types.EventType{types.EventRuleTriggered}
+}
+
+// formatRuleMessage форматирует уведомление (Markdown)
+func formatRuleMessage(data types.RuleTriggeredData) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("Правило #%d", data.RuleID)
+	if data.RuleName != "" {
+		title += " «" + escapeMarkdown(data.RuleName) + "»"
+	}
+	sb.WriteString(fmt.Sprintf("📐 *%s* сработало\n", title))
+
+	sb.WriteString(fmt.Sprintf("🪙 *%s* • %s", data.Symbol, data.Period))
+	if data.Price > 0 {
+		sb.WriteString(fmt.Sprintf(" • цена %.6g", data.Price))
+	}
+	sb.WriteString("\n")
+
+	// Выражение без экранирования: в коде Markdown "_" и "*" не ломают разметку
+	sb.WriteString(fmt.Sprintf("`%s`\n", data.Expression))
+
+	if len(data.Metrics) > 0 {
+		keys := make([]string, 0, len(data.Metrics))
+		for k := range data.Metrics {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("`%s` %.2f", k, data.Metrics[k]))
+		}
+		sb.WriteString("📊 " + strings.Join(parts, " • ") + "\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return sb.String()
+}
+
+// escapeMarkdown убирает символы разметки Markdown из пользовательского текста
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("*", "", "_", " ", "`", "", "[", "(", "]", ")").Replace(s)
+}
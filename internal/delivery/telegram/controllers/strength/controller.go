@@ -0,0 +1,76 @@
+// internal/delivery/telegram/controllers/strength/controller.go
+package strength
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"strings"
+)
+
+// NewController создает контроллер, рассылающий дайджест ротаций секторов подписанным пользователям
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return broadcast.NewController(broadcast.New(userService, broadcast.Telegram(messageSender), deliveries),
+		broadcast.Spec[types.SectorDigestData]{
+			Name:   "strength_controller",
+			Event:  types.EventSectorDigest,
+			Filter: shouldSendToUser,
+			Format: formatDigestMessage,
+			Describe: func(data types.SectorDigestData) string {
+				return "дайджест за " + data.Period
+			},
+		})
+}
+
+// shouldSendToUser — подписка на дайджест
+func shouldSendToUser(user *models.User, _ types.SectorDigestData) bool {
+	return user.NotifySectorDigest
+}
+
+// formatDigestMessage форматирует дайджест (Markdown)
+func formatDigestMessage(data types.SectorDigestData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🧩 *Ротация секторов за %s*\n", data.Period))
+	sb.WriteString(fmt.Sprintf("₿ BTC: %+.2f%% • медиана рынка: %+.2f%%\n\n", data.BTCReturn, data.MedianReturn))
+
+	for i, s := range data.Sectors {
+		sb.WriteString(fmt.Sprintf("%d. *%s* %+.2f%% (vs рынок %+.2f) • лидер `%s`\n",
+			i+1, s.Title, s.Return, s.VsMedian, s.Leader))
+	}
+
+	if len(data.Rotations) > 0 {
+		sb.WriteString("\n🔄 *Ротации*\n")
+		for _, r := range data.Rotations {
+			arrow := "⬆️"
+			if r.ToRank > r.FromRank {
+				arrow = "⬇️"
+			}
+			sb.WriteString(fmt.Sprintf("%s %s: %d → %d место\n", arrow, r.Title, r.FromRank, r.ToRank))
+		}
+	}
+
+	if len(data.Leaders) > 0 {
+		sb.WriteString("\n🚀 *Лидеры:* ")
+		sb.WriteString(formatSymbols(data.Leaders))
+	}
+	if len(data.Laggards) > 0 {
+		sb.WriteString("\n🐢 *Аутсайдеры:* ")
+		sb.WriteString(formatSymbols(data.Laggards))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n\n🕐 %s • подробнее: /top", data.Timestamp.Format("15:04")))
+	return sb.String()
+}
+
+// formatSymbols — "`SOLUSDT` +4.10%, ..."
+func formatSymbols(list []types.SymbolStrength) string {
+	parts := make([]string, 0, len(list))
+	for _, s := range list {
+		parts = append(parts, fmt.Sprintf("`%s` %+.2f%%", s.Symbol, s.Return))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,57 @@
+// internal/delivery/telegram/controllers/vwap/controller.go
+package vwap
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"strings"
+)
+
+// NewController создает контроллер, рассылающий сигналы анализатора VWAP подписанным пользователям
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return broadcast.NewController(broadcast.New(userService, broadcast.Telegram(messageSender), deliveries),
+		broadcast.Spec[types.VWAPSignalData]{
+			Name:   "vwap_controller",
+			Event:  types.EventVWAPSignal,
+			Filter: shouldSendToUser,
+			Format: formatSignalMessage,
+			Describe: func(data types.VWAPSignalData) string {
+				return fmt.Sprintf("%s %s", data.Symbol, data.Kind)
+			},
+			Delivery: broadcast.VWAPDelivery,
+		})
+}
+
+// shouldSendToUser проверяет подписку на сигналы VWAP и фильтры символов
+func shouldSendToUser(user *models.User, data types.VWAPSignalData) bool {
+	return user.NotifyVWAP && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatSignalMessage форматирует уведомление о сигнале VWAP (Markdown)
+func formatSignalMessage(data types.VWAPSignalData) string {
+	var sb strings.Builder
+
+	switch data.Kind {
+	case types.VWAPDeviationUp:
+		sb.WriteString(fmt.Sprintf("📏🔺 *%s* — выше VWAP на %.1fσ\n", data.Symbol, data.Sigmas))
+	case types.VWAPDeviationDown:
+		sb.WriteString(fmt.Sprintf("📏🔻 *%s* — ниже VWAP на %.1fσ\n", data.Symbol, -data.Sigmas))
+	case types.VWAPReclaim:
+		sb.WriteString(fmt.Sprintf("📏🟢 *%s* — цена вернулась выше VWAP\n", data.Symbol))
+	case types.VWAPLoss:
+		sb.WriteString(fmt.Sprintf("📏🔴 *%s* — цена ушла под VWAP\n", data.Symbol))
+	default:
+		sb.WriteString(fmt.Sprintf("📏 *%s* — сигнал VWAP\n", data.Symbol))
+	}
+
+	upper, lower := data.VWAP+2*data.StdDev, data.VWAP-2*data.StdDev
+	sb.WriteString(fmt.Sprintf("💰 Цена %.6g • VWAP %.6g (%+.2f%%, %+.1fσ)\n",
+		data.Price, data.VWAP, data.DistancePct, data.Sigmas))
+	sb.WriteString(fmt.Sprintf("📐 ±2σ: %.6g – %.6g\n", lower, upper))
+	sb.WriteString(fmt.Sprintf("🕐 Сессия с %s UTC • %s", data.SessionStart.UTC().Format("15:04"), data.Timestamp.Format("15:04:05")))
+	return sb.String()
+}
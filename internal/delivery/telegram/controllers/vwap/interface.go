@@ -0,0 +1,16 @@
+// internal/delivery/telegram/controllers/vwap/interface.go
+package vwap
+
+import "crypto-exchange-screener-bot/internal/types"
+
+// Controller интерфейс доставки сигналов VWAP
+type Controller interface {
+	// HandleEvent обрабатывает событие от EventBus
+	HandleEvent(event types.Event) error
+
+	// GetName возвращает имя контроллера
+	GetName() string
+
+	// GetSubscribedEvents возвращает типы событий для подписки
+	GetSubscribedEvents() []types.EventType
+}
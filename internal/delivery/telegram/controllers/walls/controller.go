@@ -0,0 +1,63 @@
+// internal/delivery/telegram/controllers/walls/controller.go
+package walls
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/formatters"
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/internal/types"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewController создает контроллер, рассылающий алерты о снятии стен подписанным пользователям
+func NewController(userService *users.Service, messageSender message_sender.MessageSender, deliveries *broadcast.Deliveries) Controller {
+	return broadcast.NewController(broadcast.New(userService, broadcast.Telegram(messageSender), deliveries),
+		broadcast.Spec[types.WallPullData]{
+			Name:   "walls_controller",
+			Event:  types.EventWallPulled,
+			Filter: shouldSendToUser,
+			Format: formatPullMessage,
+			Describe: func(data types.WallPullData) string {
+				return fmt.Sprintf("%s %s $%.0f", data.Symbol, data.Side, data.SizeUSD)
+			},
+			Delivery: broadcast.WallPullDelivery,
+		})
+}
+
+// shouldSendToUser проверяет подписку на снятие стен и фильтры символов
+func shouldSendToUser(user *models.User, data types.WallPullData) bool {
+	return user.NotifyWallPulls && broadcast.TracksSymbol(user, data.Symbol)
+}
+
+// formatPullMessage форматирует уведомление о снятии стены (Markdown)
+func formatPullMessage(data types.WallPullData) string {
+	var sb strings.Builder
+
+	size := formatters.NewNumberFormatter().FormatDollarValue(data.SizeUSD)
+	if data.Side == "ask" {
+		sb.WriteString(fmt.Sprintf("🧱🟢 *%s* — снято сопротивление $%s\n", data.Symbol, size))
+	} else {
+		sb.WriteString(fmt.Sprintf("🧱🔴 *%s* — снята поддержка $%s\n", data.Symbol, size))
+	}
+
+	sb.WriteString(fmt.Sprintf("💰 Стена %.6g • цена %.6g (%.2f%% до стены)\n", data.Price, data.Mid, data.DistancePct))
+	sb.WriteString(fmt.Sprintf("⏳ Простояла %s • уверенность %.0f%%\n", formatLifetime(data.Lifetime), data.Confidence))
+	sb.WriteString(fmt.Sprintf("🕐 %s", data.Timestamp.Format("15:04:05")))
+	return sb.String()
+}
+
+// formatLifetime форматирует время жизни стены: 45с, 12м, 2ч 05м
+func formatLifetime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%dс", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dм", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dч %02dм", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,16 @@
+// internal/delivery/telegram/controllers/walls/interface.go
+package walls
+
+import "crypto-exchange-screener-bot/internal/types"
+
+// Controller интерфейс доставки алертов о снятии стен стакана
+type Controller interface {
+	// HandleEvent обрабатывает событие от EventBus
+	HandleEvent(event types.Event) error
+
+	// GetName возвращает имя контроллера
+	GetName() string
+
+	// GetSubscribedEvents возвращает типы событий для подписки
+	GetSubscribedEvents() []types.EventType
+}
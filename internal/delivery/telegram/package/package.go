@@ -5,10 +5,21 @@ import (
 	"fmt"
 	"sync"
 
+	"crypto-exchange-screener-bot/internal/core/domain/alerts"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/strength"
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/internal/core/domain/digest"
+	"crypto-exchange-screener-bot/internal/core/domain/export"
+	"crypto-exchange-screener-bot/internal/core/domain/journal"
+	"crypto-exchange-screener-bot/internal/core/domain/paper"
 	"crypto-exchange-screener-bot/internal/core/domain/payment"
+	"crypto-exchange-screener-bot/internal/core/domain/rules"
+	"crypto-exchange-screener-bot/internal/core/domain/signals/detectors/common"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
 	core_factory "crypto-exchange-screener-bot/internal/core/package"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
 	components_factory "crypto-exchange-screener-bot/internal/delivery/telegram/components/factory"
@@ -40,6 +51,7 @@ type TelegramDeliveryPackage struct {
 	userService         *users.Service
 	subscriptionService *subscription.Service
 	paymentService      *payment.PaymentService // ⭐ Новый сервис платежей
+	rulesService        *rules.Service          // пользовательские правила сигналов
 
 	// Сервис торговых сессий (создаётся один раз, разделяется между ботом и CounterService)
 	tradingSessionService trading_session.Service
@@ -60,6 +72,36 @@ type TelegramDeliveryPackage struct {
 	// Watchlist service (опционально)
 	watchlistService watchlist_service.Service
 
+	// Сервис ценовых алертов (опционально)
+	alertService *alerts.Service
+
+	// Сведения об анализаторах для /analyzers (опционально)
+	analyzerInfos func() []common.AnalyzerInfo
+
+	// Рейтинг относительной силы для /top (опционально)
+	strengthService *strength.Service
+
+	// VWAP из CandleEngine для /vwap (опционально, ленивый)
+	vwapTracker func() *vwap.Tracker
+
+	// Журнал сигналов: запись доставок и /history (опционально)
+	signalJournal *journal.Service
+
+	// Paper trading: отработка доставленных сигналов и /paper (опционально)
+	paperTrading *paper.Service
+
+	// Общий хук доставки уведомлений: журнал + paper trading (nil, если оба отключены)
+	deliveries *broadcast.Deliveries
+
+	// Выгрузки данных для /export (опционально)
+	exportService *export.Service
+
+	// Дайджест рынка для /digest (опционально)
+	marketDigest *digest.Service
+
+	// Классификатор режима рынка для /regime (опционально, ленивый)
+	marketRegime func() *regime.Classifier
+
 	// Telegram бот и транспорт
 	bot         *bot.TelegramBot
 	transport   transport.TelegramTransport
@@ -72,8 +114,17 @@ type TelegramDeliveryPackageDependencies struct {
 	Config           *config.Config
 	CoreFactory      *core_factory.CoreServiceFactory
 	Exchange         string
-	RedisClient      *goredis.Client           // опционально, для Redis-очереди
-	WatchlistService watchlist_service.Service // опционально, для вотчлиста
+	RedisClient      *goredis.Client              // опционально, для Redis-очереди
+	WatchlistService watchlist_service.Service    // опционально, для вотчлиста
+	AlertService     *alerts.Service              // опционально, для ценовых алертов
+	AnalyzerInfos    func() []common.AnalyzerInfo // опционально, для админ-команды /analyzers
+	StrengthService  *strength.Service            // опционально, для /top
+	VWAPTracker      func() *vwap.Tracker         // опционально, для /vwap
+	SignalJournal    *journal.Service             // опционально, для истории сигналов
+	PaperTrading     *paper.Service               // опционально, для paper trading
+	ExportService    *export.Service              // опционально, для /export
+	MarketDigest     *digest.Service              // опционально, для /digest
+	MarketRegime     func() *regime.Classifier    // опционально, для /regime
 }
 
 // NewTelegramDeliveryPackage создает новый пакет доставки Telegram
@@ -89,6 +140,15 @@ func NewTelegramDeliveryPackage(deps TelegramDeliveryPackageDependencies) *Teleg
 		coreFactory:      deps.CoreFactory,
 		redisClient:      deps.RedisClient,
 		watchlistService: deps.WatchlistService,
+		alertService:     deps.AlertService,
+		analyzerInfos:    deps.AnalyzerInfos,
+		strengthService:  deps.StrengthService,
+		vwapTracker:      deps.VWAPTracker,
+		signalJournal:    deps.SignalJournal,
+		paperTrading:     deps.PaperTrading,
+		exportService:    deps.ExportService,
+		marketDigest:     deps.MarketDigest,
+		marketRegime:     deps.MarketRegime,
 		services:         make(map[string]interface{}),
 		controllers:      make(map[string]types.EventSubscriber),
 	}
@@ -242,6 +302,20 @@ func (p *TelegramDeliveryPackage) createServiceFactory() error {
 		logger.Info("ℹ️  SignalPublisher: Redis недоступен, публикация сигналов отключена")
 	}
 
+	// Не передаем nil *journal.Service в интерфейс: проверка на nil в сервисе должна срабатывать
+	var deliveryRecorder broadcast.DeliveryRecorder
+	var signalFeedback counter.SignalFeedback
+	if p.signalJournal != nil {
+		deliveryRecorder = p.signalJournal
+		signalFeedback = p.signalJournal
+	}
+	var signalFollower broadcast.SignalFollower
+	if p.paperTrading != nil {
+		signalFollower = p.paperTrading
+	}
+	// Общий хук доставки: счетчик и все контроллеры рассылки пишут через него
+	p.deliveries = broadcast.NewDeliveries(deliveryRecorder, signalFollower)
+
 	p.serviceFactory = services_factory.NewServiceFactory(
 		services_factory.ServiceDependencies{
 			UserService:           userService,
@@ -252,6 +326,8 @@ func (p *TelegramDeliveryPackage) createServiceFactory() error {
 			FormatterProvider:     p.components.FormatterProvider,
 			TradingSessionService: p.tradingSessionService,
 			SignalPublisher:       signalPublisher,
+			Deliveries:            p.deliveries,
+			SignalFeedback:        signalFeedback,
 		},
 	)
 
@@ -306,6 +382,30 @@ func (p *TelegramDeliveryPackage) getSubscriptionService() (*subscription.Servic
 	return p.subscriptionService, nil
 }
 
+// getRulesService создает сервис пользовательских правил с лимитами тарифа
+func (p *TelegramDeliveryPackage) getRulesService() (*rules.Service, error) {
+	if p.rulesService != nil {
+		return p.rulesService, nil
+	}
+
+	if p.coreFactory == nil {
+		return nil, fmt.Errorf("CoreServiceFactory не установлена")
+	}
+
+	var limits subscription.LimitChecker
+	if subscriptionService, err := p.getSubscriptionService(); err == nil && subscriptionService != nil {
+		limits = subscriptionService
+	}
+
+	rulesService, err := p.coreFactory.CreateRulesService(limits)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать RulesService: %w", err)
+	}
+
+	p.rulesService = rulesService
+	return p.rulesService, nil
+}
+
 // ⭐ НОВЫЙ МЕТОД: getPaymentService получает PaymentService из CoreFactory
 func (p *TelegramDeliveryPackage) getPaymentService() (*payment.PaymentService, error) {
 	if p.paymentService != nil {
@@ -370,6 +470,9 @@ func (p *TelegramDeliveryPackage) createControllerFactory() error {
 	p.controllerFactory = controllers_factory.NewControllerFactory(
 		controllers_factory.ControllerDependencies{
 			CounterService: counterService,
+			UserService:    p.userService,
+			MessageSender:  p.components.MessageSender,
+			Deliveries:     p.deliveries,
 		},
 	)
 
@@ -418,6 +521,22 @@ func (p *TelegramDeliveryPackage) createBotAndTransport() error {
 	deps := &bot.Dependencies{
 		ServiceFactory:   p.serviceFactory,
 		WatchlistService: p.watchlistService,
+		AlertService:     p.alertService,
+		AnalyzerInfos:    p.analyzerInfos,
+		StrengthService:  p.strengthService,
+		VWAPTracker:      p.vwapTracker,
+		SignalJournal:    p.signalJournal,
+		PaperTrading:     p.paperTrading,
+		ExportService:    p.exportService,
+		MarketDigest:     p.marketDigest,
+		MarketRegime:     p.marketRegime,
+	}
+
+	// Сервис правил опционален: без него команда /rules не регистрируется
+	if rulesService, err := p.getRulesService(); err == nil {
+		deps.RulesService = rulesService
+	} else {
+		logger.Warn("⚠️ RulesService не доступен: %v", err)
 	}
 
 	// Создаем бота
@@ -681,6 +800,7 @@ func (p *TelegramDeliveryPackage) UpdateCoreFactory(newFactory *core_factory.Cor
 	// Сбрасываем созданные сервисы ядра, чтобы пересоздать с новой фабрикой
 	p.userService = nil
 	p.subscriptionService = nil
+	p.rulesService = nil
 	p.paymentService = nil
 
 	logger.Info("✅ Фабрика ядра обновлена")
@@ -729,6 +849,7 @@ func (p *TelegramDeliveryPackage) Reset() {
 	p.initialized = false
 	p.userService = nil
 	p.subscriptionService = nil
+	p.rulesService = nil
 	p.paymentService = nil
 
 	logger.Info("🔄 TelegramDeliveryPackage сброшен")
@@ -15,11 +15,16 @@ const (
 // MessageTTL — сигнал старше этого времени не имеет смысла отправлять
 const MessageTTL = 5 * time.Minute
 
+// receiptTTL — сколько Redis хранит message_id отправленного сообщения для ожидающего отправителя
+const receiptTTL = time.Minute
+
 // QueuedMessage сообщение в очереди
 type QueuedMessage struct {
 	ChatID     int64       `json:"chat_id"`
 	Text       string      `json:"text"`
 	Keyboard   interface{} `json:"keyboard,omitempty"`
+	ReplyToID  int64       `json:"reply_to_id,omitempty"`
+	ReceiptKey string      `json:"receipt_key,omitempty"` // куда воркер кладет message_id после отправки
 	Priority   Priority    `json:"priority"`
 	Attempts   int         `json:"attempts"`
 	CreatedAt  time.Time   `json:"created_at"`
@@ -5,10 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
+const receiptKeyFmt = "tg:receipt:%d:%d"
+
 // Producer помещает сообщения в Redis очередь
 type Producer struct {
 	client *redis.Client
@@ -27,3 +31,30 @@ func (p *Producer) Enqueue(ctx context.Context, msg QueuedMessage) error {
 	}
 	return p.client.LPush(ctx, string(msg.Priority), data).Err()
 }
+
+// EnqueueAndWait ставит сообщение в очередь и ждет, пока воркер его отправит.
+// Возвращает message_id отправленного сообщения (0 в тестовом режиме).
+func (p *Producer) EnqueueAndWait(ctx context.Context, msg QueuedMessage, timeout time.Duration) (int64, error) {
+	msg.ReceiptKey = fmt.Sprintf(receiptKeyFmt, msg.ChatID, time.Now().UnixNano())
+	if err := p.Enqueue(ctx, msg); err != nil {
+		return 0, err
+	}
+
+	result, err := p.client.BLPop(ctx, timeout, msg.ReceiptKey).Result()
+	if err == redis.Nil {
+		return 0, fmt.Errorf("queue: сообщение не отправлено за %v", timeout)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("queue: ожидание отправки: %w", err)
+	}
+
+	// result[0] — ключ, result[1] — message_id; отрицательный — отправка не удалась
+	messageID, err := strconv.ParseInt(result[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("queue: неверный message_id %q", result[1])
+	}
+	if messageID < 0 {
+		return 0, fmt.Errorf("queue: сообщение не отправлено")
+	}
+	return messageID, nil
+}
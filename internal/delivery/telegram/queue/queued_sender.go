@@ -8,8 +8,11 @@ import (
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
 )
 
+// replyWaitTimeout — сколько SendReplyMessage ждет отправки сообщения, начинающего ветку
+const replyWaitTimeout = 30 * time.Second
+
 // QueuedMessageSender реализует MessageSender:
-//   - SendTextMessage / SendMessageWithKeyboard / SendCounterMessage → Redis очередь
+//   - SendTextMessage / SendMessageWithKeyboard / SendCounterMessage / SendReplyMessage → Redis очередь
 //   - SendMenuMessage / EditMessageText / DeleteMessage / AnswerCallback → прямая отправка
 //
 // Это позволяет держать интерактивные ответы (меню, callbacks) мгновенными,
@@ -70,6 +73,24 @@ func (q *QueuedMessageSender) SendMenuMessageWithID(chatID int64, text string, k
 	return q.direct.SendMenuMessageWithID(chatID, text, keyboard)
 }
 
+// SendReplyMessage → очередь high. Ответ в ветку ставится в очередь без ожидания
+// (message_id 0); для первого сообщения ветки (replyToID == 0) ждем отправки,
+// чтобы вернуть его message_id для следующих ответов.
+func (q *QueuedMessageSender) SendReplyMessage(chatID, replyToID int64, text string, keyboard interface{}) (int64, error) {
+	msg := QueuedMessage{
+		ChatID:    chatID,
+		Text:      text,
+		Keyboard:  keyboard,
+		ReplyToID: replyToID,
+		Priority:  PriorityHigh,
+		CreatedAt: time.Now(),
+	}
+	if replyToID > 0 {
+		return 0, q.producer.Enqueue(context.Background(), msg)
+	}
+	return q.producer.EnqueueAndWait(context.Background(), msg, replyWaitTimeout)
+}
+
 // EditMessageText → прямая отправка
 func (q *QueuedMessageSender) EditMessageText(chatID, messageID int64, text string, keyboard interface{}) error {
 	return q.direct.EditMessageText(chatID, messageID, text, keyboard)
@@ -137,6 +137,7 @@ func (w *Worker) run() {
 		if time.Since(msg.CreatedAt) > MessageTTL {
 			logger.Warn("⚠️ Queue worker: сообщение устарело (chatID=%d, возраст=%v), пропуск",
 				msg.ChatID, time.Since(msg.CreatedAt).Round(time.Second))
+			w.deliverReceipt(msg, -1)
 			continue
 		}
 
@@ -162,24 +163,29 @@ func (w *Worker) run() {
 		}
 
 		// Отправляем
-		if err := w.sendMessage(msg); err != nil {
-			if isRateLimitError(err) {
-				retryAfter := parseRetryAfter(err)
-				msg.Attempts++
-				if msg.Attempts < maxAttempts {
-					go func(m QueuedMessage, delay time.Duration) {
-						time.Sleep(delay)
-						if err := w.enqueuePriority(m, PriorityHigh); err != nil {
-							logger.Error("❌ Queue worker: ошибка retry постановки: %v", err)
-						}
-					}(msg, retryAfter)
-				} else {
-					logger.Warn("⚠️ Queue worker: дроп сообщения после %d попыток (chatID=%d)",
-						msg.Attempts, msg.ChatID)
-				}
+		messageID, err := w.sendMessage(msg)
+		if err == nil {
+			w.deliverReceipt(msg, messageID)
+			continue
+		}
+		if isRateLimitError(err) {
+			retryAfter := parseRetryAfter(err)
+			msg.Attempts++
+			if msg.Attempts < maxAttempts {
+				go func(m QueuedMessage, delay time.Duration) {
+					time.Sleep(delay)
+					if err := w.enqueuePriority(m, PriorityHigh); err != nil {
+						logger.Error("❌ Queue worker: ошибка retry постановки: %v", err)
+					}
+				}(msg, retryAfter)
 			} else {
-				logger.Error("❌ Queue worker: ошибка отправки (chatID=%d): %v", msg.ChatID, err)
+				logger.Warn("⚠️ Queue worker: дроп сообщения после %d попыток (chatID=%d)",
+					msg.Attempts, msg.ChatID)
+				w.deliverReceipt(msg, -1)
 			}
+		} else {
+			logger.Error("❌ Queue worker: ошибка отправки (chatID=%d): %v", msg.ChatID, err)
+			w.deliverReceipt(msg, -1)
 		}
 	}
 }
@@ -224,10 +230,23 @@ func (w *Worker) enqueuePriority(msg QueuedMessage, p Priority) error {
 	return w.redis.LPush(context.Background(), string(p), data).Err()
 }
 
-// sendMessage отправляет сообщение в Telegram API
-func (w *Worker) sendMessage(msg QueuedMessage) error {
+// deliverReceipt сообщает ожидающему отправителю message_id (-1 — отправка не удалась)
+func (w *Worker) deliverReceipt(msg QueuedMessage, messageID int64) {
+	if msg.ReceiptKey == "" {
+		return
+	}
+	pipe := w.redis.TxPipeline()
+	pipe.LPush(context.Background(), msg.ReceiptKey, messageID)
+	pipe.Expire(context.Background(), msg.ReceiptKey, receiptTTL)
+	if _, err := pipe.Exec(context.Background()); err != nil {
+		logger.Error("❌ Queue worker: ошибка записи message_id (chatID=%d): %v", msg.ChatID, err)
+	}
+}
+
+// sendMessage отправляет сообщение в Telegram API и возвращает его message_id
+func (w *Worker) sendMessage(msg QueuedMessage) (int64, error) {
 	if !w.enabled {
-		return nil
+		return 0, nil
 	}
 
 	if w.testMode {
@@ -236,7 +255,7 @@ func (w *Worker) sendMessage(msg QueuedMessage) error {
 			preview = preview[:60] + "..."
 		}
 		logger.Info("[TEST] Queue send to %d: %s", msg.ChatID, preview)
-		return nil
+		return 0, nil
 	}
 
 	request := map[string]interface{}{
@@ -244,6 +263,10 @@ func (w *Worker) sendMessage(msg QueuedMessage) error {
 		"text":       msg.Text,
 		"parse_mode": "Markdown",
 	}
+	if msg.ReplyToID > 0 {
+		request["reply_to_message_id"] = msg.ReplyToID
+		request["allow_sending_without_reply"] = true
+	}
 	if msg.Keyboard != nil {
 		request["reply_markup"] = msg.Keyboard
 	}
@@ -251,11 +274,11 @@ func (w *Worker) sendMessage(msg QueuedMessage) error {
 	return w.callTelegramAPI("sendMessage", request)
 }
 
-// callTelegramAPI выполняет HTTP запрос к Telegram Bot API
-func (w *Worker) callTelegramAPI(method string, payload map[string]interface{}) error {
+// callTelegramAPI выполняет HTTP запрос к Telegram Bot API и возвращает message_id ответа
+func (w *Worker) callTelegramAPI(method string, payload map[string]interface{}) (int64, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("marshal: %w", err)
+		return 0, fmt.Errorf("marshal: %w", err)
 	}
 
 	resp, err := w.httpClient.Post(
@@ -264,13 +287,13 @@ func (w *Worker) callTelegramAPI(method string, payload map[string]interface{})
 		bytes.NewBuffer(jsonData),
 	)
 	if err != nil {
-		return fmt.Errorf("http post: %w", err)
+		return 0, fmt.Errorf("http post: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read body: %w", err)
+		return 0, fmt.Errorf("read body: %w", err)
 	}
 
 	var tgResp struct {
@@ -280,10 +303,13 @@ func (w *Worker) callTelegramAPI(method string, payload map[string]interface{})
 		Parameters  struct {
 			RetryAfter int `json:"retry_after"`
 		} `json:"parameters,omitempty"`
+		Result struct {
+			MessageID int64 `json:"message_id"`
+		} `json:"result"`
 	}
 
 	if err := json.Unmarshal(body, &tgResp); err != nil {
-		return fmt.Errorf("parse response: %w", err)
+		return 0, fmt.Errorf("parse response: %w", err)
 	}
 
 	if !tgResp.OK {
@@ -292,10 +318,10 @@ func (w *Worker) callTelegramAPI(method string, payload map[string]interface{})
 			if retryAfter == 0 {
 				retryAfter = 5 * time.Second
 			}
-			return &telegramRateLimitError{RetryAfter: retryAfter}
+			return 0, &telegramRateLimitError{RetryAfter: retryAfter}
 		}
-		return fmt.Errorf("telegram error %d: %s", tgResp.ErrorCode, tgResp.Description)
+		return 0, fmt.Errorf("telegram error %d: %s", tgResp.ErrorCode, tgResp.Description)
 	}
 
-	return nil
+	return tgResp.Result.MessageID, nil
 }
@@ -81,6 +81,7 @@ func (s *serviceImpl) calculateNextPeriodStart(timestamp time.Time, period strin
 // convertToFormatterData конвертирует сырые данные в форматтер данные
 func (s *serviceImpl) convertToFormatterData(rawData RawCounterData) formatters.CounterData {
 	return formatters.CounterData{
+		SignalID:           rawData.SignalID,
 		Symbol:             rawData.Symbol,
 		Direction:          rawData.Direction,
 		ChangePercent:      rawData.ChangePercent,
@@ -116,6 +117,37 @@ func (s *serviceImpl) convertToFormatterData(rawData RawCounterData) formatters.
 		// Зоны S/R
 		SRSupport:    buildSRZoneData(rawData.SRSupportPrice, rawData.SRSupportStrength, rawData.SRSupportDistPct, rawData.SRSupportHasWall, rawData.SRSupportWallUSD),
 		SRResistance: buildSRZoneData(rawData.SRResistancePrice, rawData.SRResistanceStrength, rawData.SRResistanceDistPct, rawData.SRResistanceHasWall, rawData.SRResistanceWallUSD),
+
+		// Согласованность со старшими таймфреймами
+		ConfluenceScore: rawData.ConfluenceScore,
+		HasConfluence:   rawData.HasConfluence,
+
+		// Рыночный контекст
+		HasMarketContext:    rawData.HasMarketContext,
+		MarketBenchmark:     rawData.MarketBenchmark,
+		MarketBenchmarkMove: rawData.MarketBenchmarkMove,
+		MarketBeta:          rawData.MarketBeta,
+		IdiosyncraticChange: rawData.IdiosyncraticChange,
+
+		// Ликвидность стакана
+		HasLiquidity:          rawData.HasLiquidity,
+		LiquidityCostUp1Pct:   rawData.LiquidityCostUp1Pct,
+		LiquidityCostDown1Pct: rawData.LiquidityCostDown1Pct,
+		LiquidityImbalance:    rawData.LiquidityImbalance,
+		LiquidityThinBook:     rawData.LiquidityThinBook,
+
+		// Эпизод памп/дамп
+		EpisodeID:         rawData.EpisodeID,
+		EpisodeStage:      rawData.EpisodeStage,
+		EpisodePeakPrice:  rawData.EpisodePeakPrice,
+		EpisodeGainPct:    rawData.EpisodeGainPct,
+		EpisodeRetracePct: rawData.EpisodeRetracePct,
+
+		// VWAP дневной сессии
+		HasVWAP:         rawData.HasVWAP,
+		VWAPSession:     rawData.VWAPSession,
+		VWAPDistancePct: rawData.VWAPDistancePct,
+		VWAPSigmas:      rawData.VWAPSigmas,
 	}
 }
 
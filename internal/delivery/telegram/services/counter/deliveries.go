@@ -0,0 +1,22 @@
+// internal/delivery/telegram/services/counter/deliveries.go
+package counter
+
+import (
+	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/formatters"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	periodPkg "crypto-exchange-screener-bot/pkg/period"
+)
+
+// recordDelivery передаёт отправленную карточку в общий хук доставки:
+// история сигналов пользователя и paper-портфель
+func (s *serviceImpl) recordDelivery(userID int, data formatters.CounterData, card string) {
+	periodMinutes, _ := periodPkg.StringToMinutes(data.Period)
+	s.deliveries.Delivered(userID, "telegram", &models.SignalDelivery{
+		SignalID:      data.SignalID,
+		Symbol:        data.Symbol,
+		Direction:     data.Direction,
+		PeriodMinutes: periodMinutes,
+		ChangePercent: data.ChangePercent,
+		Price:         data.CurrentPrice,
+	}, card)
+}
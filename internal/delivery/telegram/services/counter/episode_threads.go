@@ -0,0 +1,72 @@
+// internal/delivery/telegram/services/counter/episode_threads.go
+package counter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// episodeThreadTTL — сколько помним первое сообщение эпизода (с запасом к TTL эпизода)
+const episodeThreadTTL = 24 * time.Hour
+
+type episodeThread struct {
+	messageID int64
+	updatedAt time.Time
+}
+
+// EpisodeThreads хранит message_id первого сообщения эпизода в каждом чате,
+// чтобы продолжения эпизода отправлялись ответом на него
+type EpisodeThreads struct {
+	mu      sync.Mutex
+	threads map[string]episodeThread // ключ: "episodeID:chatID"
+}
+
+// NewEpisodeThreads создает хранилище веток эпизодов
+func NewEpisodeThreads() *EpisodeThreads {
+	return &EpisodeThreads{threads: make(map[string]episodeThread)}
+}
+
+// Get возвращает message_id первого сообщения эпизода в чате
+func (t *EpisodeThreads) Get(episodeID string, chatID int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := t.key(episodeID, chatID)
+	thread, ok := t.threads[key]
+	if !ok {
+		return 0, false
+	}
+	if time.Since(thread.updatedAt) > episodeThreadTTL {
+		delete(t.threads, key)
+		return 0, false
+	}
+	thread.updatedAt = time.Now()
+	t.threads[key] = thread
+	return thread.messageID, true
+}
+
+// Set запоминает первое сообщение эпизода в чате
+func (t *EpisodeThreads) Set(episodeID string, chatID, messageID int64) {
+	if episodeID == "" || messageID == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.threads[t.key(episodeID, chatID)] = episodeThread{messageID: messageID, updatedAt: time.Now()}
+}
+
+// Cleanup удаляет устаревшие ветки
+func (t *EpisodeThreads) Cleanup() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, thread := range t.threads {
+		if time.Since(thread.updatedAt) > episodeThreadTTL {
+			delete(t.threads, key)
+		}
+	}
+}
+
+func (t *EpisodeThreads) key(episodeID string, chatID int64) string {
+	return fmt.Sprintf("%s:%d", episodeID, chatID)
+}
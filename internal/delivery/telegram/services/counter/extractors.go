@@ -9,6 +9,7 @@ import (
 // extractRawDataFromParams извлекает сырые данные счетчика из CounterParams
 func (s *serviceImpl) extractRawDataFromParams(params CounterParams) (RawCounterData, error) {
 	data := RawCounterData{
+		SignalID:              params.SignalID,
 		Symbol:                params.Symbol,
 		Direction:             params.Direction,
 		ChangePercent:         params.ChangePercent,
@@ -54,6 +55,40 @@ func (s *serviceImpl) extractRawDataFromParams(params CounterParams) (RawCounter
 	data.SRResistanceHasWall = params.SRResistanceHasWall
 	data.SRResistanceWallUSD = params.SRResistanceWallUSD
 
+	// Согласованность со старшими таймфреймами
+	data.ConfluenceScore = params.ConfluenceScore
+	data.HasConfluence = params.HasConfluence
+
+	// Рыночный контекст
+	data.HasMarketContext = params.HasMarketContext
+	data.MarketBenchmark = params.MarketBenchmark
+	data.MarketBenchmarkMove = params.MarketBenchmarkMove
+	data.MarketBeta = params.MarketBeta
+	data.IdiosyncraticChange = params.IdiosyncraticChange
+	data.MarketExplained = params.MarketExplained
+	data.MarketRegime = params.MarketRegime
+
+	// Ликвидность стакана
+	data.HasLiquidity = params.HasLiquidity
+	data.LiquidityCostUp1Pct = params.LiquidityCostUp1Pct
+	data.LiquidityCostDown1Pct = params.LiquidityCostDown1Pct
+	data.LiquidityImbalance = params.LiquidityImbalance
+	data.LiquidityThinBook = params.LiquidityThinBook
+
+	// Эпизод памп/дамп
+	data.EpisodeID = params.EpisodeID
+	data.EpisodeStage = params.EpisodeStage
+	data.EpisodeStageChanged = params.EpisodeStageChanged
+	data.EpisodePeakPrice = params.EpisodePeakPrice
+	data.EpisodeGainPct = params.EpisodeGainPct
+	data.EpisodeRetracePct = params.EpisodeRetracePct
+
+	// VWAP дневной сессии
+	data.HasVWAP = params.HasVWAP
+	data.VWAPSession = params.VWAPSession
+	data.VWAPDistancePct = params.VWAPDistancePct
+	data.VWAPSigmas = params.VWAPSigmas
+
 	// Логируем полученные данные прогресса
 	logger.Debug("📊 Service: Использованы данные прогресса из параметров: заполнено %d из %d (%.0f%%)",
 		data.FilledSlots, data.TotalSlots, data.ProgressPercentage)
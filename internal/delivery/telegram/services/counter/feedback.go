@@ -0,0 +1,43 @@
+// internal/delivery/telegram/services/counter/feedback.go
+package counter
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// SignalFeedback кнопки оценки сигнала и персональные пороги по голосам
+// (реализуется journal.Service)
+type SignalFeedback interface {
+	// FeedbackEnabled сообщает, нужно ли показывать кнопки оценки
+	FeedbackEnabled() bool
+	// NoiseFactor возвращает множитель порогов пользователя для символа (1 — без изменений)
+	NoiseFactor(userID int, symbol string) float64
+}
+
+// feedbackEnabled сообщает, добавлять ли кнопки оценки к карточке сигнала
+func (s *serviceImpl) feedbackEnabled() bool {
+	return s.signalFeedback != nil && s.signalFeedback.FeedbackEnabled()
+}
+
+// checkNoiseThreshold повышает порог пользователя для символов, которые он отмечает «шумом»
+func (s *serviceImpl) checkNoiseThreshold(user *models.User, signalType string, changePercent float64, data RawCounterData) bool {
+	if s.signalFeedback == nil {
+		return true
+	}
+	factor := s.signalFeedback.NoiseFactor(user.ID, data.Symbol)
+	if factor <= 1 {
+		return true
+	}
+
+	threshold := user.MinGrowthThreshold
+	if signalType == SignalTypeFall {
+		threshold = user.MinFallThreshold
+	}
+	if changePercent >= threshold*factor {
+		return true
+	}
+	logger.Debug("🔇 Пропуск user=%d: %s %.2f%% < порога %.2f%% (×%.2f за отметки «шум»)",
+		user.ID, data.Symbol, changePercent, threshold*factor, factor)
+	return false
+}
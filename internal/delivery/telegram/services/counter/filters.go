@@ -86,6 +86,16 @@ func (s *serviceImpl) shouldSendToUser(user *models.User, data RawCounterData) b
 		return false
 	}
 
+	// Персональный порог по символу, который пользователь отмечает «шумом»
+	if !s.checkNoiseThreshold(user, signalType, changePercentForCheck, data) {
+		return false
+	}
+
+	// Персональный множитель порога для текущего режима рынка
+	if !s.checkRegimeThreshold(user, signalType, changePercentForCheck, data) {
+		return false
+	}
+
 	// Применяем дополнительные фильтры пользователя
 	if !s.applyUserFilters(user, data) {
 		return false
@@ -244,6 +254,20 @@ func (s *serviceImpl) applyUserFilters(user *models.User, data RawCounterData) b
 		return false
 	}
 
+	// Проверяем согласованность со старшими таймфреймами (если оценка есть в сигнале)
+	if user.MinConfluenceScore > 0 && data.HasConfluence && data.ConfluenceScore < user.MinConfluenceScore {
+		logger.Debug("⚠️ User %d (%s) пропущен: согласованность ТФ %.0f < %.0f",
+			user.ID, user.Username, data.ConfluenceScore, user.MinConfluenceScore)
+		return false
+	}
+
+	// Скрываем движения, полностью объяснённые движением BTC/ETH с учётом беты
+	if user.SuppressMarketMoves && data.MarketExplained {
+		logger.Debug("⚠️ User %d (%s) пропущен: движение %s объясняется рынком (%s, собственное %.2f%%)",
+			user.ID, user.Username, data.Symbol, data.MarketBenchmark, data.IdiosyncraticChange)
+		return false
+	}
+
 	// Проверяем предпочтительные периоды
 	if len(user.PreferredPeriods) > 0 {
 		periodInt, err := period.StringToMinutes(data.Period)
@@ -12,6 +12,7 @@ type Service interface {
 // CounterParams параметры для Exec
 type CounterParams struct {
 	// Базовые поля
+	SignalID      string // ID сигнала в журнале (пусто — сигнал без ID)
 	Symbol        string
 	Direction     string
 	ChangePercent float64
@@ -48,6 +49,42 @@ type CounterParams struct {
 	SRResistanceDistPct  float64
 	SRResistanceHasWall  bool
 	SRResistanceWallUSD  float64
+
+	// Согласованность со старшими таймфреймами (0..100)
+	ConfluenceScore float64
+	HasConfluence   bool
+
+	// Рыночный контекст (бета к BTC/ETH)
+	HasMarketContext    bool
+	MarketBenchmark     string
+	MarketBenchmarkMove float64 // изменение ориентира, %
+	MarketBeta          float64
+	IdiosyncraticChange float64 // собственное изменение без учёта рынка, %
+	MarketExplained     bool    // движение объясняется рынком
+
+	// Режим рынка на момент сигнала (trend_up/trend_down/range/panic; "" — неизвестен)
+	MarketRegime string
+
+	// Ликвидность стакана (±1% от mid)
+	HasLiquidity          bool
+	LiquidityCostUp1Pct   float64 // USD, чтобы сдвинуть цену на +1%
+	LiquidityCostDown1Pct float64 // USD, чтобы сдвинуть цену на -1%
+	LiquidityImbalance    float64 // -1..+1, + перевес покупателей
+	LiquidityThinBook     bool
+
+	// Эпизод памп/дамп (накопление → памп → распределение → дамп)
+	EpisodeID           string
+	EpisodeStage        string
+	EpisodeStageChanged bool
+	EpisodePeakPrice    float64
+	EpisodeGainPct      float64 // рост от начала эпизода до пика, %
+	EpisodeRetracePct   float64 // откат от пика, %
+
+	// VWAP дневной сессии (от 00:00 UTC)
+	HasVWAP         bool
+	VWAPSession     float64
+	VWAPDistancePct float64 // расстояние цены от VWAP, %
+	VWAPSigmas      float64 // отклонение от VWAP в σ
 }
 
 // CounterResult результат Exec
@@ -59,6 +96,7 @@ type CounterResult struct {
 
 // RawCounterData сырые данные счетчика
 type RawCounterData struct {
+	SignalID           string    `json:"signal_id"`
 	Symbol             string    `json:"symbol"`
 	Direction          string    `json:"direction"`
 	ChangePercent      float64   `json:"change"`
@@ -102,4 +140,40 @@ type RawCounterData struct {
 	SRResistanceDistPct  float64
 	SRResistanceHasWall  bool
 	SRResistanceWallUSD  float64
+
+	// Согласованность со старшими таймфреймами (0..100)
+	ConfluenceScore float64
+	HasConfluence   bool
+
+	// Рыночный контекст (бета к BTC/ETH)
+	HasMarketContext    bool
+	MarketBenchmark     string
+	MarketBenchmarkMove float64 // изменение ориентира, %
+	MarketBeta          float64
+	IdiosyncraticChange float64 // собственное изменение без учёта рынка, %
+	MarketExplained     bool    // движение объясняется рынком
+
+	// Режим рынка на момент сигнала (trend_up/trend_down/range/panic; "" — неизвестен)
+	MarketRegime string
+
+	// Ликвидность стакана (±1% от mid)
+	HasLiquidity          bool
+	LiquidityCostUp1Pct   float64 // USD, чтобы сдвинуть цену на +1%
+	LiquidityCostDown1Pct float64 // USD, чтобы сдвинуть цену на -1%
+	LiquidityImbalance    float64 // -1..+1, + перевес покупателей
+	LiquidityThinBook     bool
+
+	// Эпизод памп/дамп (накопление → памп → распределение → дамп)
+	EpisodeID           string
+	EpisodeStage        string
+	EpisodeStageChanged bool
+	EpisodePeakPrice    float64
+	EpisodeGainPct      float64 // рост от начала эпизода до пика, %
+	EpisodeRetracePct   float64 // откат от пика, %
+
+	// VWAP дневной сессии (от 00:00 UTC)
+	HasVWAP         bool
+	VWAPSession     float64
+	VWAPDistancePct float64 // расстояние цены от VWAP, %
+	VWAPSigmas      float64 // отклонение от VWAP в σ
 }
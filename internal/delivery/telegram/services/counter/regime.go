@@ -0,0 +1,28 @@
+// internal/delivery/telegram/services/counter/regime.go
+package counter
+
+import (
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// checkRegimeThreshold повышает порог пользователя в режимах рынка,
+// для которых он задал множитель (/regime)
+func (s *serviceImpl) checkRegimeThreshold(user *models.User, signalType string, changePercent float64, data RawCounterData) bool {
+	factor := regime.FactorFor(user.RegimeThresholds, data.MarketRegime)
+	if factor <= 1 {
+		return true
+	}
+
+	threshold := user.MinGrowthThreshold
+	if signalType == SignalTypeFall {
+		threshold = user.MinFallThreshold
+	}
+	if changePercent >= threshold*factor {
+		return true
+	}
+	logger.Debug("🧭 Пропуск user=%d: %s %.2f%% < порога %.2f%% (×%.2f в режиме %s)",
+		user.ID, data.Symbol, changePercent, threshold*factor, factor, data.MarketRegime)
+	return false
+}
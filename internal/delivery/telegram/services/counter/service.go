@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/buttons"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/constants"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/formatters"
@@ -28,7 +29,10 @@ type serviceImpl struct {
 	tradingSessionService trading_session.Service
 	notificationGuard     *SymbolNotificationGuard
 	guardMu               sync.RWMutex
-	signalPublisher       SignalPublisher // опционально, nil — публикация отключена
+	episodeThreads        *EpisodeThreads
+	signalPublisher       SignalPublisher       // опционально, nil — публикация отключена
+	deliveries            *broadcast.Deliveries // опционально, nil — доставки не записываются и не отрабатываются в paper-портфеле
+	signalFeedback        SignalFeedback        // опционально, nil — без кнопок оценки и персональных порогов
 }
 
 func NewService(
@@ -39,6 +43,8 @@ func NewService(
 	buttonBuilder *buttons.ButtonBuilder,
 	tradingSessionService trading_session.Service,
 	publisher SignalPublisher,
+	deliveries *broadcast.Deliveries,
+	signalFeedback SignalFeedback,
 ) Service {
 	return &serviceImpl{
 		userService:           userService,
@@ -48,7 +54,10 @@ func NewService(
 		buttonBuilder:         buttonBuilder,
 		tradingSessionService: tradingSessionService,
 		notificationGuard:     NewSymbolNotificationGuard(),
+		episodeThreads:        NewEpisodeThreads(),
 		signalPublisher:       publisher,
+		deliveries:            deliveries,
+		signalFeedback:        signalFeedback,
 	}
 }
 
@@ -147,14 +156,24 @@ func (s *serviceImpl) sendNotificationWithGuard(user *models.User, data formatte
 				sessionCb = constants.CallbackSessionStop
 			}
 		}
-		keyboard = s.buttonBuilder.CreateSignalKeyboard(data.Symbol, sessionText, sessionCb)
+		signalKeyboard := s.buttonBuilder.CreateSignalKeyboard(data.Symbol, sessionText, sessionCb)
+		if s.feedbackEnabled() && data.SignalID != "" {
+			signalKeyboard.InlineKeyboard = append(signalKeyboard.InlineKeyboard, s.buttonBuilder.CreateSignalFeedbackRow(data.SignalID))
+		}
+		keyboard = signalKeyboard
 	}
 
 	if s.messageSender != nil {
-		err := s.messageSender.SendTextMessage(chatID, formattedMessage, keyboard)
+		var err error
+		if data.EpisodeID != "" {
+			err = s.sendEpisodeMessage(chatID, data, formattedMessage, keyboard)
+		} else {
+			err = s.messageSender.SendTextMessage(chatID, formattedMessage, keyboard)
+		}
 		if err != nil {
 			return fmt.Errorf("ошибка отправки в Telegram: %w", err)
 		}
+		s.recordDelivery(user.ID, data, formattedMessage)
 
 		// Записываем в rate limiting
 		s.guardMu.Lock()
@@ -170,6 +189,22 @@ func (s *serviceImpl) sendNotificationWithGuard(user *models.User, data formatte
 	return fmt.Errorf("message sender not initialized")
 }
 
+// sendEpisodeMessage отправляет сигнал эпизода памп/дамп: первое сообщение — полной карточкой,
+// продолжения — коротким ответом на него со стадией, пиком и откатом
+func (s *serviceImpl) sendEpisodeMessage(chatID int64, data formatters.CounterData, card string, keyboard interface{}) error {
+	if replyTo, ok := s.episodeThreads.Get(data.EpisodeID, chatID); ok {
+		_, err := s.messageSender.SendReplyMessage(chatID, replyTo, s.formatter.FormatEpisodeUpdate(data), nil)
+		return err
+	}
+
+	messageID, err := s.messageSender.SendReplyMessage(chatID, 0, card, keyboard)
+	if err != nil {
+		return err
+	}
+	s.episodeThreads.Set(data.EpisodeID, chatID, messageID)
+	return nil
+}
+
 func (s *serviceImpl) publishToAnalyzer(data RawCounterData) {
 	if s.signalPublisher == nil {
 		return
@@ -196,6 +231,7 @@ func (s *serviceImpl) cleanupOldGuardEntries() {
 	defer s.guardMu.Unlock()
 
 	s.notificationGuard.CleanupOldEntries()
+	s.episodeThreads.Cleanup()
 	logger.Debug("🧹 Очистка старых записей rate limiting")
 }
 
@@ -5,6 +5,7 @@ import (
 	"crypto-exchange-screener-bot/internal/core/domain/payment"
 	"crypto-exchange-screener-bot/internal/core/domain/subscription"
 	"crypto-exchange-screener-bot/internal/core/domain/users"
+	"crypto-exchange-screener-bot/internal/delivery/broadcast"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/buttons"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/formatters"
 	"crypto-exchange-screener-bot/internal/delivery/telegram/app/bot/message_sender"
@@ -28,6 +29,8 @@ type ServiceFactory struct {
 	formatterProvider     *formatters.FormatterProvider
 	tradingSessionService trading_session.Service
 	signalPublisher       counter.SignalPublisher
+	deliveries            *broadcast.Deliveries
+	signalFeedback        counter.SignalFeedback
 }
 
 // ServiceDependencies зависимости для фабрики сервисов
@@ -40,6 +43,8 @@ type ServiceDependencies struct {
 	FormatterProvider     *formatters.FormatterProvider
 	TradingSessionService trading_session.Service
 	SignalPublisher       counter.SignalPublisher // опционально, nil — публикация отключена
+	Deliveries            *broadcast.Deliveries   // опционально, nil — история доставок и paper trading отключены
+	SignalFeedback        counter.SignalFeedback  // опционально, nil — без кнопок оценки сигнала
 }
 
 // NewServiceFactory создает фабрику сервисов
@@ -55,6 +60,8 @@ func NewServiceFactory(deps ServiceDependencies) *ServiceFactory {
 		formatterProvider:     deps.FormatterProvider,
 		tradingSessionService: deps.TradingSessionService,
 		signalPublisher:       deps.SignalPublisher,
+		deliveries:            deps.Deliveries,
+		signalFeedback:        deps.SignalFeedback,
 	}
 }
 
@@ -73,6 +80,8 @@ func (f *ServiceFactory) CreateCounterService() counter.Service {
 		f.buttonBuilder,
 		f.tradingSessionService,
 		f.signalPublisher,
+		f.deliveries,
+		f.signalFeedback,
 	)
 }
 
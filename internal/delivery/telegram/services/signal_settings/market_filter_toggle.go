@@ -0,0 +1,46 @@
+// internal/delivery/telegram/services/signal_settings/market_filter_toggle.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// toggleMarketFilter переключает скрытие сигналов, объяснённых движением BTC/ETH
+func (s *serviceImpl) toggleMarketFilter(params SignalSettingsParams) (SignalSettingsResult, error) {
+	user, err := s.userService.GetUserByID(params.UserID)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+
+	newValue := !user.SuppressMarketMoves
+	if params.Value != nil {
+		if val, ok := params.Value.(bool); ok {
+			newValue = val
+		}
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"suppress_market_moves": newValue,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления фильтра рыночных движений: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Фильтр рыночных движений обновлен для пользователя %d: %v", params.UserID, newValue)
+
+	message := "Сигналы, объяснённые движением BTC/ETH, показываются ❌"
+	if newValue {
+		message = "Сигналы, объяснённые движением BTC/ETH, скрываются ✅"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "suppress_market_moves",
+		NewValue:     newValue,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -0,0 +1,44 @@
+// internal/delivery/telegram/services/signal_settings/min_confluence.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// updateMinConfluence обновляет минимальную согласованность со старшими таймфреймами
+func (s *serviceImpl) updateMinConfluence(params SignalSettingsParams) (SignalSettingsResult, error) {
+	score, err := convertToFloat(params.Value)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("неверное значение согласованности: %w", err)
+	}
+
+	// 0 — фильтр отключён
+	if score < 0 || score > 100 {
+		return SignalSettingsResult{}, fmt.Errorf("согласованность должна быть от 0 до 100")
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"min_confluence_score": score,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления минимальной согласованности: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Минимальная согласованность обновлена для пользователя %d: %.0f", params.UserID, score)
+
+	message := fmt.Sprintf("Минимальная согласованность: %.0f/100", score)
+	if score == 0 {
+		message = "Фильтр согласованности отключён"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "min_confluence_score",
+		NewValue:     score,
+		UserID:       params.UserID,
+	}, nil
+}
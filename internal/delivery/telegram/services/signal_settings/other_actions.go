@@ -257,14 +257,3 @@ func formatMinutesToPeriod(minutes int) string {
 		}
 	}
 }
-
-// updateSensitivity обновляет чувствительность (заглушка)
-func (s *serviceImpl) updateSensitivity(params SignalSettingsParams) (SignalSettingsResult, error) {
-	return SignalSettingsResult{
-		Success:      true,
-		Message:      "✅ Чувствительность обновлена",
-		UpdatedField: "sensitivity",
-		NewValue:     params.Value,
-		UserID:       params.UserID,
-	}, nil
-}
@@ -0,0 +1,46 @@
+// internal/delivery/telegram/services/signal_settings/pattern_zones_toggle.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// togglePatternZones переключает подписку на свечные паттерны у зон S/R
+func (s *serviceImpl) togglePatternZones(params SignalSettingsParams) (SignalSettingsResult, error) {
+	user, err := s.userService.GetUserByID(params.UserID)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+
+	newValue := !user.NotifyPatternZones
+	if params.Value != nil {
+		if val, ok := params.Value.(bool); ok {
+			newValue = val
+		}
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"notify_pattern_zones": newValue,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления подписки на паттерны у зон: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Подписка на паттерны у зон S/R обновлена для пользователя %d: %v", params.UserID, newValue)
+
+	message := "Уведомления о паттернах у зон S/R выключены ❌"
+	if newValue {
+		message = "Уведомления о паттернах у зон S/R включены ✅"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "notify_pattern_zones",
+		NewValue:     newValue,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -0,0 +1,64 @@
+// internal/delivery/telegram/services/signal_settings/range_horizon_toggle.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/ranges"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// toggleRangeHorizon включает/выключает алерты о пробое диапазона для одного горизонта.
+// params.Value — код горизонта (1d, 7d, 30d, 90d, all).
+func (s *serviceImpl) toggleRangeHorizon(params SignalSettingsParams) (SignalSettingsResult, error) {
+	horizon, ok := params.Value.(string)
+	if !ok || !ranges.IsValidHorizon(horizon) {
+		return SignalSettingsResult{}, fmt.Errorf("неверный горизонт пробоя: %v", params.Value)
+	}
+
+	user, err := s.userService.GetUserByID(params.UserID)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+
+	// Собираем новый список в каноническом порядке горизонтов
+	enabled := !user.SubscribesRangeHorizon(horizon)
+	updated := make([]string, 0, len(ranges.Horizons))
+	for _, h := range ranges.Horizons {
+		code := string(h)
+		if code == horizon {
+			if enabled {
+				updated = append(updated, code)
+			}
+			continue
+		}
+		if user.SubscribesRangeHorizon(code) {
+			updated = append(updated, code)
+		}
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"range_breakout_horizons": updated,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления горизонтов пробоев: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Горизонты пробоев обновлены для пользователя %d: %v", params.UserID, updated)
+
+	label := ranges.Horizon(horizon).Label()
+	message := fmt.Sprintf("Пробои «%s» выключены ❌", label)
+	if enabled {
+		message = fmt.Sprintf("Пробои «%s» включены ✅", label)
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "range_breakout_horizons",
+		NewValue:     updated,
+		UserID:       params.UserID,
+		Metadata:     map[string]interface{}{"horizon": horizon, "enabled": enabled},
+	}, nil
+}
@@ -0,0 +1,53 @@
+// internal/delivery/telegram/services/signal_settings/regime.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/regime"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// setRegimeThresholds задает множители порогов по режимам рынка ("panic=2,range=1.2").
+// Пустая строка снимает множители. Множитель пользователя только повышает его порог:
+// понизить порог можно в настройках роста и падения.
+func (s *serviceImpl) setRegimeThresholds(params SignalSettingsParams) (SignalSettingsResult, error) {
+	raw, ok := params.Value.(string)
+	if !ok {
+		return SignalSettingsResult{}, fmt.Errorf("неверный тип значения множителей: %T", params.Value)
+	}
+
+	factors, err := regime.ParseFactors(raw)
+	if err != nil {
+		return SignalSettingsResult{}, err
+	}
+	for name, factor := range factors {
+		if factor < 1 {
+			return SignalSettingsResult{}, fmt.Errorf("множитель для %s должен быть не меньше 1", name)
+		}
+	}
+	value := factors.String()
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"regime_thresholds": value,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления порогов по режимам рынка: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Пороги по режимам рынка обновлены для пользователя %d: %q", params.UserID, value)
+
+	message := "Пороги не зависят от режима рынка"
+	if value != "" {
+		message = "Пороги по режимам рынка:\n" + regime.FormatFactors(value)
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "regime_thresholds",
+		NewValue:     value,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// internal/delivery/telegram/services/signal_settings/sector_digest_toggle.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// toggleSectorDigest переключает подписку на дайджест ротаций секторов
+func (s *serviceImpl) toggleSectorDigest(params SignalSettingsParams) (SignalSettingsResult, error) {
+	user, err := s.userService.GetUserByID(params.UserID)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+
+	newValue := !user.NotifySectorDigest
+	if params.Value != nil {
+		if val, ok := params.Value.(bool); ok {
+			newValue = val
+		}
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"notify_sector_digest": newValue,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления подписки на дайджест секторов: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Подписка на дайджест секторов обновлена для пользователя %d: %v", params.UserID, newValue)
+
+	message := "Дайджест ротаций секторов выключен ❌"
+	if newValue {
+		message = "Дайджест ротаций секторов включен ✅"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "notify_sector_digest",
+		NewValue:     newValue,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -0,0 +1,47 @@
+// internal/delivery/telegram/services/signal_settings/sensitivity.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/anomaly"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// updateSensitivity обновляет порог z-score сигналов в сигмах.
+// Принимает число сигм или уровень low/medium/high из старого меню.
+func (s *serviceImpl) updateSensitivity(params SignalSettingsParams) (SignalSettingsResult, error) {
+	var sigma float64
+	var err error
+	if level, ok := params.Value.(string); ok {
+		sigma, err = anomaly.ParseSensitivity(level)
+	} else if sigma, err = convertToFloat(params.Value); err == nil {
+		err = anomaly.ValidateSensitivity(sigma)
+	}
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("неверное значение чувствительности: %w", err)
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"sensitivity_sigma": sigma,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления чувствительности: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Чувствительность обновлена для пользователя %d: %.1fσ", params.UserID, sigma)
+
+	message := fmt.Sprintf("Чувствительность: %s", anomaly.DescribeSensitivity(sigma))
+	if sigma == 0 {
+		message = "Сигналы по z-score отключены"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "sensitivity_sigma",
+		NewValue:     sigma,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -30,6 +30,24 @@ func (s *serviceImpl) Exec(params SignalSettingsParams) (SignalSettingsResult, e
 		return s.updateGrowthThreshold(params)
 	case "set_fall_threshold":
 		return s.updateFallThreshold(params)
+	case "toggle_market_filter":
+		return s.toggleMarketFilter(params)
+	case "toggle_pattern_zones":
+		return s.togglePatternZones(params)
+	case "toggle_sector_digest":
+		return s.toggleSectorDigest(params)
+	case "toggle_range_horizon":
+		return s.toggleRangeHorizon(params)
+	case "toggle_vwap":
+		return s.toggleVWAP(params)
+	case "set_vwap_anchor":
+		return s.setVWAPAnchor(params)
+	case "toggle_wall_pulls":
+		return s.toggleWallPulls(params)
+	case "set_regime_thresholds":
+		return s.setRegimeThresholds(params)
+	case "set_min_confluence":
+		return s.updateMinConfluence(params)
 	case "set_sensitivity":
 		return s.updateSensitivity(params)
 	case "select_period":
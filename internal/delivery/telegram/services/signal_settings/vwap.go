@@ -0,0 +1,90 @@
+// internal/delivery/telegram/services/signal_settings/vwap.go
+package signal_settings
+
+import (
+	"fmt"
+	"time"
+
+	"crypto-exchange-screener-bot/internal/core/domain/analysis/vwap"
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// toggleVWAP переключает подписку на сигналы анализатора VWAP
+func (s *serviceImpl) toggleVWAP(params SignalSettingsParams) (SignalSettingsResult, error) {
+	user, err := s.userService.GetUserByID(params.UserID)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+
+	newValue := !user.NotifyVWAP
+	if params.Value != nil {
+		if val, ok := params.Value.(bool); ok {
+			newValue = val
+		}
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"notify_vwap": newValue,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления подписки на сигналы VWAP: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Подписка на сигналы VWAP обновлена для пользователя %d: %v", params.UserID, newValue)
+
+	message := "Сигналы VWAP выключены ❌"
+	if newValue {
+		message = "Сигналы VWAP включены ✅"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "notify_vwap",
+		NewValue:     newValue,
+		UserID:       params.UserID,
+	}, nil
+}
+
+// setVWAPAnchor задает (time.Time) или снимает (nil) якорь пользовательского VWAP.
+// Якорь не старше истории 5-минутных корзин VWAP в CandleEngine.
+func (s *serviceImpl) setVWAPAnchor(params SignalSettingsParams) (SignalSettingsResult, error) {
+	var anchor *time.Time
+	switch val := params.Value.(type) {
+	case nil:
+	case time.Time:
+		at := val.UTC().Truncate(time.Minute)
+		if at.After(time.Now()) {
+			return SignalSettingsResult{}, fmt.Errorf("якорь VWAP не может быть в будущем")
+		}
+		if time.Since(at) > vwap.MaxAnchorAge {
+			return SignalSettingsResult{}, fmt.Errorf("якорь VWAP не может быть старше %.0f ч", vwap.MaxAnchorAge.Hours())
+		}
+		anchor = &at
+	default:
+		return SignalSettingsResult{}, fmt.Errorf("неверный тип значения якоря VWAP: %T", params.Value)
+	}
+
+	err := s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"vwap_anchor_at": anchor,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления якоря VWAP: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	message := "Якорь VWAP снят"
+	if anchor != nil {
+		message = fmt.Sprintf("Якорь VWAP установлен: %s UTC", anchor.Format("02.01 15:04"))
+	}
+	logger.Info("✅ %s для пользователя %d", message, params.UserID)
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "vwap_anchor_at",
+		NewValue:     anchor,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// internal/delivery/telegram/services/signal_settings/walls.go
+package signal_settings
+
+import (
+	"fmt"
+
+	"crypto-exchange-screener-bot/pkg/logger"
+)
+
+// toggleWallPulls переключает подписку на алерты о снятии стен стакана
+func (s *serviceImpl) toggleWallPulls(params SignalSettingsParams) (SignalSettingsResult, error) {
+	user, err := s.userService.GetUserByID(params.UserID)
+	if err != nil {
+		return SignalSettingsResult{}, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+
+	newValue := !user.NotifyWallPulls
+	if params.Value != nil {
+		if val, ok := params.Value.(bool); ok {
+			newValue = val
+		}
+	}
+
+	err = s.userService.UpdateSettings(params.UserID, map[string]interface{}{
+		"notify_wall_pulls": newValue,
+	})
+	if err != nil {
+		logger.Error("❌ Ошибка обновления подписки на снятие стен: %v", err)
+		return SignalSettingsResult{}, fmt.Errorf("ошибка обновления настроек: %w", err)
+	}
+
+	logger.Info("✅ Подписка на снятие стен обновлена для пользователя %d: %v", params.UserID, newValue)
+
+	message := "Алерты о снятии стен выключены ❌"
+	if newValue {
+		message = "Алерты о снятии стен включены ✅"
+	}
+
+	return SignalSettingsResult{
+		Success:      true,
+		Message:      message,
+		UpdatedField: "notify_wall_pulls",
+		NewValue:     newValue,
+		UserID:       params.UserID,
+	}, nil
+}
@@ -148,10 +148,14 @@ func (c *BybitClient) sendPublicRequest(method, endpoint string, params url.Valu
 // ============================================
 
 // GetOrderBook получает стакан ордеров для символа.
-// depth — глубина (1-200 для linear/inverse/spot).
+// depth — глубина (1-500 для linear/inverse, 1-200 для spot).
 func (c *BybitClient) GetOrderBook(symbol string, depth int) (*OrderBookV5, error) {
-	if depth <= 0 || depth > 200 {
-		depth = 200
+	maxDepth := 500
+	if c.category == CategorySpot {
+		maxDepth = 200
+	}
+	if depth <= 0 || depth > maxDepth {
+		depth = maxDepth
 	}
 
 	params := url.Values{}
@@ -29,12 +29,20 @@ func NewSlidingWindowAggregator(windowDur time.Duration) *SlidingWindowAggregato
 	}
 }
 
-// Add добавляет событие ликвидации в окно для символа
+// Add добавляет событие ликвидации в окно для символа.
+// Устаревшие события символа отсекаются сразу, чтобы длинное окно
+// не накапливало события между чтениями метрик.
 func (a *SlidingWindowAggregator) Add(symbol string, e liqEvent) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	a.windows[symbol] = append(a.windows[symbol], e)
+	events := a.windows[symbol]
+	cutoff := e.timestamp.Add(-a.windowDur)
+	expired := 0
+	for expired < len(events) && !events[expired].timestamp.After(cutoff) {
+		expired++
+	}
+	a.windows[symbol] = append(events[expired:], e)
 }
 
 // GetMetrics возвращает агрегированные метрики для символа за последнее окно.
@@ -91,6 +99,18 @@ func (a *SlidingWindowAggregator) GetMetrics(symbol string) *bybit.LiquidationMe
 	}
 }
 
+// Snapshot возвращает метрики всех символов с ликвидациями за последнее окно
+func (a *SlidingWindowAggregator) Snapshot() []*bybit.LiquidationMetrics {
+	symbols := a.Symbols()
+	result := make([]*bybit.LiquidationMetrics, 0, len(symbols))
+	for _, symbol := range symbols {
+		if metrics := a.GetMetrics(symbol); metrics != nil {
+			result = append(result, metrics)
+		}
+	}
+	return result
+}
+
 // Symbols возвращает список символов, для которых есть данные
 func (a *SlidingWindowAggregator) Symbols() []string {
 	a.mu.Lock()
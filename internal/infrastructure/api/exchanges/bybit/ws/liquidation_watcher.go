@@ -21,6 +21,7 @@ const (
 	pingInterval   = 20 * time.Second
 	flushInterval  = 10 * time.Second
 	windowDuration = 5 * time.Minute
+	dailyWindow    = 24 * time.Hour // окно суточных итогов для дайджеста рынка
 	maxSymbols     = 200 // Bybit WS лимит топиков на соединение
 	maxRetryDelay  = 60 * time.Second
 )
@@ -39,6 +40,7 @@ type LiquidationCacheSetter interface {
 type LiquidationWatcher struct {
 	cache      LiquidationCacheSetter
 	aggregator *SlidingWindowAggregator
+	daily      *SlidingWindowAggregator
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
@@ -53,6 +55,7 @@ func NewLiquidationWatcher(cache LiquidationCacheSetter) *LiquidationWatcher {
 	return &LiquidationWatcher{
 		cache:      cache,
 		aggregator: NewSlidingWindowAggregator(windowDuration),
+		daily:      NewSlidingWindowAggregator(dailyWindow),
 		stopCh:     make(chan struct{}),
 	}
 }
@@ -311,12 +314,19 @@ func (w *LiquidationWatcher) handleMessage(raw json.RawMessage) {
 		}
 
 		w.aggregator.Add(d.Symbol, event)
+		w.daily.Add(d.Symbol, event)
 
 		logger.Debug("💥 LiquidationWatcher: %s %s $%.0f",
 			d.Symbol, d.Side, sizeUSD)
 	}
 }
 
+// DailyLiquidations возвращает ликвидации по символам за последние 24 часа
+// (с момента запуска, если наблюдатель работает меньше суток)
+func (w *LiquidationWatcher) DailyLiquidations() []*bybit.LiquidationMetrics {
+	return w.daily.Snapshot()
+}
+
 // flushLoop периодически записывает агрегированные данные в кэш
 func (w *LiquidationWatcher) flushLoop() {
 	defer w.wg.Done()
@@ -187,26 +187,195 @@ func LoadConfig(path string) (*Config, error) {
 		CounterAnalyzer: AnalyzerConfig{
 			Enabled: getEnvBool("COUNTER_ANALYZER_ENABLED", true),
 			CustomSettings: map[string]interface{}{
-				"base_period_minutes":    getEnvInt("COUNTER_BASE_PERIOD_MINUTES", 1),
-				"analysis_period":        getEnv("COUNTER_ANALYSIS_PERIOD", "15m"),
-				"growth_threshold":       getEnvFloat("COUNTER_GROWTH_THRESHOLD", 0.1),
-				"fall_threshold":         getEnvFloat("COUNTER_FALL_THRESHOLD", 0.1),
-				"track_growth":           getEnvBool("COUNTER_TRACK_GROWTH", true),
-				"track_fall":             getEnvBool("COUNTER_TRACK_FALL", true),
-				"notify_on_signal":       getEnvBool("COUNTER_NOTIFY_ON_SIGNAL", true),
-				"notification_threshold": getEnvInt("COUNTER_NOTIFICATION_THRESHOLD", 1),
-				"chart_provider":         getEnv("COUNTER_CHART_PROVIDER", "coinglass"),
-				"notification_enabled":   getEnvBool("COUNTER_NOTIFICATION_ENABLED", true),
-				"max_signals_5m":         getEnvInt("COUNTER_MAX_SIGNALS_5MIN", 5),
-				"max_signals_15m":        getEnvInt("COUNTER_MAX_SIGNALS_15MIN", 8),
-				"max_signals_30m":        getEnvInt("COUNTER_MAX_SIGNALS_30MIN", 10),
-				"max_signals_1h":         getEnvInt("COUNTER_MAX_SIGNALS_1HOUR", 12),
-				"max_signals_4h":         getEnvInt("COUNTER_MAX_SIGNALS_4HOURS", 15),
-				"max_signals_1d":         getEnvInt("COUNTER_MAX_SIGNALS_1DAY", 20),
+				"base_period_minutes":      getEnvInt("COUNTER_BASE_PERIOD_MINUTES", 1),
+				"analysis_period":          getEnv("COUNTER_ANALYSIS_PERIOD", "15m"),
+				"growth_threshold":         getEnvFloat("COUNTER_GROWTH_THRESHOLD", 0.1),
+				"fall_threshold":           getEnvFloat("COUNTER_FALL_THRESHOLD", 0.1),
+				"min_volume_usd":           getEnvFloat("COUNTER_MIN_VOLUME_USD", 0),
+				"track_growth":             getEnvBool("COUNTER_TRACK_GROWTH", true),
+				"track_fall":               getEnvBool("COUNTER_TRACK_FALL", true),
+				"notify_on_signal":         getEnvBool("COUNTER_NOTIFY_ON_SIGNAL", true),
+				"notification_threshold":   getEnvInt("COUNTER_NOTIFICATION_THRESHOLD", 1),
+				"required_confirmations":   getEnvInt("COUNTER_REQUIRED_CONFIRMATIONS", 1),
+				"chart_provider":           getEnv("COUNTER_CHART_PROVIDER", "coinglass"),
+				"notification_enabled":     getEnvBool("COUNTER_NOTIFICATION_ENABLED", true),
+				"max_signals_5m":           getEnvInt("COUNTER_MAX_SIGNALS_5MIN", 5),
+				"max_signals_15m":          getEnvInt("COUNTER_MAX_SIGNALS_15MIN", 8),
+				"max_signals_30m":          getEnvInt("COUNTER_MAX_SIGNALS_30MIN", 10),
+				"max_signals_1h":           getEnvInt("COUNTER_MAX_SIGNALS_1HOUR", 12),
+				"max_signals_4h":           getEnvInt("COUNTER_MAX_SIGNALS_4HOURS", 15),
+				"max_signals_1d":           getEnvInt("COUNTER_MAX_SIGNALS_1DAY", 20),
+				"confluence_enabled":       getEnvBool("COUNTER_CONFLUENCE_ENABLED", true),
+				"market_context_enabled":   getEnvBool("COUNTER_MARKET_CONTEXT_ENABLED", true),
+				"market_benchmarks":        getEnv("COUNTER_MARKET_BENCHMARKS", "BTCUSDT,ETHUSDT"),
+				"market_beta_window":       getEnvInt("COUNTER_MARKET_BETA_WINDOW", 100),
+				"regime_threshold_factors": getEnv("COUNTER_REGIME_THRESHOLD_FACTORS", ""),
+				"liquidity_enabled":        getEnvBool("COUNTER_LIQUIDITY_ENABLED", true),
+				"episodes_enabled":         getEnvBool("COUNTER_EPISODES_ENABLED", true),
+				"episode_pump_pct":         getEnvFloat("COUNTER_EPISODE_PUMP_PCT", 3.0),
+				"episode_volume_spike":     getEnvFloat("COUNTER_EPISODE_VOLUME_SPIKE", 2.0),
+				"episode_delta_pct":        getEnvFloat("COUNTER_EPISODE_DELTA_PCT", 15.0),
+				"episode_dump_retrace_pct": getEnvFloat("COUNTER_EPISODE_DUMP_RETRACE_PCT", 3.0),
+				"episode_ttl_minutes":      getEnvInt("COUNTER_EPISODE_TTL_MINUTES", 360),
+			},
+		},
+		DivergenceAnalyzer: AnalyzerConfig{
+			Enabled:       getEnvBool("DIVERGENCE_ANALYZER_ENABLED", true),
+			MinConfidence: getEnvFloat("DIVERGENCE_ANALYZER_MIN_CONFIDENCE", 55.0),
+			CustomSettings: map[string]interface{}{
+				"periods":        getEnv("DIVERGENCE_PERIODS", "15m,1h,4h"),
+				"history_limit":  getEnvInt("DIVERGENCE_HISTORY_LIMIT", 150),
+				"pivot_lookback": getEnvInt("DIVERGENCE_PIVOT_LOOKBACK", 3),
+				"rsi_period":     getEnvInt("DIVERGENCE_RSI_PERIOD", 14),
+				"max_bars_ago":   getEnvInt("DIVERGENCE_MAX_BARS_AGO", 3),
+			},
+		},
+		LiquidityAnalyzer: AnalyzerConfig{
+			Enabled:       getEnvBool("LIQUIDITY_ANALYZER_ENABLED", true),
+			MinConfidence: getEnvFloat("LIQUIDITY_ANALYZER_MIN_CONFIDENCE", 60.0),
+			CustomSettings: map[string]interface{}{
+				"sample_interval_sec": getEnvInt("LIQUIDITY_SAMPLE_INTERVAL_SEC", 60),
+				"imbalance_threshold": getEnvFloat("LIQUIDITY_IMBALANCE_THRESHOLD", 0.6),
+				"persistence_samples": getEnvInt("LIQUIDITY_PERSISTENCE_SAMPLES", 3),
+				"band_pct":            getEnvFloat("LIQUIDITY_BAND_PCT", 1.0),
+				"cooldown_minutes":    getEnvInt("LIQUIDITY_COOLDOWN_MINUTES", 30),
+				"min_volume_24h":      getEnvFloat("LIQUIDITY_MIN_VOLUME_24H", 5000000),
+			},
+		},
+		PatternAnalyzer: AnalyzerConfig{
+			Enabled:       getEnvBool("PATTERN_ANALYZER_ENABLED", true),
+			MinConfidence: getEnvFloat("PATTERN_ANALYZER_MIN_CONFIDENCE", 60.0),
+			CustomSettings: map[string]interface{}{
+				"periods":            getEnv("PATTERN_PERIODS", "15m,1h,4h"),
+				"history_limit":      getEnvInt("PATTERN_HISTORY_LIMIT", 30),
+				"trend_lookback":     getEnvInt("PATTERN_TREND_LOOKBACK", 5),
+				"zone_alerts":        getEnvBool("PATTERN_ZONE_ALERTS", true),
+				"zone_tolerance_pct": getEnvFloat("PATTERN_ZONE_TOLERANCE_PCT", 0.3),
+				"min_zone_strength":  getEnvFloat("PATTERN_MIN_ZONE_STRENGTH", 50.0),
+			},
+		},
+		AnomalyAnalyzer: AnalyzerConfig{
+			Enabled:       getEnvBool("ANOMALY_ANALYZER_ENABLED", true),
+			MinConfidence: getEnvFloat("ANOMALY_ANALYZER_MIN_CONFIDENCE", 50.0),
+			CustomSettings: map[string]interface{}{
+				"periods":     getEnv("ANOMALY_PERIODS", "5m,15m,1h"),
+				"window":      getEnvInt("ANOMALY_WINDOW", 200),
+				"min_samples": getEnvInt("ANOMALY_MIN_SAMPLES", 50),
+				"min_z":       getEnvFloat("ANOMALY_MIN_Z", 2.0),
+			},
+		},
+		RangeBreakoutAnalyzer: AnalyzerConfig{
+			Enabled:       getEnvBool("RANGE_BREAKOUT_ANALYZER_ENABLED", true),
+			MinConfidence: getEnvFloat("RANGE_BREAKOUT_ANALYZER_MIN_CONFIDENCE", 40.0),
+			CustomSettings: map[string]interface{}{
+				"history_days":     getEnvInt("RANGE_BREAKOUT_HISTORY_DAYS", 1000),
+				"cooldown_minutes": getEnvInt("RANGE_BREAKOUT_COOLDOWN_MINUTES", 240),
+				"min_distance_pct": getEnvFloat("RANGE_BREAKOUT_MIN_DISTANCE_PCT", 0.0),
+			},
+		},
+		VWAPAnalyzer: AnalyzerConfig{
+			Enabled:       getEnvBool("VWAP_ANALYZER_ENABLED", true),
+			MinConfidence: getEnvFloat("VWAP_ANALYZER_MIN_CONFIDENCE", 50.0),
+			CustomSettings: map[string]interface{}{
+				"deviation_sigma":     getEnvFloat("VWAP_DEVIATION_SIGMA", 2.0),
+				"cross_buffer_pct":    getEnvFloat("VWAP_CROSS_BUFFER_PCT", 0.1),
+				"min_session_minutes": getEnvInt("VWAP_MIN_SESSION_MINUTES", 30),
+				"cooldown_minutes":    getEnvInt("VWAP_COOLDOWN_MINUTES", 60),
 			},
 		},
 	}
 
+	// ======================
+	// ТРЕКЕР СТЕН СТАКАНА
+	// ======================
+	cfg.WallTracker.Enabled = getEnvBool("WALL_TRACKER_ENABLED", true)
+	cfg.WallTracker.MaxSymbols = getEnvInt("WALL_TRACKER_MAX_SYMBOLS", 40)
+	cfg.WallTracker.SampleIntervalSec = getEnvInt("WALL_TRACKER_SAMPLE_INTERVAL_SEC", 15)
+	cfg.WallTracker.MinStableSec = getEnvInt("WALL_TRACKER_MIN_STABLE_SEC", 120)
+	cfg.WallTracker.PullAlerts = getEnvBool("WALL_PULL_ALERTS_ENABLED", false)
+	cfg.WallTracker.PullAlertMinUSD = getEnvFloat("WALL_PULL_ALERT_MIN_USD", 250000)
+	cfg.WallTracker.PullApproachPct = getEnvFloat("WALL_PULL_APPROACH_PCT", 0.5)
+
+	// ======================
+	// ПОЛЬЗОВАТЕЛЬСКИЕ ПРАВИЛА
+	// ======================
+	cfg.RulesEngine.Enabled = getEnvBool("RULES_ENGINE_ENABLED", true)
+	cfg.RulesEngine.RefreshSec = getEnvInt("RULES_REFRESH_SEC", 60)
+	cfg.RulesEngine.Workers = getEnvInt("RULES_WORKERS", 4)
+	cfg.PriceAlerts.Enabled = getEnvBool("PRICE_ALERTS_ENABLED", true)
+
+	// ======================
+	// ОТНОСИТЕЛЬНАЯ СИЛА И СЕКТОРЫ
+	// ======================
+	cfg.Strength.Enabled = getEnvBool("STRENGTH_ENABLED", true)
+	cfg.Strength.MaxSymbols = getEnvInt("STRENGTH_MAX_SYMBOLS", 150)
+	cfg.Strength.TopN = getEnvInt("STRENGTH_TOP_N", 5)
+	cfg.Strength.Sectors = getEnv("STRENGTH_SECTORS",
+		"L1=BTCUSDT,ETHUSDT,SOLUSDT,AVAXUSDT,ADAUSDT,DOTUSDT,NEARUSDT,APTUSDT,SUIUSDT,TONUSDT;"+
+			"Мемы=DOGEUSDT,1000PEPEUSDT,SHIB1000USDT,WIFUSDT,1000BONKUSDT,1000FLOKIUSDT;"+
+			"AI=FETUSDT,RENDERUSDT,TAOUSDT,WLDUSDT,ARKMUSDT;"+
+			"DeFi=UNIUSDT,AAVEUSDT,MKRUSDT,LDOUSDT,CRVUSDT,COMPUSDT,DYDXUSDT,PENDLEUSDT")
+	cfg.Strength.DigestPeriod = getEnv("STRENGTH_DIGEST_PERIOD", "4h")
+	cfg.Strength.DigestIntervalMin = getEnvInt("STRENGTH_DIGEST_INTERVAL_MIN", 240)
+
+	// ======================
+	// ЖУРНАЛ СИГНАЛОВ
+	// ======================
+	cfg.SignalJournal.Enabled = getEnvBool("SIGNAL_JOURNAL_ENABLED", true)
+	cfg.SignalJournal.EvalIntervalSec = getEnvInt("SIGNAL_JOURNAL_EVAL_INTERVAL_SEC", 60)
+	cfg.SignalJournal.BatchSize = getEnvInt("SIGNAL_JOURNAL_BATCH_SIZE", 500)
+	cfg.SignalJournal.RetentionDays = getEnvInt("SIGNAL_JOURNAL_RETENTION_DAYS", 180)
+	cfg.SignalJournal.AnalyticsAPIEnabled = getEnvBool("SIGNAL_ANALYTICS_API_ENABLED", false)
+	cfg.SignalJournal.AnalyticsAPIPort = getEnvInt("SIGNAL_ANALYTICS_API_PORT", 8083)
+	cfg.SignalJournal.AnalyticsAPISecret = getEnv("SIGNAL_ANALYTICS_API_SECRET", "")
+	cfg.SignalJournal.FeedbackEnabled = getEnvBool("SIGNAL_FEEDBACK_ENABLED", true)
+	cfg.SignalJournal.FeedbackWindowDays = getEnvInt("SIGNAL_FEEDBACK_WINDOW_DAYS", 30)
+	cfg.SignalJournal.FeedbackNoiseMinVotes = getEnvInt("SIGNAL_FEEDBACK_NOISE_MIN_VOTES", 3)
+	cfg.SignalJournal.FeedbackNoiseStep = getEnvFloat("SIGNAL_FEEDBACK_NOISE_STEP", 0.25)
+	cfg.SignalJournal.FeedbackNoiseMaxFactor = getEnvFloat("SIGNAL_FEEDBACK_NOISE_MAX_FACTOR", 2.0)
+
+	// ======================
+	// PAPER TRADING
+	// ======================
+	cfg.PaperTrading.Enabled = getEnvBool("PAPER_TRADING_ENABLED", true)
+	cfg.PaperTrading.StartBalance = getEnvFloat("PAPER_TRADING_START_BALANCE", 10000)
+	cfg.PaperTrading.FeePct = getEnvFloat("PAPER_TRADING_FEE_PCT", 0.055)
+	cfg.PaperTrading.MaxOpenLimit = getEnvInt("PAPER_TRADING_MAX_OPEN_LIMIT", 20)
+	cfg.PaperTrading.RefreshSec = getEnvInt("PAPER_TRADING_REFRESH_SEC", 15)
+
+	// ======================
+	// ВЫГРУЗКИ ДАННЫХ
+	// ======================
+	cfg.Export.Enabled = getEnvBool("EXPORT_ENABLED", true)
+	cfg.Export.Dir = getEnv("EXPORT_DIR", "")
+	cfg.Export.MaxRows = getEnvInt64("EXPORT_MAX_ROWS", 500000)
+	cfg.Export.MaxDocMB = getEnvInt("EXPORT_MAX_DOCUMENT_MB", 45)
+	cfg.Export.MaxRangeDays = getEnvInt("EXPORT_MAX_RANGE_DAYS", 90)
+
+	// ======================
+	// ДАЙДЖЕСТ РЫНКА
+	// ======================
+	cfg.MarketDigest.Enabled = getEnvBool("MARKET_DIGEST_ENABLED", true)
+	cfg.MarketDigest.BuildTime = getEnv("MARKET_DIGEST_BUILD_TIME", "00:05")
+	cfg.MarketDigest.SendTime = getEnv("MARKET_DIGEST_SEND_TIME", "09:00")
+	cfg.MarketDigest.TopN = getEnvInt("MARKET_DIGEST_TOP_N", 5)
+	cfg.MarketDigest.MinVolumeUSD = getEnvFloat("MARKET_DIGEST_MIN_VOLUME_USD", 1000000)
+	cfg.MarketDigest.Horizon = getEnv("MARKET_DIGEST_HORIZON", "4h")
+
+	// ======================
+	// РЕЖИМ РЫНКА
+	// ======================
+	cfg.MarketRegime.Enabled = getEnvBool("MARKET_REGIME_ENABLED", true)
+	cfg.MarketRegime.Interval = getEnv("MARKET_REGIME_INTERVAL", "5m")
+	cfg.MarketRegime.Benchmarks = getEnv("MARKET_REGIME_BENCHMARKS", "BTCUSDT,ETHUSDT")
+	cfg.MarketRegime.TrendChangePct = getEnvFloat("MARKET_REGIME_TREND_CHANGE_PCT", 2.0)
+	cfg.MarketRegime.BreadthUp = getEnvFloat("MARKET_REGIME_BREADTH_UP", 0.55)
+	cfg.MarketRegime.BreadthDown = getEnvFloat("MARKET_REGIME_BREADTH_DOWN", 0.45)
+	cfg.MarketRegime.PanicVolatility = getEnvFloat("MARKET_REGIME_PANIC_VOLATILITY", 2.5)
+	cfg.MarketRegime.PanicDeclineShare = getEnvFloat("MARKET_REGIME_PANIC_DECLINE_SHARE", 0.75)
+	cfg.MarketRegime.MinVolumeUSD = getEnvFloat("MARKET_REGIME_MIN_VOLUME_USD", 1000000)
+	cfg.MarketRegime.ConfirmRuns = getEnvInt("MARKET_REGIME_CONFIRM_RUNS", 2)
+
 	// ======================
 	// ШИНА СОБЫТИЙ
 	// ======================
@@ -223,6 +392,18 @@ func LoadConfig(path string) (*Config, error) {
 	cfg.SignalFilters.MaxSignalsPerMin = getEnvInt("MAX_SIGNALS_PER_MIN", 5)
 	cfg.SignalFilters.IncludePatterns = parsePatterns(getEnv("SIGNAL_INCLUDE_PATTERNS", ""))
 	cfg.SignalFilters.ExcludePatterns = parsePatterns(getEnv("SIGNAL_EXCLUDE_PATTERNS", ""))
+	cfg.SignalFilters.Order = parsePatterns(getEnv("SIGNAL_FILTER_ORDER", "blacklist,pattern,min_turnover,volume,confidence,cooldown,rate_limit"))
+	cfg.SignalFilters.Blacklist = parsePatterns(getEnv("SIGNAL_BLACKLIST", ""))
+	cfg.SignalFilters.MinTurnover = getEnvFloat("SIGNAL_MIN_TURNOVER", 0)
+	cfg.SignalFilters.MinVolume = getEnvFloat("SIGNAL_MIN_VOLUME", 0)
+	cfg.SignalFilters.Cooldown = getEnvDuration("SIGNAL_COOLDOWN", 15*time.Minute)
+	cfg.SignalFilters.BlacklistEnabled = getEnvBool("SIGNAL_FILTER_BLACKLIST_ENABLED", true)
+	cfg.SignalFilters.PatternEnabled = getEnvBool("SIGNAL_FILTER_PATTERN_ENABLED", true)
+	cfg.SignalFilters.MinTurnoverEnabled = getEnvBool("SIGNAL_FILTER_MIN_TURNOVER_ENABLED", true)
+	cfg.SignalFilters.VolumeEnabled = getEnvBool("SIGNAL_FILTER_VOLUME_ENABLED", false)
+	cfg.SignalFilters.ConfidenceEnabled = getEnvBool("SIGNAL_FILTER_CONFIDENCE_ENABLED", false)
+	cfg.SignalFilters.CooldownEnabled = getEnvBool("SIGNAL_FILTER_COOLDOWN_ENABLED", false)
+	cfg.SignalFilters.RateLimitEnabled = getEnvBool("SIGNAL_FILTER_RATE_LIMIT_ENABLED", false)
 
 	// ======================
 	// НАСТРОЙКИ ОТОБРАЖЕНИЯ
@@ -302,9 +483,9 @@ func LoadConfig(path string) (*Config, error) {
 	// ============================
 	// AUTH OTP SERVER
 	// ============================
-	cfg.Auth.Enabled   = getEnvBool("AUTH_ENABLED", false)
-	cfg.Auth.Port      = getEnvInt("AUTH_PORT", 8081)
-	cfg.Auth.Secret    = getEnv("AUTH_INTERNAL_SECRET", "")
+	cfg.Auth.Enabled = getEnvBool("AUTH_ENABLED", false)
+	cfg.Auth.Port = getEnvInt("AUTH_PORT", 8081)
+	cfg.Auth.Secret = getEnv("AUTH_INTERNAL_SECRET", "")
 	cfg.Auth.OTPTTLSec = getEnvInt("AUTH_OTP_TTL_SEC", 300)
 
 	// ======================
@@ -95,6 +95,24 @@ func (c *Config) IsCounterAnalyzerEnabled() bool {
 	return c.AnalyzerConfigs.CounterAnalyzer.Enabled
 }
 
+// ByName возвращает конфигурации анализаторов по именам из реестра анализаторов
+func (a AnalyzerConfigs) ByName() map[string]AnalyzerConfig {
+	return map[string]AnalyzerConfig{
+		"growth":         a.GrowthAnalyzer,
+		"fall":           a.FallAnalyzer,
+		"continuous":     a.ContinuousAnalyzer,
+		"volume":         a.VolumeAnalyzer,
+		"open_interest":  a.OpenInterestAnalyzer,
+		"counter":        a.CounterAnalyzer,
+		"divergence":     a.DivergenceAnalyzer,
+		"liquidity":      a.LiquidityAnalyzer,
+		"patterns":       a.PatternAnalyzer,
+		"anomaly":        a.AnomalyAnalyzer,
+		"range_breakout": a.RangeBreakoutAnalyzer,
+		"vwap":           a.VWAPAnalyzer,
+	}
+}
+
 // GetSymbolList возвращает список символов для мониторинга
 func (c *Config) GetSymbolList() []string {
 	if c.SymbolFilter == "" || c.SymbolFilter == "all" {
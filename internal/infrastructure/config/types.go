@@ -77,12 +77,18 @@ type AnalyzerConfig struct {
 
 // AnalyzerConfigs - конфигурация всех анализаторов
 type AnalyzerConfigs struct {
-	GrowthAnalyzer       AnalyzerConfig `mapstructure:"GROWTH_ANALYZER"`
-	FallAnalyzer         AnalyzerConfig `mapstructure:"FALL_ANALYZER"`
-	ContinuousAnalyzer   AnalyzerConfig `mapstructure:"CONTINUOUS_ANALYZER"`
-	VolumeAnalyzer       AnalyzerConfig `mapstructure:"VOLUME_ANALYZER"`
-	OpenInterestAnalyzer AnalyzerConfig `mapstructure:"OPEN_INTEREST_ANALYZER"`
-	CounterAnalyzer      AnalyzerConfig `mapstructure:"COUNTER_ANALYZER"`
+	GrowthAnalyzer        AnalyzerConfig `mapstructure:"GROWTH_ANALYZER"`
+	FallAnalyzer          AnalyzerConfig `mapstructure:"FALL_ANALYZER"`
+	ContinuousAnalyzer    AnalyzerConfig `mapstructure:"CONTINUOUS_ANALYZER"`
+	VolumeAnalyzer        AnalyzerConfig `mapstructure:"VOLUME_ANALYZER"`
+	OpenInterestAnalyzer  AnalyzerConfig `mapstructure:"OPEN_INTEREST_ANALYZER"`
+	CounterAnalyzer       AnalyzerConfig `mapstructure:"COUNTER_ANALYZER"`
+	DivergenceAnalyzer    AnalyzerConfig `mapstructure:"DIVERGENCE_ANALYZER"`
+	LiquidityAnalyzer     AnalyzerConfig `mapstructure:"LIQUIDITY_ANALYZER"`
+	PatternAnalyzer       AnalyzerConfig `mapstructure:"PATTERN_ANALYZER"`
+	AnomalyAnalyzer       AnalyzerConfig `mapstructure:"ANOMALY_ANALYZER"`
+	RangeBreakoutAnalyzer AnalyzerConfig `mapstructure:"RANGE_BREAKOUT_ANALYZER"`
+	VWAPAnalyzer          AnalyzerConfig `mapstructure:"VWAP_ANALYZER"`
 }
 
 // UserDefaultsConfig - настройки пользователей по умолчанию
@@ -163,6 +169,119 @@ type Config struct {
 	// ======================
 	AnalyzerConfigs AnalyzerConfigs `mapstructure:"ANALYZERS"`
 
+	// ======================
+	// ТРЕКЕР СТЕН СТАКАНА
+	// ======================
+	WallTracker struct {
+		Enabled           bool    `mapstructure:"WALL_TRACKER_ENABLED"`
+		MaxSymbols        int     `mapstructure:"WALL_TRACKER_MAX_SYMBOLS"`
+		SampleIntervalSec int     `mapstructure:"WALL_TRACKER_SAMPLE_INTERVAL_SEC"`
+		MinStableSec      int     `mapstructure:"WALL_TRACKER_MIN_STABLE_SEC"`
+		PullAlerts        bool    `mapstructure:"WALL_PULL_ALERTS_ENABLED"`
+		PullAlertMinUSD   float64 `mapstructure:"WALL_PULL_ALERT_MIN_USD"`
+		PullApproachPct   float64 `mapstructure:"WALL_PULL_APPROACH_PCT"`
+	} `mapstructure:",squash"`
+
+	// ======================
+	// ПОЛЬЗОВАТЕЛЬСКИЕ ПРАВИЛА
+	// ======================
+	RulesEngine struct {
+		Enabled    bool `mapstructure:"RULES_ENGINE_ENABLED"`
+		RefreshSec int  `mapstructure:"RULES_REFRESH_SEC"`
+		Workers    int  `mapstructure:"RULES_WORKERS"`
+	} `mapstructure:",squash"`
+
+	// ======================
+	// ЦЕНОВЫЕ АЛЕРТЫ
+	// ======================
+	PriceAlerts struct {
+		Enabled bool `mapstructure:"PRICE_ALERTS_ENABLED"`
+	} `mapstructure:",squash"`
+
+	// ======================
+	// ОТНОСИТЕЛЬНАЯ СИЛА И СЕКТОРЫ
+	// ======================
+	Strength struct {
+		Enabled           bool   `mapstructure:"STRENGTH_ENABLED"`
+		MaxSymbols        int    `mapstructure:"STRENGTH_MAX_SYMBOLS"`
+		TopN              int    `mapstructure:"STRENGTH_TOP_N"`
+		Sectors           string `mapstructure:"STRENGTH_SECTORS"`
+		DigestPeriod      string `mapstructure:"STRENGTH_DIGEST_PERIOD"`
+		DigestIntervalMin int    `mapstructure:"STRENGTH_DIGEST_INTERVAL_MIN"`
+	} `mapstructure:",squash"`
+
+	// ======================
+	// ЖУРНАЛ СИГНАЛОВ
+	// ======================
+	SignalJournal struct {
+		Enabled         bool `mapstructure:"SIGNAL_JOURNAL_ENABLED"`
+		EvalIntervalSec int  `mapstructure:"SIGNAL_JOURNAL_EVAL_INTERVAL_SEC"`
+		BatchSize       int  `mapstructure:"SIGNAL_JOURNAL_BATCH_SIZE"`
+		RetentionDays   int  `mapstructure:"SIGNAL_JOURNAL_RETENTION_DAYS"` // 0 — хранить бессрочно
+
+		// JSON аналитики для дашбордов (127.0.0.1, заголовок X-Internal-Secret)
+		AnalyticsAPIEnabled bool   `mapstructure:"SIGNAL_ANALYTICS_API_ENABLED"`
+		AnalyticsAPIPort    int    `mapstructure:"SIGNAL_ANALYTICS_API_PORT"`
+		AnalyticsAPISecret  string `mapstructure:"SIGNAL_ANALYTICS_API_SECRET"`
+
+		// Оценки сигналов пользователями (👍/👎/«поздно»/«шум») и персональные пороги по ним
+		FeedbackEnabled        bool    `mapstructure:"SIGNAL_FEEDBACK_ENABLED"`
+		FeedbackWindowDays     int     `mapstructure:"SIGNAL_FEEDBACK_WINDOW_DAYS"`
+		FeedbackNoiseMinVotes  int     `mapstructure:"SIGNAL_FEEDBACK_NOISE_MIN_VOTES"`
+		FeedbackNoiseStep      float64 `mapstructure:"SIGNAL_FEEDBACK_NOISE_STEP"`
+		FeedbackNoiseMaxFactor float64 `mapstructure:"SIGNAL_FEEDBACK_NOISE_MAX_FACTOR"`
+	} `mapstructure:",squash"`
+
+	// ======================
+	// PAPER TRADING
+	// ======================
+	PaperTrading struct {
+		Enabled      bool    `mapstructure:"PAPER_TRADING_ENABLED"`
+		StartBalance float64 `mapstructure:"PAPER_TRADING_START_BALANCE"`
+		FeePct       float64 `mapstructure:"PAPER_TRADING_FEE_PCT"`        // комиссия за сторону сделки, %
+		MaxOpenLimit int     `mapstructure:"PAPER_TRADING_MAX_OPEN_LIMIT"` // предел лимита открытых позиций пользователя
+		RefreshSec   int     `mapstructure:"PAPER_TRADING_REFRESH_SEC"`    // как часто монитор перечитывает позиции
+	} `mapstructure:",squash"`
+
+	// ======================
+	// ВЫГРУЗКИ ДАННЫХ
+	// ======================
+	Export struct {
+		Enabled      bool   `mapstructure:"EXPORT_ENABLED"`
+		Dir          string `mapstructure:"EXPORT_DIR"`             // каталог выгрузок на сервере (пусто — только документы Telegram)
+		MaxRows      int64  `mapstructure:"EXPORT_MAX_ROWS"`        // предел строк одной выгрузки
+		MaxDocMB     int    `mapstructure:"EXPORT_MAX_DOCUMENT_MB"` // предел размера документа Telegram, МБ
+		MaxRangeDays int    `mapstructure:"EXPORT_MAX_RANGE_DAYS"`  // максимальная длина периода выгрузки
+	} `mapstructure:",squash"`
+
+	// ======================
+	// ДАЙДЖЕСТ РЫНКА
+	// ======================
+	MarketDigest struct {
+		Enabled      bool    `mapstructure:"MARKET_DIGEST_ENABLED"`
+		BuildTime    string  `mapstructure:"MARKET_DIGEST_BUILD_TIME"`     // время построения суточного дайджеста, ЧЧ:ММ UTC
+		SendTime     string  `mapstructure:"MARKET_DIGEST_SEND_TIME"`      // время доставки по умолчанию, ЧЧ:ММ по поясу пользователя
+		TopN         int     `mapstructure:"MARKET_DIGEST_TOP_N"`          // строк в каждом разделе
+		MinVolumeUSD float64 `mapstructure:"MARKET_DIGEST_MIN_VOLUME_USD"` // минимальный суточный оборот символа
+		Horizon      string  `mapstructure:"MARKET_DIGEST_HORIZON"`        // горизонт исходов сигналов
+	} `mapstructure:",squash"`
+
+	// ======================
+	// РЕЖИМ РЫНКА
+	// ======================
+	MarketRegime struct {
+		Enabled           bool    `mapstructure:"MARKET_REGIME_ENABLED"`
+		Interval          string  `mapstructure:"MARKET_REGIME_INTERVAL"`            // период пересчета режима
+		Benchmarks        string  `mapstructure:"MARKET_REGIME_BENCHMARKS"`          // бенчмарки тренда и волатильности через запятую
+		TrendChangePct    float64 `mapstructure:"MARKET_REGIME_TREND_CHANGE_PCT"`    // суточное изменение бенчмарков для тренда, %
+		BreadthUp         float64 `mapstructure:"MARKET_REGIME_BREADTH_UP"`          // доля символов выше VWAP для восходящего тренда
+		BreadthDown       float64 `mapstructure:"MARKET_REGIME_BREADTH_DOWN"`        // доля символов выше VWAP для нисходящего тренда
+		PanicVolatility   float64 `mapstructure:"MARKET_REGIME_PANIC_VOLATILITY"`    // суточная волатильность к недельной для паники
+		PanicDeclineShare float64 `mapstructure:"MARKET_REGIME_PANIC_DECLINE_SHARE"` // доля падающих символов для паники
+		MinVolumeUSD      float64 `mapstructure:"MARKET_REGIME_MIN_VOLUME_USD"`      // минимальный оборот символа для ширины рынка
+		ConfirmRuns       int     `mapstructure:"MARKET_REGIME_CONFIRM_RUNS"`        // расчетов подряд для смены режима
+	} `mapstructure:",squash"`
+
 	// ======================
 	// ШИНА СОБЫТИЙ
 	// ======================
@@ -177,11 +296,24 @@ type Config struct {
 	// ФИЛЬТРЫ СИГНАЛОВ
 	// ======================
 	SignalFilters struct {
-		Enabled          bool     `mapstructure:"SIGNAL_FILTERS_ENABLED"`
-		MinConfidence    float64  `mapstructure:"MIN_CONFIDENCE"`
-		MaxSignalsPerMin int      `mapstructure:"MAX_SIGNALS_PER_MIN"`
-		IncludePatterns  []string `mapstructure:"SIGNAL_INCLUDE_PATTERNS"`
-		ExcludePatterns  []string `mapstructure:"SIGNAL_EXCLUDE_PATTERNS"`
+		Enabled          bool          `mapstructure:"SIGNAL_FILTERS_ENABLED"`
+		Order            []string      `mapstructure:"SIGNAL_FILTER_ORDER"` // порядок фильтров в цепочке
+		MinConfidence    float64       `mapstructure:"MIN_CONFIDENCE"`
+		MaxSignalsPerMin int           `mapstructure:"MAX_SIGNALS_PER_MIN"`
+		IncludePatterns  []string      `mapstructure:"SIGNAL_INCLUDE_PATTERNS"`
+		ExcludePatterns  []string      `mapstructure:"SIGNAL_EXCLUDE_PATTERNS"`
+		Blacklist        []string      `mapstructure:"SIGNAL_BLACKLIST"`
+		MinTurnover      float64       `mapstructure:"SIGNAL_MIN_TURNOVER"` // оборот монеты за 24ч, $
+		MinVolume        float64       `mapstructure:"SIGNAL_MIN_VOLUME"`   // объём в сигнале
+		Cooldown         time.Duration `mapstructure:"SIGNAL_COOLDOWN"`
+
+		BlacklistEnabled   bool `mapstructure:"SIGNAL_FILTER_BLACKLIST_ENABLED"`
+		PatternEnabled     bool `mapstructure:"SIGNAL_FILTER_PATTERN_ENABLED"`
+		MinTurnoverEnabled bool `mapstructure:"SIGNAL_FILTER_MIN_TURNOVER_ENABLED"`
+		VolumeEnabled      bool `mapstructure:"SIGNAL_FILTER_VOLUME_ENABLED"`
+		ConfidenceEnabled  bool `mapstructure:"SIGNAL_FILTER_CONFIDENCE_ENABLED"`
+		CooldownEnabled    bool `mapstructure:"SIGNAL_FILTER_COOLDOWN_ENABLED"`
+		RateLimitEnabled   bool `mapstructure:"SIGNAL_FILTER_RATE_LIMIT_ENABLED"`
 	} `mapstructure:",squash"`
 
 	// ======================
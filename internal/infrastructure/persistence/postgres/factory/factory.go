@@ -9,8 +9,11 @@ import (
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/invoice"
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/payment"
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/plan"
+	price_alert_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/price_alert"
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/session"
+	signal_rule_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/signal_rule"
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/subscription"
+	symbol_sector_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/symbol_sector"
 	trading_session_repo "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/trading_session"
 	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/repository/users"
 	"crypto-exchange-screener-bot/pkg/logger"
@@ -32,6 +35,9 @@ type RepositoryFactory struct {
 	invoiceRepository        invoice.InvoiceRepository
 	paymentRepository        payment.PaymentRepository
 	tradingSessionRepository trading_session_repo.TradingSessionRepository
+	signalRuleRepository     signal_rule_repo.SignalRuleRepository
+	priceAlertRepository     price_alert_repo.PriceAlertRepository
+	symbolSectorRepository   symbol_sector_repo.SymbolSectorRepository
 	mu                       sync.RWMutex
 	initialized              bool
 }
@@ -294,6 +300,72 @@ func (rf *RepositoryFactory) CreateTradingSessionRepository() (trading_session_r
 	return rf.tradingSessionRepository, nil
 }
 
+// CreateSignalRuleRepository создает или возвращает репозиторий пользовательских правил сигналов
+func (rf *RepositoryFactory) CreateSignalRuleRepository() (signal_rule_repo.SignalRuleRepository, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if !rf.initialized {
+		return nil, fmt.Errorf("фабрика репозиториев не инициализирована")
+	}
+
+	if rf.signalRuleRepository == nil {
+		db := rf.db.GetDB()
+		if db == nil {
+			return nil, fmt.Errorf("соединение с базой данных не установлено")
+		}
+
+		rf.signalRuleRepository = signal_rule_repo.NewSignalRuleRepository(db)
+		logger.Info("✅ SignalRuleRepository создан")
+	}
+
+	return rf.signalRuleRepository, nil
+}
+
+// CreatePriceAlertRepository создает или возвращает репозиторий ценовых алертов
+func (rf *RepositoryFactory) CreatePriceAlertRepository() (price_alert_repo.PriceAlertRepository, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if !rf.initialized {
+		return nil, fmt.Errorf("фабрика репозиториев не инициализирована")
+	}
+
+	if rf.priceAlertRepository == nil {
+		db := rf.db.GetDB()
+		if db == nil {
+			return nil, fmt.Errorf("соединение с базой данных не установлено")
+		}
+
+		rf.priceAlertRepository = price_alert_repo.NewPriceAlertRepository(db)
+		logger.Info("✅ PriceAlertRepository создан")
+	}
+
+	return rf.priceAlertRepository, nil
+}
+
+// CreateSymbolSectorRepository создает или возвращает репозиторий секторов символов
+func (rf *RepositoryFactory) CreateSymbolSectorRepository() (symbol_sector_repo.SymbolSectorRepository, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if !rf.initialized {
+		return nil, fmt.Errorf("фабрика репозиториев не инициализирована")
+	}
+
+	if rf.symbolSectorRepository == nil {
+		db := rf.db.GetDB()
+		if db == nil {
+			return nil, fmt.Errorf("соединение с базой данных не установлено")
+		}
+
+		rf.symbolSectorRepository = symbol_sector_repo.NewSymbolSectorRepository(db)
+		logger.Info("✅ SymbolSectorRepository создан")
+	}
+
+	return rf.symbolSectorRepository, nil
+}
+
 // GetAllRepositories создает и возвращает все репозитории
 func (rf *RepositoryFactory) GetAllRepositories() (map[string]interface{}, error) {
 	rf.mu.Lock()
@@ -353,6 +425,21 @@ func (rf *RepositoryFactory) GetAllRepositories() (map[string]interface{}, error
 		logger.Warn("⚠️ Не удалось создать CreateTradingSessionRepository: %v", err)
 	}
 
+	repositories["CreateSignalRuleRepository"], err = rf.CreateSignalRuleRepository()
+	if err != nil {
+		logger.Warn("⚠️ Не удалось создать CreateSignalRuleRepository: %v", err)
+	}
+
+	repositories["CreatePriceAlertRepository"], err = rf.CreatePriceAlertRepository()
+	if err != nil {
+		logger.Warn("⚠️ Не удалось создать CreatePriceAlertRepository: %v", err)
+	}
+
+	repositories["CreateSymbolSectorRepository"], err = rf.CreateSymbolSectorRepository()
+	if err != nil {
+		logger.Warn("⚠️ Не удалось создать CreateSymbolSectorRepository: %v", err)
+	}
+
 	logger.Info("✅ Все репозитории PostgreSQL созданы")
 	return repositories, nil
 }
@@ -394,6 +481,9 @@ func (rf *RepositoryFactory) GetHealthStatus() map[string]interface{} {
 		"payment_repository_ready":         rf.paymentRepository != nil,
 		"plan_repository_ready":            rf.planRepository != nil,
 		"trading_session_repository_ready": rf.tradingSessionRepository != nil,
+		"signal_rule_repository_ready":     rf.signalRuleRepository != nil,
+		"price_alert_repository_ready":     rf.priceAlertRepository != nil,
+		"symbol_sector_repository_ready":   rf.symbolSectorRepository != nil,
 	}
 
 	// Добавляем статус базы данных если она доступна
@@ -441,6 +531,9 @@ func (rf *RepositoryFactory) Reset() {
 	rf.paymentRepository = nil
 	rf.planRepository = nil
 	rf.tradingSessionRepository = nil
+	rf.signalRuleRepository = nil
+	rf.priceAlertRepository = nil
+	rf.symbolSectorRepository = nil
 	rf.initialized = false
 
 	logger.Info("🔄 Фабрика репозиториев сброшена")
@@ -501,6 +594,21 @@ func (rf *RepositoryFactory) GetRepository(name string) (interface{}, error) {
 			return nil, fmt.Errorf("TradingSessionRepository еще не создан")
 		}
 		return rf.tradingSessionRepository, nil
+	case "SignalRuleRepository":
+		if rf.signalRuleRepository == nil {
+			return nil, fmt.Errorf("SignalRuleRepository еще не создан")
+		}
+		return rf.signalRuleRepository, nil
+	case "PriceAlertRepository":
+		if rf.priceAlertRepository == nil {
+			return nil, fmt.Errorf("PriceAlertRepository еще не создан")
+		}
+		return rf.priceAlertRepository, nil
+	case "SymbolSectorRepository":
+		if rf.symbolSectorRepository == nil {
+			return nil, fmt.Errorf("SymbolSectorRepository еще не создан")
+		}
+		return rf.symbolSectorRepository, nil
 	default:
 		return nil, fmt.Errorf("неизвестный репозиторий: %s", name)
 	}
@@ -534,6 +642,12 @@ func (rf *RepositoryFactory) HasRepository(name string) bool {
 		return rf.planRepository != nil
 	case "TradingSessionRepository":
 		return rf.tradingSessionRepository != nil
+	case "SignalRuleRepository":
+		return rf.signalRuleRepository != nil
+	case "PriceAlertRepository":
+		return rf.priceAlertRepository != nil
+	case "SymbolSectorRepository":
+		return rf.symbolSectorRepository != nil
 	default:
 		return false
 	}
@@ -0,0 +1,4 @@
+-- Минимальная оценка согласованности сигнала со старшими таймфреймами (0..100).
+-- 0 = фильтр отключён (поведение по умолчанию, обратная совместимость).
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS min_confluence_score DECIMAL(5,2) NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Скрывать сигналы, движение которых полностью объясняется движением BTC/ETH (с учётом беты).
+-- FALSE = получать все сигналы (поведение по умолчанию, обратная совместимость).
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS suppress_market_moves BOOLEAN NOT NULL DEFAULT FALSE;
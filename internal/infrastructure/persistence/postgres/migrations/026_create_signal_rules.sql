@@ -0,0 +1,21 @@
+-- Пользовательские правила сигналов: выражение на декларативном языке
+-- (например: change(15m) > 3 AND oi_change(1h) > 5 AND rsi(1h) < 70).
+-- Правила проверяются при закрытии свечи; лимит на количество — по тарифу.
+CREATE TABLE IF NOT EXISTS signal_rules (
+    id                SERIAL PRIMARY KEY,
+    user_id           INTEGER      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name              VARCHAR(100) NOT NULL DEFAULT '',
+    expression        TEXT         NOT NULL,
+    is_active         BOOLEAN      NOT NULL DEFAULT TRUE,
+    cooldown_minutes  INTEGER      NOT NULL DEFAULT 60,
+    trigger_count     INTEGER      NOT NULL DEFAULT 0,
+    last_triggered_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at        TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_signal_rules_user_id
+    ON signal_rules (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_signal_rules_active
+    ON signal_rules (is_active) WHERE is_active = TRUE;
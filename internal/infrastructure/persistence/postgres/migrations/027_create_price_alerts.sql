@@ -0,0 +1,29 @@
+-- Ценовые алерты пользователей: пересечение уровня вверх/вниз, вход цены
+-- в диапазон и отклонение на X% от опорной цены.
+-- Рабочий индекс срабатываний хранится в Redis (ZSET по цене на символ)
+-- и перестраивается из этой таблицы при запуске.
+CREATE TABLE IF NOT EXISTS price_alerts (
+    id                SERIAL PRIMARY KEY,
+    user_id           INTEGER          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    symbol            VARCHAR(30)      NOT NULL,
+    alert_type        VARCHAR(20)      NOT NULL,
+    price             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price_high        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    percent           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reference_price   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    recurring         BOOLEAN          NOT NULL DEFAULT FALSE,
+    is_active         BOOLEAN          NOT NULL DEFAULT TRUE,
+    trigger_count     INTEGER          NOT NULL DEFAULT 0,
+    last_triggered_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at        TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT price_alerts_type_check
+        CHECK (alert_type IN ('cross_up', 'cross_down', 'range', 'percent'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_alerts_user_id
+    ON price_alerts (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_price_alerts_active
+    ON price_alerts (is_active) WHERE is_active = TRUE;
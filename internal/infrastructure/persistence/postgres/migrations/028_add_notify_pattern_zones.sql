@@ -0,0 +1,4 @@
+-- Уведомления о разворотных свечных паттернах у сильных зон S/R
+-- (например, молот на сильной поддержке). FALSE = подписка выключена.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS notify_pattern_zones BOOLEAN NOT NULL DEFAULT FALSE;
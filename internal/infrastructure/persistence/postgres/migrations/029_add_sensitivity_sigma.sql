@@ -0,0 +1,4 @@
+-- Чувствительность z-score сигналов: порог в сигмах относительно истории
+-- доходностей символа (2.0, 2.5, 3.0, 4.0). 0 = сигналы выключены.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS sensitivity_sigma DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+-- Секторы для рейтинга относительной силы и дайджеста ротаций (/top).
+-- Если таблица пуста, используются секторы из STRENGTH_SECTORS.
+CREATE TABLE IF NOT EXISTS symbol_sectors (
+    name       VARCHAR(30) PRIMARY KEY,
+    title      VARCHAR(50) NOT NULL,
+    symbols    TEXT[]      NOT NULL DEFAULT '{}',
+    sort_order INTEGER     NOT NULL DEFAULT 0,
+    is_active  BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO symbol_sectors (name, title, symbols, sort_order) VALUES
+    ('l1', 'L1', ARRAY['BTCUSDT', 'ETHUSDT', 'SOLUSDT', 'AVAXUSDT', 'ADAUSDT', 'DOTUSDT', 'NEARUSDT', 'APTUSDT', 'SUIUSDT', 'TONUSDT'], 1),
+    ('memes', 'Мемы', ARRAY['DOGEUSDT', '1000PEPEUSDT', 'SHIB1000USDT', 'WIFUSDT', '1000BONKUSDT', '1000FLOKIUSDT'], 2),
+    ('ai', 'AI', ARRAY['FETUSDT', 'RENDERUSDT', 'TAOUSDT', 'WLDUSDT', 'ARKMUSDT'], 3),
+    ('defi', 'DeFi', ARRAY['UNIUSDT', 'AAVEUSDT', 'MKRUSDT', 'LDOUSDT', 'CRVUSDT', 'COMPUSDT', 'DYDXUSDT', 'PENDLEUSDT'], 4)
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,4 @@
+-- Дайджест ротаций между секторами (лидеры/аутсайдеры по относительной силе).
+-- FALSE = подписка выключена.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS notify_sector_digest BOOLEAN NOT NULL DEFAULT FALSE;
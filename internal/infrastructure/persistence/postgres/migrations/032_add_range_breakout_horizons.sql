@@ -0,0 +1,4 @@
+-- Горизонты алертов о пробое диапазона: '1d', '7d', '30d', '90d', 'all'.
+-- Пустой массив = алерты о пробоях выключены.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS range_breakout_horizons TEXT[] NOT NULL DEFAULT '{}';
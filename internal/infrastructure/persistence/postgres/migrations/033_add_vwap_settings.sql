@@ -0,0 +1,5 @@
+-- Алерты анализатора VWAP (отклонения ±Nσ, reclaim/loss) и якорь пользовательского VWAP.
+-- vwap_anchor_at = NULL — якорь не задан, /vwap показывает только VWAP дневной сессии.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS notify_vwap BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS vwap_anchor_at TIMESTAMPTZ NULL;
@@ -0,0 +1,42 @@
+-- Журнал сигналов: каждый опубликованный analysis.Signal с контекстом и исходом.
+-- Контекст counter-сигналов (индикаторы, OI, дельта, S/R) дописывается в context
+-- по signal_id из события counter_signal_detected.
+--
+-- ret_* — изменение цены в % от entry_price через N минут после сигнала (без учета направления).
+-- mfe_pct / mae_pct — максимальное благоприятное / неблагоприятное движение в % за окно до 24ч
+-- с учетом направления сигнала (оба >= 0). Считаются по 5-минутным свечам.
+CREATE TABLE IF NOT EXISTS signals (
+    id              BIGSERIAL PRIMARY KEY,
+    signal_id       VARCHAR(64)  NOT NULL UNIQUE,
+    symbol          VARCHAR(30)  NOT NULL,
+    signal_type     VARCHAR(50)  NOT NULL DEFAULT '',
+    direction       VARCHAR(20)  NOT NULL DEFAULT '',
+    strategy        VARCHAR(50)  NOT NULL DEFAULT '',
+    period_minutes  INTEGER      NOT NULL DEFAULT 0,
+    confidence      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    change_percent  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    entry_price     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    signal_time     TIMESTAMP WITH TIME ZONE NOT NULL,
+    indicators      JSONB        NOT NULL DEFAULT '{}',
+    context         JSONB        NOT NULL DEFAULT '{}',
+    tags            TEXT[]       NOT NULL DEFAULT '{}',
+
+    ret_5m          DOUBLE PRECISION NULL,
+    ret_15m         DOUBLE PRECISION NULL,
+    ret_1h          DOUBLE PRECISION NULL,
+    ret_4h          DOUBLE PRECISION NULL,
+    ret_24h         DOUBLE PRECISION NULL,
+    mfe_pct         DOUBLE PRECISION NULL,
+    mae_pct         DOUBLE PRECISION NULL,
+    -- pending — исход еще считается, complete — все горизонты заполнены,
+    -- no_data — свечей за окно сигнала нет (символ пропал из потока, история вытеснена)
+    outcome_status  VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    evaluated_until TIMESTAMP WITH TIME ZONE NULL,
+
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_signals_pending ON signals(signal_time) WHERE outcome_status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_signals_symbol_time ON signals(symbol, signal_time DESC);
+CREATE INDEX IF NOT EXISTS idx_signals_strategy_time ON signals(strategy, signal_time DESC);
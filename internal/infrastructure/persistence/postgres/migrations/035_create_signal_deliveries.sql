@@ -0,0 +1,20 @@
+-- Доставки сигналов пользователям: что именно и когда получил каждый пользователь
+-- в Telegram или MAX. signal_id связывает доставку с журналом signals (исходы),
+-- card_text — текст отправленной карточки для повторного показа из истории.
+CREATE TABLE IF NOT EXISTS signal_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         INTEGER      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform        VARCHAR(16)  NOT NULL, -- telegram, max
+    signal_id       VARCHAR(64)  NOT NULL DEFAULT '',
+    symbol          VARCHAR(30)  NOT NULL,
+    direction       VARCHAR(20)  NOT NULL DEFAULT '',
+    period_minutes  INTEGER      NOT NULL DEFAULT 0,
+    change_percent  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    card_text       TEXT         NOT NULL DEFAULT '',
+    delivered_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_signal_deliveries_user_time ON signal_deliveries(user_id, platform, delivered_at DESC);
+CREATE INDEX IF NOT EXISTS idx_signal_deliveries_user_symbol ON signal_deliveries(user_id, symbol, delivered_at DESC);
+CREATE INDEX IF NOT EXISTS idx_signal_deliveries_signal_id ON signal_deliveries(signal_id) WHERE signal_id <> '';
@@ -0,0 +1,3 @@
+-- Аналитика исходов агрегирует журнал по окну времени без фильтра по символу
+-- или стратегии: отдельный индекс по signal_time.
+CREATE INDEX IF NOT EXISTS idx_signals_time ON signals(signal_time DESC);
@@ -0,0 +1,21 @@
+-- Оценки сигналов пользователями: кнопки 👍/👎/«поздно»/«шум» под карточкой сигнала.
+-- Один голос пользователя на сигнал (повторное нажатие заменяет голос).
+-- Символ, направление, период и стратегия копируются из доставки и журнала,
+-- чтобы оценки переживали очистку signals по сроку хранения.
+CREATE TABLE IF NOT EXISTS signal_feedback (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         INTEGER      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform        VARCHAR(16)  NOT NULL, -- telegram, max
+    signal_id       VARCHAR(64)  NOT NULL,
+    symbol          VARCHAR(30)  NOT NULL,
+    direction       VARCHAR(20)  NOT NULL DEFAULT '',
+    period_minutes  INTEGER      NOT NULL DEFAULT 0,
+    strategy        VARCHAR(64)  NOT NULL DEFAULT '',
+    vote            VARCHAR(16)  NOT NULL, -- up, down, late, noise
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, signal_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_signal_feedback_time ON signal_feedback(updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_signal_feedback_user_symbol ON signal_feedback(user_id, symbol, updated_at DESC);
@@ -0,0 +1,50 @@
+-- Paper trading: виртуальный портфель пользователя, который открывает позиции
+-- по полученным сигналам по правилам пользователя и закрывает их по TP/SL/таймауту.
+
+-- Правила портфеля (одна строка на пользователя)
+CREATE TABLE IF NOT EXISTS paper_settings (
+    user_id          INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled          BOOLEAN          NOT NULL DEFAULT FALSE,
+    direction        VARCHAR(8)       NOT NULL DEFAULT 'both', -- both, long, short
+    size_value       DOUBLE PRECISION NOT NULL DEFAULT 100,
+    size_percent     BOOLEAN          NOT NULL DEFAULT FALSE,  -- size_value в % от капитала, иначе $
+    take_profit      DOUBLE PRECISION NOT NULL DEFAULT 2,
+    take_profit_atr  BOOLEAN          NOT NULL DEFAULT FALSE,  -- take_profit в ATR, иначе в %
+    stop_loss        DOUBLE PRECISION NOT NULL DEFAULT 1,
+    stop_loss_atr    BOOLEAN          NOT NULL DEFAULT FALSE,  -- stop_loss в ATR, иначе в %
+    timeout_minutes  INTEGER          NOT NULL DEFAULT 240,    -- 0 — без таймаута
+    max_open         INTEGER          NOT NULL DEFAULT 5,
+    start_balance    DOUBLE PRECISION NOT NULL DEFAULT 10000,
+    started_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(), -- начало текущего портфеля (сброс)
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Виртуальные позиции
+CREATE TABLE IF NOT EXISTS paper_positions (
+    id                BIGSERIAL PRIMARY KEY,
+    user_id           INTEGER          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    signal_id         VARCHAR(64)      NOT NULL DEFAULT '',
+    platform          VARCHAR(16)      NOT NULL DEFAULT '',
+    symbol            VARCHAR(30)      NOT NULL,
+    side              VARCHAR(8)       NOT NULL, -- long, short
+    period_minutes    INTEGER          NOT NULL DEFAULT 0,
+    entry_price       DOUBLE PRECISION NOT NULL,
+    quantity          DOUBLE PRECISION NOT NULL,
+    size_usd          DOUBLE PRECISION NOT NULL,
+    take_profit_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    stop_loss_price   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    opened_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at        TIMESTAMP WITH TIME ZONE,
+    status            VARCHAR(8)       NOT NULL DEFAULT 'open', -- open, closed
+    exit_price        DOUBLE PRECISION,
+    exit_reason       VARCHAR(16),     -- tp, sl, timeout, manual
+    closed_at         TIMESTAMP WITH TIME ZONE,
+    pnl_usd           DOUBLE PRECISION,
+    pnl_pct           DOUBLE PRECISION
+);
+
+-- Один сигнал — одна позиция (сигнал может прийти пользователю и в Telegram, и в MAX)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_paper_positions_user_signal ON paper_positions(user_id, signal_id) WHERE signal_id <> '';
+CREATE INDEX IF NOT EXISTS idx_paper_positions_open ON paper_positions(status) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_paper_positions_user_closed ON paper_positions(user_id, closed_at);
@@ -0,0 +1,20 @@
+-- Выгрузки данных (CSV / JSON Lines): сигналы с исходами, свечи, активность пользователя.
+-- Записи нужны для дневного лимита тарифа и аудита выгрузок.
+CREATE TABLE IF NOT EXISTS data_exports (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     INTEGER     NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind        VARCHAR(16) NOT NULL, -- signals, candles, activity
+    format      VARCHAR(8)  NOT NULL, -- csv, jsonl
+    target      VARCHAR(16) NOT NULL, -- telegram, directory
+    params      JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    status      VARCHAR(16) NOT NULL DEFAULT 'running', -- running, done, failed
+    row_count   BIGINT      NOT NULL DEFAULT 0,
+    byte_count  BIGINT      NOT NULL DEFAULT 0,
+    truncated   BOOLEAN     NOT NULL DEFAULT FALSE,
+    file_path   TEXT        NOT NULL DEFAULT '',
+    error       TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_created ON data_exports(user_id, created_at DESC);
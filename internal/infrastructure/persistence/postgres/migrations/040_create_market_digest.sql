@@ -0,0 +1,33 @@
+-- Дайджест рынка: суточные и недельные сводки (лидеры роста и падения, изменения OI,
+-- крайние фандинги, ликвидации, самые частые монеты в сигналах и исходы сигналов).
+-- Сводка строится задачей планировщика раз в сутки и рассылается подписчикам
+-- в выбранное ими время по их часовому поясу.
+
+-- Построенные дайджесты; snapshot — цены и OI на момент построения
+-- для расчета изменений в следующем дайджесте
+CREATE TABLE IF NOT EXISTS market_digests (
+    id           BIGSERIAL PRIMARY KEY,
+    kind         VARCHAR(8)  NOT NULL, -- daily, weekly
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end   TIMESTAMP WITH TIME ZONE NOT NULL,
+    data         JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    snapshot     JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_market_digests_kind_end ON market_digests(kind, period_end);
+
+-- Подписки пользователей на дайджест (одна строка на пользователя)
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id          INTEGER  PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    daily            BOOLEAN  NOT NULL DEFAULT FALSE,
+    weekly           BOOLEAN  NOT NULL DEFAULT FALSE,
+    send_minute      SMALLINT NOT NULL DEFAULT 540, -- время доставки: минуты от полуночи по часовому поясу пользователя
+    weekday          SMALLINT NOT NULL DEFAULT 1,   -- день недельного дайджеста: 0 — воскресенье … 6 — суббота
+    last_daily_sent  DATE,                          -- локальная дата последней доставки
+    last_weekly_sent DATE,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_digest_subscriptions_enabled ON digest_subscriptions(user_id) WHERE daily OR weekly;
@@ -0,0 +1,4 @@
+-- Множители порогов роста/падения пользователя по режиму рынка (trend_up/trend_down/range/panic).
+-- Формат: "panic=2,range=1.2"; пустая строка — пороги не зависят от режима.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS regime_thresholds TEXT NOT NULL DEFAULT '';
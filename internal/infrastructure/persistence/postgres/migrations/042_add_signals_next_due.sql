@@ -0,0 +1,10 @@
+-- Время следующей оценки исхода сигнала: наступление ближайшего незаполненного горизонта
+-- или повторная попытка, если свеча горизонта еще не пришла. NULL — сигнал еще не оценивался.
+-- Оценщик выбирает сигналы по next_due_at, а не по signal_time, чтобы старые сигналы
+-- с разрывами истории не вытесняли новые из пачки.
+ALTER TABLE signals
+    ADD COLUMN IF NOT EXISTS next_due_at TIMESTAMP WITH TIME ZONE NULL;
+
+DROP INDEX IF EXISTS idx_signals_pending;
+CREATE INDEX IF NOT EXISTS idx_signals_pending_due
+    ON signals(next_due_at NULLS FIRST, signal_time) WHERE outcome_status = 'pending';
@@ -0,0 +1,3 @@
+-- Алерты о снятии крупной стены стакана рядом с ценой (трекер стен).
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS notify_wall_pulls BOOLEAN NOT NULL DEFAULT FALSE;
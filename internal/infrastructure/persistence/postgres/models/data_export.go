@@ -0,0 +1,32 @@
+// internal/infrastructure/persistence/postgres/models/data_export.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Статусы выгрузок данных
+const (
+	DataExportRunning = "running"
+	DataExportDone    = "done"
+	DataExportFailed  = "failed"
+)
+
+// DataExport выгрузка данных пользователем (CSV / JSON Lines)
+type DataExport struct {
+	ID         int64           `db:"id"          json:"id"`
+	UserID     int             `db:"user_id"     json:"user_id"`
+	Kind       string          `db:"kind"        json:"kind"`   // signals, candles, activity
+	Format     string          `db:"format"      json:"format"` // csv, jsonl
+	Target     string          `db:"target"      json:"target"` // telegram, directory
+	Params     json.RawMessage `db:"params"      json:"params"`
+	Status     string          `db:"status"      json:"status"`
+	Rows       int64           `db:"row_count"   json:"rows"`
+	Bytes      int64           `db:"byte_count"  json:"bytes"`
+	Truncated  bool            `db:"truncated"   json:"truncated"`
+	FilePath   string          `db:"file_path"   json:"file_path"`
+	Error      string          `db:"error"       json:"error"`
+	CreatedAt  time.Time       `db:"created_at"  json:"created_at"`
+	FinishedAt *time.Time      `db:"finished_at" json:"finished_at,omitempty"`
+}
@@ -0,0 +1,40 @@
+// internal/infrastructure/persistence/postgres/models/market_digest.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Виды дайджеста рынка
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// MarketDigest построенный дайджест рынка
+type MarketDigest struct {
+	ID          int64           `db:"id"           json:"id"`
+	Kind        string          `db:"kind"         json:"kind"`
+	PeriodStart time.Time       `db:"period_start" json:"period_start"`
+	PeriodEnd   time.Time       `db:"period_end"   json:"period_end"`
+	Data        json.RawMessage `db:"data"         json:"data"`     // types.MarketDigestData
+	Snapshot    json.RawMessage `db:"snapshot"     json:"snapshot"` // цены и OI на момент построения
+	CreatedAt   time.Time       `db:"created_at"   json:"created_at"`
+}
+
+// DigestSubscription подписка пользователя на дайджест рынка
+type DigestSubscription struct {
+	UserID         int        `db:"user_id"          json:"user_id"`
+	Daily          bool       `db:"daily"            json:"daily"`
+	Weekly         bool       `db:"weekly"           json:"weekly"`
+	SendMinute     int        `db:"send_minute"      json:"send_minute"` // минуты от полуночи по часовому поясу пользователя
+	Weekday        int        `db:"weekday"          json:"weekday"`     // 0 — воскресенье … 6 — суббота
+	LastDailySent  *time.Time `db:"last_daily_sent"  json:"last_daily_sent,omitempty"`
+	LastWeeklySent *time.Time `db:"last_weekly_sent" json:"last_weekly_sent,omitempty"`
+	CreatedAt      time.Time  `db:"created_at"       json:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"       json:"updated_at"`
+
+	// Timezone — часовой пояс пользователя (users.timezone, только для чтения)
+	Timezone string `db:"timezone" json:"timezone,omitempty"`
+}
@@ -0,0 +1,71 @@
+// internal/infrastructure/persistence/postgres/models/paper_trading.go
+package models
+
+import "time"
+
+// Направления и статусы виртуальных позиций
+const (
+	PaperSideLong  = "long"
+	PaperSideShort = "short"
+
+	PaperStatusOpen   = "open"
+	PaperStatusClosed = "closed"
+
+	PaperDirectionBoth = "both"
+)
+
+// Причины закрытия виртуальных позиций
+const (
+	PaperExitTakeProfit = "tp"
+	PaperExitStopLoss   = "sl"
+	PaperExitTimeout    = "timeout"
+	PaperExitManual     = "manual"
+)
+
+// PaperSettings правила paper-портфеля пользователя
+type PaperSettings struct {
+	UserID         int       `db:"user_id"         json:"user_id"`
+	Enabled        bool      `db:"enabled"         json:"enabled"`
+	Direction      string    `db:"direction"       json:"direction"` // both, long, short
+	SizeValue      float64   `db:"size_value"      json:"size_value"`
+	SizePercent    bool      `db:"size_percent"    json:"size_percent"` // SizeValue в % от капитала, иначе $
+	TakeProfit     float64   `db:"take_profit"     json:"take_profit"`
+	TakeProfitATR  bool      `db:"take_profit_atr" json:"take_profit_atr"` // TakeProfit в ATR, иначе в %
+	StopLoss       float64   `db:"stop_loss"       json:"stop_loss"`
+	StopLossATR    bool      `db:"stop_loss_atr"   json:"stop_loss_atr"`   // StopLoss в ATR, иначе в %
+	TimeoutMinutes int       `db:"timeout_minutes" json:"timeout_minutes"` // 0 — без таймаута
+	MaxOpen        int       `db:"max_open"        json:"max_open"`
+	StartBalance   float64   `db:"start_balance"   json:"start_balance"`
+	StartedAt      time.Time `db:"started_at"      json:"started_at"`
+	CreatedAt      time.Time `db:"created_at"      json:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"      json:"updated_at"`
+}
+
+// PaperPosition виртуальная позиция paper-портфеля
+type PaperPosition struct {
+	ID              int64      `db:"id"                json:"id"`
+	UserID          int        `db:"user_id"           json:"user_id"`
+	SignalID        string     `db:"signal_id"         json:"signal_id"`
+	Platform        string     `db:"platform"          json:"platform"`
+	Symbol          string     `db:"symbol"            json:"symbol"`
+	Side            string     `db:"side"              json:"side"`
+	PeriodMinutes   int        `db:"period_minutes"    json:"period_minutes"`
+	EntryPrice      float64    `db:"entry_price"       json:"entry_price"`
+	Quantity        float64    `db:"quantity"          json:"quantity"`
+	SizeUSD         float64    `db:"size_usd"          json:"size_usd"`
+	TakeProfitPrice float64    `db:"take_profit_price" json:"take_profit_price"` // 0 — без TP
+	StopLossPrice   float64    `db:"stop_loss_price"   json:"stop_loss_price"`   // 0 — без SL
+	OpenedAt        time.Time  `db:"opened_at"         json:"opened_at"`
+	ExpiresAt       *time.Time `db:"expires_at"        json:"expires_at,omitempty"`
+	Status          string     `db:"status"            json:"status"`
+	ExitPrice       *float64   `db:"exit_price"        json:"exit_price,omitempty"`
+	ExitReason      *string    `db:"exit_reason"       json:"exit_reason,omitempty"`
+	ClosedAt        *time.Time `db:"closed_at"         json:"closed_at,omitempty"`
+	PnLUSD          *float64   `db:"pnl_usd"           json:"pnl_usd,omitempty"`
+	PnLPct          *float64   `db:"pnl_pct"           json:"pnl_pct,omitempty"`
+}
+
+// IsLong возвращает true для длинной позиции
+func (p *PaperPosition) IsLong() bool {
+	return p.Side == PaperSideLong
+}
@@ -81,6 +81,96 @@ func (p *Plan) getDefaultAPIRequests() int {
 	}
 }
 
+// GetMaxRules возвращает лимит пользовательских правил сигналов для плана
+func (p *Plan) GetMaxRules() int {
+	features, err := p.GetFeatures()
+	if err != nil {
+		return p.getDefaultRules()
+	}
+
+	if rules, ok := features["max_rules"].(float64); ok {
+		return int(rules)
+	}
+
+	return p.getDefaultRules()
+}
+
+// getDefaultRules возвращает лимит правил по умолчанию
+func (p *Plan) getDefaultRules() int {
+	switch p.Code {
+	case PlanFree:
+		return 1
+	case PlanBasic:
+		return 3
+	case PlanPro, PlanTest:
+		return 10
+	case PlanEnterprise:
+		return -1 // неограниченно
+	default:
+		return 1
+	}
+}
+
+// GetMaxAlerts возвращает лимит активных ценовых алертов для плана
+func (p *Plan) GetMaxAlerts() int {
+	features, err := p.GetFeatures()
+	if err != nil {
+		return p.getDefaultAlerts()
+	}
+
+	if alerts, ok := features["max_alerts"].(float64); ok {
+		return int(alerts)
+	}
+
+	return p.getDefaultAlerts()
+}
+
+// getDefaultAlerts возвращает лимит ценовых алертов по умолчанию
+func (p *Plan) getDefaultAlerts() int {
+	switch p.Code {
+	case PlanFree:
+		return 3
+	case PlanBasic:
+		return 10
+	case PlanPro, PlanTest:
+		return 50
+	case PlanEnterprise:
+		return -1 // неограниченно
+	default:
+		return 3
+	}
+}
+
+// GetMaxExportsPerDay возвращает лимит выгрузок данных в сутки для плана (0 — выгрузки недоступны)
+func (p *Plan) GetMaxExportsPerDay() int {
+	features, err := p.GetFeatures()
+	if err != nil {
+		return p.getDefaultExportsPerDay()
+	}
+
+	if exports, ok := features["max_exports_per_day"].(float64); ok {
+		return int(exports)
+	}
+
+	return p.getDefaultExportsPerDay()
+}
+
+// getDefaultExportsPerDay возвращает лимит выгрузок по умолчанию
+func (p *Plan) getDefaultExportsPerDay() int {
+	switch p.Code {
+	case PlanFree:
+		return 0
+	case PlanBasic:
+		return 3
+	case PlanPro, PlanTest:
+		return 20
+	case PlanEnterprise:
+		return -1 // неограниченно
+	default:
+		return 0
+	}
+}
+
 // GetStarsPrice возвращает цену в Stars в зависимости от периода
 func (p *Plan) GetStarsPrice(isYearly bool) int {
 	if isYearly && p.StarsPriceYearly > 0 {
@@ -0,0 +1,30 @@
+// internal/infrastructure/persistence/postgres/models/price_alert.go
+package models
+
+import "time"
+
+// Типы ценовых алертов
+const (
+	PriceAlertCrossUp   = "cross_up"   // цена поднялась до уровня Price
+	PriceAlertCrossDown = "cross_down" // цена опустилась до уровня Price
+	PriceAlertRange     = "range"      // цена вошла в диапазон [Price, PriceHigh]
+	PriceAlertPercent   = "percent"    // цена отклонилась на Percent% от ReferencePrice
+)
+
+// PriceAlert ценовой алерт пользователя
+type PriceAlert struct {
+	ID              int        `db:"id"                json:"id"`
+	UserID          int        `db:"user_id"           json:"user_id"`
+	Symbol          string     `db:"symbol"            json:"symbol"`
+	AlertType       string     `db:"alert_type"        json:"alert_type"`
+	Price           float64    `db:"price"             json:"price"`
+	PriceHigh       float64    `db:"price_high"        json:"price_high"`
+	Percent         float64    `db:"percent"           json:"percent"`
+	ReferencePrice  float64    `db:"reference_price"   json:"reference_price"`
+	Recurring       bool       `db:"recurring"         json:"recurring"`
+	IsActive        bool       `db:"is_active"         json:"is_active"`
+	TriggerCount    int        `db:"trigger_count"     json:"trigger_count"`
+	LastTriggeredAt *time.Time `db:"last_triggered_at" json:"last_triggered_at,omitempty"`
+	CreatedAt       time.Time  `db:"created_at"        json:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"        json:"updated_at"`
+}
@@ -0,0 +1,22 @@
+// internal/infrastructure/persistence/postgres/models/signal_analytics.go
+package models
+
+// SignalGroupStats агрегированные исходы группы сигналов журнала.
+// Доходности, MFE и MAE — в % по направлению сигнала.
+type SignalGroupStats struct {
+	Key       string  `db:"key"        json:"key"`
+	Signals   int     `db:"signals"    json:"signals"`
+	Evaluated int     `db:"evaluated"  json:"evaluated"` // сигналы с доходностью на горизонте
+	Hits      int     `db:"hits"       json:"hits"`      // доходность на горизонте > 0
+	AvgReturn float64 `db:"avg_return" json:"avg_return"`
+	AvgMFE    float64 `db:"avg_mfe"    json:"avg_mfe"`
+	AvgMAE    float64 `db:"avg_mae"    json:"avg_mae"`
+}
+
+// HitRate возвращает долю прибыльных сигналов среди оцененных, %
+func (s *SignalGroupStats) HitRate() float64 {
+	if s.Evaluated == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Evaluated) * 100
+}
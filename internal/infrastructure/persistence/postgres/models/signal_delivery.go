@@ -0,0 +1,34 @@
+// internal/infrastructure/persistence/postgres/models/signal_delivery.go
+package models
+
+import "time"
+
+// SignalDelivery доставка сигнала пользователю
+type SignalDelivery struct {
+	ID            int64     `db:"id"             json:"id"`
+	UserID        int       `db:"user_id"        json:"user_id"`
+	Platform      string    `db:"platform"       json:"platform"`
+	SignalID      string    `db:"signal_id"      json:"signal_id"`
+	Symbol        string    `db:"symbol"         json:"symbol"`
+	Direction     string    `db:"direction"      json:"direction"`
+	PeriodMinutes int       `db:"period_minutes" json:"period_minutes"`
+	ChangePercent float64   `db:"change_percent" json:"change_percent"`
+	Price         float64   `db:"price"          json:"price"`
+	CardText      string    `db:"card_text"      json:"card_text"`
+	DeliveredAt   time.Time `db:"delivered_at"   json:"delivered_at"`
+}
+
+// SignalHistoryItem доставка с исходом сигнала из журнала (поля исхода nil, если сигнала нет в журнале)
+type SignalHistoryItem struct {
+	SignalDelivery
+
+	EntryPrice    *float64 `db:"entry_price"    json:"entry_price,omitempty"`
+	Ret5m         *float64 `db:"ret_5m"         json:"ret_5m,omitempty"`
+	Ret15m        *float64 `db:"ret_15m"        json:"ret_15m,omitempty"`
+	Ret1h         *float64 `db:"ret_1h"         json:"ret_1h,omitempty"`
+	Ret4h         *float64 `db:"ret_4h"         json:"ret_4h,omitempty"`
+	Ret24h        *float64 `db:"ret_24h"        json:"ret_24h,omitempty"`
+	MFEPct        *float64 `db:"mfe_pct"        json:"mfe_pct,omitempty"`
+	MAEPct        *float64 `db:"mae_pct"        json:"mae_pct,omitempty"`
+	OutcomeStatus *string  `db:"outcome_status" json:"outcome_status,omitempty"`
+}
@@ -0,0 +1,44 @@
+// internal/infrastructure/persistence/postgres/models/signal_feedback.go
+package models
+
+import "time"
+
+// Голоса оценки сигнала
+const (
+	FeedbackUp    = "up"    // 👍 полезный сигнал
+	FeedbackDown  = "down"  // 👎 бесполезный сигнал
+	FeedbackLate  = "late"  // сигнал пришел слишком поздно
+	FeedbackNoise = "noise" // шум по символу
+)
+
+// SignalFeedback оценка доставленного сигнала пользователем
+type SignalFeedback struct {
+	ID            int64     `db:"id"             json:"id"`
+	UserID        int       `db:"user_id"        json:"user_id"`
+	Platform      string    `db:"platform"       json:"platform"`
+	SignalID      string    `db:"signal_id"      json:"signal_id"`
+	Symbol        string    `db:"symbol"         json:"symbol"`
+	Direction     string    `db:"direction"      json:"direction"`
+	PeriodMinutes int       `db:"period_minutes" json:"period_minutes"`
+	Strategy      string    `db:"strategy"       json:"strategy"`
+	Vote          string    `db:"vote"           json:"vote"`
+	CreatedAt     time.Time `db:"created_at"     json:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"     json:"updated_at"`
+}
+
+// FeedbackGroupStats голоса группы сигналов
+type FeedbackGroupStats struct {
+	Key   string `db:"key"   json:"key"`
+	Votes int    `db:"votes" json:"votes"`
+	Up    int    `db:"up"    json:"up"`
+	Down  int    `db:"down"  json:"down"`
+	Late  int    `db:"late"  json:"late"`
+	Noise int    `db:"noise" json:"noise"`
+}
+
+// SymbolNoise чистое число отметок «шум» пользователя по символу (шум минус 👍)
+type SymbolNoise struct {
+	UserID int    `db:"user_id" json:"user_id"`
+	Symbol string `db:"symbol"  json:"symbol"`
+	Noise  int    `db:"noise"   json:"noise"`
+}
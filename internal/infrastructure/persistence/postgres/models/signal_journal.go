@@ -0,0 +1,60 @@
+// internal/infrastructure/persistence/postgres/models/signal_journal.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Статусы расчета исхода сигнала
+const (
+	SignalOutcomePending  = "pending"
+	SignalOutcomeComplete = "complete"
+	SignalOutcomeNoData   = "no_data"
+)
+
+// SignalRecord запись журнала сигналов: сигнал, его контекст и исход
+type SignalRecord struct {
+	ID            int64           `db:"id"             json:"id"`
+	SignalID      string          `db:"signal_id"      json:"signal_id"`
+	Symbol        string          `db:"symbol"         json:"symbol"`
+	SignalType    string          `db:"signal_type"    json:"signal_type"`
+	Direction     string          `db:"direction"      json:"direction"`
+	Strategy      string          `db:"strategy"       json:"strategy"`
+	PeriodMinutes int             `db:"period_minutes" json:"period_minutes"`
+	Confidence    float64         `db:"confidence"     json:"confidence"`
+	ChangePercent float64         `db:"change_percent" json:"change_percent"`
+	EntryPrice    float64         `db:"entry_price"    json:"entry_price"`
+	SignalTime    time.Time       `db:"signal_time"    json:"signal_time"`
+	Indicators    json.RawMessage `db:"indicators"     json:"indicators"`
+	Context       json.RawMessage `db:"context"        json:"context"`
+	Tags          pq.StringArray  `db:"tags"           json:"tags"`
+
+	// Изменение цены в % через 5м/15м/1ч/4ч/24ч; nil — горизонт еще не наступил
+	Ret5m  *float64 `db:"ret_5m"  json:"ret_5m,omitempty"`
+	Ret15m *float64 `db:"ret_15m" json:"ret_15m,omitempty"`
+	Ret1h  *float64 `db:"ret_1h"  json:"ret_1h,omitempty"`
+	Ret4h  *float64 `db:"ret_4h"  json:"ret_4h,omitempty"`
+	Ret24h *float64 `db:"ret_24h" json:"ret_24h,omitempty"`
+	// MFE/MAE в % по направлению сигнала (>= 0)
+	MFEPct         *float64   `db:"mfe_pct"         json:"mfe_pct,omitempty"`
+	MAEPct         *float64   `db:"mae_pct"         json:"mae_pct,omitempty"`
+	OutcomeStatus  string     `db:"outcome_status"  json:"outcome_status"`
+	EvaluatedUntil *time.Time `db:"evaluated_until" json:"evaluated_until,omitempty"`
+	// NextDueAt — когда оценить исход в следующий раз; nil — сигнал еще не оценивался
+	NextDueAt *time.Time `db:"next_due_at" json:"next_due_at,omitempty"`
+
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// IsShort возвращает true для сигналов на падение
+func (r *SignalRecord) IsShort() bool {
+	switch r.Direction {
+	case "fall", "down", "bearish", "short":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+// internal/infrastructure/persistence/postgres/models/signal_rule.go
+package models
+
+import "time"
+
+// SignalRule пользовательское правило сигнала (выражение на языке правил)
+type SignalRule struct {
+	ID              int        `db:"id"                json:"id"`
+	UserID          int        `db:"user_id"           json:"user_id"`
+	Name            string     `db:"name"              json:"name"`
+	Expression      string     `db:"expression"        json:"expression"`
+	IsActive        bool       `db:"is_active"         json:"is_active"`
+	CooldownMinutes int        `db:"cooldown_minutes"  json:"cooldown_minutes"`
+	TriggerCount    int        `db:"trigger_count"     json:"trigger_count"`
+	LastTriggeredAt *time.Time `db:"last_triggered_at" json:"last_triggered_at,omitempty"`
+	CreatedAt       time.Time  `db:"created_at"        json:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"        json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+// internal/infrastructure/persistence/postgres/models/symbol_sector.go
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// SymbolSector группа символов для рейтинга относительной силы (L1, мемы, AI, DeFi)
+type SymbolSector struct {
+	Name      string         `db:"name"       json:"name"`
+	Title     string         `db:"title"      json:"title"`
+	Symbols   pq.StringArray `db:"symbols"    json:"symbols"`
+	SortOrder int            `db:"sort_order" json:"sort_order"`
+	IsActive  bool           `db:"is_active"  json:"is_active"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
+}
@@ -45,6 +45,26 @@ type User struct {
 	// nil = фильтр отключён (все сигналы); [] = фильтр пуст (нет сигналов); [coins] = только эти
 	// ВАЖНО: без omitempty, чтобы nil и [] не смешивались при JSON-сериализации в Redis
 	WatchlistSymbols []string `db:"watchlist_symbols" json:"watchlist_symbols"`
+	// Минимальная согласованность со старшими таймфреймами (0 = фильтр отключён)
+	MinConfluenceScore float64 `db:"min_confluence_score" json:"min_confluence_score"`
+	// Скрывать сигналы, полностью объяснённые движением BTC/ETH (с учётом беты)
+	SuppressMarketMoves bool `db:"suppress_market_moves" json:"suppress_market_moves"`
+	// Уведомлять о разворотных свечных паттернах у сильных зон S/R
+	NotifyPatternZones bool `db:"notify_pattern_zones" json:"notify_pattern_zones"`
+	// Порог z-score сигналов в сигмах (0 — выключены)
+	SensitivitySigma float64 `db:"sensitivity_sigma" json:"sensitivity_sigma"`
+	// Получать дайджест ротаций между секторами
+	NotifySectorDigest bool `db:"notify_sector_digest" json:"notify_sector_digest"`
+	// Горизонты пробоев диапазона (1d, 7d, 30d, 90d, all); пусто — алерты выключены
+	RangeBreakoutHorizons []string `db:"range_breakout_horizons" json:"range_breakout_horizons"`
+	// Получать сигналы анализатора VWAP (отклонения ±Nσ, reclaim/loss)
+	NotifyVWAP bool `db:"notify_vwap" json:"notify_vwap"`
+	// Якорь пользовательского VWAP; nil — только VWAP дневной сессии
+	VWAPAnchorAt *time.Time `db:"vwap_anchor_at" json:"vwap_anchor_at,omitempty"`
+	// Множители порогов по режиму рынка: "panic=2,range=1.2"; "" — без множителей
+	RegimeThresholds string `db:"regime_thresholds" json:"regime_thresholds"`
+	// Получать алерты о снятии крупной стены стакана рядом с ценой
+	NotifyWallPulls bool `db:"notify_wall_pulls" json:"notify_wall_pulls"`
 	Language        string   `db:"language" json:"language"`
 	Timezone        string   `db:"timezone" json:"timezone"`
 	DisplayMode     string   `db:"display_mode" json:"display_mode"`
@@ -221,6 +241,16 @@ func (u *User) ShouldTrackSymbol(symbol string) bool {
 	return false // в т.ч. пустой список → ни одного сигнала
 }
 
+// SubscribesRangeHorizon возвращает true, если пользователь подписан на пробои горизонта.
+func (u *User) SubscribesRangeHorizon(horizon string) bool {
+	for _, h := range u.RangeBreakoutHorizons {
+		if h == horizon {
+			return true
+		}
+	}
+	return false
+}
+
 // IsMaxOnlyUser возвращает true, если пользователь зарегистрирован только через MAX
 // (telegram_id совпадает с max_user_id — способ хранения до привязки TG-аккаунта)
 func (u *User) IsMaxOnlyUser() bool {
@@ -31,6 +31,9 @@ type ActivityRepository interface {
 	GetRecentActivities(limit int) ([]*models.UserActivity, error)
 	GetAll(limit, offset int) ([]*models.UserActivity, error)
 	GetSuspiciousActivities(limit int) ([]*models.UserActivity, error)
+	// StreamByUser построчно передает в fn активность пользователя за [from, to) в порядке времени,
+	// не загружая ее в память; ошибка fn прерывает чтение
+	StreamByUser(ctx context.Context, userID int, from, to time.Time, fn func(*models.UserActivity) error) error
 
 	// Логирование различных событий
 	LogUserLogin(user *models.User, ip, userAgent string, success bool, failureReason string) error
@@ -351,6 +354,59 @@ func (r *ActivityRepositoryImpl) FindByUserID(userID int, limit, offset int) ([]
 	return activities, nil
 }
 
+// StreamByUser построчно читает активность пользователя за период (без кэша)
+func (r *ActivityRepositoryImpl) StreamByUser(ctx context.Context, userID int, from, to time.Time, fn func(*models.UserActivity) error) error {
+	query := `
+	SELECT
+		id, user_id, activity_type, category, severity,
+		details, ip_address, user_agent, metadata, created_at
+	FROM user_activities
+	WHERE user_id = $1 AND created_at >= $2 AND created_at < $3
+	ORDER BY created_at, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to stream activities: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activity models.UserActivity
+		var detailsJSON, metadataJSON []byte
+
+		if err := rows.Scan(
+			&activity.ID,
+			&activity.UserID,
+			&activity.ActivityType,
+			&activity.Category,
+			&activity.Severity,
+			&detailsJSON,
+			&activity.IPAddress,
+			&activity.UserAgent,
+			&metadataJSON,
+			&activity.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to scan activity: %w", err)
+		}
+
+		if len(detailsJSON) > 0 {
+			if err := json.Unmarshal(detailsJSON, &activity.Details); err != nil {
+				return fmt.Errorf("failed to unmarshal details: %w", err)
+			}
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &activity.Metadata); err != nil {
+				return fmt.Errorf("failed to unmarshal metadata: %w", err)
+			}
+		}
+
+		if err := fn(&activity); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // FindByFilter находит активность по фильтру
 func (r *ActivityRepositoryImpl) FindByFilter(filter models.ActivityFilter) ([]*models.UserActivity, int64, error) {
 	query, args, err := r.buildFilterQuery(filter)
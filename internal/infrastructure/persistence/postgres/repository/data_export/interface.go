@@ -0,0 +1,20 @@
+package data_export_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// DataExportRepository интерфейс доступа к журналу выгрузок данных
+type DataExportRepository interface {
+	// Create сохраняет начатую выгрузку
+	Create(export *models.DataExport) error
+	// Finish сохраняет итог выгрузки (статус, строки, байты, путь, ошибку)
+	Finish(export *models.DataExport) error
+	// CountSince возвращает число выгрузок (кроме упавших) пользователя начиная с since
+	CountSince(userID int, since time.Time) (int, error)
+	// CreateWithinLimit в одной транзакции проверяет, что выгрузок пользователя
+	// (кроме упавших) начиная с since меньше maxCount (-1 — без ограничений),
+	// и сохраняет выгрузку. false — лимит достигнут, выгрузка не создана.
+	CreateWithinLimit(export *models.DataExport, since time.Time, maxCount int) (bool, error)
+}
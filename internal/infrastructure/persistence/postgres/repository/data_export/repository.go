@@ -0,0 +1,119 @@
+// internal/infrastructure/persistence/postgres/repository/data_export/repository.go
+package data_export_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type dataExportRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewDataExportRepository создаёт реализацию DataExportRepository
+func NewDataExportRepository(db *sqlx.DB) DataExportRepository {
+	return &dataExportRepoImpl{db: db}
+}
+
+// Create сохраняет начатую выгрузку
+func (r *dataExportRepoImpl) Create(export *models.DataExport) error {
+	if len(export.Params) == 0 {
+		export.Params = []byte(`{}`)
+	}
+	if export.Status == "" {
+		export.Status = models.DataExportRunning
+	}
+	query := `
+		INSERT INTO data_exports (user_id, kind, format, target, params, status)
+		VALUES (:user_id, :kind, :format, :target, :params, :status)
+		RETURNING id, created_at
+	`
+	rows, err := r.db.NamedQuery(query, export)
+	if err != nil {
+		return fmt.Errorf("DataExportRepo.Create: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&export.ID, &export.CreatedAt); err != nil {
+			return fmt.Errorf("DataExportRepo.Create: %w", err)
+		}
+	}
+	return nil
+}
+
+// Finish сохраняет итог выгрузки
+func (r *dataExportRepoImpl) Finish(export *models.DataExport) error {
+	query := `
+		UPDATE data_exports
+		SET status = :status, row_count = :row_count, byte_count = :byte_count, truncated = :truncated,
+			file_path = :file_path, error = :error, finished_at = :finished_at
+		WHERE id = :id
+	`
+	if _, err := r.db.NamedExec(query, export); err != nil {
+		return fmt.Errorf("DataExportRepo.Finish: %w", err)
+	}
+	return nil
+}
+
+// CreateWithinLimit сохраняет выгрузку, если выгрузок с since меньше maxCount.
+// Строка пользователя блокируется до конца транзакции, поэтому параллельные
+// выгрузки одного пользователя проверяют лимит по очереди.
+func (r *dataExportRepoImpl) CreateWithinLimit(export *models.DataExport, since time.Time, maxCount int) (bool, error) {
+	if len(export.Params) == 0 {
+		export.Params = []byte(`{}`)
+	}
+	if export.Status == "" {
+		export.Status = models.DataExportRunning
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("DataExportRepo.CreateWithinLimit: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, export.UserID); err != nil {
+		return false, fmt.Errorf("DataExportRepo.CreateWithinLimit: %w", err)
+	}
+
+	if maxCount >= 0 {
+		var used int
+		query := `SELECT COUNT(*) FROM data_exports WHERE user_id = $1 AND created_at >= $2 AND status <> 'failed'`
+		if err := tx.Get(&used, query, export.UserID, since); err != nil {
+			return false, fmt.Errorf("DataExportRepo.CreateWithinLimit: %w", err)
+		}
+		if used >= maxCount {
+			return false, nil
+		}
+	}
+
+	query := `
+		INSERT INTO data_exports (user_id, kind, format, target, params, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowx(query, export.UserID, export.Kind, export.Format, export.Target, export.Params, export.Status).
+		Scan(&export.ID, &export.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("DataExportRepo.CreateWithinLimit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("DataExportRepo.CreateWithinLimit: %w", err)
+	}
+	return true, nil
+}
+
+// CountSince возвращает число выгрузок пользователя (кроме упавших) начиная с since
+func (r *dataExportRepoImpl) CountSince(userID int, since time.Time) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM data_exports WHERE user_id = $1 AND created_at >= $2 AND status <> 'failed'`
+	if err := r.db.Get(&count, query, userID, since); err != nil {
+		return 0, fmt.Errorf("DataExportRepo.CountSince: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,27 @@
+package market_digest_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// MarketDigestRepository интерфейс доступа к дайджестам рынка и подпискам на них
+type MarketDigestRepository interface {
+	// SaveDigest создаёт или перезаписывает дайджест вида за период (kind, period_end)
+	SaveDigest(digest *models.MarketDigest) error
+	// LatestDigest возвращает последний дайджест вида или nil
+	LatestDigest(kind string) (*models.MarketDigest, error)
+	// DigestAt возвращает последний дайджест вида, завершившийся не позже at, или nil
+	DigestAt(kind string, at time.Time) (*models.MarketDigest, error)
+	// DigestsBetween возвращает дайджесты вида, завершившиеся в (from, to], по возрастанию
+	DigestsBetween(kind string, from, to time.Time) ([]*models.MarketDigest, error)
+
+	// GetSubscription возвращает подписку пользователя или nil, если она не создавалась
+	GetSubscription(userID int) (*models.DigestSubscription, error)
+	// SaveSubscription создаёт или обновляет подписку (даты доставки не меняются)
+	SaveSubscription(subscription *models.DigestSubscription) error
+	// FindSubscribed возвращает включенные подписки активных пользователей с их часовыми поясами
+	FindSubscribed() ([]*models.DigestSubscription, error)
+	// MarkSent отмечает доставку дайджеста вида пользователю в локальную дату day
+	MarkSent(userID int, kind string, day time.Time) error
+}
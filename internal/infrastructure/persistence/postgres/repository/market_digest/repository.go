@@ -0,0 +1,178 @@
+// internal/infrastructure/persistence/postgres/repository/market_digest/repository.go
+package market_digest_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const marketDigestColumns = `id, kind, period_start, period_end, data, snapshot, created_at`
+
+const digestSubscriptionColumns = `s.user_id, s.daily, s.weekly, s.send_minute, s.weekday,
+	s.last_daily_sent, s.last_weekly_sent, s.created_at, s.updated_at, COALESCE(u.timezone, '') AS timezone`
+
+type marketDigestRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewMarketDigestRepository создаёт реализацию MarketDigestRepository
+func NewMarketDigestRepository(db *sqlx.DB) MarketDigestRepository {
+	return &marketDigestRepoImpl{db: db}
+}
+
+// SaveDigest создаёт или перезаписывает дайджест
+func (r *marketDigestRepoImpl) SaveDigest(digest *models.MarketDigest) error {
+	if len(digest.Data) == 0 {
+		digest.Data = []byte("{}")
+	}
+	if len(digest.Snapshot) == 0 {
+		digest.Snapshot = []byte("{}")
+	}
+	query := `
+		INSERT INTO market_digests (kind, period_start, period_end, data, snapshot)
+		VALUES (:kind, :period_start, :period_end, :data, :snapshot)
+		ON CONFLICT (kind, period_end) DO UPDATE SET
+			period_start = EXCLUDED.period_start,
+			data = EXCLUDED.data,
+			snapshot = EXCLUDED.snapshot,
+			created_at = NOW()
+		RETURNING id, created_at
+	`
+	rows, err := r.db.NamedQuery(query, digest)
+	if err != nil {
+		return fmt.Errorf("MarketDigestRepo.SaveDigest: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&digest.ID, &digest.CreatedAt); err != nil {
+			return fmt.Errorf("MarketDigestRepo.SaveDigest: %w", err)
+		}
+	}
+	return nil
+}
+
+// LatestDigest возвращает последний дайджест вида
+func (r *marketDigestRepoImpl) LatestDigest(kind string) (*models.MarketDigest, error) {
+	return r.getDigest("LatestDigest", `
+		SELECT `+marketDigestColumns+` FROM market_digests
+		WHERE kind = $1
+		ORDER BY period_end DESC
+		LIMIT 1
+	`, kind)
+}
+
+// DigestAt возвращает последний дайджест вида, завершившийся не позже at
+func (r *marketDigestRepoImpl) DigestAt(kind string, at time.Time) (*models.MarketDigest, error) {
+	return r.getDigest("DigestAt", `
+		SELECT `+marketDigestColumns+` FROM market_digests
+		WHERE kind = $1 AND period_end <= $2
+		ORDER BY period_end DESC
+		LIMIT 1
+	`, kind, at)
+}
+
+func (r *marketDigestRepoImpl) getDigest(method, query string, args ...interface{}) (*models.MarketDigest, error) {
+	var digest models.MarketDigest
+	err := r.db.Get(&digest, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("MarketDigestRepo.%s: %w", method, err)
+	}
+	return &digest, nil
+}
+
+// DigestsBetween возвращает дайджесты вида, завершившиеся в (from, to]
+func (r *marketDigestRepoImpl) DigestsBetween(kind string, from, to time.Time) ([]*models.MarketDigest, error) {
+	var digests []*models.MarketDigest
+	err := r.db.Select(&digests, `
+		SELECT `+marketDigestColumns+` FROM market_digests
+		WHERE kind = $1 AND period_end > $2 AND period_end <= $3
+		ORDER BY period_end
+	`, kind, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("MarketDigestRepo.DigestsBetween: %w", err)
+	}
+	return digests, nil
+}
+
+// GetSubscription возвращает подписку пользователя
+func (r *marketDigestRepoImpl) GetSubscription(userID int) (*models.DigestSubscription, error) {
+	var subscription models.DigestSubscription
+	err := r.db.Get(&subscription, `
+		SELECT `+digestSubscriptionColumns+`
+		FROM digest_subscriptions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.user_id = $1
+	`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("MarketDigestRepo.GetSubscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// SaveSubscription создаёт или обновляет подписку
+func (r *marketDigestRepoImpl) SaveSubscription(subscription *models.DigestSubscription) error {
+	query := `
+		INSERT INTO digest_subscriptions (user_id, daily, weekly, send_minute, weekday)
+		VALUES (:user_id, :daily, :weekly, :send_minute, :weekday)
+		ON CONFLICT (user_id) DO UPDATE SET
+			daily = EXCLUDED.daily,
+			weekly = EXCLUDED.weekly,
+			send_minute = EXCLUDED.send_minute,
+			weekday = EXCLUDED.weekday,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	rows, err := r.db.NamedQuery(query, subscription)
+	if err != nil {
+		return fmt.Errorf("MarketDigestRepo.SaveSubscription: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&subscription.CreatedAt, &subscription.UpdatedAt); err != nil {
+			return fmt.Errorf("MarketDigestRepo.SaveSubscription: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindSubscribed возвращает включенные подписки активных пользователей
+func (r *marketDigestRepoImpl) FindSubscribed() ([]*models.DigestSubscription, error) {
+	var subscriptions []*models.DigestSubscription
+	err := r.db.Select(&subscriptions, `
+		SELECT `+digestSubscriptionColumns+`
+		FROM digest_subscriptions s
+		JOIN users u ON u.id = s.user_id
+		WHERE (s.daily OR s.weekly) AND u.is_active
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("MarketDigestRepo.FindSubscribed: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// MarkSent отмечает доставку дайджеста вида пользователю
+func (r *marketDigestRepoImpl) MarkSent(userID int, kind string, day time.Time) error {
+	column := "last_daily_sent"
+	if kind == models.DigestWeekly {
+		column = "last_weekly_sent"
+	}
+	_, err := r.db.Exec(`UPDATE digest_subscriptions SET `+column+` = $2 WHERE user_id = $1`,
+		userID, day.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("MarketDigestRepo.MarkSent: %w", err)
+	}
+	return nil
+}
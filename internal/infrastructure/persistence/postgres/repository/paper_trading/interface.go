@@ -0,0 +1,30 @@
+package paper_trading_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+)
+
+// PaperTradingRepository интерфейс доступа к paper-портфелям пользователей
+type PaperTradingRepository interface {
+	// GetSettings возвращает правила портфеля пользователя или nil, если они не заданы
+	GetSettings(userID int) (*models.PaperSettings, error)
+	// SaveSettings создаёт или обновляет правила портфеля
+	SaveSettings(settings *models.PaperSettings) error
+	// Reset удаляет все позиции пользователя и начинает портфель заново
+	Reset(userID int) error
+
+	// OpenPosition открывает позицию, если у пользователя меньше maxOpen открытых позиций,
+	// нет открытой позиции по символу и позиция по сигналу ещё не открывалась.
+	// Возвращает false, если позиция не открыта.
+	OpenPosition(position *models.PaperPosition, maxOpen int) (bool, error)
+	// ClosePosition закрывает открытую позицию; возвращает false, если она уже закрыта
+	ClosePosition(position *models.PaperPosition) (bool, error)
+	// FindOpen возвращает все открытые позиции
+	FindOpen() ([]*models.PaperPosition, error)
+	// FindOpenByUser возвращает открытые позиции пользователя
+	FindOpenByUser(userID int) ([]*models.PaperPosition, error)
+	// FindClosedByUser возвращает закрытые позиции пользователя в порядке закрытия
+	FindClosedByUser(userID int) ([]*models.PaperPosition, error)
+	// RealizedPnL возвращает суммарный реализованный PnL пользователя в $
+	RealizedPnL(userID int) (float64, error)
+}
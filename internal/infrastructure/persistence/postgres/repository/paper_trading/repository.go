@@ -0,0 +1,192 @@
+// internal/infrastructure/persistence/postgres/repository/paper_trading/repository.go
+package paper_trading_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const paperSettingsColumns = `user_id, enabled, direction, size_value, size_percent, take_profit, take_profit_atr,
+	stop_loss, stop_loss_atr, timeout_minutes, max_open, start_balance, started_at, created_at, updated_at`
+
+const paperPositionColumns = `id, user_id, signal_id, platform, symbol, side, period_minutes, entry_price,
+	quantity, size_usd, take_profit_price, stop_loss_price, opened_at, expires_at, status,
+	exit_price, exit_reason, closed_at, pnl_usd, pnl_pct`
+
+// closedPositionsLimit — сколько последних закрытых позиций учитывается в отчёте
+const closedPositionsLimit = 5000
+
+type paperTradingRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewPaperTradingRepository создаёт реализацию PaperTradingRepository
+func NewPaperTradingRepository(db *sqlx.DB) PaperTradingRepository {
+	return &paperTradingRepoImpl{db: db}
+}
+
+// GetSettings возвращает правила портфеля пользователя
+func (r *paperTradingRepoImpl) GetSettings(userID int) (*models.PaperSettings, error) {
+	var settings models.PaperSettings
+	err := r.db.Get(&settings, `SELECT `+paperSettingsColumns+` FROM paper_settings WHERE user_id = $1`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PaperTradingRepo.GetSettings: %w", err)
+	}
+	return &settings, nil
+}
+
+// SaveSettings создаёт или обновляет правила портфеля
+func (r *paperTradingRepoImpl) SaveSettings(settings *models.PaperSettings) error {
+	query := `
+		INSERT INTO paper_settings (user_id, enabled, direction, size_value, size_percent, take_profit,
+			take_profit_atr, stop_loss, stop_loss_atr, timeout_minutes, max_open, start_balance)
+		VALUES (:user_id, :enabled, :direction, :size_value, :size_percent, :take_profit,
+			:take_profit_atr, :stop_loss, :stop_loss_atr, :timeout_minutes, :max_open, :start_balance)
+		ON CONFLICT (user_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			direction = EXCLUDED.direction,
+			size_value = EXCLUDED.size_value,
+			size_percent = EXCLUDED.size_percent,
+			take_profit = EXCLUDED.take_profit,
+			take_profit_atr = EXCLUDED.take_profit_atr,
+			stop_loss = EXCLUDED.stop_loss,
+			stop_loss_atr = EXCLUDED.stop_loss_atr,
+			timeout_minutes = EXCLUDED.timeout_minutes,
+			max_open = EXCLUDED.max_open,
+			start_balance = EXCLUDED.start_balance,
+			updated_at = NOW()
+		RETURNING started_at, created_at, updated_at
+	`
+	rows, err := r.db.NamedQuery(query, settings)
+	if err != nil {
+		return fmt.Errorf("PaperTradingRepo.SaveSettings: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&settings.StartedAt, &settings.CreatedAt, &settings.UpdatedAt); err != nil {
+			return fmt.Errorf("PaperTradingRepo.SaveSettings: %w", err)
+		}
+	}
+	return nil
+}
+
+// Reset удаляет позиции пользователя и сдвигает начало портфеля
+func (r *paperTradingRepoImpl) Reset(userID int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("PaperTradingRepo.Reset: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM paper_positions WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("PaperTradingRepo.Reset: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE paper_settings SET started_at = NOW(), updated_at = NOW() WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("PaperTradingRepo.Reset: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("PaperTradingRepo.Reset: %w", err)
+	}
+	return nil
+}
+
+// OpenPosition открывает позицию с проверкой лимита открытых позиций одним запросом
+func (r *paperTradingRepoImpl) OpenPosition(position *models.PaperPosition, maxOpen int) (bool, error) {
+	query := `
+		INSERT INTO paper_positions (user_id, signal_id, platform, symbol, side, period_minutes,
+			entry_price, quantity, size_usd, take_profit_price, stop_loss_price, opened_at, expires_at, status)
+		SELECT $1::int, $2::varchar, $3::varchar, $4::varchar, $5::varchar, $6::int,
+			$7::float8, $8::float8, $9::float8, $10::float8, $11::float8, $12::timestamptz, $13::timestamptz, 'open'
+		WHERE (SELECT COUNT(*) FROM paper_positions WHERE user_id = $1 AND status = 'open') < $14
+			AND NOT EXISTS (
+				SELECT 1 FROM paper_positions WHERE user_id = $1 AND symbol = $4 AND status = 'open'
+			)
+		ON CONFLICT (user_id, signal_id) WHERE signal_id <> '' DO NOTHING
+		RETURNING id
+	`
+	err := r.db.Get(&position.ID, query,
+		position.UserID, position.SignalID, position.Platform, position.Symbol, position.Side,
+		position.PeriodMinutes, position.EntryPrice, position.Quantity, position.SizeUSD,
+		position.TakeProfitPrice, position.StopLossPrice, position.OpenedAt, position.ExpiresAt, maxOpen)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("PaperTradingRepo.OpenPosition: %w", err)
+	}
+	position.Status = models.PaperStatusOpen
+	return true, nil
+}
+
+// ClosePosition закрывает позицию, только если она ещё открыта
+func (r *paperTradingRepoImpl) ClosePosition(position *models.PaperPosition) (bool, error) {
+	query := `
+		UPDATE paper_positions
+		SET status = 'closed', exit_price = $2, exit_reason = $3, closed_at = $4, pnl_usd = $5, pnl_pct = $6
+		WHERE id = $1 AND status = 'open'
+	`
+	res, err := r.db.Exec(query, position.ID, position.ExitPrice, position.ExitReason,
+		position.ClosedAt, position.PnLUSD, position.PnLPct)
+	if err != nil {
+		return false, fmt.Errorf("PaperTradingRepo.ClosePosition: %w", err)
+	}
+	affected, _ := res.RowsAffected()
+	if affected > 0 {
+		position.Status = models.PaperStatusClosed
+	}
+	return affected > 0, nil
+}
+
+// FindOpen возвращает все открытые позиции
+func (r *paperTradingRepoImpl) FindOpen() ([]*models.PaperPosition, error) {
+	var positions []*models.PaperPosition
+	query := `SELECT ` + paperPositionColumns + ` FROM paper_positions WHERE status = 'open' ORDER BY id`
+	if err := r.db.Select(&positions, query); err != nil {
+		return nil, fmt.Errorf("PaperTradingRepo.FindOpen: %w", err)
+	}
+	return positions, nil
+}
+
+// FindOpenByUser возвращает открытые позиции пользователя
+func (r *paperTradingRepoImpl) FindOpenByUser(userID int) ([]*models.PaperPosition, error) {
+	var positions []*models.PaperPosition
+	query := `SELECT ` + paperPositionColumns + ` FROM paper_positions
+		WHERE user_id = $1 AND status = 'open' ORDER BY opened_at`
+	if err := r.db.Select(&positions, query, userID); err != nil {
+		return nil, fmt.Errorf("PaperTradingRepo.FindOpenByUser: %w", err)
+	}
+	return positions, nil
+}
+
+// FindClosedByUser возвращает последние закрытые позиции пользователя в порядке закрытия
+func (r *paperTradingRepoImpl) FindClosedByUser(userID int) ([]*models.PaperPosition, error) {
+	var positions []*models.PaperPosition
+	query := `SELECT * FROM (
+			SELECT ` + paperPositionColumns + ` FROM paper_positions
+			WHERE user_id = $1 AND status = 'closed'
+			ORDER BY closed_at DESC
+			LIMIT $2
+		) p ORDER BY closed_at, id`
+	if err := r.db.Select(&positions, query, userID, closedPositionsLimit); err != nil {
+		return nil, fmt.Errorf("PaperTradingRepo.FindClosedByUser: %w", err)
+	}
+	return positions, nil
+}
+
+// RealizedPnL возвращает суммарный реализованный PnL пользователя
+func (r *paperTradingRepoImpl) RealizedPnL(userID int) (float64, error) {
+	var pnl float64
+	query := `SELECT COALESCE(SUM(pnl_usd), 0) FROM paper_positions WHERE user_id = $1 AND status = 'closed'`
+	if err := r.db.Get(&pnl, query, userID); err != nil {
+		return 0, fmt.Errorf("PaperTradingRepo.RealizedPnL: %w", err)
+	}
+	return pnl, nil
+}
@@ -0,0 +1,29 @@
+package price_alert_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// PriceAlertRepository интерфейс доступа к ценовым алертам
+type PriceAlertRepository interface {
+	// Create сохраняет новый алерт и заполняет ID/CreatedAt/UpdatedAt
+	Create(alert *models.PriceAlert) error
+	// FindByID возвращает алерт по ID (nil — не найден)
+	FindByID(alertID int) (*models.PriceAlert, error)
+	// Delete удаляет алерт пользователя; false — алерт не найден
+	Delete(userID, alertID int) (bool, error)
+	// FindActiveByUser возвращает активные алерты пользователя
+	FindActiveByUser(userID int) ([]*models.PriceAlert, error)
+	// CountActiveByUser возвращает количество активных алертов пользователя
+	CountActiveByUser(userID int) (int, error)
+	// CreateWithinLimit в одной транзакции проверяет, что активных алертов
+	// пользователя меньше maxActive (-1 — без ограничений), и сохраняет алерт.
+	// false — лимит достигнут, алерт не создан.
+	CreateWithinLimit(alert *models.PriceAlert, maxActive int) (bool, error)
+	// FindAllActive возвращает активные алерты всех пользователей
+	FindAllActive() ([]*models.PriceAlert, error)
+	// MarkTriggered фиксирует срабатывание: одноразовый алерт деактивируется,
+	// у повторяющегося обновляется опорная цена (для алертов на отклонение в %)
+	MarkTriggered(alertID int, at time.Time, deactivate bool, referencePrice float64) error
+}
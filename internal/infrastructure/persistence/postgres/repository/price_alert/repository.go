@@ -0,0 +1,162 @@
+// /internal/infrastructure/persistence/postgres/repository/price_alert/repository.go
+package price_alert_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const priceAlertColumns = `id, user_id, symbol, alert_type, price, price_high, percent, reference_price,
+	recurring, is_active, trigger_count, last_triggered_at, created_at, updated_at`
+
+type priceAlertRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewPriceAlertRepository создаёт реализацию PriceAlertRepository
+func NewPriceAlertRepository(db *sqlx.DB) PriceAlertRepository {
+	return &priceAlertRepoImpl{db: db}
+}
+
+// Create сохраняет новый алерт
+func (r *priceAlertRepoImpl) Create(alert *models.PriceAlert) error {
+	query := `
+		INSERT INTO price_alerts (user_id, symbol, alert_type, price, price_high, percent,
+			reference_price, recurring, is_active)
+		VALUES (:user_id, :symbol, :alert_type, :price, :price_high, :percent,
+			:reference_price, :recurring, :is_active)
+		RETURNING id, created_at, updated_at
+	`
+	rows, err := r.db.NamedQuery(query, alert)
+	if err != nil {
+		return fmt.Errorf("PriceAlertRepo.Create: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&alert.ID, &alert.CreatedAt, &alert.UpdatedAt); err != nil {
+			return fmt.Errorf("PriceAlertRepo.Create: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindByID возвращает алерт по ID
+func (r *priceAlertRepoImpl) FindByID(alertID int) (*models.PriceAlert, error) {
+	var alert models.PriceAlert
+	err := r.db.Get(&alert, `SELECT `+priceAlertColumns+` FROM price_alerts WHERE id = $1`, alertID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PriceAlertRepo.FindByID: %w", err)
+	}
+	return &alert, nil
+}
+
+// Delete удаляет алерт пользователя
+func (r *priceAlertRepoImpl) Delete(userID, alertID int) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM price_alerts WHERE id = $1 AND user_id = $2`, alertID, userID)
+	if err != nil {
+		return false, fmt.Errorf("PriceAlertRepo.Delete: %w", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// FindActiveByUser возвращает активные алерты пользователя
+func (r *priceAlertRepoImpl) FindActiveByUser(userID int) ([]*models.PriceAlert, error) {
+	query := `SELECT ` + priceAlertColumns + ` FROM price_alerts
+		WHERE user_id = $1 AND is_active = TRUE ORDER BY symbol, id`
+	var alerts []*models.PriceAlert
+	if err := r.db.Select(&alerts, query, userID); err != nil {
+		return nil, fmt.Errorf("PriceAlertRepo.FindActiveByUser: %w", err)
+	}
+	return alerts, nil
+}
+
+// CountActiveByUser возвращает количество активных алертов пользователя
+func (r *priceAlertRepoImpl) CountActiveByUser(userID int) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM price_alerts WHERE user_id = $1 AND is_active = TRUE`
+	if err := r.db.Get(&count, query, userID); err != nil {
+		return 0, fmt.Errorf("PriceAlertRepo.CountActiveByUser: %w", err)
+	}
+	return count, nil
+}
+
+// CreateWithinLimit сохраняет алерт, если активных алертов меньше maxActive.
+// Строка пользователя блокируется до конца транзакции, поэтому параллельные
+// создания алертов одного пользователя проверяют лимит по очереди.
+func (r *priceAlertRepoImpl) CreateWithinLimit(alert *models.PriceAlert, maxActive int) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("PriceAlertRepo.CreateWithinLimit: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, alert.UserID); err != nil {
+		return false, fmt.Errorf("PriceAlertRepo.CreateWithinLimit: %w", err)
+	}
+
+	if maxActive >= 0 {
+		var active int
+		query := `SELECT COUNT(*) FROM price_alerts WHERE user_id = $1 AND is_active = TRUE`
+		if err := tx.Get(&active, query, alert.UserID); err != nil {
+			return false, fmt.Errorf("PriceAlertRepo.CreateWithinLimit: %w", err)
+		}
+		if active >= maxActive {
+			return false, nil
+		}
+	}
+
+	query := `
+		INSERT INTO price_alerts (user_id, symbol, alert_type, price, price_high, percent,
+			reference_price, recurring, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRowx(query, alert.UserID, alert.Symbol, alert.AlertType, alert.Price, alert.PriceHigh,
+		alert.Percent, alert.ReferencePrice, alert.Recurring, alert.IsActive).
+		Scan(&alert.ID, &alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("PriceAlertRepo.CreateWithinLimit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("PriceAlertRepo.CreateWithinLimit: %w", err)
+	}
+	return true, nil
+}
+
+// FindAllActive возвращает активные алерты всех пользователей
+func (r *priceAlertRepoImpl) FindAllActive() ([]*models.PriceAlert, error) {
+	query := `SELECT ` + priceAlertColumns + ` FROM price_alerts WHERE is_active = TRUE`
+	var alerts []*models.PriceAlert
+	if err := r.db.Select(&alerts, query); err != nil {
+		return nil, fmt.Errorf("PriceAlertRepo.FindAllActive: %w", err)
+	}
+	return alerts, nil
+}
+
+// MarkTriggered фиксирует срабатывание алерта
+func (r *priceAlertRepoImpl) MarkTriggered(alertID int, at time.Time, deactivate bool, referencePrice float64) error {
+	query := `
+		UPDATE price_alerts
+		SET trigger_count = trigger_count + 1,
+			last_triggered_at = $2,
+			is_active = is_active AND NOT $3::BOOLEAN,
+			reference_price = CASE WHEN $4::DOUBLE PRECISION > 0 THEN $4::DOUBLE PRECISION ELSE reference_price END,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.Exec(query, alertID, at, deactivate, referencePrice); err != nil {
+		return fmt.Errorf("PriceAlertRepo.MarkTriggered: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package signal_delivery_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// HistoryFilter фильтр истории доставок пользователя (пустые поля не фильтруют)
+type HistoryFilter struct {
+	UserID        int
+	Platform      string
+	Symbol        string
+	Direction     string
+	PeriodMinutes int
+	From          time.Time
+	To            time.Time
+}
+
+// SignalDeliveryRepository интерфейс доступа к доставкам сигналов
+type SignalDeliveryRepository interface {
+	// Create сохраняет доставку
+	Create(delivery *models.SignalDelivery) error
+	// FindHistory возвращает страницу истории (новые — первыми) и общее число записей по фильтру
+	FindHistory(filter HistoryFilter, limit, offset int) ([]*models.SignalHistoryItem, int, error)
+	// FindByID возвращает доставку пользователя с исходом; nil, если не найдена
+	FindByID(userID int, id int64) (*models.SignalHistoryItem, error)
+	// DeleteOlderThan удаляет доставки старше before и возвращает их количество
+	DeleteOlderThan(before time.Time) (int64, error)
+}
@@ -0,0 +1,119 @@
+// internal/infrastructure/persistence/postgres/repository/signal_delivery/repository.go
+package signal_delivery_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// historyColumns — доставка и исход сигнала из журнала (LEFT JOIN signals s)
+const historyColumns = `d.id, d.user_id, d.platform, d.signal_id, d.symbol, d.direction, d.period_minutes,
+	d.change_percent, d.price, d.card_text, d.delivered_at,
+	s.entry_price, s.ret_5m, s.ret_15m, s.ret_1h, s.ret_4h, s.ret_24h, s.mfe_pct, s.mae_pct, s.outcome_status`
+
+const historyFrom = ` FROM signal_deliveries d
+	LEFT JOIN signals s ON s.signal_id = d.signal_id AND d.signal_id <> ''`
+
+type signalDeliveryRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewSignalDeliveryRepository создаёт реализацию SignalDeliveryRepository
+func NewSignalDeliveryRepository(db *sqlx.DB) SignalDeliveryRepository {
+	return &signalDeliveryRepoImpl{db: db}
+}
+
+// Create сохраняет доставку
+func (r *signalDeliveryRepoImpl) Create(delivery *models.SignalDelivery) error {
+	if delivery.DeliveredAt.IsZero() {
+		delivery.DeliveredAt = time.Now()
+	}
+	query := `
+		INSERT INTO signal_deliveries (user_id, platform, signal_id, symbol, direction, period_minutes,
+			change_percent, price, card_text, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+	err := r.db.QueryRowx(query,
+		delivery.UserID, delivery.Platform, delivery.SignalID, delivery.Symbol, delivery.Direction,
+		delivery.PeriodMinutes, delivery.ChangePercent, delivery.Price, delivery.CardText, delivery.DeliveredAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return fmt.Errorf("SignalDeliveryRepo.Create: %w", err)
+	}
+	return nil
+}
+
+// FindHistory возвращает страницу истории и общее число записей
+func (r *signalDeliveryRepoImpl) FindHistory(filter HistoryFilter, limit, offset int) ([]*models.SignalHistoryItem, int, error) {
+	conditions := []string{"d.user_id = $1"}
+	args := []interface{}{filter.UserID}
+	add := func(cond string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.Platform != "" {
+		add("d.platform = $%d", filter.Platform)
+	}
+	if filter.Symbol != "" {
+		add("d.symbol = $%d", filter.Symbol)
+	}
+	if filter.Direction != "" {
+		add("d.direction = $%d", filter.Direction)
+	}
+	if filter.PeriodMinutes > 0 {
+		add("d.period_minutes = $%d", filter.PeriodMinutes)
+	}
+	if !filter.From.IsZero() {
+		add("d.delivered_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("d.delivered_at < $%d", filter.To)
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.Get(&total, `SELECT COUNT(*) FROM signal_deliveries d`+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("SignalDeliveryRepo.FindHistory count: %w", err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	query := `SELECT ` + historyColumns + historyFrom + where +
+		fmt.Sprintf(" ORDER BY d.delivered_at DESC, d.id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	var items []*models.SignalHistoryItem
+	if err := r.db.Select(&items, query, append(args, limit, offset)...); err != nil {
+		return nil, 0, fmt.Errorf("SignalDeliveryRepo.FindHistory: %w", err)
+	}
+	return items, total, nil
+}
+
+// FindByID возвращает доставку пользователя с исходом
+func (r *signalDeliveryRepoImpl) FindByID(userID int, id int64) (*models.SignalHistoryItem, error) {
+	var item models.SignalHistoryItem
+	err := r.db.Get(&item, `SELECT `+historyColumns+historyFrom+` WHERE d.id = $1 AND d.user_id = $2`, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SignalDeliveryRepo.FindByID: %w", err)
+	}
+	return &item, nil
+}
+
+// DeleteOlderThan удаляет доставки старше before
+func (r *signalDeliveryRepoImpl) DeleteOlderThan(before time.Time) (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM signal_deliveries WHERE delivered_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("SignalDeliveryRepo.DeleteOlderThan: %w", err)
+	}
+	deleted, _ := res.RowsAffected()
+	return deleted, nil
+}
@@ -0,0 +1,29 @@
+package signal_feedback_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// SignalFeedbackRepository интерфейс доступа к оценкам сигналов пользователями
+type SignalFeedbackRepository interface {
+	// Vote сохраняет голос пользователя за доставленный ему сигнал (повторный голос заменяет прежний).
+	// Возвращает false, если доставки сигнала пользователю на платформе нет.
+	Vote(userID int, platform, signalID, vote string) (bool, error)
+	// Aggregate группирует голоса окна [from, to) по измерению (Dimension*)
+	Aggregate(from, to time.Time, dimension string) ([]*models.FeedbackGroupStats, error)
+	// NoisySymbols возвращает символы, которые пользователи отмечали «шумом» начиная с since,
+	// с чистым числом отметок не меньше minVotes
+	NoisySymbols(since time.Time, minVotes int) ([]*models.SymbolNoise, error)
+	// DeleteOlderThan удаляет голоса старше before и возвращает их количество
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+// Измерения агрегации голосов (совпадают с измерениями журнала)
+const (
+	DimensionTotal     = "total"
+	DimensionAnalyzer  = "analyzer"
+	DimensionPeriod    = "period"
+	DimensionDirection = "direction"
+	DimensionSymbol    = "symbol"
+)
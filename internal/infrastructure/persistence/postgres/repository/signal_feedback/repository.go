@@ -0,0 +1,107 @@
+// internal/infrastructure/persistence/postgres/repository/signal_feedback/repository.go
+package signal_feedback_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type signalFeedbackRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewSignalFeedbackRepository создаёт реализацию SignalFeedbackRepository
+func NewSignalFeedbackRepository(db *sqlx.DB) SignalFeedbackRepository {
+	return &signalFeedbackRepoImpl{db: db}
+}
+
+// Vote сохраняет голос: символ, направление и период берутся из последней доставки сигнала
+// пользователю, стратегия — из журнала
+func (r *signalFeedbackRepoImpl) Vote(userID int, platform, signalID, vote string) (bool, error) {
+	query := `
+		INSERT INTO signal_feedback (user_id, platform, signal_id, symbol, direction, period_minutes, strategy, vote)
+		SELECT d.user_id, d.platform, d.signal_id, d.symbol, d.direction, d.period_minutes,
+			COALESCE(s.strategy, ''), $4
+		FROM signal_deliveries d
+		LEFT JOIN signals s ON s.signal_id = d.signal_id
+		WHERE d.user_id = $1 AND d.platform = $2 AND d.signal_id = $3
+		ORDER BY d.delivered_at DESC
+		LIMIT 1
+		ON CONFLICT (user_id, signal_id) DO UPDATE
+			SET vote = EXCLUDED.vote, platform = EXCLUDED.platform, updated_at = NOW()
+	`
+	res, err := r.db.Exec(query, userID, platform, signalID, vote)
+	if err != nil {
+		return false, fmt.Errorf("SignalFeedbackRepo.Vote: %w", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// dimensionExpressions — SQL-выражения ключей группировки
+var dimensionExpressions = map[string]string{
+	DimensionTotal:     `'all'`,
+	DimensionAnalyzer:  `COALESCE(NULLIF(strategy, ''), 'unknown')`,
+	DimensionPeriod:    `period_minutes::text`,
+	DimensionDirection: `direction`,
+	DimensionSymbol:    `symbol`,
+}
+
+// Aggregate группирует голоса окна по измерению
+func (r *signalFeedbackRepoImpl) Aggregate(from, to time.Time, dimension string) ([]*models.FeedbackGroupStats, error) {
+	key, ok := dimensionExpressions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("SignalFeedbackRepo.Aggregate: неизвестное измерение %q", dimension)
+	}
+
+	query := `SELECT ` + key + ` AS key,
+			COUNT(*) AS votes,
+			COUNT(*) FILTER (WHERE vote = 'up') AS up,
+			COUNT(*) FILTER (WHERE vote = 'down') AS down,
+			COUNT(*) FILTER (WHERE vote = 'late') AS late,
+			COUNT(*) FILTER (WHERE vote = 'noise') AS noise
+		FROM signal_feedback
+		WHERE updated_at >= $1 AND ($2::timestamptz IS NULL OR updated_at < $2)
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`
+
+	var toArg interface{}
+	if !to.IsZero() {
+		toArg = to
+	}
+	var stats []*models.FeedbackGroupStats
+	if err := r.db.Select(&stats, query, from, toArg); err != nil {
+		return nil, fmt.Errorf("SignalFeedbackRepo.Aggregate: %w", err)
+	}
+	return stats, nil
+}
+
+// NoisySymbols возвращает символы с отметками «шум» за вычетом 👍 того же пользователя
+func (r *signalFeedbackRepoImpl) NoisySymbols(since time.Time, minVotes int) ([]*models.SymbolNoise, error) {
+	query := `
+		SELECT user_id, symbol,
+			COUNT(*) FILTER (WHERE vote = 'noise') - COUNT(*) FILTER (WHERE vote = 'up') AS noise
+		FROM signal_feedback
+		WHERE updated_at >= $1 AND vote IN ('noise', 'up')
+		GROUP BY user_id, symbol
+		HAVING COUNT(*) FILTER (WHERE vote = 'noise') - COUNT(*) FILTER (WHERE vote = 'up') >= $2
+	`
+	var noisy []*models.SymbolNoise
+	if err := r.db.Select(&noisy, query, since, minVotes); err != nil {
+		return nil, fmt.Errorf("SignalFeedbackRepo.NoisySymbols: %w", err)
+	}
+	return noisy, nil
+}
+
+// DeleteOlderThan удаляет голоса старше before
+func (r *signalFeedbackRepoImpl) DeleteOlderThan(before time.Time) (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM signal_feedback WHERE updated_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("SignalFeedbackRepo.DeleteOlderThan: %w", err)
+	}
+	deleted, _ := res.RowsAffected()
+	return deleted, nil
+}
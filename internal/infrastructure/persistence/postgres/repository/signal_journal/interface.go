@@ -0,0 +1,48 @@
+package signal_journal_repo
+
+import (
+	"context"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// SignalJournalRepository интерфейс доступа к журналу сигналов
+type SignalJournalRepository interface {
+	// Record сохраняет сигнал; повторная запись того же signal_id обновляет поля
+	// сигнала и дополняет контекст
+	Record(rec *models.SignalRecord) error
+	// AttachContext дополняет контекст уже сохраненного сигнала; false — сигнала
+	// с таким signal_id еще нет, запись не создается
+	AttachContext(rec *models.SignalRecord) (bool, error)
+	// FindPending возвращает сигналы с незавершенным исходом, выданные не позже issuedBefore,
+	// у которых наступил next_due_at (или которые еще не оценивались) — ближайшие к сроку первыми
+	FindPending(issuedBefore, now time.Time, limit int) ([]*models.SignalRecord, error)
+	// UpdateOutcome сохраняет доходности, MFE/MAE и статус исхода
+	UpdateOutcome(rec *models.SignalRecord) error
+	// DeleteOlderThan удаляет сигналы старше before и возвращает их количество
+	DeleteOlderThan(before time.Time) (int64, error)
+	// Aggregate группирует исходы сигналов окна по измерению (Dimension*)
+	Aggregate(filter AnalyticsFilter, dimension string) ([]*models.SignalGroupStats, error)
+	// Stream построчно передает в fn сигналы окна [from, to) в порядке времени, не загружая их в память;
+	// ошибка fn прерывает чтение
+	Stream(ctx context.Context, from, to time.Time, fn func(*models.SignalRecord) error) error
+}
+
+// Измерения агрегации журнала
+const (
+	DimensionTotal     = "total"     // одна группа со всеми сигналами
+	DimensionAnalyzer  = "analyzer"  // стратегия (анализатор) сигнала
+	DimensionPeriod    = "period"    // период в минутах
+	DimensionDirection = "direction" // long / short
+	DimensionSymbol    = "symbol"
+	DimensionHour      = "hour"   // час сигнала по UTC, 00-23
+	DimensionRegime    = "regime" // режим рынка на момент сигнала
+	DimensionDay       = "day"    // дата сигнала по UTC, 2006-01-02
+)
+
+// AnalyticsFilter окно и горизонт агрегации
+type AnalyticsFilter struct {
+	From    time.Time
+	To      time.Time // нулевое — без верхней границы
+	Horizon string    // 5m, 15m, 1h, 4h, 24h
+}
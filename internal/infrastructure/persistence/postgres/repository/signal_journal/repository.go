@@ -0,0 +1,230 @@
+// /internal/infrastructure/persistence/postgres/repository/signal_journal/repository.go
+package signal_journal_repo
+
+import (
+	"context"
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const signalColumns = `id, signal_id, symbol, signal_type, direction, strategy, period_minutes,
+	confidence, change_percent, entry_price, signal_time, indicators, context, tags,
+	ret_5m, ret_15m, ret_1h, ret_4h, ret_24h, mfe_pct, mae_pct,
+	outcome_status, evaluated_until, next_due_at, created_at, updated_at`
+
+type signalJournalRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewSignalJournalRepository создаёт реализацию SignalJournalRepository
+func NewSignalJournalRepository(db *sqlx.DB) SignalJournalRepository {
+	return &signalJournalRepoImpl{db: db}
+}
+
+// Record сохраняет сигнал
+func (r *signalJournalRepoImpl) Record(rec *models.SignalRecord) error {
+	if rec.Tags == nil {
+		rec.Tags = pq.StringArray{} // nil драйвер передал бы как NULL
+	}
+	query := `
+		INSERT INTO signals (signal_id, symbol, signal_type, direction, strategy, period_minutes,
+			confidence, change_percent, entry_price, signal_time, indicators, context, tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11::jsonb, $12::jsonb, $13)
+		ON CONFLICT (signal_id) DO UPDATE SET
+			signal_type = EXCLUDED.signal_type,
+			direction = EXCLUDED.direction,
+			strategy = EXCLUDED.strategy,
+			period_minutes = EXCLUDED.period_minutes,
+			confidence = EXCLUDED.confidence,
+			change_percent = EXCLUDED.change_percent,
+			entry_price = CASE WHEN EXCLUDED.entry_price > 0 THEN EXCLUDED.entry_price ELSE signals.entry_price END,
+			indicators = EXCLUDED.indicators,
+			context = signals.context || EXCLUDED.context,
+			tags = EXCLUDED.tags,
+			updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRowx(query,
+		rec.SignalID, rec.Symbol, rec.SignalType, rec.Direction, rec.Strategy, rec.PeriodMinutes,
+		rec.Confidence, rec.ChangePercent, rec.EntryPrice, rec.SignalTime,
+		jsonOrEmpty(rec.Indicators), jsonOrEmpty(rec.Context), rec.Tags,
+	).Scan(&rec.ID, &rec.CreatedAt, &rec.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("SignalJournalRepo.Record: %w", err)
+	}
+	return nil
+}
+
+// AttachContext дополняет контекст сохраненного сигнала
+func (r *signalJournalRepoImpl) AttachContext(rec *models.SignalRecord) (bool, error) {
+	query := `
+		UPDATE signals SET
+			context = context || $2::jsonb,
+			entry_price = CASE WHEN entry_price > 0 THEN entry_price ELSE $3 END,
+			updated_at = NOW()
+		WHERE signal_id = $1
+	`
+	result, err := r.db.Exec(query, rec.SignalID, jsonOrEmpty(rec.Context), rec.EntryPrice)
+	if err != nil {
+		return false, fmt.Errorf("SignalJournalRepo.AttachContext: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("SignalJournalRepo.AttachContext: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// FindPending возвращает сигналы, которым пора оценить исход: еще не оценивавшиеся —
+// первыми, затем по next_due_at
+func (r *signalJournalRepoImpl) FindPending(issuedBefore, now time.Time, limit int) ([]*models.SignalRecord, error) {
+	query := `SELECT ` + signalColumns + ` FROM signals
+		WHERE outcome_status = 'pending' AND signal_time <= $1
+			AND (next_due_at IS NULL OR next_due_at <= $2)
+		ORDER BY next_due_at NULLS FIRST, signal_time
+		LIMIT $3`
+	var records []*models.SignalRecord
+	if err := r.db.Select(&records, query, issuedBefore, now, limit); err != nil {
+		return nil, fmt.Errorf("SignalJournalRepo.FindPending: %w", err)
+	}
+	return records, nil
+}
+
+// UpdateOutcome сохраняет исход сигнала
+func (r *signalJournalRepoImpl) UpdateOutcome(rec *models.SignalRecord) error {
+	query := `
+		UPDATE signals
+		SET ret_5m = $2, ret_15m = $3, ret_1h = $4, ret_4h = $5, ret_24h = $6,
+			mfe_pct = $7, mae_pct = $8,
+			outcome_status = $9,
+			evaluated_until = $10,
+			next_due_at = $11,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(query, rec.ID,
+		rec.Ret5m, rec.Ret15m, rec.Ret1h, rec.Ret4h, rec.Ret24h,
+		rec.MFEPct, rec.MAEPct, rec.OutcomeStatus, rec.EvaluatedUntil, rec.NextDueAt,
+	)
+	if err != nil {
+		return fmt.Errorf("SignalJournalRepo.UpdateOutcome: %w", err)
+	}
+	return nil
+}
+
+// DeleteOlderThan удаляет сигналы старше before
+func (r *signalJournalRepoImpl) DeleteOlderThan(before time.Time) (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM signals WHERE signal_time < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("SignalJournalRepo.DeleteOlderThan: %w", err)
+	}
+	deleted, _ := res.RowsAffected()
+	return deleted, nil
+}
+
+// horizonColumns — колонки доходностей по горизонтам журнала
+var horizonColumns = map[string]string{
+	"5m":  "ret_5m",
+	"15m": "ret_15m",
+	"1h":  "ret_1h",
+	"4h":  "ret_4h",
+	"24h": "ret_24h",
+}
+
+// shortCondition — сигнал на падение (как models.SignalRecord.IsShort)
+const shortCondition = `direction IN ('fall', 'down', 'bearish', 'short')`
+
+// regimeExpression — режим рынка: из контекста сигнала, а для сигналов без него —
+// по движению ориентира (BTC/ETH) на свече сигнала из market_context
+const regimeExpression = `COALESCE(NULLIF(context->>'market_regime', ''),
+	CASE
+		WHEN context->'market_context'->>'benchmark_change' IS NULL THEN 'unknown'
+		WHEN (context->'market_context'->>'benchmark_change')::float8 >= 0.3 THEN 'bull'
+		WHEN (context->'market_context'->>'benchmark_change')::float8 <= -0.3 THEN 'bear'
+		ELSE 'flat'
+	END)`
+
+// dimensionExpressions — SQL-выражения ключей группировки
+var dimensionExpressions = map[string]string{
+	DimensionTotal:     `'all'`,
+	DimensionAnalyzer:  `strategy`,
+	DimensionPeriod:    `period_minutes::text`,
+	DimensionDirection: `CASE WHEN ` + shortCondition + ` THEN 'short' ELSE 'long' END`,
+	DimensionSymbol:    `symbol`,
+	DimensionHour:      `to_char(signal_time AT TIME ZONE 'UTC', 'HH24')`,
+	DimensionRegime:    regimeExpression,
+	DimensionDay:       `to_char(signal_time AT TIME ZONE 'UTC', 'YYYY-MM-DD')`,
+}
+
+// Aggregate группирует исходы сигналов окна по измерению
+func (r *signalJournalRepoImpl) Aggregate(filter AnalyticsFilter, dimension string) ([]*models.SignalGroupStats, error) {
+	key, ok := dimensionExpressions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("SignalJournalRepo.Aggregate: неизвестное измерение %q", dimension)
+	}
+	column, ok := horizonColumns[filter.Horizon]
+	if !ok {
+		return nil, fmt.Errorf("SignalJournalRepo.Aggregate: неизвестный горизонт %q", filter.Horizon)
+	}
+	directed := `CASE WHEN ` + shortCondition + ` THEN -` + column + ` ELSE ` + column + ` END`
+
+	query := `SELECT ` + key + ` AS key,
+			COUNT(*) AS signals,
+			COUNT(` + column + `) AS evaluated,
+			COUNT(*) FILTER (WHERE ` + directed + ` > 0) AS hits,
+			COALESCE(AVG(` + directed + `), 0) AS avg_return,
+			COALESCE(AVG(mfe_pct), 0) AS avg_mfe,
+			COALESCE(AVG(mae_pct), 0) AS avg_mae
+		FROM signals
+		WHERE signal_time >= $1 AND ($2::timestamptz IS NULL OR signal_time < $2)
+		GROUP BY 1
+		ORDER BY 1`
+
+	var to interface{}
+	if !filter.To.IsZero() {
+		to = filter.To
+	}
+	var stats []*models.SignalGroupStats
+	if err := r.db.Select(&stats, query, filter.From, to); err != nil {
+		return nil, fmt.Errorf("SignalJournalRepo.Aggregate: %w", err)
+	}
+	return stats, nil
+}
+
+// jsonOrEmpty передает JSONB строкой: []byte драйвер отправил бы как bytea
+func jsonOrEmpty(raw []byte) string {
+	if len(raw) == 0 {
+		return "{}"
+	}
+	return string(raw)
+}
+
+// Stream построчно читает сигналы окна
+func (r *signalJournalRepoImpl) Stream(ctx context.Context, from, to time.Time, fn func(*models.SignalRecord) error) error {
+	query := `SELECT ` + signalColumns + ` FROM signals
+		WHERE signal_time >= $1 AND signal_time < $2
+		ORDER BY signal_time, id`
+	rows, err := r.db.QueryxContext(ctx, query, from, to)
+	if err != nil {
+		return fmt.Errorf("SignalJournalRepo.Stream: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec models.SignalRecord
+		if err := rows.StructScan(&rec); err != nil {
+			return fmt.Errorf("SignalJournalRepo.Stream: %w", err)
+		}
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("SignalJournalRepo.Stream: %w", err)
+	}
+	return nil
+}
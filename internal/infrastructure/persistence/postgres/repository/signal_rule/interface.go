@@ -0,0 +1,30 @@
+package signal_rule_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"time"
+)
+
+// SignalRuleRepository интерфейс доступа к пользовательским правилам сигналов
+type SignalRuleRepository interface {
+	// Create сохраняет новое правило и заполняет ID/CreatedAt/UpdatedAt
+	Create(rule *models.SignalRule) error
+	// Delete удаляет правило пользователя; false — правило не найдено
+	Delete(userID, ruleID int) (bool, error)
+	// SetActive включает или выключает правило пользователя; false — правило не найдено
+	SetActive(userID, ruleID int, active bool) (bool, error)
+	// FindByUser возвращает все правила пользователя
+	FindByUser(userID int) ([]*models.SignalRule, error)
+	// CreateWithinLimit в одной транзакции проверяет, что активных правил
+	// пользователя меньше maxActive (-1 — без ограничений), и сохраняет правило.
+	// false — лимит достигнут, правило не создано.
+	CreateWithinLimit(rule *models.SignalRule, maxActive int) (bool, error)
+	// ActivateWithinLimit в одной транзакции проверяет, что остальных активных правил
+	// пользователя меньше maxActive (-1 — без ограничений), и включает правило.
+	// found=false — правило не найдено; activated=false — лимит достигнут.
+	ActivateWithinLimit(userID, ruleID, maxActive int) (found, activated bool, err error)
+	// FindAllActive возвращает активные правила всех пользователей
+	FindAllActive() ([]*models.SignalRule, error)
+	// MarkTriggered фиксирует срабатывание правила
+	MarkTriggered(ruleID int, at time.Time) error
+}
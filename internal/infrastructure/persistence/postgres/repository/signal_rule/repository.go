@@ -0,0 +1,193 @@
+// /internal/infrastructure/persistence/postgres/repository/signal_rule/repository.go
+package signal_rule_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const signalRuleColumns = `id, user_id, name, expression, is_active, cooldown_minutes,
+	trigger_count, last_triggered_at, created_at, updated_at`
+
+type signalRuleRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewSignalRuleRepository создаёт реализацию SignalRuleRepository
+func NewSignalRuleRepository(db *sqlx.DB) SignalRuleRepository {
+	return &signalRuleRepoImpl{db: db}
+}
+
+// Create сохраняет новое правило
+func (r *signalRuleRepoImpl) Create(rule *models.SignalRule) error {
+	query := `
+		INSERT INTO signal_rules (user_id, name, expression, is_active, cooldown_minutes)
+		VALUES (:user_id, :name, :expression, :is_active, :cooldown_minutes)
+		RETURNING id, created_at, updated_at
+	`
+	rows, err := r.db.NamedQuery(query, rule)
+	if err != nil {
+		return fmt.Errorf("SignalRuleRepo.Create: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt); err != nil {
+			return fmt.Errorf("SignalRuleRepo.Create: %w", err)
+		}
+	}
+	return nil
+}
+
+// Delete удаляет правило пользователя
+func (r *signalRuleRepoImpl) Delete(userID, ruleID int) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM signal_rules WHERE id = $1 AND user_id = $2`, ruleID, userID)
+	if err != nil {
+		return false, fmt.Errorf("SignalRuleRepo.Delete: %w", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// SetActive включает или выключает правило пользователя
+func (r *signalRuleRepoImpl) SetActive(userID, ruleID int, active bool) (bool, error) {
+	query := `
+		UPDATE signal_rules
+		SET is_active = $3, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+	`
+	res, err := r.db.Exec(query, ruleID, userID, active)
+	if err != nil {
+		return false, fmt.Errorf("SignalRuleRepo.SetActive: %w", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// FindByUser возвращает все правила пользователя
+func (r *signalRuleRepoImpl) FindByUser(userID int) ([]*models.SignalRule, error) {
+	query := `SELECT ` + signalRuleColumns + ` FROM signal_rules WHERE user_id = $1 ORDER BY id`
+	var rules []*models.SignalRule
+	if err := r.db.Select(&rules, query, userID); err != nil {
+		return nil, fmt.Errorf("SignalRuleRepo.FindByUser: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateWithinLimit сохраняет правило, если активных правил меньше maxActive.
+// Строка пользователя блокируется до конца транзакции, поэтому параллельные
+// добавления одного пользователя проверяют лимит по очереди.
+func (r *signalRuleRepoImpl) CreateWithinLimit(rule *models.SignalRule, maxActive int) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("SignalRuleRepo.CreateWithinLimit: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, rule.UserID); err != nil {
+		return false, fmt.Errorf("SignalRuleRepo.CreateWithinLimit: %w", err)
+	}
+
+	if maxActive >= 0 {
+		var active int
+		query := `SELECT COUNT(*) FROM signal_rules WHERE user_id = $1 AND is_active = TRUE`
+		if err := tx.Get(&active, query, rule.UserID); err != nil {
+			return false, fmt.Errorf("SignalRuleRepo.CreateWithinLimit: %w", err)
+		}
+		if active >= maxActive {
+			return false, nil
+		}
+	}
+
+	query := `
+		INSERT INTO signal_rules (user_id, name, expression, is_active, cooldown_minutes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRowx(query, rule.UserID, rule.Name, rule.Expression, rule.IsActive, rule.CooldownMinutes).
+		Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("SignalRuleRepo.CreateWithinLimit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("SignalRuleRepo.CreateWithinLimit: %w", err)
+	}
+	return true, nil
+}
+
+// ActivateWithinLimit включает правило, если остальных активных правил меньше maxActive.
+// Блокирует строку пользователя так же, как CreateWithinLimit, поэтому включение
+// и добавление правил одного пользователя не обходят лимит параллельно.
+func (r *signalRuleRepoImpl) ActivateWithinLimit(userID, ruleID, maxActive int) (bool, bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, false, fmt.Errorf("SignalRuleRepo.ActivateWithinLimit: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		return false, false, fmt.Errorf("SignalRuleRepo.ActivateWithinLimit: %w", err)
+	}
+
+	var isActive bool
+	err = tx.Get(&isActive, `SELECT is_active FROM signal_rules WHERE id = $1 AND user_id = $2`, ruleID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("SignalRuleRepo.ActivateWithinLimit: %w", err)
+	}
+	if isActive {
+		return true, true, nil
+	}
+
+	if maxActive >= 0 {
+		var active int
+		query := `SELECT COUNT(*) FROM signal_rules WHERE user_id = $1 AND is_active = TRUE`
+		if err := tx.Get(&active, query, userID); err != nil {
+			return false, false, fmt.Errorf("SignalRuleRepo.ActivateWithinLimit: %w", err)
+		}
+		if active >= maxActive {
+			return true, false, nil
+		}
+	}
+
+	query := `UPDATE signal_rules SET is_active = TRUE, updated_at = NOW() WHERE id = $1 AND user_id = $2`
+	if _, err := tx.Exec(query, ruleID, userID); err != nil {
+		return false, false, fmt.Errorf("SignalRuleRepo.ActivateWithinLimit: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, false, fmt.Errorf("SignalRuleRepo.ActivateWithinLimit: %w", err)
+	}
+	return true, true, nil
+}
+
+// FindAllActive возвращает активные правила всех пользователей
+func (r *signalRuleRepoImpl) FindAllActive() ([]*models.SignalRule, error) {
+	query := `SELECT ` + signalRuleColumns + ` FROM signal_rules WHERE is_active = TRUE`
+	var rules []*models.SignalRule
+	if err := r.db.Select(&rules, query); err != nil {
+		return nil, fmt.Errorf("SignalRuleRepo.FindAllActive: %w", err)
+	}
+	return rules, nil
+}
+
+// MarkTriggered фиксирует срабатывание правила
+func (r *signalRuleRepoImpl) MarkTriggered(ruleID int, at time.Time) error {
+	query := `
+		UPDATE signal_rules
+		SET trigger_count = trigger_count + 1, last_triggered_at = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.Exec(query, ruleID, at); err != nil {
+		return fmt.Errorf("SignalRuleRepo.MarkTriggered: %w", err)
+	}
+	return nil
+}
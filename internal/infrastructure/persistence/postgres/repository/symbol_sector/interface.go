@@ -0,0 +1,9 @@
+package symbol_sector_repo
+
+import "crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+
+// SymbolSectorRepository интерфейс доступа к секторам символов
+type SymbolSectorRepository interface {
+	// FindActive возвращает активные секторы в порядке sort_order
+	FindActive() ([]*models.SymbolSector, error)
+}
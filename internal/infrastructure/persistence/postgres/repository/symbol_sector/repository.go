@@ -0,0 +1,31 @@
+// /internal/infrastructure/persistence/postgres/repository/symbol_sector/repository.go
+package symbol_sector_repo
+
+import (
+	"crypto-exchange-screener-bot/internal/infrastructure/persistence/postgres/models"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const symbolSectorColumns = `name, title, symbols, sort_order, is_active, created_at, updated_at`
+
+type symbolSectorRepoImpl struct {
+	db *sqlx.DB
+}
+
+// NewSymbolSectorRepository создаёт реализацию SymbolSectorRepository
+func NewSymbolSectorRepository(db *sqlx.DB) SymbolSectorRepository {
+	return &symbolSectorRepoImpl{db: db}
+}
+
+// FindActive возвращает активные секторы
+func (r *symbolSectorRepoImpl) FindActive() ([]*models.SymbolSector, error) {
+	query := `SELECT ` + symbolSectorColumns + ` FROM symbol_sectors
+		WHERE is_active = TRUE ORDER BY sort_order, name`
+	var sectors []*models.SymbolSector
+	if err := r.db.Select(&sectors, query); err != nil {
+		return nil, fmt.Errorf("SymbolSectorRepo.FindActive: %w", err)
+	}
+	return sectors, nil
+}
@@ -67,7 +67,11 @@ func (r *UserRepositoryImpl) GetAllActive() ([]*models.User, error) {
         signals_today, max_signals_per_day,
         created_at, updated_at, last_login_at, last_signal_at,
         max_user_id, max_chat_id, link_code, link_code_expires_at,
-        watchlist_symbols
+        watchlist_symbols, min_confluence_score, suppress_market_moves,
+        notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+        range_breakout_horizons,
+        notify_vwap, vwap_anchor_at,
+        regime_thresholds, notify_wall_pulls
     FROM users
     WHERE is_active = TRUE
     ORDER BY created_at DESC
@@ -111,9 +115,13 @@ func (r *UserRepositoryImpl) GetAll(limit, offset int) ([]*models.User, error) {
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $1 OFFSET $2
 	`
 
@@ -297,7 +305,11 @@ func (r *UserRepositoryImpl) FindByID(id int) (*models.User, error) {
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE id = $1
 	`
@@ -320,7 +332,11 @@ func (r *UserRepositoryImpl) FindByTelegramID(telegramID int64) (*models.User, e
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE telegram_id = $1
 	`
@@ -343,7 +359,11 @@ func (r *UserRepositoryImpl) FindByChatID(chatID string) (*models.User, error) {
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE chat_id = $1
 	`
@@ -366,7 +386,11 @@ func (r *UserRepositoryImpl) FindByEmail(email string) (*models.User, error) {
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE email = $1
 	`
@@ -419,8 +443,18 @@ func (r *UserRepositoryImpl) Update(user *models.User) error {
 			link_code_expires_at = $31,
 			max_notifications_enabled = $32,
 			watchlist_symbols = $33,
-			updated_at = $34
-		WHERE id = $35
+			min_confluence_score = $34,
+			suppress_market_moves = $35,
+			notify_pattern_zones = $36,
+			sensitivity_sigma = $37,
+			notify_sector_digest = $38,
+			range_breakout_horizons = $39,
+			notify_vwap = $40,
+			vwap_anchor_at = $41,
+			regime_thresholds = $42,
+			notify_wall_pulls = $43,
+			updated_at = $44
+		WHERE id = $45
 	`
 
 	result, err := tx.Exec(query,
@@ -437,6 +471,16 @@ func (r *UserRepositoryImpl) Update(user *models.User) error {
 		getNullTimePtr(user.LinkCodeExpiresAt),
 		user.MaxNotificationsEnabled,
 		pq.Array(user.WatchlistSymbols),
+		user.MinConfluenceScore,
+		user.SuppressMarketMoves,
+		user.NotifyPatternZones,
+		user.SensitivitySigma,
+		user.NotifySectorDigest,
+		pq.Array(user.RangeBreakoutHorizons),
+		user.NotifyVWAP,
+		getNullTimePtr(user.VWAPAnchorAt),
+		user.RegimeThresholds,
+		user.NotifyWallPulls,
 		time.Now(), user.ID,
 	)
 
@@ -571,7 +615,11 @@ func (r *UserRepositoryImpl) SearchUsers(query string, limit, offset int) ([]*mo
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE username ILIKE $1 OR first_name ILIKE $1 OR last_name ILIKE $1 OR email ILIKE $1
 		ORDER BY created_at DESC
@@ -623,6 +671,8 @@ func (r *UserRepositoryImpl) scanUser(rows *sql.Rows) (*models.User, error) {
 	var linkCodeExpiresAt sql.NullTime
 
 	var watchlistSymbols []sql.NullString
+	var rangeHorizons []sql.NullString
+	var vwapAnchorAt sql.NullTime
 	err := rows.Scan(
 		&user.ID, &user.TelegramID, &user.Username, &user.FirstName,
 		&user.LastName, &user.ChatID, &user.Email, &user.Phone,
@@ -634,7 +684,15 @@ func (r *UserRepositoryImpl) scanUser(rows *sql.Rows) (*models.User, error) {
 		&user.SignalsToday, &user.MaxSignalsPerDay,
 		&user.CreatedAt, &user.UpdatedAt, &lastLoginAt, &lastSignalAt,
 		&maxUserID, &maxChatID, &linkCode, &linkCodeExpiresAt,
-		pq.Array(&watchlistSymbols),
+		pq.Array(&watchlistSymbols), &user.MinConfluenceScore, &user.SuppressMarketMoves,
+		&user.NotifyPatternZones,
+		&user.SensitivitySigma,
+		&user.NotifySectorDigest,
+		pq.Array(&rangeHorizons),
+		&user.NotifyVWAP,
+		&vwapAnchorAt,
+		&user.RegimeThresholds,
+		&user.NotifyWallPulls,
 	)
 
 	if err != nil {
@@ -680,6 +738,17 @@ func (r *UserRepositoryImpl) scanUser(rows *sql.Rows) (*models.User, error) {
 		}
 	}
 
+	if vwapAnchorAt.Valid {
+		user.VWAPAnchorAt = &vwapAnchorAt.Time
+	}
+
+	user.RangeBreakoutHorizons = make([]string, 0, len(rangeHorizons))
+	for _, v := range rangeHorizons {
+		if v.Valid {
+			user.RangeBreakoutHorizons = append(user.RangeBreakoutHorizons, v.String)
+		}
+	}
+
 	if watchlistSymbols != nil {
 		// nil = фильтр отключён (все сигналы); [] = фильтр пуст (нет сигналов)
 		user.WatchlistSymbols = make([]string, len(watchlistSymbols))
@@ -704,6 +773,8 @@ func (r *UserRepositoryImpl) scanUserRow(row *sql.Row) (*models.User, error) {
 	var linkCodeExpiresAt sql.NullTime
 
 	var watchlistSymbols []sql.NullString
+	var rangeHorizons []sql.NullString
+	var vwapAnchorAt sql.NullTime
 	err := row.Scan(
 		&user.ID, &user.TelegramID, &user.Username, &user.FirstName,
 		&user.LastName, &user.ChatID, &user.Email, &user.Phone,
@@ -715,7 +786,15 @@ func (r *UserRepositoryImpl) scanUserRow(row *sql.Row) (*models.User, error) {
 		&user.SignalsToday, &user.MaxSignalsPerDay,
 		&user.CreatedAt, &user.UpdatedAt, &lastLoginAt, &lastSignalAt,
 		&maxUserID, &maxChatID, &linkCode, &linkCodeExpiresAt,
-		pq.Array(&watchlistSymbols),
+		pq.Array(&watchlistSymbols), &user.MinConfluenceScore, &user.SuppressMarketMoves,
+		&user.NotifyPatternZones,
+		&user.SensitivitySigma,
+		&user.NotifySectorDigest,
+		pq.Array(&rangeHorizons),
+		&user.NotifyVWAP,
+		&vwapAnchorAt,
+		&user.RegimeThresholds,
+		&user.NotifyWallPulls,
 	)
 
 	if err != nil {
@@ -764,6 +843,17 @@ func (r *UserRepositoryImpl) scanUserRow(row *sql.Row) (*models.User, error) {
 		}
 	}
 
+	if vwapAnchorAt.Valid {
+		user.VWAPAnchorAt = &vwapAnchorAt.Time
+	}
+
+	user.RangeBreakoutHorizons = make([]string, 0, len(rangeHorizons))
+	for _, v := range rangeHorizons {
+		if v.Valid {
+			user.RangeBreakoutHorizons = append(user.RangeBreakoutHorizons, v.String)
+		}
+	}
+
 	if watchlistSymbols != nil {
 		// nil = фильтр отключён (все сигналы); [] = фильтр пуст (нет сигналов)
 		user.WatchlistSymbols = make([]string, len(watchlistSymbols))
@@ -839,7 +929,11 @@ func (r *UserRepositoryImpl) FindByMaxUserID(maxUserID int64) (*models.User, err
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE max_user_id = $1
 	`
@@ -861,7 +955,11 @@ func (r *UserRepositoryImpl) FindByLinkCode(code string) (*models.User, error) {
 			signals_today, max_signals_per_day,
 			created_at, updated_at, last_login_at, last_signal_at,
 			max_user_id, max_chat_id, link_code, link_code_expires_at,
-			watchlist_symbols
+			watchlist_symbols, min_confluence_score, suppress_market_moves,
+			notify_pattern_zones, sensitivity_sigma, notify_sector_digest,
+			range_breakout_horizons,
+			notify_vwap, vwap_anchor_at,
+			regime_thresholds, notify_wall_pulls
 		FROM users
 		WHERE link_code = $1
 		  AND link_code_expires_at > NOW()
@@ -0,0 +1,196 @@
+// internal/infrastructure/persistence/redis_storage/alert_index/index.go
+package alert_index
+
+import (
+	"context"
+	redis_service "crypto-exchange-screener-bot/internal/infrastructure/cache/redis"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	aboveKeyPrefix = "alerts:above:" // срабатывает, когда цена >= score
+	belowKeyPrefix = "alerts:below:" // срабатывает, когда цена <= score
+	symbolsKey     = "alerts:symbols"
+	rearmSuffix    = ":r"
+)
+
+// Side — сторона уровня относительно текущей цены
+type Side int
+
+const (
+	// Above — уровень выше цены: ждём роста до уровня
+	Above Side = iota
+	// Below — уровень ниже цены: ждём падения до уровня
+	Below
+)
+
+// Entry — уровень алерта в индексе.
+// Rearm-уровень не уведомляет пользователя, а лишь перевзводит повторяющийся алерт.
+type Entry struct {
+	AlertID int
+	Side    Side
+	Level   float64
+	Rearm   bool
+}
+
+// Hit — достигнутый уровень алерта
+type Hit struct {
+	AlertID int
+	Rearm   bool
+}
+
+// AlertIndex — индекс ценовых алертов в Redis.
+// Ключи: alerts:above:{symbol} и alerts:below:{symbol}
+// Структура: ZSET, score = цена уровня, member = "{alertID}" или "{alertID}:r".
+// Проверка цены — ZRANGEBYSCORE, O(log n + k) на символ.
+type AlertIndex struct {
+	client *redis.Client
+	ctx    context.Context
+}
+
+// NewAlertIndex создаёт индекс алертов
+func NewAlertIndex(redisService *redis_service.RedisService) (*AlertIndex, error) {
+	if redisService == nil {
+		return nil, fmt.Errorf("redisService не инициализирован")
+	}
+	client := redisService.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("redis клиент недоступен")
+	}
+	return &AlertIndex{
+		client: client,
+		ctx:    context.Background(),
+	}, nil
+}
+
+func sideKey(side Side, symbol string) string {
+	if side == Above {
+		return aboveKeyPrefix + symbol
+	}
+	return belowKeyPrefix + symbol
+}
+
+func member(alertID int, rearm bool) string {
+	m := strconv.Itoa(alertID)
+	if rearm {
+		m += rearmSuffix
+	}
+	return m
+}
+
+func parseMember(m string) (Hit, bool) {
+	rearm := strings.HasSuffix(m, rearmSuffix)
+	id, err := strconv.Atoi(strings.TrimSuffix(m, rearmSuffix))
+	if err != nil {
+		return Hit{}, false
+	}
+	return Hit{AlertID: id, Rearm: rearm}, true
+}
+
+// Arm добавляет уровни алерта символа в индекс
+func (x *AlertIndex) Arm(symbol string, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	pipe := x.client.Pipeline()
+	for _, e := range entries {
+		pipe.ZAdd(x.ctx, sideKey(e.Side, symbol), &redis.Z{
+			Score:  e.Level,
+			Member: member(e.AlertID, e.Rearm),
+		})
+	}
+	pipe.SAdd(x.ctx, symbolsKey, symbol)
+
+	if _, err := pipe.Exec(x.ctx); err != nil {
+		return fmt.Errorf("alert_index: ошибка добавления алерта %s: %w", symbol, err)
+	}
+	return nil
+}
+
+// Remove удаляет все уровни алерта. Возвращает true, если что-то было удалено:
+// при одновременной обработке одного уровня несколькими воркерами
+// срабатывание обрабатывает только тот, чей ZREM удалил запись.
+func (x *AlertIndex) Remove(symbol string, alertID int) (bool, error) {
+	pipe := x.client.Pipeline()
+	cmds := []*redis.IntCmd{
+		pipe.ZRem(x.ctx, sideKey(Above, symbol), member(alertID, false), member(alertID, true)),
+		pipe.ZRem(x.ctx, sideKey(Below, symbol), member(alertID, false), member(alertID, true)),
+	}
+	if _, err := pipe.Exec(x.ctx); err != nil {
+		return false, fmt.Errorf("alert_index: ошибка удаления алерта #%d: %w", alertID, err)
+	}
+
+	removed := int64(0)
+	for _, cmd := range cmds {
+		removed += cmd.Val()
+	}
+	return removed > 0, nil
+}
+
+// Check возвращает уровни, достигнутые текущими ценами.
+// Запрашиваются только символы, для которых в индексе есть алерты.
+func (x *AlertIndex) Check(prices map[string]float64) (map[string][]Hit, error) {
+	symbols, err := x.client.SMembers(x.ctx, symbolsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("alert_index: ошибка чтения символов: %w", err)
+	}
+
+	type query struct {
+		symbol string
+		cmd    *redis.StringSliceCmd
+	}
+
+	pipe := x.client.Pipeline()
+	queries := make([]query, 0, len(symbols)*2)
+	for _, symbol := range symbols {
+		price, ok := prices[symbol]
+		if !ok || price <= 0 {
+			continue
+		}
+		p := strconv.FormatFloat(price, 'f', -1, 64)
+		queries = append(queries,
+			query{symbol, pipe.ZRangeByScore(x.ctx, sideKey(Above, symbol), &redis.ZRangeBy{Min: "-inf", Max: p})},
+			query{symbol, pipe.ZRangeByScore(x.ctx, sideKey(Below, symbol), &redis.ZRangeBy{Min: p, Max: "+inf"})},
+		)
+	}
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	if _, err := pipe.Exec(x.ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("alert_index: ошибка проверки цен: %w", err)
+	}
+
+	hits := make(map[string][]Hit)
+	for _, q := range queries {
+		for _, m := range q.cmd.Val() {
+			if hit, ok := parseMember(m); ok {
+				hits[q.symbol] = append(hits[q.symbol], hit)
+			}
+		}
+	}
+	return hits, nil
+}
+
+// Clear удаляет индекс целиком (перед перестроением из Postgres)
+func (x *AlertIndex) Clear() error {
+	symbols, err := x.client.SMembers(x.ctx, symbolsKey).Result()
+	if err != nil {
+		return fmt.Errorf("alert_index: ошибка чтения символов: %w", err)
+	}
+
+	keys := make([]string, 0, len(symbols)*2+1)
+	for _, symbol := range symbols {
+		keys = append(keys, sideKey(Above, symbol), sideKey(Below, symbol))
+	}
+	keys = append(keys, symbolsKey)
+
+	if err := x.client.Del(x.ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("alert_index: ошибка очистки индекса: %w", err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -158,6 +159,40 @@ func (rcs *RedisCandleStorage) getHistoryInternal(symbol, period string, limit i
 	return candles, nil
 }
 
+// ScanHistory передает в fn закрытые свечи истории за [from, to) в порядке времени
+// порциями по batch, не загружая всю историю в память; ошибка fn прерывает чтение
+func (rcs *RedisCandleStorage) ScanHistory(symbol, period string, from, to time.Time, batch int, fn func(*storage.Candle) error) error {
+	if batch <= 0 {
+		batch = 500
+	}
+	historyKey := rcs.getHistoryKey(symbol, period)
+
+	for offset := int64(0); ; offset += int64(batch) {
+		results, err := rcs.client.ZRangeByScore(rcs.ctx, historyKey, &redis.ZRangeBy{
+			Min:    strconv.FormatInt(from.Unix(), 10),
+			Max:    "(" + strconv.FormatInt(to.Unix(), 10),
+			Offset: offset,
+			Count:  int64(batch),
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("ошибка чтения истории из Redis: %w", err)
+		}
+
+		for _, result := range results {
+			candle, err := rcs.unmarshalCandle(result)
+			if err != nil {
+				continue
+			}
+			if err := fn(candle); err != nil {
+				return err
+			}
+		}
+		if len(results) < batch {
+			return nil
+		}
+	}
+}
+
 // GetLatestCandle возвращает последнюю свечу (реализация интерфейса)
 func (rcs *RedisCandleStorage) GetLatestCandle(symbol, period string) (storage.CandleInterface, bool) {
 	candle, exists := rcs.getLatestCandleInternal(symbol, period)
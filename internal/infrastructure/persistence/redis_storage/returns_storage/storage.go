@@ -0,0 +1,101 @@
+// internal/infrastructure/persistence/redis_storage/returns_storage/storage.go
+package returns_storage
+
+import (
+	"context"
+	redis_service "crypto-exchange-screener-bot/internal/infrastructure/cache/redis"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	returnsKeyPrefix = "returns:"      // LIST доходностей, новые слева
+	lastKeyPrefix    = "returns:last:" // время начала последней учтенной свечи (unix)
+)
+
+// ReturnsStorage — Redis-хранилище скользящих окон доходностей по symbol+period.
+// Ключи: returns:{symbol}:{period} (LIST, значения в процентах, новые слева)
+// и returns:last:{symbol}:{period} (время последней свечи, чтобы не учесть ее дважды).
+type ReturnsStorage struct {
+	client *redis.Client
+	ctx    context.Context
+}
+
+// NewReturnsStorage создаёт хранилище
+func NewReturnsStorage(redisService *redis_service.RedisService) (*ReturnsStorage, error) {
+	if redisService == nil {
+		return nil, fmt.Errorf("redisService не инициализирован")
+	}
+	client := redisService.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("redis клиент недоступен")
+	}
+	return &ReturnsStorage{
+		client: client,
+		ctx:    context.Background(),
+	}, nil
+}
+
+func listKey(symbol, period string) string {
+	return returnsKeyPrefix + symbol + ":" + period
+}
+
+func lastKey(symbol, period string) string {
+	return lastKeyPrefix + symbol + ":" + period
+}
+
+// Load возвращает последние limit доходностей (в хронологическом порядке)
+// и время свечи, на которой окно было обновлено последний раз.
+// Пустое окно — не ошибка: last будет нулевым.
+func (s *ReturnsStorage) Load(symbol, period string, limit int) ([]float64, time.Time, error) {
+	pipe := s.client.Pipeline()
+	valuesCmd := pipe.LRange(s.ctx, listKey(symbol, period), 0, int64(limit-1))
+	lastCmd := pipe.Get(s.ctx, lastKey(symbol, period))
+	if _, err := pipe.Exec(s.ctx); err != nil && err != redis.Nil {
+		return nil, time.Time{}, fmt.Errorf("returns_storage: ошибка чтения %s/%s: %w", symbol, period, err)
+	}
+
+	raw := valuesCmd.Val()
+	returns := make([]float64, 0, len(raw))
+	for i := len(raw) - 1; i >= 0; i-- {
+		v, err := strconv.ParseFloat(raw[i], 64)
+		if err != nil {
+			continue
+		}
+		returns = append(returns, v)
+	}
+
+	var last time.Time
+	if unix, err := lastCmd.Int64(); err == nil {
+		last = time.Unix(unix, 0)
+	}
+	return returns, last, nil
+}
+
+// Append добавляет доходности (в хронологическом порядке), обрезает окно до window
+// значений и запоминает время последней свечи. ttl продлевается при каждой записи.
+func (s *ReturnsStorage) Append(symbol, period string, values []float64, candleTime time.Time, window int, ttl time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		members = append(members, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	key := listKey(symbol, period)
+	pipe := s.client.Pipeline()
+	pipe.LPush(s.ctx, key, members...)
+	pipe.LTrim(s.ctx, key, 0, int64(window-1))
+	pipe.Expire(s.ctx, key, ttl)
+	pipe.Set(s.ctx, lastKey(symbol, period), candleTime.Unix(), ttl)
+
+	if _, err := pipe.Exec(s.ctx); err != nil {
+		return fmt.Errorf("returns_storage: ошибка записи %s/%s: %w", symbol, period, err)
+	}
+	return nil
+}
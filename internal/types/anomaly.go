@@ -0,0 +1,19 @@
+// internal/types/anomaly.go
+package types
+
+import "time"
+
+// ReturnAnomalyData — данные события "аномальная доходность свечи"
+type ReturnAnomalyData struct {
+	Symbol     string
+	Period     string  // период закрытой свечи
+	Direction  string  // growth / fall
+	ChangePct  float64 // доходность свечи (close к предыдущему close), %
+	ZScore     float64 // робастный z-score доходности
+	Median     float64 // медиана доходностей окна, %
+	Sigma      float64 // оценка σ доходностей окна (1.4826·MAD), %
+	Samples    int     // доходностей в окне
+	Price      float64 // закрытие свечи
+	CandleTime time.Time
+	Timestamp  time.Time
+}
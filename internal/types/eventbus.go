@@ -89,4 +89,14 @@ const (
 	EventPaymentFailed              EventType = "payment.failed"
 	EventPaymentRefunded            EventType = "payment.refunded"
 	EventCandleClosed               EventType = "candle_closed"
+	EventRuleTriggered              EventType = "rule_triggered"
+	EventPriceAlertTriggered        EventType = "price_alert_triggered"
+	EventPatternAtZone              EventType = "pattern_at_zone"
+	EventReturnAnomaly              EventType = "return_anomaly"
+	EventSectorDigest               EventType = "sector_digest"
+	EventRangeBreakout              EventType = "range_breakout"
+	EventVWAPSignal                 EventType = "vwap_signal"
+	EventMarketDigest               EventType = "market_digest"
+	EventMarketRegimeChanged        EventType = "market_regime_changed"
+	EventWallPulled                 EventType = "wall_pulled"
 )
@@ -0,0 +1,50 @@
+// internal/types/market_digest.go
+package types
+
+import "time"
+
+// MarketDigestData — данные события "дайджест рынка" (суточный или недельный)
+type MarketDigestData struct {
+	Kind string    `json:"kind"` // daily / weekly
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	Gainers   []DigestValue `json:"gainers"`    // лидеры роста, %
+	Losers    []DigestValue `json:"losers"`     // лидеры падения, %
+	OIChanges []DigestValue `json:"oi_changes"` // крупнейшие изменения OI с прошлого дайджеста, %
+	FundingHi []DigestValue `json:"funding_hi"` // самый высокий фандинг, %
+	FundingLo []DigestValue `json:"funding_lo"` // самый низкий фандинг, %
+
+	Liquidations DigestLiquidations `json:"liquidations"`
+
+	TopSignalled []DigestSignalStats `json:"top_signalled"` // монеты с наибольшим числом сигналов
+	Signals      DigestSignalStats   `json:"signals"`       // исходы сигналов периода
+	Analyzers    []DigestSignalStats `json:"analyzers"`     // исходы по анализаторам
+	Horizon      string              `json:"horizon"`       // горизонт исходов сигналов
+
+	// Recipients — пользователи, которым дайджест доставляется сейчас (не хранится)
+	Recipients []int `json:"-"`
+}
+
+// DigestValue — символ и значение показателя
+type DigestValue struct {
+	Symbol string  `json:"symbol"`
+	Value  float64 `json:"value"`
+}
+
+// DigestLiquidations — итоги ликвидаций за период
+type DigestLiquidations struct {
+	TotalUSD float64       `json:"total_usd"`
+	LongUSD  float64       `json:"long_usd"`
+	ShortUSD float64       `json:"short_usd"`
+	Top      []DigestValue `json:"top"` // символы с наибольшим объемом ликвидаций, $
+}
+
+// DigestSignalStats — сигналы группы (монеты или анализатора) и их исходы
+type DigestSignalStats struct {
+	Key       string  `json:"key"`
+	Signals   int     `json:"signals"`
+	Evaluated int     `json:"evaluated"`
+	HitRate   float64 `json:"hit_rate"`   // %
+	AvgReturn float64 `json:"avg_return"` // %
+}
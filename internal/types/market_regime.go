@@ -0,0 +1,18 @@
+// internal/types/market_regime.go
+package types
+
+import "time"
+
+// MarketRegimeData — данные события "смена режима рынка"
+type MarketRegimeData struct {
+	Regime   string `json:"regime"`   // trend_up / trend_down / range / panic
+	Previous string `json:"previous"` // режим до смены (unknown — первая классификация)
+
+	BenchmarkChange float64 `json:"benchmark_change"` // средняя доходность бенчмарков (BTC/ETH) за сутки, %
+	VolatilityRatio float64 `json:"volatility_ratio"` // волатильность бенчмарков за сутки к базовой
+	AboveVWAPShare  float64 `json:"above_vwap_share"` // доля символов выше суточного VWAP, 0..1
+	AdvanceShare    float64 `json:"advance_share"`    // доля растущих за 24ч символов, 0..1
+	Symbols         int     `json:"symbols"`          // символов в расчете ширины рынка
+
+	Timestamp time.Time `json:"timestamp"`
+}
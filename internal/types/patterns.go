@@ -0,0 +1,21 @@
+// internal/types/patterns.go
+package types
+
+import "time"
+
+// PatternZoneData — данные события "свечной паттерн у зоны S/R"
+type PatternZoneData struct {
+	Symbol       string
+	Period       string  // период свечи, на которой завершился паттерн
+	Pattern      string  // имя паттерна: hammer, bullish_engulfing, ...
+	PatternTitle string  // название для уведомления: "Молот"
+	Direction    string  // growth — бычий паттерн у поддержки, fall — медвежий у сопротивления
+	Price        float64 // закрытие последней свечи паттерна
+	ZoneType     string  // support / resistance
+	ZonePeriod   string  // период, по которому построена зона
+	ZoneLow      float64
+	ZoneHigh     float64
+	ZoneStrength float64 // 0-100
+	ZoneTouches  int
+	Timestamp    time.Time
+}
@@ -0,0 +1,16 @@
+// internal/types/price_alerts.go
+package types
+
+import "time"
+
+// PriceAlertTriggeredData — данные события срабатывания ценового алерта
+type PriceAlertTriggeredData struct {
+	AlertID     int
+	UserID      int
+	Symbol      string
+	AlertType   string  // cross_up, cross_down, range, percent
+	Description string  // человекочитаемое условие: "пересечение вверх 180"
+	Price       float64 // цена в момент срабатывания
+	Recurring   bool    // алерт останется активным после срабатывания
+	Timestamp   time.Time
+}
@@ -0,0 +1,32 @@
+// internal/types/range_breakout.go
+package types
+
+import "time"
+
+// RangeBreakoutData — данные события "цена обновила максимум/минимум диапазона"
+type RangeBreakoutData struct {
+	Symbol    string
+	Kind      string  // high / low
+	Price     float64 // цена пробоя
+	Breaks    []RangeBreak
+	Timestamp time.Time
+}
+
+// RangeBreak — пробой на одном горизонте
+type RangeBreak struct {
+	Horizon       string    // 1d / 7d / 30d / 90d / all
+	PrevPrice     float64   // предыдущий экстремум
+	PrevTime      time.Time // когда он был установлен
+	DistancePct   float64   // выход за предыдущий экстремум, %
+	DaysSincePrev int
+}
+
+// Longest возвращает пробой самого длинного горизонта из allowed (nil — любой)
+func (d RangeBreakoutData) Longest(allowed func(horizon string) bool) (RangeBreak, bool) {
+	for i := len(d.Breaks) - 1; i >= 0; i-- {
+		if allowed == nil || allowed(d.Breaks[i].Horizon) {
+			return d.Breaks[i], true
+		}
+	}
+	return RangeBreak{}, false
+}
@@ -0,0 +1,17 @@
+// internal/types/rules.go
+package types
+
+import "time"
+
+// RuleTriggeredData — данные события срабатывания пользовательского правила
+type RuleTriggeredData struct {
+	RuleID     int
+	UserID     int
+	RuleName   string
+	Expression string
+	Symbol     string
+	Period     string             // период свечи, закрытие которой запустило проверку
+	Price      float64            // 0 — цена недоступна
+	Metrics    map[string]float64 // значения метрик выражения: "rsi(1h)" → 64.2
+	Timestamp  time.Time
+}
@@ -0,0 +1,38 @@
+// internal/types/strength.go
+package types
+
+import "time"
+
+// SectorDigestData — данные события "дайджест ротаций между секторами"
+type SectorDigestData struct {
+	Period       string // период рейтинга: 1h / 4h / 1d
+	BTCReturn    float64
+	MedianReturn float64
+	Sectors      []SectorStrength // текущий рейтинг секторов, сильнейший первым
+	Rotations    []SectorRotation // изменения мест с прошлого дайджеста
+	Leaders      []SymbolStrength // сильнейшие символы
+	Laggards     []SymbolStrength // слабейшие символы
+	Timestamp    time.Time
+}
+
+// SectorStrength — сектор в дайджесте
+type SectorStrength struct {
+	Title    string
+	Return   float64 // медианная доходность сектора, %
+	VsMedian float64 // относительно медианы рынка, п.п.
+	Leader   string
+}
+
+// SectorRotation — смена места сектора в рейтинге
+type SectorRotation struct {
+	Title    string
+	FromRank int
+	ToRank   int
+}
+
+// SymbolStrength — символ в дайджесте
+type SymbolStrength struct {
+	Symbol string
+	Return float64 // %
+	VsBTC  float64 // п.п.
+}
@@ -0,0 +1,25 @@
+// internal/types/vwap.go
+package types
+
+import "time"
+
+// Виды VWAP-сигналов
+const (
+	VWAPDeviationUp   = "deviation_up"   // цена выше VWAP + Nσ
+	VWAPDeviationDown = "deviation_down" // цена ниже VWAP − Nσ
+	VWAPReclaim       = "reclaim"        // возврат цены выше VWAP
+	VWAPLoss          = "loss"           // потеря VWAP — уход цены ниже
+)
+
+// VWAPSignalData — данные события VWAP-анализатора (VWAP дневной сессии)
+type VWAPSignalData struct {
+	Symbol       string
+	Kind         string // deviation_up / deviation_down / reclaim / loss
+	Price        float64
+	VWAP         float64
+	StdDev       float64
+	Sigmas       float64 // отклонение цены от VWAP в σ
+	DistancePct  float64 // расстояние от VWAP, %
+	SessionStart time.Time
+	Timestamp    time.Time
+}
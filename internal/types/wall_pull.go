@@ -0,0 +1,19 @@
+// internal/types/wall_pull.go
+package types
+
+import "time"
+
+// WallPullData — данные события трекера стен: крупную стену сняли, когда к ней подошла цена
+type WallPullData struct {
+	SignalID    string // запись журнала сигналов (EventSignalDetected)
+	Symbol      string
+	Side        string        // bid — снята поддержка, ask — снято сопротивление
+	Direction   string        // growth / fall — куда давит снятие
+	Price       float64       // цена стены
+	SizeUSD     float64       // объём стены, $
+	Mid         float64       // mid-цена в момент снятия
+	DistancePct float64       // расстояние от mid до стены, %
+	Lifetime    time.Duration // сколько стена простояла
+	Confidence  float64
+	Timestamp   time.Time
+}